@@ -0,0 +1,70 @@
+package main
+
+import (
+	"2026champs/internal/bootstrap"
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+)
+
+// runImport loads a room bundle from GET /v1/admin/rooms/{code}/bundle into the database
+func runImport(args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	file := fs.String("file", "", "bundle JSON file to import ('-' reads stdin)")
+	dbName := fs.String("db", "", "MongoDB database name (default $MONGO_DB or champsdb)")
+	code := fs.String("code", "", "import under this room code instead of the exported one")
+	hostID := fs.String("host", "", "give the room and survey to this host ID instead of the exported one")
+	fs.Parse(args)
+
+	if *file == "" {
+		fmt.Fprintln(os.Stderr, "import: -file is required")
+		fs.Usage()
+		os.Exit(2)
+	}
+	bundle, err := readBundle(*file)
+	if err != nil {
+		log.Fatalf("Failed to read bundle: %v", err)
+	}
+
+	ctx := context.Background()
+	app, err := bootstrap.New(ctx, bootstrap.Options{DBName: *dbName})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer app.Close()
+
+	result, err := app.Bundle.Import(ctx, bundle, service.BundleImportOptions{RoomCode: *code, HostID: *hostID})
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+	fmt.Printf("Imported room %s for host '%s' into %s: %d answers, %d events\n",
+		result.RoomCode, result.HostID, app.DBName(), result.Answers, result.Events)
+	if result.SurveyID != "" {
+		fmt.Printf("Survey imported as %s\n", result.SurveyID)
+	}
+}
+
+// readBundle decodes a bundle from a file, or stdin for "-"
+func readBundle(path string) (*model.RoomBundle, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var bundle model.RoomBundle
+	if err := json.NewDecoder(r).Decode(&bundle); err != nil {
+		return nil, err
+	}
+	return &bundle, nil
+}
@@ -0,0 +1,62 @@
+// Command champs is the single entry point for the API and its tooling:
+//
+//	champs serve     run the HTTP/WebSocket API and, with -grpc-port, the gRPC API (default)
+//	champs seed      insert fixture surveys, rooms and answers
+//	champs migrate   apply schema changes and create indexes, then exit
+//	champs simulate  drive a live room with virtual players
+//	champs import    load a room bundle exported by the admin API
+//	champs plan      show a host's plan usage or change their plan
+//
+// Every subcommand that touches the database or Redis builds its services through
+// internal/bootstrap, so they all see the same wiring as the server. The API's
+// OpenAPI documents are generated from the router and served at
+// /v1/openapi.json and /v2/openapi.json.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// subcommands maps each subcommand to its runner; runners parse their own flags
+var subcommands = map[string]func(args []string){
+	"serve":    runServe,
+	"seed":     runSeed,
+	"migrate":  runMigrate,
+	"simulate": runSimulate,
+	"import":   runImport,
+	"plan":     runPlan,
+}
+
+func main() {
+	name, args := "serve", os.Args[1:]
+	if len(args) > 0 && (args[0] == "help" || args[0] == "-h" || args[0] == "--help") {
+		usage()
+		return
+	}
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		name, args = args[0], args[1:]
+	}
+
+	run, ok := subcommands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		usage()
+		os.Exit(2)
+	}
+	run(args)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: champs <command> [flags]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  serve     run the API server (default)")
+	fmt.Fprintln(os.Stderr, "  seed      insert fixture surveys, rooms and answers")
+	fmt.Fprintln(os.Stderr, "  migrate   apply schema changes and create indexes")
+	fmt.Fprintln(os.Stderr, "  simulate  join a live room with virtual players")
+	fmt.Fprintln(os.Stderr, "  import    load a room bundle exported by the admin API")
+	fmt.Fprintln(os.Stderr, "  plan      show a host's plan usage or change their plan")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "run 'champs <command> -h' for the command's flags")
+}
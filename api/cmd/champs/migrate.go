@@ -0,0 +1,74 @@
+package main
+
+import (
+	"2026champs/internal/bootstrap"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+// runMigrate applies pending schema migrations and ensures the retention TTL
+// indexes, then exits without serving traffic
+func runMigrate(args []string) {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dbName := fs.String("db", "", "MongoDB database name (default $MONGO_DB or champsdb); ignored on PostgreSQL")
+	status := fs.Bool("status", false, "list migrations and whether they are applied, without applying any")
+	verify := fs.Bool("verify", false, "report missing indexes without changing anything; exits 1 if any are missing")
+	fs.Parse(args)
+
+	ctx := context.Background()
+	app, err := bootstrap.New(ctx, bootstrap.Options{DBName: *dbName})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer app.Close()
+
+	if *status {
+		statuses, err := app.MigrationStatus(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read migrations: %v", err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied != nil {
+				state = "applied " + s.Applied.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%4d  %-32s %s\n", s.Version, s.Name, state)
+		}
+		return
+	}
+
+	if *verify {
+		if missing := reportMissingIndexes(ctx, app); missing > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	n, err := app.Migrate(ctx)
+	if err != nil {
+		log.Fatalf("Migration failed after %d applied: %v", n, err)
+	}
+	app.EnsureIndexes(ctx)
+	log.Printf("Applied %d migrations on %s", n, app.DBName())
+	reportMissingIndexes(ctx, app)
+}
+
+// reportMissingIndexes prints each required index that does not exist and returns how many
+func reportMissingIndexes(ctx context.Context, app *bootstrap.App) int {
+	missing, err := app.MissingIndexes(ctx)
+	if err != nil {
+		log.Fatalf("Failed to verify indexes: %v", err)
+	}
+	if len(missing) == 0 {
+		fmt.Println("All required indexes exist")
+		return 0
+	}
+	fmt.Printf("%d required indexes are missing:\n", len(missing))
+	for _, idx := range missing {
+		fmt.Printf("  %s\n", idx)
+	}
+	return len(missing)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"2026champs/internal/bootstrap"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+// runPlan shows a host's plan usage, or puts the host on another plan with -plan
+func runPlan(args []string) {
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	hostID := fs.String("host", "", "host ID")
+	plan := fs.String("plan", "", "put the host on this plan")
+	dbName := fs.String("db", "", "MongoDB database name (default $MONGO_DB or champsdb)")
+	fs.Parse(args)
+
+	if *hostID == "" {
+		fmt.Fprintln(os.Stderr, "plan: -host is required")
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	app, err := bootstrap.New(ctx, bootstrap.Options{DBName: *dbName})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer app.Close()
+
+	if *plan != "" {
+		if err := app.Plan.SetPlan(ctx, *hostID, *plan); err != nil {
+			log.Fatalf("%v (plans: %s)", err, strings.Join(app.Plan.Plans(), ", "))
+		}
+	}
+
+	usage, err := app.Plan.Usage(ctx, *hostID)
+	if err != nil {
+		log.Fatalf("Failed to read usage: %v", err)
+	}
+	limit := func(n int64) string {
+		if n <= 0 {
+			return "unlimited"
+		}
+		return fmt.Sprint(n)
+	}
+	fmt.Printf("Host %s is on the %s plan\n", usage.HostID, usage.Plan)
+	fmt.Printf("  surveys            %d / %s\n", usage.Surveys, limit(int64(usage.Limits.MaxSurveys)))
+	fmt.Printf("  concurrent rooms   %d / %s\n", usage.ConcurrentRooms, limit(int64(usage.Limits.MaxConcurrentRooms)))
+	fmt.Printf("  players per room   %s\n", limit(int64(usage.Limits.MaxPlayersPerRoom)))
+	fmt.Printf("  AI calls (%s) %d / %s\n", usage.Month, usage.AICallsThisMonth, limit(usage.Limits.MonthlyAICalls))
+}
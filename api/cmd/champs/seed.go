@@ -0,0 +1,243 @@
+package main
+
+import (
+	"2026champs/internal/bootstrap"
+	"2026champs/internal/cache"
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"2026champs/internal/service"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// seedOptions are the command-line flags
+type seedOptions struct {
+	hostID   string
+	dbName   string
+	surveys  int
+	rooms    int
+	players  int
+	evaluate bool
+	end      bool
+	skipRate float64
+	dropRate float64
+	randSeed int64
+}
+
+// seeder holds the repositories and caches a run writes to
+type seeder struct {
+	opts        seedOptions
+	rng         *rand.Rand
+	surveyRepo  repository.SurveyRepo
+	roomRepo    repository.RoomRepo
+	answerRepo  repository.AnswerRepo
+	roomCache   cache.RoomCache
+	playerCache cache.PlayerCache
+	leaderboard cache.LeaderboardCache
+	analytics   *service.AnalyticsService
+	reportSvc   *service.ReportService
+}
+
+// runSeed inserts fixture surveys and, optionally, rooms full of simulated answers
+func runSeed(args []string) {
+	var opts seedOptions
+	fs := flag.NewFlagSet("seed", flag.ExitOnError)
+	fs.StringVar(&opts.hostID, "host", "host_8263b93c", "host ID that owns the generated surveys and rooms")
+	fs.StringVar(&opts.dbName, "db", "", "MongoDB database name (default $MONGO_DB or champsdb)")
+	fs.IntVar(&opts.surveys, "surveys", 1, "number of surveys to create")
+	fs.IntVar(&opts.rooms, "rooms", 0, "number of rooms to create (spread across the surveys)")
+	fs.IntVar(&opts.players, "players", 20, "simulated players per room")
+	fs.BoolVar(&opts.evaluate, "evaluate", false, "attach fake evaluations, scores and analytics to answers")
+	fs.BoolVar(&opts.end, "end", false, "end each room and build its snapshot")
+	fs.Float64Var(&opts.skipRate, "skip-rate", 0.1, "probability a player skips an essay question")
+	fs.Float64Var(&opts.dropRate, "drop-rate", 0.05, "probability a player abandons the room at each question")
+	fs.Int64Var(&opts.randSeed, "seed", time.Now().UnixNano(), "random seed for reproducible fixtures")
+	fs.Parse(args)
+
+	ctx := context.Background()
+	app, err := bootstrap.New(ctx, bootstrap.Options{DBName: opts.dbName})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer app.Close()
+
+	s := &seeder{
+		opts:        opts,
+		rng:         rand.New(rand.NewSource(opts.randSeed)),
+		surveyRepo:  app.SurveyRepo,
+		roomRepo:    app.RoomRepo,
+		answerRepo:  app.AnswerRepo,
+		roomCache:   app.RoomCache,
+		playerCache: app.PlayerCache,
+		leaderboard: app.Leaderboard,
+		analytics:   app.Analytics,
+		reportSvc:   app.Report,
+	}
+
+	if err := s.run(ctx); err != nil {
+		log.Fatalf("Seeding failed: %v", err)
+	}
+}
+
+// run creates the surveys, then the rooms round-robin across them
+func (s *seeder) run(ctx context.Context) error {
+	if s.opts.surveys < 1 {
+		return fmt.Errorf("need at least one survey")
+	}
+
+	surveys := make([]*model.Survey, 0, s.opts.surveys)
+	tpls := make([]surveyTemplate, 0, s.opts.surveys)
+	for i := 0; i < s.opts.surveys; i++ {
+		survey, tpl := newSurvey(i, s.opts.hostID)
+		if _, err := s.surveyRepo.Create(ctx, survey); err != nil {
+			return fmt.Errorf("failed to insert survey: %w", err)
+		}
+		surveys = append(surveys, survey)
+		tpls = append(tpls, tpl)
+		fmt.Printf("Created survey '%s' (%s) for host '%s'\n", survey.Title, survey.ID, s.opts.hostID)
+	}
+
+	for i := 0; i < s.opts.rooms; i++ {
+		idx := i % len(surveys)
+		code, err := s.seedRoom(ctx, surveys[idx], tpls[idx])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created room %s with %d players (survey '%s')\n", code, s.opts.players, surveys[idx].Title)
+	}
+
+	fmt.Printf("Done (seed %d)\n", s.opts.randSeed)
+	return nil
+}
+
+// seedRoom creates one room and simulates every player working through the survey
+func (s *seeder) seedRoom(ctx context.Context, survey *model.Survey, tpl surveyTemplate) (string, error) {
+	code := roomCode(s.rng)
+	started := time.Now()
+	room := &model.Room{
+		Code:         code,
+		SurveyID:     survey.ID,
+		HostID:       s.opts.hostID,
+		Status:       model.RoomStatusActive,
+		ScopeSummary: survey.Intent,
+		StartedAt:    &started,
+	}
+	if err := s.roomRepo.Create(ctx, room); err != nil {
+		return "", fmt.Errorf("failed to create room: %w", err)
+	}
+
+	settingsJSON, _ := json.Marshal(room.Settings)
+	meta := &model.RoomMeta{
+		SurveyID:     survey.ID,
+		HostID:       s.opts.hostID,
+		Status:       model.RoomStatusActive,
+		CreatedAt:    room.CreatedAt,
+		SettingsJSON: string(settingsJSON),
+		ScopeSummary: room.ScopeSummary,
+	}
+	meta.SetSurvey(survey)
+	if err := s.roomCache.SetMeta(ctx, code, meta); err != nil {
+		return "", fmt.Errorf("failed to cache room: %w", err)
+	}
+
+	for p := 0; p < s.opts.players; p++ {
+		if err := s.seedPlayer(ctx, code, survey, tpl); err != nil {
+			return "", err
+		}
+	}
+
+	if s.opts.end {
+		if err := s.endRoom(ctx, room, survey); err != nil {
+			return "", err
+		}
+	}
+	return code, nil
+}
+
+// seedPlayer joins one simulated player and answers until they finish or drop off
+func (s *seeder) seedPlayer(ctx context.Context, code string, survey *model.Survey, tpl surveyTemplate) error {
+	playerID := uuid.New().String()
+	now := time.Now()
+	player := &model.Player{
+		ID:           playerID,
+		RoomCode:     code,
+		Nickname:     nickname(s.rng),
+		LastActiveAt: now,
+		JoinedAt:     now,
+	}
+
+	for _, q := range survey.Questions {
+		if s.rng.Float64() < s.opts.dropRate {
+			// Abandoned on this question
+			player.CurrentKey = q.Key
+			if err := s.playerCache.SetCurrent(ctx, code, playerID, q.Key); err != nil {
+				return err
+			}
+			return s.savePlayer(ctx, code, player)
+		}
+
+		answer, themes := fakeAnswer(s.rng, tpl, q)
+		answer.RoomCode = code
+		answer.PlayerID = playerID
+		answer.ClientAttemptID = uuid.New().String()
+		answer.Status = model.AnswerStatusSubmitted
+
+		if q.Type == model.QuestionTypeEssay && s.rng.Float64() < s.opts.skipRate {
+			answer.TextAnswer = ""
+			answer.Resolution = model.ResolutionSkipped
+		} else if s.opts.evaluate {
+			fakeEvaluate(s.rng, q, answer, themes)
+			player.Score += answer.PointsEarned
+		}
+
+		if _, err := s.answerRepo.Create(ctx, answer); err != nil {
+			return fmt.Errorf("failed to insert answer: %w", err)
+		}
+
+		if s.opts.evaluate {
+			s.analytics.UpdatePlayerProfile(ctx, code, playerID, answer.Signals, answer.Resolution)
+			s.analytics.UpdateQuestionProfile(ctx, code, q.Key, answer.Signals, answer.Resolution, answer.DegreeValue, answer.OptionIndex, answer.MatrixValues, answer.Ranking)
+			s.analytics.UpdateRoomMemory(ctx, code, answer.Signals)
+		}
+	}
+
+	// Finished the whole queue
+	if err := s.playerCache.MarkDone(ctx, code, playerID); err != nil {
+		return err
+	}
+	return s.savePlayer(ctx, code, player)
+}
+
+// savePlayer stores the player and their final leaderboard score
+func (s *seeder) savePlayer(ctx context.Context, code string, player *model.Player) error {
+	if err := s.playerCache.SetPlayer(ctx, code, player.ID, player); err != nil {
+		return err
+	}
+	return s.leaderboard.UpdateScore(ctx, code, player.ID, player.Score, player.LastActiveAt.Sub(player.JoinedAt))
+}
+
+// endRoom marks the room ended and builds its snapshot like RoomService.EndRoom
+func (s *seeder) endRoom(ctx context.Context, room *model.Room, survey *model.Survey) error {
+	ended := time.Now()
+	room.Status = model.RoomStatusEnded
+	room.EndedAt = &ended
+	if err := s.roomRepo.Update(ctx, room); err != nil {
+		return fmt.Errorf("failed to end room: %w", err)
+	}
+
+	keys := make([]string, 0, len(survey.Questions))
+	for _, q := range survey.Questions {
+		keys = append(keys, q.Key)
+	}
+	if _, err := s.reportSvc.CreateSnapshot(ctx, room.Code, keys); err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	return s.roomCache.SetStatus(ctx, room.Code, model.RoomStatusEnded)
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"2026champs/internal/model"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// essayAnswer is a canned essay response with the themes a real evaluation would extract
+type essayAnswer struct {
+	text   string
+	themes []string
+}
+
+// surveyTemplate is a survey plus realistic essay answers per question key
+type surveyTemplate struct {
+	title     string
+	intent    string
+	questions []model.BaseQuestion
+	essays    map[string][]essayAnswer
+}
+
+var templates = []surveyTemplate{
+	{
+		title:  "Smartphone Launch Feedback",
+		intent: "Understand user perception, satisfaction, and improvement areas for the new device.",
+		questions: []model.BaseQuestion{
+			{
+				Key:       "Q1",
+				Type:      model.QuestionTypeDegree,
+				Prompt:    "On a scale from 1 to 5, how satisfied are you with this smartphone overall?",
+				PointsMax: 50,
+				ScaleMin:  1,
+				ScaleMax:  5,
+			},
+			{
+				Key:       "Q2",
+				Type:      model.QuestionTypeMCQ,
+				Prompt:    "Which model did you purchase?",
+				PointsMax: 50,
+				Options: []string{
+					"Standard Model",
+					"Pro / Plus Model",
+					"Ultra / Max Model",
+				},
+			},
+			{
+				Key:       "Q3",
+				Type:      model.QuestionTypeEssay,
+				Prompt:    "Which feature do you find the most impressive? (Display, Battery, Camera, Speed, Design)",
+				Rubric:    "Look for specific mention of one of the listed features and why they like it.",
+				PointsMax: 100,
+				Threshold: 0.6,
+			},
+			{
+				Key:       "Q4",
+				Type:      model.QuestionTypeDegree,
+				Prompt:    "How would you rate the phone’s performance during everyday tasks?",
+				PointsMax: 50,
+				ScaleMin:  1,
+				ScaleMax:  5,
+			},
+			{
+				Key:       "Q5",
+				Type:      model.QuestionTypeEssay,
+				Prompt:    "What was the main reason you chose this phone? (Price, Features, Brand, Design, Reviews)",
+				Rubric:    "Identify the primary motivation factor.",
+				PointsMax: 100,
+				Threshold: 0.6,
+			},
+			{
+				Key:       "Q6",
+				Type:      model.QuestionTypeEssay,
+				Prompt:    "What is one thing you would improve or change about this smartphone?",
+				Rubric:    "Constructive criticism or specific feature requests.",
+				PointsMax: 100,
+				Threshold: 0.6,
+			},
+		},
+		essays: map[string][]essayAnswer{
+			"Q3": {
+				{"The OLED display is stunning, colors pop and it stays readable in direct sunlight.", []string{"display", "outdoor visibility"}},
+				{"Battery easily lasts two days with my usage, I stopped carrying a charger.", []string{"battery life"}},
+				{"Night mode on the camera is unreal, my concert photos finally look sharp.", []string{"camera", "low light"}},
+				{"It is fast.", []string{"performance"}},
+				{"The titanium frame feels premium and lighter than my old phone.", []string{"design", "build quality"}},
+				{"camera", []string{"camera"}},
+				{"120Hz scrolling makes everything feel smooth, especially in games.", []string{"display", "performance"}},
+			},
+			"Q5": {
+				{"Price. It was 200 dollars cheaper than the competitor with the same specs.", []string{"price", "value"}},
+				{"I've used this brand for years and my whole ecosystem is here.", []string{"brand loyalty", "ecosystem"}},
+				{"Reviews said the camera was the best this year.", []string{"reviews", "camera"}},
+				{"looks good", []string{"design"}},
+				{"My carrier had a trade-in deal that made the upgrade almost free.", []string{"price", "carrier deal"}},
+			},
+			"Q6": {
+				{"The charger is not included in the box which feels cheap at this price.", []string{"accessories", "price"}},
+				{"It gets warm when gaming for more than 20 minutes.", []string{"thermals", "performance"}},
+				{"Please bring back the headphone jack.", []string{"headphone jack"}},
+				{"nothing", []string{}},
+				{"The camera bump makes it wobble on the table.", []string{"design", "camera"}},
+				{"Face unlock fails with sunglasses, add an under-display fingerprint sensor.", []string{"biometrics"}},
+			},
+		},
+	},
+	{
+		title:  "Coffee Shop Experience",
+		intent: "Learn what drives repeat visits and where the in-store experience falls short.",
+		questions: []model.BaseQuestion{
+			{
+				Key:       "Q1",
+				Type:      model.QuestionTypeMCQ,
+				Prompt:    "How often do you visit us?",
+				PointsMax: 50,
+				Options:   []string{"Daily", "Weekly", "Monthly", "First visit"},
+			},
+			{
+				Key:       "Q2",
+				Type:      model.QuestionTypeEssay,
+				Prompt:    "What keeps you coming back (or what would)?",
+				Rubric:    "Look for a concrete driver such as a product, staff, location or price.",
+				PointsMax: 100,
+				Threshold: 0.6,
+			},
+			{
+				Key:       "Q3",
+				Type:      model.QuestionTypeDegree,
+				Prompt:    "How would you rate the wait time for your order?",
+				PointsMax: 50,
+				ScaleMin:  1,
+				ScaleMax:  5,
+			},
+			{
+				Key:       "Q4",
+				Type:      model.QuestionTypeEssay,
+				Prompt:    "Describe one moment during your last visit that annoyed you.",
+				Rubric:    "A specific situation with enough detail to act on.",
+				PointsMax: 100,
+				Threshold: 0.6,
+			},
+		},
+		essays: map[string][]essayAnswer{
+			"Q2": {
+				{"The oat flat white is the best in the neighborhood and the baristas remember my name.", []string{"coffee quality", "staff"}},
+				{"It's on my way to the train station.", []string{"location"}},
+				{"coffee", []string{"coffee quality"}},
+				{"The loyalty card gives me a free drink every ten, which adds up.", []string{"loyalty program", "price"}},
+				{"Quiet corner seats with outlets so I can work for a couple of hours.", []string{"workspace", "ambience"}},
+			},
+			"Q4": {
+				{"The line was out the door and only one register was open at 8am.", []string{"wait time", "staffing"}},
+				{"My name was spelled wrong and someone else took my drink.", []string{"order accuracy"}},
+				{"music too loud", []string{"ambience"}},
+				{"The mobile order said ready but it took another ten minutes.", []string{"wait time", "mobile ordering"}},
+				{"No free tables and people were saving seats with laptops.", []string{"seating", "workspace"}},
+			},
+		},
+	},
+}
+
+var (
+	firstNames = []string{"Alex", "Sam", "Jordan", "Taylor", "Riley", "Casey", "Morgan", "Jamie", "Avery", "Quinn", "Rowan", "Skyler"}
+	animals    = []string{"Otter", "Falcon", "Panda", "Lynx", "Koala", "Heron", "Badger", "Gecko", "Bison", "Marten"}
+)
+
+// newSurvey builds survey i for hostID from the template rotation
+func newSurvey(i int, hostID string) (*model.Survey, surveyTemplate) {
+	tpl := templates[i%len(templates)]
+	title := tpl.title
+	if i >= len(templates) {
+		title = fmt.Sprintf("%s #%d", tpl.title, i/len(templates)+1)
+	}
+
+	now := time.Now()
+	return &model.Survey{
+		ID:     primitive.NewObjectID().Hex(),
+		HostID: hostID,
+		Title:  title,
+		Intent: tpl.intent,
+		Settings: model.SurveySettings{
+			SatisfactoryThreshold: 0.7,
+			MaxFollowUps:          2,
+			DefaultPointsMax:      100,
+			AllowSkipAfter:        1,
+		},
+		Questions: tpl.questions,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, tpl
+}
+
+// roomCode returns a random 6-char join code in the same alphabet the server uses
+func roomCode(rng *rand.Rand) string {
+	const charset = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	b := make([]byte, 6)
+	for i := range b {
+		b[i] = charset[rng.Intn(len(charset))]
+	}
+	return string(b)
+}
+
+func nickname(rng *rand.Rand) string {
+	return fmt.Sprintf("%s%s%d", firstNames[rng.Intn(len(firstNames))], animals[rng.Intn(len(animals))], rng.Intn(100))
+}
+
+// fakeAnswer builds a plausible answer to q; for essays it also returns the themes to report
+func fakeAnswer(rng *rand.Rand, tpl surveyTemplate, q model.BaseQuestion) (*model.Answer, []string) {
+	answer := &model.Answer{QuestionKey: q.Key, Tries: 1}
+	switch q.Type {
+	case model.QuestionTypeDegree:
+		// Skew positive like real satisfaction data
+		answer.DegreeValue = q.ScaleMax - int(rng.ExpFloat64())%(q.ScaleMax-q.ScaleMin+1)
+	case model.QuestionTypeMCQ:
+		idx := rng.Intn(len(q.Options))
+		answer.OptionIndex = &idx
+	default:
+		pool := tpl.essays[q.Key]
+		if len(pool) == 0 {
+			answer.TextAnswer = "No strong opinion."
+			return answer, nil
+		}
+		pick := pool[rng.Intn(len(pool))]
+		answer.TextAnswer = pick.text
+		return answer, pick.themes
+	}
+	return answer, nil
+}
+
+// fakeEvaluate fills resolution, points and signals the way the L1 evaluator would
+func fakeEvaluate(rng *rand.Rand, q model.BaseQuestion, answer *model.Answer, themes []string) {
+	now := time.Now()
+	answer.Status = model.AnswerStatusEvaluated
+	answer.EvaluatedAt = &now
+
+	if q.Type != model.QuestionTypeEssay {
+		answer.Resolution = model.ResolutionSat
+		answer.PointsEarned = q.PointsMax
+		return
+	}
+
+	words := len(strings.Fields(answer.TextAnswer))
+	quality := float64(words)/15.0 + (rng.Float64()-0.5)*0.2
+	if quality > 1 {
+		quality = 1
+	}
+	if quality < 0 {
+		quality = 0
+	}
+
+	answer.Resolution = model.ResolutionUnsat
+	if quality >= q.Threshold {
+		answer.Resolution = model.ResolutionSat
+	}
+	answer.PointsEarned = int(float64(q.PointsMax) * quality)
+
+	missing := []string{}
+	if quality < 0.5 {
+		missing = append(missing, "specifics")
+	}
+	answer.Signals = &model.Signals{
+		Themes:             themes,
+		Missing:            missing,
+		Specificity:        quality,
+		Clarity:            quality,
+		Sentiment:          rng.Float64()*2 - 1,
+		ConfidenceLanguage: quality,
+		Summary:            answer.TextAnswer,
+	}
+	answer.EvalSummary = "Seeded evaluation"
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"2026champs/internal/bootstrap"
+	"2026champs/internal/events"
+	"2026champs/internal/migrations"
+	"2026champs/internal/transport/rest"
+	"2026champs/internal/transport/rpc"
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// runServe starts the HTTP/WebSocket API, the optional gRPC API and their background jobs
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	port := fs.String("port", os.Getenv("PORT"), "listen port (default $PORT or 8080)")
+	grpcPort := fs.String("grpc-port", os.Getenv("GRPC_PORT"), "gRPC listen port (default $GRPC_PORT; off when empty)")
+	fs.Parse(args)
+	if *port == "" {
+		*port = "8080"
+	}
+
+	log.Println("started")
+	ctx := context.Background()
+
+	app, err := bootstrap.New(ctx, bootstrap.Options{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer app.Close()
+
+	// Log AI model settings
+	aiConfig := app.AIConfig
+	log.Printf("AI Config:")
+	log.Printf("  L1 Eval:   %s", aiConfig.Models.L1Eval)
+	log.Printf("  FollowUp:  %s", aiConfig.Models.FollowUp)
+	log.Printf("  L3 Refresh: %s", aiConfig.Models.L3Refresh)
+	log.Printf("  Pool Gen:  %s", aiConfig.Models.PoolGen)
+	log.Printf("  Report:    %s", aiConfig.Models.Report)
+	log.Printf("  Quality:   %s (per-question \"quality\" tier)", aiConfig.Models.Quality)
+	log.Printf("  Budget:    %d calls/room, %d calls/day global (0 = unlimited)", aiConfig.Budget.MaxRoomCalls, aiConfig.Budget.MaxGlobalCalls)
+	if aiConfig.IsEnabled() {
+		log.Println("  API Key:   configured ✓")
+	} else {
+		log.Println("  API Key:   NOT SET (using mock evaluator)")
+	}
+
+	// Apply pending migrations unless a release step runs `champs migrate` instead
+	if os.Getenv("MIGRATE_ON_START") != "false" {
+		if n, err := app.Migrate(ctx); errors.Is(err, migrations.ErrLocked) {
+			log.Println("Migrations are running on another instance, continuing")
+		} else if err != nil {
+			log.Fatal(err)
+		} else if n > 0 {
+			log.Printf("Applied %d migrations", n)
+		}
+	}
+	app.EnsureIndexes(ctx)
+
+	// Queries still work without their indexes, so only warn
+	if missing, err := app.MissingIndexes(ctx); err != nil {
+		log.Printf("Warning: failed to verify indexes: %v", err)
+	} else {
+		for _, idx := range missing {
+			log.Printf("Warning: missing index %s (run `champs migrate`)", idx)
+		}
+	}
+
+	jobCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+	app.StartJobs(jobCtx)
+	log.Println("WebSocket hub started")
+
+	router := rest.NewRouter(app.Container())
+
+	// Start server
+	srv := &http.Server{
+		Addr:    ":" + *port,
+		Handler: router,
+	}
+
+	go func() {
+		log.Printf("Server starting on :%s", *port)
+		log.Printf("Host auth: username=%s", os.Getenv("HOST_USERNAME"))
+		log.Println("Endpoints:")
+		log.Println("  POST /v1/auth/login")
+		log.Println("  POST/GET /v1/surveys")
+		log.Println("  POST/GET /v1/rooms")
+		log.Println("  POST /v1/rooms/{code}/join")
+		log.Println("  GET  /v1/rooms/{code}/embed[/stream]?token=...")
+		log.Println("  GET  /v1/rooms/{code}/quotas")
+		log.Println("  GET  /v1/rooms/{code}/attachments[/{id}]")
+		log.Println("  POST /v1/rooms/{code}/questions/{key}/attachments")
+		log.Println("  POST /v1/rooms/{code}/questions/{key}/voice")
+		log.Println("  GET  /v1/rooms/{code}/voice/{clipId}")
+		log.Println("  GET  /v1/reports/{code}/snapshot")
+		log.Println("  GET  /v1/reports/{code}/funnel")
+		log.Println("  GET  /v1/reports/{code}/usage")
+		log.Println("  GET/POST /v1/reports/{code}/ai")
+		log.Println("  POST /v1/reports/{code}/share")
+		log.Println("  GET/POST /v1/reports/{code}/email")
+		log.Println("  GET  /v1/shared/{token}")
+		log.Println("  WS  /v1/ws/rooms/{code}/host")
+		log.Println("  WS  /v1/ws/rooms/{code}/player")
+		log.Println("  SSE /v1/sse/rooms/{code}/host")
+		log.Println("  SSE /v1/sse/rooms/{code}/player")
+
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal("ListenAndServe:", err)
+		}
+	}()
+
+	// gRPC API for service-to-service integrations, on its own port
+	var grpcSrv *grpc.Server
+	if *grpcPort != "" {
+		lis, err := net.Listen("tcp", ":"+*grpcPort)
+		if err != nil {
+			log.Fatal("gRPC listen:", err)
+		}
+		grpcSrv = rpc.NewServer(app.RPCDeps())
+		go func() {
+			log.Printf("gRPC server starting on :%s", *grpcPort)
+			if err := grpcSrv.Serve(lis); err != nil {
+				log.Fatal("gRPC Serve:", err)
+			}
+		}()
+	}
+
+	// Wait for interrupt
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	log.Println("Draining server...")
+
+	drainTimeout := 20 * time.Second
+	if v, err := strconv.Atoi(os.Getenv("DRAIN_TIMEOUT_SECONDS")); err == nil && v > 0 {
+		drainTimeout = time.Duration(v) * time.Second
+	}
+
+	// 1. Refuse new joins and fail health checks
+	app.Player.StartDrain()
+
+	// 2. Tell connected clients to reconnect to another instance
+	sent := app.Hub.BroadcastToEveryone(events.ReconnectHint, events.ReconnectHintPayload{
+		Reason:       "deploy",
+		RetryAfterMs: 2000,
+	})
+	log.Printf("Sent reconnect_hint to %d connections", sent)
+
+	// 3. Stop HTTP so no new evaluation jobs start
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer shutdownCancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP shutdown incomplete: %v", err)
+	}
+	if grpcSrv != nil {
+		stopGRPC(shutdownCtx, grpcSrv)
+	}
+
+	// 4. Let in-flight evaluations finish (bounded)
+	if err := app.Answer.WaitInFlight(shutdownCtx); err != nil {
+		log.Printf("Abandoning evaluations: %v", err)
+	}
+	stopJobs()
+
+	// 5. Persist pending analytics before exit
+	archiveCtx, archiveCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer archiveCancel()
+	if n, err := app.Archive.ArchiveAll(archiveCtx); err != nil {
+		log.Printf("Analytics archive failed: %v", err)
+	} else {
+		log.Printf("Archived analytics for %d rooms", n)
+	}
+
+	log.Println("Server exited")
+}
+
+// stopGRPC lets running calls finish until ctx is done, then cuts them off
+func stopGRPC(ctx context.Context, srv *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Println("gRPC shutdown incomplete, closing open calls")
+		srv.Stop()
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// simConfig holds the command-line flags
+type simConfig struct {
+	api         string
+	room        string
+	players     int
+	ramp        time.Duration
+	think       time.Duration
+	skipRate    float64
+	maxTries    int
+	evalTimeout time.Duration
+	duration    time.Duration
+	corpusPath  string
+	nickPrefix  string
+	seed        int64
+	verbose     bool
+}
+
+// runSimulate joins a live room with virtual players over the real REST and
+// WebSocket APIs and reports end-to-end latencies of the evaluation pipeline.
+//
+//	go run ./cmd/champs simulate -room ABC123 -players 50 -think 2s
+func runSimulate(args []string) {
+	cfg := &simConfig{}
+	fs := flag.NewFlagSet("simulate", flag.ExitOnError)
+	fs.StringVar(&cfg.api, "api", "http://localhost:8080", "base URL of the API server")
+	fs.StringVar(&cfg.room, "room", "", "room code to join (required)")
+	fs.IntVar(&cfg.players, "players", 10, "number of virtual players")
+	fs.DurationVar(&cfg.ramp, "ramp", 5*time.Second, "spread player joins over this duration")
+	fs.DurationVar(&cfg.think, "think", 2*time.Second, "mean think time before each answer")
+	fs.Float64Var(&cfg.skipRate, "skip-rate", 0.05, "probability of skipping an essay question")
+	fs.IntVar(&cfg.maxTries, "max-tries", 2, "essay attempts per question before skipping")
+	fs.DurationVar(&cfg.evalTimeout, "eval-timeout", 30*time.Second, "how long to wait for evaluation_result")
+	fs.DurationVar(&cfg.duration, "duration", 0, "stop after this long (0 = until every bot finishes)")
+	fs.StringVar(&cfg.corpusPath, "corpus", "", "file with one essay answer per line (default: built-in corpus)")
+	fs.StringVar(&cfg.nickPrefix, "nick", "bot", "nickname prefix for virtual players")
+	fs.Int64Var(&cfg.seed, "seed", time.Now().UnixNano(), "random seed")
+	fs.BoolVar(&cfg.verbose, "v", false, "log per-bot errors")
+	fs.Parse(args)
+
+	if cfg.room == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	corpus, err := loadCorpus(cfg.corpusPath)
+	if err != nil {
+		log.Fatalf("Failed to load corpus: %v", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	if cfg.duration > 0 {
+		ctx, cancel = context.WithTimeout(ctx, cfg.duration)
+		defer cancel()
+	}
+
+	fmt.Printf("Simulating %d players in room %s against %s (seed %d)\n", cfg.players, cfg.room, cfg.api, cfg.seed)
+
+	stats := newLatencies()
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.players; i++ {
+		if cfg.players > 1 && cfg.ramp > 0 && i > 0 {
+			if !sleepCtx(ctx, cfg.ramp/time.Duration(cfg.players-1)) {
+				break
+			}
+		}
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			newBot(id, cfg, stats, corpus).run(ctx)
+		}(i + 1)
+	}
+	wg.Wait()
+
+	stats.print(time.Since(start))
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"2026champs/internal/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// wsEvent is a message pushed by the server hub
+type wsEvent struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// bot is one virtual player driving the real REST and WebSocket APIs
+type bot struct {
+	id     int
+	cfg    *simConfig
+	client *http.Client
+	rng    *rand.Rand
+	stats  *latencies
+	corpus []string
+
+	playerID string
+	token    string
+	inLobby  bool
+	events   chan wsEvent
+	tries    map[string]int
+}
+
+func newBot(id int, cfg *simConfig, stats *latencies, corpus []string) *bot {
+	return &bot{
+		id:     id,
+		cfg:    cfg,
+		client: &http.Client{Timeout: 30 * time.Second},
+		rng:    rand.New(rand.NewSource(cfg.seed + int64(id))),
+		stats:  stats,
+		corpus: corpus,
+		events: make(chan wsEvent, 64),
+		tries:  make(map[string]int),
+	}
+}
+
+// run joins the room and answers until the queue is empty or ctx ends
+func (b *bot) run(ctx context.Context) {
+	if err := b.join(ctx); err != nil {
+		b.fail("join", err)
+		return
+	}
+
+	conn, err := b.connect(ctx)
+	if err != nil {
+		b.fail("ws_connect", err)
+		return
+	}
+	defer conn.Close()
+	go b.readEvents(conn)
+
+	if b.inLobby && !b.waitForStart(ctx) {
+		return
+	}
+
+	for ctx.Err() == nil {
+		question, err := b.current(ctx)
+		if err != nil {
+			b.fail("current", err)
+			return
+		}
+		if question == nil {
+			b.stats.inc("players_completed")
+			return
+		}
+
+		if !sleepCtx(ctx, b.thinkTime()) {
+			return
+		}
+
+		if question.Type == model.QuestionTypeEssay &&
+			(b.rng.Float64() < b.cfg.skipRate || b.tries[question.Key] >= b.cfg.maxTries) {
+			if err := b.skip(ctx, question.Key); err != nil {
+				b.fail("skip", err)
+				return
+			}
+			continue
+		}
+
+		if err := b.answer(ctx, question); err != nil {
+			b.fail("submit", err)
+			return
+		}
+	}
+}
+
+func (b *bot) join(ctx context.Context) error {
+	start := time.Now()
+	body := map[string]string{"nickname": fmt.Sprintf("%s%d", b.cfg.nickPrefix, b.id)}
+	var resp model.PlayerJoinResponse
+	if err := b.do(ctx, http.MethodPost, "/v1/rooms/"+b.cfg.room+"/join", body, &resp); err != nil {
+		return err
+	}
+	b.stats.observe("join", time.Since(start))
+	b.playerID = resp.PlayerID
+	b.token = resp.Token
+	b.inLobby = resp.RoomMeta != nil && resp.RoomMeta.Status == model.RoomStatusLobby
+	return nil
+}
+
+func (b *bot) connect(ctx context.Context) (*websocket.Conn, error) {
+	u, err := url.Parse(b.cfg.api)
+	if err != nil {
+		return nil, err
+	}
+	u.Scheme = strings.Replace(u.Scheme, "http", "ws", 1)
+	u.Path = "/v1/ws/rooms/" + b.cfg.room + "/player"
+	u.RawQuery = url.Values{"token": {b.token}}.Encode()
+
+	start := time.Now()
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	b.stats.observe("ws_connect", time.Since(start))
+	return conn, nil
+}
+
+// readEvents forwards hub messages until the connection closes
+func (b *bot) readEvents(conn *websocket.Conn) {
+	defer close(b.events)
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var ev wsEvent
+		if err := json.Unmarshal(data, &ev); err != nil {
+			continue
+		}
+		select {
+		case b.events <- ev:
+		default:
+			// Drop rather than block the reader; only evaluation events matter
+		}
+	}
+}
+
+// current returns the player's current question, or nil once the queue is empty
+func (b *bot) current(ctx context.Context) (*model.Question, error) {
+	start := time.Now()
+	var resp struct {
+		Question *model.Question `json:"question"`
+	}
+	if err := b.do(ctx, http.MethodGet, "/v1/rooms/"+b.cfg.room+"/question/current", nil, &resp); err != nil {
+		return nil, err
+	}
+	b.stats.observe("current", time.Since(start))
+	return resp.Question, nil
+}
+
+// waitForStart blocks until the host starts the room
+func (b *bot) waitForStart(ctx context.Context) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case ev, ok := <-b.events:
+			if !ok {
+				b.fail("ws_closed", fmt.Errorf("websocket closed in lobby"))
+				return false
+			}
+			if ev.Type == "room_started" {
+				return true
+			}
+		}
+	}
+}
+
+func (b *bot) skip(ctx context.Context, questionKey string) error {
+	start := time.Now()
+	if err := b.do(ctx, http.MethodPost, "/v1/rooms/"+b.cfg.room+"/questions/"+questionKey+"/skip", nil, nil); err != nil {
+		return err
+	}
+	b.stats.observe("skip", time.Since(start))
+	return nil
+}
+
+// answer submits a response and waits for the async evaluation_result
+func (b *bot) answer(ctx context.Context, q *model.Question) error {
+	req := model.SubmitAnswerRequest{
+		QuestionKey:     q.Key,
+		ClientAttemptID: uuid.New().String(),
+	}
+	switch q.Type {
+	case model.QuestionTypeDegree:
+		req.DegreeValue = q.ScaleMin + b.rng.Intn(q.ScaleMax-q.ScaleMin+1)
+	case model.QuestionTypeMCQ:
+		idx := 0
+		if len(q.Options) > 0 {
+			idx = b.rng.Intn(len(q.Options))
+		}
+		req.OptionIndex = &idx
+	default:
+		req.TextAnswer = b.corpus[b.rng.Intn(len(b.corpus))]
+	}
+	b.tries[q.Key]++
+
+	start := time.Now()
+	if err := b.do(ctx, http.MethodPost, "/v1/rooms/"+b.cfg.room+"/answers", req, nil); err != nil {
+		return err
+	}
+	b.stats.observe("submit_ack", time.Since(start))
+
+	timeout := time.NewTimer(b.cfg.evalTimeout)
+	defer timeout.Stop()
+	sawPartial := false
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timeout.C:
+			b.stats.inc("eval_timeouts")
+			return nil
+		case ev, ok := <-b.events:
+			if !ok {
+				return fmt.Errorf("websocket closed")
+			}
+			switch ev.Type {
+			case "followup_partial":
+				if !sawPartial {
+					sawPartial = true
+					b.stats.observe("followup_ttfb", time.Since(start))
+				}
+			case "error":
+				b.stats.inc("eval_errors")
+				return nil
+			case "evaluation_result":
+				b.stats.observe("evaluation", time.Since(start))
+				var res model.SubmitAnswerResponse
+				if json.Unmarshal(ev.Payload, &res) == nil {
+					b.stats.inc("resolution_" + strings.ToLower(string(res.Resolution)))
+					if res.FollowUp != nil {
+						b.stats.inc("followups")
+					}
+				}
+				return nil
+			}
+		}
+	}
+}
+
+func (b *bot) thinkTime() time.Duration {
+	if b.cfg.think <= 0 {
+		return 0
+	}
+	return time.Duration(b.rng.ExpFloat64() * float64(b.cfg.think))
+}
+
+func (b *bot) fail(stage string, err error) {
+	b.stats.inc("errors_" + stage)
+	if b.cfg.verbose {
+		fmt.Printf("[bot %d] %s: %v\n", b.id, stage, err)
+	}
+}
+
+// do sends a JSON request with the player token and decodes a JSON response into out
+func (b *bot) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(b.cfg.api, "/")+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if b.token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("%s %s: %d %s", method, path, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if out != nil {
+		return json.Unmarshal(data, out)
+	}
+	return nil
+}
+
+// sleepCtx sleeps for d and reports false if ctx ended first
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// defaultCorpus mixes strong, mid and minimal essay answers so evaluations land on both sides of the threshold
+var defaultCorpus = []string{
+	"The OLED display is stunning, colors pop and it stays readable in direct sunlight.",
+	"Battery easily lasts two days with my usage, I stopped carrying a charger.",
+	"Night mode on the camera is unreal, my concert photos finally look sharp.",
+	"It is fast.",
+	"Price. It was 200 dollars cheaper than the competitor with the same specs.",
+	"I've used this brand for years and my whole ecosystem is here.",
+	"looks good",
+	"It gets warm when gaming for more than 20 minutes, especially while charging.",
+	"Please bring back the headphone jack, adapters keep getting lost.",
+	"nothing",
+	"The camera bump makes it wobble on the table which is annoying when typing.",
+	"Mostly the screen size, I read a lot of comics and the bigger panel helps.",
+}
+
+// loadCorpus reads one answer per non-empty line, falling back to the built-in corpus
+func loadCorpus(path string) ([]string, error) {
+	if path == "" {
+		return defaultCorpus, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	lines := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return defaultCorpus, nil
+	}
+	return lines, nil
+}
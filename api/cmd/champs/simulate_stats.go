@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// latencies collects named latency samples from all bots
+type latencies struct {
+	mu      sync.Mutex
+	samples map[string][]time.Duration
+	counts  map[string]int
+}
+
+func newLatencies() *latencies {
+	return &latencies{
+		samples: make(map[string][]time.Duration),
+		counts:  make(map[string]int),
+	}
+}
+
+// observe records one latency sample for metric
+func (l *latencies) observe(metric string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.samples[metric] = append(l.samples[metric], d)
+}
+
+// inc bumps a named counter (errors, timeouts, completions)
+func (l *latencies) inc(counter string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.counts[counter]++
+}
+
+// print writes a percentile table and the counters
+func (l *latencies) print(elapsed time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	metrics := make([]string, 0, len(l.samples))
+	for m := range l.samples {
+		metrics = append(metrics, m)
+	}
+	sort.Strings(metrics)
+
+	fmt.Printf("\nRun finished in %s\n\n", elapsed.Round(time.Millisecond))
+	fmt.Printf("%-12s %7s %9s %9s %9s %9s\n", "metric", "count", "p50", "p90", "p99", "max")
+	for _, m := range metrics {
+		s := append([]time.Duration(nil), l.samples[m]...)
+		sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+		fmt.Printf("%-12s %7d %9s %9s %9s %9s\n", m, len(s),
+			percentile(s, 0.50), percentile(s, 0.90), percentile(s, 0.99), s[len(s)-1].Round(time.Millisecond))
+	}
+
+	counters := make([]string, 0, len(l.counts))
+	for c := range l.counts {
+		counters = append(counters, c)
+	}
+	sort.Strings(counters)
+	fmt.Println()
+	for _, c := range counters {
+		fmt.Printf("%-20s %d\n", c, l.counts[c])
+	}
+}
+
+// percentile returns the p-th percentile of sorted samples
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(float64(len(sorted)-1) * p)
+	return sorted[idx].Round(time.Millisecond)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"2026champs/internal/mockapi"
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// mockapi serves recorded Gemini and SurveyMonkey responses so the evaluator
+// and SM client can be exercised offline against known-good and broken payloads.
+func main() {
+	addr := flag.String("addr", ":9090", "listen address")
+	gemini := flag.String("gemini", mockapi.ScenarioOK, "initial Gemini scenario")
+	sm := flag.String("sm", mockapi.ScenarioOK, "initial SurveyMonkey scenario")
+	flag.Parse()
+
+	srv := mockapi.NewServer()
+	if err := srv.SetGeminiScenario(*gemini); err != nil {
+		log.Fatal(err)
+	}
+	if err := srv.SetSMScenario(*sm); err != nil {
+		log.Fatal(err)
+	}
+
+	base := "http://localhost" + *addr
+	if !strings.HasPrefix(*addr, ":") {
+		base = "http://" + *addr
+	}
+	scenarios := mockapi.Scenarios()
+	log.Printf("Mock API listening on %s", *addr)
+	log.Printf("  GEMINI_BASE_URL=%s/v1beta/models", base)
+	log.Printf("  SM_BASE_URL=%s/v3", base)
+	log.Printf("  Gemini scenarios: %s", strings.Join(scenarios["gemini"], ", "))
+	log.Printf("  SM scenarios:     %s", strings.Join(scenarios["sm"], ", "))
+	log.Printf("  Switch with: POST %s/_mock/scenario?api=gemini&name=rate_limited", base)
+
+	log.Fatal(http.ListenAndServe(*addr, srv))
+}
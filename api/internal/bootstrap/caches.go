@@ -0,0 +1,80 @@
+package bootstrap
+
+import (
+	"2026champs/internal/cache"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Cache backends selectable with CACHE_BACKEND
+const (
+	CacheRedis  = "redis"
+	CacheMemory = "memory"
+)
+
+// caches is every cache the services use, from one backend
+type caches struct {
+	outbox       cache.OutboxCache
+	survey       cache.SurveyCache
+	room         cache.RoomCache
+	player       cache.PlayerCache
+	leaderboard  cache.LeaderboardCache
+	pool         cache.PoolCache
+	analytics    cache.AnalyticsCache
+	eval         cache.EvalCache
+	aiUsage      cache.AIUsageCache
+	quota        cache.QuotaCache
+	event        cache.EventCache
+	timeseries   cache.TimeseriesCache
+	poll         cache.PollCache
+	reaction     cache.ReactionCache
+	metaFeedback cache.MetaFeedbackCache
+	duplicate    cache.DuplicateCache
+	change       cache.ChangeCache
+}
+
+func redisCaches(rdb *redis.Client, evalTTL time.Duration) *caches {
+	return &caches{
+		outbox:       cache.NewOutboxCache(rdb),
+		survey:       cache.NewSurveyCache(rdb),
+		room:         cache.NewRoomCache(rdb),
+		player:       cache.NewPlayerCache(rdb),
+		leaderboard:  cache.NewLeaderboardCache(rdb),
+		pool:         cache.NewPoolCache(rdb),
+		analytics:    cache.NewAnalyticsCache(rdb),
+		eval:         cache.NewEvalCache(rdb, evalTTL),
+		aiUsage:      cache.NewAIUsageCache(rdb),
+		quota:        cache.NewQuotaCache(rdb),
+		event:        cache.NewEventCache(rdb),
+		timeseries:   cache.NewTimeseriesCache(rdb),
+		poll:         cache.NewPollCache(rdb),
+		reaction:     cache.NewReactionCache(rdb),
+		metaFeedback: cache.NewMetaFeedbackCache(rdb),
+		duplicate:    cache.NewDuplicateCache(rdb),
+		change:       cache.NewChangeCache(rdb),
+	}
+}
+
+// memoryCaches holds every cache in this process, so only one instance may run
+func memoryCaches(evalTTL time.Duration) *caches {
+	return &caches{
+		outbox:       cache.NewMemoryOutboxCache(),
+		survey:       cache.NewMemorySurveyCache(),
+		room:         cache.NewMemoryRoomCache(),
+		player:       cache.NewMemoryPlayerCache(),
+		leaderboard:  cache.NewMemoryLeaderboardCache(),
+		pool:         cache.NewMemoryPoolCache(),
+		analytics:    cache.NewMemoryAnalyticsCache(),
+		eval:         cache.NewMemoryEvalCache(evalTTL),
+		aiUsage:      cache.NewMemoryAIUsageCache(),
+		quota:        cache.NewMemoryQuotaCache(),
+		event:        cache.NewMemoryEventCache(),
+		timeseries:   cache.NewMemoryTimeseriesCache(),
+		poll:         cache.NewMemoryPollCache(),
+		reaction:     cache.NewMemoryReactionCache(),
+		metaFeedback: cache.NewMemoryMetaFeedbackCache(),
+		duplicate:    cache.NewMemoryDuplicateCache(),
+		change:       cache.NewMemoryChangeCache(),
+	}
+}
@@ -0,0 +1,102 @@
+package bootstrap
+
+import (
+	"2026champs/internal/repository"
+	"2026champs/internal/repository/postgres"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Backends selectable with DB_BACKEND
+const (
+	BackendMongo    = "mongo"
+	BackendPostgres = "postgres"
+)
+
+// repositories is every persistent store the services use, from one backend
+type repositories struct {
+	survey      repository.SurveyRepo
+	room        repository.RoomRepo
+	answer      repository.AnswerRepo
+	report      repository.ReportRepo
+	sm          repository.SMRepo
+	analytics   repository.AnalyticsRepo
+	plan        repository.PlanRepo
+	attachment  repository.AttachmentRepo
+	voice       repository.VoiceRepo
+	experiment  repository.ExperimentRepo
+	apiKey      repository.APIKeyRepo
+	audit       repository.AuditRepo
+	feedback    repository.FeedbackRepo
+	event       repository.EventRepo
+	poll        repository.PollRepo
+	integration repository.IntegrationRepo
+	template    repository.TemplateRepo
+	difficulty  repository.DifficultyRepo
+	eventGroup  repository.EventGroupRepo
+	comparison  repository.ComparisonRepo
+	email       repository.EmailRepo
+	digest      repository.DigestRepo
+	retention   repository.RetentionRepo
+
+	// changeStream is nil where the backend cannot follow writes
+	changeStream repository.ChangeStreamRepo
+}
+
+func mongoRepositories(db *mongo.Database) *repositories {
+	return &repositories{
+		survey:       repository.NewSurveyRepo(db),
+		room:         repository.NewRoomRepo(db),
+		answer:       repository.NewAnswerRepo(db),
+		report:       repository.NewReportRepo(db),
+		sm:           repository.NewSMRepo(db),
+		analytics:    repository.NewAnalyticsRepo(db),
+		plan:         repository.NewPlanRepo(db),
+		attachment:   repository.NewAttachmentRepo(db),
+		voice:        repository.NewVoiceRepo(db),
+		experiment:   repository.NewExperimentRepo(db),
+		apiKey:       repository.NewAPIKeyRepo(db),
+		audit:        repository.NewAuditRepo(db),
+		feedback:     repository.NewFeedbackRepo(db),
+		event:        repository.NewEventRepo(db),
+		poll:         repository.NewPollRepo(db),
+		integration:  repository.NewIntegrationRepo(db),
+		template:     repository.NewTemplateRepo(db),
+		difficulty:   repository.NewDifficultyRepo(db),
+		eventGroup:   repository.NewEventGroupRepo(db),
+		comparison:   repository.NewComparisonRepo(db),
+		email:        repository.NewEmailRepo(db),
+		digest:       repository.NewDigestRepo(db),
+		retention:    repository.NewRetentionRepo(db),
+		changeStream: repository.NewChangeStreamRepo(db),
+	}
+}
+
+func postgresRepositories(pool *pgxpool.Pool) *repositories {
+	return &repositories{
+		survey:      postgres.NewSurveyRepo(pool),
+		room:        postgres.NewRoomRepo(pool),
+		answer:      postgres.NewAnswerRepo(pool),
+		report:      postgres.NewReportRepo(pool),
+		sm:          postgres.NewSMRepo(pool),
+		analytics:   postgres.NewAnalyticsRepo(pool),
+		plan:        postgres.NewPlanRepo(pool),
+		attachment:  postgres.NewAttachmentRepo(pool),
+		voice:       postgres.NewVoiceRepo(pool),
+		experiment:  postgres.NewExperimentRepo(pool),
+		apiKey:      postgres.NewAPIKeyRepo(pool),
+		audit:       postgres.NewAuditRepo(pool),
+		feedback:    postgres.NewFeedbackRepo(pool),
+		event:       postgres.NewEventRepo(pool),
+		poll:        postgres.NewPollRepo(pool),
+		integration: postgres.NewIntegrationRepo(pool),
+		template:    postgres.NewTemplateRepo(pool),
+		difficulty:  postgres.NewDifficultyRepo(pool),
+		eventGroup:  postgres.NewEventGroupRepo(pool),
+		comparison:  postgres.NewComparisonRepo(pool),
+		email:       postgres.NewEmailRepo(pool),
+		digest:      postgres.NewDigestRepo(pool),
+		retention:   postgres.NewRetentionRepo(pool),
+	}
+}
@@ -0,0 +1,313 @@
+package bootstrap
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+// testRepositories connects to the backend named by DB_BACKEND (reached through
+// MONGO_URI or POSTGRES_URI), applies its migrations and returns its
+// repositories. Without DB_BACKEND the integration tests are skipped.
+func testRepositories(t *testing.T) *repositories {
+	t.Helper()
+	backend := os.Getenv("DB_BACKEND")
+	if backend == "" {
+		t.Skip("DB_BACKEND not set; repository integration tests need a database")
+	}
+
+	ctx := context.Background()
+	app := &App{Backend: backend}
+	t.Cleanup(app.Close)
+
+	var repos *repositories
+	switch backend {
+	case BackendMongo:
+		// A throwaway database, so the migrations build every index from scratch
+		opts := Options{DBName: fmt.Sprintf("champs_test_%d", time.Now().UnixNano())}
+		if err := app.connectMongo(ctx, opts); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { app.DB.Drop(context.Background()) })
+		repos = mongoRepositories(app.DB)
+	case BackendPostgres:
+		if err := app.connectPostgres(ctx, Options{}); err != nil {
+			t.Fatal(err)
+		}
+		repos = postgresRepositories(app.Postgres)
+	default:
+		t.Fatalf("unknown DB_BACKEND %q", backend)
+	}
+
+	if _, err := app.Migrate(ctx); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return repos
+}
+
+// testRoomCode returns a room code no other run uses, since PostgreSQL tests
+// share the database
+func testRoomCode() string {
+	return fmt.Sprintf("T%d", time.Now().UnixNano()%1e9)
+}
+
+func TestAnswerAttemptIsUnique(t *testing.T) {
+	repos := testRepositories(t)
+	ctx := context.Background()
+	code := testRoomCode()
+	defer repos.answer.DeleteByRoomAndPlayer(ctx, code, "p1")
+	defer repos.answer.DeleteByRoomAndPlayer(ctx, code, "p2")
+
+	first := &model.Answer{RoomCode: code, PlayerID: "p1", QuestionKey: "Q1", ClientAttemptID: "a1", TextAnswer: "first", Status: model.AnswerStatusSubmitted}
+	id, err := repos.answer.Create(ctx, first)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if id == "" || first.ID != id {
+		t.Fatalf("Create returned id %q, answer.ID %q", id, first.ID)
+	}
+
+	// A retry of the attempt gets the stored answer back, not a second row
+	retry := &model.Answer{RoomCode: code, PlayerID: "p1", QuestionKey: "Q1", ClientAttemptID: "a1", TextAnswer: "retry", Status: model.AnswerStatusSubmitted}
+	dupID, err := repos.answer.Create(ctx, retry)
+	if !errors.Is(err, repository.ErrDuplicateAnswer) {
+		t.Fatalf("duplicate Create error = %v, want ErrDuplicateAnswer", err)
+	}
+	if dupID != id || retry.TextAnswer != "first" {
+		t.Errorf("duplicate Create = %q with text %q, want %q with the stored text", dupID, retry.TextAnswer, id)
+	}
+
+	// The attempt is scoped to the player's question
+	for _, a := range []*model.Answer{
+		{RoomCode: code, PlayerID: "p2", QuestionKey: "Q1", ClientAttemptID: "a1"},
+		{RoomCode: code, PlayerID: "p1", QuestionKey: "Q2", ClientAttemptID: "a1"},
+		{RoomCode: code, PlayerID: "p1", QuestionKey: "Q1"},
+		{RoomCode: code, PlayerID: "p1", QuestionKey: "Q1"},
+	} {
+		if _, err := repos.answer.Create(ctx, a); err != nil {
+			t.Errorf("Create %s/%s/%q: %v", a.PlayerID, a.QuestionKey, a.ClientAttemptID, err)
+		}
+	}
+
+	answers, err := repos.answer.GetByRoomCode(ctx, code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(answers) != 5 {
+		t.Errorf("room has %d answers, want 5", len(answers))
+	}
+}
+
+func TestAnswerReplayRoundTrip(t *testing.T) {
+	repos := testRepositories(t)
+	ctx := context.Background()
+	code := testRoomCode()
+	defer repos.answer.DeleteByRoomAndPlayer(ctx, code, "p1")
+
+	answer := &model.Answer{RoomCode: code, PlayerID: "p1", QuestionKey: "Q1", ClientAttemptID: "a1", Status: model.AnswerStatusEvaluated}
+	id, err := repos.answer.Create(ctx, answer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := &model.SubmitAnswerResponse{
+		Status:       model.AnswerStatusEvaluated,
+		Resolution:   model.ResolutionUnsat,
+		FollowUp:     &model.Question{Key: "Q1.1", ParentKey: "Q1", Prompt: "Why?"},
+		NextQuestion: &model.Question{Key: "Q2", Prompt: "Next"},
+	}
+	if err := repos.answer.SetReplay(ctx, id, resp); err != nil {
+		t.Fatalf("SetReplay: %v", err)
+	}
+
+	stored, err := repos.answer.GetByClientAttempt(ctx, code, "p1", "Q1", "a1")
+	if err != nil || stored == nil {
+		t.Fatalf("GetByClientAttempt = %v, %v", stored, err)
+	}
+	if stored.Replay == nil || stored.Replay.FollowUp == nil || stored.Replay.FollowUp.Key != "Q1.1" || stored.Replay.NextQuestion == nil {
+		t.Errorf("stored replay = %+v, want the follow-up and next question", stored.Replay)
+	}
+}
+
+func TestAnswerCreateManyAndDeleteByIDs(t *testing.T) {
+	repos := testRepositories(t)
+	ctx := context.Background()
+	code := testRoomCode()
+	defer repos.answer.DeleteByRoomAndPlayer(ctx, code, "p1")
+
+	kept := &model.Answer{RoomCode: code, PlayerID: "p1", QuestionKey: "Q1", Status: model.AnswerStatusEvaluated}
+	if _, err := repos.answer.Create(ctx, kept); err != nil {
+		t.Fatal(err)
+	}
+	abandoned := []*model.Answer{
+		{RoomCode: code, PlayerID: "p1", QuestionKey: "Q2", Status: model.AnswerStatusSubmitted, Resolution: model.ResolutionAbandoned},
+		{RoomCode: code, PlayerID: "p1", QuestionKey: "Q3", Status: model.AnswerStatusSubmitted, Resolution: model.ResolutionAbandoned},
+	}
+	if err := repos.answer.CreateMany(ctx, abandoned); err != nil {
+		t.Fatalf("CreateMany: %v", err)
+	}
+	ids := []string{abandoned[0].ID, abandoned[1].ID}
+	if ids[0] == "" || ids[1] == "" {
+		t.Fatalf("CreateMany left IDs %q unset", ids)
+	}
+
+	deleted, err := repos.answer.DeleteByIDs(ctx, ids)
+	if err != nil || deleted != 2 {
+		t.Fatalf("DeleteByIDs = %d, %v; want 2", deleted, err)
+	}
+	answers, err := repos.answer.GetByRoomAndPlayer(ctx, code, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(answers) != 1 || answers[0].QuestionKey != "Q1" {
+		t.Errorf("left %d answers, want only Q1", len(answers))
+	}
+}
+
+func TestSnapshotAndReportUpsert(t *testing.T) {
+	repos := testRepositories(t)
+	ctx := context.Background()
+	code := testRoomCode()
+
+	// Ending a room again replaces its snapshot and report instead of failing
+	for _, surveyID := range []string{"s1", "s2"} {
+		if err := repos.report.SaveSnapshot(ctx, &model.RoomSnapshot{RoomCode: code, SurveyID: surveyID, EndedAt: time.Now()}); err != nil {
+			t.Fatalf("SaveSnapshot %s: %v", surveyID, err)
+		}
+		if err := repos.report.SaveAIReport(ctx, &model.AIReport{RoomCode: code}); err != nil {
+			t.Fatalf("SaveAIReport: %v", err)
+		}
+	}
+
+	snapshots, err := repos.report.GetSnapshots(ctx, []string{code})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 1 || snapshots[0].SurveyID != "s2" {
+		t.Errorf("snapshots = %+v, want only the second", snapshots)
+	}
+	reports, err := repos.report.GetAIReports(ctx, []string{code})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 1 {
+		t.Errorf("got %d AI reports, want 1", len(reports))
+	}
+}
+
+func TestRoomCodeIsUnique(t *testing.T) {
+	repos := testRepositories(t)
+	ctx := context.Background()
+	code := testRoomCode()
+
+	if err := repos.room.Create(ctx, &model.Room{Code: code, Status: model.RoomStatusActive}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	defer repos.room.Delete(ctx, code)
+	if err := repos.room.Create(ctx, &model.Room{Code: code, Status: model.RoomStatusActive}); err == nil {
+		t.Error("second room with the same code was created")
+	}
+}
+
+func TestFeedbackAndAssignmentUpserts(t *testing.T) {
+	repos := testRepositories(t)
+	ctx := context.Background()
+	code := testRoomCode()
+	defer repos.feedback.Delete(ctx, code, "p1")
+
+	// Feedback is replaced per room and player
+	for _, score := range []int{10, 20} {
+		if err := repos.feedback.Upsert(ctx, &model.PlayerFeedback{RoomCode: code, PlayerID: "p1", Score: score}); err != nil {
+			t.Fatalf("Upsert: %v", err)
+		}
+	}
+	feedback, err := repos.feedback.Get(ctx, code, "p1")
+	if err != nil || feedback == nil || feedback.Score != 20 {
+		t.Errorf("feedback = %+v, %v; want the second upsert", feedback, err)
+	}
+
+	// An assignment keeps its first variant
+	experimentID := "exp-" + code
+	for _, variant := range []string{"A", "B"} {
+		if err := repos.experiment.Assign(ctx, &model.ExperimentAssignment{ExperimentID: experimentID, RoomCode: code, PlayerID: "p1", Variant: variant, AssignedAt: time.Now()}); err != nil {
+			t.Fatalf("Assign %s: %v", variant, err)
+		}
+	}
+	assignments, err := repos.experiment.GetAssignments(ctx, experimentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(assignments) != 1 || assignments[0].Variant != "A" {
+		t.Errorf("assignments = %+v, want only variant A", assignments)
+	}
+}
+
+func TestVoiceClipsByPlayer(t *testing.T) {
+	repos := testRepositories(t)
+	ctx := context.Background()
+	code := testRoomCode()
+	defer repos.voice.DeleteByPlayer(ctx, code, "p2")
+
+	for i, playerID := range []string{"p1", "p1", "p2"} {
+		clip := &model.VoiceClip{ID: fmt.Sprintf("%s-%d", code, i), RoomCode: code, PlayerID: playerID, QuestionKey: "Q1", StorageKey: fmt.Sprintf("voice/%s/%d", code, i)}
+		if err := repos.voice.Create(ctx, clip); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	clips, err := repos.voice.ListByPlayer(ctx, code, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clips) != 2 || clips[0].StorageKey == "" {
+		t.Errorf("ListByPlayer = %+v, want p1's two clips with their storage keys", clips)
+	}
+
+	deleted, err := repos.voice.DeleteByPlayer(ctx, code, "p1")
+	if err != nil || deleted != 2 {
+		t.Errorf("DeleteByPlayer = %d, %v; want 2", deleted, err)
+	}
+	if left, _ := repos.voice.ListByPlayer(ctx, code, "p2"); len(left) != 1 {
+		t.Errorf("p2 has %d clips after deleting p1's, want 1", len(left))
+	}
+}
+
+func TestAttachmentDeletes(t *testing.T) {
+	repos := testRepositories(t)
+	ctx := context.Background()
+	code := testRoomCode()
+
+	for i, playerID := range []string{"p1", "p1", "p2"} {
+		attachment := &model.Attachment{ID: fmt.Sprintf("%s-%d", code, i), RoomCode: code, PlayerID: playerID, QuestionKey: "Q1", StorageKey: fmt.Sprintf("%s/%d", code, i)}
+		if err := repos.attachment.Create(ctx, attachment); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	if mine, err := repos.attachment.ListByPlayer(ctx, code, "p1"); err != nil || len(mine) != 2 || mine[0].StorageKey == "" {
+		t.Errorf("ListByPlayer = %+v, %v; want p1's two uploads with their storage keys", mine, err)
+	}
+	if old, err := repos.attachment.ListOlderThan(ctx, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	} else {
+		for _, a := range old {
+			if a.RoomCode == code {
+				t.Errorf("ListOlderThan returned the new upload %s", a.ID)
+			}
+		}
+	}
+
+	if deleted, err := repos.attachment.DeleteByPlayer(ctx, code, "p1"); err != nil || deleted != 2 {
+		t.Errorf("DeleteByPlayer = %d, %v; want 2", deleted, err)
+	}
+	if deleted, err := repos.attachment.DeleteByRoom(ctx, code); err != nil || deleted != 1 {
+		t.Errorf("DeleteByRoom = %d, %v; want p2's upload", deleted, err)
+	}
+	if left, _ := repos.attachment.ListByRoom(ctx, code); len(left) != 0 {
+		t.Errorf("room has %d uploads after DeleteByRoom, want 0", len(left))
+	}
+}
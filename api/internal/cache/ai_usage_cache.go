@@ -0,0 +1,123 @@
+package cache
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// AIUsageCache tracks per-room and global Gemini usage for budget enforcement and cost reports
+type AIUsageCache interface {
+	GetRoomUsage(ctx context.Context, roomCode string) (*model.AIUsage, error)
+	AddRoomUsage(ctx context.Context, roomCode, modelName string, inputTokens, outputTokens int) error
+	GetGlobalUsage(ctx context.Context) (*model.AIUsage, error)
+	AddGlobalUsage(ctx context.Context, modelName string, inputTokens, outputTokens int) error
+	// Host usage is bucketed per UTC calendar month for plan limits
+	GetHostUsage(ctx context.Context, hostID string, month time.Time) (*model.AIUsage, error)
+	AddHostUsage(ctx context.Context, hostID, modelName string, inputTokens, outputTokens int) error
+}
+
+type aiUsageCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewAIUsageCache creates a new AI usage cache
+func NewAIUsageCache(client *redis.Client) AIUsageCache {
+	return &aiUsageCache{
+		client: client,
+		ttl:    24 * time.Hour,
+	}
+}
+
+func (c *aiUsageCache) roomKey(roomCode string) string {
+	return fmt.Sprintf("room:%s:ai:usage", roomCode)
+}
+
+// globalKey buckets global usage per UTC day
+func (c *aiUsageCache) globalKey() string {
+	return fmt.Sprintf("ai:usage:global:%s", time.Now().UTC().Format("2006-01-02"))
+}
+
+func (c *aiUsageCache) hostKey(hostID string, month time.Time) string {
+	return fmt.Sprintf("ai:usage:host:%s:%s", hostID, month.UTC().Format("2006-01"))
+}
+
+func (c *aiUsageCache) GetRoomUsage(ctx context.Context, roomCode string) (*model.AIUsage, error) {
+	return c.get(ctx, c.roomKey(roomCode))
+}
+
+func (c *aiUsageCache) AddRoomUsage(ctx context.Context, roomCode, modelName string, inputTokens, outputTokens int) error {
+	return c.add(ctx, c.roomKey(roomCode), modelName, inputTokens, outputTokens, c.ttl)
+}
+
+func (c *aiUsageCache) GetGlobalUsage(ctx context.Context) (*model.AIUsage, error) {
+	return c.get(ctx, c.globalKey())
+}
+
+func (c *aiUsageCache) AddGlobalUsage(ctx context.Context, modelName string, inputTokens, outputTokens int) error {
+	return c.add(ctx, c.globalKey(), modelName, inputTokens, outputTokens, 2*c.ttl)
+}
+
+func (c *aiUsageCache) GetHostUsage(ctx context.Context, hostID string, month time.Time) (*model.AIUsage, error) {
+	return c.get(ctx, c.hostKey(hostID, month))
+}
+
+// AddHostUsage keeps a month's bucket a little past the month's end
+func (c *aiUsageCache) AddHostUsage(ctx context.Context, hostID, modelName string, inputTokens, outputTokens int) error {
+	return c.add(ctx, c.hostKey(hostID, time.Now()), modelName, inputTokens, outputTokens, 32*c.ttl)
+}
+
+// Hash fields: calls/tokens/in/out totals plus "m:<model>:calls|in|out" per model
+func (c *aiUsageCache) get(ctx context.Context, key string) (*model.AIUsage, error) {
+	data, err := c.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	usage := &model.AIUsage{Models: map[string]model.ModelUsage{}}
+	usage.Calls, _ = strconv.ParseInt(data["calls"], 10, 64)
+	usage.EstimatedTokens, _ = strconv.ParseInt(data["tokens"], 10, 64)
+	usage.InputTokens, _ = strconv.ParseInt(data["in"], 10, 64)
+	usage.OutputTokens, _ = strconv.ParseInt(data["out"], 10, 64)
+
+	for field, v := range data {
+		if !strings.HasPrefix(field, "m:") {
+			continue
+		}
+		idx := strings.LastIndex(field, ":")
+		name, metric := field[2:idx], field[idx+1:]
+		n, _ := strconv.ParseInt(v, 10, 64)
+		mu := usage.Models[name]
+		switch metric {
+		case "calls":
+			mu.Calls = n
+		case "in":
+			mu.InputTokens = n
+		case "out":
+			mu.OutputTokens = n
+		}
+		usage.Models[name] = mu
+	}
+	return usage, nil
+}
+
+func (c *aiUsageCache) add(ctx context.Context, key, modelName string, inputTokens, outputTokens int, ttl time.Duration) error {
+	pipe := c.client.TxPipeline()
+	pipe.HIncrBy(ctx, key, "calls", 1)
+	pipe.HIncrBy(ctx, key, "tokens", int64(inputTokens+outputTokens))
+	pipe.HIncrBy(ctx, key, "in", int64(inputTokens))
+	pipe.HIncrBy(ctx, key, "out", int64(outputTokens))
+	if modelName != "" {
+		pipe.HIncrBy(ctx, key, "m:"+modelName+":calls", 1)
+		pipe.HIncrBy(ctx, key, "m:"+modelName+":in", int64(inputTokens))
+		pipe.HIncrBy(ctx, key, "m:"+modelName+":out", int64(outputTokens))
+	}
+	pipe.Expire(ctx, key, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -15,15 +17,29 @@ type AnalyticsCache interface {
 	// L2: Player Profile
 	GetPlayerProfile(ctx context.Context, roomCode, playerID string) (*model.PlayerProfile, error)
 	SetPlayerProfile(ctx context.Context, profile *model.PlayerProfile) error
+	DeletePlayerProfile(ctx context.Context, roomCode, playerID string) error
 
 	// L3: Question Profile
 	GetQuestionProfile(ctx context.Context, roomCode, questionKey string) (*model.QuestionProfile, error)
 	SetQuestionProfile(ctx context.Context, profile *model.QuestionProfile) error
 	IncrementQuestionStats(ctx context.Context, roomCode, questionKey string, sat, unsat, skip int) error
 
+	// Response times (serve to submit), newest first, capped at MaxResponseSamples
+	AddResponseTime(ctx context.Context, roomCode, questionKey string, ms int64) ([]int64, error)
+
+	// Player relevance ratings of AI follow-ups; RateFollowUp returns false if the
+	// player already rated the question
+	RateFollowUp(ctx context.Context, roomCode, playerID, questionKey, source, hint string, relevant bool) (bool, error)
+	GetFollowUpRelevance(ctx context.Context, roomCode string) (*model.FollowUpRelevance, error)
+
 	// L4: Room Memory
 	GetRoomMemory(ctx context.Context, roomCode string) (*model.RoomMemory, error)
 	SetRoomMemory(ctx context.Context, memory *model.RoomMemory) error
+
+	// Archival enumeration
+	ListPlayerProfiles(ctx context.Context, roomCode string) ([]*model.PlayerProfile, error)
+	ListQuestionProfiles(ctx context.Context, roomCode string) ([]*model.QuestionProfile, error)
+	ListRoomCodes(ctx context.Context) ([]string, error)
 }
 
 type analyticsCache struct {
@@ -48,11 +64,27 @@ func (c *analyticsCache) questionProfileKey(roomCode, questionKey string) string
 	return fmt.Sprintf("room:%s:q:%s:profile", roomCode, questionKey)
 }
 
+func (c *analyticsCache) responseTimesKey(roomCode, questionKey string) string {
+	return fmt.Sprintf("room:%s:q:%s:rt", roomCode, questionKey)
+}
+
+func (c *analyticsCache) relevanceKey(roomCode string) string {
+	return fmt.Sprintf("room:%s:fu:relevance", roomCode)
+}
+
+func (c *analyticsCache) ratedKey(roomCode string) string {
+	return fmt.Sprintf("room:%s:fu:rated", roomCode)
+}
+
 func (c *analyticsCache) roomMemoryKey(roomCode string) string {
 	return fmt.Sprintf("room:%s:memory", roomCode)
 }
 
 // L2: Player Profile
+func (c *analyticsCache) DeletePlayerProfile(ctx context.Context, roomCode, playerID string) error {
+	return c.client.Del(ctx, c.playerProfileKey(roomCode, playerID)).Err()
+}
+
 func (c *analyticsCache) GetPlayerProfile(ctx context.Context, roomCode, playerID string) (*model.PlayerProfile, error) {
 	data, err := c.client.Get(ctx, c.playerProfileKey(roomCode, playerID)).Result()
 	if err == redis.Nil {
@@ -107,6 +139,11 @@ func (c *analyticsCache) IncrementQuestionStats(ctx context.Context, roomCode, q
 	if err != nil {
 		return err
 	}
+	return c.SetQuestionProfile(ctx, addQuestionStats(profile, roomCode, questionKey, sat, unsat, skip))
+}
+
+// addQuestionStats adds answer counts to a question profile, starting an empty one if nil
+func addQuestionStats(profile *model.QuestionProfile, roomCode, questionKey string, sat, unsat, skip int) *model.QuestionProfile {
 	if profile == nil {
 		profile = &model.QuestionProfile{
 			RoomCode:      roomCode,
@@ -120,7 +157,116 @@ func (c *analyticsCache) IncrementQuestionStats(ctx context.Context, roomCode, q
 	profile.UnsatCount += unsat
 	profile.SkipCount += skip
 	profile.AnswerCount += sat + unsat + skip
-	return c.SetQuestionProfile(ctx, profile)
+	return profile
+}
+
+// MaxResponseSamples caps the response times kept per question for percentiles
+const MaxResponseSamples = 1000
+
+// AddResponseTime records one response time and returns the kept samples
+func (c *analyticsCache) AddResponseTime(ctx context.Context, roomCode, questionKey string, ms int64) ([]int64, error) {
+	key := c.responseTimesKey(roomCode, questionKey)
+	pipe := c.client.TxPipeline()
+	pipe.LPush(ctx, key, ms)
+	pipe.LTrim(ctx, key, 0, MaxResponseSamples-1)
+	pipe.Expire(ctx, key, c.ttl)
+	samples := pipe.LRange(ctx, key, 0, -1)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	vals := samples.Val()
+	out := make([]int64, 0, len(vals))
+	for _, v := range vals {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			out = append(out, n)
+		}
+	}
+	return out, nil
+}
+
+// RateFollowUp records one rating per player and question; counters are
+// "source:<source>:<up|down>" and "hint:<hint>:<up|down>" fields
+func (c *analyticsCache) RateFollowUp(ctx context.Context, roomCode, playerID, questionKey, source, hint string, relevant bool) (bool, error) {
+	vote := "down"
+	if relevant {
+		vote = "up"
+	}
+	set, err := c.client.HSetNX(ctx, c.ratedKey(roomCode), playerID+":"+questionKey, vote).Result()
+	if err != nil || !set {
+		return false, err
+	}
+
+	pipe := c.client.TxPipeline()
+	pipe.HIncrBy(ctx, c.relevanceKey(roomCode), "source:"+source+":"+vote, 1)
+	pipe.HIncrBy(ctx, c.relevanceKey(roomCode), "hint:"+hint+":"+vote, 1)
+	pipe.Expire(ctx, c.relevanceKey(roomCode), c.ttl)
+	pipe.Expire(ctx, c.ratedKey(roomCode), c.ttl)
+	_, err = pipe.Exec(ctx)
+	return true, err
+}
+
+// GetFollowUpRelevance totals the room's ratings; nil when there are none
+func (c *analyticsCache) GetFollowUpRelevance(ctx context.Context, roomCode string) (*model.FollowUpRelevance, error) {
+	fields, err := c.client.HGetAll(ctx, c.relevanceKey(roomCode)).Result()
+	if err != nil || len(fields) == 0 {
+		return nil, err
+	}
+	counts := make(map[string]int, len(fields))
+	for field, value := range fields {
+		if n, err := strconv.Atoi(value); err == nil {
+			counts[field] = n
+		}
+	}
+	return followUpRelevance(counts), nil
+}
+
+// followUpRelevance totals the rating counters written by RateFollowUp
+func followUpRelevance(counts map[string]int) *model.FollowUpRelevance {
+	out := &model.FollowUpRelevance{
+		BySource: make(map[string]model.RelevanceStats),
+		ByHint:   make(map[string]model.RelevanceStats),
+	}
+	for field, n := range counts {
+		parts := strings.Split(field, ":")
+		if len(parts) != 3 {
+			continue
+		}
+		group := out.BySource
+		if parts[0] == "hint" {
+			group = out.ByHint
+		}
+		stats := group[parts[1]]
+		if parts[2] == "up" {
+			stats.Up += n
+		} else {
+			stats.Down += n
+		}
+		group[parts[1]] = stats
+		// Every rating is counted once per dimension; total it from the sources
+		if parts[0] == "source" {
+			if parts[2] == "up" {
+				out.Overall.Up += n
+			} else {
+				out.Overall.Down += n
+			}
+		}
+	}
+	out.Overall.Rate = relevanceRate(out.Overall)
+	for _, group := range []map[string]model.RelevanceStats{out.BySource, out.ByHint} {
+		for k, stats := range group {
+			stats.Rate = relevanceRate(stats)
+			group[k] = stats
+		}
+	}
+	return out
+}
+
+func relevanceRate(stats model.RelevanceStats) float64 {
+	if stats.Total() == 0 {
+		return 0
+	}
+	return float64(stats.Up) / float64(stats.Total())
 }
 
 // L4: Room Memory
@@ -147,3 +293,61 @@ func (c *analyticsCache) SetRoomMemory(ctx context.Context, memory *model.RoomMe
 	}
 	return c.client.Set(ctx, c.roomMemoryKey(memory.RoomCode), data, c.ttl).Err()
 }
+
+// Archival enumeration
+func (c *analyticsCache) ListPlayerProfiles(ctx context.Context, roomCode string) ([]*model.PlayerProfile, error) {
+	profiles := []*model.PlayerProfile{}
+	err := c.scanJSON(ctx, fmt.Sprintf("room:%s:p:*:profile", roomCode), func(data []byte) error {
+		var profile model.PlayerProfile
+		if err := json.Unmarshal(data, &profile); err != nil {
+			return err
+		}
+		profiles = append(profiles, &profile)
+		return nil
+	})
+	return profiles, err
+}
+
+func (c *analyticsCache) ListQuestionProfiles(ctx context.Context, roomCode string) ([]*model.QuestionProfile, error) {
+	profiles := []*model.QuestionProfile{}
+	err := c.scanJSON(ctx, fmt.Sprintf("room:%s:q:*:profile", roomCode), func(data []byte) error {
+		var profile model.QuestionProfile
+		if err := json.Unmarshal(data, &profile); err != nil {
+			return err
+		}
+		profiles = append(profiles, &profile)
+		return nil
+	})
+	return profiles, err
+}
+
+// ListRoomCodes returns every room that currently has room memory in Redis
+func (c *analyticsCache) ListRoomCodes(ctx context.Context) ([]string, error) {
+	codes := []string{}
+	iter := c.client.Scan(ctx, 0, "room:*:memory", 100).Iterator()
+	for iter.Next(ctx) {
+		parts := strings.Split(iter.Val(), ":")
+		if len(parts) == 3 {
+			codes = append(codes, parts[1])
+		}
+	}
+	return codes, iter.Err()
+}
+
+// scanJSON calls fn with the value of every key matching pattern
+func (c *analyticsCache) scanJSON(ctx context.Context, pattern string, fn func([]byte) error) error {
+	iter := c.client.Scan(ctx, 0, pattern, 100).Iterator()
+	for iter.Next(ctx) {
+		data, err := c.client.Get(ctx, iter.Val()).Bytes()
+		if err == redis.Nil {
+			continue // Expired between SCAN and GET
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
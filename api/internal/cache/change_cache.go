@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ChangeCache lets every instance follow the same change streams while only one
+// of them publishes each change
+type ChangeCache interface {
+	// Claim reports whether this caller is the first to claim the change
+	Claim(ctx context.Context, changeID string) (bool, error)
+}
+
+type changeCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewChangeCache creates a new change cache
+func NewChangeCache(client *redis.Client) ChangeCache {
+	return &changeCache{
+		client: client,
+		ttl:    10 * time.Minute,
+	}
+}
+
+func (c *changeCache) key(changeID string) string {
+	return fmt.Sprintf("changes:%s", changeID)
+}
+
+// Claim sets the change's key if no instance has; the TTL outlasts any resume after a reconnect
+func (c *changeCache) Claim(ctx context.Context, changeID string) (bool, error) {
+	return c.client.SetNX(ctx, c.key(changeID), 1, c.ttl).Result()
+}
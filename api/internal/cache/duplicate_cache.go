@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// AnswerFingerprint is a recent ESSAY answer kept for duplicate checks
+type AnswerFingerprint struct {
+	PlayerID string    `json:"playerId"`
+	Hash     string    `json:"hash"` // Of the normalized text
+	Text     string    `json:"text"` // Normalized text, for fuzzy matching
+	At       time.Time `json:"at"`
+}
+
+// DuplicateCache keeps the latest answers to each question so copies from other
+// players can be spotted as they arrive
+type DuplicateCache interface {
+	// Recent returns the question's kept answers, newest first
+	Recent(ctx context.Context, roomCode, questionKey string) ([]AnswerFingerprint, error)
+	// Add keeps an answer, dropping the oldest beyond the window
+	Add(ctx context.Context, roomCode, questionKey string, fp AnswerFingerprint) error
+}
+
+type duplicateCache struct {
+	client *redis.Client
+	window int64
+	ttl    time.Duration
+}
+
+// NewDuplicateCache creates a new duplicate cache
+func NewDuplicateCache(client *redis.Client) DuplicateCache {
+	return &duplicateCache{
+		client: client,
+		window: 200,
+		ttl:    48 * time.Hour,
+	}
+}
+
+func (c *duplicateCache) key(roomCode, questionKey string) string {
+	return fmt.Sprintf("room:%s:q:%s:recent", roomCode, questionKey)
+}
+
+func (c *duplicateCache) Recent(ctx context.Context, roomCode, questionKey string) ([]AnswerFingerprint, error) {
+	items, err := c.client.LRange(ctx, c.key(roomCode, questionKey), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]AnswerFingerprint, 0, len(items))
+	for _, item := range items {
+		var fp AnswerFingerprint
+		if err := json.Unmarshal([]byte(item), &fp); err == nil {
+			out = append(out, fp)
+		}
+	}
+	return out, nil
+}
+
+func (c *duplicateCache) Add(ctx context.Context, roomCode, questionKey string, fp AnswerFingerprint) error {
+	data, err := json.Marshal(fp)
+	if err != nil {
+		return err
+	}
+	key := c.key(roomCode, questionKey)
+	pipe := c.client.TxPipeline()
+	pipe.LPush(ctx, key, data)
+	pipe.LTrim(ctx, key, 0, c.window-1)
+	pipe.Expire(ctx, key, c.ttl)
+	_, err = pipe.Exec(ctx)
+	return err
+}
@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// EvalCache handles Redis operations for reusable L1 evaluation results
+type EvalCache interface {
+	Get(ctx context.Context, roomCode, questionKey, normalized string) (*model.EvaluationResult, error)
+	GetAll(ctx context.Context, roomCode, questionKey string) (map[string]*model.EvaluationResult, error)
+	Set(ctx context.Context, roomCode, questionKey, normalized string, result *model.EvaluationResult) error
+	Delete(ctx context.Context, roomCode, questionKey, normalized string) error
+}
+
+// evalEntry is a cached result with the time it was stored. All entries of a question
+// share one hash whose expiry every write pushes back, so each entry checks its own age.
+type evalEntry struct {
+	Result   *model.EvaluationResult `json:"result"`
+	StoredAt time.Time               `json:"storedAt"`
+}
+
+// encodeEvalEntry wraps a result stored now
+func encodeEvalEntry(result *model.EvaluationResult) ([]byte, error) {
+	return json.Marshal(evalEntry{Result: result, StoredAt: time.Now()})
+}
+
+// decodeEvalEntry returns the entry's result; nil when it is older than ttl or unreadable
+func decodeEvalEntry(data []byte, ttl time.Duration) *model.EvaluationResult {
+	var entry evalEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Result == nil {
+		return nil
+	}
+	if time.Since(entry.StoredAt) > ttl {
+		return nil
+	}
+	return entry.Result
+}
+
+type evalCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewEvalCache creates a new evaluation cache
+func NewEvalCache(client *redis.Client, ttl time.Duration) EvalCache {
+	return &evalCache{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+// key holds normalized answer text -> evalEntry JSON for one question
+func (c *evalCache) key(roomCode, questionKey string) string {
+	return fmt.Sprintf("room:%s:q:%s:evalcache", roomCode, questionKey)
+}
+
+func (c *evalCache) Get(ctx context.Context, roomCode, questionKey, normalized string) (*model.EvaluationResult, error) {
+	data, err := c.client.HGet(ctx, c.key(roomCode, questionKey), normalized).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeEvalEntry([]byte(data), c.ttl), nil
+}
+
+func (c *evalCache) GetAll(ctx context.Context, roomCode, questionKey string) (map[string]*model.EvaluationResult, error) {
+	data, err := c.client.HGetAll(ctx, c.key(roomCode, questionKey)).Result()
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string]*model.EvaluationResult)
+	var stale []string
+	for text, jsonStr := range data {
+		if r := decodeEvalEntry([]byte(jsonStr), c.ttl); r != nil {
+			results[text] = r
+		} else {
+			stale = append(stale, text)
+		}
+	}
+	if len(stale) > 0 {
+		c.client.HDel(ctx, c.key(roomCode, questionKey), stale...)
+	}
+	return results, nil
+}
+
+func (c *evalCache) Set(ctx context.Context, roomCode, questionKey, normalized string, result *model.EvaluationResult) error {
+	data, err := encodeEvalEntry(result)
+	if err != nil {
+		return err
+	}
+	key := c.key(roomCode, questionKey)
+	pipe := c.client.TxPipeline()
+	pipe.HSet(ctx, key, normalized, data)
+	pipe.Expire(ctx, key, c.ttl)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (c *evalCache) Delete(ctx context.Context, roomCode, questionKey, normalized string) error {
+	return c.client.HDel(ctx, c.key(roomCode, questionKey), normalized).Err()
+}
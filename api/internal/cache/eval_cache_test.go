@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// Later writes to a question keep its hash alive, so older entries must still expire
+func TestEvalCacheEntriesExpireIndividually(t *testing.T) {
+	const ttl = 50 * time.Millisecond
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	backends := map[string]EvalCache{
+		"redis":  NewEvalCache(client, ttl),
+		"memory": NewMemoryEvalCache(ttl),
+	}
+	for name, c := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if err := c.Set(ctx, "ROOM1", "Q1", "too slow", &model.EvaluationResult{Resolution: "UNSAT"}); err != nil {
+				t.Fatal(err)
+			}
+			time.Sleep(2 * ttl)
+			if err := c.Set(ctx, "ROOM1", "Q1", "price", &model.EvaluationResult{Resolution: "SAT"}); err != nil {
+				t.Fatal(err)
+			}
+
+			if old, err := c.Get(ctx, "ROOM1", "Q1", "too slow"); err != nil || old != nil {
+				t.Fatalf("Get(stale) = %v, %v; want nil", old, err)
+			}
+			fresh, err := c.Get(ctx, "ROOM1", "Q1", "price")
+			if err != nil || fresh == nil || fresh.Resolution != "SAT" {
+				t.Fatalf("Get(fresh) = %v, %v; want SAT", fresh, err)
+			}
+			all, err := c.GetAll(ctx, "ROOM1", "Q1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(all) != 1 || all["price"] == nil {
+				t.Fatalf("GetAll = %v, want only the fresh entry", all)
+			}
+		})
+	}
+}
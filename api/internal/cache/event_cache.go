@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// EventCache hands out per-room sequence numbers for the persisted event stream
+type EventCache interface {
+	NextSeq(ctx context.Context, roomCode string) (int64, error)
+}
+
+type eventCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewEventCache creates a new event cache
+func NewEventCache(client *redis.Client) EventCache {
+	return &eventCache{
+		client: client,
+		ttl:    48 * time.Hour,
+	}
+}
+
+func (c *eventCache) key(roomCode string) string {
+	return fmt.Sprintf("room:%s:events:seq", roomCode)
+}
+
+// NextSeq increments the room's counter, starting at 1; the TTL is refreshed on every call
+func (c *eventCache) NextSeq(ctx context.Context, roomCode string) (int64, error) {
+	pipe := c.client.TxPipeline()
+	incr := pipe.Incr(ctx, c.key(roomCode))
+	pipe.Expire(ctx, c.key(roomCode), c.ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
@@ -3,15 +3,29 @@ package cache
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
-// LeaderboardCache handles Redis ZSET operations for leaderboard
+// LeaderboardCache handles Redis ZSET operations for leaderboard. Equal scores
+// are ordered by the shorter completion time, then the earlier join.
 type LeaderboardCache interface {
-	UpdateScore(ctx context.Context, roomCode, playerID string, score int) error
+	// UpdateScore sets a player's points; completion is the time from their join
+	// to the answer that produced the score
+	UpdateScore(ctx context.Context, roomCode, playerID string, score int, completion time.Duration) error
 	GetTop(ctx context.Context, roomCode string, limit int) ([]LeaderboardEntry, error)
 	GetRank(ctx context.Context, roomCode, playerID string) (int64, error)
+	GetStanding(ctx context.Context, roomCode, playerID string) (*LeaderboardStanding, error)
+
+	// Freeze stops further score updates once a room ends; Unfreeze reverts it
+	Freeze(ctx context.Context, roomCode string) error
+	Unfreeze(ctx context.Context, roomCode string) error
+
+	// Remove deletes a player's entry (data deletion requests bypass the freeze)
+	Remove(ctx context.Context, roomCode, playerID string) error
 }
 
 // LeaderboardEntry represents a single leaderboard entry
@@ -20,6 +34,40 @@ type LeaderboardEntry struct {
 	Nickname string `json:"nickname"`
 	Score    int    `json:"score"`
 	Rank     int    `json:"rank"`
+
+	// Tie-break details: Tied is set when a neighbouring entry has the same score
+	Tied          bool  `json:"tied"`
+	CompletionSec int64 `json:"completionSec"`
+	JoinOrder     int   `json:"joinOrder"` // 1 = first to join
+}
+
+// LeaderboardStanding is one player's position and the entry directly ahead of them
+type LeaderboardStanding struct {
+	Rank  int // 1-indexed
+	Score int
+	Total int // Entries on the leaderboard
+
+	AheadID    string // Empty for the leader
+	AheadScore int
+	Tied       bool // The entry ahead or behind has the same score
+}
+
+// The ZSET score packs points, completion time and join order into the 53-bit
+// integer range of a double, so ZREVRANGE/ZREVRANK order ties deterministically:
+// points * 2^33 + (maxElapsed - seconds) * 2^15 + (maxJoinOrder - joinOrder)
+const (
+	lbPointsUnit   = 1 << 33
+	lbElapsedUnit  = 1 << 15
+	lbMaxElapsed   = 1<<18 - 1 // ~72h in seconds
+	lbMaxJoinOrder = 1<<15 - 1
+	lbMaxPoints    = 1<<20 - 1
+)
+
+// decodeLeaderboardScore splits a composite ZSET score into its parts
+func decodeLeaderboardScore(v float64) (points int, completionSec int64, joinOrder int) {
+	p := math.Floor(v / lbPointsUnit)
+	rest := int64(v - p*lbPointsUnit)
+	return int(p), lbMaxElapsed - rest/lbElapsedUnit, int(lbMaxJoinOrder - rest%lbElapsedUnit)
 }
 
 type leaderboardCache struct {
@@ -37,28 +85,79 @@ func (c *leaderboardCache) key(roomCode string) string {
 	return fmt.Sprintf("room:%s:lb", roomCode)
 }
 
-func (c *leaderboardCache) UpdateScore(ctx context.Context, roomCode, playerID string, score int) error {
-	return c.client.ZAdd(ctx, c.key(roomCode), redis.Z{
-		Score:  float64(score),
-		Member: playerID,
-	}).Err()
+// joinKey maps each player to their join order; "#next" holds the counter
+func (c *leaderboardCache) joinKey(roomCode string) string {
+	return fmt.Sprintf("room:%s:lb:join", roomCode)
+}
+
+func (c *leaderboardCache) frozenKey(roomCode string) string {
+	return fmt.Sprintf("room:%s:lb:frozen", roomCode)
+}
+
+// updateScoreScript skips the ZADD when the leaderboard is frozen, so late
+// evaluations cannot reorder a final leaderboard. A player's join order is
+// assigned on their first update and kept for the life of the room.
+var updateScoreScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[2]) == 1 then
+	return 0
+end
+local order = redis.call("HGET", KEYS[3], ARGV[2])
+if not order then
+	order = redis.call("HINCRBY", KEYS[3], "#next", 1)
+	redis.call("HSET", KEYS[3], ARGV[2], order)
+end
+order = math.min(tonumber(order), tonumber(ARGV[6]))
+local points = math.max(math.min(tonumber(ARGV[1]), tonumber(ARGV[5])), -tonumber(ARGV[5]))
+local elapsed = math.max(math.min(tonumber(ARGV[3]), tonumber(ARGV[4])), 0)
+local score = points * 2^33 + (tonumber(ARGV[4]) - elapsed) * 2^15 + (tonumber(ARGV[6]) - order)
+return redis.call("ZADD", KEYS[1], string.format("%.0f", score), ARGV[2])
+`)
+
+func (c *leaderboardCache) UpdateScore(ctx context.Context, roomCode, playerID string, score int, completion time.Duration) error {
+	keys := []string{c.key(roomCode), c.frozenKey(roomCode), c.joinKey(roomCode)}
+	args := []interface{}{score, playerID, int64(completion / time.Second), lbMaxElapsed, lbMaxPoints, lbMaxJoinOrder}
+	return updateScoreScript.Run(ctx, c.client, keys, args...).Err()
+}
+
+func (c *leaderboardCache) Freeze(ctx context.Context, roomCode string) error {
+	return c.client.Set(ctx, c.frozenKey(roomCode), "1", 24*time.Hour).Err()
+}
+
+func (c *leaderboardCache) Unfreeze(ctx context.Context, roomCode string) error {
+	return c.client.Del(ctx, c.frozenKey(roomCode)).Err()
 }
 
 func (c *leaderboardCache) GetTop(ctx context.Context, roomCode string, limit int) ([]LeaderboardEntry, error) {
-	results, err := c.client.ZRevRangeWithScores(ctx, c.key(roomCode), 0, int64(limit-1)).Result()
+	// One extra entry tells whether the last one shares its score with the next
+	results, err := c.client.ZRevRangeWithScores(ctx, c.key(roomCode), 0, int64(limit)).Result()
 	if err != nil {
 		return nil, err
 	}
+	return leaderboardEntries(results, limit), nil
+}
 
+// leaderboardEntries decodes ranked composite scores, highest first, into at
+// most limit entries; one extra result marks a tie on the last entry
+func leaderboardEntries(results []redis.Z, limit int) []LeaderboardEntry {
 	entries := make([]LeaderboardEntry, len(results))
 	for i, z := range results {
+		points, completionSec, joinOrder := decodeLeaderboardScore(z.Score)
 		entries[i] = LeaderboardEntry{
-			PlayerID: z.Member.(string),
-			Score:    int(z.Score),
-			Rank:     i + 1,
+			PlayerID:      z.Member.(string),
+			Score:         points,
+			Rank:          i + 1,
+			CompletionSec: completionSec,
+			JoinOrder:     joinOrder,
 		}
+		if i > 0 && entries[i-1].Score == points {
+			entries[i-1].Tied = true
+			entries[i].Tied = true
+		}
+	}
+	if len(entries) > limit {
+		entries = entries[:limit]
 	}
-	return entries, nil
+	return entries
 }
 
 func (c *leaderboardCache) GetRank(ctx context.Context, roomCode, playerID string) (int64, error) {
@@ -68,3 +167,69 @@ func (c *leaderboardCache) GetRank(ctx context.Context, roomCode, playerID strin
 	}
 	return rank + 1, err // 1-indexed
 }
+
+// standingScript reads a player's rank, score, the leaderboard size and the
+// entries directly ahead and behind in one round trip; nil when the player has
+// no entry. Scores are the composite values, returned as strings to keep precision.
+var standingScript = redis.NewScript(`
+local rank = redis.call("ZREVRANK", KEYS[1], ARGV[1])
+if not rank then
+	return false
+end
+local score = redis.call("ZSCORE", KEYS[1], ARGV[1])
+local total = redis.call("ZCARD", KEYS[1])
+local ahead = {}
+if rank > 0 then
+	ahead = redis.call("ZREVRANGE", KEYS[1], rank - 1, rank - 1, "WITHSCORES")
+end
+local behind = redis.call("ZREVRANGE", KEYS[1], rank + 1, rank + 1, "WITHSCORES")
+return {rank, score, total, ahead[1] or "", ahead[2] or "", behind[2] or ""}
+`)
+
+func (c *leaderboardCache) GetStanding(ctx context.Context, roomCode, playerID string) (*LeaderboardStanding, error) {
+	res, err := standingScript.Run(ctx, c.client, []string{c.key(roomCode)}, playerID).Slice()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(res) != 6 {
+		return nil, fmt.Errorf("unexpected standing reply: %v", res)
+	}
+
+	// points parses a composite score string; ok is false for a missing neighbour
+	points := func(v interface{}) (int, bool) {
+		s, _ := v.(string)
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, false
+		}
+		p, _, _ := decodeLeaderboardScore(f)
+		return p, true
+	}
+
+	standing := &LeaderboardStanding{}
+	rank, _ := res[0].(int64)
+	total, _ := res[2].(int64)
+	standing.Rank = int(rank) + 1
+	standing.Score, _ = points(res[1])
+	standing.Total = int(total)
+	standing.AheadID, _ = res[3].(string)
+	if aheadScore, ok := points(res[4]); ok {
+		standing.AheadScore = aheadScore
+		standing.Tied = aheadScore == standing.Score
+	}
+	if behindScore, ok := points(res[5]); ok && behindScore == standing.Score {
+		standing.Tied = true
+	}
+	return standing, nil
+}
+
+func (c *leaderboardCache) Remove(ctx context.Context, roomCode, playerID string) error {
+	pipe := c.client.TxPipeline()
+	pipe.ZRem(ctx, c.key(roomCode), playerID)
+	pipe.HDel(ctx, c.joinKey(roomCode), playerID)
+	_, err := pipe.Exec(ctx)
+	return err
+}
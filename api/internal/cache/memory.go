@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// The memory caches keep their state in this process instead of Redis, for
+// demo and development deployments without Redis (CACHE_BACKEND=memory).
+// Nothing is shared between instances or survives a restart, so only a single
+// instance may serve a deployment that uses them. Values are kept JSON
+// encoded, as in Redis, so callers never share a value with the cache.
+
+// memoryTTL matches the 24h expiry of the Redis room keys
+const memoryTTL = 24 * time.Hour
+
+// memorySweepInterval is how often writes drop expired rooms
+const memorySweepInterval = time.Hour
+
+// memoryRooms holds one memory cache's state per room (or per survey, host or
+// other key of the cache). A room's state expires ttl after its last write,
+// rather than key by key as in Redis. Callers hold mu around get, touch and drop.
+type memoryRooms[T any] struct {
+	mu        sync.Mutex
+	rooms     map[string]*memoryRoom[T]
+	newState  func() *T
+	ttl       time.Duration
+	lastSweep time.Time
+}
+
+type memoryRoom[T any] struct {
+	state   *T
+	expires time.Time
+}
+
+func newMemoryRooms[T any](newState func() *T) *memoryRooms[T] {
+	return newMemoryRoomsTTL(memoryTTL, newState)
+}
+
+// newMemoryRoomsTTL is newMemoryRooms for caches whose Redis keys live longer
+// or shorter than a day
+func newMemoryRoomsTTL[T any](ttl time.Duration, newState func() *T) *memoryRooms[T] {
+	return &memoryRooms[T]{
+		rooms:     make(map[string]*memoryRoom[T]),
+		newState:  newState,
+		ttl:       ttl,
+		lastSweep: time.Now(),
+	}
+}
+
+// get returns the room's state; nil when it has none or it expired
+func (m *memoryRooms[T]) get(code string) *T {
+	room, ok := m.rooms[code]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(room.expires) {
+		delete(m.rooms, code)
+		return nil
+	}
+	return room.state
+}
+
+// touch returns the room's state for a write, creating it if needed, and
+// pushes back its expiry
+func (m *memoryRooms[T]) touch(code string) *T {
+	now := time.Now()
+	if now.Sub(m.lastSweep) > memorySweepInterval {
+		for c, room := range m.rooms {
+			if now.After(room.expires) {
+				delete(m.rooms, c)
+			}
+		}
+		m.lastSweep = now
+	}
+
+	state := m.get(code)
+	if state == nil {
+		state = m.newState()
+		m.rooms[code] = &memoryRoom[T]{state: state}
+	}
+	m.rooms[code].expires = now.Add(m.ttl)
+	return state
+}
+
+func (m *memoryRooms[T]) drop(code string) {
+	delete(m.rooms, code)
+}
+
+// codes lists the rooms that have unexpired state
+func (m *memoryRooms[T]) codes() []string {
+	codes := make([]string, 0, len(m.rooms))
+	for code := range m.rooms {
+		if m.get(code) != nil {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+// decodeJSON decodes data into a new T; nil when data is nil
+func decodeJSON[T any](data []byte) (*T, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
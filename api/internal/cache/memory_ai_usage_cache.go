@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"time"
+)
+
+type memoryAIUsageCache struct {
+	rooms  *memoryRooms[model.AIUsage]
+	global *memoryRooms[model.AIUsage] // By UTC day
+	hosts  *memoryRooms[model.AIUsage] // By "<hostID>:<UTC month>"
+}
+
+// NewMemoryAIUsageCache creates an AI usage cache held in this process (single instance only)
+func NewMemoryAIUsageCache() AIUsageCache {
+	newUsage := func() *model.AIUsage { return &model.AIUsage{Models: map[string]model.ModelUsage{}} }
+	return &memoryAIUsageCache{
+		rooms:  newMemoryRooms(newUsage),
+		global: newMemoryRoomsTTL(2*memoryTTL, newUsage),
+		hosts:  newMemoryRoomsTTL(32*memoryTTL, newUsage),
+	}
+}
+
+func (c *memoryAIUsageCache) globalKey() string {
+	return time.Now().UTC().Format("2006-01-02")
+}
+
+func (c *memoryAIUsageCache) hostKey(hostID string, month time.Time) string {
+	return hostID + ":" + month.UTC().Format("2006-01")
+}
+
+func (c *memoryAIUsageCache) GetRoomUsage(ctx context.Context, roomCode string) (*model.AIUsage, error) {
+	return c.get(c.rooms, roomCode), nil
+}
+
+func (c *memoryAIUsageCache) AddRoomUsage(ctx context.Context, roomCode, modelName string, inputTokens, outputTokens int) error {
+	c.add(c.rooms, roomCode, modelName, inputTokens, outputTokens)
+	return nil
+}
+
+func (c *memoryAIUsageCache) GetGlobalUsage(ctx context.Context) (*model.AIUsage, error) {
+	return c.get(c.global, c.globalKey()), nil
+}
+
+func (c *memoryAIUsageCache) AddGlobalUsage(ctx context.Context, modelName string, inputTokens, outputTokens int) error {
+	c.add(c.global, c.globalKey(), modelName, inputTokens, outputTokens)
+	return nil
+}
+
+func (c *memoryAIUsageCache) GetHostUsage(ctx context.Context, hostID string, month time.Time) (*model.AIUsage, error) {
+	return c.get(c.hosts, c.hostKey(hostID, month)), nil
+}
+
+func (c *memoryAIUsageCache) AddHostUsage(ctx context.Context, hostID, modelName string, inputTokens, outputTokens int) error {
+	c.add(c.hosts, c.hostKey(hostID, time.Now()), modelName, inputTokens, outputTokens)
+	return nil
+}
+
+// get returns a copy of the bucket's usage; empty, as in Redis, when there is none
+func (c *memoryAIUsageCache) get(buckets *memoryRooms[model.AIUsage], key string) *model.AIUsage {
+	buckets.mu.Lock()
+	defer buckets.mu.Unlock()
+	usage := &model.AIUsage{Models: map[string]model.ModelUsage{}}
+	if stored := buckets.get(key); stored != nil {
+		*usage = *stored
+		usage.Models = make(map[string]model.ModelUsage, len(stored.Models))
+		for name, mu := range stored.Models {
+			usage.Models[name] = mu
+		}
+	}
+	return usage
+}
+
+func (c *memoryAIUsageCache) add(buckets *memoryRooms[model.AIUsage], key, modelName string, inputTokens, outputTokens int) {
+	buckets.mu.Lock()
+	defer buckets.mu.Unlock()
+	usage := buckets.touch(key)
+	usage.Calls++
+	usage.EstimatedTokens += int64(inputTokens + outputTokens)
+	usage.InputTokens += int64(inputTokens)
+	usage.OutputTokens += int64(outputTokens)
+	if modelName != "" {
+		mu := usage.Models[modelName]
+		mu.Calls++
+		mu.InputTokens += int64(inputTokens)
+		mu.OutputTokens += int64(outputTokens)
+		usage.Models[modelName] = mu
+	}
+}
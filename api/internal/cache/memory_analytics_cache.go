@@ -0,0 +1,223 @@
+package cache
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// memoryAnalyticsRoom is one room's L2-L4 analytics; values are JSON as in Redis
+type memoryAnalyticsRoom struct {
+	players       map[string][]byte  // Player profiles by player ID
+	questions     map[string][]byte  // Question profiles by question key
+	responseTimes map[string][]int64 // Newest first, by question key
+	rated         map[string]bool    // "<playerID>:<questionKey>"
+	relevance     map[string]int     // Counters as in RateFollowUp
+	memory        []byte
+}
+
+type memoryAnalyticsCache struct {
+	rooms *memoryRooms[memoryAnalyticsRoom]
+}
+
+// NewMemoryAnalyticsCache creates an analytics cache held in this process (single instance only)
+func NewMemoryAnalyticsCache() AnalyticsCache {
+	return &memoryAnalyticsCache{
+		rooms: newMemoryRooms(func() *memoryAnalyticsRoom {
+			return &memoryAnalyticsRoom{
+				players:       make(map[string][]byte),
+				questions:     make(map[string][]byte),
+				responseTimes: make(map[string][]int64),
+				rated:         make(map[string]bool),
+				relevance:     make(map[string]int),
+			}
+		}),
+	}
+}
+
+// read returns the value fn picks from the room under the lock; nil when the room has no state
+func (c *memoryAnalyticsCache) read(roomCode string, fn func(room *memoryAnalyticsRoom) []byte) []byte {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	room := c.rooms.get(roomCode)
+	if room == nil {
+		return nil
+	}
+	return fn(room)
+}
+
+// L2: Player Profile
+func (c *memoryAnalyticsCache) DeletePlayerProfile(ctx context.Context, roomCode, playerID string) error {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	if room := c.rooms.get(roomCode); room != nil {
+		delete(room.players, playerID)
+	}
+	return nil
+}
+
+func (c *memoryAnalyticsCache) GetPlayerProfile(ctx context.Context, roomCode, playerID string) (*model.PlayerProfile, error) {
+	return decodeJSON[model.PlayerProfile](c.read(roomCode, func(room *memoryAnalyticsRoom) []byte {
+		return room.players[playerID]
+	}))
+}
+
+func (c *memoryAnalyticsCache) SetPlayerProfile(ctx context.Context, profile *model.PlayerProfile) error {
+	profile.UpdatedAt = time.Now()
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	c.rooms.touch(profile.RoomCode).players[profile.PlayerID] = data
+	return nil
+}
+
+// L3: Question Profile
+func (c *memoryAnalyticsCache) GetQuestionProfile(ctx context.Context, roomCode, questionKey string) (*model.QuestionProfile, error) {
+	return decodeJSON[model.QuestionProfile](c.read(roomCode, func(room *memoryAnalyticsRoom) []byte {
+		return room.questions[questionKey]
+	}))
+}
+
+func (c *memoryAnalyticsCache) SetQuestionProfile(ctx context.Context, profile *model.QuestionProfile) error {
+	profile.UpdatedAt = time.Now()
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	c.rooms.touch(profile.RoomCode).questions[profile.QuestionKey] = data
+	return nil
+}
+
+// IncrementQuestionStats reads and writes the profile under the lock, so concurrent increments are not lost
+func (c *memoryAnalyticsCache) IncrementQuestionStats(ctx context.Context, roomCode, questionKey string, sat, unsat, skip int) error {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	room := c.rooms.touch(roomCode)
+	profile, err := decodeJSON[model.QuestionProfile](room.questions[questionKey])
+	if err != nil {
+		return err
+	}
+	profile = addQuestionStats(profile, roomCode, questionKey, sat, unsat, skip)
+	profile.UpdatedAt = time.Now()
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+	room.questions[questionKey] = data
+	return nil
+}
+
+// AddResponseTime records one response time and returns the kept samples
+func (c *memoryAnalyticsCache) AddResponseTime(ctx context.Context, roomCode, questionKey string, ms int64) ([]int64, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	room := c.rooms.touch(roomCode)
+	samples := append([]int64{ms}, room.responseTimes[questionKey]...)
+	if len(samples) > MaxResponseSamples {
+		samples = samples[:MaxResponseSamples]
+	}
+	room.responseTimes[questionKey] = samples
+	return append([]int64(nil), samples...), nil
+}
+
+// RateFollowUp records one rating per player and question
+func (c *memoryAnalyticsCache) RateFollowUp(ctx context.Context, roomCode, playerID, questionKey, source, hint string, relevant bool) (bool, error) {
+	vote := "down"
+	if relevant {
+		vote = "up"
+	}
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	room := c.rooms.touch(roomCode)
+	if room.rated[playerID+":"+questionKey] {
+		return false, nil
+	}
+	room.rated[playerID+":"+questionKey] = true
+	room.relevance["source:"+source+":"+vote]++
+	room.relevance["hint:"+hint+":"+vote]++
+	return true, nil
+}
+
+// GetFollowUpRelevance totals the room's ratings; nil when there are none
+func (c *memoryAnalyticsCache) GetFollowUpRelevance(ctx context.Context, roomCode string) (*model.FollowUpRelevance, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	room := c.rooms.get(roomCode)
+	if room == nil || len(room.relevance) == 0 {
+		return nil, nil
+	}
+	return followUpRelevance(room.relevance), nil
+}
+
+// L4: Room Memory
+func (c *memoryAnalyticsCache) GetRoomMemory(ctx context.Context, roomCode string) (*model.RoomMemory, error) {
+	return decodeJSON[model.RoomMemory](c.read(roomCode, func(room *memoryAnalyticsRoom) []byte {
+		return room.memory
+	}))
+}
+
+func (c *memoryAnalyticsCache) SetRoomMemory(ctx context.Context, memory *model.RoomMemory) error {
+	memory.UpdatedAt = time.Now()
+	data, err := json.Marshal(memory)
+	if err != nil {
+		return err
+	}
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	c.rooms.touch(memory.RoomCode).memory = data
+	return nil
+}
+
+// Archival enumeration
+func (c *memoryAnalyticsCache) ListPlayerProfiles(ctx context.Context, roomCode string) ([]*model.PlayerProfile, error) {
+	return listMemoryJSON[model.PlayerProfile](c, roomCode, func(room *memoryAnalyticsRoom) map[string][]byte {
+		return room.players
+	})
+}
+
+func (c *memoryAnalyticsCache) ListQuestionProfiles(ctx context.Context, roomCode string) ([]*model.QuestionProfile, error) {
+	return listMemoryJSON[model.QuestionProfile](c, roomCode, func(room *memoryAnalyticsRoom) map[string][]byte {
+		return room.questions
+	})
+}
+
+// ListRoomCodes returns every room that currently has room memory
+func (c *memoryAnalyticsCache) ListRoomCodes(ctx context.Context) ([]string, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	codes := []string{}
+	for _, code := range c.rooms.codes() {
+		if c.rooms.get(code).memory != nil {
+			codes = append(codes, code)
+		}
+	}
+	return codes, nil
+}
+
+// listMemoryJSON decodes every value of the room map that pick returns
+func listMemoryJSON[T any](c *memoryAnalyticsCache, roomCode string, pick func(room *memoryAnalyticsRoom) map[string][]byte) ([]*T, error) {
+	c.rooms.mu.Lock()
+	var values [][]byte
+	if room := c.rooms.get(roomCode); room != nil {
+		for _, data := range pick(room) {
+			values = append(values, data)
+		}
+	}
+	c.rooms.mu.Unlock()
+
+	out := []*T{}
+	for _, data := range values {
+		v, err := decodeJSON[T](data)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
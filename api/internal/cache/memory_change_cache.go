@@ -0,0 +1,27 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+type memoryChangeCache struct {
+	claimed *memoryRooms[struct{}] // By change ID
+}
+
+// NewMemoryChangeCache creates a change cache held in this process (single instance only)
+func NewMemoryChangeCache() ChangeCache {
+	return &memoryChangeCache{
+		claimed: newMemoryRoomsTTL(10*time.Minute, func() *struct{} { return &struct{}{} }),
+	}
+}
+
+func (c *memoryChangeCache) Claim(ctx context.Context, changeID string) (bool, error) {
+	c.claimed.mu.Lock()
+	defer c.claimed.mu.Unlock()
+	if c.claimed.get(changeID) != nil {
+		return false, nil
+	}
+	c.claimed.touch(changeID)
+	return true, nil
+}
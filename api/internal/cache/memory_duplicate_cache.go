@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+type memoryDuplicateCache struct {
+	questions *memoryRooms[[]AnswerFingerprint] // Newest first, by "<roomCode>:<questionKey>"
+	window    int
+}
+
+// NewMemoryDuplicateCache creates a duplicate cache held in this process (single instance only)
+func NewMemoryDuplicateCache() DuplicateCache {
+	return &memoryDuplicateCache{
+		questions: newMemoryRoomsTTL(48*time.Hour, func() *[]AnswerFingerprint { return new([]AnswerFingerprint) }),
+		window:    200,
+	}
+}
+
+func (c *memoryDuplicateCache) key(roomCode, questionKey string) string {
+	return roomCode + ":" + questionKey
+}
+
+func (c *memoryDuplicateCache) Recent(ctx context.Context, roomCode, questionKey string) ([]AnswerFingerprint, error) {
+	c.questions.mu.Lock()
+	defer c.questions.mu.Unlock()
+	out := []AnswerFingerprint{}
+	if recent := c.questions.get(c.key(roomCode, questionKey)); recent != nil {
+		out = append(out, *recent...)
+	}
+	return out, nil
+}
+
+func (c *memoryDuplicateCache) Add(ctx context.Context, roomCode, questionKey string, fp AnswerFingerprint) error {
+	c.questions.mu.Lock()
+	defer c.questions.mu.Unlock()
+	recent := c.questions.touch(c.key(roomCode, questionKey))
+	*recent = append([]AnswerFingerprint{fp}, *recent...)
+	if len(*recent) > c.window {
+		*recent = (*recent)[:c.window]
+	}
+	return nil
+}
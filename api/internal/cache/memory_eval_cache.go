@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"time"
+)
+
+type memoryEvalCache struct {
+	questions *memoryRooms[map[string][]byte] // evalEntry JSON by normalized text, by "<roomCode>:<questionKey>"
+	ttl       time.Duration
+}
+
+// NewMemoryEvalCache creates an evaluation cache held in this process (single instance only)
+func NewMemoryEvalCache(ttl time.Duration) EvalCache {
+	return &memoryEvalCache{
+		questions: newMemoryRoomsTTL(ttl, func() *map[string][]byte {
+			results := make(map[string][]byte)
+			return &results
+		}),
+		ttl: ttl,
+	}
+}
+
+func (c *memoryEvalCache) key(roomCode, questionKey string) string {
+	return roomCode + ":" + questionKey
+}
+
+func (c *memoryEvalCache) Get(ctx context.Context, roomCode, questionKey, normalized string) (*model.EvaluationResult, error) {
+	c.questions.mu.Lock()
+	var data []byte
+	if results := c.questions.get(c.key(roomCode, questionKey)); results != nil {
+		data = (*results)[normalized]
+	}
+	c.questions.mu.Unlock()
+	if data == nil {
+		return nil, nil
+	}
+	return decodeEvalEntry(data, c.ttl), nil
+}
+
+func (c *memoryEvalCache) GetAll(ctx context.Context, roomCode, questionKey string) (map[string]*model.EvaluationResult, error) {
+	c.questions.mu.Lock()
+	defer c.questions.mu.Unlock()
+	results := make(map[string]*model.EvaluationResult)
+	if entries := c.questions.get(c.key(roomCode, questionKey)); entries != nil {
+		for text, data := range *entries {
+			if r := decodeEvalEntry(data, c.ttl); r != nil {
+				results[text] = r
+			} else {
+				delete(*entries, text)
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *memoryEvalCache) Set(ctx context.Context, roomCode, questionKey, normalized string, result *model.EvaluationResult) error {
+	data, err := encodeEvalEntry(result)
+	if err != nil {
+		return err
+	}
+	c.questions.mu.Lock()
+	defer c.questions.mu.Unlock()
+	(*c.questions.touch(c.key(roomCode, questionKey)))[normalized] = data
+	return nil
+}
+
+func (c *memoryEvalCache) Delete(ctx context.Context, roomCode, questionKey, normalized string) error {
+	c.questions.mu.Lock()
+	defer c.questions.mu.Unlock()
+	if results := c.questions.get(c.key(roomCode, questionKey)); results != nil {
+		delete(*results, normalized)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+type memoryEventCache struct {
+	rooms *memoryRooms[int64] // Last sequence number handed out
+}
+
+// NewMemoryEventCache creates an event cache held in this process (single instance only)
+func NewMemoryEventCache() EventCache {
+	return &memoryEventCache{
+		rooms: newMemoryRoomsTTL(48*time.Hour, func() *int64 { return new(int64) }),
+	}
+}
+
+func (c *memoryEventCache) NextSeq(ctx context.Context, roomCode string) (int64, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	seq := c.rooms.touch(roomCode)
+	*seq++
+	return *seq, nil
+}
@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// memoryLeaderboard is one room's leaderboard, scored like the Redis ZSET
+type memoryLeaderboard struct {
+	scores   map[string]float64 // Composite scores by player
+	join     map[string]int     // Join order by player
+	nextJoin int
+	frozen   bool
+}
+
+type memoryLeaderboardCache struct {
+	rooms *memoryRooms[memoryLeaderboard]
+}
+
+// NewMemoryLeaderboardCache creates a leaderboard held in this process (single instance only)
+func NewMemoryLeaderboardCache() LeaderboardCache {
+	return &memoryLeaderboardCache{
+		rooms: newMemoryRooms(func() *memoryLeaderboard {
+			return &memoryLeaderboard{scores: make(map[string]float64), join: make(map[string]int)}
+		}),
+	}
+}
+
+// encodeLeaderboardScore packs points, completion time and join order as updateScoreScript does
+func encodeLeaderboardScore(points int, completionSec int64, joinOrder int) float64 {
+	points = max(min(points, lbMaxPoints), -lbMaxPoints)
+	completionSec = max(min(completionSec, lbMaxElapsed), 0)
+	joinOrder = min(joinOrder, lbMaxJoinOrder)
+	return float64(points)*lbPointsUnit + float64(lbMaxElapsed-completionSec)*lbElapsedUnit + float64(lbMaxJoinOrder-joinOrder)
+}
+
+func (c *memoryLeaderboardCache) UpdateScore(ctx context.Context, roomCode, playerID string, score int, completion time.Duration) error {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	lb := c.rooms.touch(roomCode)
+	if lb.frozen {
+		return nil
+	}
+	order, ok := lb.join[playerID]
+	if !ok {
+		lb.nextJoin++
+		order = lb.nextJoin
+		lb.join[playerID] = order
+	}
+	lb.scores[playerID] = encodeLeaderboardScore(score, int64(completion/time.Second), order)
+	return nil
+}
+
+func (c *memoryLeaderboardCache) Freeze(ctx context.Context, roomCode string) error {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	c.rooms.touch(roomCode).frozen = true
+	return nil
+}
+
+func (c *memoryLeaderboardCache) Unfreeze(ctx context.Context, roomCode string) error {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	if lb := c.rooms.get(roomCode); lb != nil {
+		lb.frozen = false
+	}
+	return nil
+}
+
+// ranked returns the room's entries highest first, ordered like ZREVRANGE
+func (c *memoryLeaderboardCache) ranked(roomCode string) []redis.Z {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	lb := c.rooms.get(roomCode)
+	if lb == nil {
+		return nil
+	}
+	results := make([]redis.Z, 0, len(lb.scores))
+	for id, score := range lb.scores {
+		results = append(results, redis.Z{Score: score, Member: id})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Member.(string) > results[j].Member.(string)
+	})
+	return results
+}
+
+func (c *memoryLeaderboardCache) GetTop(ctx context.Context, roomCode string, limit int) ([]LeaderboardEntry, error) {
+	results := c.ranked(roomCode)
+	if len(results) > limit+1 {
+		results = results[:limit+1]
+	}
+	return leaderboardEntries(results, limit), nil
+}
+
+func (c *memoryLeaderboardCache) GetRank(ctx context.Context, roomCode, playerID string) (int64, error) {
+	for i, z := range c.ranked(roomCode) {
+		if z.Member == playerID {
+			return int64(i) + 1, nil
+		}
+	}
+	return -1, nil
+}
+
+func (c *memoryLeaderboardCache) GetStanding(ctx context.Context, roomCode, playerID string) (*LeaderboardStanding, error) {
+	results := c.ranked(roomCode)
+	for i, z := range results {
+		if z.Member != playerID {
+			continue
+		}
+		standing := &LeaderboardStanding{Rank: i + 1, Total: len(results)}
+		standing.Score, _, _ = decodeLeaderboardScore(z.Score)
+		if i > 0 {
+			ahead := results[i-1]
+			standing.AheadID = ahead.Member.(string)
+			standing.AheadScore, _, _ = decodeLeaderboardScore(ahead.Score)
+			standing.Tied = standing.AheadScore == standing.Score
+		}
+		if i+1 < len(results) {
+			if behind, _, _ := decodeLeaderboardScore(results[i+1].Score); behind == standing.Score {
+				standing.Tied = true
+			}
+		}
+		return standing, nil
+	}
+	return nil, nil
+}
+
+func (c *memoryLeaderboardCache) Remove(ctx context.Context, roomCode, playerID string) error {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	if lb := c.rooms.get(roomCode); lb != nil {
+		delete(lb.scores, playerID)
+		delete(lb.join, playerID)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"encoding/json"
+)
+
+type memoryMetaFeedbackCache struct {
+	rooms *memoryRooms[map[string][]byte] // Answers by player ID
+}
+
+// NewMemoryMetaFeedbackCache creates a meta-feedback cache held in this process (single instance only)
+func NewMemoryMetaFeedbackCache() MetaFeedbackCache {
+	return &memoryMetaFeedbackCache{
+		rooms: newMemoryRooms(func() *map[string][]byte {
+			answers := make(map[string][]byte)
+			return &answers
+		}),
+	}
+}
+
+func (c *memoryMetaFeedbackCache) Save(ctx context.Context, roomCode, playerID string, answers *model.MetaFeedbackRequest) (bool, error) {
+	data, err := json.Marshal(answers)
+	if err != nil {
+		return false, err
+	}
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	players := *c.rooms.touch(roomCode)
+	if _, ok := players[playerID]; ok {
+		return false, nil
+	}
+	players[playerID] = data
+	return true, nil
+}
+
+func (c *memoryMetaFeedbackCache) Has(ctx context.Context, roomCode, playerID string) (bool, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	if players := c.rooms.get(roomCode); players != nil {
+		_, ok := (*players)[playerID]
+		return ok, nil
+	}
+	return false, nil
+}
+
+func (c *memoryMetaFeedbackCache) GetAll(ctx context.Context, roomCode string) ([]model.MetaFeedbackRequest, error) {
+	c.rooms.mu.Lock()
+	var raw [][]byte
+	if players := c.rooms.get(roomCode); players != nil {
+		for _, data := range *players {
+			raw = append(raw, data)
+		}
+	}
+	c.rooms.mu.Unlock()
+
+	out := make([]model.MetaFeedbackRequest, 0, len(raw))
+	for _, data := range raw {
+		var answers model.MetaFeedbackRequest
+		if err := json.Unmarshal(data, &answers); err == nil {
+			out = append(out, answers)
+		}
+	}
+	return out, nil
+}
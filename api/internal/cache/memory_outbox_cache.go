@@ -0,0 +1,207 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// memoryOutboxRoom is one room's outbox stream and consumer acknowledgements
+type memoryOutboxRoom struct {
+	entries []OutboxEntry // Oldest first
+	last    streamID
+	acks    map[string]string
+}
+
+type memoryOutboxCache struct {
+	rooms  *memoryRooms[memoryOutboxRoom]
+	maxLen int
+
+	// appended is closed and replaced on every Append to wake blocked Reads; guarded by rooms.mu
+	appended chan struct{}
+}
+
+// NewMemoryOutboxCache creates an outbox held in this process (single instance only).
+// Entry IDs have the form of Redis stream IDs, so clients resume the same way.
+func NewMemoryOutboxCache() OutboxCache {
+	return &memoryOutboxCache{
+		rooms: newMemoryRoomsTTL(48*time.Hour, func() *memoryOutboxRoom {
+			return &memoryOutboxRoom{acks: make(map[string]string)}
+		}),
+		maxLen:   1000,
+		appended: make(chan struct{}),
+	}
+}
+
+// streamID is a parsed "<ms>-<seq>" stream ID
+type streamID struct {
+	ms, seq int64
+}
+
+// parseStreamID also accepts "<ms>", "-" (the lowest ID) and "+" (the highest)
+func parseStreamID(id string) (streamID, error) {
+	switch id {
+	case "-":
+		return streamID{}, nil
+	case "+":
+		return streamID{ms: 1<<63 - 1, seq: 1<<63 - 1}, nil
+	}
+	msPart, seqPart, hasSeq := strings.Cut(id, "-")
+	ms, err := strconv.ParseInt(msPart, 10, 64)
+	if err != nil {
+		return streamID{}, fmt.Errorf("invalid stream ID %q", id)
+	}
+	var seq int64
+	if hasSeq {
+		if seq, err = strconv.ParseInt(seqPart, 10, 64); err != nil {
+			return streamID{}, fmt.Errorf("invalid stream ID %q", id)
+		}
+	}
+	return streamID{ms: ms, seq: seq}, nil
+}
+
+func (id streamID) before(other streamID) bool {
+	return id.ms < other.ms || (id.ms == other.ms && id.seq < other.seq)
+}
+
+func (id streamID) String() string {
+	return fmt.Sprintf("%d-%d", id.ms, id.seq)
+}
+
+// Append trims the stream to maxLen entries; the TTL is refreshed on every call
+func (c *memoryOutboxCache) Append(ctx context.Context, roomCode string, entry *OutboxEntry) (string, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	room := c.rooms.touch(roomCode)
+
+	// Like XADD: the current time in ms, with a sequence for entries in the same ms
+	id := streamID{ms: time.Now().UnixMilli()}
+	if !room.last.before(id) {
+		id = streamID{ms: room.last.ms, seq: room.last.seq + 1}
+	}
+	room.last = id
+	entry.ID = id.String()
+
+	stored := *entry
+	stored.Payload = bytes.Clone(entry.Payload)
+	room.entries = append(room.entries, stored)
+	if len(room.entries) > c.maxLen {
+		room.entries = append([]OutboxEntry(nil), room.entries[len(room.entries)-c.maxLen:]...)
+	}
+
+	close(c.appended)
+	c.appended = make(chan struct{})
+	return entry.ID, nil
+}
+
+func (c *memoryOutboxCache) Range(ctx context.Context, roomCode, afterID, toID string, count int64) ([]OutboxEntry, error) {
+	after, err := parseStreamID(afterID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseStreamID(toID)
+	if err != nil {
+		return nil, err
+	}
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	return c.entriesAfter(roomCode, after, to, count), nil
+}
+
+// entriesAfter copies up to count entries in (after, to]; callers hold rooms.mu
+func (c *memoryOutboxCache) entriesAfter(roomCode string, after, to streamID, count int64) []OutboxEntry {
+	out := []OutboxEntry{}
+	room := c.rooms.get(roomCode)
+	if room == nil {
+		return out
+	}
+	for _, entry := range room.entries {
+		if count > 0 && int64(len(out)) >= count {
+			break
+		}
+		id, _ := parseStreamID(entry.ID)
+		if !after.before(id) {
+			continue
+		}
+		if to.before(id) {
+			break
+		}
+		entry.Payload = bytes.Clone(entry.Payload)
+		out = append(out, entry)
+	}
+	return out
+}
+
+// Read waits for an Append until block passes; a block of 0 waits until ctx is done
+func (c *memoryOutboxCache) Read(ctx context.Context, after map[string]string, count int64, block time.Duration) (map[string][]OutboxEntry, error) {
+	out := make(map[string][]OutboxEntry)
+	if len(after) == 0 {
+		return out, nil
+	}
+	heads := make(map[string]streamID, len(after))
+	for roomCode, id := range after {
+		head, err := parseStreamID(id)
+		if err != nil {
+			return nil, err
+		}
+		heads[roomCode] = head
+	}
+	latest, _ := parseStreamID("+")
+
+	var timeout <-chan time.Time
+	if block > 0 {
+		timer := time.NewTimer(block)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	for {
+		c.rooms.mu.Lock()
+		for roomCode, head := range heads {
+			if list := c.entriesAfter(roomCode, head, latest, count); len(list) > 0 {
+				out[roomCode] = list
+			}
+		}
+		appended := c.appended
+		c.rooms.mu.Unlock()
+
+		if len(out) > 0 || block < 0 {
+			return out, nil
+		}
+		select {
+		case <-appended:
+		case <-timeout:
+			return out, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (c *memoryOutboxCache) Bounds(ctx context.Context, roomCode string) (string, string, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	room := c.rooms.get(roomCode)
+	if room == nil || len(room.entries) == 0 {
+		return "", "", nil
+	}
+	return room.entries[0].ID, room.entries[len(room.entries)-1].ID, nil
+}
+
+func (c *memoryOutboxCache) Ack(ctx context.Context, roomCode, consumer, id string) error {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	c.rooms.touch(roomCode).acks[consumer] = id
+	return nil
+}
+
+func (c *memoryOutboxCache) Acked(ctx context.Context, roomCode, consumer string) (string, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	if room := c.rooms.get(roomCode); room != nil {
+		return room.acks[consumer], nil
+	}
+	return "", nil
+}
@@ -0,0 +1,508 @@
+package cache
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"encoding/json"
+)
+
+// memoryPlayerRoom is one room's player state; values are JSON as in Redis
+type memoryPlayerRoom struct {
+	players map[string][]byte
+	base    map[string][]byte // Base questions by key
+	done    map[string]bool
+	state   map[string]*memoryPlayerState
+}
+
+// memoryPlayerState holds what the Redis cache keeps under room:<code>:p:<id>:*
+type memoryPlayerState struct {
+	queue    []string
+	current  string
+	qmap     map[string][]byte
+	closed   map[string]bool
+	attempts map[string][]byte // By question key
+	submits  map[string][]byte // By question key and client attempt ID
+}
+
+type memoryPlayerCache struct {
+	rooms *memoryRooms[memoryPlayerRoom]
+}
+
+// NewMemoryPlayerCache creates a player cache held in this process (single instance only)
+func NewMemoryPlayerCache() PlayerCache {
+	return &memoryPlayerCache{
+		rooms: newMemoryRooms(func() *memoryPlayerRoom {
+			return &memoryPlayerRoom{
+				players: make(map[string][]byte),
+				base:    make(map[string][]byte),
+				done:    make(map[string]bool),
+				state:   make(map[string]*memoryPlayerState),
+			}
+		}),
+	}
+}
+
+// read runs fn under the lock with the player's state; nil when the room or player has none
+func (c *memoryPlayerCache) read(roomCode, playerID string, fn func(room *memoryPlayerRoom, p *memoryPlayerState)) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	room := c.rooms.get(roomCode)
+	if room == nil {
+		fn(nil, nil)
+		return
+	}
+	fn(room, room.state[playerID])
+}
+
+// write runs fn under the lock with the player's state, creating it if needed
+func (c *memoryPlayerCache) write(roomCode, playerID string, fn func(room *memoryPlayerRoom, p *memoryPlayerState)) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	room := c.rooms.touch(roomCode)
+	p := room.state[playerID]
+	if p == nil {
+		p = &memoryPlayerState{
+			qmap:     make(map[string][]byte),
+			closed:   make(map[string]bool),
+			attempts: make(map[string][]byte),
+			submits:  make(map[string][]byte),
+		}
+		room.state[playerID] = p
+	}
+	fn(room, p)
+}
+
+func submitField(questionKey, clientAttemptID string) string {
+	return questionKey + ":" + clientAttemptID
+}
+
+// Player operations
+func (c *memoryPlayerCache) SetPlayer(ctx context.Context, roomCode, playerID string, player *model.Player) error {
+	data, err := json.Marshal(player)
+	if err != nil {
+		return err
+	}
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	c.rooms.touch(roomCode).players[playerID] = data
+	return nil
+}
+
+func (c *memoryPlayerCache) GetPlayer(ctx context.Context, roomCode, playerID string) (*model.Player, error) {
+	var data []byte
+	c.read(roomCode, playerID, func(room *memoryPlayerRoom, _ *memoryPlayerState) {
+		if room != nil {
+			data = room.players[playerID]
+		}
+	})
+	return decodeJSON[model.Player](data)
+}
+
+func (c *memoryPlayerCache) GetAllPlayers(ctx context.Context, roomCode string) (map[string]*model.Player, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	players := make(map[string]*model.Player)
+	room := c.rooms.get(roomCode)
+	if room == nil {
+		return players, nil
+	}
+	for id, data := range room.players {
+		var p model.Player
+		if err := json.Unmarshal(data, &p); err != nil {
+			continue
+		}
+		players[id] = &p
+	}
+	return players, nil
+}
+
+func (c *memoryPlayerCache) UpdateScore(ctx context.Context, roomCode, playerID string, score int) error {
+	player, err := c.GetPlayer(ctx, roomCode, playerID)
+	if err != nil || player == nil {
+		return err
+	}
+	player.Score = score
+	return c.SetPlayer(ctx, roomCode, playerID, player)
+}
+
+// Queue operations
+func (c *memoryPlayerCache) SetQueue(ctx context.Context, roomCode, playerID string, questions []string) error {
+	c.write(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		p.queue = append([]string(nil), questions...)
+	})
+	return nil
+}
+
+func (c *memoryPlayerCache) GetQueue(ctx context.Context, roomCode, playerID string) ([]string, error) {
+	queue := []string{}
+	c.read(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		if p != nil {
+			queue = append(queue, p.queue...)
+		}
+	})
+	return queue, nil
+}
+
+func (c *memoryPlayerCache) PopQueue(ctx context.Context, roomCode, playerID string) (string, error) {
+	var key string
+	c.write(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		if len(p.queue) > 0 {
+			key, p.queue = p.queue[0], p.queue[1:]
+		}
+	})
+	return key, nil
+}
+
+// InsertInQueue inserts under the lock, so unlike Redis no write can interleave
+func (c *memoryPlayerCache) InsertInQueue(ctx context.Context, roomCode, playerID string, afterKey string, newKeys ...string) error {
+	c.write(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		if queue, ok := insertAfter(p.queue, afterKey, newKeys); ok {
+			p.queue = queue
+		}
+	})
+	return nil
+}
+
+// Current question
+func (c *memoryPlayerCache) SetCurrent(ctx context.Context, roomCode, playerID, questionKey string) error {
+	c.write(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		p.current = questionKey
+	})
+	return nil
+}
+
+func (c *memoryPlayerCache) GetCurrent(ctx context.Context, roomCode, playerID string) (string, error) {
+	var key string
+	c.read(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		if p != nil {
+			key = p.current
+		}
+	})
+	return key, nil
+}
+
+// Base questions
+func (c *memoryPlayerCache) SetBaseQuestions(ctx context.Context, roomCode string, questions []*model.Question) error {
+	if len(questions) == 0 {
+		return nil
+	}
+	encoded := make(map[string][]byte, len(questions))
+	for _, q := range questions {
+		data, err := json.Marshal(q)
+		if err != nil {
+			return err
+		}
+		encoded[q.Key] = data
+	}
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	room := c.rooms.touch(roomCode)
+	for key, data := range encoded {
+		room.base[key] = data
+	}
+	return nil
+}
+
+func (c *memoryPlayerCache) HasBaseQuestions(ctx context.Context, roomCode string) (bool, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	room := c.rooms.get(roomCode)
+	return room != nil && len(room.base) > 0, nil
+}
+
+// Question map
+func (c *memoryPlayerCache) SetQuestionMap(ctx context.Context, roomCode, playerID, key string, q *model.Question) error {
+	data, err := json.Marshal(q)
+	if err != nil {
+		return err
+	}
+	c.write(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		p.qmap[key] = data
+	})
+	return nil
+}
+
+// questionData returns the player's entry for key, or the room's base question. Callers hold the lock.
+func questionData(room *memoryPlayerRoom, p *memoryPlayerState, key string) []byte {
+	if room == nil {
+		return nil
+	}
+	if p != nil {
+		if data, ok := p.qmap[key]; ok {
+			return data
+		}
+	}
+	return room.base[key]
+}
+
+// GetQuestionMap returns the player's entry for key, or the room's base question
+func (c *memoryPlayerCache) GetQuestionMap(ctx context.Context, roomCode, playerID, key string) (*model.Question, error) {
+	var data []byte
+	c.read(roomCode, playerID, func(room *memoryPlayerRoom, p *memoryPlayerState) {
+		data = questionData(room, p, key)
+	})
+	return decodeJSON[model.Question](data)
+}
+
+func (c *memoryPlayerCache) GetQuestionMaps(ctx context.Context, roomCode, playerID string, keys []string) (map[string]*model.Question, error) {
+	found := make(map[string][]byte, len(keys))
+	c.read(roomCode, playerID, func(room *memoryPlayerRoom, p *memoryPlayerState) {
+		for _, key := range keys {
+			if data := questionData(room, p, key); data != nil {
+				found[key] = data
+			}
+		}
+	})
+	questions := make(map[string]*model.Question, len(found))
+	for key, data := range found {
+		q, err := decodeJSON[model.Question](data)
+		if err != nil {
+			return nil, err
+		}
+		questions[key] = q
+	}
+	return questions, nil
+}
+
+func (c *memoryPlayerCache) GetQuestionMapKeys(ctx context.Context, roomCode, playerID string) ([]string, error) {
+	keys := []string{}
+	c.read(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		if p != nil {
+			for key := range p.qmap {
+				keys = append(keys, key)
+			}
+		}
+	})
+	return keys, nil
+}
+
+// Closed parents
+func (c *memoryPlayerCache) AddClosedParent(ctx context.Context, roomCode, playerID, parentKey string) error {
+	c.write(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		p.closed[parentKey] = true
+	})
+	return nil
+}
+
+func (c *memoryPlayerCache) IsParentClosed(ctx context.Context, roomCode, playerID, parentKey string) (bool, error) {
+	var closed bool
+	c.read(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		closed = p != nil && p.closed[parentKey]
+	})
+	return closed, nil
+}
+
+// Attempt state
+func (c *memoryPlayerCache) SetAttempt(ctx context.Context, roomCode, playerID, questionKey string, state *model.AttemptState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	c.write(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		p.attempts[questionKey] = data
+	})
+	return nil
+}
+
+func (c *memoryPlayerCache) GetAttempt(ctx context.Context, roomCode, playerID, questionKey string) (*model.AttemptState, error) {
+	var data []byte
+	c.read(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		if p != nil {
+			data = p.attempts[questionKey]
+		}
+	})
+	return decodeJSON[model.AttemptState](data)
+}
+
+// UpdateAttempt runs update and stores its result under the lock
+func (c *memoryPlayerCache) UpdateAttempt(ctx context.Context, roomCode, playerID, questionKey string, update func(state *model.AttemptState) (*model.AttemptState, error)) (*model.AttemptState, error) {
+	var next *model.AttemptState
+	var err error
+	c.write(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		var current *model.AttemptState
+		if current, err = decodeJSON[model.AttemptState](p.attempts[questionKey]); err != nil {
+			return
+		}
+		if next, err = update(current); err != nil {
+			return
+		}
+		var data []byte
+		if data, err = json.Marshal(next); err != nil {
+			return
+		}
+		p.attempts[questionKey] = data
+	})
+	if err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// GetAttemptStates reads the attempt states of several questions; missing ones are omitted
+func (c *memoryPlayerCache) GetAttemptStates(ctx context.Context, roomCode, playerID string, questionKeys []string) (map[string]*model.AttemptState, error) {
+	found := make(map[string][]byte, len(questionKeys))
+	c.read(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		if p == nil {
+			return
+		}
+		for _, qk := range questionKeys {
+			if data, ok := p.attempts[qk]; ok {
+				found[qk] = data
+			}
+		}
+	})
+	return decodeAttempts(found), nil
+}
+
+// decodeAttempts decodes attempt states by question key, skipping undecodable ones like the Redis cache
+func decodeAttempts(found map[string][]byte) map[string]*model.AttemptState {
+	states := make(map[string]*model.AttemptState, len(found))
+	for qk, data := range found {
+		var state model.AttemptState
+		if err := json.Unmarshal(data, &state); err != nil {
+			continue
+		}
+		states[qk] = &state
+	}
+	return states
+}
+
+// GetPlayerState reads the player, their current key, and that question's map
+// entry (falling back to the base question) and attempt state
+func (c *memoryPlayerCache) GetPlayerState(ctx context.Context, roomCode, playerID string) (*model.CurrentState, error) {
+	var playerData, questionJSON, attemptData []byte
+	state := &model.CurrentState{}
+	c.read(roomCode, playerID, func(room *memoryPlayerRoom, p *memoryPlayerState) {
+		if room == nil {
+			return
+		}
+		playerData = room.players[playerID]
+		if p == nil || p.current == "" {
+			return
+		}
+		state.CurrentKey = p.current
+		questionJSON = questionData(room, p, p.current)
+		attemptData = p.attempts[p.current]
+	})
+
+	var err error
+	if state.Player, err = decodeJSON[model.Player](playerData); err != nil {
+		return nil, err
+	}
+	if state.Question, err = decodeJSON[model.Question](questionJSON); err != nil {
+		return nil, err
+	}
+	if state.Attempt, err = decodeJSON[model.AttemptState](attemptData); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Submit results
+
+// ClaimSubmit records a pending result for a new clientAttemptId. It returns
+// false if the attempt was already claimed by an earlier submission.
+func (c *memoryPlayerCache) ClaimSubmit(ctx context.Context, roomCode, playerID, questionKey, clientAttemptID string) (bool, error) {
+	pending, err := json.Marshal(&model.SubmitAnswerResponse{Status: model.AnswerStatusSubmitted})
+	if err != nil {
+		return false, err
+	}
+	claimed := false
+	c.write(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		field := submitField(questionKey, clientAttemptID)
+		if _, ok := p.submits[field]; !ok {
+			p.submits[field] = pending
+			claimed = true
+		}
+	})
+	return claimed, nil
+}
+
+func (c *memoryPlayerCache) SetSubmitResult(ctx context.Context, roomCode, playerID, questionKey, clientAttemptID string, resp *model.SubmitAnswerResponse) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	c.write(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		p.submits[submitField(questionKey, clientAttemptID)] = data
+	})
+	return nil
+}
+
+func (c *memoryPlayerCache) GetSubmitResult(ctx context.Context, roomCode, playerID, questionKey, clientAttemptID string) (*model.SubmitAnswerResponse, error) {
+	var data []byte
+	c.read(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		if p != nil {
+			data = p.submits[submitField(questionKey, clientAttemptID)]
+		}
+	})
+	return decodeJSON[model.SubmitAnswerResponse](data)
+}
+
+func (c *memoryPlayerCache) DeleteSubmitResult(ctx context.Context, roomCode, playerID, questionKey, clientAttemptID string) error {
+	c.read(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		if p != nil {
+			delete(p.submits, submitField(questionKey, clientAttemptID))
+		}
+	})
+	return nil
+}
+
+// Completion
+func (c *memoryPlayerCache) MarkDone(ctx context.Context, roomCode, playerID string) error {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	c.rooms.touch(roomCode).done[playerID] = true
+	return nil
+}
+
+func (c *memoryPlayerCache) GetDonePlayers(ctx context.Context, roomCode string) ([]string, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	done := []string{}
+	if room := c.rooms.get(roomCode); room != nil {
+		for id := range room.done {
+			done = append(done, id)
+		}
+	}
+	return done, nil
+}
+
+// GetAttempts returns every attempt state of a player, keyed by question
+func (c *memoryPlayerCache) GetAttempts(ctx context.Context, roomCode, playerID string) (map[string]*model.AttemptState, error) {
+	found := make(map[string][]byte)
+	c.read(roomCode, playerID, func(_ *memoryPlayerRoom, p *memoryPlayerState) {
+		if p != nil {
+			for qk, data := range p.attempts {
+				found[qk] = data
+			}
+		}
+	})
+	return decodeAttempts(found), nil
+}
+
+// DeletePlayer removes the player and all of their state. Returns the number
+// of entries deleted, counted like the Redis keys.
+func (c *memoryPlayerCache) DeletePlayer(ctx context.Context, roomCode, playerID string) (int, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	room := c.rooms.get(roomCode)
+	if room == nil {
+		return 0, nil
+	}
+	delete(room.players, playerID)
+	delete(room.done, playerID)
+
+	p := room.state[playerID]
+	if p == nil {
+		return 0, nil
+	}
+	delete(room.state, playerID)
+	deleted := len(p.attempts) + len(p.submits)
+	for _, held := range []bool{len(p.queue) > 0, p.current != "", len(p.qmap) > 0, len(p.closed) > 0} {
+		if held {
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"encoding/json"
+)
+
+// memoryPollRoom is one room's open poll and the votes of its polls
+type memoryPollRoom struct {
+	openID string
+	open   []byte
+	votes  map[string]map[string]int // Choices by player ID, by poll ID
+}
+
+type memoryPollCache struct {
+	rooms *memoryRooms[memoryPollRoom]
+}
+
+// NewMemoryPollCache creates a poll cache held in this process (single instance only)
+func NewMemoryPollCache() PollCache {
+	return &memoryPollCache{
+		rooms: newMemoryRooms(func() *memoryPollRoom {
+			return &memoryPollRoom{votes: make(map[string]map[string]int)}
+		}),
+	}
+}
+
+func (c *memoryPollCache) Open(ctx context.Context, roomCode string, poll *model.Poll) (bool, error) {
+	data, err := json.Marshal(poll)
+	if err != nil {
+		return false, err
+	}
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	room := c.rooms.touch(roomCode)
+	if room.open != nil {
+		return false, nil
+	}
+	room.openID, room.open = poll.ID, data
+	return true, nil
+}
+
+func (c *memoryPollCache) GetOpen(ctx context.Context, roomCode string) (*model.Poll, error) {
+	c.rooms.mu.Lock()
+	var data []byte
+	if room := c.rooms.get(roomCode); room != nil {
+		data = room.open
+	}
+	c.rooms.mu.Unlock()
+	return decodeJSON[model.Poll](data)
+}
+
+func (c *memoryPollCache) Close(ctx context.Context, roomCode, pollID string) (bool, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	room := c.rooms.get(roomCode)
+	if room == nil || room.open == nil || room.openID != pollID {
+		return false, nil
+	}
+	room.openID, room.open = "", nil
+	return true, nil
+}
+
+func (c *memoryPollCache) Vote(ctx context.Context, roomCode, pollID, playerID string, choice int) (bool, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	room := c.rooms.touch(roomCode)
+	votes := room.votes[pollID]
+	if votes == nil {
+		votes = make(map[string]int)
+		room.votes[pollID] = votes
+	}
+	if _, voted := votes[playerID]; voted {
+		return false, nil
+	}
+	votes[playerID] = choice
+	return true, nil
+}
+
+func (c *memoryPollCache) Votes(ctx context.Context, roomCode, pollID string) (map[string]int, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	votes := make(map[string]int)
+	if room := c.rooms.get(roomCode); room != nil {
+		for playerID, choice := range room.votes[pollID] {
+			votes[playerID] = choice
+		}
+	}
+	return votes, nil
+}
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"encoding/json"
+)
+
+type memoryPoolCache struct {
+	rooms *memoryRooms[map[string][]byte] // Pools by question key
+}
+
+// NewMemoryPoolCache creates a follow-up pool cache held in this process (single instance only)
+func NewMemoryPoolCache() PoolCache {
+	return &memoryPoolCache{
+		rooms: newMemoryRooms(func() *map[string][]byte {
+			pools := make(map[string][]byte)
+			return &pools
+		}),
+	}
+}
+
+func (c *memoryPoolCache) SetPool(ctx context.Context, roomCode, questionKey string, pool *model.FollowUpPool) error {
+	data, err := json.Marshal(pool)
+	if err != nil {
+		return err
+	}
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	(*c.rooms.touch(roomCode))[questionKey] = data
+	return nil
+}
+
+func (c *memoryPoolCache) GetPool(ctx context.Context, roomCode, questionKey string) (*model.FollowUpPool, error) {
+	c.rooms.mu.Lock()
+	var data []byte
+	if pools := c.rooms.get(roomCode); pools != nil {
+		data = (*pools)[questionKey]
+	}
+	c.rooms.mu.Unlock()
+	if data == nil {
+		return nil, nil
+	}
+	var pool model.FollowUpPool
+	if err := json.Unmarshal(data, &pool); err != nil {
+		return nil, err
+	}
+	return &pool, nil
+}
+
+func (c *memoryPoolCache) DeletePool(ctx context.Context, roomCode, questionKey string) error {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	if pools := c.rooms.get(roomCode); pools != nil {
+		delete(*pools, questionKey)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+)
+
+// memoryQuotaRoom is one room's quota members and screened-out players
+type memoryQuotaRoom struct {
+	options  map[string]map[string]bool // Players by "<questionKey>:<option>"
+	screened map[string]bool
+}
+
+type memoryQuotaCache struct {
+	rooms *memoryRooms[memoryQuotaRoom]
+}
+
+// NewMemoryQuotaCache creates a quota cache held in this process (single instance only)
+func NewMemoryQuotaCache() QuotaCache {
+	return &memoryQuotaCache{
+		rooms: newMemoryRooms(func() *memoryQuotaRoom {
+			return &memoryQuotaRoom{
+				options:  make(map[string]map[string]bool),
+				screened: make(map[string]bool),
+			}
+		}),
+	}
+}
+
+func (c *memoryQuotaCache) key(questionKey string, option int) string {
+	return fmt.Sprintf("%s:%d", questionKey, option)
+}
+
+// Claim admits players already in the option again, as the Redis script does
+func (c *memoryQuotaCache) Claim(ctx context.Context, roomCode, questionKey string, option, limit int, playerID string) (bool, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	room := c.rooms.touch(roomCode)
+	key := c.key(questionKey, option)
+	members := room.options[key]
+	if members[playerID] {
+		return true, nil
+	}
+	if len(members) >= limit {
+		return false, nil
+	}
+	if members == nil {
+		members = make(map[string]bool)
+		room.options[key] = members
+	}
+	members[playerID] = true
+	return true, nil
+}
+
+func (c *memoryQuotaCache) Count(ctx context.Context, roomCode, questionKey string, option int) (int, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	if room := c.rooms.get(roomCode); room != nil {
+		return len(room.options[c.key(questionKey, option)]), nil
+	}
+	return 0, nil
+}
+
+func (c *memoryQuotaCache) MarkScreenedOut(ctx context.Context, roomCode, playerID string) error {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	c.rooms.touch(roomCode).screened[playerID] = true
+	return nil
+}
+
+func (c *memoryQuotaCache) CountScreenedOut(ctx context.Context, roomCode string) (int, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	if room := c.rooms.get(roomCode); room != nil {
+		return len(room.screened), nil
+	}
+	return 0, nil
+}
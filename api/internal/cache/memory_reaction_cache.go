@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// memoryReactionRoom is one room's reaction totals and recent windows
+type memoryReactionRoom struct {
+	totals  map[string]int64
+	windows map[int64]*memoryReactionWindow // By window start, Unix seconds
+}
+
+// memoryReactionWindow expires like its Redis keys, windowTTL after its last reaction
+type memoryReactionWindow struct {
+	counts  map[string]int64 // By emoji
+	players map[string]int   // Taps by player ID, over the limit included
+	expires time.Time
+}
+
+type memoryReactionCache struct {
+	rooms     *memoryRooms[memoryReactionRoom]
+	windowTTL time.Duration
+}
+
+// NewMemoryReactionCache creates a reaction cache held in this process (single instance only)
+func NewMemoryReactionCache() ReactionCache {
+	return &memoryReactionCache{
+		rooms: newMemoryRooms(func() *memoryReactionRoom {
+			return &memoryReactionRoom{
+				totals:  make(map[string]int64),
+				windows: make(map[int64]*memoryReactionWindow),
+			}
+		}),
+		windowTTL: 5 * time.Minute,
+	}
+}
+
+// window returns the room's unexpired window, dropping the expired ones
+func (c *memoryReactionCache) window(room *memoryReactionRoom, start time.Time) *memoryReactionWindow {
+	now := time.Now()
+	for ts, w := range room.windows {
+		if now.After(w.expires) {
+			delete(room.windows, ts)
+		}
+	}
+	return room.windows[start.Unix()]
+}
+
+// Add follows the Redis script: every tap counts toward the player's limit
+func (c *memoryReactionCache) Add(ctx context.Context, roomCode, playerID, emoji string, window time.Time, max int) (int, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	room := c.rooms.touch(roomCode)
+	w := c.window(room, window)
+	if w == nil {
+		w = &memoryReactionWindow{counts: make(map[string]int64), players: make(map[string]int)}
+		room.windows[window.Unix()] = w
+	}
+	w.expires = time.Now().Add(c.windowTTL)
+
+	w.players[playerID]++
+	n := w.players[playerID]
+	if n > max {
+		return ReactionDropped, nil
+	}
+	w.counts[emoji]++
+	room.totals[emoji]++
+	if n == 1 && len(w.players) == 1 {
+		return ReactionFirst, nil
+	}
+	return ReactionCounted, nil
+}
+
+func (c *memoryReactionCache) Window(ctx context.Context, roomCode string, window time.Time) (map[string]int64, int, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	counts := make(map[string]int64)
+	room := c.rooms.get(roomCode)
+	if room == nil {
+		return counts, 0, nil
+	}
+	w := c.window(room, window)
+	if w == nil {
+		return counts, 0, nil
+	}
+	for emoji, n := range w.counts {
+		counts[emoji] = n
+	}
+	return counts, len(w.players), nil
+}
+
+func (c *memoryReactionCache) Totals(ctx context.Context, roomCode string) (map[string]int64, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	totals := make(map[string]int64)
+	if room := c.rooms.get(roomCode); room != nil {
+		for emoji, n := range room.totals {
+			totals[emoji] = n
+		}
+	}
+	return totals, nil
+}
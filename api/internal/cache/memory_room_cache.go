@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type memoryRoomCache struct {
+	rooms *memoryRooms[[]byte]
+}
+
+// NewMemoryRoomCache creates a room cache held in this process (single instance only)
+func NewMemoryRoomCache() RoomCache {
+	return &memoryRoomCache{
+		rooms: newMemoryRooms(func() *[]byte { return new([]byte) }),
+	}
+}
+
+func (c *memoryRoomCache) SetMeta(ctx context.Context, code string, meta *model.RoomMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	*c.rooms.touch(code) = data
+	return nil
+}
+
+func (c *memoryRoomCache) GetMeta(ctx context.Context, code string) (*model.RoomMeta, error) {
+	c.rooms.mu.Lock()
+	data := c.rooms.get(code)
+	c.rooms.mu.Unlock()
+	if data == nil {
+		return nil, nil
+	}
+	var meta model.RoomMeta
+	if err := json.Unmarshal(*data, &meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+func (c *memoryRoomCache) SetStatus(ctx context.Context, code string, status model.RoomStatus) error {
+	meta, err := c.GetMeta(ctx, code)
+	if err != nil {
+		return err
+	}
+	if meta == nil {
+		return fmt.Errorf("room %s not found", code)
+	}
+	meta.Status = status
+	return c.SetMeta(ctx, code, meta)
+}
+
+func (c *memoryRoomCache) Delete(ctx context.Context, code string) error {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	c.rooms.drop(code)
+	return nil
+}
+
+func (c *memoryRoomCache) Exists(ctx context.Context, code string) (bool, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	return c.rooms.get(code) != nil, nil
+}
@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"encoding/json"
+	"time"
+)
+
+type memorySurveyCache struct {
+	surveys *memoryRooms[[]byte] // By survey ID
+}
+
+// NewMemorySurveyCache creates a survey cache held in this process (single instance only)
+func NewMemorySurveyCache() SurveyCache {
+	return &memorySurveyCache{
+		surveys: newMemoryRoomsTTL(time.Hour, func() *[]byte { return new([]byte) }),
+	}
+}
+
+func (c *memorySurveyCache) Get(ctx context.Context, id string) (*model.Survey, error) {
+	c.surveys.mu.Lock()
+	data := c.surveys.get(id)
+	c.surveys.mu.Unlock()
+	if data == nil {
+		return nil, nil
+	}
+	return decodeJSON[model.Survey](*data)
+}
+
+func (c *memorySurveyCache) Set(ctx context.Context, survey *model.Survey) error {
+	data, err := json.Marshal(survey)
+	if err != nil {
+		return err
+	}
+	c.surveys.mu.Lock()
+	defer c.surveys.mu.Unlock()
+	*c.surveys.touch(survey.ID) = data
+	return nil
+}
+
+func (c *memorySurveyCache) Delete(ctx context.Context, id string) error {
+	c.surveys.mu.Lock()
+	defer c.surveys.mu.Unlock()
+	c.surveys.drop(id)
+	return nil
+}
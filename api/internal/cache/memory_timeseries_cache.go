@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+type memoryTimeseriesCache struct {
+	rooms *memoryRooms[map[int64]map[string]int64] // Counters by Unix minute, then metric
+}
+
+// NewMemoryTimeseriesCache creates a timeseries cache held in this process (single instance only)
+func NewMemoryTimeseriesCache() TimeseriesCache {
+	return &memoryTimeseriesCache{
+		rooms: newMemoryRoomsTTL(48*time.Hour, func() *map[int64]map[string]int64 {
+			minutes := make(map[int64]map[string]int64)
+			return &minutes
+		}),
+	}
+}
+
+func (c *memoryTimeseriesCache) Incr(ctx context.Context, roomCode, metric string, at time.Time, by int64) error {
+	minute := at.Truncate(time.Minute).Unix()
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	minutes := *c.rooms.touch(roomCode)
+	if minutes[minute] == nil {
+		minutes[minute] = make(map[string]int64)
+	}
+	minutes[minute][metric] += by
+	return nil
+}
+
+func (c *memoryTimeseriesCache) GetAll(ctx context.Context, roomCode string) (map[int64]map[string]int64, error) {
+	c.rooms.mu.Lock()
+	defer c.rooms.mu.Unlock()
+	out := make(map[int64]map[string]int64)
+	if minutes := c.rooms.get(roomCode); minutes != nil {
+		for minute, metrics := range *minutes {
+			out[minute] = make(map[string]int64, len(metrics))
+			for metric, n := range metrics {
+				out[minute][metric] = n
+			}
+		}
+	}
+	return out, nil
+}
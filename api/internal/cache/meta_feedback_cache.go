@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// MetaFeedbackCache keeps players' meta-survey answers until the room's snapshot
+type MetaFeedbackCache interface {
+	// Save stores a player's answers once; false if they already answered
+	Save(ctx context.Context, roomCode, playerID string, answers *model.MetaFeedbackRequest) (bool, error)
+	Has(ctx context.Context, roomCode, playerID string) (bool, error)
+	GetAll(ctx context.Context, roomCode string) ([]model.MetaFeedbackRequest, error)
+}
+
+type metaFeedbackCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewMetaFeedbackCache creates a new meta-feedback cache
+func NewMetaFeedbackCache(client *redis.Client) MetaFeedbackCache {
+	return &metaFeedbackCache{
+		client: client,
+		ttl:    24 * time.Hour,
+	}
+}
+
+func (c *metaFeedbackCache) key(roomCode string) string {
+	return fmt.Sprintf("room:%s:meta", roomCode)
+}
+
+func (c *metaFeedbackCache) Save(ctx context.Context, roomCode, playerID string, answers *model.MetaFeedbackRequest) (bool, error) {
+	data, err := json.Marshal(answers)
+	if err != nil {
+		return false, err
+	}
+	pipe := c.client.TxPipeline()
+	set := pipe.HSetNX(ctx, c.key(roomCode), playerID, data)
+	pipe.Expire(ctx, c.key(roomCode), c.ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+	return set.Val(), nil
+}
+
+func (c *metaFeedbackCache) Has(ctx context.Context, roomCode, playerID string) (bool, error) {
+	return c.client.HExists(ctx, c.key(roomCode), playerID).Result()
+}
+
+func (c *metaFeedbackCache) GetAll(ctx context.Context, roomCode string) ([]model.MetaFeedbackRequest, error) {
+	raw, err := c.client.HGetAll(ctx, c.key(roomCode)).Result()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.MetaFeedbackRequest, 0, len(raw))
+	for _, data := range raw {
+		var answers model.MetaFeedbackRequest
+		if err := json.Unmarshal([]byte(data), &answers); err == nil {
+			out = append(out, answers)
+		}
+	}
+	return out, nil
+}
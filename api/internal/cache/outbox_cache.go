@@ -0,0 +1,183 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// OutboxEntry is one room event waiting in the room's outbox stream
+type OutboxEntry struct {
+	ID       string // Stream ID, assigned by Append
+	Type     string
+	Audience string
+	PlayerID string // Recipient when Audience is player
+	Seq      int64  // Room event log sequence, 0 when not persisted
+	Payload  []byte // Encoded payload JSON
+	Close    bool   // Disconnect the audience once everything before is delivered
+}
+
+// OutboxCache keeps each room's published events in a Redis stream, so every
+// instance delivers them to its own connections and clients can resume after
+// the last event they saw
+type OutboxCache interface {
+	// Append adds the entry to the room's stream and returns its ID
+	Append(ctx context.Context, roomCode string, entry *OutboxEntry) (string, error)
+	// Range returns up to count entries after afterID and up to toID, oldest first
+	Range(ctx context.Context, roomCode, afterID, toID string, count int64) ([]OutboxEntry, error)
+	// Read blocks up to block for entries after the given ID of each room; the
+	// result is keyed by room code and empty when nothing arrived
+	Read(ctx context.Context, after map[string]string, count int64, block time.Duration) (map[string][]OutboxEntry, error)
+	// Bounds returns the oldest and newest retained IDs, both empty for an empty stream
+	Bounds(ctx context.Context, roomCode string) (first, last string, err error)
+	// Ack records the last ID a consumer (host, player:{id}) processed; Acked reads it back
+	Ack(ctx context.Context, roomCode, consumer, id string) error
+	Acked(ctx context.Context, roomCode, consumer string) (string, error)
+}
+
+type outboxCache struct {
+	client *redis.Client
+	maxLen int64
+	ttl    time.Duration
+}
+
+// NewOutboxCache creates a new outbox cache
+func NewOutboxCache(client *redis.Client) OutboxCache {
+	return &outboxCache{
+		client: client,
+		maxLen: 1000,
+		ttl:    48 * time.Hour,
+	}
+}
+
+func (c *outboxCache) key(roomCode string) string {
+	return fmt.Sprintf("room:%s:outbox", roomCode)
+}
+
+func (c *outboxCache) acksKey(roomCode string) string {
+	return fmt.Sprintf("room:%s:outbox:acks", roomCode)
+}
+
+// Append trims the stream to about maxLen entries; the TTL is refreshed on every call
+func (c *outboxCache) Append(ctx context.Context, roomCode string, entry *OutboxEntry) (string, error) {
+	values := map[string]interface{}{
+		"type":     entry.Type,
+		"audience": entry.Audience,
+		"payload":  entry.Payload,
+	}
+	if entry.PlayerID != "" {
+		values["playerId"] = entry.PlayerID
+	}
+	if entry.Seq > 0 {
+		values["seq"] = entry.Seq
+	}
+	if entry.Close {
+		values["close"] = "1"
+	}
+
+	pipe := c.client.TxPipeline()
+	add := pipe.XAdd(ctx, &redis.XAddArgs{
+		Stream: c.key(roomCode),
+		MaxLen: c.maxLen,
+		Approx: true,
+		Values: values,
+	})
+	pipe.Expire(ctx, c.key(roomCode), c.ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", err
+	}
+	entry.ID = add.Val()
+	return entry.ID, nil
+}
+
+func (c *outboxCache) Range(ctx context.Context, roomCode, afterID, toID string, count int64) ([]OutboxEntry, error) {
+	msgs, err := c.client.XRangeN(ctx, c.key(roomCode), "("+afterID, toID, count).Result()
+	if err != nil {
+		return nil, err
+	}
+	return outboxEntries(msgs), nil
+}
+
+func (c *outboxCache) Read(ctx context.Context, after map[string]string, count int64, block time.Duration) (map[string][]OutboxEntry, error) {
+	out := make(map[string][]OutboxEntry)
+	if len(after) == 0 {
+		return out, nil
+	}
+
+	rooms := make([]string, 0, len(after))
+	streams := make([]string, 0, 2*len(after))
+	for roomCode := range after {
+		rooms = append(rooms, roomCode)
+		streams = append(streams, c.key(roomCode))
+	}
+	for _, roomCode := range rooms {
+		streams = append(streams, after[roomCode])
+	}
+
+	res, err := c.client.XRead(ctx, &redis.XReadArgs{Streams: streams, Count: count, Block: block}).Result()
+	if errors.Is(err, redis.Nil) {
+		return out, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, stream := range res {
+		roomCode := strings.TrimSuffix(strings.TrimPrefix(stream.Stream, "room:"), ":outbox")
+		out[roomCode] = outboxEntries(stream.Messages)
+	}
+	return out, nil
+}
+
+func (c *outboxCache) Bounds(ctx context.Context, roomCode string) (string, string, error) {
+	pipe := c.client.Pipeline()
+	first := pipe.XRangeN(ctx, c.key(roomCode), "-", "+", 1)
+	last := pipe.XRevRangeN(ctx, c.key(roomCode), "+", "-", 1)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", "", err
+	}
+	if len(first.Val()) == 0 || len(last.Val()) == 0 {
+		return "", "", nil
+	}
+	return first.Val()[0].ID, last.Val()[0].ID, nil
+}
+
+func (c *outboxCache) Ack(ctx context.Context, roomCode, consumer, id string) error {
+	pipe := c.client.TxPipeline()
+	pipe.HSet(ctx, c.acksKey(roomCode), consumer, id)
+	pipe.Expire(ctx, c.acksKey(roomCode), c.ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Acked returns an empty ID when the consumer never acknowledged anything
+func (c *outboxCache) Acked(ctx context.Context, roomCode, consumer string) (string, error) {
+	id, err := c.client.HGet(ctx, c.acksKey(roomCode), consumer).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return id, err
+}
+
+func outboxEntries(msgs []redis.XMessage) []OutboxEntry {
+	out := make([]OutboxEntry, 0, len(msgs))
+	for _, msg := range msgs {
+		entry := OutboxEntry{ID: msg.ID}
+		entry.Type, _ = msg.Values["type"].(string)
+		entry.Audience, _ = msg.Values["audience"].(string)
+		entry.PlayerID, _ = msg.Values["playerId"].(string)
+		if payload, ok := msg.Values["payload"].(string); ok {
+			entry.Payload = []byte(payload)
+		}
+		if seq, ok := msg.Values["seq"].(string); ok {
+			entry.Seq, _ = strconv.ParseInt(seq, 10, 64)
+		}
+		entry.Close = msg.Values["close"] == "1"
+		out = append(out, entry)
+	}
+	return out
+}
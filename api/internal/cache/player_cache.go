@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -28,9 +29,17 @@ type PlayerCache interface {
 	SetCurrent(ctx context.Context, roomCode, playerID, questionKey string) error
 	GetCurrent(ctx context.Context, roomCode, playerID string) (string, error)
 
-	// Question map (stores Question JSON for follow-ups/overrides)
+	// Base questions, stored once per room and shared by every player's question map
+	SetBaseQuestions(ctx context.Context, roomCode string, questions []*model.Question) error
+	HasBaseQuestions(ctx context.Context, roomCode string) (bool, error)
+
+	// Question map (stores Question JSON for follow-ups/overrides). Reads fall
+	// back to the room's base questions for keys the player has no entry for.
 	SetQuestionMap(ctx context.Context, roomCode, playerID, key string, q *model.Question) error
 	GetQuestionMap(ctx context.Context, roomCode, playerID, key string) (*model.Question, error)
+	GetQuestionMaps(ctx context.Context, roomCode, playerID string, keys []string) (map[string]*model.Question, error)
+	// GetQuestionMapKeys lists the keys the player has their own entry for (follow-ups and overrides)
+	GetQuestionMapKeys(ctx context.Context, roomCode, playerID string) ([]string, error)
 
 	// Closed parents (for skip chains)
 	AddClosedParent(ctx context.Context, roomCode, playerID, parentKey string) error
@@ -39,6 +48,28 @@ type PlayerCache interface {
 	// Attempt state
 	SetAttempt(ctx context.Context, roomCode, playerID, questionKey string, state *model.AttemptState) error
 	GetAttempt(ctx context.Context, roomCode, playerID, questionKey string) (*model.AttemptState, error)
+	GetAttemptStates(ctx context.Context, roomCode, playerID string, questionKeys []string) (map[string]*model.AttemptState, error)
+	// UpdateAttempt atomically replaces the attempt state with update's result. update gets
+	// the current state (nil if none) and may run more than once; an error from it is returned
+	// unchanged and nothing is written.
+	UpdateAttempt(ctx context.Context, roomCode, playerID, questionKey string, update func(state *model.AttemptState) (*model.AttemptState, error)) (*model.AttemptState, error)
+
+	// Composite read for serving the current question in two round trips
+	GetPlayerState(ctx context.Context, roomCode, playerID string) (*model.CurrentState, error)
+
+	// Submit results (idempotency by clientAttemptId)
+	ClaimSubmit(ctx context.Context, roomCode, playerID, questionKey, clientAttemptID string) (bool, error)
+	SetSubmitResult(ctx context.Context, roomCode, playerID, questionKey, clientAttemptID string, resp *model.SubmitAnswerResponse) error
+	GetSubmitResult(ctx context.Context, roomCode, playerID, questionKey, clientAttemptID string) (*model.SubmitAnswerResponse, error)
+	DeleteSubmitResult(ctx context.Context, roomCode, playerID, questionKey, clientAttemptID string) error
+
+	// Completion (players whose queue has emptied)
+	MarkDone(ctx context.Context, roomCode, playerID string) error
+	GetDonePlayers(ctx context.Context, roomCode string) ([]string, error)
+
+	// Data subject requests
+	GetAttempts(ctx context.Context, roomCode, playerID string) (map[string]*model.AttemptState, error)
+	DeletePlayer(ctx context.Context, roomCode, playerID string) (int, error)
 }
 
 type playerCache struct {
@@ -67,6 +98,10 @@ func (c *playerCache) currentKey(roomCode, playerID string) string {
 	return fmt.Sprintf("room:%s:p:%s:current", roomCode, playerID)
 }
 
+func (c *playerCache) baseQuestionsKey(roomCode string) string {
+	return fmt.Sprintf("room:%s:qbase", roomCode)
+}
+
 func (c *playerCache) qmapKey(roomCode, playerID string) string {
 	return fmt.Sprintf("room:%s:p:%s:qmap", roomCode, playerID)
 }
@@ -79,6 +114,14 @@ func (c *playerCache) attemptKey(roomCode, playerID, questionKey string) string
 	return fmt.Sprintf("room:%s:p:%s:attempt:%s", roomCode, playerID, questionKey)
 }
 
+func (c *playerCache) submitKey(roomCode, playerID, questionKey, clientAttemptID string) string {
+	return fmt.Sprintf("room:%s:p:%s:submit:%s:%s", roomCode, playerID, questionKey, clientAttemptID)
+}
+
+func (c *playerCache) doneKey(roomCode string) string {
+	return fmt.Sprintf("room:%s:done", roomCode)
+}
+
 // Player operations
 func (c *playerCache) SetPlayer(ctx context.Context, roomCode, playerID string, player *model.Player) error {
 	data, err := json.Marshal(player)
@@ -160,7 +203,16 @@ func (c *playerCache) InsertInQueue(ctx context.Context, roomCode, playerID stri
 	if err != nil {
 		return err
 	}
+	newQueue, ok := insertAfter(queue, afterKey, newKeys)
+	if !ok {
+		return nil // Nothing to insert
+	}
+	return c.SetQueue(ctx, roomCode, playerID, newQueue)
+}
 
+// insertAfter returns queue with the keys it does not hold yet inserted after
+// afterKey, or appended if afterKey is missing; false when none are new
+func insertAfter(queue []string, afterKey string, newKeys []string) ([]string, bool) {
 	// Filter out keys that already exist in the queue
 	exists := make(map[string]bool)
 	for _, k := range queue {
@@ -175,7 +227,7 @@ func (c *playerCache) InsertInQueue(ctx context.Context, roomCode, playerID stri
 	}
 
 	if len(uniqueNewKeys) == 0 {
-		return nil // Nothing to insert
+		return nil, false
 	}
 
 	// Find position and insert
@@ -192,8 +244,7 @@ func (c *playerCache) InsertInQueue(ctx context.Context, roomCode, playerID stri
 		// Append at end if afterKey not found
 		newQueue = append(newQueue, uniqueNewKeys...)
 	}
-
-	return c.SetQueue(ctx, roomCode, playerID, newQueue)
+	return newQueue, true
 }
 
 // Current question
@@ -209,6 +260,31 @@ func (c *playerCache) GetCurrent(ctx context.Context, roomCode, playerID string)
 	return val, err
 }
 
+// Base questions
+func (c *playerCache) SetBaseQuestions(ctx context.Context, roomCode string, questions []*model.Question) error {
+	if len(questions) == 0 {
+		return nil
+	}
+	fields := make([]interface{}, 0, len(questions)*2)
+	for _, q := range questions {
+		data, err := json.Marshal(q)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, q.Key, data)
+	}
+	pipe := c.client.TxPipeline()
+	pipe.HSet(ctx, c.baseQuestionsKey(roomCode), fields...)
+	pipe.Expire(ctx, c.baseQuestionsKey(roomCode), c.ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *playerCache) HasBaseQuestions(ctx context.Context, roomCode string) (bool, error) {
+	n, err := c.client.Exists(ctx, c.baseQuestionsKey(roomCode)).Result()
+	return n > 0, err
+}
+
 // Question map
 func (c *playerCache) SetQuestionMap(ctx context.Context, roomCode, playerID, key string, q *model.Question) error {
 	data, err := json.Marshal(q)
@@ -218,19 +294,69 @@ func (c *playerCache) SetQuestionMap(ctx context.Context, roomCode, playerID, ke
 	return c.client.HSet(ctx, c.qmapKey(roomCode, playerID), key, data).Err()
 }
 
+// GetQuestionMap returns the player's entry for key, or the room's base question
 func (c *playerCache) GetQuestionMap(ctx context.Context, roomCode, playerID, key string) (*model.Question, error) {
-	data, err := c.client.HGet(ctx, c.qmapKey(roomCode, playerID), key).Result()
-	if err == redis.Nil {
-		return nil, nil
-	}
-	if err != nil {
+	pipe := c.client.Pipeline()
+	playerCmd := pipe.HGet(ctx, c.qmapKey(roomCode, playerID), key)
+	baseCmd := pipe.HGet(ctx, c.baseQuestionsKey(roomCode), key)
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
 		return nil, err
 	}
-	var q model.Question
-	if err := json.Unmarshal([]byte(data), &q); err != nil {
+	return decodeQuestion(playerCmd, baseCmd)
+}
+
+// decodeQuestion decodes the first of the HGET results that found an entry
+func decodeQuestion(cmds ...*redis.StringCmd) (*model.Question, error) {
+	for _, cmd := range cmds {
+		data, err := cmd.Bytes()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var q model.Question
+		if err := json.Unmarshal(data, &q); err != nil {
+			return nil, err
+		}
+		return &q, nil
+	}
+	return nil, nil
+}
+
+// GetQuestionMaps reads several question map entries in one pipelined HMGET of the
+// player's map and the room's base questions; missing keys are omitted
+func (c *playerCache) GetQuestionMaps(ctx context.Context, roomCode, playerID string, keys []string) (map[string]*model.Question, error) {
+	questions := make(map[string]*model.Question, len(keys))
+	if len(keys) == 0 {
+		return questions, nil
+	}
+	pipe := c.client.Pipeline()
+	playerCmd := pipe.HMGet(ctx, c.qmapKey(roomCode, playerID), keys...)
+	baseCmd := pipe.HMGet(ctx, c.baseQuestionsKey(roomCode), keys...)
+	if _, err := pipe.Exec(ctx); err != nil {
 		return nil, err
 	}
-	return &q, nil
+	playerValues, baseValues := playerCmd.Val(), baseCmd.Val()
+	for i := range keys {
+		data, ok := playerValues[i].(string)
+		if !ok {
+			data, ok = baseValues[i].(string)
+		}
+		if !ok {
+			continue
+		}
+		var q model.Question
+		if err := json.Unmarshal([]byte(data), &q); err != nil {
+			return nil, err
+		}
+		questions[keys[i]] = &q
+	}
+	return questions, nil
+}
+
+func (c *playerCache) GetQuestionMapKeys(ctx context.Context, roomCode, playerID string) ([]string, error) {
+	return c.client.HKeys(ctx, c.qmapKey(roomCode, playerID)).Result()
 }
 
 // Closed parents
@@ -265,3 +391,226 @@ func (c *playerCache) GetAttempt(ctx context.Context, roomCode, playerID, questi
 	}
 	return &state, nil
 }
+
+// attemptUpdateRetries bounds how often UpdateAttempt retries after a concurrent write
+const attemptUpdateRetries = 10
+
+// UpdateAttempt reads the state under WATCH and writes update's result in a transaction,
+// retrying when another write to the attempt got in between
+func (c *playerCache) UpdateAttempt(ctx context.Context, roomCode, playerID, questionKey string, update func(state *model.AttemptState) (*model.AttemptState, error)) (*model.AttemptState, error) {
+	key := c.attemptKey(roomCode, playerID, questionKey)
+	var next *model.AttemptState
+	txf := func(tx *redis.Tx) error {
+		var current *model.AttemptState
+		data, err := tx.Get(ctx, key).Result()
+		if err != nil && err != redis.Nil {
+			return err
+		}
+		if err == nil {
+			current = &model.AttemptState{}
+			if err := json.Unmarshal([]byte(data), current); err != nil {
+				return err
+			}
+		}
+
+		if next, err = update(current); err != nil {
+			return err
+		}
+		encoded, err := json.Marshal(next)
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, encoded, c.ttl)
+			return nil
+		})
+		return err
+	}
+
+	for i := 0; i < attemptUpdateRetries; i++ {
+		err := c.client.Watch(ctx, txf, key)
+		if err == redis.TxFailedErr {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return next, nil
+	}
+	return nil, fmt.Errorf("attempt %s kept changing: %w", questionKey, redis.TxFailedErr)
+}
+
+// GetAttemptStates reads the attempt states of several questions with one MGET; missing ones are omitted
+func (c *playerCache) GetAttemptStates(ctx context.Context, roomCode, playerID string, questionKeys []string) (map[string]*model.AttemptState, error) {
+	states := make(map[string]*model.AttemptState, len(questionKeys))
+	if len(questionKeys) == 0 {
+		return states, nil
+	}
+	keys := make([]string, len(questionKeys))
+	for i, qk := range questionKeys {
+		keys[i] = c.attemptKey(roomCode, playerID, qk)
+	}
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range values {
+		data, ok := v.(string)
+		if !ok {
+			continue
+		}
+		var state model.AttemptState
+		if err := json.Unmarshal([]byte(data), &state); err != nil {
+			continue
+		}
+		states[questionKeys[i]] = &state
+	}
+	return states, nil
+}
+
+// GetPlayerState reads the player, their current key, and that question's map
+// entry (falling back to the base question) and attempt state. The second pipeline depends on the current key, so
+// this is two round trips instead of four sequential calls.
+func (c *playerCache) GetPlayerState(ctx context.Context, roomCode, playerID string) (*model.CurrentState, error) {
+	pipe := c.client.Pipeline()
+	playerCmd := pipe.HGet(ctx, c.playersKey(roomCode), playerID)
+	currentCmd := pipe.Get(ctx, c.currentKey(roomCode, playerID))
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	state := &model.CurrentState{}
+	if data, err := playerCmd.Bytes(); err == nil {
+		var player model.Player
+		if err := json.Unmarshal(data, &player); err != nil {
+			return nil, err
+		}
+		state.Player = &player
+	} else if err != redis.Nil {
+		return nil, err
+	}
+	if key, err := currentCmd.Result(); err == nil {
+		state.CurrentKey = key
+	} else if err != redis.Nil {
+		return nil, err
+	}
+	if state.CurrentKey == "" {
+		return state, nil
+	}
+
+	pipe = c.client.Pipeline()
+	qmapCmd := pipe.HGet(ctx, c.qmapKey(roomCode, playerID), state.CurrentKey)
+	baseCmd := pipe.HGet(ctx, c.baseQuestionsKey(roomCode), state.CurrentKey)
+	attemptCmd := pipe.Get(ctx, c.attemptKey(roomCode, playerID, state.CurrentKey))
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+	q, err := decodeQuestion(qmapCmd, baseCmd)
+	if err != nil {
+		return nil, err
+	}
+	state.Question = q
+	if data, err := attemptCmd.Bytes(); err == nil {
+		var attempt model.AttemptState
+		if err := json.Unmarshal(data, &attempt); err != nil {
+			return nil, err
+		}
+		state.Attempt = &attempt
+	} else if err != redis.Nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Submit results
+
+// ClaimSubmit records a pending result for a new clientAttemptId. It returns
+// false if the attempt was already claimed by an earlier submission.
+func (c *playerCache) ClaimSubmit(ctx context.Context, roomCode, playerID, questionKey, clientAttemptID string) (bool, error) {
+	pending, err := json.Marshal(&model.SubmitAnswerResponse{Status: model.AnswerStatusSubmitted})
+	if err != nil {
+		return false, err
+	}
+	return c.client.SetNX(ctx, c.submitKey(roomCode, playerID, questionKey, clientAttemptID), pending, c.ttl).Result()
+}
+
+func (c *playerCache) SetSubmitResult(ctx context.Context, roomCode, playerID, questionKey, clientAttemptID string, resp *model.SubmitAnswerResponse) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, c.submitKey(roomCode, playerID, questionKey, clientAttemptID), data, c.ttl).Err()
+}
+
+func (c *playerCache) GetSubmitResult(ctx context.Context, roomCode, playerID, questionKey, clientAttemptID string) (*model.SubmitAnswerResponse, error) {
+	data, err := c.client.Get(ctx, c.submitKey(roomCode, playerID, questionKey, clientAttemptID)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var resp model.SubmitAnswerResponse
+	if err := json.Unmarshal([]byte(data), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *playerCache) DeleteSubmitResult(ctx context.Context, roomCode, playerID, questionKey, clientAttemptID string) error {
+	return c.client.Del(ctx, c.submitKey(roomCode, playerID, questionKey, clientAttemptID)).Err()
+}
+
+// Completion
+func (c *playerCache) MarkDone(ctx context.Context, roomCode, playerID string) error {
+	return c.client.SAdd(ctx, c.doneKey(roomCode), playerID).Err()
+}
+
+func (c *playerCache) GetDonePlayers(ctx context.Context, roomCode string) ([]string, error) {
+	return c.client.SMembers(ctx, c.doneKey(roomCode)).Result()
+}
+
+// GetAttempts returns every attempt state of a player, keyed by question
+func (c *playerCache) GetAttempts(ctx context.Context, roomCode, playerID string) (map[string]*model.AttemptState, error) {
+	prefix := c.attemptKey(roomCode, playerID, "")
+	var questionKeys []string
+	iter := c.client.Scan(ctx, 0, prefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		questionKeys = append(questionKeys, strings.TrimPrefix(iter.Val(), prefix))
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return c.GetAttemptStates(ctx, roomCode, playerID, questionKeys)
+}
+
+// DeletePlayer removes the player and all of their per-player keys. Returns keys deleted.
+func (c *playerCache) DeletePlayer(ctx context.Context, roomCode, playerID string) (int, error) {
+	keys := []string{
+		c.queueKey(roomCode, playerID),
+		c.currentKey(roomCode, playerID),
+		c.qmapKey(roomCode, playerID),
+		c.closedKey(roomCode, playerID),
+	}
+	for _, pattern := range []string{
+		c.attemptKey(roomCode, playerID, "*"),
+		c.submitKey(roomCode, playerID, "*", "*"),
+	} {
+		iter := c.client.Scan(ctx, 0, pattern, 100).Iterator()
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+		}
+		if err := iter.Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	pipe := c.client.TxPipeline()
+	pipe.HDel(ctx, c.playersKey(roomCode), playerID)
+	pipe.SRem(ctx, c.doneKey(roomCode), playerID)
+	del := pipe.Del(ctx, keys...)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return int(del.Val()), nil
+}
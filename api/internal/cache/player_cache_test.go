@@ -0,0 +1,156 @@
+package cache
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	benchRoom      = "BENCH1"
+	benchPlayer    = "p1"
+	benchQuestions = 20
+)
+
+// newBenchPlayerCache returns a player cache on an in-process Redis holding one
+// room: base questions Q1..Q20, and a player on Q5 with follow-ups for every
+// other question in their own question map
+func newBenchPlayerCache(b *testing.B) (*playerCache, []string) {
+	b.Helper()
+	server := miniredis.RunT(b)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	b.Cleanup(func() { client.Close() })
+	c := NewPlayerCache(client).(*playerCache)
+	ctx := context.Background()
+
+	keys := make([]string, benchQuestions)
+	base := make([]*model.Question, benchQuestions)
+	for i := range base {
+		keys[i] = fmt.Sprintf("Q%d", i+1)
+		base[i] = &model.Question{Key: keys[i], Type: model.QuestionTypeEssay, Prompt: "Tell us about " + keys[i], PointsMax: 100}
+	}
+	fatalIf(b, c.SetBaseQuestions(ctx, benchRoom, base))
+	for i := 0; i < benchQuestions; i += 2 {
+		followUp := &model.Question{Key: keys[i], ParentKey: keys[i], Type: model.QuestionTypeEssay, Prompt: "Why " + keys[i] + "?"}
+		fatalIf(b, c.SetQuestionMap(ctx, benchRoom, benchPlayer, keys[i], followUp))
+	}
+	fatalIf(b, c.SetPlayer(ctx, benchRoom, benchPlayer, &model.Player{ID: benchPlayer, Nickname: "bench", Score: 300}))
+	fatalIf(b, c.SetCurrent(ctx, benchRoom, benchPlayer, "Q5"))
+	fatalIf(b, c.SetAttempt(ctx, benchRoom, benchPlayer, "Q5", &model.AttemptState{DraftAnswer: "draft", DraftVersion: 3, Status: model.AnswerStatusDraft, UpdatedAt: time.Now().UTC()}))
+	return c, keys
+}
+
+func fatalIf(b *testing.B, err error) {
+	b.Helper()
+	if err != nil {
+		b.Fatal(err)
+	}
+}
+
+// sequentialQuestionMap is GetQuestionMap as separate reads of the player's
+// map and then the base questions, as before the reads were pipelined
+func sequentialQuestionMap(ctx context.Context, c *playerCache, roomCode, playerID, key string) (*model.Question, error) {
+	q, err := decodeQuestion(c.client.HGet(ctx, c.qmapKey(roomCode, playerID), key))
+	if q != nil || err != nil {
+		return q, err
+	}
+	return decodeQuestion(c.client.HGet(ctx, c.baseQuestionsKey(roomCode), key))
+}
+
+// sequentialPlayerState is GetPlayerState as one call per value
+func sequentialPlayerState(ctx context.Context, c *playerCache, roomCode, playerID string) (*model.CurrentState, error) {
+	player, err := c.GetPlayer(ctx, roomCode, playerID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := c.GetCurrent(ctx, roomCode, playerID)
+	if err != nil || current == "" {
+		return &model.CurrentState{Player: player}, err
+	}
+	q, err := sequentialQuestionMap(ctx, c, roomCode, playerID, current)
+	if err != nil {
+		return nil, err
+	}
+	attempt, err := c.GetAttempt(ctx, roomCode, playerID, current)
+	if err != nil {
+		return nil, err
+	}
+	return &model.CurrentState{Player: player, CurrentKey: current, Question: q, Attempt: attempt}, nil
+}
+
+// sequentialQuestionMaps is GetQuestionMaps as one sequentialQuestionMap per key
+func sequentialQuestionMaps(ctx context.Context, c *playerCache, roomCode, playerID string, keys []string) (map[string]*model.Question, error) {
+	questions := make(map[string]*model.Question, len(keys))
+	for _, key := range keys {
+		q, err := sequentialQuestionMap(ctx, c, roomCode, playerID, key)
+		if err != nil {
+			return nil, err
+		}
+		if q != nil {
+			questions[key] = q
+		}
+	}
+	return questions, nil
+}
+
+func BenchmarkGetPlayerState(b *testing.B) {
+	c, _ := newBenchPlayerCache(b)
+	ctx := context.Background()
+
+	want, err := sequentialPlayerState(ctx, c, benchRoom, benchPlayer)
+	fatalIf(b, err)
+	got, err := c.GetPlayerState(ctx, benchRoom, benchPlayer)
+	fatalIf(b, err)
+	if !reflect.DeepEqual(got, want) {
+		b.Fatalf("pipelined state %+v differs from sequential %+v", got, want)
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := sequentialPlayerState(ctx, c, benchRoom, benchPlayer); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("pipelined", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := c.GetPlayerState(ctx, benchRoom, benchPlayer); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkGetQuestionMaps(b *testing.B) {
+	c, keys := newBenchPlayerCache(b)
+	ctx := context.Background()
+
+	want, err := sequentialQuestionMaps(ctx, c, benchRoom, benchPlayer, keys)
+	fatalIf(b, err)
+	got, err := c.GetQuestionMaps(ctx, benchRoom, benchPlayer, keys)
+	fatalIf(b, err)
+	if !reflect.DeepEqual(got, want) {
+		b.Fatalf("pipelined questions differ from sequential: %d vs %d", len(got), len(want))
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := sequentialQuestionMaps(ctx, c, benchRoom, benchPlayer, keys); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("pipelined", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := c.GetQuestionMaps(ctx, benchRoom, benchPlayer, keys); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
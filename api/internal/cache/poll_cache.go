@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// PollCache holds a room's open live poll and its votes until the poll closes
+type PollCache interface {
+	// Open makes poll the room's open poll; false while another one is open
+	Open(ctx context.Context, roomCode string, poll *model.Poll) (bool, error)
+	// GetOpen returns the room's open poll, nil when there is none
+	GetOpen(ctx context.Context, roomCode string) (*model.Poll, error)
+	// Close clears the open poll if it is pollID; false if it was already closed
+	Close(ctx context.Context, roomCode, pollID string) (bool, error)
+	// Vote records a player's choice once; false if the player already voted
+	Vote(ctx context.Context, roomCode, pollID, playerID string, choice int) (bool, error)
+	// Votes returns each voter's choice
+	Votes(ctx context.Context, roomCode, pollID string) (map[string]int, error)
+}
+
+type pollCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewPollCache creates a new poll cache
+func NewPollCache(client *redis.Client) PollCache {
+	return &pollCache{
+		client: client,
+		ttl:    24 * time.Hour,
+	}
+}
+
+func (c *pollCache) key(roomCode string) string {
+	return fmt.Sprintf("room:%s:poll", roomCode)
+}
+
+func (c *pollCache) votesKey(roomCode, pollID string) string {
+	return fmt.Sprintf("room:%s:poll:%s:votes", roomCode, pollID)
+}
+
+func (c *pollCache) Open(ctx context.Context, roomCode string, poll *model.Poll) (bool, error) {
+	data, err := json.Marshal(poll)
+	if err != nil {
+		return false, err
+	}
+	return c.client.SetNX(ctx, c.key(roomCode), data, c.ttl).Result()
+}
+
+func (c *pollCache) GetOpen(ctx context.Context, roomCode string) (*model.Poll, error) {
+	data, err := c.client.Get(ctx, c.key(roomCode)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var poll model.Poll
+	if err := json.Unmarshal([]byte(data), &poll); err != nil {
+		return nil, err
+	}
+	return &poll, nil
+}
+
+// closeScript deletes the open poll only if it is still the one being closed,
+// so exactly one caller tallies it
+var closeScript = redis.NewScript(`
+local data = redis.call("GET", KEYS[1])
+if not data or cjson.decode(data).id ~= ARGV[1] then
+	return 0
+end
+redis.call("DEL", KEYS[1])
+return 1
+`)
+
+func (c *pollCache) Close(ctx context.Context, roomCode, pollID string) (bool, error) {
+	n, err := closeScript.Run(ctx, c.client, []string{c.key(roomCode)}, pollID).Int()
+	return n == 1, err
+}
+
+func (c *pollCache) Vote(ctx context.Context, roomCode, pollID, playerID string, choice int) (bool, error) {
+	pipe := c.client.TxPipeline()
+	set := pipe.HSetNX(ctx, c.votesKey(roomCode, pollID), playerID, choice)
+	pipe.Expire(ctx, c.votesKey(roomCode, pollID), c.ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+	return set.Val(), nil
+}
+
+func (c *pollCache) Votes(ctx context.Context, roomCode, pollID string) (map[string]int, error) {
+	raw, err := c.client.HGetAll(ctx, c.votesKey(roomCode, pollID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	votes := make(map[string]int, len(raw))
+	for playerID, v := range raw {
+		if choice, err := strconv.Atoi(v); err == nil {
+			votes[playerID] = choice
+		}
+	}
+	return votes, nil
+}
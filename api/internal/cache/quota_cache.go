@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// QuotaCache counts respondents per MCQ option quota and tracks screened-out players
+type QuotaCache interface {
+	// Claim admits playerID into the option's quota; false once limit others are in
+	Claim(ctx context.Context, roomCode, questionKey string, option, limit int, playerID string) (bool, error)
+	Count(ctx context.Context, roomCode, questionKey string, option int) (int, error)
+	MarkScreenedOut(ctx context.Context, roomCode, playerID string) error
+	CountScreenedOut(ctx context.Context, roomCode string) (int, error)
+}
+
+type quotaCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewQuotaCache creates a new quota cache
+func NewQuotaCache(client *redis.Client) QuotaCache {
+	return &quotaCache{
+		client: client,
+		ttl:    24 * time.Hour,
+	}
+}
+
+func (c *quotaCache) key(roomCode, questionKey string, option int) string {
+	return fmt.Sprintf("room:%s:quota:%s:%d", roomCode, questionKey, option)
+}
+
+func (c *quotaCache) screenedKey(roomCode string) string {
+	return fmt.Sprintf("room:%s:screened", roomCode)
+}
+
+// claimScript adds the player to the option's set unless it is already full.
+// Members already in the set are admitted again, so resubmits are not counted twice.
+var claimScript = redis.NewScript(`
+if redis.call("SISMEMBER", KEYS[1], ARGV[1]) == 1 then
+	return 1
+end
+if redis.call("SCARD", KEYS[1]) >= tonumber(ARGV[2]) then
+	return 0
+end
+redis.call("SADD", KEYS[1], ARGV[1])
+redis.call("EXPIRE", KEYS[1], ARGV[3])
+return 1
+`)
+
+func (c *quotaCache) Claim(ctx context.Context, roomCode, questionKey string, option, limit int, playerID string) (bool, error) {
+	keys := []string{c.key(roomCode, questionKey, option)}
+	n, err := claimScript.Run(ctx, c.client, keys, playerID, limit, int(c.ttl.Seconds())).Int()
+	return n == 1, err
+}
+
+func (c *quotaCache) Count(ctx context.Context, roomCode, questionKey string, option int) (int, error) {
+	n, err := c.client.SCard(ctx, c.key(roomCode, questionKey, option)).Result()
+	return int(n), err
+}
+
+func (c *quotaCache) MarkScreenedOut(ctx context.Context, roomCode, playerID string) error {
+	pipe := c.client.Pipeline()
+	pipe.SAdd(ctx, c.screenedKey(roomCode), playerID)
+	pipe.Expire(ctx, c.screenedKey(roomCode), c.ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *quotaCache) CountScreenedOut(ctx context.Context, roomCode string) (int, error) {
+	n, err := c.client.SCard(ctx, c.screenedKey(roomCode)).Result()
+	return int(n), err
+}
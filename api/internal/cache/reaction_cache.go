@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Outcomes of ReactionCache.Add
+const (
+	ReactionDropped = iota // The player reached the per-window limit
+	ReactionCounted
+	ReactionFirst // Counted, and the first reaction of the room in this window
+)
+
+// ReactionCache counts player reactions per window and over the whole room
+type ReactionCache interface {
+	// Add counts one reaction in the window starting at window unless the player
+	// already sent max in it; returns ReactionDropped, ReactionCounted or ReactionFirst
+	Add(ctx context.Context, roomCode, playerID, emoji string, window time.Time, max int) (int, error)
+	// Window returns the window's counts per emoji and how many players reacted
+	Window(ctx context.Context, roomCode string, window time.Time) (map[string]int64, int, error)
+	// Totals returns the room's counts per emoji
+	Totals(ctx context.Context, roomCode string) (map[string]int64, error)
+}
+
+type reactionCache struct {
+	client    *redis.Client
+	ttl       time.Duration
+	windowTTL time.Duration
+}
+
+// NewReactionCache creates a new reaction cache
+func NewReactionCache(client *redis.Client) ReactionCache {
+	return &reactionCache{
+		client:    client,
+		ttl:       24 * time.Hour,
+		windowTTL: 5 * time.Minute,
+	}
+}
+
+func (c *reactionCache) key(roomCode string) string {
+	return fmt.Sprintf("room:%s:reactions", roomCode)
+}
+
+func (c *reactionCache) windowKey(roomCode string, window time.Time) string {
+	return fmt.Sprintf("room:%s:reactions:%d", roomCode, window.Unix())
+}
+
+func (c *reactionCache) playersKey(roomCode string, window time.Time) string {
+	return fmt.Sprintf("room:%s:reactions:%d:players", roomCode, window.Unix())
+}
+
+// addScript counts a player's tap, then the emoji in the window and room totals
+// if the player is still under the limit
+var addScript = redis.NewScript(`
+local n = redis.call("HINCRBY", KEYS[2], ARGV[1], 1)
+redis.call("EXPIRE", KEYS[2], ARGV[4])
+if n > tonumber(ARGV[3]) then
+	return 0
+end
+redis.call("HINCRBY", KEYS[1], ARGV[2], 1)
+redis.call("EXPIRE", KEYS[1], ARGV[4])
+redis.call("HINCRBY", KEYS[3], ARGV[2], 1)
+redis.call("EXPIRE", KEYS[3], ARGV[5])
+if n == 1 and redis.call("HLEN", KEYS[2]) == 1 then
+	return 2
+end
+return 1
+`)
+
+func (c *reactionCache) Add(ctx context.Context, roomCode, playerID, emoji string, window time.Time, max int) (int, error) {
+	keys := []string{c.windowKey(roomCode, window), c.playersKey(roomCode, window), c.key(roomCode)}
+	return addScript.Run(ctx, c.client, keys, playerID, emoji, max,
+		int(c.windowTTL.Seconds()), int(c.ttl.Seconds())).Int()
+}
+
+func (c *reactionCache) Window(ctx context.Context, roomCode string, window time.Time) (map[string]int64, int, error) {
+	pipe := c.client.Pipeline()
+	counts := pipe.HGetAll(ctx, c.windowKey(roomCode, window))
+	players := pipe.HLen(ctx, c.playersKey(roomCode, window))
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, 0, err
+	}
+	return parseCounts(counts.Val()), int(players.Val()), nil
+}
+
+func (c *reactionCache) Totals(ctx context.Context, roomCode string) (map[string]int64, error) {
+	raw, err := c.client.HGetAll(ctx, c.key(roomCode)).Result()
+	if err != nil {
+		return nil, err
+	}
+	return parseCounts(raw), nil
+}
+
+func parseCounts(raw map[string]string) map[string]int64 {
+	out := make(map[string]int64, len(raw))
+	for field, v := range raw {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			out[field] = n
+		}
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SurveyCache holds survey documents read on hot paths (joins, follow-ups)
+type SurveyCache interface {
+	Get(ctx context.Context, id string) (*model.Survey, error)
+	Set(ctx context.Context, survey *model.Survey) error
+	Delete(ctx context.Context, id string) error
+}
+
+type surveyCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewSurveyCache creates a new survey cache
+func NewSurveyCache(client *redis.Client) SurveyCache {
+	return &surveyCache{
+		client: client,
+		ttl:    time.Hour, // Bounds staleness if an invalidation is ever missed
+	}
+}
+
+func (c *surveyCache) key(id string) string {
+	return fmt.Sprintf("survey:%s", id)
+}
+
+func (c *surveyCache) Get(ctx context.Context, id string) (*model.Survey, error) {
+	data, err := c.client.Get(ctx, c.key(id)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var survey model.Survey
+	if err := json.Unmarshal(data, &survey); err != nil {
+		return nil, err
+	}
+	return &survey, nil
+}
+
+func (c *surveyCache) Set(ctx context.Context, survey *model.Survey) error {
+	data, err := json.Marshal(survey)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, c.key(survey.ID), data, c.ttl).Err()
+}
+
+func (c *surveyCache) Delete(ctx context.Context, id string) error {
+	return c.client.Del(ctx, c.key(id)).Err()
+}
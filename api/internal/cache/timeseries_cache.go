@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Per-minute participation counters
+const (
+	MetricJoins         = "joins"
+	MetricSubmissions   = "submissions"
+	MetricSkips         = "skips"
+	MetricEvaluations   = "evaluations"
+	MetricReactions     = "reactions"
+	MetricEvalLatencyMs = "evalLatencyMs" // Sum over the minute; divide by evaluations for the mean
+)
+
+// TimeseriesCache counts room activity per minute for the host's participation curve
+type TimeseriesCache interface {
+	Incr(ctx context.Context, roomCode, metric string, at time.Time, by int64) error
+	// GetAll returns the counters keyed by the minute's Unix time, then metric
+	GetAll(ctx context.Context, roomCode string) (map[int64]map[string]int64, error)
+}
+
+type timeseriesCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewTimeseriesCache creates a new timeseries cache
+func NewTimeseriesCache(client *redis.Client) TimeseriesCache {
+	return &timeseriesCache{
+		client: client,
+		ttl:    48 * time.Hour,
+	}
+}
+
+func (c *timeseriesCache) key(roomCode string) string {
+	return fmt.Sprintf("room:%s:timeseries", roomCode)
+}
+
+// Incr adds to a metric in the minute of at; fields are "<unix minute>:<metric>"
+func (c *timeseriesCache) Incr(ctx context.Context, roomCode, metric string, at time.Time, by int64) error {
+	field := fmt.Sprintf("%d:%s", at.Truncate(time.Minute).Unix(), metric)
+	pipe := c.client.TxPipeline()
+	pipe.HIncrBy(ctx, c.key(roomCode), field, by)
+	pipe.Expire(ctx, c.key(roomCode), c.ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *timeseriesCache) GetAll(ctx context.Context, roomCode string) (map[int64]map[string]int64, error) {
+	fields, err := c.client.HGetAll(ctx, c.key(roomCode)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[int64]map[string]int64)
+	for field, value := range fields {
+		minute, metric, ok := strings.Cut(field, ":")
+		if !ok {
+			continue
+		}
+		ts, err := strconv.ParseInt(minute, 10, 64)
+		if err != nil {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		if out[ts] == nil {
+			out[ts] = make(map[string]int64)
+		}
+		out[ts][metric] = n
+	}
+	return out, nil
+}
@@ -1,6 +1,10 @@
 package config
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"strings"
+)
 
 // GeminiModels defines which Gemini models to use for different tasks
 type GeminiModels struct {
@@ -21,21 +25,103 @@ type GeminiModels struct {
 
 	// Report is for post-room AI report generation (deep analysis, not blocking)
 	Report string `json:"report"`
+
+	// Quality replaces L1Eval/FollowUp for questions that opt into the "quality" tier
+	Quality string `json:"quality"`
+}
+
+// EvalCacheConfig controls reuse of L1 evaluations for near-identical short answers
+type EvalCacheConfig struct {
+	// MaxWords is the longest answer (in words) eligible for reuse
+	MaxWords int `json:"maxWords"`
+
+	// SimilarityThreshold is the minimum token overlap (0-1) to reuse a cached result
+	SimilarityThreshold float64 `json:"similarityThreshold"`
+
+	// TTLSeconds is how long cached evaluations stay valid
+	TTLSeconds int `json:"ttlSeconds"`
+}
+
+// BatchConfig controls coalescing of L1 evaluations into one Gemini call
+type BatchConfig struct {
+	// WindowMS is how long to wait for more answers to the same question
+	WindowMS int `json:"windowMs"`
+
+	// MaxSize is the largest batch per call (1 disables batching)
+	MaxSize int `json:"maxSize"`
+}
+
+// EvidenceConfig controls how answers are sampled as evidence for AI reports
+type EvidenceConfig struct {
+	// Strategy is "stratified" (quality extremes, contrasting sentiment, outlier themes)
+	// or "first" (the first answers submitted)
+	Strategy string `json:"strategy"`
+
+	// PerQuestion is the most samples per question, host-starred answers included
+	PerQuestion int `json:"perQuestion"`
+}
+
+// BudgetConfig limits Gemini usage and configures the circuit breaker (0 = unlimited)
+type BudgetConfig struct {
+	MaxRoomCalls    int64 `json:"maxRoomCalls"`
+	MaxRoomTokens   int64 `json:"maxRoomTokens"`
+	MaxGlobalCalls  int64 `json:"maxGlobalCalls"` // Per UTC day
+	MaxGlobalTokens int64 `json:"maxGlobalTokens"`
+
+	// BreakerThreshold is the number of consecutive failures that trips the breaker
+	BreakerThreshold int `json:"breakerThreshold"`
+
+	// BreakerCooldownSeconds is how long the breaker stays open before retrying
+	BreakerCooldownSeconds int `json:"breakerCooldownSeconds"`
+}
+
+// ModelPricing is the USD price per million tokens of a model
+type ModelPricing struct {
+	InputPerMillion  float64 `json:"inputPerMillion"`
+	OutputPerMillion float64 `json:"outputPerMillion"`
+}
+
+// defaultPricing covers the models used by default; extend with GEMINI_PRICING
+var defaultPricing = map[string]ModelPricing{
+	"gemini-2.0-flash-exp": {InputPerMillion: 0.10, OutputPerMillion: 0.40},
+	"gemini-2.0-flash":     {InputPerMillion: 0.10, OutputPerMillion: 0.40},
+	"gemini-1.5-flash":     {InputPerMillion: 0.075, OutputPerMillion: 0.30},
+	"gemini-1.5-pro":       {InputPerMillion: 1.25, OutputPerMillion: 5.00},
 }
 
 // AIConfig holds all AI-related configuration
 type AIConfig struct {
-	APIKey    string       `json:"-"` // Never serialize
-	BaseURL   string       `json:"baseUrl"`
-	Models    GeminiModels `json:"models"`
-	TimeoutMS int          `json:"timeoutMs"`
+	APIKey    string          `json:"-"` // Never serialize
+	BaseURL   string          `json:"baseUrl"`
+	Models    GeminiModels    `json:"models"`
+	TimeoutMS int             `json:"timeoutMs"`
+	EvalCache EvalCacheConfig `json:"evalCache"`
+	Budget    BudgetConfig    `json:"budget"`
+	Batch     BatchConfig     `json:"batch"`
+	Evidence  EvidenceConfig  `json:"evidence"`
+
+	// L4RefreshSeconds is the interval of the room memory (contrast/friction) job
+	L4RefreshSeconds int `json:"l4RefreshSeconds"`
+
+	// CopilotSeconds is the interval of host co-pilot hints for live rooms (0 = on request only)
+	CopilotSeconds int `json:"copilotSeconds"`
+
+	// StreamFollowUps streams follow-up generation to the player as it is produced;
+	// in rooms with guardrails or a scope anchor it is held until the checks pass
+	StreamFollowUps bool `json:"streamFollowUps"`
+
+	// PromptAnswerChars caps how much of a player's answer is sent to Gemini (0 = no cap)
+	PromptAnswerChars int `json:"promptAnswerChars"`
+
+	// Pricing maps model name to token prices for cost reports
+	Pricing map[string]ModelPricing `json:"pricing"`
 }
 
 // DefaultAIConfig returns the default AI configuration
 func DefaultAIConfig() *AIConfig {
 	return &AIConfig{
 		APIKey:  os.Getenv("GEMINI_API_KEY"),
-		BaseURL: "https://generativelanguage.googleapis.com/v1beta/models",
+		BaseURL: getEnvOrDefault("GEMINI_BASE_URL", "https://generativelanguage.googleapis.com/v1beta/models"),
 		Models: GeminiModels{
 			// Fast models for real-time operations
 			L1Eval:    getEnvOrDefault("GEMINI_MODEL_L1", "gemini-2.0-flash-exp"),
@@ -46,9 +132,70 @@ func DefaultAIConfig() *AIConfig {
 			PoolGen:     getEnvOrDefault("GEMINI_MODEL_POOL", "gemini-2.0-flash-exp"),
 			ScopeAnchor: getEnvOrDefault("GEMINI_MODEL_SCOPE", "gemini-2.0-flash-exp"),
 			Report:      getEnvOrDefault("GEMINI_MODEL_REPORT", "gemini-2.0-flash-exp"),
+			Quality:     getEnvOrDefault("GEMINI_MODEL_QUALITY", "gemini-1.5-pro"),
 		},
 		TimeoutMS: 30000, // 30 second default timeout
+		EvalCache: EvalCacheConfig{
+			MaxWords:            getEnvIntOrDefault("EVAL_CACHE_MAX_WORDS", 6),
+			SimilarityThreshold: getEnvFloatOrDefault("EVAL_CACHE_SIMILARITY", 0.8),
+			TTLSeconds:          getEnvIntOrDefault("EVAL_CACHE_TTL_SECONDS", 1800),
+		},
+		Budget: BudgetConfig{
+			MaxRoomCalls:           int64(getEnvIntOrDefault("AI_BUDGET_ROOM_CALLS", 2000)),
+			MaxRoomTokens:          int64(getEnvIntOrDefault("AI_BUDGET_ROOM_TOKENS", 0)),
+			MaxGlobalCalls:         int64(getEnvIntOrDefault("AI_BUDGET_GLOBAL_CALLS", 0)),
+			MaxGlobalTokens:        int64(getEnvIntOrDefault("AI_BUDGET_GLOBAL_TOKENS", 0)),
+			BreakerThreshold:       getEnvIntOrDefault("AI_BREAKER_THRESHOLD", 5),
+			BreakerCooldownSeconds: getEnvIntOrDefault("AI_BREAKER_COOLDOWN_SECONDS", 60),
+		},
+		Batch: BatchConfig{
+			WindowMS: getEnvIntOrDefault("AI_BATCH_WINDOW_MS", 250),
+			MaxSize:  getEnvIntOrDefault("AI_BATCH_MAX_SIZE", 8),
+		},
+		Evidence: EvidenceConfig{
+			Strategy:    getEnvOrDefault("AI_EVIDENCE_STRATEGY", "stratified"),
+			PerQuestion: getEnvIntOrDefault("AI_EVIDENCE_SAMPLES", 5),
+		},
+		L4RefreshSeconds:  getEnvIntOrDefault("AI_L4_REFRESH_SECONDS", 60),
+		CopilotSeconds:    getEnvIntOrDefault("AI_COPILOT_SECONDS", 120),
+		StreamFollowUps:   getEnvOrDefault("GEMINI_STREAM_FOLLOWUPS", "true") == "true",
+		PromptAnswerChars: getEnvIntOrDefault("AI_PROMPT_ANSWER_CHARS", 2000),
+		Pricing:           loadPricing(os.Getenv("GEMINI_PRICING")),
+	}
+}
+
+// loadPricing merges "model=input:output;model2=input:output" (USD per 1M tokens) over the defaults
+func loadPricing(spec string) map[string]ModelPricing {
+	pricing := make(map[string]ModelPricing, len(defaultPricing))
+	for k, v := range defaultPricing {
+		pricing[k] = v
+	}
+	for _, entry := range strings.Split(spec, ";") {
+		name, prices, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			continue
+		}
+		in, out, ok := strings.Cut(prices, ":")
+		if !ok {
+			continue
+		}
+		inPrice, err1 := strconv.ParseFloat(in, 64)
+		outPrice, err2 := strconv.ParseFloat(out, 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		pricing[name] = ModelPricing{InputPerMillion: inPrice, OutputPerMillion: outPrice}
+	}
+	return pricing
+}
+
+// EstimateCost returns the USD cost of the given token counts; ok is false for unpriced models
+func (c *AIConfig) EstimateCost(model string, inputTokens, outputTokens int64) (float64, bool) {
+	p, ok := c.Pricing[model]
+	if !ok {
+		return 0, false
 	}
+	return (float64(inputTokens)*p.InputPerMillion + float64(outputTokens)*p.OutputPerMillion) / 1e6, true
 }
 
 // IsEnabled returns true if the AI API is configured
@@ -61,9 +208,32 @@ func (c *AIConfig) ModelEndpoint(model string) string {
 	return c.BaseURL + "/" + model + ":generateContent"
 }
 
+// ModelStreamEndpoint returns the server-sent events streaming endpoint for a given model
+func (c *AIConfig) ModelStreamEndpoint(model string) string {
+	return c.BaseURL + "/" + model + ":streamGenerateContent?alt=sse"
+}
+
 func getEnvOrDefault(key, defaultValue string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
 	}
 	return defaultValue
 }
+
+func getEnvIntOrDefault(key string, defaultValue int) int {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	}
+	return defaultValue
+}
+
+func getEnvFloatOrDefault(key string, defaultValue float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"2026champs/internal/model"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// PlanConfig holds the plans hosts can be put on and the plan of hosts without one
+type PlanConfig struct {
+	DefaultPlan string                      `json:"defaultPlan"`
+	Plans       map[string]model.PlanLimits `json:"plans"`
+}
+
+// DefaultPlanConfig returns the built-in free, pro and unlimited plans. PLANS_JSON
+// ({"name": {"maxSurveys": 5, ...}}) adds plans or replaces built-in ones, and
+// PLAN_DEFAULT picks the plan of hosts without one (unlimited, so existing
+// deployments are not limited until they opt in).
+func DefaultPlanConfig() *PlanConfig {
+	cfg := &PlanConfig{
+		DefaultPlan: getEnvOrDefault("PLAN_DEFAULT", model.PlanUnlimited),
+		Plans: map[string]model.PlanLimits{
+			model.PlanFree: {
+				MaxSurveys:         10,
+				MaxConcurrentRooms: 1,
+				MaxPlayersPerRoom:  30,
+				MonthlyAICalls:     2000,
+			},
+			model.PlanPro: {
+				MaxSurveys:         200,
+				MaxConcurrentRooms: 10,
+				MaxPlayersPerRoom:  500,
+				MonthlyAICalls:     100000,
+			},
+			model.PlanUnlimited: {},
+		},
+	}
+
+	if raw := os.Getenv("PLANS_JSON"); raw != "" {
+		var plans map[string]model.PlanLimits
+		if err := json.Unmarshal([]byte(raw), &plans); err != nil {
+			fmt.Printf("[Config] Ignoring invalid PLANS_JSON: %v\n", err)
+		}
+		for name, limits := range plans {
+			cfg.Plans[name] = limits
+		}
+	}
+	if _, ok := cfg.Plans[cfg.DefaultPlan]; !ok {
+		fmt.Printf("[Config] Unknown PLAN_DEFAULT %q, using %s\n", cfg.DefaultPlan, model.PlanUnlimited)
+		cfg.DefaultPlan = model.PlanUnlimited
+	}
+	return cfg
+}
@@ -0,0 +1,38 @@
+package config
+
+// RetentionConfig controls how long stored data is kept before it is purged.
+// A value of 0 days keeps the data forever.
+type RetentionConfig struct {
+	AnswersDays   int `json:"answersDays"`   // Raw player answers and their attachments
+	SnapshotsDays int `json:"snapshotsDays"` // Room-end snapshots
+	AIReportsDays int `json:"aiReportsDays"` // AI insight reports
+	SMRawDays     int `json:"smRawDays"`     // Raw SurveyMonkey responses
+	VoiceDays     int `json:"voiceDays"`     // Recorded voice answers (audio; transcripts stay on the answer)
+
+	// DryRun makes the scheduled purge only report what it would delete
+	DryRun bool `json:"dryRun"`
+
+	// TTLIndexes lets MongoDB expire documents itself where the collection has a usable date field
+	TTLIndexes bool `json:"ttlIndexes"`
+
+	// PurgeHourUTC is when the daily purge job runs
+	PurgeHourUTC int `json:"purgeHourUtc"`
+}
+
+// DefaultRetentionConfig returns the retention policy from the environment
+func DefaultRetentionConfig() *RetentionConfig {
+	cfg := &RetentionConfig{
+		AnswersDays:   getEnvIntOrDefault("RETENTION_ANSWERS_DAYS", 90),
+		SnapshotsDays: getEnvIntOrDefault("RETENTION_SNAPSHOTS_DAYS", 365),
+		AIReportsDays: getEnvIntOrDefault("RETENTION_AI_REPORTS_DAYS", 365),
+		SMRawDays:     getEnvIntOrDefault("RETENTION_SM_RAW_DAYS", 90),
+		VoiceDays:     getEnvIntOrDefault("RETENTION_VOICE_DAYS", 30),
+		DryRun:        getEnvOrDefault("RETENTION_DRY_RUN", "false") == "true",
+		TTLIndexes:    getEnvOrDefault("RETENTION_TTL_INDEXES", "true") == "true",
+		PurgeHourUTC:  getEnvIntOrDefault("RETENTION_PURGE_HOUR_UTC", 4),
+	}
+	if cfg.PurgeHourUTC < 0 || cfg.PurgeHourUTC > 23 {
+		cfg.PurgeHourUTC = 4
+	}
+	return cfg
+}
@@ -0,0 +1,110 @@
+// Package events is the catalog of real-time room events and the bus services publish them on.
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Audience is who in a room receives an event
+type Audience string
+
+const (
+	AudienceHost    Audience = "host"
+	AudiencePlayer  Audience = "player"  // One player, see Event.PlayerID
+	AudiencePlayers Audience = "players" // Every player in the room
+	AudienceRoom    Audience = "room"    // The host and every player
+)
+
+// Event is one message published to a room
+type Event struct {
+	Type     Type        `json:"type"`
+	RoomCode string      `json:"roomCode"`
+	Audience Audience    `json:"audience"`
+	PlayerID string      `json:"playerId,omitempty"` // Recipient when Audience is player
+	Payload  interface{} `json:"payload"`            // The payload struct registered for Type
+	At       time.Time   `json:"at"`
+
+	Seq int64 `json:"seq,omitempty"` // Position in the room's persisted stream, 0 when not persisted
+}
+
+// Handler processes a published event
+type Handler func(e *Event)
+
+// Middleware wraps the delivery of every event, e.g. to log, count or persist it
+type Middleware func(next Handler) Handler
+
+// Sink delivers events to connected clients and can drop their connections
+type Sink interface {
+	Dispatch(e *Event)
+	DisconnectRoom(roomCode string)
+	DisconnectPlayer(roomCode, playerID string)
+}
+
+// Bus publishes events through its middleware chain to a sink
+type Bus struct {
+	sink Sink
+
+	mu         sync.RWMutex
+	middleware []Middleware
+	handler    Handler
+}
+
+// NewBus creates a bus that delivers to sink
+func NewBus(sink Sink) *Bus {
+	return &Bus{sink: sink, handler: sink.Dispatch}
+}
+
+// Use appends middleware; the first one added sees each event first
+func (b *Bus) Use(mw ...Middleware) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.middleware = append(b.middleware, mw...)
+	handler := Handler(b.sink.Dispatch)
+	for i := len(b.middleware) - 1; i >= 0; i-- {
+		handler = b.middleware[i](handler)
+	}
+	b.handler = handler
+}
+
+// Publish sends an event through the middleware chain, stamping it if needed
+func (b *Bus) Publish(e *Event) {
+	if e.At.IsZero() {
+		e.At = time.Now()
+	}
+	b.mu.RLock()
+	handler := b.handler
+	b.mu.RUnlock()
+	handler(e)
+}
+
+// ToHost publishes an event to the room host
+func (b *Bus) ToHost(roomCode string, t Type, payload interface{}) {
+	b.Publish(&Event{Type: t, RoomCode: roomCode, Audience: AudienceHost, Payload: payload})
+}
+
+// ToPlayer publishes an event to one player
+func (b *Bus) ToPlayer(roomCode, playerID string, t Type, payload interface{}) {
+	b.Publish(&Event{Type: t, RoomCode: roomCode, Audience: AudiencePlayer, PlayerID: playerID, Payload: payload})
+}
+
+// ToPlayers publishes an event to every player in the room
+func (b *Bus) ToPlayers(roomCode string, t Type, payload interface{}) {
+	b.Publish(&Event{Type: t, RoomCode: roomCode, Audience: AudiencePlayers, Payload: payload})
+}
+
+// ToRoom publishes an event to the host and every player
+func (b *Bus) ToRoom(roomCode string, t Type, payload interface{}) {
+	b.Publish(&Event{Type: t, RoomCode: roomCode, Audience: AudienceRoom, Payload: payload})
+}
+
+// DisconnectRoom closes every connection to the room
+func (b *Bus) DisconnectRoom(roomCode string) {
+	b.sink.DisconnectRoom(roomCode)
+}
+
+// DisconnectPlayer closes one player's connection
+func (b *Bus) DisconnectPlayer(roomCode, playerID string) {
+	b.sink.DisconnectPlayer(roomCode, playerID)
+}
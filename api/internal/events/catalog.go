@@ -0,0 +1,339 @@
+package events
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/model"
+	"reflect"
+	"time"
+)
+
+// Type names an event; it is the "type" of the message clients receive
+type Type string
+
+// Host events
+const (
+	RoomStarted          Type = "room_started"
+	RoomEnded            Type = "room_ended"
+	PlayerJoined         Type = "player_joined"
+	PlayerLeft           Type = "player_left"
+	LeaderboardUpdate    Type = "leaderboard_update"
+	PlayerProgressUpdate Type = "player_progress_update"
+	AnalyticsUpdate      Type = "analytics_update"
+	AIDegraded           Type = "ai_degraded"
+	CompletionUpdate     Type = "completion_update"
+	PlayerIdle           Type = "player_idle"
+	PlayerActive         Type = "player_active"
+	LobbyUpdate          Type = "lobby_update" // Also sent to players
+	PlayerScreenedOut    Type = "player_screened_out"
+	PlayerAbandoned      Type = "player_abandoned"
+	QuestionHeatmap      Type = "question_heatmap"
+	CopilotHint          Type = "copilot_hint"
+	AnswerUpdated        Type = "answer_updated"   // From the answers change stream
+	AIReportStatus       Type = "ai_report_status" // From the ai_reports change stream
+	PollVotes            Type = "poll_votes"       // Running tally of the open live poll
+	ReactionPulse        Type = "reaction_pulse"   // Player reactions of the last window
+)
+
+// Shared events
+const (
+	Welcome        Type = "welcome" // First message on every connection
+	ReconnectHint  Type = "reconnect_hint"
+	ResyncRequired Type = "resync_required" // Events the client asked to resume from are gone
+	PollStarted    Type = "poll_started"    // The host launched a live poll
+	PollResults    Type = "poll_results"    // A live poll closed
+)
+
+// Player events
+const (
+	NextQuestion     Type = "next_question"
+	AIThinking       Type = "ai_thinking"
+	EvaluationResult Type = "evaluation_result"
+	FollowUpPartial  Type = "followup_partial"
+	EvalOverridden   Type = "evaluation_overridden"
+	Error            Type = "error"
+	ScreenedOut      Type = "screened_out"
+	LeftRoom         Type = "left_room"
+	PlayerFeedback   Type = "player_feedback" // Last event before the room disconnects
+)
+
+// Client messages (sent by clients to the server)
+const (
+	Activity       Type = "activity"        // Player is interacting with the page
+	CopilotRequest Type = "copilot_request" // Host asks for co-pilot hints now
+	Ack            Type = "ack"             // Client processed events up to an outbox ID
+	Reaction       Type = "reaction"        // Player tapped an emoji reaction
+)
+
+// WelcomePayload tells the client which protocol the server speaks
+type WelcomePayload struct {
+	ProtocolVersion    int  `json:"protocolVersion"`
+	MinProtocolVersion int  `json:"minProtocolVersion"`
+	ClientVersion      int  `json:"clientVersion"`
+	UpgradeRecommended bool `json:"upgradeRecommended"`
+}
+
+// RoomStartedPayload is sent to players when the host starts the room
+type RoomStartedPayload struct {
+	Status string `json:"status"`
+}
+
+// RoomEndedPayload summarizes the session for players and the host
+type RoomEndedPayload struct {
+	Status          string                   `json:"status"`
+	EndedAt         time.Time                `json:"endedAt"`
+	TotalPlayers    int                      `json:"totalPlayers"`
+	CompletionRate  float64                  `json:"completionRate"`
+	OverallSkipRate float64                  `json:"overallSkipRate"`
+	Abandoned       int                      `json:"abandoned"`
+	TopPlayers      []model.LeaderboardEntry `json:"topPlayers"`
+}
+
+// PlayerJoinedPayload is sent to the host when a player connects
+type PlayerJoinedPayload struct {
+	PlayerID string `json:"playerId"`
+	Nickname string `json:"nickname"`
+}
+
+// PlayerLeftPayload is sent to the host when a player disconnects
+type PlayerLeftPayload struct {
+	PlayerID string `json:"playerId"`
+}
+
+// LeaderboardUpdatePayload carries the current top of the leaderboard
+type LeaderboardUpdatePayload struct {
+	Leaderboard []cache.LeaderboardEntry `json:"leaderboard"`
+}
+
+// PlayerProgressPayload reports a player's submission state to the host
+type PlayerProgressPayload struct {
+	PlayerID    string `json:"playerId"`
+	QuestionKey string `json:"questionKey"`
+	Status      string `json:"status"`
+	Resolution  string `json:"resolution,omitempty"`
+	OptionIndex *int   `json:"optionIndex"`
+	Attachments int    `json:"attachments,omitempty"` // Images uploaded with the answer
+
+	Duplicate *model.DuplicateFlag `json:"duplicate,omitempty"` // The answer matches another player's
+}
+
+// AnswerUpdatedPayload tells the host a stored answer changed after it was
+// recorded, e.g. overridden, starred or audited
+type AnswerUpdatedPayload struct {
+	AnswerID      string   `json:"answerId"`
+	PlayerID      string   `json:"playerId"`
+	QuestionKey   string   `json:"questionKey"`
+	Status        string   `json:"status"`
+	Resolution    string   `json:"resolution,omitempty"`
+	PointsEarned  int      `json:"pointsEarned"`
+	Overridden    bool     `json:"overridden,omitempty"`
+	Starred       bool     `json:"starred,omitempty"`
+	UpdatedFields []string `json:"updatedFields,omitempty"` // Top-level answer fields the write set; empty for replacements
+}
+
+// AIReportStatusPayload tells the host the room's AI report moved to another status
+type AIReportStatusPayload struct {
+	Status  string     `json:"status"` // pending, generating, ready, failed
+	ReadyAt *time.Time `json:"readyAt,omitempty"`
+}
+
+// AnalyticsUpdatePayload carries refreshed per-question analytics
+type AnalyticsUpdatePayload struct {
+	QuestionKey string                 `json:"questionKey"`
+	Profile     *model.QuestionProfile `json:"profile"`
+	WordCloud   []model.WordCount      `json:"wordCloud,omitempty"` // WORDS questions
+}
+
+// AIDegradedPayload tells the host AI features are degraded or recovered
+type AIDegradedPayload struct {
+	Degraded bool   `json:"degraded"`
+	Reason   string `json:"reason"`
+}
+
+// CompletionUpdatePayload reports how many players finished the survey
+type CompletionUpdatePayload struct {
+	PlayerID       string  `json:"playerId"`
+	Completed      int     `json:"completed"`
+	Total          int     `json:"total"`
+	CompletionRate float64 `json:"completionRate"`
+}
+
+// PlayerIdlePayload tells the host a player has gone quiet
+type PlayerIdlePayload struct {
+	PlayerID     string    `json:"playerId"`
+	LastActiveAt time.Time `json:"lastActiveAt"`
+	IdleSeconds  int       `json:"idleSeconds"`
+}
+
+// PlayerActivePayload tells the host an idle player is back
+type PlayerActivePayload struct {
+	PlayerID string `json:"playerId"`
+}
+
+// PlayerScreenedOutPayload tells the host a full quota ended a player's survey
+type PlayerScreenedOutPayload struct {
+	PlayerID    string `json:"playerId"`
+	QuestionKey string `json:"questionKey"`
+	Option      int    `json:"option"`
+}
+
+// PlayerAbandonedPayload tells the host a player left the room for good
+type PlayerAbandonedPayload struct {
+	PlayerID               string `json:"playerId"`
+	Reason                 string `json:"reason"` // left, disconnected
+	AbandonedQuestions     int    `json:"abandonedQuestions"`
+	RemovedFromLeaderboard bool   `json:"removedFromLeaderboard"`
+}
+
+// LobbyUpdatePayload is the periodic roster broadcast while the room is in LOBBY
+type LobbyUpdatePayload = model.LobbyRoster
+
+// QuestionHeatmapPayload is the periodic per-question progress broadcast while the room is ACTIVE
+type QuestionHeatmapPayload = model.QuestionHeatmap
+
+// CopilotHintPayload carries facilitation suggestions for the host
+type CopilotHintPayload struct {
+	Hints   []model.CopilotHint `json:"hints"`
+	Trigger string              `json:"trigger"` // scheduled, requested
+	At      time.Time           `json:"at"`
+}
+
+// ReconnectHintPayload asks clients to reconnect, e.g. during a deploy
+type ReconnectHintPayload struct {
+	Reason       string `json:"reason"`
+	RetryAfterMs int    `json:"retryAfterMs"`
+}
+
+// ResyncRequiredPayload tells a resuming client that events after its last ID
+// were trimmed from the outbox; it should reload room state over REST
+type ResyncRequiredPayload struct {
+	LastEventID   string `json:"lastEventId"`
+	OldestEventID string `json:"oldestEventId,omitempty"`
+}
+
+// AckPayload is sent by clients to acknowledge events up to ID
+type AckPayload struct {
+	ID string `json:"id"`
+}
+
+// ReactionPayload is a player's emoji tap; emoji is one of model.ReactionEmojis
+type ReactionPayload struct {
+	Emoji string `json:"emoji"`
+}
+
+// NextQuestionPayload pushes the player's next question (reserved)
+type NextQuestionPayload struct {
+	Question *model.Question `json:"question"`
+}
+
+// AIThinkingPayload acknowledges a submission while it is evaluated
+type AIThinkingPayload struct {
+	QuestionKey string `json:"questionKey"`
+}
+
+// EvaluationResultPayload is the outcome of an evaluated submission
+type EvaluationResultPayload = model.SubmitAnswerResponse
+
+// FollowUpPartialPayload streams a follow-up prompt as it is generated
+type FollowUpPartialPayload struct {
+	QuestionKey string `json:"questionKey"`
+	ParentKey   string `json:"parentKey"`
+	Prompt      string `json:"prompt"`
+}
+
+// EvalOverriddenPayload tells a player the host changed their evaluation
+type EvalOverriddenPayload struct {
+	AnswerID     string `json:"answerId"`
+	QuestionKey  string `json:"questionKey"`
+	Resolution   string `json:"resolution"`
+	PointsEarned int    `json:"pointsEarned"`
+}
+
+// ScreenedOutPayload politely ends the survey for an over-quota player
+type ScreenedOutPayload struct {
+	Message string `json:"message"`
+}
+
+// LeftRoomPayload confirms to a player that they left; the connection closes after it
+type LeftRoomPayload struct {
+	Reason string `json:"reason"`
+}
+
+// PlayerFeedbackPayload is the player's end-of-room summary
+type PlayerFeedbackPayload = model.PlayerFeedback
+
+// ErrorPayload reports a failure to the client
+type ErrorPayload struct {
+	Message string `json:"message"`
+}
+
+// PollStartedPayload puts a live poll to the room; votes are accepted until closesAt
+type PollStartedPayload struct {
+	Poll model.Poll `json:"poll"`
+}
+
+// PollResultsPayload is the closed poll with its results, for the room's chart
+type PollResultsPayload struct {
+	Poll model.Poll `json:"poll"`
+}
+
+// PollVotesPayload is the host's live tally of the open poll
+type PollVotesPayload struct {
+	PollID  string `json:"pollId"`
+	Votes   int    `json:"votes"`
+	Counts  []int  `json:"counts"`
+	Players int    `json:"players"`
+}
+
+// ReactionPulsePayload is the host's engagement pulse: reactions counted in one
+// window per emoji and how many players reacted
+type ReactionPulsePayload struct {
+	WindowStart time.Time        `json:"windowStart"`
+	WindowEnd   time.Time        `json:"windowEnd"`
+	Counts      map[string]int64 `json:"counts"`
+	Total       int64            `json:"total"`
+	Players     int              `json:"players"`
+}
+
+// payloadTypes is the schema: the payload struct for every event type
+var payloadTypes = map[Type]reflect.Type{
+	Welcome:              reflect.TypeOf(WelcomePayload{}),
+	RoomStarted:          reflect.TypeOf(RoomStartedPayload{}),
+	RoomEnded:            reflect.TypeOf(RoomEndedPayload{}),
+	PlayerJoined:         reflect.TypeOf(PlayerJoinedPayload{}),
+	PlayerLeft:           reflect.TypeOf(PlayerLeftPayload{}),
+	LeaderboardUpdate:    reflect.TypeOf(LeaderboardUpdatePayload{}),
+	PlayerProgressUpdate: reflect.TypeOf(PlayerProgressPayload{}),
+	AnalyticsUpdate:      reflect.TypeOf(AnalyticsUpdatePayload{}),
+	AIDegraded:           reflect.TypeOf(AIDegradedPayload{}),
+	CompletionUpdate:     reflect.TypeOf(CompletionUpdatePayload{}),
+	PlayerIdle:           reflect.TypeOf(PlayerIdlePayload{}),
+	PlayerActive:         reflect.TypeOf(PlayerActivePayload{}),
+	LobbyUpdate:          reflect.TypeOf(LobbyUpdatePayload{}),
+	PlayerScreenedOut:    reflect.TypeOf(PlayerScreenedOutPayload{}),
+	PlayerAbandoned:      reflect.TypeOf(PlayerAbandonedPayload{}),
+	QuestionHeatmap:      reflect.TypeOf(QuestionHeatmapPayload{}),
+	CopilotHint:          reflect.TypeOf(CopilotHintPayload{}),
+	AnswerUpdated:        reflect.TypeOf(AnswerUpdatedPayload{}),
+	AIReportStatus:       reflect.TypeOf(AIReportStatusPayload{}),
+	ReconnectHint:        reflect.TypeOf(ReconnectHintPayload{}),
+	ResyncRequired:       reflect.TypeOf(ResyncRequiredPayload{}),
+	PollStarted:          reflect.TypeOf(PollStartedPayload{}),
+	PollResults:          reflect.TypeOf(PollResultsPayload{}),
+	PollVotes:            reflect.TypeOf(PollVotesPayload{}),
+	ReactionPulse:        reflect.TypeOf(ReactionPulsePayload{}),
+	NextQuestion:         reflect.TypeOf(NextQuestionPayload{}),
+	AIThinking:           reflect.TypeOf(AIThinkingPayload{}),
+	EvaluationResult:     reflect.TypeOf(EvaluationResultPayload{}),
+	FollowUpPartial:      reflect.TypeOf(FollowUpPartialPayload{}),
+	EvalOverridden:       reflect.TypeOf(EvalOverriddenPayload{}),
+	Error:                reflect.TypeOf(ErrorPayload{}),
+	ScreenedOut:          reflect.TypeOf(ScreenedOutPayload{}),
+	LeftRoom:             reflect.TypeOf(LeftRoomPayload{}),
+	PlayerFeedback:       reflect.TypeOf(PlayerFeedbackPayload{}),
+}
+
+// PayloadType returns the payload struct registered for t
+func PayloadType(t Type) (reflect.Type, bool) {
+	typ, ok := payloadTypes[t]
+	return typ, ok
+}
@@ -0,0 +1,102 @@
+package events
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Logging logs every event except the types in skip (e.g. chatty lobby updates)
+func Logging(skip ...Type) Middleware {
+	skipped := make(map[Type]bool, len(skip))
+	for _, t := range skip {
+		skipped[t] = true
+	}
+	return func(next Handler) Handler {
+		return func(e *Event) {
+			if !skipped[e.Type] {
+				if e.PlayerID != "" {
+					log.Printf("[Events] %s %s -> %s %s", e.RoomCode, e.Type, e.Audience, e.PlayerID)
+				} else {
+					log.Printf("[Events] %s %s -> %s", e.RoomCode, e.Type, e.Audience)
+				}
+			}
+			next(e)
+		}
+	}
+}
+
+// Metrics counts published events by type and audience
+type Metrics struct {
+	mu         sync.Mutex
+	since      time.Time
+	total      int64
+	byType     map[Type]int64
+	byAudience map[Audience]int64
+}
+
+// MetricsSnapshot is a copy of the counters
+type MetricsSnapshot struct {
+	Since      time.Time          `json:"since"`
+	Total      int64              `json:"total"`
+	ByType     map[Type]int64     `json:"byType"`
+	ByAudience map[Audience]int64 `json:"byAudience"`
+}
+
+// NewMetrics creates empty counters
+func NewMetrics() *Metrics {
+	return &Metrics{
+		since:      time.Now(),
+		byType:     make(map[Type]int64),
+		byAudience: make(map[Audience]int64),
+	}
+}
+
+// Middleware counts each event before passing it on
+func (m *Metrics) Middleware() Middleware {
+	return func(next Handler) Handler {
+		return func(e *Event) {
+			m.mu.Lock()
+			m.total++
+			m.byType[e.Type]++
+			m.byAudience[e.Audience]++
+			m.mu.Unlock()
+			next(e)
+		}
+	}
+}
+
+// Snapshot returns the counters since the process started
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snap := MetricsSnapshot{
+		Since:      m.since,
+		Total:      m.total,
+		ByType:     make(map[Type]int64, len(m.byType)),
+		ByAudience: make(map[Audience]int64, len(m.byAudience)),
+	}
+	for t, n := range m.byType {
+		snap.ByType[t] = n
+	}
+	for a, n := range m.byAudience {
+		snap.ByAudience[a] = n
+	}
+	return snap
+}
+
+// Recorder stores the event stream; Record may stamp Seq and must not block delivery for long
+type Recorder interface {
+	Record(e *Event)
+}
+
+// Persist hands every event to r before it is delivered
+func Persist(r Recorder) Middleware {
+	return func(next Handler) Handler {
+		return func(e *Event) {
+			r.Record(e)
+			next(e)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Server error codes for a missing collection or index, or a collection that already exists
+const (
+	namespaceNotFound = 26
+	indexNotFound     = 27
+	namespaceExists   = 48
+)
+
+// IndexSpec is an index a collection is expected to have
+type IndexSpec struct {
+	Collection string
+	Keys       bson.D
+	Unique     bool
+	Partial    bson.M // Optional partialFilterExpression
+}
+
+// String renders the spec like Mongo's default index name, prefixed by its collection
+func (s IndexSpec) String() string {
+	name := s.Collection + "." + keyPattern(s.Keys)
+	if s.Unique {
+		name += " (unique)"
+	}
+	return name
+}
+
+func (s IndexSpec) model() mongo.IndexModel {
+	opts := options.Index().SetUnique(s.Unique)
+	if s.Partial != nil {
+		opts.SetPartialFilterExpression(s.Partial)
+	}
+	return mongo.IndexModel{Keys: s.Keys, Options: opts}
+}
+
+// RequiredIndexes lists every index the repositories rely on, for verification
+func RequiredIndexes() []IndexSpec {
+	specs := append(currentCoreIndexSpecs(), experimentIndexSpecs()...)
+	specs = append(specs, auditIndexSpecs()...)
+	specs = append(specs, eventIndexSpecs()...)
+	specs = append(specs, feedbackIndexSpecs()...)
+	specs = append(specs, smScheduleIndexSpecs()...)
+	specs = append(specs, roomTemplateIndexSpecs()...)
+	specs = append(specs, surveyDifficultyIndexSpecs()...)
+	specs = append(specs, eventGroupIndexSpecs()...)
+	specs = append(specs, roomHostIndexSpecs()...)
+	specs = append(specs, pollIndexSpecs()...)
+	specs = append(specs, comparisonIndexSpecs()...)
+	specs = append(specs, digestIndexSpecs()...)
+	return append(specs, smIndexSpecs()...)
+}
+
+// coreIndexSpecs covers the live room collections
+func coreIndexSpecs() []IndexSpec {
+	return []IndexSpec{
+		{Collection: "answers", Keys: bson.D{{Key: "roomCode", Value: 1}, {Key: "playerId", Value: 1}, {Key: "questionKey", Value: 1}}},
+		{
+			Collection: "answers",
+			Keys:       bson.D{{Key: "clientAttemptId", Value: 1}},
+			Unique:     true,
+			Partial:    bson.M{"clientAttemptId": bson.M{"$gt": ""}}, // Older answers may lack one
+		},
+		{Collection: "rooms", Keys: bson.D{{Key: "code", Value: 1}}, Unique: true},
+		{Collection: "surveys", Keys: bson.D{{Key: "hostId", Value: 1}}},
+		{Collection: "room_snapshots", Keys: bson.D{{Key: "roomCode", Value: 1}}, Unique: true},
+		{Collection: "ai_reports", Keys: bson.D{{Key: "roomCode", Value: 1}}, Unique: true},
+	}
+}
+
+// currentCoreIndexSpecs is coreIndexSpecs with the indexes later migrations replaced:
+// answers_attempt_unique (v4) swaps the global clientAttemptId index for answerAttemptIndex
+func currentCoreIndexSpecs() []IndexSpec {
+	var specs []IndexSpec
+	for _, spec := range coreIndexSpecs() {
+		if spec.Collection == "answers" && keyPattern(spec.Keys) == "clientAttemptId_1" {
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	return append(specs, answerAttemptIndex())
+}
+
+// answerAttemptIndex makes each submit attempt persist once; AnswerRepo.Create upserts on it.
+// Skipped and abandoned answers carry no clientAttemptId and are left out.
+func answerAttemptIndex() IndexSpec {
+	return IndexSpec{
+		Collection: "answers",
+		Keys: bson.D{
+			{Key: "roomCode", Value: 1},
+			{Key: "playerId", Value: 1},
+			{Key: "questionKey", Value: 1},
+			{Key: "clientAttemptId", Value: 1},
+		},
+		Unique:  true,
+		Partial: bson.M{"clientAttemptId": bson.M{"$gt": ""}},
+	}
+}
+
+// experimentIndexSpecs covers A/B experiments; an assignment is per room (empty
+// playerId) or per player
+func experimentIndexSpecs() []IndexSpec {
+	return []IndexSpec{
+		{Collection: "experiments", Keys: bson.D{{Key: "hostId", Value: 1}}},
+		{Collection: "experiment_assignments", Keys: bson.D{{Key: "experimentId", Value: 1}, {Key: "roomCode", Value: 1}, {Key: "playerId", Value: 1}}, Unique: true},
+	}
+}
+
+// auditIndexSpecs covers room audit trails, read per room in time order
+func auditIndexSpecs() []IndexSpec {
+	return []IndexSpec{
+		{Collection: "room_audit", Keys: bson.D{{Key: "roomCode", Value: 1}, {Key: "at", Value: 1}}},
+	}
+}
+
+// eventIndexSpecs covers the persisted room event stream, read per room in sequence order
+func eventIndexSpecs() []IndexSpec {
+	return []IndexSpec{
+		{Collection: "room_events", Keys: bson.D{{Key: "roomCode", Value: 1}, {Key: "seq", Value: 1}}},
+	}
+}
+
+// feedbackIndexSpecs covers end-of-room player feedback, one per room and player
+func feedbackIndexSpecs() []IndexSpec {
+	return []IndexSpec{
+		{Collection: "player_feedback", Keys: bson.D{{Key: "roomCode", Value: 1}, {Key: "playerId", Value: 1}}, Unique: true},
+	}
+}
+
+// smScheduleIndexSpecs covers automatic SM sync schedules
+func smScheduleIndexSpecs() []IndexSpec {
+	return []IndexSpec{
+		{Collection: "sm_sync_schedules", Keys: bson.D{{Key: "survey_id", Value: 1}}, Unique: true},
+		{Collection: "sm_sync_schedules", Keys: bson.D{{Key: "enabled", Value: 1}, {Key: "next_run_at", Value: 1}}},
+	}
+}
+
+// roomTemplateIndexSpecs covers room templates, listed per host
+func roomTemplateIndexSpecs() []IndexSpec {
+	return []IndexSpec{
+		{Collection: "room_templates", Keys: bson.D{{Key: "hostId", Value: 1}}},
+	}
+}
+
+// surveyDifficultyIndexSpecs covers the per-survey difficulty calibrations
+func surveyDifficultyIndexSpecs() []IndexSpec {
+	return []IndexSpec{
+		{Collection: "survey_difficulty", Keys: bson.D{{Key: "surveyId", Value: 1}}, Unique: true},
+	}
+}
+
+// eventGroupIndexSpecs covers multi-room events, listed per host
+func eventGroupIndexSpecs() []IndexSpec {
+	return []IndexSpec{
+		{Collection: "event_groups", Keys: bson.D{{Key: "hostId", Value: 1}, {Key: "createdAt", Value: -1}}},
+	}
+}
+
+// roomHostIndexSpecs lets plan limits count a host's open rooms
+func roomHostIndexSpecs() []IndexSpec {
+	return []IndexSpec{
+		{Collection: "rooms", Keys: bson.D{{Key: "hostId", Value: 1}, {Key: "status", Value: 1}}},
+	}
+}
+
+// pollIndexSpecs covers live polls, read per room in launch order
+func pollIndexSpecs() []IndexSpec {
+	return []IndexSpec{
+		{Collection: "polls", Keys: bson.D{{Key: "roomCode", Value: 1}, {Key: "startedAt", Value: 1}}},
+	}
+}
+
+// comparisonIndexSpecs covers room comparisons, listed per host newest first
+func comparisonIndexSpecs() []IndexSpec {
+	return []IndexSpec{
+		{Collection: "comparison_reports", Keys: bson.D{{Key: "hostId", Value: 1}, {Key: "createdAt", Value: -1}}},
+	}
+}
+
+// digestIndexSpecs lets the weekly digest job find the hosts who opted in
+func digestIndexSpecs() []IndexSpec {
+	return []IndexSpec{
+		{Collection: "digest_settings", Keys: bson.D{{Key: "enabled", Value: 1}}},
+	}
+}
+
+// smIndexSpecs covers the SurveyMonkey collections
+func smIndexSpecs() []IndexSpec {
+	return []IndexSpec{
+		{Collection: "sm_responses_raw", Keys: bson.D{{Key: "response_id", Value: 1}}, Unique: true},
+		{Collection: "sm_responses_raw", Keys: bson.D{{Key: "survey_id", Value: 1}, {Key: "date_modified", Value: -1}}},
+		{Collection: "sm_responses_raw", Keys: bson.D{{Key: "collector_id", Value: 1}, {Key: "date_modified", Value: -1}}},
+		{Collection: "sm_answers", Keys: bson.D{{Key: "response_id", Value: 1}}},
+		{Collection: "sm_answers", Keys: bson.D{{Key: "survey_id", Value: 1}, {Key: "question_id", Value: 1}}},
+		{Collection: "sm_answers", Keys: bson.D{{Key: "question_id", Value: 1}, {Key: "choice_id", Value: 1}}},
+		{Collection: "sm_response_features", Keys: bson.D{{Key: "response_id", Value: 1}}, Unique: true},
+		{Collection: "sm_response_features", Keys: bson.D{{Key: "survey_id", Value: 1}, {Key: "submitted_at", Value: -1}}},
+		{Collection: "sm_response_features", Keys: bson.D{{Key: "survey_id", Value: 1}, {Key: "overall_satisfaction", Value: 1}}},
+		{Collection: "sm_collectors", Keys: bson.D{{Key: "collector_id", Value: 1}}, Unique: true},
+		{Collection: "sm_collectors", Keys: bson.D{{Key: "survey_id", Value: 1}}},
+	}
+}
+
+// EnsureIndexes creates specs grouped by collection. Creating an index that
+// already exists with the same options is a no-op.
+func EnsureIndexes(ctx context.Context, db *mongo.Database, specs []IndexSpec) error {
+	order := []string{}
+	byCollection := map[string][]mongo.IndexModel{}
+	for _, s := range specs {
+		if _, ok := byCollection[s.Collection]; !ok {
+			order = append(order, s.Collection)
+		}
+		byCollection[s.Collection] = append(byCollection[s.Collection], s.model())
+	}
+	for _, coll := range order {
+		if _, err := db.Collection(coll).Indexes().CreateMany(ctx, byCollection[coll]); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return fmt.Errorf("%s: existing documents violate a unique index, remove the duplicates first: %w", coll, err)
+			}
+			return fmt.Errorf("%s: %w", coll, err)
+		}
+	}
+	return nil
+}
+
+// MissingIndexes returns the specs with no index of the same keys and uniqueness
+func MissingIndexes(ctx context.Context, db *mongo.Database, specs []IndexSpec) ([]IndexSpec, error) {
+	existing := map[string]map[string]bool{} // collection -> key pattern -> unique
+	missing := []IndexSpec{}
+	for _, s := range specs {
+		indexes, ok := existing[s.Collection]
+		if !ok {
+			var err error
+			if indexes, err = listIndexes(ctx, db.Collection(s.Collection)); err != nil {
+				return nil, fmt.Errorf("%s: %w", s.Collection, err)
+			}
+			existing[s.Collection] = indexes
+		}
+		unique, found := indexes[keyPattern(s.Keys)]
+		if !found || unique != s.Unique {
+			missing = append(missing, s)
+		}
+	}
+	return missing, nil
+}
+
+// listIndexes maps each index's key pattern to whether it is unique
+func listIndexes(ctx context.Context, coll *mongo.Collection) (map[string]bool, error) {
+	cursor, err := coll.Indexes().List(ctx)
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == namespaceNotFound {
+		return map[string]bool{}, nil // Collection not created yet
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		Key    bson.D `bson:"key"`
+		Unique bool   `bson:"unique"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	indexes := make(map[string]bool, len(docs))
+	for _, d := range docs {
+		indexes[keyPattern(d.Key)] = d.Unique
+	}
+	return indexes, nil
+}
+
+// keyPattern renders keys the way Mongo names indexes, e.g. roomCode_1_playerId_1
+func keyPattern(keys bson.D) string {
+	parts := make([]string, 0, len(keys)*2)
+	for _, k := range keys {
+		parts = append(parts, k.Key, fmt.Sprint(k.Value))
+	}
+	return strings.Join(parts, "_")
+}
@@ -0,0 +1,167 @@
+// Package migrations applies ordered, recorded schema changes to MongoDB.
+// Each applied migration is stored in the migrations collection so it runs once
+// per database, whether triggered at server startup or by `champs migrate`.
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	migrationsCollection = "migrations"
+	locksCollection      = "migration_locks"
+	lockID               = "migrate"
+	lockStaleAfter       = 10 * time.Minute // A crashed runner's lock is taken over after this
+)
+
+// ErrLocked is returned when another instance is applying migrations
+var ErrLocked = errors.New("migrations are being applied by another instance")
+
+// Migration is one schema change. Versions are applied in ascending order and never reused.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, db *mongo.Database) error
+}
+
+// Record is an applied migration as stored in the migrations collection
+type Record struct {
+	Version    int       `json:"version" bson:"_id"`
+	Name       string    `json:"name" bson:"name"`
+	AppliedAt  time.Time `json:"appliedAt" bson:"appliedAt"`
+	DurationMS int64     `json:"durationMs" bson:"durationMs"`
+}
+
+// Status pairs a known migration with its record, if applied
+type Status struct {
+	Version int
+	Name    string
+	Applied *Record
+}
+
+// Runner applies pending migrations against one database
+type Runner struct {
+	db         *mongo.Database
+	migrations []Migration
+}
+
+// NewRunner creates a runner for the registered migrations
+func NewRunner(db *mongo.Database) *Runner {
+	return &Runner{db: db, migrations: All()}
+}
+
+// Status lists every known migration in order with its applied record
+func (r *Runner) Status(ctx context.Context) ([]Status, error) {
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, 0, len(r.migrations))
+	for _, m := range r.migrations {
+		statuses = append(statuses, Status{Version: m.Version, Name: m.Name, Applied: applied[m.Version]})
+	}
+	return statuses, nil
+}
+
+// Up applies every pending migration in version order and returns how many ran.
+// It stops at the first failure so later migrations never run on a half-migrated schema.
+func (r *Runner) Up(ctx context.Context) (int, error) {
+	if err := r.validate(); err != nil {
+		return 0, err
+	}
+	if err := r.lock(ctx); err != nil {
+		return 0, err
+	}
+	defer r.unlock()
+
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	ran := 0
+	for _, m := range r.migrations {
+		if applied[m.Version] != nil {
+			continue
+		}
+
+		log.Printf("Applying migration %d: %s", m.Version, m.Name)
+		start := time.Now()
+		if err := m.Up(ctx, r.db); err != nil {
+			return ran, fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+
+		record := Record{
+			Version:    m.Version,
+			Name:       m.Name,
+			AppliedAt:  time.Now(),
+			DurationMS: time.Since(start).Milliseconds(),
+		}
+		if _, err := r.db.Collection(migrationsCollection).InsertOne(ctx, record); err != nil {
+			return ran, fmt.Errorf("failed to record migration %d: %w", m.Version, err)
+		}
+		ran++
+	}
+	return ran, nil
+}
+
+// validate checks versions are positive and strictly ascending
+func (r *Runner) validate() error {
+	last := 0
+	for _, m := range r.migrations {
+		if m.Version <= last {
+			return fmt.Errorf("migration %d (%s) is out of order", m.Version, m.Name)
+		}
+		last = m.Version
+	}
+	return nil
+}
+
+// applied returns the recorded migrations keyed by version
+func (r *Runner) applied(ctx context.Context) (map[int]*Record, error) {
+	cursor, err := r.db.Collection(migrationsCollection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []*Record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	applied := make(map[int]*Record, len(records))
+	for _, rec := range records {
+		applied[rec.Version] = rec
+	}
+	return applied, nil
+}
+
+// lock claims the single migration lock, taking over one left by a crashed runner
+func (r *Runner) lock(ctx context.Context) error {
+	now := time.Now()
+	filter := bson.M{"_id": lockID, "lockedAt": bson.M{"$lt": now.Add(-lockStaleAfter)}}
+	update := bson.M{"$set": bson.M{"lockedAt": now}}
+
+	_, err := r.db.Collection(locksCollection).UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		// The lock exists and is fresh
+		return ErrLocked
+	}
+	return err
+}
+
+func (r *Runner) unlock() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := r.db.Collection(locksCollection).DeleteOne(ctx, bson.M{"_id": lockID}); err != nil {
+		log.Printf("Warning: failed to release migration lock: %v", err)
+	}
+}
@@ -0,0 +1,430 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// postgresLockKey is the advisory lock held while PostgreSQL migrations run
+const postgresLockKey = 2026_0001
+
+// PostgresMigration is one schema change of the PostgreSQL backend. Versions
+// are numbered apart from the MongoDB migrations.
+type PostgresMigration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// AllPostgres returns every PostgreSQL migration in version order. Append new
+// ones at the end; never edit or renumber a migration that has shipped.
+func AllPostgres() []PostgresMigration {
+	return []PostgresMigration{
+		{Version: 1, Name: "core_tables", SQL: postgresCoreTables},
+		{Version: 2, Name: "room_tables", SQL: postgresRoomTables},
+		{Version: 3, Name: "host_tables", SQL: postgresHostTables},
+		{Version: 4, Name: "sm_tables", SQL: postgresSMTables},
+		{Version: 5, Name: "voice_clips_player", SQL: postgresVoiceClipsPlayer},
+		{Version: 6, Name: "attachments_created_at", SQL: postgresAttachmentsCreatedAt},
+	}
+}
+
+// Each table keeps the columns its queries filter and sort on; the model itself is the doc column
+
+const postgresCoreTables = `
+CREATE TABLE surveys (
+	id         TEXT PRIMARY KEY,
+	host_id    TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	deleted_at TIMESTAMPTZ,
+	doc        JSONB NOT NULL
+);
+CREATE INDEX surveys_host_id ON surveys (host_id);
+
+CREATE TABLE rooms (
+	code       TEXT PRIMARY KEY,
+	survey_id  TEXT NOT NULL,
+	host_id    TEXT NOT NULL,
+	status     TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	doc        JSONB NOT NULL
+);
+CREATE INDEX rooms_survey_id ON rooms (survey_id);
+CREATE INDEX rooms_host_id_status ON rooms (host_id, status);
+
+CREATE TABLE answers (
+	id                TEXT PRIMARY KEY,
+	room_code         TEXT NOT NULL,
+	player_id         TEXT NOT NULL,
+	question_key      TEXT NOT NULL,
+	client_attempt_id TEXT NOT NULL DEFAULT '',
+	created_at        TIMESTAMPTZ NOT NULL,
+	doc               JSONB NOT NULL
+);
+CREATE INDEX answers_room_player_question ON answers (room_code, player_id, question_key);
+CREATE INDEX answers_room_question ON answers (room_code, question_key);
+CREATE INDEX answers_created_at ON answers (created_at);
+CREATE UNIQUE INDEX answers_attempt_unique ON answers (room_code, player_id, question_key, client_attempt_id)
+	WHERE client_attempt_id <> '';
+
+CREATE TABLE room_snapshots (
+	room_code TEXT PRIMARY KEY,
+	ended_at  TIMESTAMPTZ NOT NULL,
+	doc       JSONB NOT NULL
+);
+CREATE INDEX room_snapshots_ended_at ON room_snapshots (ended_at);
+
+CREATE TABLE ai_reports (
+	room_code  TEXT PRIMARY KEY,
+	created_at TIMESTAMPTZ NOT NULL,
+	doc        JSONB NOT NULL
+);
+CREATE INDEX ai_reports_created_at ON ai_reports (created_at);
+
+CREATE TABLE analytics_player_profiles (
+	room_code TEXT NOT NULL,
+	player_id TEXT NOT NULL,
+	doc       JSONB NOT NULL,
+	PRIMARY KEY (room_code, player_id)
+);
+
+CREATE TABLE analytics_question_profiles (
+	room_code    TEXT NOT NULL,
+	question_key TEXT NOT NULL,
+	updated_at   TIMESTAMPTZ NOT NULL,
+	doc          JSONB NOT NULL,
+	PRIMARY KEY (room_code, question_key)
+);
+CREATE INDEX analytics_question_profiles_updated_at ON analytics_question_profiles (updated_at DESC);
+
+CREATE TABLE analytics_room_memory (
+	room_code  TEXT PRIMARY KEY,
+	updated_at TIMESTAMPTZ NOT NULL,
+	doc        JSONB NOT NULL
+);
+CREATE INDEX analytics_room_memory_updated_at ON analytics_room_memory (updated_at DESC);
+`
+
+const postgresRoomTables = `
+CREATE TABLE room_audit (
+	id        TEXT PRIMARY KEY,
+	room_code TEXT NOT NULL,
+	at        TIMESTAMPTZ NOT NULL,
+	doc       JSONB NOT NULL
+);
+CREATE INDEX room_audit_room_at ON room_audit (room_code, at);
+
+CREATE TABLE room_events (
+	id        BIGSERIAL PRIMARY KEY,
+	room_code TEXT NOT NULL,
+	seq       BIGINT NOT NULL,
+	doc       JSONB NOT NULL
+);
+CREATE INDEX room_events_room_seq ON room_events (room_code, seq);
+
+CREATE TABLE player_feedback (
+	room_code  TEXT NOT NULL,
+	player_id  TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	doc        JSONB NOT NULL,
+	PRIMARY KEY (room_code, player_id)
+);
+CREATE INDEX player_feedback_created_at ON player_feedback (created_at);
+
+CREATE TABLE attachments (
+	id           TEXT PRIMARY KEY,
+	room_code    TEXT NOT NULL,
+	player_id    TEXT NOT NULL,
+	question_key TEXT NOT NULL,
+	storage_key  TEXT NOT NULL,
+	created_at   TIMESTAMPTZ NOT NULL,
+	doc          JSONB NOT NULL
+);
+CREATE INDEX attachments_room_player_question ON attachments (room_code, player_id, question_key);
+
+CREATE TABLE voice_clips (
+	id          TEXT PRIMARY KEY,
+	storage_key TEXT NOT NULL,
+	created_at  TIMESTAMPTZ NOT NULL,
+	doc         JSONB NOT NULL
+);
+CREATE INDEX voice_clips_created_at ON voice_clips (created_at);
+
+CREATE TABLE polls (
+	id         TEXT PRIMARY KEY,
+	room_code  TEXT NOT NULL,
+	started_at TIMESTAMPTZ NOT NULL,
+	doc        JSONB NOT NULL
+);
+CREATE INDEX polls_room_started_at ON polls (room_code, started_at);
+
+CREATE TABLE email_deliveries (
+	id         TEXT PRIMARY KEY,
+	room_code  TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	doc        JSONB NOT NULL
+);
+CREATE INDEX email_deliveries_room_created_at ON email_deliveries (room_code, created_at DESC);
+`
+
+const postgresHostTables = `
+CREATE TABLE api_keys (
+	id         TEXT PRIMARY KEY,
+	host_id    TEXT NOT NULL,
+	hash       TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	doc        JSONB NOT NULL
+);
+CREATE INDEX api_keys_host_id ON api_keys (host_id);
+
+CREATE TABLE integrations (
+	id          TEXT PRIMARY KEY,
+	host_id     TEXT NOT NULL,
+	webhook_url TEXT NOT NULL,
+	created_at  TIMESTAMPTZ NOT NULL,
+	doc         JSONB NOT NULL
+);
+CREATE INDEX integrations_host_id ON integrations (host_id);
+
+CREATE TABLE host_plans (
+	host_id TEXT PRIMARY KEY,
+	doc     JSONB NOT NULL
+);
+
+CREATE TABLE digest_settings (
+	host_id   TEXT PRIMARY KEY,
+	enabled   BOOLEAN NOT NULL,
+	last_week TIMESTAMPTZ,
+	doc       JSONB NOT NULL
+);
+CREATE INDEX digest_settings_enabled ON digest_settings (enabled);
+
+CREATE TABLE experiments (
+	id         TEXT PRIMARY KEY,
+	host_id    TEXT NOT NULL,
+	status     TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	doc        JSONB NOT NULL
+);
+CREATE INDEX experiments_host_id ON experiments (host_id);
+
+CREATE TABLE experiment_assignments (
+	experiment_id TEXT NOT NULL,
+	room_code     TEXT NOT NULL,
+	player_id     TEXT NOT NULL,
+	doc           JSONB NOT NULL,
+	PRIMARY KEY (experiment_id, room_code, player_id)
+);
+
+CREATE TABLE room_templates (
+	id      TEXT PRIMARY KEY,
+	host_id TEXT NOT NULL,
+	name    TEXT NOT NULL,
+	doc     JSONB NOT NULL
+);
+CREATE INDEX room_templates_host_name ON room_templates (host_id, name);
+
+CREATE TABLE survey_difficulty (
+	survey_id TEXT PRIMARY KEY,
+	doc       JSONB NOT NULL
+);
+
+CREATE TABLE event_groups (
+	id         TEXT PRIMARY KEY,
+	host_id    TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	doc        JSONB NOT NULL
+);
+CREATE INDEX event_groups_host_created_at ON event_groups (host_id, created_at DESC);
+
+CREATE TABLE comparison_reports (
+	id         TEXT PRIMARY KEY,
+	host_id    TEXT NOT NULL,
+	survey_id  TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	doc        JSONB NOT NULL
+);
+CREATE INDEX comparison_reports_host_created_at ON comparison_reports (host_id, created_at DESC);
+`
+
+const postgresSMTables = `
+CREATE TABLE sm_collectors (
+	collector_id TEXT PRIMARY KEY,
+	survey_id    TEXT NOT NULL,
+	doc          JSONB NOT NULL
+);
+CREATE INDEX sm_collectors_survey_id ON sm_collectors (survey_id);
+
+CREATE TABLE sm_responses_raw (
+	response_id   TEXT PRIMARY KEY,
+	survey_id     TEXT NOT NULL,
+	date_modified TIMESTAMPTZ NOT NULL,
+	doc           JSONB NOT NULL
+);
+CREATE INDEX sm_responses_raw_survey_modified ON sm_responses_raw (survey_id, date_modified DESC);
+CREATE INDEX sm_responses_raw_date_modified ON sm_responses_raw (date_modified);
+
+CREATE TABLE sm_answers (
+	id          TEXT PRIMARY KEY,
+	response_id TEXT NOT NULL,
+	survey_id   TEXT NOT NULL,
+	question_id TEXT NOT NULL,
+	doc         JSONB NOT NULL
+);
+CREATE INDEX sm_answers_response_id ON sm_answers (response_id);
+CREATE INDEX sm_answers_survey_question ON sm_answers (survey_id, question_id);
+
+CREATE TABLE sm_response_features (
+	response_id  TEXT PRIMARY KEY,
+	survey_id    TEXT NOT NULL,
+	submitted_at TIMESTAMPTZ NOT NULL,
+	doc          JSONB NOT NULL
+);
+CREATE INDEX sm_response_features_survey_submitted ON sm_response_features (survey_id, submitted_at DESC);
+
+CREATE TABLE sm_sync_schedules (
+	survey_id    TEXT PRIMARY KEY,
+	enabled      BOOLEAN NOT NULL,
+	next_run_at  TIMESTAMPTZ,
+	locked_until TIMESTAMPTZ,
+	doc          JSONB NOT NULL
+);
+CREATE INDEX sm_sync_schedules_due ON sm_sync_schedules (enabled, next_run_at);
+`
+
+// Privacy requests list and delete a player's clips
+const postgresVoiceClipsPlayer = `
+ALTER TABLE voice_clips ADD COLUMN room_code TEXT, ADD COLUMN player_id TEXT;
+UPDATE voice_clips SET room_code = doc->>'roomCode', player_id = doc->>'playerId';
+ALTER TABLE voice_clips ALTER COLUMN room_code SET NOT NULL, ALTER COLUMN player_id SET NOT NULL;
+CREATE INDEX voice_clips_room_player ON voice_clips (room_code, player_id);
+`
+
+// The retention job purges attachments by upload time
+const postgresAttachmentsCreatedAt = `
+CREATE INDEX attachments_created_at ON attachments (created_at);
+`
+
+// PostgresRunner applies pending PostgreSQL migrations, recording each in schema_migrations
+type PostgresRunner struct {
+	pool       *pgxpool.Pool
+	migrations []PostgresMigration
+}
+
+// NewPostgresRunner creates a runner for the registered PostgreSQL migrations
+func NewPostgresRunner(pool *pgxpool.Pool) *PostgresRunner {
+	return &PostgresRunner{pool: pool, migrations: AllPostgres()}
+}
+
+// Status lists every known migration in order with its applied record
+func (r *PostgresRunner) Status(ctx context.Context) ([]Status, error) {
+	if err := r.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, 0, len(r.migrations))
+	for _, m := range r.migrations {
+		statuses = append(statuses, Status{Version: m.Version, Name: m.Name, Applied: applied[m.Version]})
+	}
+	return statuses, nil
+}
+
+// Up applies every pending migration in version order, each in its own
+// transaction with its record, and returns how many ran
+func (r *PostgresRunner) Up(ctx context.Context) (int, error) {
+	last := 0
+	for _, m := range r.migrations {
+		if m.Version <= last {
+			return 0, fmt.Errorf("migration %d (%s) is out of order", m.Version, m.Name)
+		}
+		last = m.Version
+	}
+
+	// The advisory lock belongs to a session, so hold one connection throughout
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
+
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, postgresLockKey).Scan(&locked); err != nil {
+		return 0, err
+	}
+	if !locked {
+		return 0, ErrLocked
+	}
+	defer func() {
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, postgresLockKey); err != nil {
+			log.Printf("Warning: failed to release migration lock: %v", err)
+		}
+	}()
+
+	if err := r.ensureTable(ctx); err != nil {
+		return 0, err
+	}
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	ran := 0
+	for _, m := range r.migrations {
+		if applied[m.Version] != nil {
+			continue
+		}
+
+		log.Printf("Applying PostgreSQL migration %d: %s", m.Version, m.Name)
+		start := time.Now()
+		err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, m.SQL); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, name, applied_at, duration_ms) VALUES ($1, $2, $3, $4)`,
+				m.Version, m.Name, time.Now(), time.Since(start).Milliseconds())
+			return err
+		})
+		if err != nil {
+			return ran, fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+		ran++
+	}
+	return ran, nil
+}
+
+func (r *PostgresRunner) ensureTable(ctx context.Context) error {
+	_, err := r.pool.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version     INTEGER PRIMARY KEY,
+		name        TEXT NOT NULL,
+		applied_at  TIMESTAMPTZ NOT NULL,
+		duration_ms BIGINT NOT NULL
+	)`)
+	return err
+}
+
+// applied returns the recorded migrations keyed by version
+func (r *PostgresRunner) applied(ctx context.Context) (map[int]*Record, error) {
+	rows, err := r.pool.Query(ctx, `SELECT version, name, applied_at, duration_ms FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]*Record)
+	for rows.Next() {
+		var rec Record
+		if err := rows.Scan(&rec.Version, &rec.Name, &rec.AppliedAt, &rec.DurationMS); err != nil {
+			return nil, err
+		}
+		applied[rec.Version] = &rec
+	}
+	return applied, rows.Err()
+}
@@ -0,0 +1,135 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// All returns every migration in version order. Append new ones at the end;
+// never edit or renumber a migration that has shipped.
+func All() []Migration {
+	return []Migration{
+		{Version: 1, Name: "sm_indexes", Up: smIndexes},
+		{Version: 2, Name: "sm_raw_schema_version", Up: smRawSchemaVersion},
+		{Version: 3, Name: "core_indexes", Up: coreIndexes},
+		{Version: 4, Name: "answers_attempt_unique", Up: answersAttemptUnique},
+		{Version: 5, Name: "experiment_indexes", Up: experimentIndexes},
+		{Version: 6, Name: "audit_indexes", Up: auditIndexes},
+		{Version: 7, Name: "room_events_capped", Up: roomEventsCapped},
+		{Version: 8, Name: "feedback_indexes", Up: feedbackIndexes},
+		{Version: 9, Name: "sm_schedule_indexes", Up: smScheduleIndexes},
+		{Version: 10, Name: "room_template_indexes", Up: roomTemplateIndexes},
+		{Version: 11, Name: "survey_difficulty_indexes", Up: surveyDifficultyIndexes},
+		{Version: 12, Name: "event_group_indexes", Up: eventGroupIndexes},
+		{Version: 13, Name: "room_host_indexes", Up: roomHostIndexes},
+		{Version: 14, Name: "poll_indexes", Up: pollIndexes},
+		{Version: 15, Name: "comparison_indexes", Up: comparisonIndexes},
+		{Version: 16, Name: "digest_indexes", Up: digestIndexes},
+	}
+}
+
+// smIndexes creates the SurveyMonkey indexes NewSMRepo used to create on every boot
+func smIndexes(ctx context.Context, db *mongo.Database) error {
+	return EnsureIndexes(ctx, db, smIndexSpecs())
+}
+
+// smRawSchemaVersion stamps raw SM responses stored before schema_version existed as version 1
+func smRawSchemaVersion(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("sm_responses_raw").UpdateMany(ctx,
+		bson.M{"schema_version": bson.M{"$in": bson.A{nil, 0}}},
+		bson.M{"$set": bson.M{"schema_version": 1}},
+	)
+	return err
+}
+
+// coreIndexes indexes answers, rooms, surveys, snapshots and AI reports
+func coreIndexes(ctx context.Context, db *mongo.Database) error {
+	return EnsureIndexes(ctx, db, coreIndexSpecs())
+}
+
+// answersAttemptUnique scopes clientAttemptId uniqueness to the player's question,
+// replacing the global clientAttemptId index an early core_indexes run created
+func answersAttemptUnique(ctx context.Context, db *mongo.Database) error {
+	if err := EnsureIndexes(ctx, db, []IndexSpec{answerAttemptIndex()}); err != nil {
+		return err
+	}
+	_, err := db.Collection("answers").Indexes().DropOne(ctx, "clientAttemptId_1")
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && (cmdErr.Code == indexNotFound || cmdErr.Code == namespaceNotFound) {
+		return nil
+	}
+	return err
+}
+
+// experimentIndexes indexes A/B experiments by host and makes each assignment unique
+func experimentIndexes(ctx context.Context, db *mongo.Database) error {
+	return EnsureIndexes(ctx, db, experimentIndexSpecs())
+}
+
+// auditIndexes indexes room audit trails by room and time
+func auditIndexes(ctx context.Context, db *mongo.Database) error {
+	return EnsureIndexes(ctx, db, auditIndexSpecs())
+}
+
+// roomEventsCappedSize bounds the persisted event stream; the oldest events are overwritten first
+const roomEventsCappedSize = 512 << 20
+
+// roomEventsCapped creates the capped room event stream collection and indexes it by room and sequence
+func roomEventsCapped(ctx context.Context, db *mongo.Database) error {
+	opts := options.CreateCollection().SetCapped(true).SetSizeInBytes(roomEventsCappedSize)
+	err := db.CreateCollection(ctx, "room_events", opts)
+	var cmdErr mongo.CommandError
+	if err != nil && !(errors.As(err, &cmdErr) && cmdErr.Code == namespaceExists) {
+		return err
+	}
+	return EnsureIndexes(ctx, db, eventIndexSpecs())
+}
+
+// feedbackIndexes makes end-of-room feedback unique per room and player
+func feedbackIndexes(ctx context.Context, db *mongo.Database) error {
+	return EnsureIndexes(ctx, db, feedbackIndexSpecs())
+}
+
+// smScheduleIndexes makes SM sync schedules unique per survey and indexes them by next run
+func smScheduleIndexes(ctx context.Context, db *mongo.Database) error {
+	return EnsureIndexes(ctx, db, smScheduleIndexSpecs())
+}
+
+// roomTemplateIndexes indexes room templates by host
+func roomTemplateIndexes(ctx context.Context, db *mongo.Database) error {
+	return EnsureIndexes(ctx, db, roomTemplateIndexSpecs())
+}
+
+// surveyDifficultyIndexes keeps one difficulty calibration per survey
+func surveyDifficultyIndexes(ctx context.Context, db *mongo.Database) error {
+	return EnsureIndexes(ctx, db, surveyDifficultyIndexSpecs())
+}
+
+// eventGroupIndexes indexes multi-room events by host
+func eventGroupIndexes(ctx context.Context, db *mongo.Database) error {
+	return EnsureIndexes(ctx, db, eventGroupIndexSpecs())
+}
+
+// roomHostIndexes indexes rooms by host and status for plan limits
+func roomHostIndexes(ctx context.Context, db *mongo.Database) error {
+	return EnsureIndexes(ctx, db, roomHostIndexSpecs())
+}
+
+// pollIndexes indexes live polls by room and launch time
+func pollIndexes(ctx context.Context, db *mongo.Database) error {
+	return EnsureIndexes(ctx, db, pollIndexSpecs())
+}
+
+// comparisonIndexes indexes room comparisons by host and creation time
+func comparisonIndexes(ctx context.Context, db *mongo.Database) error {
+	return EnsureIndexes(ctx, db, comparisonIndexSpecs())
+}
+
+// digestIndexes indexes digest settings by opt-in
+func digestIndexes(ctx context.Context, db *mongo.Database) error {
+	return EnsureIndexes(ctx, db, digestIndexSpecs())
+}
@@ -0,0 +1,293 @@
+package mockapi
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+)
+
+//go:embed fixtures
+var fixturesFS embed.FS
+
+// Scenario names shared by both APIs
+const (
+	ScenarioOK          = "ok"
+	ScenarioRateLimited = "rate_limited"
+)
+
+// Fixture is a canned HTTP response loaded from fixtures/
+type Fixture struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+	Raw     string            `json:"raw,omitempty"` // sent verbatim, for malformed payloads
+}
+
+// payload returns the bytes to write for the fixture
+func (f *Fixture) payload() []byte {
+	if f.Raw != "" {
+		return []byte(f.Raw)
+	}
+	return f.Body
+}
+
+// Server replays recorded Gemini and SurveyMonkey responses.
+// Point GEMINI_BASE_URL at <addr>/v1beta/models and SM_BASE_URL at <addr>/v3.
+type Server struct {
+	mu       sync.RWMutex
+	gemini   string
+	sm       string
+	requests map[string]int
+	mux      *http.ServeMux
+}
+
+// NewServer creates a mock server with both APIs on the "ok" scenario
+func NewServer() *Server {
+	s := &Server{
+		gemini:   ScenarioOK,
+		sm:       ScenarioOK,
+		requests: make(map[string]int),
+		mux:      http.NewServeMux(),
+	}
+	s.mux.HandleFunc("/v1beta/models/", s.handleGemini)
+	s.mux.HandleFunc("/v3/", s.handleSM)
+	s.mux.HandleFunc("/_mock/scenario", s.handleScenario)
+	s.mux.HandleFunc("/_mock/requests", s.handleRequests)
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// SetGeminiScenario selects the fixture served for Gemini calls
+func (s *Server) SetGeminiScenario(name string) error {
+	if _, err := fs.Stat(fixturesFS, path.Join("fixtures", "gemini", name+".json")); err != nil {
+		return fmt.Errorf("unknown gemini scenario: %s", name)
+	}
+	s.mu.Lock()
+	s.gemini = name
+	s.mu.Unlock()
+	return nil
+}
+
+// SetSMScenario selects the fixture directory served for SurveyMonkey calls
+func (s *Server) SetSMScenario(name string) error {
+	if _, err := fs.Stat(fixturesFS, path.Join("fixtures", "sm", name)); err != nil {
+		return fmt.Errorf("unknown sm scenario: %s", name)
+	}
+	s.mu.Lock()
+	s.sm = name
+	s.mu.Unlock()
+	return nil
+}
+
+// Scenarios lists the available fixture names per API
+func Scenarios() map[string][]string {
+	out := map[string][]string{"gemini": {}, "sm": {}}
+	if entries, err := fs.ReadDir(fixturesFS, "fixtures/gemini"); err == nil {
+		for _, e := range entries {
+			out["gemini"] = append(out["gemini"], strings.TrimSuffix(e.Name(), ".json"))
+		}
+	}
+	if entries, err := fs.ReadDir(fixturesFS, "fixtures/sm"); err == nil {
+		for _, e := range entries {
+			if e.IsDir() {
+				out["sm"] = append(out["sm"], e.Name())
+			}
+		}
+	}
+	return out
+}
+
+// RequestCount returns how many calls hit an endpoint ("gemini", "sm:<resource>")
+func (s *Server) RequestCount(endpoint string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.requests[endpoint]
+}
+
+// handleGemini serves generateContent and streamGenerateContent
+func (s *Server) handleGemini(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mu.Lock()
+	s.requests["gemini"]++
+	scenario := s.gemini
+	s.mu.Unlock()
+
+	fixture, err := loadFixture(path.Join("fixtures", "gemini", scenario+".json"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	streaming := strings.HasSuffix(r.URL.Path, ":streamGenerateContent")
+	if streaming && fixture.Status < 400 {
+		writeSSE(w, fixture)
+		return
+	}
+	writeFixture(w, fixture)
+}
+
+// handleSM routes SurveyMonkey v3 paths to a fixture file in the current scenario
+func (s *Server) handleSM(w http.ResponseWriter, r *http.Request) {
+	resource := smResource(r.Method, strings.TrimPrefix(r.URL.Path, "/v3"))
+
+	s.mu.Lock()
+	s.requests["sm:"+resource]++
+	scenario := s.sm
+	s.mu.Unlock()
+
+	if resource == "" {
+		fixture, _ := loadFixture("fixtures/sm/not_found.json")
+		writeFixture(w, fixture)
+		return
+	}
+
+	// Scenarios only override the resources they care about
+	fixture, err := loadFixture(path.Join("fixtures", "sm", scenario, resource+".json"))
+	if err != nil {
+		fixture, err = loadFixture(path.Join("fixtures", "sm", ScenarioOK, resource+".json"))
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeFixture(w, fixture)
+}
+
+// smResource maps a v3 path to its fixture name
+func smResource(method, p string) string {
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+	if len(parts) == 2 && parts[0] == "collectors" {
+		switch method {
+		case http.MethodPatch:
+			return "collector_update"
+		case http.MethodDelete:
+			return "collector_delete"
+		}
+		return ""
+	}
+	if len(parts) == 0 || parts[0] != "surveys" {
+		return ""
+	}
+	switch {
+	case len(parts) == 4 && parts[2] == "responses" && parts[3] == "bulk":
+		return "responses_bulk"
+	case len(parts) == 5 && parts[2] == "responses" && parts[4] == "details":
+		return "response_details"
+	case len(parts) == 3 && parts[2] == "collectors" && method == http.MethodGet:
+		return "collectors"
+	case len(parts) == 3 && parts[2] == "collectors":
+		return "collector"
+	case method == http.MethodPost:
+		// surveys, pages and questions all answer with a created resource
+		return "create"
+	}
+	return ""
+}
+
+// handleScenario switches scenarios: POST /_mock/scenario?api=gemini|sm&name=...
+func (s *Server) handleScenario(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		s.mu.RLock()
+		current := map[string]string{"gemini": s.gemini, "sm": s.sm}
+		s.mu.RUnlock()
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"current":   current,
+			"available": Scenarios(),
+		})
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	var err error
+	switch r.URL.Query().Get("api") {
+	case "gemini":
+		err = s.SetGeminiScenario(name)
+	case "sm":
+		err = s.SetSMScenario(name)
+	default:
+		err = fmt.Errorf("api must be gemini or sm")
+	}
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleRequests returns per-endpoint request counters
+func (s *Server) handleRequests(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	counts := make(map[string]int, len(s.requests))
+	for k, v := range s.requests {
+		counts[k] = v
+	}
+	s.mu.RUnlock()
+	writeJSON(w, http.StatusOK, counts)
+}
+
+// loadFixture reads and decodes an embedded fixture
+func loadFixture(name string) (*Fixture, error) {
+	data, err := fixturesFS.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("fixture not found: %s", name)
+	}
+	var f Fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %w", name, err)
+	}
+	if f.Status == 0 {
+		f.Status = http.StatusOK
+	}
+	return &f, nil
+}
+
+// writeFixture writes a fixture as a plain JSON response
+func writeFixture(w http.ResponseWriter, f *Fixture) {
+	for k, v := range f.Headers {
+		w.Header().Set(k, v)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(f.Status)
+	w.Write(f.payload())
+}
+
+// writeSSE wraps a fixture body as a single server-sent event, like alt=sse
+func writeSSE(w http.ResponseWriter, f *Fixture) {
+	for k, v := range f.Headers {
+		w.Header().Set(k, v)
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(f.Status)
+
+	// SSE events are line based, so compact multi-line JSON first
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, f.payload()); err != nil {
+		compact.Reset()
+		compact.Write(f.payload())
+	}
+	fmt.Fprintf(w, "data: %s\r\n\r\n", compact.String())
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,200 @@
+package mockapi_test
+
+import (
+	"2026champs/internal/mockapi"
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newGeminiEvaluator returns an evaluator pointed at the mock server, or an
+// offline one (mock results) when baseURL is empty
+func newGeminiEvaluator(t *testing.T, baseURL string) *service.EvaluatorService {
+	t.Helper()
+	if baseURL == "" {
+		t.Setenv("GEMINI_API_KEY", "")
+	} else {
+		t.Setenv("GEMINI_API_KEY", "test-key")
+		t.Setenv("GEMINI_BASE_URL", baseURL+"/v1beta/models")
+	}
+	return service.NewEvaluatorService()
+}
+
+func TestEvaluatorAgainstGeminiFixtures(t *testing.T) {
+	question := &model.Question{Key: "Q1", Type: model.QuestionTypeEssay, Prompt: "What do you like about the phone?", Threshold: 0.5, PointsMax: 100}
+	answer := &model.Answer{QuestionKey: "Q1", TextAnswer: "The battery lasts two full days even with heavy use"}
+
+	fallback, err := newGeminiEvaluator(t, "").EvaluateAnswer(context.Background(), question, answer)
+	if err != nil {
+		t.Fatalf("offline evaluation: %v", err)
+	}
+
+	tests := []struct {
+		scenario string
+		calls    int  // Gemini requests, including the corrective retry
+		mocked   bool // falls back to the offline result
+	}{
+		{scenario: "ok", calls: 1},
+		{scenario: "rate_limited", calls: 1, mocked: true},
+		{scenario: "server_error", calls: 1, mocked: true},
+		{scenario: "malformed", calls: 1, mocked: true},
+		{scenario: "malformed_text", calls: 2, mocked: true},
+		{scenario: "empty_candidates", calls: 1, mocked: true},
+		{scenario: "safety_blocked", calls: 1, mocked: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			mock := mockapi.NewServer()
+			if err := mock.SetGeminiScenario(tt.scenario); err != nil {
+				t.Fatal(err)
+			}
+			srv := httptest.NewServer(mock)
+			defer srv.Close()
+
+			result, err := newGeminiEvaluator(t, srv.URL).EvaluateAnswer(context.Background(), question, answer)
+			if err != nil {
+				t.Fatalf("EvaluateAnswer: %v", err)
+			}
+			if got := mock.RequestCount("gemini"); got != tt.calls {
+				t.Errorf("gemini requests = %d, want %d", got, tt.calls)
+			}
+			if tt.mocked {
+				if !reflect.DeepEqual(result, fallback) {
+					t.Errorf("result = %+v, want the offline fallback %+v", result, fallback)
+				}
+				return
+			}
+			if result.Resolution != "SAT" || result.QualityScore != 0.72 || result.Signals.Summary != "Praises two-day battery life." {
+				t.Errorf("result = %+v, want the fixture's evaluation", result)
+			}
+		})
+	}
+}
+
+// newSMClient returns a client for the mock server with a fast retry policy
+func newSMClient(baseURL string) *service.SMClient {
+	c := service.NewSMClient()
+	c.SetBaseURL(baseURL + "/v3")
+	c.SetRetryPolicy(3, time.Millisecond)
+	return c
+}
+
+func TestSMClientAgainstFixtures(t *testing.T) {
+	tests := []struct {
+		scenario   string
+		listErr    string // substring of the ListResponses error, "" for success
+		detailsErr string // substring of the GetResponseDetails error, "" for success
+		calls      int    // requests per endpoint, including retries
+	}{
+		{scenario: "ok", calls: 1},
+		{scenario: "rate_limited", listErr: "max retries exceeded", detailsErr: "max retries exceeded", calls: 3},
+		{scenario: "truncated_page", listErr: "failed to parse response list", detailsErr: "failed to parse raw response", calls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			mock := mockapi.NewServer()
+			if err := mock.SetSMScenario(tt.scenario); err != nil {
+				t.Fatal(err)
+			}
+			srv := httptest.NewServer(mock)
+			defer srv.Close()
+			client := newSMClient(srv.URL)
+
+			list, err := client.ListResponses("S900", nil)
+			checkErr(t, "ListResponses", err, tt.listErr)
+			if err == nil && len(list.Data) != 2 {
+				t.Errorf("ListResponses returned %d responses, want 2", len(list.Data))
+			}
+
+			details, raw, err := client.GetResponseDetails("S900", "R1001")
+			checkErr(t, "GetResponseDetails", err, tt.detailsErr)
+			if err == nil {
+				if details.ID != "R1001" || len(details.Pages) != 1 || len(details.Pages[0].Questions) != 2 {
+					t.Errorf("GetResponseDetails = %+v, want R1001 with one page of two questions", details)
+				}
+				if raw["id"] != "R1001" {
+					t.Errorf("raw id = %v, want R1001", raw["id"])
+				}
+			}
+
+			for _, endpoint := range []string{"sm:responses_bulk", "sm:response_details"} {
+				if got := mock.RequestCount(endpoint); got != tt.calls {
+					t.Errorf("%s requests = %d, want %d", endpoint, got, tt.calls)
+				}
+			}
+		})
+	}
+}
+
+func TestSMScenarioFallsBackToOK(t *testing.T) {
+	mock := mockapi.NewServer()
+	if err := mock.SetSMScenario("truncated_page"); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(mock)
+	defer srv.Close()
+
+	// truncated_page only overrides the response endpoints
+	collectors, err := newSMClient(srv.URL).ListCollectors("S900")
+	if err != nil {
+		t.Fatalf("ListCollectors: %v", err)
+	}
+	if len(collectors) == 0 {
+		t.Error("ListCollectors returned no collectors from the ok fixture")
+	}
+}
+
+func TestScenarioEndpoint(t *testing.T) {
+	mock := mockapi.NewServer()
+	srv := httptest.NewServer(mock)
+	defer srv.Close()
+
+	tests := []struct {
+		query  string
+		status int
+	}{
+		{query: "api=gemini&name=rate_limited", status: http.StatusOK},
+		{query: "api=sm&name=truncated_page", status: http.StatusOK},
+		{query: "api=gemini&name=missing", status: http.StatusBadRequest},
+		{query: "api=other&name=ok", status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		resp, err := http.Post(srv.URL+"/_mock/scenario?"+tt.query, "application/json", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.status {
+			t.Errorf("POST ?%s = %d, want %d", tt.query, resp.StatusCode, tt.status)
+		}
+	}
+
+	// The switched scenarios are served: one Gemini 429, then the truncated SM page
+	eval := newGeminiEvaluator(t, srv.URL)
+	question := &model.Question{Key: "Q1", Type: model.QuestionTypeEssay, Threshold: 0.5}
+	if _, err := eval.EvaluateAnswer(context.Background(), question, &model.Answer{TextAnswer: "fine"}); err != nil {
+		t.Fatalf("EvaluateAnswer: %v", err)
+	}
+	if got := mock.RequestCount("gemini"); got != 1 {
+		t.Errorf("gemini requests = %d, want 1", got)
+	}
+	if _, err := newSMClient(srv.URL).ListResponses("S900", nil); err == nil || !strings.Contains(err.Error(), "failed to parse") {
+		t.Errorf("ListResponses error = %v, want a parse error", err)
+	}
+}
+
+func checkErr(t *testing.T, call string, err error, want string) {
+	t.Helper()
+	switch {
+	case want == "" && err != nil:
+		t.Errorf("%s: unexpected error %v", call, err)
+	case want != "" && (err == nil || !strings.Contains(err.Error(), want)):
+		t.Errorf("%s error = %v, want one containing %q", call, err, want)
+	}
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// AISchemaStats counts how one prompt template's Gemini responses fared against its schema
+type AISchemaStats struct {
+	Responses   int64      `json:"responses"`             // Responses checked, corrective retries excluded
+	Invalid     int64      `json:"invalid"`               // Responses that failed the schema on the first try
+	Retries     int64      `json:"retries"`               // Corrective "fix your JSON" retries sent
+	Recovered   int64      `json:"recovered"`             // Retries that came back valid
+	Failed      int64      `json:"failed"`                // Responses given up on; the caller fell back
+	FailureRate float64    `json:"failureRate"`           // invalid / responses
+	LastError   string     `json:"lastError,omitempty"`   // Most recent schema errors
+	LastErrorAt *time.Time `json:"lastErrorAt,omitempty"` // When they were seen
+}
+
+// AISchemaReport is the schema failure counters of this instance, per prompt template
+type AISchemaReport struct {
+	Since     time.Time                `json:"since"`
+	Templates map[string]AISchemaStats `json:"templates"`
+}
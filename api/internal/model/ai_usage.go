@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// AIUsage tracks Gemini calls and estimated token consumption
+type AIUsage struct {
+	Calls           int64                 `json:"calls"`
+	EstimatedTokens int64                 `json:"estimatedTokens"`
+	InputTokens     int64                 `json:"inputTokens"`
+	OutputTokens    int64                 `json:"outputTokens"`
+	Models          map[string]ModelUsage `json:"models,omitempty"`
+}
+
+// ModelUsage is the usage of a single Gemini model
+type ModelUsage struct {
+	Calls        int64 `json:"calls" bson:"calls"`
+	InputTokens  int64 `json:"inputTokens" bson:"inputTokens"`
+	OutputTokens int64 `json:"outputTokens" bson:"outputTokens"`
+}
+
+// RoomUsageReport is the AI cost of one room session. Tokens are chars/4 estimates.
+type RoomUsageReport struct {
+	RoomCode         string            `json:"roomCode" bson:"roomCode"`
+	Calls            int64             `json:"calls" bson:"calls"`
+	InputTokens      int64             `json:"inputTokens" bson:"inputTokens"`
+	OutputTokens     int64             `json:"outputTokens" bson:"outputTokens"`
+	EstimatedTokens  int64             `json:"estimatedTokens" bson:"estimatedTokens"`
+	EstimatedCostUSD float64           `json:"estimatedCostUsd" bson:"estimatedCostUsd"`
+	Models           []ModelCostReport `json:"models" bson:"models"`
+	GeneratedAt      time.Time         `json:"generatedAt" bson:"generatedAt"`
+}
+
+// ModelCostReport is one model's share of a room's AI cost
+type ModelCostReport struct {
+	Model            string `json:"model" bson:"model"`
+	ModelUsage       `bson:",inline"`
+	EstimatedCostUSD float64 `json:"estimatedCostUsd" bson:"estimatedCostUsd"`
+	Priced           bool    `json:"priced" bson:"priced"` // false when the model has no configured price
+}
@@ -38,6 +38,8 @@ type QuestionProfile struct {
 	// Theme tracking
 	ThemeCounts   map[string]int `json:"themeCounts" bson:"themeCounts"`     // theme -> count
 	MissingCounts map[string]int `json:"missingCounts" bson:"missingCounts"` // missing detail -> count
+	// Themes outside the survey's taxonomy, counted under "other" in ThemeCounts
+	EmergentCounts map[string]int `json:"emergentCounts,omitempty" bson:"emergentCounts,omitempty"`
 
 	// Misunderstandings (refreshed periodically by AI)
 	Misunderstandings []string `json:"misunderstandings" bson:"misunderstandings"` // Top 3-5 bullets
@@ -48,6 +50,12 @@ type QuestionProfile struct {
 	UnsatCount int `json:"unsatCount" bson:"unsatCount"`
 	SkipCount  int `json:"skipCount" bson:"skipCount"`
 
+	// Skip attempts refused by skip gating, before the required tries or time
+	SkipLockedCount int `json:"skipLockedCount,omitempty" bson:"skipLockedCount,omitempty"`
+
+	// ESSAY answers flagged as copies of another player's; their themes and missing details are not counted
+	DuplicateCount int `json:"duplicateCount,omitempty" bson:"duplicateCount,omitempty"`
+
 	// Follow-up effectiveness
 	FollowUpTriggered int `json:"followupTriggered" bson:"followupTriggered"`
 	FollowUpHelped    int `json:"followupHelped" bson:"followupHelped"` // Led to SAT or improved quality
@@ -60,13 +68,63 @@ type QuestionProfile struct {
 	// Choice stats (for MCQ type)
 	OptionHist map[int]int `json:"optionHist" bson:"optionHist"` // index -> count
 
+	// Grid stats (for MATRIX type)
+	MatrixHist map[int]map[int]int `json:"matrixHist,omitempty" bson:"matrixHist,omitempty"` // row -> column -> count
+
+	// Ordering stats (for RANKING type); positions are 1-based
+	RankCount       int             `json:"rankCount,omitempty" bson:"rankCount,omitempty"`
+	RankPositionSum map[int]int     `json:"rankPositionSum,omitempty" bson:"rankPositionSum,omitempty"` // option -> sum of positions
+	BordaScores     map[int]int     `json:"bordaScores,omitempty" bson:"bordaScores,omitempty"`         // option -> Borda points
+	AvgPosition     map[int]float64 `json:"avgPosition,omitempty" bson:"avgPosition,omitempty"`         // option -> mean position
+
+	// Word cloud (for WORDS type); words are normalized to stems
+	WordCounts map[string]int    `json:"wordCounts,omitempty" bson:"wordCounts,omitempty"` // stem -> count
+	WordForms  map[string]string `json:"wordForms,omitempty" bson:"wordForms,omitempty"`   // stem -> word shown in the cloud
+	WordCloud  []WordCount       `json:"wordCloud,omitempty" bson:"wordCloud,omitempty"`   // Most frequent words first
+
 	// Mini-clusters (optional, for advanced analytics)
 	Clusters []QuestionCluster `json:"clusters,omitempty" bson:"clusters,omitempty"`
 
+	// Time to answer, from serve to submit
+	ResponseTime *ResponseTimeStats `json:"responseTime,omitempty" bson:"responseTime,omitempty"`
+
 	AnswerCount int       `json:"answerCount" bson:"answerCount"`
 	UpdatedAt   time.Time `json:"updatedAt" bson:"updatedAt"`
 }
 
+// RelevanceStats counts player ratings of AI follow-ups
+type RelevanceStats struct {
+	Up   int     `json:"up" bson:"up"`
+	Down int     `json:"down" bson:"down"`
+	Rate float64 `json:"rate" bson:"rate"` // up / (up + down), 0 without ratings
+}
+
+// Total returns the number of ratings
+func (r RelevanceStats) Total() int {
+	return r.Up + r.Down
+}
+
+// FollowUpRelevance is how relevant players found a room's AI follow-ups
+type FollowUpRelevance struct {
+	Overall  RelevanceStats            `json:"overall" bson:"overall"`
+	BySource map[string]RelevanceStats `json:"bySource" bson:"bySource"` // pool, generated
+	ByHint   map[string]RelevanceStats `json:"byHint" bson:"byHint"`     // clarify, deepen, ...
+}
+
+// ResponseTimeStats summarizes a question's response times in milliseconds
+type ResponseTimeStats struct {
+	Count    int   `json:"count" bson:"count"` // Samples behind the percentiles (the latest 1000 at most)
+	MedianMs int64 `json:"medianMs" bson:"medianMs"`
+	P75Ms    int64 `json:"p75Ms" bson:"p75Ms"`
+	P90Ms    int64 `json:"p90Ms" bson:"p90Ms"`
+}
+
+// WordCount is one entry of a word cloud
+type WordCount struct {
+	Word  string `json:"word" bson:"word"`
+	Count int    `json:"count" bson:"count"`
+}
+
 // QuestionCluster is a mini-cluster of viewpoints
 type QuestionCluster struct {
 	Label       string   `json:"label" bson:"label"`
@@ -116,6 +174,15 @@ type Contrast struct {
 	SideBCount int    `json:"sideBCount" bson:"sideBCount"`
 }
 
+// SlowQuestion is a friction candidate: its median response time is well above
+// the median across the room's questions
+type SlowQuestion struct {
+	QuestionKey  string  `json:"questionKey" bson:"questionKey"`
+	MedianMs     int64   `json:"medianMs" bson:"medianMs"`
+	RoomMedianMs int64   `json:"roomMedianMs" bson:"roomMedianMs"`
+	Ratio        float64 `json:"ratio" bson:"ratio"` // MedianMs / RoomMedianMs
+}
+
 // FrictionPoint is a question with high friction
 type FrictionPoint struct {
 	QuestionKey string  `json:"questionKey" bson:"questionKey"`
@@ -131,6 +198,7 @@ type RoomSnapshot struct {
 	SMSurveyID string    `json:"smSurveyId,omitempty" bson:"smSurveyId,omitempty"`
 	SMWebLink  string    `json:"smWebLink,omitempty" bson:"smWebLink,omitempty"`
 	EndedAt    time.Time `json:"endedAt" bson:"endedAt"`
+	Practice   bool      `json:"practice,omitempty" bson:"practice,omitempty"` // Demo room, AI output is canned
 
 	// Final leaderboard
 	Leaderboard []LeaderboardEntry `json:"leaderboard" bson:"leaderboard"`
@@ -141,10 +209,82 @@ type RoomSnapshot struct {
 	// Room memory
 	Memory RoomMemory `json:"memory" bson:"memory"`
 
+	// Per-player completion and drop-off
+	PlayerCompletion []PlayerCompletion `json:"playerCompletion" bson:"playerCompletion"`
+
+	// Answers the host starred, oldest star first; kept current when the snapshot is read
+	StarredAnswers []StarredAnswer `json:"starredAnswers" bson:"starredAnswers"`
+
+	// Per-minute participation curve, first activity to room end
+	Participation []ParticipationPoint `json:"participation" bson:"participation"`
+
+	// Questions that took abnormally long to answer, slowest first
+	SlowQuestions []SlowQuestion `json:"slowQuestions" bson:"slowQuestions"`
+
+	// Live polls the host ran during the session, in launch order (engagement, not survey answers)
+	Polls []Poll `json:"polls" bson:"polls"`
+
+	// Player emoji reactions over the session per emoji; the per-minute curve is in participation
+	Reactions map[string]int64 `json:"reactions" bson:"reactions"`
+
+	// Player ratings of the survey's clarity and length (meta-survey), nil without answers
+	MetaFeedback *MetaFeedback `json:"metaFeedback,omitempty" bson:"metaFeedback,omitempty"`
+
+	// Player ratings of AI follow-ups, nil when none were rated
+	FollowUpRelevance *FollowUpRelevance `json:"followUpRelevance,omitempty" bson:"followUpRelevance,omitempty"`
+
 	// Stats
 	TotalPlayers    int     `json:"totalPlayers" bson:"totalPlayers"`
 	CompletionRate  float64 `json:"completionRate" bson:"completionRate"`
 	OverallSkipRate float64 `json:"overallSkipRate" bson:"overallSkipRate"`
+
+	// AI cost of the session up to the room end
+	AIUsage *RoomUsageReport `json:"aiUsage,omitempty" bson:"aiUsage,omitempty"`
+}
+
+// ParticipationPoint is one minute of room activity
+type ParticipationPoint struct {
+	Minute           time.Time `json:"minute" bson:"minute"`
+	Joins            int64     `json:"joins" bson:"joins"`
+	Submissions      int64     `json:"submissions" bson:"submissions"`
+	Skips            int64     `json:"skips" bson:"skips"`
+	Evaluations      int64     `json:"evaluations" bson:"evaluations"`
+	AvgEvalLatencyMs float64   `json:"avgEvalLatencyMs" bson:"avgEvalLatencyMs"` // 0 when nothing was evaluated
+	Reactions        int64     `json:"reactions" bson:"reactions"`               // Player emoji reactions
+}
+
+// RoomTimeseries is the participation curve for the host dashboard
+type RoomTimeseries struct {
+	RoomCode string               `json:"roomCode"`
+	Points   []ParticipationPoint `json:"points"` // Oldest first, one per minute including quiet ones
+}
+
+// PlayerCompletion records whether a player finished and where they stopped
+type PlayerCompletion struct {
+	PlayerID   string `json:"playerId" bson:"playerId"`
+	Nickname   string `json:"nickname" bson:"nickname"`
+	Completed  bool   `json:"completed" bson:"completed"`
+	DropOffKey string `json:"dropOffKey,omitempty" bson:"dropOffKey,omitempty"` // Question they were on when the room ended
+}
+
+// FunnelReport shows how players progressed through the base questions
+type FunnelReport struct {
+	RoomCode     string       `json:"roomCode"`
+	TotalPlayers int          `json:"totalPlayers"`
+	Steps        []FunnelStep `json:"steps"` // Ordered by survey position
+}
+
+// FunnelStep is the funnel for a single base question (follow-ups count toward their parent).
+// Answered, Skipped and Abandoned are not exclusive: a player may answer the base question
+// and abandon during its follow-ups.
+type FunnelStep struct {
+	QuestionKey string `json:"questionKey"`
+	Position    int    `json:"position"` // 1-based survey position
+	Prompt      string `json:"prompt"`
+	Reached     int    `json:"reached"`
+	Answered    int    `json:"answered"`
+	Skipped     int    `json:"skipped"`
+	Abandoned   int    `json:"abandoned"` // Left the room while on this question
 }
 
 // LeaderboardEntry for snapshot
@@ -153,6 +293,11 @@ type LeaderboardEntry struct {
 	Nickname string `json:"nickname" bson:"nickname"`
 	Score    int    `json:"score" bson:"score"`
 	Rank     int    `json:"rank" bson:"rank"`
+
+	// Tie-break details, see cache.LeaderboardEntry
+	Tied          bool  `json:"tied" bson:"tied"`
+	CompletionSec int64 `json:"completionSec" bson:"completionSec"`
+	JoinOrder     int   `json:"joinOrder" bson:"joinOrder"`
 }
 
 // AIReport is the AI-generated insight report (async)
@@ -169,6 +314,9 @@ type AIReport struct {
 	RecommendedQuestions []string          `json:"recommendedQuestions,omitempty" bson:"recommendedQuestions,omitempty"`
 	RecommendedEdits     []QuestionEdit    `json:"recommendedEdits,omitempty" bson:"recommendedEdits,omitempty"`
 
+	// How the answer samples given to the model were picked
+	Sampling *EvidenceSampling `json:"sampling,omitempty" bson:"sampling,omitempty"`
+
 	CreatedAt time.Time  `json:"createdAt" bson:"createdAt"`
 	ReadyAt   *time.Time `json:"readyAt,omitempty" bson:"readyAt,omitempty"`
 }
@@ -212,3 +360,51 @@ type QuestionEdit struct {
 	SuggestedText string `json:"suggestedText" bson:"suggestedText"`
 	Reason        string `json:"reason" bson:"reason"`
 }
+
+// EvalQualityReport summarizes how often hosts corrected the AI's evaluations in a room
+type EvalQualityReport struct {
+	RoomCode       string                `json:"roomCode"`
+	Evaluated      int                   `json:"evaluated"`
+	Overridden     int                   `json:"overridden"`
+	OverrideRate   float64               `json:"overrideRate"`
+	UnsatToSat     int                   `json:"unsatToSat"` // AI too strict
+	SatToUnsat     int                   `json:"satToUnsat"` // AI too lenient
+	PointsOnly     int                   `json:"pointsOnly"` // Resolution kept, points changed
+	AvgPointsDelta float64               `json:"avgPointsDelta"`
+	Questions      []EvalQualityQuestion `json:"questions"`
+	Corrections    []EvalCorrection      `json:"corrections"`
+}
+
+// EvalQualityQuestion is the override breakdown for one question
+type EvalQualityQuestion struct {
+	QuestionKey  string  `json:"questionKey"`
+	Evaluated    int     `json:"evaluated"`
+	Overridden   int     `json:"overridden"`
+	OverrideRate float64 `json:"overrideRate"`
+}
+
+// EvalCorrection is one overridden answer, for reviewing rubrics
+type EvalCorrection struct {
+	AnswerID           string           `json:"answerId"`
+	QuestionKey        string           `json:"questionKey"`
+	TextAnswer         string           `json:"textAnswer"`
+	EvalSummary        string           `json:"evalSummary,omitempty"`
+	OriginalResolution AnswerResolution `json:"originalResolution"`
+	Resolution         AnswerResolution `json:"resolution"`
+	OriginalPoints     int              `json:"originalPoints"`
+	Points             int              `json:"points"`
+	Reason             string           `json:"reason,omitempty"`
+}
+
+// InsightProbe is a follow-up angle mined from a host's past rooms
+type InsightProbe struct {
+	Text  string `json:"text"`
+	Rooms int    `json:"rooms"` // Rooms whose analytics suggested it
+}
+
+// InsightQuestions is the result of condensing past probes into new survey questions
+type InsightQuestions struct {
+	Questions   []BaseQuestion `json:"questions"`
+	SourceRooms int            `json:"sourceRooms"` // Ended rooms mined for probes
+	Probes      []InsightProbe `json:"probes"`      // Probes sent to the condensation prompt, most common first
+}
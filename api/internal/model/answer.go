@@ -19,8 +19,15 @@ const (
 	AnswerStatusDraft     AnswerStatus = "DRAFT"
 	AnswerStatusSubmitted AnswerStatus = "SUBMITTED"
 	AnswerStatusEvaluated AnswerStatus = "EVALUATED"
+	AnswerStatusScreened  AnswerStatus = "SCREENED_OUT" // Option quota full; the player's survey ended
 )
 
+// DuplicateFlag marks an ESSAY answer that matches another player's recent answer
+type DuplicateFlag struct {
+	OfPlayerID string  `json:"ofPlayerId" bson:"ofPlayerId"`
+	Similarity float64 `json:"similarity" bson:"similarity"` // 1 for the same normalized text
+}
+
 // Answer represents a player's response to a question
 type Answer struct {
 	ID              string `json:"id" bson:"_id,omitempty"`
@@ -30,22 +37,46 @@ type Answer struct {
 	ClientAttemptID string `json:"clientAttemptId" bson:"clientAttemptId"` // For idempotency
 
 	// Response data
-	TextAnswer  string `json:"textAnswer,omitempty" bson:"textAnswer,omitempty"`   // For ESSAY
-	DegreeValue int    `json:"degreeValue,omitempty" bson:"degreeValue,omitempty"` // For DEGREE
-	OptionIndex *int   `json:"optionIndex,omitempty" bson:"optionIndex,omitempty"` // For MCQ
+	TextAnswer   string `json:"textAnswer,omitempty" bson:"textAnswer,omitempty"`     // For ESSAY
+	DegreeValue  int    `json:"degreeValue,omitempty" bson:"degreeValue,omitempty"`   // For DEGREE
+	OptionIndex  *int   `json:"optionIndex,omitempty" bson:"optionIndex,omitempty"`   // For MCQ
+	MatrixValues []int  `json:"matrixValues,omitempty" bson:"matrixValues,omitempty"` // For MATRIX: column index per row
+	Ranking      []int  `json:"ranking,omitempty" bson:"ranking,omitempty"`           // For RANKING: option indexes, best first
+
+	Attachments []string `json:"attachments,omitempty" bson:"attachments,omitempty"` // Uploaded attachment IDs
+	VoiceClipID string   `json:"voiceClipId,omitempty" bson:"voiceClipId,omitempty"` // Recorded answer; TextAnswer is its transcript
 
 	// State
 	Status     AnswerStatus     `json:"status" bson:"status"`
 	Resolution AnswerResolution `json:"resolution,omitempty" bson:"resolution,omitempty"`
 	Tries      int              `json:"tries" bson:"tries"`
 
+	// Time from the question being served to this submission, 0 when unknown
+	ResponseMs int64 `json:"responseMs,omitempty" bson:"responseMs,omitempty"`
+
 	// Points
-	PointsEarned int `json:"pointsEarned" bson:"pointsEarned"`
+	PointsEarned int     `json:"pointsEarned" bson:"pointsEarned"`
+	QualityScore float64 `json:"qualityScore,omitempty" bson:"qualityScore,omitempty"` // AI score (0-1), kept for calibration
 
 	// AI Evaluation
 	Signals     *Signals `json:"signals,omitempty" bson:"signals,omitempty"`
 	EvalSummary string   `json:"evalSummary,omitempty" bson:"evalSummary,omitempty"` // Short summary
 
+	// Host correction of the AI judgment (original values kept for the quality report)
+	Override *AnswerOverride `json:"override,omitempty" bson:"override,omitempty"`
+
+	// Later human review used for calibration
+	Audit *AnswerAudit `json:"audit,omitempty" bson:"audit,omitempty"`
+
+	// Host flagged the answer as noteworthy during the session
+	Star *AnswerStar `json:"star,omitempty" bson:"star,omitempty"`
+
+	// Matches another player's recent answer to the question (copy-paste, collusion)
+	Duplicate *DuplicateFlag `json:"duplicate,omitempty" bson:"duplicate,omitempty"`
+
+	// Full submit response, replayed for a repeated clientAttemptId once Redis no longer holds it
+	Replay *SubmitAnswerResponse `json:"replay,omitempty" bson:"replay,omitempty"`
+
 	// Timestamps
 	CreatedAt   time.Time  `json:"createdAt" bson:"createdAt"`
 	UpdatedAt   time.Time  `json:"updatedAt" bson:"updatedAt"`
@@ -55,12 +86,43 @@ type Answer struct {
 // AttemptState is stored in Redis per player per question
 type AttemptState struct {
 	DraftAnswer     string           `json:"draftAnswer,omitempty"`
+	DraftVersion    int              `json:"draftVersion"` // Incremented on every draft save
+	DraftUpdatedAt  *time.Time       `json:"draftUpdatedAt,omitempty"`
 	SubmittedAnswer string           `json:"submittedAnswer,omitempty"`
+	PipedValue      string           `json:"pipedValue,omitempty"` // Answer as shown in later prompts ({{KEY.answer}})
 	Status          AnswerStatus     `json:"status"`
 	Resolution      AnswerResolution `json:"resolution,omitempty"`
 	Tries           int              `json:"tries"`
 	EvalSummary     string           `json:"evalSummary,omitempty"`
 	UpdatedAt       time.Time        `json:"updatedAt"`
+
+	ServedAt   *time.Time `json:"servedAt,omitempty"`   // First time the question was shown to the player
+	ResponseMs int64      `json:"responseMs,omitempty"` // ServedAt to the latest submission
+
+	// Retries of UNSAT answers: the best quality so far and the points already awarded for the question
+	BestQuality   float64 `json:"bestQuality,omitempty"`
+	PointsAwarded int     `json:"pointsAwarded,omitempty"`
+}
+
+// DraftState is returned when reading or saving a draft
+type DraftState struct {
+	QuestionKey  string     `json:"questionKey"`
+	Draft        string     `json:"draft"`
+	Version      int        `json:"version"` // Pass back as baseVersion / If-Match to avoid clobbering
+	LastModified *time.Time `json:"lastModified,omitempty"`
+}
+
+// DraftFromAttempt returns the draft held in an attempt state; a nil state is an empty draft
+func DraftFromAttempt(questionKey string, state *AttemptState) *DraftState {
+	if state == nil {
+		return &DraftState{QuestionKey: questionKey}
+	}
+	return &DraftState{
+		QuestionKey:  questionKey,
+		Draft:        state.DraftAnswer,
+		Version:      state.DraftVersion,
+		LastModified: state.DraftUpdatedAt,
+	}
 }
 
 // SubmitAnswerRequest is the request body for answer submission
@@ -70,6 +132,11 @@ type SubmitAnswerRequest struct {
 	TextAnswer      string `json:"textAnswer,omitempty"`
 	DegreeValue     int    `json:"degreeValue,omitempty"`
 	OptionIndex     *int   `json:"optionIndex,omitempty"`
+	MatrixValues    []int  `json:"matrixValues,omitempty"` // MATRIX: column index per row, in row order
+	Ranking         []int  `json:"ranking,omitempty"`      // RANKING: every option index once, best first
+
+	AttachmentIDs []string `json:"attachmentIds,omitempty"` // From the upload endpoint; question must allow attachments
+	VoiceClipID   string   `json:"voiceClipId,omitempty"`   // From the voice endpoint; replaces textAnswer with the transcript
 }
 
 // SubmitAnswerResponse is returned after answer submission
@@ -80,4 +147,82 @@ type SubmitAnswerResponse struct {
 	EvalSummary  string           `json:"evalSummary,omitempty"`
 	NextQuestion *Question        `json:"nextQuestion,omitempty"`
 	FollowUp     *Question        `json:"followUp,omitempty"` // If UNSAT and follow-up triggered
+	Message      string           `json:"message,omitempty"`  // Shown to screened-out players
+	Retry        *RetryGuidance   `json:"retry,omitempty"`    // UNSAT with tries left: the question stays current
+	Progress     *Progress        `json:"progress,omitempty"`
+}
+
+// RetryGuidance tells a player what their UNSAT answer is missing before they try again
+type RetryGuidance struct {
+	TriesLeft int      `json:"triesLeft"`
+	Guidance  string   `json:"guidance"`
+	Missing   []string `json:"missing,omitempty"` // From the evaluation's signals
+}
+
+// AnswerOverride records a host's manual correction of an evaluation
+type AnswerOverride struct {
+	HostID             string           `json:"hostId" bson:"hostId"`
+	OriginalResolution AnswerResolution `json:"originalResolution" bson:"originalResolution"`
+	OriginalPoints     int              `json:"originalPoints" bson:"originalPoints"`
+	Resolution         AnswerResolution `json:"resolution" bson:"resolution"`
+	Points             int              `json:"points" bson:"points"`
+	PointsMax          int              `json:"pointsMax" bson:"pointsMax"`
+	Reason             string           `json:"reason,omitempty" bson:"reason,omitempty"`
+	OverriddenAt       time.Time        `json:"overriddenAt" bson:"overriddenAt"`
+}
+
+// AnswerStar marks an answer the host found noteworthy, with an optional note
+type AnswerStar struct {
+	HostID    string    `json:"hostId" bson:"hostId"`
+	Note      string    `json:"note,omitempty" bson:"note,omitempty"`
+	StarredAt time.Time `json:"starredAt" bson:"starredAt"`
+}
+
+// MaxStarNoteChars caps the host's note on a starred answer
+const MaxStarNoteChars = 1000
+
+// StarRequest is the body of POST /v1/rooms/{code}/answers/{id}/star
+type StarRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+// StarredAnswer is a host-starred answer as listed in the room snapshot
+type StarredAnswer struct {
+	AnswerID    string    `json:"answerId" bson:"answerId"`
+	PlayerID    string    `json:"playerId" bson:"playerId"`
+	QuestionKey string    `json:"questionKey" bson:"questionKey"`
+	TextAnswer  string    `json:"textAnswer,omitempty" bson:"textAnswer,omitempty"`
+	EvalSummary string    `json:"evalSummary,omitempty" bson:"evalSummary,omitempty"`
+	Note        string    `json:"note,omitempty" bson:"note,omitempty"`
+	StarredAt   time.Time `json:"starredAt" bson:"starredAt"`
+}
+
+// AnswerAudit is a reviewer's independent judgment of an evaluated answer
+type AnswerAudit struct {
+	AuditorID    string           `json:"auditorId" bson:"auditorId"`
+	Resolution   AnswerResolution `json:"resolution" bson:"resolution"`
+	QualityScore float64          `json:"qualityScore" bson:"qualityScore"` // 0-1
+	Notes        string           `json:"notes,omitempty" bson:"notes,omitempty"`
+	AuditedAt    time.Time        `json:"auditedAt" bson:"auditedAt"`
+}
+
+// AuditRequest is the reviewer's judgment for POST /admin/answers/{id}/audit
+type AuditRequest struct {
+	Resolution   AnswerResolution `json:"resolution"`
+	QualityScore float64          `json:"qualityScore"`
+	Notes        string           `json:"notes,omitempty"`
+}
+
+// OverrideRequest is the host's requested correction
+type OverrideRequest struct {
+	Resolution AnswerResolution `json:"resolution"` // SAT or UNSAT
+	Points     *int             `json:"points,omitempty"`
+	Reason     string           `json:"reason,omitempty"`
+}
+
+// OverrideResponse is returned after an override is applied
+type OverrideResponse struct {
+	Answer              *Answer `json:"answer"`
+	LeaderboardAdjusted bool    `json:"leaderboardAdjusted"` // false once the room has ended
+	PlayerScore         int     `json:"playerScore,omitempty"`
 }
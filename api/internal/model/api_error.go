@@ -0,0 +1,20 @@
+package model
+
+// APIError is the error body of /v1 routes; some errors add fields, e.g. plan limits
+type APIError struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+// TypedError is the error body of /v2 routes
+type TypedError struct {
+	Error TypedErrorDetail `json:"error"`
+}
+
+// TypedErrorDetail says what went wrong in a form clients can switch on
+type TypedErrorDetail struct {
+	Code    string                 `json:"code"` // e.g. not_found, plan_limit_exceeded
+	Message string                 `json:"message"`
+	Status  int                    `json:"status"`
+	Details map[string]interface{} `json:"details,omitempty"` // Extra fields of the error, e.g. plan and limit
+}
@@ -0,0 +1,56 @@
+package model
+
+import "time"
+
+// API key scopes
+const (
+	ScopeRoomsRead    = "rooms:read"
+	ScopeRoomsWrite   = "rooms:write"
+	ScopeReportsRead  = "reports:read"
+	ScopeReportsWrite = "reports:write"
+	ScopeSurveysRead  = "surveys:read"
+	ScopeSurveysWrite = "surveys:write"
+)
+
+// AllScopes lists every scope an API key can be granted
+var AllScopes = []string{
+	ScopeRoomsRead, ScopeRoomsWrite,
+	ScopeReportsRead, ScopeReportsWrite,
+	ScopeSurveysRead, ScopeSurveysWrite,
+}
+
+// APIKey is a host-scoped credential for server-to-server integrations.
+// Only the SHA-256 hash of the key is stored.
+type APIKey struct {
+	ID         string     `json:"id" bson:"_id,omitempty"`
+	HostID     string     `json:"hostId" bson:"hostId"`
+	Name       string     `json:"name" bson:"name"`
+	Prefix     string     `json:"prefix" bson:"prefix"` // First characters of the key, to tell keys apart
+	Hash       string     `json:"-" bson:"hash"`
+	Scopes     []string   `json:"scopes" bson:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt" bson:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty" bson:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty" bson:"revokedAt,omitempty"`
+}
+
+// HasScope reports whether the key was granted scope
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateAPIKeyRequest is the request body for creating an API key
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// CreateAPIKeyResponse returns the plaintext key, which is shown only once
+type CreateAPIKeyResponse struct {
+	Key    string  `json:"key"`
+	APIKey *APIKey `json:"apiKey"`
+}
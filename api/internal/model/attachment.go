@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// Limits on answer attachments
+const (
+	MaxAttachmentBytes      = 5 << 20 // 5 MB per file
+	MaxAttachmentsPerAnswer = 3
+)
+
+// AttachmentContentTypes are the image types accepted for upload, by sniffed content type
+var AttachmentContentTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// Attachment is an image a player uploaded for a question. The file itself
+// lives in the attachment store under StorageKey.
+type Attachment struct {
+	ID          string    `json:"id" bson:"_id"`
+	RoomCode    string    `json:"roomCode" bson:"roomCode"`
+	PlayerID    string    `json:"playerId" bson:"playerId"`
+	QuestionKey string    `json:"questionKey" bson:"questionKey"`
+	FileName    string    `json:"fileName" bson:"fileName"`
+	ContentType string    `json:"contentType" bson:"contentType"`
+	Size        int64     `json:"size" bson:"size"`
+	StorageKey  string    `json:"-" bson:"storageKey"`
+	CreatedAt   time.Time `json:"createdAt" bson:"createdAt"`
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// AuditAction is a host action recorded in a room's audit trail
+type AuditAction string
+
+const (
+	AuditRoomCreated       AuditAction = "room_created"
+	AuditRoomStarted       AuditAction = "room_started"
+	AuditRoomEnded         AuditAction = "room_ended"
+	AuditAnswerOverridden  AuditAction = "answer_overridden"
+	AuditPlayerDataDeleted AuditAction = "player_data_deleted"
+	AuditReportShared      AuditAction = "report_shared"
+	AuditAnswerStarred     AuditAction = "answer_starred"
+	AuditAnswerUnstarred   AuditAction = "answer_unstarred"
+)
+
+// AuditEntry is one host action on a room; entries are append-only
+type AuditEntry struct {
+	ID       string                 `json:"id" bson:"_id,omitempty"`
+	RoomCode string                 `json:"roomCode" bson:"roomCode"`
+	HostID   string                 `json:"hostId" bson:"hostId"`
+	Action   AuditAction            `json:"action" bson:"action"`
+	Payload  map[string]interface{} `json:"payload,omitempty" bson:"payload,omitempty"`
+	At       time.Time              `json:"at" bson:"at"`
+}
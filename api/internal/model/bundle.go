@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// BundleVersion is bumped whenever the room bundle layout changes incompatibly
+const BundleVersion = 1
+
+// RoomBundle is everything stored about one room as a single JSON document, for
+// offline analysis, study archives and reproducing issues locally
+type RoomBundle struct {
+	Version          int                `json:"version"`
+	ExportedAt       time.Time          `json:"exportedAt"`
+	Room             *Room              `json:"room"`
+	Survey           *Survey            `json:"survey,omitempty"`
+	Answers          []*Answer          `json:"answers"`
+	QuestionProfiles []*QuestionProfile `json:"questionProfiles"` // Archived L3 profiles
+	PlayerProfiles   []*PlayerProfile   `json:"playerProfiles"`   // Archived L2 profiles
+	Memory           *RoomMemory        `json:"memory,omitempty"` // Archived L4 memory
+	Snapshot         *RoomSnapshot      `json:"snapshot,omitempty"`
+	AIReport         *AIReport          `json:"aiReport,omitempty"`
+	Events           []*RoomEvent       `json:"events"` // Oldest first; older events may have been overwritten
+}
+
+// BundleImport reports what an import wrote
+type BundleImport struct {
+	RoomCode string `json:"roomCode"`
+	SurveyID string `json:"surveyId,omitempty"`
+	HostID   string `json:"hostId"`
+	Answers  int    `json:"answers"`
+	Events   int    `json:"events"`
+}
@@ -0,0 +1,45 @@
+package model
+
+// CalibrationReport compares AI quality scores with human judgments (host
+// overrides and audits) to surface systematic evaluation bias
+type CalibrationReport struct {
+	HostID           string              `json:"hostId"`
+	Samples          int                 `json:"samples"`
+	Overrides        int                 `json:"overrides"`
+	Audits           int                 `json:"audits"`
+	AgreementRate    float64             `json:"agreementRate"`    // AI and human resolution match
+	MeanAIQuality    float64             `json:"meanAiQuality"`    // Over samples with a human score
+	MeanHumanQuality float64             `json:"meanHumanQuality"` // Over samples with a human score
+	MeanBias         float64             `json:"meanBias"`         // human - AI; positive means the AI is too harsh
+	LengthBuckets    []CalibrationBucket `json:"lengthBuckets"`
+	Rubrics          []RubricCalibration `json:"rubrics"`
+	Findings         []string            `json:"findings"`
+}
+
+// CalibrationBucket groups samples by answer length
+type CalibrationBucket struct {
+	Label        string  `json:"label"`
+	MinWords     int     `json:"minWords"`
+	MaxWords     int     `json:"maxWords"` // 0 = unbounded
+	Samples      int     `json:"samples"`
+	MeanBias     float64 `json:"meanBias"`
+	HarshFlips   int     `json:"harshFlips"`   // AI UNSAT, human SAT
+	LenientFlips int     `json:"lenientFlips"` // AI SAT, human UNSAT
+}
+
+// RubricCalibration is the calibration of one survey question's rubric
+type RubricCalibration struct {
+	SurveyID           string   `json:"surveyId"`
+	SurveyTitle        string   `json:"surveyTitle"`
+	QuestionKey        string   `json:"questionKey"`
+	Prompt             string   `json:"prompt"`
+	Samples            int      `json:"samples"`
+	AgreementRate      float64  `json:"agreementRate"`
+	MeanBias           float64  `json:"meanBias"`
+	HarshFlips         int      `json:"harshFlips"`
+	LenientFlips       int      `json:"lenientFlips"`
+	CurrentThreshold   float64  `json:"currentThreshold"`
+	SuggestedThreshold *float64 `json:"suggestedThreshold,omitempty"` // Only with enough samples and a clear gain
+	ThresholdAgreement float64  `json:"thresholdAgreement"`           // Agreement of quality >= current threshold with humans
+	SuggestedAgreement float64  `json:"suggestedAgreement,omitempty"`
+}
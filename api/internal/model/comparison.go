@@ -0,0 +1,107 @@
+package model
+
+import "time"
+
+// Comparison report limits
+const (
+	MaxComparisonThemes    = 10   // Theme shifts kept, largest first
+	MinComparisonThemeMove = 0.05 // Share change below which a theme counts as steady
+)
+
+// Theme shift directions
+const (
+	ThemeShiftNew  = "new"  // Not raised in the baseline room
+	ThemeShiftGone = "gone" // Raised in the baseline room only
+	ThemeShiftUp   = "up"
+	ThemeShiftDown = "down"
+)
+
+// Comparison verdicts, the model's reading of whether the changes had impact
+const (
+	ComparisonImproved  = "improved"
+	ComparisonMixed     = "mixed"
+	ComparisonWorse     = "worse"
+	ComparisonUnchanged = "unchanged"
+)
+
+// ComparisonRequest names two ended rooms of the same survey to compare
+type ComparisonRequest struct {
+	BaselineRoom string `json:"baselineRoom"` // The earlier session
+	RoomCode     string `json:"roomCode"`     // The session after the changes
+}
+
+// ComparisonReport compares a room with an earlier session of the same survey so
+// the host can see whether changes between the sessions had impact. Numbers are
+// measured from the two snapshots; the summary, notes and verdict come from the
+// comparison prompt.
+type ComparisonReport struct {
+	ID           string `json:"id" bson:"_id,omitempty"`
+	HostID       string `json:"hostId" bson:"hostId"`
+	SurveyID     string `json:"surveyId" bson:"surveyId"`
+	BaselineRoom string `json:"baselineRoom" bson:"baselineRoom"`
+	RoomCode     string `json:"roomCode" bson:"roomCode"`
+	Status       string `json:"status" bson:"status"` // "pending", "ready", "failed"
+
+	// Measured (populated when ready)
+	Stats              *ComparisonStats    `json:"stats,omitempty" bson:"stats,omitempty"`
+	ThemeShifts        []ThemeShift        `json:"themeShifts,omitempty" bson:"themeShifts,omitempty"`               // Largest change first
+	SatisfactionDeltas []SatisfactionDelta `json:"satisfactionDeltas,omitempty" bson:"satisfactionDeltas,omitempty"` // Per question, survey order
+	NewFrictionPoints  []NewFrictionPoint  `json:"newFrictionPoints,omitempty" bson:"newFrictionPoints,omitempty"`   // Worst first
+
+	// Written by the model (populated when ready)
+	Summary         []string `json:"summary,omitempty" bson:"summary,omitempty"`
+	Verdict         string   `json:"verdict,omitempty" bson:"verdict,omitempty"`
+	Recommendations []string `json:"recommendations,omitempty" bson:"recommendations,omitempty"`
+
+	CreatedAt time.Time  `json:"createdAt" bson:"createdAt"`
+	ReadyAt   *time.Time `json:"readyAt,omitempty" bson:"readyAt,omitempty"`
+}
+
+// MetricDelta is one measure in both rooms
+type MetricDelta struct {
+	Baseline float64 `json:"baseline" bson:"baseline"`
+	Value    float64 `json:"value" bson:"value"`
+	Delta    float64 `json:"delta" bson:"delta"` // value - baseline
+}
+
+// NewMetricDelta pairs a baseline measure with the compared one
+func NewMetricDelta(baseline, value float64) MetricDelta {
+	return MetricDelta{Baseline: baseline, Value: value, Delta: value - baseline}
+}
+
+// ComparisonStats compares the rooms overall
+type ComparisonStats struct {
+	Players        MetricDelta  `json:"players" bson:"players"`
+	CompletionRate MetricDelta  `json:"completionRate" bson:"completionRate"`
+	SkipRate       MetricDelta  `json:"skipRate" bson:"skipRate"`
+	SatRate        *MetricDelta `json:"satRate,omitempty" bson:"satRate,omitempty"`     // Share of SAT answers; nil unless both rooms resolved answers
+	AvgRating      *MetricDelta `json:"avgRating,omitempty" bson:"avgRating,omitempty"` // DEGREE answers; nil unless both rooms have ratings
+}
+
+// ThemeShift is a theme whose share of the theme mentions moved between the rooms
+type ThemeShift struct {
+	Theme         string  `json:"theme" bson:"theme"`
+	BaselineShare float64 `json:"baselineShare" bson:"baselineShare"`
+	Share         float64 `json:"share" bson:"share"`
+	Delta         float64 `json:"delta" bson:"delta"`
+	Change        string  `json:"change" bson:"change"`                 // new, gone, up or down
+	Note          string  `json:"note,omitempty" bson:"note,omitempty"` // The model's reading of the shift
+}
+
+// SatisfactionDelta compares one question across the rooms
+type SatisfactionDelta struct {
+	QuestionKey string       `json:"questionKey" bson:"questionKey"`
+	SkipRate    MetricDelta  `json:"skipRate" bson:"skipRate"`
+	SatRate     *MetricDelta `json:"satRate,omitempty" bson:"satRate,omitempty"`     // nil unless both rooms resolved answers
+	AvgRating   *MetricDelta `json:"avgRating,omitempty" bson:"avgRating,omitempty"` // nil unless both rooms have ratings
+}
+
+// NewFrictionPoint is a question with friction in the room but not in the baseline room
+type NewFrictionPoint struct {
+	QuestionKey        string  `json:"questionKey" bson:"questionKey"`
+	SkipRate           float64 `json:"skipRate" bson:"skipRate"`
+	UnsatRate          float64 `json:"unsatRate" bson:"unsatRate"`
+	BaselineSkipRate   float64 `json:"baselineSkipRate" bson:"baselineSkipRate"`
+	BaselineUnsatRate  float64 `json:"baselineUnsatRate" bson:"baselineUnsatRate"`
+	HypothesizedReason string  `json:"hypothesizedReason,omitempty" bson:"hypothesizedReason,omitempty"` // From the model
+}
@@ -0,0 +1,7 @@
+package model
+
+// CopilotHint is one facilitation suggestion for the host during a live session
+type CopilotHint struct {
+	Text        string `json:"text"`
+	QuestionKey string `json:"questionKey,omitempty"` // The question the hint is about, if any
+}
@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// QuestionDifficulty is how hard one survey question proved across the survey's ended rooms
+type QuestionDifficulty struct {
+	QuestionKey string       `json:"questionKey" bson:"questionKey"`
+	Type        QuestionType `json:"type" bson:"type"`
+	Rooms       int          `json:"rooms" bson:"rooms"`     // Rooms in which the question was answered or skipped
+	Answers     int          `json:"answers" bson:"answers"` // SAT + UNSAT evaluations
+	Skips       int          `json:"skips" bson:"skips"`
+	UnsatRate   float64      `json:"unsatRate" bson:"unsatRate"`   // UNSAT / answers
+	SkipRate    float64      `json:"skipRate" bson:"skipRate"`     // skips / (answers + skips)
+	Difficulty  float64      `json:"difficulty" bson:"difficulty"` // 0 = easy, 1 = nobody gets through
+
+	// Current values and, when the data supports a change, the suggested ones
+	Threshold          float64  `json:"threshold" bson:"threshold"`
+	PointsMax          int      `json:"pointsMax" bson:"pointsMax"`
+	SuggestedThreshold *float64 `json:"suggestedThreshold,omitempty" bson:"suggestedThreshold,omitempty"`
+	SuggestedPointsMax *int     `json:"suggestedPointsMax,omitempty" bson:"suggestedPointsMax,omitempty"`
+	Reason             string   `json:"reason,omitempty" bson:"reason,omitempty"`
+}
+
+// HasSuggestion reports whether the question has a threshold or points change to apply
+func (q QuestionDifficulty) HasSuggestion() bool {
+	return q.SuggestedThreshold != nil || q.SuggestedPointsMax != nil
+}
+
+// DifficultyReport is the latest difficulty calibration of a survey
+type DifficultyReport struct {
+	SurveyID   string               `json:"surveyId" bson:"surveyId"`
+	HostID     string               `json:"hostId" bson:"hostId"`
+	Rooms      int                  `json:"rooms" bson:"rooms"` // Ended, non-practice rooms analysed
+	Questions  []QuestionDifficulty `json:"questions" bson:"questions"`
+	ComputedAt time.Time            `json:"computedAt" bson:"computedAt"`
+	AppliedAt  *time.Time           `json:"appliedAt,omitempty" bson:"appliedAt,omitempty"` // When suggestions were last written to the survey
+	Applied    []string             `json:"applied,omitempty" bson:"applied,omitempty"`     // Question keys changed then
+}
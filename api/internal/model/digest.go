@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// Weekly digest limits
+const (
+	DigestThemes     = 5 // Top themes across the week
+	MaxDigestChanges = 6
+)
+
+// DigestSettings is a host's opt-in to the weekly digest of their rooms. The
+// digest is emailed to the recipients and posted to the host's integrations
+// subscribed to the weekly_digest event.
+type DigestSettings struct {
+	HostID     string   `json:"hostId" bson:"_id"`
+	Enabled    bool     `json:"enabled" bson:"enabled"`
+	Recipients []string `json:"recipients" bson:"recipients"`
+
+	LastWeek   *time.Time `json:"lastWeek,omitempty" bson:"lastWeek,omitempty"` // Start of the last week a digest went out for
+	LastSentAt *time.Time `json:"lastSentAt,omitempty" bson:"lastSentAt,omitempty"`
+	LastError  string     `json:"lastError,omitempty" bson:"lastError,omitempty"`
+	UpdatedAt  time.Time  `json:"updatedAt" bson:"updatedAt"`
+}
+
+// DigestSettingsRequest is the request body for changing digest settings
+type DigestSettingsRequest struct {
+	Enabled    bool     `json:"enabled"`
+	Recipients []string `json:"recipients"`
+}
+
+// Digest summarizes the rooms a host ran in one week, Monday to Monday UTC
+type Digest struct {
+	HostID    string    `json:"hostId"`
+	WeekStart time.Time `json:"weekStart"`
+	WeekEnd   time.Time `json:"weekEnd"`
+
+	Rooms          []DigestRoom `json:"rooms"` // Ended in the week, practice rooms left out, oldest first
+	Players        int          `json:"players"`
+	CompletionRate float64      `json:"completionRate"` // Over all players of the week
+	TopThemes      []ThemeCount `json:"topThemes"`
+
+	// Notable changes against the week before, measured
+	Changes []string `json:"changes"`
+	// Written by the report model from the rooms and changes
+	Highlights []string `json:"highlights"`
+}
+
+// DigestRoom is one room of a digest
+type DigestRoom struct {
+	RoomCode       string    `json:"roomCode"`
+	SurveyID       string    `json:"surveyId"`
+	SurveyTitle    string    `json:"surveyTitle,omitempty"`
+	EndedAt        time.Time `json:"endedAt"`
+	Players        int       `json:"players"`
+	CompletionRate float64   `json:"completionRate"`
+	SkipRate       float64   `json:"skipRate"`
+	TopThemes      []string  `json:"topThemes,omitempty"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// Email delivery statuses per recipient
+const (
+	EmailStatusSent   = "sent"
+	EmailStatusFailed = "failed"
+)
+
+// EmailReportRequest is the request body for emailing a room's AI report
+type EmailReportRequest struct {
+	Recipients []string `json:"recipients"`
+	Subject    string   `json:"subject,omitempty"` // Defaults to "Results for room CODE"
+	Message    string   `json:"message,omitempty"` // Optional note shown above the report
+}
+
+// EmailDelivery records one send of a report to a list of recipients
+type EmailDelivery struct {
+	ID         string           `json:"id" bson:"_id,omitempty"`
+	RoomCode   string           `json:"roomCode" bson:"roomCode"`
+	HostID     string           `json:"hostId" bson:"hostId"`
+	Provider   string           `json:"provider" bson:"provider"`
+	Subject    string           `json:"subject" bson:"subject"`
+	Recipients []EmailRecipient `json:"recipients" bson:"recipients"`
+	CreatedAt  time.Time        `json:"createdAt" bson:"createdAt"`
+}
+
+// EmailRecipient is the delivery status of a report for one address
+type EmailRecipient struct {
+	Email  string     `json:"email" bson:"email"`
+	Status string     `json:"status" bson:"status"` // sent, failed
+	Error  string     `json:"error,omitempty" bson:"error,omitempty"`
+	SentAt *time.Time `json:"sentAt,omitempty" bson:"sentAt,omitempty"`
+}
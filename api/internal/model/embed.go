@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+// EmbedTokenRequest is the request body for creating an embed token
+type EmbedTokenRequest struct {
+	ExpiresInHours int `json:"expiresInHours"` // Defaults to 24 hours
+}
+
+// EmbedToken grants read-only access to a room's live results widget
+type EmbedToken struct {
+	Token     string    `json:"token"`
+	DataURL   string    `json:"dataUrl"`   // JSON polling endpoint
+	StreamURL string    `json:"streamUrl"` // SSE endpoint
+	IframeURL string    `json:"iframeUrl"` // Web app widget page
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// EmbedData is the live results payload shaped for an embeddable widget.
+// It carries no player identities.
+type EmbedData struct {
+	RoomCode      string             `json:"roomCode"`
+	Status        RoomStatus         `json:"status"`
+	Participation EmbedParticipation `json:"participation"`
+	Themes        []ThemeCount       `json:"themes"`
+	Questions     []EmbedQuestion    `json:"questions"`
+	UpdatedAt     time.Time          `json:"updatedAt"`
+}
+
+// EmbedParticipation counts players and answers in the room
+type EmbedParticipation struct {
+	Players        int     `json:"players"`
+	Finished       int     `json:"finished"`
+	Answers        int     `json:"answers"`
+	CompletionRate float64 `json:"completionRate"`
+}
+
+// EmbedQuestion holds the aggregate results of one base question
+type EmbedQuestion struct {
+	Key         string       `json:"key"`
+	Prompt      string       `json:"prompt"`
+	Type        QuestionType `json:"type"`
+	AnswerCount int          `json:"answerCount"`
+	SkipCount   int          `json:"skipCount"`
+	ScaleMin    int          `json:"scaleMin,omitempty"`
+	ScaleMax    int          `json:"scaleMax,omitempty"`
+	RatingHist  map[int]int  `json:"ratingHist,omitempty"` // DEGREE: value -> count
+	RatingMean  float64      `json:"ratingMean,omitempty"`
+	Options     []string     `json:"options,omitempty"`    // MCQ
+	OptionHist  map[int]int  `json:"optionHist,omitempty"` // MCQ: index -> count
+	Themes      []ThemeCount `json:"themes,omitempty"`     // ESSAY: top themes
+
+	// MATRIX: rows, columns and row -> column -> count
+	Rows       []string            `json:"rows,omitempty"`
+	Columns    []string            `json:"columns,omitempty"`
+	MatrixHist map[int]map[int]int `json:"matrixHist,omitempty"`
+
+	// RANKING: option -> mean position (1 = best)
+	AvgPosition map[int]float64 `json:"avgPosition,omitempty"`
+
+	// WORDS: most frequent words first
+	WordCloud []WordCount `json:"wordCloud,omitempty"`
+}
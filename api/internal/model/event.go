@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// RoomEvent is one persisted broadcast from a room's event stream
+type RoomEvent struct {
+	ID       string      `json:"-" bson:"_id,omitempty"`
+	RoomCode string      `json:"roomCode" bson:"roomCode"`
+	Seq      int64       `json:"seq" bson:"seq"` // Increases per room in publish order
+	Type     string      `json:"type" bson:"type"`
+	Audience string      `json:"audience" bson:"audience"` // host, player, players, room
+	PlayerID string      `json:"playerId,omitempty" bson:"playerId,omitempty"`
+	Payload  interface{} `json:"payload" bson:"payload"`
+	At       time.Time   `json:"at" bson:"at"`
+}
+
+// RoomEventPage is a slice of a room's event stream
+type RoomEventPage struct {
+	RoomCode string       `json:"roomCode"`
+	Events   []*RoomEvent `json:"events"`
+	LastSeq  int64        `json:"lastSeq"` // Pass as afterSeq to continue; echoes afterSeq when empty
+	HasMore  bool         `json:"hasMore"`
+}
@@ -0,0 +1,70 @@
+package model
+
+import "time"
+
+// Event limits
+const (
+	MaxEventGroupNameChars = 100
+	MaxEventGroupRooms     = 50
+)
+
+// EventGroup is a multi-room event, e.g. a conference running breakout rooms
+// of the same survey, with a combined leaderboard, analytics and AI report
+type EventGroup struct {
+	ID        string   `json:"id" bson:"_id,omitempty"`
+	HostID    string   `json:"hostId" bson:"hostId"`
+	Name      string   `json:"name" bson:"name"`
+	SurveyID  string   `json:"surveyId" bson:"surveyId"` // Every room runs this survey
+	RoomCodes []string `json:"roomCodes" bson:"roomCodes"`
+
+	// Merged AI report over all rooms; its roomCode holds the event ID
+	Report *AIReport `json:"report,omitempty" bson:"report,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// EventGroupRequest is the request body for creating an event
+type EventGroupRequest struct {
+	Name      string   `json:"name"`
+	RoomCodes []string `json:"roomCodes"`
+}
+
+// EventLeaderboardEntry is one player on an event's combined leaderboard
+type EventLeaderboardEntry struct {
+	PlayerID      string `json:"playerId"` // "<roomCode>:<playerId>", unique across the event
+	RoomCode      string `json:"roomCode"`
+	Nickname      string `json:"nickname"`
+	Score         int    `json:"score"`
+	Rank          int    `json:"rank"`
+	Tied          bool   `json:"tied"`
+	CompletionSec int64  `json:"completionSec"`
+}
+
+// EventLeaderboard ranks every player of an event's rooms together
+type EventLeaderboard struct {
+	EventID      string                  `json:"eventId"`
+	TotalPlayers int                     `json:"totalPlayers"`
+	Entries      []EventLeaderboardEntry `json:"entries"` // Best first
+}
+
+// EventRoomSummary is one room's share of an event
+type EventRoomSummary struct {
+	RoomCode       string     `json:"roomCode"`
+	Status         RoomStatus `json:"status"`
+	Players        int        `json:"players"`
+	CompletionRate float64    `json:"completionRate"`
+	SkipRate       float64    `json:"skipRate"`
+}
+
+// EventAnalytics aggregates the question profiles and themes of an event's rooms
+type EventAnalytics struct {
+	EventID         string             `json:"eventId"`
+	SurveyID        string             `json:"surveyId"`
+	Rooms           []EventRoomSummary `json:"rooms"`
+	TotalPlayers    int                `json:"totalPlayers"`
+	CompletionRate  float64            `json:"completionRate"`
+	OverallSkipRate float64            `json:"overallSkipRate"`
+	Questions       []QuestionProfile  `json:"questions"` // Survey order; counts summed across rooms
+	Themes          []ThemeCount       `json:"themes"`    // Most frequent first
+}
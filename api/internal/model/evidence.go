@@ -0,0 +1,33 @@
+package model
+
+// Evidence sampling strategies for the AI report prompt
+const (
+	EvidenceStrategyStratified = "stratified" // Quality extremes, contrasting sentiment and outlier themes, then spread over the room
+	EvidenceStrategyFirst      = "first"      // The first summaries in submission order
+)
+
+// Evidence strata: why a sample was picked
+const (
+	EvidenceStarred      = "starred"
+	EvidenceHighQuality  = "high_quality"
+	EvidenceLowQuality   = "low_quality"
+	EvidencePositive     = "positive"
+	EvidenceNegative     = "negative"
+	EvidenceOutlierTheme = "outlier_theme" // Brings up a theme few other answers mention
+	EvidenceSpread       = "spread"        // Evenly spaced over the rest of the room, in submission order
+	EvidenceFirst        = "first"
+)
+
+// EvidenceSampling documents how the answer samples behind an AI report were picked
+type EvidenceSampling struct {
+	Strategy    string                     `json:"strategy" bson:"strategy"`
+	PerQuestion int                        `json:"perQuestion" bson:"perQuestion"` // Most samples per question
+	Questions   []QuestionEvidenceSampling `json:"questions" bson:"questions"`
+}
+
+// QuestionEvidenceSampling is the sample of one question
+type QuestionEvidenceSampling struct {
+	QuestionKey string         `json:"questionKey" bson:"questionKey"`
+	Eligible    int            `json:"eligible" bson:"eligible"` // Answers with a summary, duplicates left out
+	Strata      map[string]int `json:"strata" bson:"strata"`     // Samples per stratum
+}
@@ -0,0 +1,118 @@
+package model
+
+import "time"
+
+// ExperimentUnit is what an experiment assigns to variants
+type ExperimentUnit string
+
+const (
+	ExperimentUnitRoom   ExperimentUnit = "room"   // Every player in a room gets the room's variant
+	ExperimentUnitPlayer ExperimentUnit = "player" // Players in the same room are split across variants
+)
+
+// ExperimentStatus is the lifecycle state of an experiment
+type ExperimentStatus string
+
+const (
+	ExperimentStatusActive  ExperimentStatus = "ACTIVE"  // New rooms may join and players are assigned
+	ExperimentStatusStopped ExperimentStatus = "STOPPED" // No new rooms; running rooms keep their variants
+)
+
+// FollowUpStyle changes how the follow-up prompt asks for more detail
+type FollowUpStyle string
+
+const (
+	FollowUpStyleDefault    FollowUpStyle = ""           // The standard prompt
+	FollowUpStyleGentle     FollowUpStyle = "gentle"     // Only follow up on clearly vague answers, soft wording
+	FollowUpStyleAggressive FollowUpStyle = "aggressive" // Probe most answers for one more concrete detail
+)
+
+// Experiment compares prompt and follow-up strategies across rooms or players
+type Experiment struct {
+	ID          string              `json:"id" bson:"_id,omitempty"`
+	HostID      string              `json:"hostId" bson:"hostId"`
+	Name        string              `json:"name" bson:"name"`
+	Description string              `json:"description,omitempty" bson:"description,omitempty"`
+	Unit        ExperimentUnit      `json:"unit" bson:"unit"`
+	Variants    []ExperimentVariant `json:"variants" bson:"variants"`
+	Status      ExperimentStatus    `json:"status" bson:"status"`
+	CreatedAt   time.Time           `json:"createdAt" bson:"createdAt"`
+	StoppedAt   *time.Time          `json:"stoppedAt,omitempty" bson:"stoppedAt,omitempty"`
+}
+
+// Variant returns the variant with key, or nil
+func (e *Experiment) Variant(key string) *ExperimentVariant {
+	for i := range e.Variants {
+		if e.Variants[i].Key == key {
+			return &e.Variants[i]
+		}
+	}
+	return nil
+}
+
+// ExperimentVariant is one arm of an experiment
+type ExperimentVariant struct {
+	Key    string `json:"key" bson:"key"`       // e.g. "control", "aggressive"
+	Weight int    `json:"weight" bson:"weight"` // Relative share of assignments (0 = 1)
+
+	FollowUpStyle FollowUpStyle `json:"followUpStyle,omitempty" bson:"followUpStyle,omitempty"`
+	MaxFollowUps  *int          `json:"maxFollowUps,omitempty" bson:"maxFollowUps,omitempty"` // Overrides per-question caps
+	PromptNote    string        `json:"promptNote,omitempty" bson:"promptNote,omitempty"`     // Extra instruction for follow-up generation
+}
+
+// ChangesPrompt reports whether follow-ups for this variant need their own prompt
+// instead of pre-generated pool entries
+func (v *ExperimentVariant) ChangesPrompt() bool {
+	return v.FollowUpStyle != FollowUpStyleDefault || v.PromptNote != ""
+}
+
+// ExperimentAssignment records which variant a room or player received
+type ExperimentAssignment struct {
+	ExperimentID string    `json:"experimentId" bson:"experimentId"`
+	RoomCode     string    `json:"roomCode" bson:"roomCode"`
+	PlayerID     string    `json:"playerId,omitempty" bson:"playerId"` // Empty for room-unit assignments
+	Variant      string    `json:"variant" bson:"variant"`
+	AssignedAt   time.Time `json:"assignedAt" bson:"assignedAt"`
+}
+
+// RoomExperiment is the experiment a room takes part in, copied into the room and its meta
+type RoomExperiment struct {
+	ID       string              `json:"id" bson:"id"`
+	Unit     ExperimentUnit      `json:"unit" bson:"unit"`
+	Variant  string              `json:"variant,omitempty" bson:"variant,omitempty"` // Room unit only
+	Variants []ExperimentVariant `json:"variants,omitempty" bson:"-"`                // Meta only, for player assignment
+}
+
+// CreateExperimentRequest is the request body for creating an experiment
+type CreateExperimentRequest struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Unit        ExperimentUnit      `json:"unit"` // Defaults to room
+	Variants    []ExperimentVariant `json:"variants"`
+}
+
+// ExperimentComparison reports outcome metrics side by side for each variant
+type ExperimentComparison struct {
+	ExperimentID string           `json:"experimentId"`
+	Name         string           `json:"name"`
+	Unit         ExperimentUnit   `json:"unit"`
+	Status       ExperimentStatus `json:"status"`
+	Variants     []VariantOutcome `json:"variants"`
+	Findings     []string         `json:"findings"`
+}
+
+// VariantOutcome aggregates the outcomes of one variant across its rooms
+type VariantOutcome struct {
+	Variant            string  `json:"variant"`
+	Rooms              int     `json:"rooms"`
+	Players            int     `json:"players"`
+	Answers            int     `json:"answers"` // Including skips
+	Skipped            int     `json:"skipped"`
+	SkipRate           float64 `json:"skipRate"`
+	SatRate            float64 `json:"satRate"`         // Over evaluated ESSAY answers
+	MeanQuality        float64 `json:"meanQuality"`     // Over evaluated ESSAY answers
+	FollowUpAnswers    int     `json:"followUpAnswers"` // Answers to AI follow-ups
+	FollowUpsPerPlayer float64 `json:"followUpsPerPlayer"`
+	CompletionRate     float64 `json:"completionRate"` // Over players in ended rooms
+	CompletionBase     int     `json:"completionBase"` // Players in ended rooms
+}
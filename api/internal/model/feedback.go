@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// PlayerFeedback is a player's end-of-room summary, sent as the last event before
+// the room disconnects and kept for late fetches
+type PlayerFeedback struct {
+	ID           string `json:"-" bson:"_id,omitempty"`
+	RoomCode     string `json:"roomCode" bson:"roomCode"`
+	PlayerID     string `json:"playerId" bson:"playerId"`
+	Nickname     string `json:"nickname" bson:"nickname"`
+	Score        int    `json:"score" bson:"score"`
+	Rank         int    `json:"rank,omitempty" bson:"rank,omitempty"` // 0 when not on the leaderboard
+	TotalPlayers int    `json:"totalPlayers" bson:"totalPlayers"`
+	Answered     int    `json:"answered" bson:"answered"` // Questions answered (skips and abandoned excluded)
+
+	StrongestAnswers []FeedbackAnswer `json:"strongestAnswers" bson:"strongestAnswers"`
+	Themes           []string         `json:"themes" bson:"themes"` // Themes the player raised, most frequent first
+
+	Message   string    `json:"message" bson:"message"` // AI-written thank-you and insight paragraph
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+}
+
+// FeedbackAnswer is one of the player's best-rated answers
+type FeedbackAnswer struct {
+	QuestionKey  string  `json:"questionKey" bson:"questionKey"`
+	Prompt       string  `json:"prompt,omitempty" bson:"prompt,omitempty"`
+	Excerpt      string  `json:"excerpt" bson:"excerpt"`
+	QualityScore float64 `json:"qualityScore" bson:"qualityScore"`
+	PointsEarned int     `json:"pointsEarned" bson:"pointsEarned"`
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Guardrail limits
+const (
+	MaxGuardrailItems       = 50
+	MaxGuardrailItemChars   = 100
+	MaxComplianceNotesChars = 2000
+)
+
+// Guardrails are host rules for AI-written follow-ups and reports on a survey
+type Guardrails struct {
+	BannedTopics     []string `json:"bannedTopics,omitempty" bson:"bannedTopics,omitempty"`         // Never asked about or raised
+	ForbiddenPhrases []string `json:"forbiddenPhrases,omitempty" bson:"forbiddenPhrases,omitempty"` // Wording the AI must not use
+	ComplianceNotes  string   `json:"complianceNotes,omitempty" bson:"complianceNotes,omitempty"`   // Free-form rules passed to the AI
+}
+
+// IsEmpty reports whether no guardrail is set
+func (g *Guardrails) IsEmpty() bool {
+	return g == nil || (len(g.BannedTopics) == 0 && len(g.ForbiddenPhrases) == 0 && strings.TrimSpace(g.ComplianceNotes) == "")
+}
+
+// Violation returns the banned topic or forbidden phrase text touches, or "".
+// Matching ignores case and punctuation and only counts whole words.
+func (g *Guardrails) Violation(text string) string {
+	if g == nil {
+		return ""
+	}
+	normalized := " " + normalizeGuardrailText(text) + " "
+	for _, list := range [][]string{g.BannedTopics, g.ForbiddenPhrases} {
+		for _, item := range list {
+			needle := normalizeGuardrailText(item)
+			if needle != "" && strings.Contains(normalized, " "+needle+" ") {
+				return item
+			}
+		}
+	}
+	return ""
+}
+
+// normalizeGuardrailText lowercases text and collapses everything but letters and digits to single spaces
+func normalizeGuardrailText(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// Integration providers
+const (
+	IntegrationSlack = "slack"
+	IntegrationTeams = "teams"
+)
+
+// Integration events
+const (
+	IntegrationEventRoomEnded   = "room_ended"
+	IntegrationEventReportReady = "report_ready"
+	IntegrationEventDigest      = "weekly_digest" // Sent only to hosts who opted in to the weekly digest
+)
+
+// Integration is a host's chat webhook that receives room summaries
+type Integration struct {
+	ID             string     `json:"id" bson:"_id,omitempty"`
+	HostID         string     `json:"hostId" bson:"hostId"`
+	Provider       string     `json:"provider" bson:"provider"` // slack, teams
+	Name           string     `json:"name" bson:"name"`
+	WebhookURL     string     `json:"-" bson:"webhookUrl"`            // Secret; never returned
+	WebhookHint    string     `json:"webhookHint" bson:"webhookHint"` // Host of the webhook, to tell integrations apart
+	Events         []string   `json:"events" bson:"events"`
+	CreatedAt      time.Time  `json:"createdAt" bson:"createdAt"`
+	LastDeliveryAt *time.Time `json:"lastDeliveryAt,omitempty" bson:"lastDeliveryAt,omitempty"`
+	LastError      string     `json:"lastError,omitempty" bson:"lastError,omitempty"`
+}
+
+// Wants reports whether the integration is subscribed to event
+func (i *Integration) Wants(event string) bool {
+	for _, e := range i.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateIntegrationRequest is the request body for connecting a webhook
+type CreateIntegrationRequest struct {
+	Provider   string   `json:"provider"`
+	Name       string   `json:"name"`
+	WebhookURL string   `json:"webhookUrl"`
+	Events     []string `json:"events"` // Defaults to all events
+}
@@ -0,0 +1,23 @@
+package model
+
+// MediaType says how a question's media is shown
+type MediaType string
+
+const (
+	MediaTypeImage MediaType = "IMAGE"
+	MediaTypeVideo MediaType = "VIDEO"
+)
+
+// Question media limits
+const (
+	MaxMediaURLChars     = 2048
+	MaxMediaAltTextChars = 300
+)
+
+// QuestionMedia is an image or video shown with a question's prompt, e.g. a
+// design mockup players react to. The URL is linked, not uploaded.
+type QuestionMedia struct {
+	Type    MediaType `json:"type" bson:"type"`
+	URL     string    `json:"url" bson:"url"`                             // http(s); YouTube links are stored in embed form
+	AltText string    `json:"altText,omitempty" bson:"altText,omitempty"` // What it shows, for screen readers and the AI evaluator
+}
@@ -0,0 +1,109 @@
+package model
+
+import "time"
+
+// Meta-survey: two standard questions about the survey experience itself, put to
+// players after their last question when the survey's settings.metaSurvey is on.
+// Answers are kept apart from survey answers: no points, analytics or AI.
+const (
+	MetaQuestionClarity = "META.clarity"
+	MetaQuestionLength  = "META.length"
+	MetaScaleMin        = 1
+	MetaScaleMax        = 5
+)
+
+// MetaQuestions returns the meta-survey questions in the order they are asked
+func MetaQuestions() []*Question {
+	return []*Question{
+		{
+			Key:      MetaQuestionClarity,
+			Type:     QuestionTypeDegree,
+			Prompt:   "How clear were the questions? (1 = very confusing, 5 = very clear)",
+			ScaleMin: MetaScaleMin,
+			ScaleMax: MetaScaleMax,
+		},
+		{
+			Key:      MetaQuestionLength,
+			Type:     QuestionTypeDegree,
+			Prompt:   "How did the length of the survey feel? (1 = far too short, 3 = about right, 5 = far too long)",
+			ScaleMin: MetaScaleMin,
+			ScaleMax: MetaScaleMax,
+		},
+	}
+}
+
+// MetaFeedbackRequest is a player's answers to the meta-survey; either may be left out
+type MetaFeedbackRequest struct {
+	Clarity *int `json:"clarity,omitempty"`
+	Length  *int `json:"length,omitempty"`
+}
+
+// MetaFeedback aggregates a room's (or several rooms') meta-survey answers
+type MetaFeedback struct {
+	Responses int            `json:"responses" bson:"responses"` // Players who answered
+	Clarity   MetaScaleStats `json:"clarity" bson:"clarity"`
+	Length    MetaScaleStats `json:"length" bson:"length"`
+}
+
+// MetaScaleStats summarizes answers on the 1-5 meta scale
+type MetaScaleStats struct {
+	Count   int     `json:"count" bson:"count"`
+	Average float64 `json:"average" bson:"average"` // 0 without answers
+	Counts  []int   `json:"counts" bson:"counts"`   // Per scale value from 1
+}
+
+// Add counts one answer; values off the scale are ignored
+func (m *MetaScaleStats) Add(value int) {
+	if value < MetaScaleMin || value > MetaScaleMax {
+		return
+	}
+	m.addCounts(func(counts []int) { counts[value-MetaScaleMin]++ })
+}
+
+// Merge adds another summary's answers
+func (m *MetaScaleStats) Merge(other MetaScaleStats) {
+	m.addCounts(func(counts []int) {
+		for i := 0; i < len(counts) && i < len(other.Counts); i++ {
+			counts[i] += other.Counts[i]
+		}
+	})
+}
+
+// addCounts applies update to the histogram and recomputes count and average from it
+func (m *MetaScaleStats) addCounts(update func(counts []int)) {
+	if len(m.Counts) != MetaScaleMax-MetaScaleMin+1 {
+		m.Counts = make([]int, MetaScaleMax-MetaScaleMin+1)
+	}
+	update(m.Counts)
+	m.Count, m.Average = 0, 0
+	sum := 0
+	for i, n := range m.Counts {
+		m.Count += n
+		sum += n * (i + MetaScaleMin)
+	}
+	if m.Count > 0 {
+		m.Average = float64(sum) / float64(m.Count)
+	}
+}
+
+// Merge adds another aggregate's answers
+func (m *MetaFeedback) Merge(other *MetaFeedback) {
+	m.Responses += other.Responses
+	m.Clarity.Merge(other.Clarity)
+	m.Length.Merge(other.Length)
+}
+
+// SurveyMetaFeedback is a survey's meta-feedback across its ended rooms, so the
+// host can see whether edits made it clearer or better paced
+type SurveyMetaFeedback struct {
+	SurveyID string             `json:"surveyId"`
+	Overall  MetaFeedback       `json:"overall"`
+	Rooms    []RoomMetaFeedback `json:"rooms"` // Oldest room end first; rooms without answers are left out
+}
+
+// RoomMetaFeedback is one ended room's meta-feedback
+type RoomMetaFeedback struct {
+	RoomCode     string       `json:"roomCode"`
+	EndedAt      time.Time    `json:"endedAt"`
+	MetaFeedback MetaFeedback `json:"metaFeedback"`
+}
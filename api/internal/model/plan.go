@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// Plan names built into the default plan configuration
+const (
+	PlanFree      = "free"
+	PlanPro       = "pro"
+	PlanUnlimited = "unlimited"
+)
+
+// Plan-limited resources, as reported in plan limit errors
+const (
+	PlanResourceSurveys = "surveys"
+	PlanResourceRooms   = "rooms"
+	PlanResourcePlayers = "players"
+	PlanResourceAICalls = "aiCalls"
+)
+
+// PlanLimits caps what a host on a plan may use; 0 means unlimited
+type PlanLimits struct {
+	MaxSurveys         int   `json:"maxSurveys"`         // Surveys outside the trash
+	MaxConcurrentRooms int   `json:"maxConcurrentRooms"` // Rooms in LOBBY or ACTIVE
+	MaxPlayersPerRoom  int   `json:"maxPlayersPerRoom"`  // Players who have not left
+	MonthlyAICalls     int64 `json:"monthlyAiCalls"`     // Gemini calls per UTC calendar month
+}
+
+// HostPlan assigns a plan to a host; hosts without one are on the default plan
+type HostPlan struct {
+	HostID    string    `json:"hostId" bson:"_id"`
+	Plan      string    `json:"plan" bson:"plan"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// HostUsage is a host's current use of their plan's limits
+type HostUsage struct {
+	HostID           string     `json:"hostId"`
+	Plan             string     `json:"plan"`
+	Limits           PlanLimits `json:"limits"`
+	Surveys          int        `json:"surveys"`
+	ConcurrentRooms  int        `json:"concurrentRooms"`
+	AICallsThisMonth int64      `json:"aiCallsThisMonth"`
+	Month            string     `json:"month"`    // YYYY-MM, UTC
+	ResetsAt         time.Time  `json:"resetsAt"` // Start of next month; the AI call count starts over
+}
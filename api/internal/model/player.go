@@ -2,6 +2,14 @@ package model
 
 import "time"
 
+// PlayerStatus is a player's standing in the room
+type PlayerStatus string
+
+const (
+	PlayerStatusActive    PlayerStatus = ""          // Playing (the zero value, for players cached before statuses existed)
+	PlayerStatusAbandoned PlayerStatus = "ABANDONED" // Left for good; remaining questions were finalized as ABANDONED
+)
+
 // Player represents a participant in a room
 type Player struct {
 	ID            string    `json:"id" bson:"_id,omitempty"`
@@ -12,6 +20,32 @@ type Player struct {
 	FollowUpsUsed int       `json:"followUpsUsed" bson:"followUpsUsed"` // Total follow-ups seen
 	LastActiveAt  time.Time `json:"lastActiveAt" bson:"lastActiveAt"`
 	JoinedAt      time.Time `json:"joinedAt" bson:"joinedAt"`
+
+	Variant string `json:"variant,omitempty" bson:"variant,omitempty"` // Experiment variant, if the room runs one
+
+	Status PlayerStatus `json:"status,omitempty" bson:"status,omitempty"`
+	LeftAt *time.Time   `json:"leftAt,omitempty" bson:"leftAt,omitempty"`
+}
+
+// HasLeft reports whether the player left the room for good
+func (p *Player) HasLeft() bool {
+	return p.Status == PlayerStatusAbandoned
+}
+
+// Reasons a player leaves a room
+const (
+	LeaveReasonLeft         = "left"         // The player asked to leave
+	LeaveReasonDisconnected = "disconnected" // Disconnected longer than the room allows
+)
+
+// LeaveResult is returned when a player leaves a room
+type LeaveResult struct {
+	PlayerID               string       `json:"playerId"`
+	Status                 PlayerStatus `json:"status"`
+	Reason                 string       `json:"reason"`
+	AbandonedQuestions     int          `json:"abandonedQuestions"` // Unanswered questions finalized as ABANDONED
+	RemovedFromLeaderboard bool         `json:"removedFromLeaderboard"`
+	LeftAt                 time.Time    `json:"leftAt"`
 }
 
 // PlayerState is the full Redis state for a player (extends Player with queue info)
@@ -21,10 +55,96 @@ type PlayerState struct {
 	ClosedParents []string `json:"closedParents"` // Base questions whose follow-ups are closed
 }
 
+// CurrentState is everything serving a player's current question reads from Redis
+type CurrentState struct {
+	Player     *Player
+	CurrentKey string        // Empty once the queue is exhausted
+	Question   *Question     // Question map entry for CurrentKey
+	Attempt    *AttemptState // Draft and evaluation state for CurrentKey
+
+	MetaQuestions []*Question // Meta-survey still to answer once the queue is exhausted
+}
+
+// Progress tells a player how far along the survey they are. Follow-ups and
+// time left are estimates from the room's answers so far.
+type Progress struct {
+	Answered             int `json:"answered"`             // Questions answered or skipped, follow-ups included
+	BaseTotal            int `json:"baseTotal"`            // Base questions in the survey
+	BaseRemaining        int `json:"baseRemaining"`        // Base questions still to answer, the current one included
+	EstimatedFollowUps   int `json:"estimatedFollowUps"`   // Follow-ups still expected, queued ones included
+	EstimatedMinutesLeft int `json:"estimatedMinutesLeft"` // Rounded up; 0 once done
+}
+
 // PlayerJoinResponse is returned when a player joins a room
 type PlayerJoinResponse struct {
 	PlayerID      string    `json:"playerId"`
 	Token         string    `json:"token"`
 	RoomMeta      *RoomMeta `json:"roomMeta"`
 	FirstQuestion *Question `json:"firstQuestion,omitempty"`
+	Branding      *Branding `json:"branding,omitempty"`
+	Practice      bool      `json:"practice,omitempty"` // Practice room: evaluations are canned, nothing counts
+}
+
+// Connection states reported in the lobby roster
+const (
+	ConnectionConnected    = "connected"
+	ConnectionIdle         = "idle"
+	ConnectionDisconnected = "disconnected"
+)
+
+// LobbyPlayer is one entry of the room roster
+type LobbyPlayer struct {
+	PlayerID     string    `json:"playerId"`
+	Nickname     string    `json:"nickname"`
+	JoinedAt     time.Time `json:"joinedAt"`
+	LastActiveAt time.Time `json:"lastActiveAt"`
+	Score        int       `json:"score"`
+	CurrentKey   string    `json:"currentKey,omitempty"`
+	Remaining    int       `json:"remaining"` // Questions left in the player's queue
+	Done         bool      `json:"done"`
+	Connection   string    `json:"connection"` // connected, idle or disconnected (as seen by this server)
+
+	Left bool `json:"left,omitempty"` // Left the room; not counted in live completion
+}
+
+// LobbyRoster lists the players currently in a room
+type LobbyRoster struct {
+	RoomCode  string        `json:"roomCode"`
+	Status    RoomStatus    `json:"status"`
+	Players   []LobbyPlayer `json:"players"`
+	Connected int           `json:"connected"`
+}
+
+// QuestionHeatmap shows the host where players are in the survey right now
+type QuestionHeatmap struct {
+	RoomCode      string            `json:"roomCode"`
+	Players       int               `json:"players"`       // Players counted (those who left are not)
+	StuckAfterSec int               `json:"stuckAfterSec"` // Time on one question before a player is stuck
+	Questions     []HeatmapQuestion `json:"questions"`     // Base questions in survey order
+	At            time.Time         `json:"at"`
+}
+
+// HeatmapQuestion counts players per state for one base question; players on
+// one of its follow-ups count as on it
+type HeatmapQuestion struct {
+	QuestionKey string `json:"questionKey"`
+	Current     int    `json:"current"` // On it now, stuck included
+	Stuck       int    `json:"stuck"`
+	Done        int    `json:"done"`
+	Skipped     int    `json:"skipped"`
+}
+
+// PlayerStanding is a player's own position on the room leaderboard
+type PlayerStanding struct {
+	PlayerID     string  `json:"playerId"`
+	Nickname     string  `json:"nickname"`
+	Rank         int     `json:"rank"` // 1 = leader
+	Score        int     `json:"score"`
+	TotalPlayers int     `json:"totalPlayers"`
+	Percentile   float64 `json:"percentile"` // Share of the other players ranked below, 0-100
+	Tied         bool    `json:"tied"`       // Level on points with a neighbour; ranked by completion time, then join order
+
+	// The player directly ahead; absent for the leader
+	AheadNickname string `json:"aheadNickname,omitempty"`
+	PointsToNext  int    `json:"pointsToNext"` // Points needed to draw level with them
 }
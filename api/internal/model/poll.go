@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// Live poll limits
+const (
+	PollDuration       = 30 * time.Second // Voting window after launch
+	MaxPollOptions     = 10
+	MaxPollPromptChars = 300
+)
+
+// Poll is an ephemeral MCQ or DEGREE question the host puts to the whole room
+// outside the survey flow. Votes are kept apart from survey answers.
+type Poll struct {
+	ID        string       `json:"id" bson:"_id"`
+	RoomCode  string       `json:"roomCode" bson:"roomCode"`
+	Type      QuestionType `json:"type" bson:"type"` // MCQ or DEGREE
+	Prompt    string       `json:"prompt" bson:"prompt"`
+	Options   []string     `json:"options,omitempty" bson:"options,omitempty"`   // MCQ only
+	ScaleMin  int          `json:"scaleMin,omitempty" bson:"scaleMin,omitempty"` // DEGREE only
+	ScaleMax  int          `json:"scaleMax,omitempty" bson:"scaleMax,omitempty"` // DEGREE only
+	StartedAt time.Time    `json:"startedAt" bson:"startedAt"`
+	ClosesAt  time.Time    `json:"closesAt" bson:"closesAt"`
+
+	ClosedAt *time.Time   `json:"closedAt,omitempty" bson:"closedAt,omitempty"`
+	Results  *PollResults `json:"results,omitempty" bson:"results,omitempty"` // Set once closed
+}
+
+// Choices returns how many distinct values a vote can take
+func (p *Poll) Choices() int {
+	if p.Type == QuestionTypeDegree {
+		return p.ScaleMax - p.ScaleMin + 1
+	}
+	return len(p.Options)
+}
+
+// PollResults tallies a poll's votes
+type PollResults struct {
+	Votes   int      `json:"votes" bson:"votes"`
+	Counts  []int    `json:"counts" bson:"counts"`                       // Per option, or per scale value from scaleMin
+	Average *float64 `json:"average,omitempty" bson:"average,omitempty"` // DEGREE only, nil without votes
+	Players int      `json:"players" bson:"players"`                     // Players in the room when the poll closed
+}
+
+// LaunchPollRequest is the host's request to start a poll
+type LaunchPollRequest struct {
+	Type     QuestionType `json:"type"`
+	Prompt   string       `json:"prompt"`
+	Options  []string     `json:"options,omitempty"`
+	ScaleMin int          `json:"scaleMin,omitempty"`
+	ScaleMax int          `json:"scaleMax,omitempty"`
+}
+
+// PollVoteRequest is a player's vote: the option index (MCQ) or scale value (DEGREE)
+type PollVoteRequest struct {
+	OptionIndex *int `json:"optionIndex,omitempty"`
+	DegreeValue *int `json:"degreeValue,omitempty"`
+}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// PlayerDataExport is everything stored about one player in a room (subject access request)
+type PlayerDataExport struct {
+	RoomCode    string                   `json:"roomCode"`
+	PlayerID    string                   `json:"playerId"`
+	Player      *Player                  `json:"player,omitempty"`
+	Answers     []*Answer                `json:"answers"`
+	Drafts      map[string]*AttemptState `json:"drafts,omitempty"` // In-progress attempts keyed by question
+	Profile     *PlayerProfile           `json:"profile,omitempty"`
+	Leaderboard *LeaderboardEntry        `json:"leaderboard,omitempty"`
+	Feedback    *PlayerFeedback          `json:"feedback,omitempty"`    // End-of-room summary
+	VoiceClips  []*VoiceClip             `json:"voiceClips,omitempty"`  // Recorded answers and transcripts; the audio is served by the voice endpoint
+	Attachments []*Attachment            `json:"attachments,omitempty"` // Upload metadata; the files are served by the attachment endpoint
+	ExportedAt  time.Time                `json:"exportedAt"`
+}
+
+// PlayerDataDeletion summarizes what a deletion request removed
+type PlayerDataDeletion struct {
+	RoomCode           string    `json:"roomCode"`
+	PlayerID           string    `json:"playerId"`
+	AnswersDeleted     int64     `json:"answersDeleted"`
+	CacheKeysDeleted   int       `json:"cacheKeysDeleted"`
+	ProfileDeleted     bool      `json:"profileDeleted"`
+	SnapshotScrubbed   bool      `json:"snapshotScrubbed"`
+	FeedbackDeleted    bool      `json:"feedbackDeleted"`
+	VoiceClipsDeleted  int64     `json:"voiceClipsDeleted"`  // Audio files included
+	AttachmentsDeleted int64     `json:"attachmentsDeleted"` // Stored files included
+	Notes              []string  `json:"notes,omitempty"`
+	DeletedAt          time.Time `json:"deletedAt"`
+}
@@ -7,6 +7,10 @@ const (
 	QuestionTypeEssay  QuestionType = "ESSAY"  // Free text, AI-evaluated, can gate
 	QuestionTypeDegree QuestionType = "DEGREE" // Rating/slider, never gates
 	QuestionTypeMCQ    QuestionType = "MCQ"    // Multiple choice, Kahoot-style, never gates
+	QuestionTypeMatrix QuestionType = "MATRIX" // Grid: each row picks one column, never gates
+
+	QuestionTypeRanking QuestionType = "RANKING" // Order all options best-first, never gates
+	QuestionTypeWords   QuestionType = "WORDS"   // A few words, aggregated into a word cloud, never gates
 )
 
 // Question is a runtime question instance (base or follow-up)
@@ -20,7 +24,60 @@ type Question struct {
 	Threshold float64      `json:"threshold,omitempty"` // ESSAY: satisfactory threshold
 	ScaleMin  int          `json:"scaleMin,omitempty"`  // DEGREE only
 	ScaleMax  int          `json:"scaleMax,omitempty"`  // DEGREE only
-	Options   []string     `json:"options,omitempty"`   // MCQ only
+	Options   []string     `json:"options,omitempty"`   // MCQ and RANKING
+	Rows      []string     `json:"rows,omitempty"`      // MATRIX only
+	Columns   []string     `json:"columns,omitempty"`   // MATRIX only
+
+	Media *QuestionMedia `json:"media,omitempty"` // Shown with the prompt; inherited by follow-ups
+
+	AllowAttachments bool `json:"allowAttachments,omitempty"` // Player may upload images with the answer
+	AllowVoice       bool `json:"allowVoice,omitempty"`       // ESSAY: player may record the answer instead
+
+	MinLength int `json:"minLength,omitempty"` // ESSAY: fewest characters accepted
+	MaxLength int `json:"maxLength,omitempty"` // ESSAY: most characters accepted (0 = MaxAnswerChars)
+
+	AI *QuestionAISettings `json:"ai,omitempty"` // Inherited by follow-ups
+
+	// AI follow-ups only: where the question came from and the evaluation hint it answers,
+	// so player relevance ratings can be aggregated
+	Source string `json:"source,omitempty"` // FollowUpSourcePool or FollowUpSourceGenerated
+	Hint   string `json:"hint,omitempty"`   // clarify, deepen, ...
+
+	Strictness Strictness     `json:"-"` // The room's preset, applied when the answer is evaluated
+	Taxonomy   *ThemeTaxonomy `json:"-"` // The survey's theme taxonomy, applied when the answer is evaluated
+}
+
+// Follow-up sources
+const (
+	FollowUpSourcePool      = "pool"      // Pre-generated follow-up pool
+	FollowUpSourceGenerated = "generated" // Written on demand for the player's answer
+)
+
+// Text answer size limits
+const (
+	MaxAnswerChars     = 5000     // Hard cap on a text answer in characters; questions may only lower it
+	MaxAnswerBodyBytes = 64 << 10 // Request body limit for answer and draft submissions
+)
+
+// AnswerLimit returns the most characters a text answer to q may have
+func (q *Question) AnswerLimit() int {
+	if q.MaxLength > 0 && q.MaxLength < MaxAnswerChars {
+		return q.MaxLength
+	}
+	return MaxAnswerChars
+}
+
+// Model tiers a question can request for its AI calls
+const (
+	ModelTierFast    = "fast"    // Default task models
+	ModelTierQuality = "quality" // Slower, smarter model for critical questions
+)
+
+// QuestionAISettings overrides AI behavior for one question; nil fields use the defaults
+type QuestionAISettings struct {
+	ModelTier    string   `json:"modelTier,omitempty" bson:"modelTier,omitempty"`
+	Temperature  *float64 `json:"temperature,omitempty" bson:"temperature,omitempty"`
+	MaxFollowUps *int     `json:"maxFollowUps,omitempty" bson:"maxFollowUps,omitempty"`
 }
 
 // FollowUpMode describes the type of follow-up
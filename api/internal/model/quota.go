@@ -0,0 +1,28 @@
+package model
+
+// OptionQuota caps how many respondents may pick one MCQ option.
+// Players who pick a full option are screened out of the survey.
+type OptionQuota struct {
+	Option int `json:"option" bson:"option"` // Option index
+	Limit  int `json:"limit" bson:"limit"`   // Respondents accepted for this option
+}
+
+// QuotaStatus is the fill level of one option quota
+type QuotaStatus struct {
+	QuestionKey string `json:"questionKey"`
+	Option      int    `json:"option"`
+	Label       string `json:"label"`
+	Limit       int    `json:"limit"`
+	Count       int    `json:"count"`
+	Full        bool   `json:"full"`
+}
+
+// RoomQuotas is the quota dashboard of a room
+type RoomQuotas struct {
+	RoomCode    string        `json:"roomCode"`
+	Quotas      []QuotaStatus `json:"quotas"`
+	ScreenedOut int           `json:"screenedOut"` // Players ended by a full quota
+}
+
+// ScreenedOutMessage is shown to players whose answer hit a full quota
+const ScreenedOutMessage = "Thanks for your interest! We already have enough responses from people like you, so your survey ends here."
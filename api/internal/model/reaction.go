@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Reaction limits
+const (
+	ReactionWindow        = 10 * time.Second // Reactions are counted and pulsed to the host per window
+	MaxReactionsPerWindow = 5                // Per player; further taps in the window are dropped
+)
+
+// ReactionEmojis are the reactions players can send, in display order
+var ReactionEmojis = []string{"👍", "❤️", "😂", "😮", "🤔", "👏"}
+
+// IsReactionEmoji reports whether e is one of ReactionEmojis
+func IsReactionEmoji(e string) bool {
+	for _, r := range ReactionEmojis {
+		if r == e {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package model
+
+// Replay modes for a question
+const (
+	ReplayModeAI      = "ai"      // Rubric, prompt or AI settings changed: answers are re-evaluated
+	ReplayModeSignals = "signals" // Only threshold/points changed: stored quality scores are re-scored
+)
+
+// ReplayRequest is the request body for replaying a finished room against edited questions
+type ReplayRequest struct {
+	SurveyID    string                        `json:"surveyId,omitempty"`    // Survey to replay against; defaults to the room's survey as it is now
+	Questions   map[string]ReplayQuestionEdit `json:"questions,omitempty"`   // Edits on top of that survey, by question key
+	MaxAICalls  int                           `json:"maxAiCalls,omitempty"`  // Budget for re-evaluation calls (0 = default)
+	ForceAI     bool                          `json:"forceAi,omitempty"`     // Re-evaluate even when only thresholds changed
+	OnlyChanges *bool                         `json:"onlyChanges,omitempty"` // List only answers whose result changed (default true)
+}
+
+// ReplayQuestionEdit overrides parts of one question definition for a replay
+type ReplayQuestionEdit struct {
+	Prompt    *string             `json:"prompt,omitempty"`
+	Rubric    *string             `json:"rubric,omitempty"`
+	Threshold *float64            `json:"threshold,omitempty"`
+	PointsMax *int                `json:"pointsMax,omitempty"`
+	AI        *QuestionAISettings `json:"ai,omitempty"`
+}
+
+// ReplayReport is the diff between the stored evaluations of a room and a replay
+type ReplayReport struct {
+	RoomCode   string             `json:"roomCode"`
+	SurveyID   string             `json:"surveyId"`
+	Mock       bool               `json:"mock"` // true when the AI is not configured
+	AICalls    int                `json:"aiCalls"`
+	MaxAICalls int                `json:"maxAiCalls"`
+	Questions  []QuestionReplay   `json:"questions"`
+	Players    []PlayerReplayDiff `json:"players"` // Players whose points change
+	Answers    []AnswerReplayDiff `json:"answers"`
+	Truncated  bool               `json:"truncated,omitempty"` // Answers list was capped
+}
+
+// QuestionReplay summarizes the replay of one question
+type QuestionReplay struct {
+	QuestionKey string  `json:"questionKey"`
+	Mode        string  `json:"mode"` // ai, signals
+	Rubric      string  `json:"rubric"`
+	Threshold   float64 `json:"threshold"`
+	PointsMax   int     `json:"pointsMax"`
+
+	Answers     int `json:"answers"`     // Evaluated answers in the room
+	Replayed    int `json:"replayed"`    // Answers with a replay result
+	NotReplayed int `json:"notReplayed"` // Left out because the AI budget ran out or a call failed
+	UnsatToSat  int `json:"unsatToSat"`
+	SatToUnsat  int `json:"satToUnsat"`
+
+	PointsBefore      int     `json:"pointsBefore"`
+	PointsAfter       int     `json:"pointsAfter"`
+	MeanQualityBefore float64 `json:"meanQualityBefore"`
+	MeanQualityAfter  float64 `json:"meanQualityAfter"`
+}
+
+// PlayerReplayDiff is a player's points over the replayed answers, before and after
+type PlayerReplayDiff struct {
+	PlayerID     string `json:"playerId"`
+	PointsBefore int    `json:"pointsBefore"`
+	PointsAfter  int    `json:"pointsAfter"`
+}
+
+// AnswerReplayDiff compares one answer's stored AI evaluation with its replay
+type AnswerReplayDiff struct {
+	AnswerID    string       `json:"answerId"`
+	PlayerID    string       `json:"playerId"`
+	QuestionKey string       `json:"questionKey"`
+	TextAnswer  string       `json:"textAnswer"`
+	Overridden  bool         `json:"overridden,omitempty"` // Before is the AI judgment the host later overrode
+	Before      ReplayResult `json:"before"`
+	After       ReplayResult `json:"after"`
+}
+
+// ReplayResult is one evaluation outcome
+type ReplayResult struct {
+	Resolution   AnswerResolution `json:"resolution"`
+	QualityScore float64          `json:"qualityScore"`
+	Points       int              `json:"points"`
+	Summary      string           `json:"summary,omitempty"`
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// RetentionReport is the outcome of a retention purge (or a dry run of one)
+type RetentionReport struct {
+	DryRun bool            `json:"dryRun"`
+	RanAt  time.Time       `json:"ranAt"`
+	Items  []RetentionItem `json:"items"`
+}
+
+// RetentionItem covers one collection under a retention policy
+type RetentionItem struct {
+	Collection    string    `json:"collection"`
+	DateField     string    `json:"dateField"`
+	RetentionDays int       `json:"retentionDays"`
+	Cutoff        time.Time `json:"cutoff"`
+	Expired       int64     `json:"expired"` // Documents older than the cutoff
+	Deleted       int64     `json:"deleted"`
+	TTLIndex      bool      `json:"ttlIndex"` // MongoDB also expires these documents itself
+	Error         string    `json:"error,omitempty"`
+
+	FilesDeleted int64 `json:"filesDeleted,omitempty"` // Stored files (e.g. voice audio) removed with the documents
+}
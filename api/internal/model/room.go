@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"math"
+	"time"
+)
 
 // RoomStatus represents the lifecycle of a room
 type RoomStatus string
@@ -16,6 +20,147 @@ type RoomSettings struct {
 	SatisfactoryThreshold *float64 `json:"satisfactoryThreshold,omitempty" bson:"satisfactoryThreshold,omitempty"`
 	MaxFollowUps          *int     `json:"maxFollowUps,omitempty" bson:"maxFollowUps,omitempty"`
 	AllowSkipAfter        *int     `json:"allowSkipAfter,omitempty" bson:"allowSkipAfter,omitempty"`
+
+	// Skip gating: overrides of the survey's minimum seconds on a question before skipping,
+	// or no gating at all (e.g. demos)
+	SkipAfterSeconds     *int `json:"skipAfterSeconds,omitempty" bson:"skipAfterSeconds,omitempty"`
+	AllowSkipImmediately bool `json:"allowSkipImmediately,omitempty" bson:"allowSkipImmediately,omitempty"`
+
+	// Leaving: players disconnected this long from a running room are treated as having left
+	// (nil = DefaultAbandonAfterMinutes, 0 = never); leavers can also be dropped from the leaderboard
+	AbandonAfterMinutes          *int `json:"abandonAfterMinutes,omitempty" bson:"abandonAfterMinutes,omitempty"`
+	RemoveLeaversFromLeaderboard bool `json:"removeLeaversFromLeaderboard,omitempty" bson:"removeLeaversFromLeaderboard,omitempty"`
+
+	// How hard ESSAY answers are graded and probed (empty = standard)
+	Strictness Strictness `json:"strictness,omitempty" bson:"strictness,omitempty"`
+
+	// Submissions a player gets per ESSAY question before an UNSAT answer is final
+	// (nil = DefaultMaxTries, 1 = no retries)
+	MaxTries *int `json:"maxTries,omitempty" bson:"maxTries,omitempty"`
+
+	// Practice rooms (demos, onboarding) are always evaluated by the mock evaluator and
+	// are left out of the host's analytics history, experiments and integrations
+	Practice bool `json:"practice,omitempty" bson:"practice,omitempty"`
+}
+
+// Strictness presets the rigor of ESSAY evaluation for a room
+type Strictness string
+
+const (
+	StrictnessLenient  Strictness = "lenient"  // Ice-breakers: lower SAT bar, generous scale, at most one gentle follow-up
+	StrictnessStandard Strictness = "standard" // Survey thresholds and grading as written
+	StrictnessStrict   Strictness = "strict"   // Research: higher SAT bar, strict scale, probe for concrete detail
+)
+
+// strictnessThresholdShift is how far lenient and strict move a question's SAT threshold
+const strictnessThresholdShift = 0.15
+
+// Valid reports whether s is a known preset; empty means standard
+func (s Strictness) Valid() bool {
+	switch s {
+	case "", StrictnessLenient, StrictnessStandard, StrictnessStrict:
+		return true
+	}
+	return false
+}
+
+// Threshold shifts a question's SAT threshold for the preset, keeping it within 0.05-0.95;
+// unset thresholds stay unset
+func (s Strictness) Threshold(t float64) float64 {
+	if t <= 0 {
+		return t
+	}
+	switch s {
+	case StrictnessLenient:
+		t -= strictnessThresholdShift
+	case StrictnessStrict:
+		t += strictnessThresholdShift
+	default:
+		return t
+	}
+	return math.Min(0.95, math.Max(0.05, t))
+}
+
+// FollowUpStyle is the follow-up prompt style the preset implies
+func (s Strictness) FollowUpStyle() FollowUpStyle {
+	switch s {
+	case StrictnessLenient:
+		return FollowUpStyleGentle
+	case StrictnessStrict:
+		return FollowUpStyleAggressive
+	}
+	return FollowUpStyleDefault
+}
+
+// MaxFollowUps caps follow-ups per base question for the preset; nil leaves the question's cap
+func (s Strictness) MaxFollowUps() *int {
+	if s == StrictnessLenient {
+		one := 1
+		return &one
+	}
+	return nil
+}
+
+// StrictnessPreset returns the room's strictness, standard when unset
+func (s RoomSettings) StrictnessPreset() Strictness {
+	if s.Strictness == "" {
+		return StrictnessStandard
+	}
+	return s.Strictness
+}
+
+// Retry limits for UNSAT ESSAY answers
+const (
+	DefaultMaxTries = 3
+	MaxMaxTries     = 10
+)
+
+// EssayMaxTries returns how many submissions a player gets per ESSAY question
+func (s RoomSettings) EssayMaxTries() int {
+	if s.MaxTries != nil && *s.MaxTries > 0 {
+		return *s.MaxTries
+	}
+	return DefaultMaxTries
+}
+
+// MaxSkipAfterSeconds caps the dwell time skip gating can require
+const MaxSkipAfterSeconds = 600
+
+// SkipGate is what a player must do on a question before it can be skipped
+type SkipGate struct {
+	MinTries   int // Submissions of ESSAY questions, the only ones that take retries
+	MinSeconds int // Time since the question was first shown
+}
+
+// SkipGate applies the room's overrides to the survey's skip settings
+func (s RoomSettings) SkipGate(survey SurveySettings) SkipGate {
+	if s.AllowSkipImmediately {
+		return SkipGate{}
+	}
+	gate := SkipGate{MinTries: survey.AllowSkipAfter, MinSeconds: survey.SkipAfterSeconds}
+	if s.AllowSkipAfter != nil {
+		gate.MinTries = *s.AllowSkipAfter
+	}
+	if s.SkipAfterSeconds != nil {
+		gate.MinSeconds = *s.SkipAfterSeconds
+	}
+	return gate
+}
+
+// DefaultAbandonAfterMinutes is how long a player may stay disconnected from a running room
+const DefaultAbandonAfterMinutes = 15
+
+// AbandonAfter returns how long a disconnected player is kept before being treated as
+// having left; 0 disables automatic leaving
+func (s RoomSettings) AbandonAfter() time.Duration {
+	minutes := DefaultAbandonAfterMinutes
+	if s.AbandonAfterMinutes != nil {
+		minutes = *s.AbandonAfterMinutes
+	}
+	if minutes <= 0 {
+		return 0
+	}
+	return time.Duration(minutes) * time.Minute
 }
 
 // Room is a live session created from a survey (ephemeral in Redis, persisted in Mongo for history)
@@ -24,19 +169,160 @@ type Room struct {
 	SurveyID     string       `json:"surveyId" bson:"surveyId"`
 	HostID       string       `json:"hostId" bson:"hostId"`
 	Status       RoomStatus   `json:"status" bson:"status"`
-	Settings     RoomSettings `json:"settings" bson:"settings"`         // Overrides from survey
-	ScopeSummary string       `json:"scopeSummary" bson:"scopeSummary"` // Short AI-generated scope
+	Settings     RoomSettings `json:"settings" bson:"settings"`                       // Overrides from survey
+	HostNotes    string       `json:"hostNotes,omitempty" bson:"hostNotes,omitempty"` // Extra context from the host
+	ScopeSummary string       `json:"scopeSummary" bson:"scopeSummary"`               // Short AI-generated scope
+	ScopeAnchor  *ScopeAnchor `json:"scopeAnchor,omitempty" bson:"scopeAnchor,omitempty"`
 	CreatedAt    time.Time    `json:"createdAt" bson:"createdAt"`
 	StartedAt    *time.Time   `json:"startedAt,omitempty" bson:"startedAt,omitempty"`
 	EndedAt      *time.Time   `json:"endedAt,omitempty" bson:"endedAt,omitempty"`
+
+	Experiment *RoomExperiment `json:"experiment,omitempty" bson:"experiment,omitempty"` // A/B test the room takes part in
+
+	Branding *Branding `json:"branding,omitempty" bson:"branding,omitempty"` // Overrides the survey's branding
+}
+
+// Branding limits
+const (
+	MaxBrandingTitleChars   = 80
+	MaxBrandingMessageChars = 500
+	MaxBrandingURLChars     = 2048
+)
+
+// Branding skins the player UI for white-label deployments; empty fields use the defaults.
+// A survey carries its default branding and a room may override it field by field.
+type Branding struct {
+	Title           string `json:"title,omitempty" bson:"title,omitempty"`
+	LogoURL         string `json:"logoUrl,omitempty" bson:"logoUrl,omitempty"`                 // https only
+	PrimaryColor    string `json:"primaryColor,omitempty" bson:"primaryColor,omitempty"`       // #RRGGBB
+	AccentColor     string `json:"accentColor,omitempty" bson:"accentColor,omitempty"`         // #RRGGBB
+	BackgroundColor string `json:"backgroundColor,omitempty" bson:"backgroundColor,omitempty"` // #RRGGBB
+	TextColor       string `json:"textColor,omitempty" bson:"textColor,omitempty"`             // #RRGGBB
+	WelcomeMessage  string `json:"welcomeMessage,omitempty" bson:"welcomeMessage,omitempty"`
+	ThankYouMessage string `json:"thankYouMessage,omitempty" bson:"thankYouMessage,omitempty"` // Shown when the player finishes
+}
+
+// Merge returns b with every field override sets replaced; nil when both are nil
+func (b *Branding) Merge(override *Branding) *Branding {
+	if b == nil && override == nil {
+		return nil
+	}
+	out := Branding{}
+	if b != nil {
+		out = *b
+	}
+	if override == nil {
+		return &out
+	}
+	out.Title = orDefault(override.Title, out.Title)
+	out.LogoURL = orDefault(override.LogoURL, out.LogoURL)
+	out.PrimaryColor = orDefault(override.PrimaryColor, out.PrimaryColor)
+	out.AccentColor = orDefault(override.AccentColor, out.AccentColor)
+	out.BackgroundColor = orDefault(override.BackgroundColor, out.BackgroundColor)
+	out.TextColor = orDefault(override.TextColor, out.TextColor)
+	out.WelcomeMessage = orDefault(override.WelcomeMessage, out.WelcomeMessage)
+	out.ThankYouMessage = orDefault(override.ThankYouMessage, out.ThankYouMessage)
+	return &out
 }
 
 // RoomMeta is the Redis-stored room metadata
 type RoomMeta struct {
-	SurveyID     string     `json:"surveyId"`
-	HostID       string     `json:"hostId"`
-	Status       RoomStatus `json:"status"`
-	CreatedAt    time.Time  `json:"createdAt"`
-	SettingsJSON string     `json:"settingsJson"`
-	ScopeSummary string     `json:"scopeSummary"`
+	SurveyID     string       `json:"surveyId"`
+	HostID       string       `json:"hostId"`
+	Status       RoomStatus   `json:"status"`
+	CreatedAt    time.Time    `json:"createdAt"`
+	SettingsJSON string       `json:"settingsJson"`
+	ScopeSummary string       `json:"scopeSummary"`
+	ScopeAnchor  *ScopeAnchor `json:"scopeAnchor,omitempty"`
+
+	// Survey data copied at room creation so the answer path skips Mongo
+	SurveyIntent string             `json:"surveyIntent,omitempty"`
+	Questions    []RoomQuestionMeta `json:"questions,omitempty"`
+	Branching    []BranchRule       `json:"branching,omitempty"`
+	Guardrails   *Guardrails        `json:"guardrails,omitempty"`
+	Taxonomy     *ThemeTaxonomy     `json:"themeTaxonomy,omitempty"`
+	SkipSettings SurveySettings     `json:"skipSettings"` // Only AllowSkipAfter and SkipAfterSeconds are copied
+	MetaSurvey   bool               `json:"metaSurvey,omitempty"`
+
+	Experiment *RoomExperiment `json:"experiment,omitempty"`
+
+	Branding *Branding `json:"branding,omitempty"` // Survey branding with the room's overrides applied
+}
+
+// RoomQuestionMeta is the per-question survey data the answer path needs
+type RoomQuestionMeta struct {
+	Key       string       `json:"key"`
+	Type      QuestionType `json:"type"`
+	PointsMax int          `json:"pointsMax"`
+}
+
+// SetSurvey denormalizes the survey's intent, questions and branching into the meta
+func (m *RoomMeta) SetSurvey(survey *Survey) {
+	m.SurveyIntent = survey.Intent
+	m.Branching = survey.Branching
+	m.Guardrails = survey.Guardrails
+	m.Taxonomy = survey.ThemeTaxonomy
+	m.MetaSurvey = survey.Settings.MetaSurvey
+	m.SkipSettings = SurveySettings{
+		AllowSkipAfter:   survey.Settings.AllowSkipAfter,
+		SkipAfterSeconds: survey.Settings.SkipAfterSeconds,
+	}
+	m.Questions = make([]RoomQuestionMeta, 0, len(survey.Questions))
+	for _, q := range survey.Questions {
+		m.Questions = append(m.Questions, RoomQuestionMeta{Key: q.Key, Type: q.Type, PointsMax: q.PointsMax})
+	}
+}
+
+// Settings decodes the room settings stored with the meta; unreadable settings are empty
+func (m *RoomMeta) Settings() RoomSettings {
+	var settings RoomSettings
+	if m.SettingsJSON != "" {
+		_ = json.Unmarshal([]byte(m.SettingsJSON), &settings)
+	}
+	return settings
+}
+
+// HasSurvey reports whether SetSurvey ran; rooms cached before it was added lack the copy
+func (m *RoomMeta) HasSurvey() bool {
+	return m.Questions != nil
+}
+
+// Question returns the denormalized metadata for key, or nil
+func (m *RoomMeta) Question(key string) *RoomQuestionMeta {
+	for i := range m.Questions {
+		if m.Questions[i].Key == key {
+			return &m.Questions[i]
+		}
+	}
+	return nil
+}
+
+// ScopeAnchor bounds what AI follow-ups may ask about in a room
+type ScopeAnchor struct {
+	Summary    string   `json:"summary" bson:"summary"`       // 1-2 sentence scope
+	InScope    []string `json:"inScope" bson:"inScope"`       // Topics follow-ups may explore
+	OutOfScope []string `json:"outOfScope" bson:"outOfScope"` // Topics follow-ups must avoid
+}
+
+// ScopeCheck is the AI verdict on whether a generated follow-up stays in scope
+type ScopeCheck struct {
+	InScope bool   `json:"inScope"`
+	Reason  string `json:"reason"`
+}
+
+// JoinInfo is what a host projects so players can join: links and a QR code
+type JoinInfo struct {
+	RoomCode string `json:"roomCode"`
+	JoinURL  string `json:"joinUrl"`
+	ShortURL string `json:"shortUrl"` // Encoded in the QR code
+	QRSVG    string `json:"qrSvg"`
+	QRPNG    string `json:"qrPng"` // data:image/png;base64 URL
+}
+
+// orDefault returns value unless it is empty
+func orDefault(value, fallback string) string {
+	if value != "" {
+		return value
+	}
+	return fallback
 }
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Audiences of read-only room tokens
+const (
+	ShareAudience = "share" // Shared results of an ended room
+	EmbedAudience = "embed" // Live results widget
+)
+
+// ShareClaims are JWT claims of a results share link or embed token
+type ShareClaims struct {
+	RoomCode string `json:"roomCode"`
+	HostID   string `json:"hostId"` // Who created the link, for auditing
+	jwt.RegisteredClaims
+}
+
+// ShareRequest is the request body for creating a share link
+type ShareRequest struct {
+	ExpiresInHours int `json:"expiresInHours"` // Defaults to 7 days
+}
+
+// ShareLink is a signed, expiring link to a room's results
+type ShareLink struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// SharedReport is the sanitized, read-only view of a room's results.
+// It carries no player identities.
+type SharedReport struct {
+	RoomCode         string            `json:"roomCode"`
+	SurveyTitle      string            `json:"surveyTitle,omitempty"`
+	EndedAt          time.Time         `json:"endedAt"`
+	TotalPlayers     int               `json:"totalPlayers"`
+	CompletionRate   float64           `json:"completionRate"`
+	OverallSkipRate  float64           `json:"overallSkipRate"`
+	TopScores        []int             `json:"topScores"` // Leaderboard scores by rank, without names
+	QuestionProfiles []QuestionProfile `json:"questionProfiles"`
+	Memory           RoomMemory        `json:"memory"`
+	AIReport         *AIReport         `json:"aiReport,omitempty"` // Only once ready
+	ExpiresAt        time.Time         `json:"expiresAt"`
+}
@@ -2,15 +2,16 @@ package model
 
 // Signals contains AI-extracted structured data from an answer
 type Signals struct {
-	Themes             []string `json:"themes,omitempty"`       // Key themes mentioned
-	Missing            []string `json:"missing,omitempty"`      // Missing details
-	Specificity        float64  `json:"specificity"`            // 0-1
-	Clarity            float64  `json:"clarity"`                // 0-1
-	Sentiment          float64  `json:"sentiment"`              // -1 to 1
-	ConfidenceLanguage float64  `json:"confidence_language"`    // 0-1
-	Summary            string   `json:"summary,omitempty"`      // 1 sentence
-	ClusterHint        string   `json:"cluster_hint,omitempty"` // Optional grouping hint
-	RiskFlags          []string `json:"risk_flags,omitempty"`   // toxicity, spam, irrelevant
+	Themes             []string `json:"themes,omitempty"`          // Key themes mentioned; canonical names under a theme taxonomy
+	EmergentThemes     []string `json:"emergent_themes,omitempty"` // Themes outside the taxonomy, counted as "other"
+	Missing            []string `json:"missing,omitempty"`         // Missing details
+	Specificity        float64  `json:"specificity"`               // 0-1
+	Clarity            float64  `json:"clarity"`                   // 0-1
+	Sentiment          float64  `json:"sentiment"`                 // -1 to 1
+	ConfidenceLanguage float64  `json:"confidence_language"`       // 0-1
+	Summary            string   `json:"summary,omitempty"`         // 1 sentence
+	ClusterHint        string   `json:"cluster_hint,omitempty"`    // Optional grouping hint
+	RiskFlags          []string `json:"risk_flags,omitempty"`      // toxicity, spam, irrelevant
 }
 
 // EvaluationResult is the AI response for answer evaluation
@@ -20,6 +21,8 @@ type EvaluationResult struct {
 	Signals      Signals `json:"signals"`
 	FollowUpHint string  `json:"followup_hint,omitempty"`  // Suggestion for follow-up type
 	NotesForHost string  `json:"notes_for_host,omitempty"` // Private notes
+	// Fallback marks an offline result used in place of the model's (never cached)
+	Fallback bool `json:"-" bson:"-"`
 }
 
 // FollowUpGeneration is the AI response for follow-up generation
@@ -39,3 +42,34 @@ type GeneratedFollowUp struct {
 	Options       []string     `json:"options,omitempty"` // For MCQ type
 	ReasonInScope string       `json:"reason_in_scope"`   // Why this is relevant to survey intent
 }
+
+// TestEvalRequest is a rubric sandbox run: sample answers plus optional
+// unsaved overrides of the question's rubric, threshold and AI settings
+type TestEvalRequest struct {
+	Answers   []string            `json:"answers"`
+	Rubric    *string             `json:"rubric,omitempty"`
+	Threshold *float64            `json:"threshold,omitempty"`
+	AI        *QuestionAISettings `json:"ai,omitempty"`
+}
+
+// TestEvalResult is the evaluation of one sample answer
+type TestEvalResult struct {
+	Answer       string   `json:"answer"`
+	Resolution   string   `json:"resolution"`
+	QualityScore float64  `json:"qualityScore"`
+	PointsEarned int      `json:"pointsEarned"`
+	Themes       []string `json:"themes,omitempty"`
+	Missing      []string `json:"missing,omitempty"`
+	Summary      string   `json:"summary,omitempty"`
+	FollowUpHint string   `json:"followupHint,omitempty"`
+	RiskFlags    []string `json:"riskFlags,omitempty"`
+}
+
+// TestEvalResponse is the rubric sandbox result
+type TestEvalResponse struct {
+	QuestionKey string           `json:"questionKey"`
+	Rubric      string           `json:"rubric"`
+	Threshold   float64          `json:"threshold"`
+	Mock        bool             `json:"mock"` // true when the AI is not configured
+	Results     []TestEvalResult `json:"results"`
+}
@@ -70,8 +70,19 @@ type SMCollector struct {
 	Type        string             `json:"type" bson:"type"`
 	WebLinkURL  string             `json:"webLinkUrl" bson:"weblink_url"`
 	CreatedAt   time.Time          `json:"createdAt" bson:"created_at"`
+
+	// Refreshed from SurveyMonkey whenever the collectors are listed or changed
+	Status        string     `json:"status,omitempty" bson:"status,omitempty"` // open | closed | new
+	ResponseCount int        `json:"responseCount" bson:"response_count"`
+	SyncedAt      *time.Time `json:"syncedAt,omitempty" bson:"synced_at,omitempty"`
 }
 
+// SurveyMonkey collector statuses a host can set
+const (
+	SMCollectorOpen   = "open"
+	SMCollectorClosed = "closed"
+)
+
 // SMQuestionMapping maps SurveyMonkey question IDs to internal keys
 type SMQuestionMapping struct {
 	SurveyID    string `json:"surveyId" bson:"survey_id"`
@@ -91,10 +102,75 @@ type SMChoiceMapping struct {
 
 // SMSyncResult is returned after sync operation
 type SMSyncResult struct {
-	Fetched         int `json:"fetched"`
-	InsertedRaw     int `json:"insertedRaw"`
-	ParsedAnswers   int `json:"parsedAnswers"`
-	UpdatedFeatures int `json:"updatedFeatures"`
+	Fetched         int `json:"fetched" bson:"fetched"`
+	InsertedRaw     int `json:"insertedRaw" bson:"inserted_raw"`
+	ParsedAnswers   int `json:"parsedAnswers" bson:"parsed_answers"`
+	UpdatedFeatures int `json:"updatedFeatures" bson:"updated_features"`
+}
+
+// SMSyncDryRun reports what a sync would change without writing anything
+type SMSyncDryRun struct {
+	SurveyID            string             `json:"surveyId"`
+	Fetched             int                `json:"fetched"`
+	New                 []string           `json:"new"`      // Response IDs not stored yet
+	Modified            []SMResponseChange `json:"modified"` // Stored responses edited in SurveyMonkey since
+	Unchanged           int                `json:"unchanged"`
+	Failed              []string           `json:"failed,omitempty"` // Details could not be fetched
+	ParsedAnswers       int                `json:"parsedAnswers"`    // Answer cells that would be (re)written
+	UnmappedQuestionIDs []string           `json:"unmappedQuestionIds"`
+}
+
+// SMResponseChange is a stored response whose SurveyMonkey copy is newer
+type SMResponseChange struct {
+	ResponseID     string    `json:"responseId"`
+	StoredModified time.Time `json:"storedModified"`
+	RemoteModified time.Time `json:"remoteModified"`
+	StoredStatus   string    `json:"storedStatus"`
+	RemoteStatus   string    `json:"remoteStatus"`
+}
+
+// Outcomes of an SM sync run
+const (
+	SMSyncStatusOK     = "ok"
+	SMSyncStatusFailed = "failed"
+)
+
+// SMSyncSchedule is a survey's automatic sync cadence and the outcome of its
+// last sync (scheduled or manual)
+type SMSyncSchedule struct {
+	ID              primitive.ObjectID `json:"-" bson:"_id,omitempty"`
+	SurveyID        string             `json:"surveyId" bson:"survey_id"`
+	IntervalMinutes int                `json:"intervalMinutes" bson:"interval_minutes"` // 0 = manual syncs only
+	Enabled         bool               `json:"enabled" bson:"enabled"`
+	NextRunAt       *time.Time         `json:"nextRunAt,omitempty" bson:"next_run_at,omitempty"`
+
+	// Held by the instance running the sync; expires so a crashed run is retried
+	LockedUntil *time.Time `json:"-" bson:"locked_until,omitempty"`
+
+	LastRunAt           *time.Time    `json:"lastRunAt,omitempty" bson:"last_run_at,omitempty"`
+	LastSuccessAt       *time.Time    `json:"lastSuccessAt,omitempty" bson:"last_success_at,omitempty"`
+	LastStatus          string        `json:"lastStatus,omitempty" bson:"last_status,omitempty"` // ok | failed
+	LastError           string        `json:"lastError,omitempty" bson:"last_error,omitempty"`
+	LastResult          *SMSyncResult `json:"lastResult,omitempty" bson:"last_result,omitempty"`
+	LastDurationMs      int64         `json:"lastDurationMs,omitempty" bson:"last_duration_ms,omitempty"`
+	ConsecutiveFailures int           `json:"consecutiveFailures" bson:"consecutive_failures"`
+
+	CreatedAt time.Time `json:"createdAt" bson:"created_at"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updated_at"`
+}
+
+// SMScheduleRequest is the body of PUT /v1/sm/surveys/{surveyId}/schedule
+type SMScheduleRequest struct {
+	IntervalMinutes int   `json:"intervalMinutes"`
+	Enabled         *bool `json:"enabled,omitempty"` // Defaults to true
+}
+
+// SMSyncStatus is the last-sync status of a survey
+type SMSyncStatus struct {
+	SurveyID string          `json:"surveyId"`
+	Schedule *SMSyncSchedule `json:"schedule,omitempty"` // Nil until the survey is scheduled or synced
+	Running  bool            `json:"running"`
+	Stale    bool            `json:"stale"` // No successful sync within two intervals (or ever)
 }
 
 // SMSurveySummary is the analytics summary response
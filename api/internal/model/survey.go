@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // SurveySettings configures survey behavior
 type SurveySettings struct {
@@ -8,6 +11,15 @@ type SurveySettings struct {
 	MaxFollowUps          int     `json:"maxFollowUps" bson:"maxFollowUps"`                   // per question
 	DefaultPointsMax      int     `json:"defaultPointsMax" bson:"defaultPointsMax"`
 	AllowSkipAfter        int     `json:"allowSkipAfter" bson:"allowSkipAfter"` // number of attempts before skip allowed
+	// Seconds a player must spend on a question before skip is allowed (0 = no minimum)
+	SkipAfterSeconds int `json:"skipAfterSeconds,omitempty" bson:"skipAfterSeconds,omitempty"`
+
+	// Host approval for difficulty calibration to rewrite question thresholds and points
+	// after each ended room; otherwise suggestions wait for POST .../difficulty/apply
+	AutoCalibrate bool `json:"autoCalibrate,omitempty" bson:"autoCalibrate,omitempty"`
+
+	// Ask players to rate the survey's clarity and length after their last question
+	MetaSurvey bool `json:"metaSurvey,omitempty" bson:"metaSurvey,omitempty"`
 }
 
 // Survey is a persistent template created by a host
@@ -18,11 +30,19 @@ type Survey struct {
 	Intent    string         `json:"intent" bson:"intent"` // Scope/purpose description
 	Settings  SurveySettings `json:"settings" bson:"settings"`
 	Questions []BaseQuestion `json:"questions" bson:"questions"`
+	Branching []BranchRule   `json:"branching,omitempty" bson:"branching,omitempty"` // Host-defined, evaluated before AI follow-ups
+	Branding  *Branding      `json:"branding,omitempty" bson:"branding,omitempty"`   // Default player UI skin for rooms of this survey
+	// Host rules injected into follow-up and report prompts; follow-ups that break them are dropped
+	Guardrails *Guardrails `json:"guardrails,omitempty" bson:"guardrails,omitempty"`
+	// Canonical themes the evaluator maps detected themes onto; set with PUT .../theme-taxonomy
+	ThemeTaxonomy *ThemeTaxonomy `json:"themeTaxonomy,omitempty" bson:"themeTaxonomy,omitempty"`
 	// Persistent SurveyMonkey Meta
 	SMSurveyID string    `json:"smSurveyId,omitempty" bson:"smSurveyId,omitempty"`
 	SMWebLink  string    `json:"smWebLink,omitempty" bson:"smWebLink,omitempty"`
 	CreatedAt  time.Time `json:"createdAt" bson:"createdAt"`
 	UpdatedAt  time.Time `json:"updatedAt" bson:"updatedAt"`
+
+	DeletedAt *time.Time `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"` // Set while the survey is in the trash
 }
 
 // BaseQuestion is a question template in a survey
@@ -37,6 +57,113 @@ type BaseQuestion struct {
 	ScaleMin int `json:"scaleMin,omitempty" bson:"scaleMin,omitempty"`
 	ScaleMax int `json:"scaleMax,omitempty" bson:"scaleMax,omitempty"`
 
-	// For MCQ type
-	Options []string `json:"options,omitempty" bson:"options,omitempty"`
+	// For MCQ and RANKING types
+	Options []string      `json:"options,omitempty" bson:"options,omitempty"`
+	Quotas  []OptionQuota `json:"quotas,omitempty" bson:"quotas,omitempty"` // Screening caps per option
+
+	// For MATRIX type: every row is answered with one column
+	Rows    []string `json:"rows,omitempty" bson:"rows,omitempty"`
+	Columns []string `json:"columns,omitempty" bson:"columns,omitempty"`
+
+	// Image or video shown with the prompt
+	Media *QuestionMedia `json:"media,omitempty" bson:"media,omitempty"`
+
+	// Lets players upload images (e.g. a screenshot of a bug) with their answer
+	AllowAttachments bool `json:"allowAttachments,omitempty" bson:"allowAttachments,omitempty"`
+
+	// ESSAY only: players may record a short audio answer, transcribed into the text answer
+	AllowVoice bool `json:"allowVoice,omitempty" bson:"allowVoice,omitempty"`
+
+	// ESSAY only: accepted answer length in characters (MaxLength 0 = model.MaxAnswerChars)
+	MinLength int `json:"minLength,omitempty" bson:"minLength,omitempty"`
+	MaxLength int `json:"maxLength,omitempty" bson:"maxLength,omitempty"`
+
+	// Optional AI overrides (model tier, temperature, follow-up cap)
+	AI *QuestionAISettings `json:"ai,omitempty" bson:"ai,omitempty"`
+}
+
+// Theme rule limits
+const (
+	MaxBranchThemes     = 10
+	MaxBranchThemeChars = 50
+)
+
+// BranchRule asks extra questions when an MCQ or DEGREE answer matches, or when
+// the player's ESSAY answers bring up a theme (a theme rule). Questions listed in
+// Ask are held back from the queue until a rule fires.
+type BranchRule struct {
+	When    string   `json:"when,omitempty" bson:"when,omitempty"`       // Source question key; optional for theme rules
+	Options []int    `json:"options,omitempty" bson:"options,omitempty"` // MCQ: any of these option indexes
+	Min     *int     `json:"min,omitempty" bson:"min,omitempty"`         // DEGREE: inclusive lower bound
+	Max     *int     `json:"max,omitempty" bson:"max,omitempty"`         // DEGREE: inclusive upper bound
+	Themes  []string `json:"themes,omitempty" bson:"themes,omitempty"`   // Theme rule: any of these in a detected theme
+	Ask     []string `json:"ask" bson:"ask"`                             // Question keys to inject, in order
+}
+
+// IsThemeRule reports whether the rule fires on detected themes instead of an answer value
+func (r BranchRule) IsThemeRule() bool {
+	return len(r.Themes) > 0
+}
+
+// MatchesThemes reports whether a theme rule fires. Rules with a source question
+// look at the themes of answerKey's answer only; the rest at every theme the player
+// brought up so far. Terms match case-insensitively anywhere in a theme.
+func (r BranchRule) MatchesThemes(answerKey string, answerThemes []string, playerThemes map[string]int) bool {
+	if !r.IsThemeRule() {
+		return false
+	}
+	themes := answerThemes
+	if r.When == "" {
+		themes = make([]string, 0, len(playerThemes))
+		for t := range playerThemes {
+			themes = append(themes, t)
+		}
+	} else if answerKey != r.When {
+		return false
+	}
+	for _, theme := range themes {
+		theme = strings.ToLower(theme)
+		for _, term := range r.Themes {
+			if strings.Contains(theme, strings.ToLower(term)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Matches reports whether an answer to the rule's source question triggers it
+func (r BranchRule) Matches(answer *Answer) bool {
+	if answer.QuestionKey != r.When || r.IsThemeRule() {
+		return false
+	}
+	if len(r.Options) > 0 {
+		if answer.OptionIndex == nil {
+			return false
+		}
+		for _, o := range r.Options {
+			if o == *answer.OptionIndex {
+				return true
+			}
+		}
+		return false
+	}
+	if r.Min != nil && answer.DegreeValue < *r.Min {
+		return false
+	}
+	if r.Max != nil && answer.DegreeValue > *r.Max {
+		return false
+	}
+	return r.Min != nil || r.Max != nil
+}
+
+// BranchTargets returns the set of question keys only reached through branching
+func (s *Survey) BranchTargets() map[string]bool {
+	targets := make(map[string]bool)
+	for _, r := range s.Branching {
+		for _, k := range r.Ask {
+			targets[k] = true
+		}
+	}
+	return targets
 }
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// MaxTemplateNameChars caps a room template's name
+const MaxTemplateNameChars = 100
+
+// RoomTemplate captures everything needed to open a recurring session in one call
+type RoomTemplate struct {
+	ID        string       `json:"id" bson:"_id,omitempty"`
+	HostID    string       `json:"hostId" bson:"hostId"`
+	Name      string       `json:"name" bson:"name"`
+	SurveyID  string       `json:"surveyId" bson:"surveyId"`
+	Settings  RoomSettings `json:"settings" bson:"settings"` // Includes the AI strictness preset
+	Branding  *Branding    `json:"branding,omitempty" bson:"branding,omitempty"`
+	HostNotes string       `json:"hostNotes,omitempty" bson:"hostNotes,omitempty"`
+
+	UseCount   int        `json:"useCount" bson:"useCount"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty" bson:"lastUsedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt" bson:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt" bson:"updatedAt"`
+}
+
+// RoomTemplateRequest is the request body for creating or replacing a template
+type RoomTemplateRequest struct {
+	Name      string        `json:"name"`
+	SurveyID  string        `json:"surveyId"`
+	Settings  *RoomSettings `json:"settings,omitempty"`
+	Branding  *Branding     `json:"branding,omitempty"`
+	HostNotes string        `json:"hostNotes,omitempty"`
+}
@@ -0,0 +1,88 @@
+package model
+
+import "strings"
+
+// Theme taxonomy limits
+const (
+	MaxTaxonomyThemes    = 30
+	MaxThemeSynonyms     = 20
+	MaxTaxonomyTermChars = 50
+)
+
+// ThemeOther is the canonical theme of detected themes the taxonomy does not cover
+const ThemeOther = "other"
+
+// ThemeTaxonomy is a host's canonical list of themes for a survey. Themes the
+// evaluator detects are mapped onto it so analytics count "battery life" and
+// "charging" as one theme.
+type ThemeTaxonomy struct {
+	Themes []TaxonomyTheme `json:"themes" bson:"themes"`
+}
+
+// TaxonomyTheme is one canonical theme and the words that mean it
+type TaxonomyTheme struct {
+	Name     string   `json:"name" bson:"name"`                             // e.g. "Battery"
+	Synonyms []string `json:"synonyms,omitempty" bson:"synonyms,omitempty"` // e.g. "charging", "battery life"
+}
+
+// IsEmpty reports whether no theme is defined
+func (t *ThemeTaxonomy) IsEmpty() bool {
+	return t == nil || len(t.Themes) == 0
+}
+
+// Canonical returns the name of the taxonomy theme a detected theme matches, or "".
+// A theme matches when it is the name or a synonym, or contains one as whole
+// words; case and punctuation are ignored and earlier themes win.
+func (t *ThemeTaxonomy) Canonical(theme string) string {
+	if t.IsEmpty() {
+		return ""
+	}
+	normalized := normalizeGuardrailText(theme)
+	if normalized == "" {
+		return ""
+	}
+	for _, exact := range []bool{true, false} {
+		for _, tt := range t.Themes {
+			for _, term := range append([]string{tt.Name}, tt.Synonyms...) {
+				needle := normalizeGuardrailText(term)
+				if needle == "" {
+					continue
+				}
+				if normalized == needle || (!exact && strings.Contains(" "+normalized+" ", " "+needle+" ")) {
+					return tt.Name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// Apply rewrites signals' themes to canonical names, each listed once. Themes
+// outside the taxonomy count as ThemeOther and are kept in EmergentThemes.
+func (t *ThemeTaxonomy) Apply(signals *Signals) {
+	if t.IsEmpty() || signals == nil || len(signals.Themes) == 0 {
+		return
+	}
+	var themes, emergent []string
+	seen := make(map[string]bool, len(signals.Themes))
+	for _, theme := range signals.Themes {
+		// The evaluator is asked to write themes it cannot map as "other: <theme>"
+		theme = strings.TrimSpace(theme)
+		if prefix := ThemeOther + ":"; len(theme) > len(prefix) && strings.EqualFold(theme[:len(prefix)], prefix) {
+			theme = strings.TrimSpace(theme[len(prefix):])
+		}
+		canonical := t.Canonical(theme)
+		if canonical == "" {
+			canonical = ThemeOther
+			if theme != "" && !strings.EqualFold(theme, ThemeOther) {
+				emergent = append(emergent, theme)
+			}
+		}
+		if !seen[canonical] {
+			seen[canonical] = true
+			themes = append(themes, canonical)
+		}
+	}
+	signals.Themes = themes
+	signals.EmergentThemes = emergent
+}
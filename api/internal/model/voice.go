@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// MaxVoiceBytes caps a voice answer upload (roughly two minutes of compressed audio)
+const MaxVoiceBytes = 10 << 20
+
+// VoiceContentTypes maps sniffed content types to the stored type and file extension
+var VoiceContentTypes = map[string]struct{ ContentType, Ext string }{
+	"audio/mpeg":      {"audio/mpeg", ".mp3"},
+	"audio/wave":      {"audio/wav", ".wav"},
+	"application/ogg": {"audio/ogg", ".ogg"},
+	"video/webm":      {"audio/webm", ".webm"}, // Browser MediaRecorder output sniffs as WebM video
+	"video/mp4":       {"audio/mp4", ".m4a"},
+}
+
+// VoiceClip is a recorded answer and its transcript. The audio lives in the
+// attachment store under StorageKey until the voice retention period ends.
+type VoiceClip struct {
+	ID          string    `json:"id" bson:"_id"`
+	RoomCode    string    `json:"roomCode" bson:"roomCode"`
+	PlayerID    string    `json:"playerId" bson:"playerId"`
+	QuestionKey string    `json:"questionKey" bson:"questionKey"`
+	ContentType string    `json:"contentType" bson:"contentType"`
+	Size        int64     `json:"size" bson:"size"`
+	StorageKey  string    `json:"-" bson:"storageKey"`
+	Transcript  string    `json:"transcript" bson:"transcript"`
+	Provider    string    `json:"provider" bson:"provider"` // Transcription provider
+	CreatedAt   time.Time `json:"createdAt" bson:"createdAt"`
+}
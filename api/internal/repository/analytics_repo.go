@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AnalyticsRepo handles MongoDB archival of L2-L4 analytics
+type AnalyticsRepo interface {
+	// Archival (upserts, safe to repeat)
+	SavePlayerProfiles(ctx context.Context, profiles []*model.PlayerProfile) error
+	SaveQuestionProfiles(ctx context.Context, profiles []*model.QuestionProfile) error
+	SaveRoomMemory(ctx context.Context, memory *model.RoomMemory) error
+
+	// Per-room reads
+	GetPlayerProfiles(ctx context.Context, roomCode string) ([]*model.PlayerProfile, error)
+	GetQuestionProfiles(ctx context.Context, roomCode string) ([]*model.QuestionProfile, error)
+	GetRoomMemory(ctx context.Context, roomCode string) (*model.RoomMemory, error)
+
+	// Cross-room reads
+	GetQuestionProfilesForRooms(ctx context.Context, roomCodes []string) ([]*model.QuestionProfile, error)
+	GetRoomMemories(ctx context.Context, roomCodes []string) ([]*model.RoomMemory, error)
+
+	// Data subject requests
+	GetPlayerProfile(ctx context.Context, roomCode, playerID string) (*model.PlayerProfile, error)
+	DeletePlayerProfile(ctx context.Context, roomCode, playerID string) error
+}
+
+type analyticsRepo struct {
+	playerProfiles   *mongo.Collection
+	questionProfiles *mongo.Collection
+	roomMemories     *mongo.Collection
+}
+
+// NewAnalyticsRepo creates a new analytics repository with indexes
+func NewAnalyticsRepo(db *mongo.Database) AnalyticsRepo {
+	repo := &analyticsRepo{
+		playerProfiles:   db.Collection("analytics_player_profiles"),
+		questionProfiles: db.Collection("analytics_question_profiles"),
+		roomMemories:     db.Collection("analytics_room_memory"),
+	}
+
+	repo.ensureIndexes(context.Background())
+
+	return repo
+}
+
+func (r *analyticsRepo) ensureIndexes(ctx context.Context) {
+	r.createIndex(ctx, r.playerProfiles, bson.D{
+		{Key: "roomCode", Value: 1},
+		{Key: "playerId", Value: 1},
+	}, true)
+	r.createIndex(ctx, r.questionProfiles, bson.D{
+		{Key: "roomCode", Value: 1},
+		{Key: "questionKey", Value: 1},
+	}, true)
+	r.createIndex(ctx, r.questionProfiles, bson.D{{Key: "updatedAt", Value: -1}}, false)
+	r.createIndex(ctx, r.roomMemories, bson.D{{Key: "roomCode", Value: 1}}, true)
+	r.createIndex(ctx, r.roomMemories, bson.D{{Key: "updatedAt", Value: -1}}, false)
+
+	log.Println("Analytics indexes ensured")
+}
+
+func (r *analyticsRepo) createIndex(ctx context.Context, coll *mongo.Collection, keys bson.D, unique bool) {
+	opts := options.Index().SetUnique(unique)
+	_, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: keys, Options: opts})
+	if err != nil {
+		log.Printf("Warning: failed to create index on %s: %v", coll.Name(), err)
+	}
+}
+
+func (r *analyticsRepo) SavePlayerProfiles(ctx context.Context, profiles []*model.PlayerProfile) error {
+	if len(profiles) == 0 {
+		return nil
+	}
+	writes := make([]mongo.WriteModel, 0, len(profiles))
+	for _, p := range profiles {
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"roomCode": p.RoomCode, "playerId": p.PlayerID}).
+			SetReplacement(p).
+			SetUpsert(true))
+	}
+	_, err := r.playerProfiles.BulkWrite(ctx, writes)
+	return err
+}
+
+func (r *analyticsRepo) SaveQuestionProfiles(ctx context.Context, profiles []*model.QuestionProfile) error {
+	if len(profiles) == 0 {
+		return nil
+	}
+	writes := make([]mongo.WriteModel, 0, len(profiles))
+	for _, p := range profiles {
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"roomCode": p.RoomCode, "questionKey": p.QuestionKey}).
+			SetReplacement(p).
+			SetUpsert(true))
+	}
+	_, err := r.questionProfiles.BulkWrite(ctx, writes)
+	return err
+}
+
+func (r *analyticsRepo) SaveRoomMemory(ctx context.Context, memory *model.RoomMemory) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.roomMemories.ReplaceOne(ctx, bson.M{"roomCode": memory.RoomCode}, memory, opts)
+	return err
+}
+
+func (r *analyticsRepo) GetPlayerProfiles(ctx context.Context, roomCode string) ([]*model.PlayerProfile, error) {
+	cursor, err := r.playerProfiles.Find(ctx, bson.M{"roomCode": roomCode})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var profiles []*model.PlayerProfile
+	if err := cursor.All(ctx, &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func (r *analyticsRepo) GetPlayerProfile(ctx context.Context, roomCode, playerID string) (*model.PlayerProfile, error) {
+	var profile model.PlayerProfile
+	err := r.playerProfiles.FindOne(ctx, bson.M{"roomCode": roomCode, "playerId": playerID}).Decode(&profile)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *analyticsRepo) DeletePlayerProfile(ctx context.Context, roomCode, playerID string) error {
+	_, err := r.playerProfiles.DeleteOne(ctx, bson.M{"roomCode": roomCode, "playerId": playerID})
+	return err
+}
+
+func (r *analyticsRepo) GetQuestionProfiles(ctx context.Context, roomCode string) ([]*model.QuestionProfile, error) {
+	return r.GetQuestionProfilesForRooms(ctx, []string{roomCode})
+}
+
+func (r *analyticsRepo) GetRoomMemory(ctx context.Context, roomCode string) (*model.RoomMemory, error) {
+	var memory model.RoomMemory
+	err := r.roomMemories.FindOne(ctx, bson.M{"roomCode": roomCode}).Decode(&memory)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &memory, nil
+}
+
+func (r *analyticsRepo) GetQuestionProfilesForRooms(ctx context.Context, roomCodes []string) ([]*model.QuestionProfile, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "roomCode", Value: 1}, {Key: "questionKey", Value: 1}})
+	cursor, err := r.questionProfiles.Find(ctx, bson.M{"roomCode": bson.M{"$in": roomCodes}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var profiles []*model.QuestionProfile
+	if err := cursor.All(ctx, &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func (r *analyticsRepo) GetRoomMemories(ctx context.Context, roomCodes []string) ([]*model.RoomMemory, error) {
+	cursor, err := r.roomMemories.Find(ctx, bson.M{"roomCode": bson.M{"$in": roomCodes}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var memories []*model.RoomMemory
+	if err := cursor.All(ctx, &memories); err != nil {
+		return nil, err
+	}
+	return memories, nil
+}
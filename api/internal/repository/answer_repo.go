@@ -3,22 +3,38 @@ package repository
 import (
 	"2026champs/internal/model"
 	"context"
+	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// ErrDuplicateAnswer is returned by Create when an answer with the same
+// room, player, question and clientAttemptId already exists
+var ErrDuplicateAnswer = errors.New("answer already recorded for this attempt")
+
 // AnswerRepo handles MongoDB operations for answers (historical persistence)
 type AnswerRepo interface {
 	Create(ctx context.Context, answer *model.Answer) (string, error)
+	// CreateMany inserts answers in one write, setting their IDs
+	CreateMany(ctx context.Context, answers []*model.Answer) error
+	Restore(ctx context.Context, answers []*model.Answer) error
 	GetByID(ctx context.Context, id string) (*model.Answer, error)
 	GetByRoomCode(ctx context.Context, roomCode string) ([]*model.Answer, error)
+	GetByRoomCodes(ctx context.Context, roomCodes []string) ([]*model.Answer, error)
 	GetByRoomAndPlayer(ctx context.Context, roomCode, playerID string) ([]*model.Answer, error)
 	GetByRoomAndQuestion(ctx context.Context, roomCode, questionKey string) ([]*model.Answer, error)
 	Update(ctx context.Context, answer *model.Answer) error
-	CheckIdempotency(ctx context.Context, roomCode, playerID, questionKey, clientAttemptID string) (bool, error)
+	GetByClientAttempt(ctx context.Context, roomCode, playerID, questionKey, clientAttemptID string) (*model.Answer, error)
+	GetReviewed(ctx context.Context, roomCodes []string) ([]*model.Answer, error)
+	SetStar(ctx context.Context, id string, star *model.AnswerStar) error
+	SetReplay(ctx context.Context, id string, resp *model.SubmitAnswerResponse) error
+	GetStarred(ctx context.Context, roomCode string) ([]*model.Answer, error)
+	DeleteByRoomAndPlayer(ctx context.Context, roomCode, playerID string) (int64, error)
+	DeleteByIDs(ctx context.Context, ids []string) (int64, error)
 }
 
 type answerRepo struct {
@@ -32,20 +48,89 @@ func NewAnswerRepo(db *mongo.Database) AnswerRepo {
 	}
 }
 
+// Create inserts the answer. Answers with a clientAttemptId are upserted on the
+// unique (roomCode, playerId, questionKey, clientAttemptId) index, so concurrent
+// duplicates resolve atomically: the loser gets ErrDuplicateAnswer with answer
+// replaced by the stored one.
 func (r *answerRepo) Create(ctx context.Context, answer *model.Answer) (string, error) {
 	answer.CreatedAt = time.Now()
 	answer.UpdatedAt = time.Now()
 
-	result, err := r.collection.InsertOne(ctx, answer)
+	if answer.ClientAttemptID == "" {
+		result, err := r.collection.InsertOne(ctx, answer)
+		if err != nil {
+			return "", err
+		}
+		oid, ok := result.InsertedID.(primitive.ObjectID)
+		if !ok {
+			return "", nil
+		}
+		return oid.Hex(), nil
+	}
+
+	filter := bson.M{
+		"roomCode":        answer.RoomCode,
+		"playerId":        answer.PlayerID,
+		"questionKey":     answer.QuestionKey,
+		"clientAttemptId": answer.ClientAttemptID,
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": answer}, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		// Two upserts raced past the match; the index let only one insert
+		err = nil
+		result = &mongo.UpdateResult{}
+	}
 	if err != nil {
 		return "", err
 	}
+	if oid, ok := result.UpsertedID.(primitive.ObjectID); ok {
+		answer.ID = oid.Hex()
+		return answer.ID, nil
+	}
+
+	if err := r.collection.FindOne(ctx, filter).Decode(answer); err != nil {
+		return "", err
+	}
+	return answer.ID, ErrDuplicateAnswer
+}
+
+// CreateMany inserts answers in a single write
+func (r *answerRepo) CreateMany(ctx context.Context, answers []*model.Answer) error {
+	if len(answers) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	docs := make([]interface{}, len(answers))
+	for i, a := range answers {
+		a.CreatedAt = now
+		a.UpdatedAt = now
+		docs[i] = a
+	}
 
-	oid, ok := result.InsertedID.(primitive.ObjectID)
-	if !ok {
-		return "", nil
+	result, err := r.collection.InsertMany(ctx, docs)
+	if err != nil {
+		return err
+	}
+	for i, id := range result.InsertedIDs {
+		if oid, ok := id.(primitive.ObjectID); ok {
+			answers[i].ID = oid.Hex()
+		}
 	}
-	return oid.Hex(), nil
+	return nil
+}
+
+// Restore inserts answers as they are, keeping their timestamps (bundle imports)
+func (r *answerRepo) Restore(ctx context.Context, answers []*model.Answer) error {
+	if len(answers) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(answers))
+	for i, a := range answers {
+		docs[i] = a
+	}
+	_, err := r.collection.InsertMany(ctx, docs)
+	return err
 }
 
 func (r *answerRepo) GetByID(ctx context.Context, id string) (*model.Answer, error) {
@@ -82,6 +167,80 @@ func (r *answerRepo) GetByRoomCode(ctx context.Context, roomCode string) ([]*mod
 	return answers, nil
 }
 
+// GetByRoomCodes returns every answer in the given rooms
+func (r *answerRepo) GetByRoomCodes(ctx context.Context, roomCodes []string) ([]*model.Answer, error) {
+	answers := []*model.Answer{}
+	if len(roomCodes) == 0 {
+		return answers, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"roomCode": bson.M{"$in": roomCodes}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &answers); err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+// GetReviewed returns answers in the given rooms that a human overrode or audited
+func (r *answerRepo) GetReviewed(ctx context.Context, roomCodes []string) ([]*model.Answer, error) {
+	answers := []*model.Answer{}
+	if len(roomCodes) == 0 {
+		return answers, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"roomCode": bson.M{"$in": roomCodes},
+		"$or": []bson.M{
+			{"override": bson.M{"$exists": true}},
+			{"audit": bson.M{"$exists": true}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &answers); err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+func (r *answerRepo) DeleteByRoomAndPlayer(ctx context.Context, roomCode, playerID string) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{
+		"roomCode": roomCode,
+		"playerId": playerID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+func (r *answerRepo) DeleteByIDs(ctx context.Context, ids []string) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	oids := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		oid, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return 0, err
+		}
+		oids = append(oids, oid)
+	}
+	result, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": oids}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
 func (r *answerRepo) GetByRoomAndPlayer(ctx context.Context, roomCode, playerID string) ([]*model.Answer, error) {
 	cursor, err := r.collection.Find(ctx, bson.M{
 		"roomCode": roomCode,
@@ -140,23 +299,77 @@ func (r *answerRepo) Update(ctx context.Context, answer *model.Answer) error {
 			"pointsEarned":    answer.PointsEarned,
 			"signals":         answer.Signals,
 			"evalSummary":     answer.EvalSummary,
+			"qualityScore":    answer.QualityScore,
 			"updatedAt":       answer.UpdatedAt,
 		},
 	}
 
+	// Review fields are only written once present, GetReviewed matches on their existence
+	set := updateDoc["$set"].(bson.M)
+	if answer.Override != nil {
+		set["override"] = answer.Override
+	}
+	if answer.Audit != nil {
+		set["audit"] = answer.Audit
+	}
+
 	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, updateDoc)
 	return err
 }
 
-func (r *answerRepo) CheckIdempotency(ctx context.Context, roomCode, playerID, questionKey, clientAttemptID string) (bool, error) {
-	count, err := r.collection.CountDocuments(ctx, bson.M{
+// SetStar stars an answer, or removes its star when star is nil
+func (r *answerRepo) SetStar(ctx context.Context, id string, star *model.AnswerStar) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$unset": bson.M{"star": ""}}
+	if star != nil {
+		update = bson.M{"$set": bson.M{"star": star}}
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	return err
+}
+
+// SetReplay stores the response sent for the answer's submission
+func (r *answerRepo) SetReplay(ctx context.Context, id string, resp *model.SubmitAnswerResponse) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"replay": resp}})
+	return err
+}
+
+// GetStarred returns a room's host-starred answers, oldest star first
+func (r *answerRepo) GetStarred(ctx context.Context, roomCode string) ([]*model.Answer, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "star.starredAt", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"roomCode": roomCode, "star": bson.M{"$exists": true}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	answers := []*model.Answer{}
+	if err := cursor.All(ctx, &answers); err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+func (r *answerRepo) GetByClientAttempt(ctx context.Context, roomCode, playerID, questionKey, clientAttemptID string) (*model.Answer, error) {
+	var answer model.Answer
+	err := r.collection.FindOne(ctx, bson.M{
 		"roomCode":        roomCode,
 		"playerId":        playerID,
 		"questionKey":     questionKey,
 		"clientAttemptId": clientAttemptID,
-	})
+	}).Decode(&answer)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	return count > 0, nil
+	return &answer, nil
 }
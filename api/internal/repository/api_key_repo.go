@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// APIKeyRepo handles MongoDB operations for host API keys
+type APIKeyRepo interface {
+	Create(ctx context.Context, key *model.APIKey) (string, error)
+	GetByHash(ctx context.Context, hash string) (*model.APIKey, error)
+	ListByHost(ctx context.Context, hostID string) ([]*model.APIKey, error)
+	Revoke(ctx context.Context, hostID, keyID string) (bool, error)
+	TouchLastUsed(ctx context.Context, keyID string, at time.Time) error
+}
+
+type apiKeyRepo struct {
+	collection *mongo.Collection
+}
+
+// NewAPIKeyRepo creates a new API key repository
+func NewAPIKeyRepo(db *mongo.Database) APIKeyRepo {
+	repo := &apiKeyRepo{
+		collection: db.Collection("api_keys"),
+	}
+	repo.ensureIndexes(context.Background())
+	return repo
+}
+
+func (r *apiKeyRepo) ensureIndexes(ctx context.Context) {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "hostId", Value: 1}}},
+	}
+	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		log.Printf("Warning: failed to create index on %s: %v", r.collection.Name(), err)
+	}
+}
+
+func (r *apiKeyRepo) Create(ctx context.Context, key *model.APIKey) (string, error) {
+	key.CreatedAt = time.Now()
+	result, err := r.collection.InsertOne(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", nil
+	}
+	return oid.Hex(), nil
+}
+
+func (r *apiKeyRepo) GetByHash(ctx context.Context, hash string) (*model.APIKey, error) {
+	var key model.APIKey
+	err := r.collection.FindOne(ctx, bson.M{"hash": hash}).Decode(&key)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepo) ListByHost(ctx context.Context, hostID string) ([]*model.APIKey, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"hostId": hostID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	keys := []*model.APIKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Revoke marks a host's key as revoked; returns false if no active key matched
+func (r *apiKeyRepo) Revoke(ctx context.Context, hostID, keyID string) (bool, error) {
+	oid, err := primitive.ObjectIDFromHex(keyID)
+	if err != nil {
+		return false, nil
+	}
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": oid, "hostId": hostID, "revokedAt": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revokedAt": time.Now()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (r *apiKeyRepo) TouchLastUsed(ctx context.Context, keyID string, at time.Time) error {
+	oid, err := primitive.ObjectIDFromHex(keyID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"lastUsedAt": at}})
+	return err
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AttachmentRepo handles MongoDB operations for answer attachments
+type AttachmentRepo interface {
+	Create(ctx context.Context, attachment *model.Attachment) error
+	GetByID(ctx context.Context, id string) (*model.Attachment, error)
+	ListByRoom(ctx context.Context, roomCode string) ([]*model.Attachment, error)
+	CountByQuestion(ctx context.Context, roomCode, playerID, questionKey string) (int64, error)
+	ListByPlayer(ctx context.Context, roomCode, playerID string) ([]*model.Attachment, error)
+	ListOlderThan(ctx context.Context, cutoff time.Time) ([]*model.Attachment, error)
+	DeleteByPlayer(ctx context.Context, roomCode, playerID string) (int64, error)
+	DeleteByRoom(ctx context.Context, roomCode string) (int64, error)
+}
+
+type attachmentRepo struct {
+	collection *mongo.Collection
+}
+
+// NewAttachmentRepo creates a new attachment repository
+func NewAttachmentRepo(db *mongo.Database) AttachmentRepo {
+	repo := &attachmentRepo{
+		collection: db.Collection("attachments"),
+	}
+	repo.ensureIndexes(context.Background())
+	return repo
+}
+
+func (r *attachmentRepo) ensureIndexes(ctx context.Context) {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "roomCode", Value: 1}, {Key: "playerId", Value: 1}, {Key: "questionKey", Value: 1}}},
+		{Keys: bson.D{{Key: "createdAt", Value: 1}}},
+	}
+	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		log.Printf("Warning: failed to create index on %s: %v", r.collection.Name(), err)
+	}
+}
+
+func (r *attachmentRepo) Create(ctx context.Context, attachment *model.Attachment) error {
+	attachment.CreatedAt = time.Now()
+	_, err := r.collection.InsertOne(ctx, attachment)
+	return err
+}
+
+func (r *attachmentRepo) GetByID(ctx context.Context, id string) (*model.Attachment, error) {
+	var attachment model.Attachment
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&attachment)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *attachmentRepo) ListByRoom(ctx context.Context, roomCode string) ([]*model.Attachment, error) {
+	return r.list(ctx, bson.M{"roomCode": roomCode})
+}
+
+// ListByPlayer returns a player's uploads in a room, oldest first
+func (r *attachmentRepo) ListByPlayer(ctx context.Context, roomCode, playerID string) ([]*model.Attachment, error) {
+	return r.list(ctx, bson.M{"roomCode": roomCode, "playerId": playerID})
+}
+
+func (r *attachmentRepo) ListOlderThan(ctx context.Context, cutoff time.Time) ([]*model.Attachment, error) {
+	return r.list(ctx, bson.M{"createdAt": bson.M{"$lt": cutoff}})
+}
+
+// list returns the matching attachments, oldest first
+func (r *attachmentRepo) list(ctx context.Context, filter bson.M) ([]*model.Attachment, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	attachments := []*model.Attachment{}
+	if err := cursor.All(ctx, &attachments); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *attachmentRepo) CountByQuestion(ctx context.Context, roomCode, playerID, questionKey string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"roomCode": roomCode, "playerId": playerID, "questionKey": questionKey})
+}
+
+func (r *attachmentRepo) DeleteByPlayer(ctx context.Context, roomCode, playerID string) (int64, error) {
+	return r.deleteMany(ctx, bson.M{"roomCode": roomCode, "playerId": playerID})
+}
+
+func (r *attachmentRepo) DeleteByRoom(ctx context.Context, roomCode string) (int64, error) {
+	return r.deleteMany(ctx, bson.M{"roomCode": roomCode})
+}
+
+func (r *attachmentRepo) deleteMany(ctx context.Context, filter bson.M) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
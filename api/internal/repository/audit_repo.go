@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"2026champs/internal/model"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AuditRepo handles MongoDB operations for room audit trails
+type AuditRepo interface {
+	Append(ctx context.Context, entry *model.AuditEntry) error
+	GetByRoom(ctx context.Context, roomCode string) ([]*model.AuditEntry, error)
+}
+
+type auditRepo struct {
+	collection *mongo.Collection
+}
+
+// NewAuditRepo creates a new audit repository; indexes are created by migrations
+func NewAuditRepo(db *mongo.Database) AuditRepo {
+	return &auditRepo{collection: db.Collection("room_audit")}
+}
+
+func (r *auditRepo) Append(ctx context.Context, entry *model.AuditEntry) error {
+	_, err := r.collection.InsertOne(ctx, entry)
+	return err
+}
+
+// GetByRoom returns a room's audit trail, oldest first
+func (r *auditRepo) GetByRoom(ctx context.Context, roomCode string) ([]*model.AuditEntry, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"roomCode": roomCode}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []*model.AuditEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/model"
+	"context"
+	"log"
+	"time"
+)
+
+type cachedSurveyRepo struct {
+	SurveyRepo
+	cache cache.SurveyCache
+}
+
+// NewCachedSurveyRepo wraps repo so GetByID reads through the survey cache.
+// Update, SetThemeTaxonomy, SoftDelete and Restore invalidate the cached copy; cache errors fall back to Mongo.
+func NewCachedSurveyRepo(repo SurveyRepo, surveyCache cache.SurveyCache) SurveyRepo {
+	return &cachedSurveyRepo{SurveyRepo: repo, cache: surveyCache}
+}
+
+func (r *cachedSurveyRepo) GetByID(ctx context.Context, id string) (*model.Survey, error) {
+	if survey, err := r.cache.Get(ctx, id); err == nil && survey != nil {
+		return survey, nil
+	}
+
+	survey, err := r.SurveyRepo.GetByID(ctx, id)
+	if err != nil || survey == nil {
+		return survey, err
+	}
+	if err := r.cache.Set(ctx, survey); err != nil {
+		log.Printf("Warning: failed to cache survey %s: %v", id, err)
+	}
+	return survey, nil
+}
+
+func (r *cachedSurveyRepo) Update(ctx context.Context, survey *model.Survey) error {
+	if err := r.SurveyRepo.Update(ctx, survey); err != nil {
+		return err
+	}
+	r.invalidate(ctx, survey.ID)
+	return nil
+}
+
+func (r *cachedSurveyRepo) SetThemeTaxonomy(ctx context.Context, id string, taxonomy *model.ThemeTaxonomy) error {
+	if err := r.SurveyRepo.SetThemeTaxonomy(ctx, id, taxonomy); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id)
+	return nil
+}
+
+func (r *cachedSurveyRepo) SoftDelete(ctx context.Context, id string, at time.Time) (bool, error) {
+	deleted, err := r.SurveyRepo.SoftDelete(ctx, id, at)
+	if deleted {
+		r.invalidate(ctx, id)
+	}
+	return deleted, err
+}
+
+func (r *cachedSurveyRepo) Restore(ctx context.Context, id string) (bool, error) {
+	restored, err := r.SurveyRepo.Restore(ctx, id)
+	if restored {
+		r.invalidate(ctx, id)
+	}
+	return restored, err
+}
+
+func (r *cachedSurveyRepo) invalidate(ctx context.Context, id string) {
+	if err := r.cache.Delete(ctx, id); err != nil {
+		log.Printf("Warning: failed to invalidate cached survey %s: %v", id, err)
+	}
+}
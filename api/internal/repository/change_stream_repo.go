@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Change stream errors callers react to
+var (
+	// ErrChangeStreamsUnsupported is returned when MongoDB runs standalone; change
+	// streams need a replica set or sharded cluster
+	ErrChangeStreamsUnsupported = errors.New("change streams need a replica set")
+	// ErrChangeHistoryLost is returned when the resume token fell off the oplog
+	ErrChangeHistoryLost = errors.New("change stream history lost")
+)
+
+// MongoDB error codes for the errors above
+const (
+	codeChangeStreamsUnsupported = 40573
+	codeChangeHistoryLost        = 286
+	codeChangeStreamFatal        = 280
+)
+
+// ChangeOp is the kind of write a change stream reports
+type ChangeOp string
+
+const (
+	ChangeInsert  ChangeOp = "insert"
+	ChangeUpdate  ChangeOp = "update"
+	ChangeReplace ChangeOp = "replace"
+)
+
+// ResumeToken marks a position in a change stream; opaque to callers
+type ResumeToken []byte
+
+// Change is one write to a watched collection
+type Change struct {
+	Op            ChangeOp
+	UpdatedFields []string    // Top-level fields an update set; empty for inserts and replacements
+	Token         ResumeToken // Resume after this change
+
+	id  string
+	doc bson.Raw
+}
+
+// ID identifies the change across every process watching the collection
+func (c *Change) ID() string {
+	return c.id
+}
+
+// Decode unmarshals the document as it was after the write
+func (c *Change) Decode(v interface{}) error {
+	return bson.Unmarshal(c.doc, v)
+}
+
+// ChangeStream reads the changes of one collection in order
+type ChangeStream struct {
+	stream *mongo.ChangeStream
+}
+
+// Next blocks until the next change; it returns an error once ctx is done or the stream fails
+func (s *ChangeStream) Next(ctx context.Context) (*Change, error) {
+	for s.stream.Next(ctx) {
+		var event struct {
+			ID                bson.Raw `bson:"_id"`
+			OperationType     ChangeOp `bson:"operationType"`
+			FullDocument      bson.Raw `bson:"fullDocument"`
+			UpdateDescription struct {
+				UpdatedFields bson.Raw `bson:"updatedFields"`
+			} `bson:"updateDescription"`
+		}
+		if err := s.stream.Decode(&event); err != nil {
+			return nil, err
+		}
+		if event.FullDocument == nil {
+			continue // Deleted again before the update lookup ran
+		}
+
+		change := &Change{
+			Op:    event.OperationType,
+			Token: ResumeToken(s.stream.ResumeToken()),
+			doc:   event.FullDocument,
+		}
+		change.id, _ = event.ID.Lookup("_data").StringValueOK()
+		if elems, err := event.UpdateDescription.UpdatedFields.Elements(); err == nil {
+			seen := make(map[string]bool, len(elems))
+			for _, elem := range elems {
+				field, _, _ := strings.Cut(elem.Key(), ".")
+				if !seen[field] {
+					seen[field] = true
+					change.UpdatedFields = append(change.UpdatedFields, field)
+				}
+			}
+		}
+		return change, nil
+	}
+	if err := s.stream.Err(); err != nil {
+		return nil, changeStreamError(err)
+	}
+	return nil, ctx.Err()
+}
+
+// Close releases the server cursor
+func (s *ChangeStream) Close() {
+	s.stream.Close(context.Background())
+}
+
+// ChangeStreamRepo follows writes to collections through MongoDB change streams
+type ChangeStreamRepo interface {
+	// WatchAnswers streams answer inserts and updates after token, or from now when token is nil
+	WatchAnswers(ctx context.Context, token ResumeToken) (*ChangeStream, error)
+	// WatchAIReports streams AI report inserts and updates after token, or from now when token is nil
+	WatchAIReports(ctx context.Context, token ResumeToken) (*ChangeStream, error)
+}
+
+type changeStreamRepo struct {
+	answers   *mongo.Collection
+	aiReports *mongo.Collection
+}
+
+// NewChangeStreamRepo creates a new change stream repository
+func NewChangeStreamRepo(db *mongo.Database) ChangeStreamRepo {
+	return &changeStreamRepo{
+		answers:   db.Collection("answers"),
+		aiReports: db.Collection("ai_reports"),
+	}
+}
+
+func (r *changeStreamRepo) WatchAnswers(ctx context.Context, token ResumeToken) (*ChangeStream, error) {
+	return watch(ctx, r.answers, token)
+}
+
+func (r *changeStreamRepo) WatchAIReports(ctx context.Context, token ResumeToken) (*ChangeStream, error) {
+	return watch(ctx, r.aiReports, token)
+}
+
+// watch opens a stream of inserts, updates and replacements with the document
+// as it is after each write
+func watch(ctx context.Context, collection *mongo.Collection, token ResumeToken) (*ChangeStream, error) {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{
+		"operationType": bson.M{"$in": bson.A{ChangeInsert, ChangeUpdate, ChangeReplace}},
+	}}}}
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if token != nil {
+		opts.SetResumeAfter(bson.Raw(token))
+	}
+
+	stream, err := collection.Watch(ctx, pipeline, opts)
+	if err != nil {
+		return nil, changeStreamError(err)
+	}
+	return &ChangeStream{stream: stream}, nil
+}
+
+// changeStreamError maps the server errors callers handle to sentinels
+func changeStreamError(err error) error {
+	var cmdErr mongo.CommandError
+	if !errors.As(err, &cmdErr) {
+		return err
+	}
+	switch cmdErr.Code {
+	case codeChangeStreamsUnsupported:
+		return ErrChangeStreamsUnsupported
+	case codeChangeHistoryLost, codeChangeStreamFatal:
+		return ErrChangeHistoryLost
+	}
+	return err
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"2026champs/internal/model"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ComparisonRepo handles MongoDB operations for reports comparing two rooms
+type ComparisonRepo interface {
+	Create(ctx context.Context, report *model.ComparisonReport) (string, error)
+	// Get returns one of the host's comparisons; nil if it does not exist or is someone else's
+	Get(ctx context.Context, hostID, id string) (*model.ComparisonReport, error)
+	// ListByHost returns the host's comparisons, newest first, of one survey when surveyID is set
+	ListByHost(ctx context.Context, hostID, surveyID string) ([]*model.ComparisonReport, error)
+	// SetResult stores the status and content of a generated comparison
+	SetResult(ctx context.Context, report *model.ComparisonReport) error
+}
+
+type comparisonRepo struct {
+	collection *mongo.Collection
+}
+
+// NewComparisonRepo creates a new comparison repository; indexes are created by migrations
+func NewComparisonRepo(db *mongo.Database) ComparisonRepo {
+	return &comparisonRepo{collection: db.Collection("comparison_reports")}
+}
+
+func (r *comparisonRepo) Create(ctx context.Context, report *model.ComparisonReport) (string, error) {
+	result, err := r.collection.InsertOne(ctx, report)
+	if err != nil {
+		return "", err
+	}
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", nil
+	}
+	return oid.Hex(), nil
+}
+
+func (r *comparisonRepo) Get(ctx context.Context, hostID, id string) (*model.ComparisonReport, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	var report model.ComparisonReport
+	err = r.collection.FindOne(ctx, bson.M{"_id": oid, "hostId": hostID}).Decode(&report)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *comparisonRepo) ListByHost(ctx context.Context, hostID, surveyID string) ([]*model.ComparisonReport, error) {
+	filter := bson.M{"hostId": hostID}
+	if surveyID != "" {
+		filter["surveyId"] = surveyID
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	reports := []*model.ComparisonReport{}
+	if err := cursor.All(ctx, &reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (r *comparisonRepo) SetResult(ctx context.Context, report *model.ComparisonReport) error {
+	oid, err := primitive.ObjectIDFromHex(report.ID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{
+		"status":             report.Status,
+		"stats":              report.Stats,
+		"themeShifts":        report.ThemeShifts,
+		"satisfactionDeltas": report.SatisfactionDeltas,
+		"newFrictionPoints":  report.NewFrictionPoints,
+		"summary":            report.Summary,
+		"verdict":            report.Verdict,
+		"recommendations":    report.Recommendations,
+		"readyAt":            report.ReadyAt,
+	}})
+	return err
+}
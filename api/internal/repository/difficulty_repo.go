@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"2026champs/internal/model"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DifficultyRepo handles MongoDB operations for survey difficulty calibrations
+type DifficultyRepo interface {
+	Upsert(ctx context.Context, report *model.DifficultyReport) error
+	Get(ctx context.Context, surveyID string) (*model.DifficultyReport, error)
+}
+
+type difficultyRepo struct {
+	collection *mongo.Collection
+}
+
+// NewDifficultyRepo creates a new difficulty repository; indexes are created by migrations
+func NewDifficultyRepo(db *mongo.Database) DifficultyRepo {
+	return &difficultyRepo{collection: db.Collection("survey_difficulty")}
+}
+
+// Upsert stores the survey's calibration, replacing the previous one
+func (r *difficultyRepo) Upsert(ctx context.Context, report *model.DifficultyReport) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"surveyId": report.SurveyID}, report, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *difficultyRepo) Get(ctx context.Context, surveyID string) (*model.DifficultyReport, error) {
+	var report model.DifficultyReport
+	err := r.collection.FindOne(ctx, bson.M{"surveyId": surveyID}).Decode(&report)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DigestRepo handles MongoDB operations for hosts' weekly digest settings
+type DigestRepo interface {
+	// Get returns the host's settings, nil if the host never set them
+	Get(ctx context.Context, hostID string) (*model.DigestSettings, error)
+	// Save stores whether the host gets digests and where they go
+	Save(ctx context.Context, settings *model.DigestSettings) error
+	ListEnabled(ctx context.Context) ([]*model.DigestSettings, error)
+	// ClaimWeek marks the week as sent for the host; false if it already was (e.g. by another instance)
+	ClaimWeek(ctx context.Context, hostID string, weekStart time.Time) (bool, error)
+	RecordSend(ctx context.Context, hostID string, at time.Time, lastError string) error
+}
+
+type digestRepo struct {
+	collection *mongo.Collection
+}
+
+// NewDigestRepo creates a new digest repository; indexes are created by migrations
+func NewDigestRepo(db *mongo.Database) DigestRepo {
+	return &digestRepo{collection: db.Collection("digest_settings")}
+}
+
+func (r *digestRepo) Get(ctx context.Context, hostID string) (*model.DigestSettings, error) {
+	var settings model.DigestSettings
+	err := r.collection.FindOne(ctx, bson.M{"_id": hostID}).Decode(&settings)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (r *digestRepo) Save(ctx context.Context, settings *model.DigestSettings) error {
+	settings.UpdatedAt = time.Now()
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": settings.HostID}, bson.M{"$set": bson.M{
+		"enabled":    settings.Enabled,
+		"recipients": settings.Recipients,
+		"updatedAt":  settings.UpdatedAt,
+	}}, options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *digestRepo) ListEnabled(ctx context.Context) ([]*model.DigestSettings, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"enabled": true})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	settings := []*model.DigestSettings{}
+	if err := cursor.All(ctx, &settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (r *digestRepo) ClaimWeek(ctx context.Context, hostID string, weekStart time.Time) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":     hostID,
+		"enabled": true,
+		"$or": bson.A{
+			bson.M{"lastWeek": bson.M{"$exists": false}},
+			bson.M{"lastWeek": bson.M{"$lt": weekStart}},
+		},
+	}, bson.M{"$set": bson.M{"lastWeek": weekStart}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *digestRepo) RecordSend(ctx context.Context, hostID string, at time.Time, lastError string) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": hostID}, bson.M{"$set": bson.M{
+		"lastSentAt": at,
+		"lastError":  lastError,
+	}})
+	return err
+}
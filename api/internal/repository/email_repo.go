@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EmailRepo handles MongoDB operations for report email deliveries
+type EmailRepo interface {
+	Create(ctx context.Context, delivery *model.EmailDelivery) (string, error)
+	ListByRoom(ctx context.Context, roomCode string) ([]*model.EmailDelivery, error)
+}
+
+type emailRepo struct {
+	collection *mongo.Collection
+}
+
+// NewEmailRepo creates a new email delivery repository
+func NewEmailRepo(db *mongo.Database) EmailRepo {
+	repo := &emailRepo{
+		collection: db.Collection("email_deliveries"),
+	}
+	repo.ensureIndexes(context.Background())
+	return repo
+}
+
+func (r *emailRepo) ensureIndexes(ctx context.Context) {
+	index := mongo.IndexModel{Keys: bson.D{{Key: "roomCode", Value: 1}, {Key: "createdAt", Value: -1}}}
+	if _, err := r.collection.Indexes().CreateOne(ctx, index); err != nil {
+		log.Printf("Warning: failed to create index on %s: %v", r.collection.Name(), err)
+	}
+}
+
+func (r *emailRepo) Create(ctx context.Context, delivery *model.EmailDelivery) (string, error) {
+	delivery.CreatedAt = time.Now()
+	result, err := r.collection.InsertOne(ctx, delivery)
+	if err != nil {
+		return "", err
+	}
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", nil
+	}
+	return oid.Hex(), nil
+}
+
+func (r *emailRepo) ListByRoom(ctx context.Context, roomCode string) ([]*model.EmailDelivery, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"roomCode": roomCode}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := []*model.EmailDelivery{}
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
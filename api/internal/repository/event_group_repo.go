@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EventGroupRepo handles MongoDB operations for multi-room events
+type EventGroupRepo interface {
+	Create(ctx context.Context, group *model.EventGroup) (string, error)
+	// Get returns one of the host's events; nil if it does not exist or is someone else's
+	Get(ctx context.Context, hostID, id string) (*model.EventGroup, error)
+	ListByHost(ctx context.Context, hostID string) ([]*model.EventGroup, error)
+	SetRooms(ctx context.Context, group *model.EventGroup) error
+	SetReport(ctx context.Context, id string, report *model.AIReport) error
+	Delete(ctx context.Context, hostID, id string) (bool, error)
+}
+
+type eventGroupRepo struct {
+	collection *mongo.Collection
+}
+
+// NewEventGroupRepo creates a new event repository; indexes are created by migrations
+func NewEventGroupRepo(db *mongo.Database) EventGroupRepo {
+	return &eventGroupRepo{
+		collection: db.Collection("event_groups"),
+	}
+}
+
+func (r *eventGroupRepo) Create(ctx context.Context, group *model.EventGroup) (string, error) {
+	group.CreatedAt = time.Now()
+	group.UpdatedAt = group.CreatedAt
+	result, err := r.collection.InsertOne(ctx, group)
+	if err != nil {
+		return "", err
+	}
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", nil
+	}
+	return oid.Hex(), nil
+}
+
+func (r *eventGroupRepo) Get(ctx context.Context, hostID, id string) (*model.EventGroup, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	var group model.EventGroup
+	err = r.collection.FindOne(ctx, bson.M{"_id": oid, "hostId": hostID}).Decode(&group)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *eventGroupRepo) ListByHost(ctx context.Context, hostID string) ([]*model.EventGroup, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"hostId": hostID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	groups := []*model.EventGroup{}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// SetRooms stores the event's room list and survey
+func (r *eventGroupRepo) SetRooms(ctx context.Context, group *model.EventGroup) error {
+	oid, err := primitive.ObjectIDFromHex(group.ID)
+	if err != nil {
+		return err
+	}
+	group.UpdatedAt = time.Now()
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid, "hostId": group.HostID}, bson.M{"$set": bson.M{
+		"roomCodes": group.RoomCodes,
+		"surveyId":  group.SurveyID,
+		"updatedAt": group.UpdatedAt,
+	}})
+	return err
+}
+
+// SetReport stores the event's merged AI report
+func (r *eventGroupRepo) SetReport(ctx context.Context, id string, report *model.AIReport) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"report": report}})
+	return err
+}
+
+// Delete removes a host's event (its rooms are kept); returns false if none matched
+func (r *eventGroupRepo) Delete(ctx context.Context, hostID, id string) (bool, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, nil
+	}
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": oid, "hostId": hostID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
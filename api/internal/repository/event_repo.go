@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"2026champs/internal/model"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EventRepo handles MongoDB operations for the persisted room event stream
+type EventRepo interface {
+	Append(ctx context.Context, events []*model.RoomEvent) error
+	ListAfter(ctx context.Context, roomCode string, afterSeq int64, limit int) ([]*model.RoomEvent, error)
+}
+
+type eventRepo struct {
+	collection *mongo.Collection
+}
+
+// NewEventRepo creates a new event repository; the capped collection and its
+// indexes are created by migrations
+func NewEventRepo(db *mongo.Database) EventRepo {
+	return &eventRepo{collection: db.Collection("room_events")}
+}
+
+func (r *eventRepo) Append(ctx context.Context, events []*model.RoomEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(events))
+	for i, e := range events {
+		docs[i] = e
+	}
+	_, err := r.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	return err
+}
+
+// ListAfter returns up to limit of a room's events with seq > afterSeq, in seq order
+func (r *eventRepo) ListAfter(ctx context.Context, roomCode string, afterSeq int64, limit int) ([]*model.RoomEvent, error) {
+	filter := bson.M{"roomCode": roomCode, "seq": bson.M{"$gt": afterSeq}}
+	opts := options.Find().SetSort(bson.D{{Key: "seq", Value: 1}}).SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []*model.RoomEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ExperimentRepo handles MongoDB operations for A/B experiments and their assignments
+type ExperimentRepo interface {
+	Create(ctx context.Context, experiment *model.Experiment) (string, error)
+	GetByID(ctx context.Context, id string) (*model.Experiment, error)
+	ListByHost(ctx context.Context, hostID string) ([]*model.Experiment, error)
+	Stop(ctx context.Context, hostID, id string, at time.Time) (bool, error)
+	Assign(ctx context.Context, assignment *model.ExperimentAssignment) error
+	GetAssignments(ctx context.Context, experimentID string) ([]*model.ExperimentAssignment, error)
+}
+
+type experimentRepo struct {
+	experiments *mongo.Collection
+	assignments *mongo.Collection
+}
+
+// NewExperimentRepo creates a new experiment repository; indexes are created by migrations
+func NewExperimentRepo(db *mongo.Database) ExperimentRepo {
+	return &experimentRepo{
+		experiments: db.Collection("experiments"),
+		assignments: db.Collection("experiment_assignments"),
+	}
+}
+
+func (r *experimentRepo) Create(ctx context.Context, experiment *model.Experiment) (string, error) {
+	experiment.CreatedAt = time.Now()
+	result, err := r.experiments.InsertOne(ctx, experiment)
+	if err != nil {
+		return "", err
+	}
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", nil
+	}
+	return oid.Hex(), nil
+}
+
+func (r *experimentRepo) GetByID(ctx context.Context, id string) (*model.Experiment, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	var experiment model.Experiment
+	err = r.experiments.FindOne(ctx, bson.M{"_id": oid}).Decode(&experiment)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &experiment, nil
+}
+
+func (r *experimentRepo) ListByHost(ctx context.Context, hostID string) ([]*model.Experiment, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := r.experiments.Find(ctx, bson.M{"hostId": hostID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	experiments := []*model.Experiment{}
+	if err := cursor.All(ctx, &experiments); err != nil {
+		return nil, err
+	}
+	return experiments, nil
+}
+
+// Stop marks a host's active experiment stopped; returns false if none matched
+func (r *experimentRepo) Stop(ctx context.Context, hostID, id string, at time.Time) (bool, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, nil
+	}
+	filter := bson.M{"_id": oid, "hostId": hostID, "status": model.ExperimentStatusActive}
+	update := bson.M{"$set": bson.M{"status": model.ExperimentStatusStopped, "stoppedAt": at}}
+	result, err := r.experiments.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// Assign records an assignment; assigning the same room or player again keeps the first variant
+func (r *experimentRepo) Assign(ctx context.Context, assignment *model.ExperimentAssignment) error {
+	filter := bson.M{
+		"experimentId": assignment.ExperimentID,
+		"roomCode":     assignment.RoomCode,
+		"playerId":     assignment.PlayerID,
+	}
+	update := bson.M{"$setOnInsert": assignment}
+	_, err := r.assignments.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *experimentRepo) GetAssignments(ctx context.Context, experimentID string) ([]*model.ExperimentAssignment, error) {
+	cursor, err := r.assignments.Find(ctx, bson.M{"experimentId": experimentID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	assignments := []*model.ExperimentAssignment{}
+	if err := cursor.All(ctx, &assignments); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
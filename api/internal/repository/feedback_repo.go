@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"2026champs/internal/model"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FeedbackRepo handles MongoDB operations for end-of-room player feedback
+type FeedbackRepo interface {
+	Upsert(ctx context.Context, feedback *model.PlayerFeedback) error
+	Get(ctx context.Context, roomCode, playerID string) (*model.PlayerFeedback, error)
+	Delete(ctx context.Context, roomCode, playerID string) (bool, error)
+}
+
+type feedbackRepo struct {
+	collection *mongo.Collection
+}
+
+// NewFeedbackRepo creates a new feedback repository; indexes are created by migrations
+func NewFeedbackRepo(db *mongo.Database) FeedbackRepo {
+	return &feedbackRepo{collection: db.Collection("player_feedback")}
+}
+
+// Upsert stores the player's feedback, replacing an earlier one for the same room
+func (r *feedbackRepo) Upsert(ctx context.Context, feedback *model.PlayerFeedback) error {
+	filter := bson.M{"roomCode": feedback.RoomCode, "playerId": feedback.PlayerID}
+	_, err := r.collection.ReplaceOne(ctx, filter, feedback, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *feedbackRepo) Get(ctx context.Context, roomCode, playerID string) (*model.PlayerFeedback, error) {
+	var feedback model.PlayerFeedback
+	err := r.collection.FindOne(ctx, bson.M{"roomCode": roomCode, "playerId": playerID}).Decode(&feedback)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &feedback, nil
+}
+
+func (r *feedbackRepo) Delete(ctx context.Context, roomCode, playerID string) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"roomCode": roomCode, "playerId": playerID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
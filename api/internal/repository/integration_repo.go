@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IntegrationRepo handles MongoDB operations for chat integrations
+type IntegrationRepo interface {
+	Create(ctx context.Context, integration *model.Integration) (string, error)
+	ListByHost(ctx context.Context, hostID string) ([]*model.Integration, error)
+	Delete(ctx context.Context, hostID, integrationID string) (bool, error)
+	RecordDelivery(ctx context.Context, integrationID string, at time.Time, deliveryErr string) error
+}
+
+type integrationRepo struct {
+	collection *mongo.Collection
+}
+
+// NewIntegrationRepo creates a new integration repository
+func NewIntegrationRepo(db *mongo.Database) IntegrationRepo {
+	repo := &integrationRepo{
+		collection: db.Collection("integrations"),
+	}
+	repo.ensureIndexes(context.Background())
+	return repo
+}
+
+func (r *integrationRepo) ensureIndexes(ctx context.Context) {
+	index := mongo.IndexModel{Keys: bson.D{{Key: "hostId", Value: 1}}}
+	if _, err := r.collection.Indexes().CreateOne(ctx, index); err != nil {
+		log.Printf("Warning: failed to create index on %s: %v", r.collection.Name(), err)
+	}
+}
+
+func (r *integrationRepo) Create(ctx context.Context, integration *model.Integration) (string, error) {
+	integration.CreatedAt = time.Now()
+	result, err := r.collection.InsertOne(ctx, integration)
+	if err != nil {
+		return "", err
+	}
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", nil
+	}
+	return oid.Hex(), nil
+}
+
+func (r *integrationRepo) ListByHost(ctx context.Context, hostID string) ([]*model.Integration, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"hostId": hostID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	integrations := []*model.Integration{}
+	if err := cursor.All(ctx, &integrations); err != nil {
+		return nil, err
+	}
+	return integrations, nil
+}
+
+// Delete removes a host's integration; returns false if none matched
+func (r *integrationRepo) Delete(ctx context.Context, hostID, integrationID string) (bool, error) {
+	oid, err := primitive.ObjectIDFromHex(integrationID)
+	if err != nil {
+		return false, nil
+	}
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": oid, "hostId": hostID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// RecordDelivery stores the outcome of the latest webhook post; an empty deliveryErr clears the last error
+func (r *integrationRepo) RecordDelivery(ctx context.Context, integrationID string, at time.Time, deliveryErr string) error {
+	oid, err := primitive.ObjectIDFromHex(integrationID)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$set": bson.M{"lastDeliveryAt": at}}
+	if deliveryErr == "" {
+		update["$unset"] = bson.M{"lastError": ""}
+	} else {
+		update["$set"] = bson.M{"lastDeliveryAt": at, "lastError": deliveryErr}
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	return err
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PlanRepo handles MongoDB operations for host plan assignments
+type PlanRepo interface {
+	// Get returns the host's plan assignment; nil if the host has none
+	Get(ctx context.Context, hostID string) (*model.HostPlan, error)
+	Set(ctx context.Context, hostID, plan string) error
+}
+
+type planRepo struct {
+	collection *mongo.Collection
+}
+
+// NewPlanRepo creates a new plan repository; documents are keyed by host ID, so no indexes are needed
+func NewPlanRepo(db *mongo.Database) PlanRepo {
+	return &planRepo{
+		collection: db.Collection("host_plans"),
+	}
+}
+
+func (r *planRepo) Get(ctx context.Context, hostID string) (*model.HostPlan, error) {
+	var plan model.HostPlan
+	err := r.collection.FindOne(ctx, bson.M{"_id": hostID}).Decode(&plan)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (r *planRepo) Set(ctx context.Context, hostID, plan string) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": hostID},
+		bson.M{"$set": bson.M{"plan": plan, "updatedAt": time.Now()}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"2026champs/internal/model"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PollRepo handles MongoDB operations for closed live polls, kept apart from survey answers
+type PollRepo interface {
+	Save(ctx context.Context, poll *model.Poll) error
+	// GetByRoom lists a room's polls in launch order
+	GetByRoom(ctx context.Context, roomCode string) ([]*model.Poll, error)
+}
+
+type pollRepo struct {
+	collection *mongo.Collection
+}
+
+// NewPollRepo creates a new poll repository; indexes are created by migrations
+func NewPollRepo(db *mongo.Database) PollRepo {
+	return &pollRepo{collection: db.Collection("polls")}
+}
+
+func (r *pollRepo) Save(ctx context.Context, poll *model.Poll) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": poll.ID}, poll, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *pollRepo) GetByRoom(ctx context.Context, roomCode string) ([]*model.Poll, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "startedAt", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"roomCode": roomCode}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	polls := []*model.Poll{}
+	if err := cursor.All(ctx, &polls); err != nil {
+		return nil, err
+	}
+	return polls, nil
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type analyticsRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewAnalyticsRepo creates a new analytics repository; profiles and room
+// memories are kept whole in JSONB columns
+func NewAnalyticsRepo(pool *pgxpool.Pool) repository.AnalyticsRepo {
+	return &analyticsRepo{pool: pool}
+}
+
+func (r *analyticsRepo) SavePlayerProfiles(ctx context.Context, profiles []*model.PlayerProfile) error {
+	if len(profiles) == 0 {
+		return nil
+	}
+	batch := &pgx.Batch{}
+	for _, p := range profiles {
+		doc, err := marshal(p)
+		if err != nil {
+			return err
+		}
+		batch.Queue(`INSERT INTO analytics_player_profiles (room_code, player_id, doc) VALUES ($1, $2, $3)
+			ON CONFLICT (room_code, player_id) DO UPDATE SET doc = EXCLUDED.doc`, p.RoomCode, p.PlayerID, doc)
+	}
+	return r.pool.SendBatch(ctx, batch).Close()
+}
+
+func (r *analyticsRepo) SaveQuestionProfiles(ctx context.Context, profiles []*model.QuestionProfile) error {
+	if len(profiles) == 0 {
+		return nil
+	}
+	batch := &pgx.Batch{}
+	for _, p := range profiles {
+		doc, err := marshal(p)
+		if err != nil {
+			return err
+		}
+		batch.Queue(`INSERT INTO analytics_question_profiles (room_code, question_key, updated_at, doc) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (room_code, question_key) DO UPDATE SET updated_at = EXCLUDED.updated_at, doc = EXCLUDED.doc`,
+			p.RoomCode, p.QuestionKey, p.UpdatedAt, doc)
+	}
+	return r.pool.SendBatch(ctx, batch).Close()
+}
+
+func (r *analyticsRepo) SaveRoomMemory(ctx context.Context, memory *model.RoomMemory) error {
+	doc, err := marshal(memory)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO analytics_room_memory (room_code, updated_at, doc) VALUES ($1, $2, $3)
+		ON CONFLICT (room_code) DO UPDATE SET updated_at = EXCLUDED.updated_at, doc = EXCLUDED.doc`,
+		memory.RoomCode, memory.UpdatedAt, doc)
+	return err
+}
+
+func (r *analyticsRepo) GetPlayerProfiles(ctx context.Context, roomCode string) ([]*model.PlayerProfile, error) {
+	return listDocs[model.PlayerProfile](ctx, r.pool,
+		`SELECT doc FROM analytics_player_profiles WHERE room_code = $1 ORDER BY player_id`, roomCode)
+}
+
+func (r *analyticsRepo) GetPlayerProfile(ctx context.Context, roomCode, playerID string) (*model.PlayerProfile, error) {
+	return getDoc[model.PlayerProfile](ctx, r.pool,
+		`SELECT doc FROM analytics_player_profiles WHERE room_code = $1 AND player_id = $2`, roomCode, playerID)
+}
+
+func (r *analyticsRepo) DeletePlayerProfile(ctx context.Context, roomCode, playerID string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM analytics_player_profiles WHERE room_code = $1 AND player_id = $2`, roomCode, playerID)
+	return err
+}
+
+func (r *analyticsRepo) GetQuestionProfiles(ctx context.Context, roomCode string) ([]*model.QuestionProfile, error) {
+	return r.GetQuestionProfilesForRooms(ctx, []string{roomCode})
+}
+
+func (r *analyticsRepo) GetRoomMemory(ctx context.Context, roomCode string) (*model.RoomMemory, error) {
+	return getDoc[model.RoomMemory](ctx, r.pool, `SELECT doc FROM analytics_room_memory WHERE room_code = $1`, roomCode)
+}
+
+func (r *analyticsRepo) GetQuestionProfilesForRooms(ctx context.Context, roomCodes []string) ([]*model.QuestionProfile, error) {
+	return listDocs[model.QuestionProfile](ctx, r.pool,
+		`SELECT doc FROM analytics_question_profiles WHERE room_code = ANY($1) ORDER BY room_code, question_key`, roomCodes)
+}
+
+func (r *analyticsRepo) GetRoomMemories(ctx context.Context, roomCodes []string) ([]*model.RoomMemory, error) {
+	return listDocs[model.RoomMemory](ctx, r.pool, `SELECT doc FROM analytics_room_memory WHERE room_code = ANY($1)`, roomCodes)
+}
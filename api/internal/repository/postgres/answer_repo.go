@@ -0,0 +1,236 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type answerRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewAnswerRepo creates a new answer repository
+func NewAnswerRepo(pool *pgxpool.Pool) repository.AnswerRepo {
+	return &answerRepo{pool: pool}
+}
+
+const insertAnswer = `INSERT INTO answers (id, room_code, player_id, question_key, client_attempt_id, created_at, doc)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+// Create inserts the answer. Answers with a clientAttemptId are unique per
+// (room_code, player_id, question_key, client_attempt_id), so concurrent
+// duplicates resolve atomically: the loser gets repository.ErrDuplicateAnswer
+// with answer replaced by the stored one.
+func (r *answerRepo) Create(ctx context.Context, answer *model.Answer) (string, error) {
+	answer.ID = newID()
+	answer.CreatedAt = time.Now()
+	answer.UpdatedAt = time.Now()
+
+	doc, err := marshal(answer)
+	if err != nil {
+		return "", err
+	}
+	args := []interface{}{answer.ID, answer.RoomCode, answer.PlayerID, answer.QuestionKey, answer.ClientAttemptID, answer.CreatedAt, doc}
+	if answer.ClientAttemptID == "" {
+		if _, err := r.pool.Exec(ctx, insertAnswer, args...); err != nil {
+			return "", err
+		}
+		return answer.ID, nil
+	}
+
+	var id string
+	err = r.pool.QueryRow(ctx, insertAnswer+` ON CONFLICT (room_code, player_id, question_key, client_attempt_id)
+		WHERE client_attempt_id <> '' DO NOTHING RETURNING id`, args...).Scan(&id)
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return "", err
+	}
+
+	stored, err := r.GetByClientAttempt(ctx, answer.RoomCode, answer.PlayerID, answer.QuestionKey, answer.ClientAttemptID)
+	if err != nil {
+		return "", err
+	}
+	if stored == nil {
+		return "", pgx.ErrNoRows
+	}
+	*answer = *stored
+	return answer.ID, repository.ErrDuplicateAnswer
+}
+
+// CreateMany inserts answers in a single batch
+func (r *answerRepo) CreateMany(ctx context.Context, answers []*model.Answer) error {
+	now := time.Now()
+	for _, a := range answers {
+		a.CreatedAt = now
+		a.UpdatedAt = now
+	}
+	return r.insert(ctx, answers)
+}
+
+// Restore inserts answers as they are, keeping their timestamps (bundle imports)
+func (r *answerRepo) Restore(ctx context.Context, answers []*model.Answer) error {
+	return r.insert(ctx, answers)
+}
+
+// insert writes answers in one transaction, giving those without an ID a new one
+func (r *answerRepo) insert(ctx context.Context, answers []*model.Answer) error {
+	if len(answers) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, a := range answers {
+		if a.ID == "" {
+			a.ID = newID()
+		}
+		doc, err := marshal(a)
+		if err != nil {
+			return err
+		}
+		batch.Queue(insertAnswer, a.ID, a.RoomCode, a.PlayerID, a.QuestionKey, a.ClientAttemptID, a.CreatedAt, doc)
+	}
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		return tx.SendBatch(ctx, batch).Close()
+	})
+}
+
+func (r *answerRepo) GetByID(ctx context.Context, id string) (*model.Answer, error) {
+	if err := parseID(id); err != nil {
+		return nil, err
+	}
+	return getDoc[model.Answer](ctx, r.pool, `SELECT doc FROM answers WHERE id = $1`, id)
+}
+
+func (r *answerRepo) GetByRoomCode(ctx context.Context, roomCode string) ([]*model.Answer, error) {
+	return listDocs[model.Answer](ctx, r.pool, `SELECT doc FROM answers WHERE room_code = $1 ORDER BY created_at, id`, roomCode)
+}
+
+// GetByRoomCodes returns every answer in the given rooms
+func (r *answerRepo) GetByRoomCodes(ctx context.Context, roomCodes []string) ([]*model.Answer, error) {
+	if len(roomCodes) == 0 {
+		return []*model.Answer{}, nil
+	}
+	return listDocs[model.Answer](ctx, r.pool, `SELECT doc FROM answers WHERE room_code = ANY($1) ORDER BY created_at, id`, roomCodes)
+}
+
+// GetReviewed returns answers in the given rooms that a human overrode or audited
+func (r *answerRepo) GetReviewed(ctx context.Context, roomCodes []string) ([]*model.Answer, error) {
+	if len(roomCodes) == 0 {
+		return []*model.Answer{}, nil
+	}
+	return listDocs[model.Answer](ctx, r.pool,
+		`SELECT doc FROM answers WHERE room_code = ANY($1) AND (doc ? 'override' OR doc ? 'audit') ORDER BY created_at, id`, roomCodes)
+}
+
+func (r *answerRepo) DeleteByRoomAndPlayer(ctx context.Context, roomCode, playerID string) (int64, error) {
+	return exec(ctx, r.pool, `DELETE FROM answers WHERE room_code = $1 AND player_id = $2`, roomCode, playerID)
+}
+
+func (r *answerRepo) DeleteByIDs(ctx context.Context, ids []string) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	return exec(ctx, r.pool, `DELETE FROM answers WHERE id = ANY($1)`, ids)
+}
+
+func (r *answerRepo) GetByRoomAndPlayer(ctx context.Context, roomCode, playerID string) ([]*model.Answer, error) {
+	return listDocs[model.Answer](ctx, r.pool,
+		`SELECT doc FROM answers WHERE room_code = $1 AND player_id = $2 ORDER BY created_at, id`, roomCode, playerID)
+}
+
+func (r *answerRepo) GetByRoomAndQuestion(ctx context.Context, roomCode, questionKey string) ([]*model.Answer, error) {
+	return listDocs[model.Answer](ctx, r.pool,
+		`SELECT doc FROM answers WHERE room_code = $1 AND question_key = $2 ORDER BY created_at, id`, roomCode, questionKey)
+}
+
+func (r *answerRepo) Update(ctx context.Context, answer *model.Answer) error {
+	if err := parseID(answer.ID); err != nil {
+		return err
+	}
+
+	answer.UpdatedAt = time.Now()
+	set := patch{
+		"roomCode":        answer.RoomCode,
+		"playerId":        answer.PlayerID,
+		"questionKey":     answer.QuestionKey,
+		"clientAttemptId": answer.ClientAttemptID,
+		"textAnswer":      answer.TextAnswer,
+		"degreeValue":     answer.DegreeValue,
+		"optionIndex":     answer.OptionIndex,
+		"status":          answer.Status,
+		"resolution":      answer.Resolution,
+		"tries":           answer.Tries,
+		"pointsEarned":    answer.PointsEarned,
+		"signals":         answer.Signals,
+		"evalSummary":     answer.EvalSummary,
+		"qualityScore":    answer.QualityScore,
+		"updatedAt":       answer.UpdatedAt,
+	}
+
+	// Review fields are only written once present, GetReviewed matches on their existence
+	if answer.Override != nil {
+		set["override"] = answer.Override
+	}
+	if answer.Audit != nil {
+		set["audit"] = answer.Audit
+	}
+
+	doc, err := set.json()
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `UPDATE answers SET room_code = $2, player_id = $3, question_key = $4, client_attempt_id = $5,
+		doc = doc || $6::jsonb WHERE id = $1`,
+		answer.ID, answer.RoomCode, answer.PlayerID, answer.QuestionKey, answer.ClientAttemptID, doc)
+	return err
+}
+
+// SetStar stars an answer, or removes its star when star is nil
+func (r *answerRepo) SetStar(ctx context.Context, id string, star *model.AnswerStar) error {
+	if err := parseID(id); err != nil {
+		return err
+	}
+	if star == nil {
+		_, err := r.pool.Exec(ctx, `UPDATE answers SET doc = doc - 'star' WHERE id = $1`, id)
+		return err
+	}
+	doc, err := patch{"star": star}.json()
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `UPDATE answers SET doc = doc || $2::jsonb WHERE id = $1`, id, doc)
+	return err
+}
+
+// SetReplay stores the response sent for the answer's submission
+func (r *answerRepo) SetReplay(ctx context.Context, id string, resp *model.SubmitAnswerResponse) error {
+	if err := parseID(id); err != nil {
+		return err
+	}
+	doc, err := patch{"replay": resp}.json()
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `UPDATE answers SET doc = doc || $2::jsonb WHERE id = $1`, id, doc)
+	return err
+}
+
+// GetStarred returns a room's host-starred answers, oldest star first
+func (r *answerRepo) GetStarred(ctx context.Context, roomCode string) ([]*model.Answer, error) {
+	return listDocs[model.Answer](ctx, r.pool, `SELECT doc FROM answers WHERE room_code = $1 AND doc ? 'star'
+		ORDER BY (doc #>> '{star,starredAt}')::timestamptz`, roomCode)
+}
+
+func (r *answerRepo) GetByClientAttempt(ctx context.Context, roomCode, playerID, questionKey, clientAttemptID string) (*model.Answer, error) {
+	return getDoc[model.Answer](ctx, r.pool, `SELECT doc FROM answers
+		WHERE room_code = $1 AND player_id = $2 AND question_key = $3 AND client_attempt_id = $4`,
+		roomCode, playerID, questionKey, clientAttemptID)
+}
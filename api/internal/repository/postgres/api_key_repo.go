@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type apiKeyRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewAPIKeyRepo creates a new API key repository. The key hash is never
+// serialized, so it lives in its own column.
+func NewAPIKeyRepo(pool *pgxpool.Pool) repository.APIKeyRepo {
+	return &apiKeyRepo{pool: pool}
+}
+
+func (r *apiKeyRepo) Create(ctx context.Context, key *model.APIKey) (string, error) {
+	key.ID = newID()
+	key.CreatedAt = time.Now()
+	doc, err := marshal(key)
+	if err != nil {
+		return "", err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO api_keys (id, host_id, hash, created_at, doc) VALUES ($1, $2, $3, $4, $5)`,
+		key.ID, key.HostID, key.Hash, key.CreatedAt, doc)
+	if err != nil {
+		return "", err
+	}
+	return key.ID, nil
+}
+
+func (r *apiKeyRepo) GetByHash(ctx context.Context, hash string) (*model.APIKey, error) {
+	key, err := getDoc[model.APIKey](ctx, r.pool, `SELECT doc FROM api_keys WHERE hash = $1`, hash)
+	if key != nil {
+		key.Hash = hash
+	}
+	return key, err
+}
+
+func (r *apiKeyRepo) ListByHost(ctx context.Context, hostID string) ([]*model.APIKey, error) {
+	return listDocs[model.APIKey](ctx, r.pool, `SELECT doc FROM api_keys WHERE host_id = $1 ORDER BY created_at DESC`, hostID)
+}
+
+// Revoke marks a host's key as revoked; returns false if no active key matched
+func (r *apiKeyRepo) Revoke(ctx context.Context, hostID, keyID string) (bool, error) {
+	if parseID(keyID) != nil {
+		return false, nil
+	}
+	set, err := patch{"revokedAt": time.Now()}.json()
+	if err != nil {
+		return false, err
+	}
+	n, err := exec(ctx, r.pool, `UPDATE api_keys SET doc = doc || $3::jsonb
+		WHERE id = $1 AND host_id = $2 AND NOT doc ? 'revokedAt'`, keyID, hostID, set)
+	return n > 0, err
+}
+
+func (r *apiKeyRepo) TouchLastUsed(ctx context.Context, keyID string, at time.Time) error {
+	if err := parseID(keyID); err != nil {
+		return err
+	}
+	set, err := patch{"lastUsedAt": at}.json()
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `UPDATE api_keys SET doc = doc || $2::jsonb WHERE id = $1`, keyID, set)
+	return err
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type attachmentRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewAttachmentRepo creates a new attachment repository
+func NewAttachmentRepo(pool *pgxpool.Pool) repository.AttachmentRepo {
+	return &attachmentRepo{pool: pool}
+}
+
+func (r *attachmentRepo) Create(ctx context.Context, attachment *model.Attachment) error {
+	attachment.CreatedAt = time.Now()
+	doc, err := marshal(attachment)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO attachments (id, room_code, player_id, question_key, storage_key, created_at, doc)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		attachment.ID, attachment.RoomCode, attachment.PlayerID, attachment.QuestionKey, attachment.StorageKey, attachment.CreatedAt, doc)
+	return err
+}
+
+func (r *attachmentRepo) GetByID(ctx context.Context, id string) (*model.Attachment, error) {
+	attachments, err := r.list(ctx, `SELECT doc, storage_key FROM attachments WHERE id = $1`, id)
+	if err != nil || len(attachments) == 0 {
+		return nil, err
+	}
+	return attachments[0], nil
+}
+
+func (r *attachmentRepo) ListByRoom(ctx context.Context, roomCode string) ([]*model.Attachment, error) {
+	return r.list(ctx, `SELECT doc, storage_key FROM attachments WHERE room_code = $1 ORDER BY created_at`, roomCode)
+}
+
+func (r *attachmentRepo) CountByQuestion(ctx context.Context, roomCode, playerID, questionKey string) (int64, error) {
+	var n int64
+	err := r.pool.QueryRow(ctx, `SELECT count(*) FROM attachments WHERE room_code = $1 AND player_id = $2 AND question_key = $3`,
+		roomCode, playerID, questionKey).Scan(&n)
+	return n, err
+}
+
+// ListByPlayer returns a player's uploads in a room, oldest first
+func (r *attachmentRepo) ListByPlayer(ctx context.Context, roomCode, playerID string) ([]*model.Attachment, error) {
+	return r.list(ctx, `SELECT doc, storage_key FROM attachments WHERE room_code = $1 AND player_id = $2 ORDER BY created_at`, roomCode, playerID)
+}
+
+func (r *attachmentRepo) ListOlderThan(ctx context.Context, cutoff time.Time) ([]*model.Attachment, error) {
+	return r.list(ctx, `SELECT doc, storage_key FROM attachments WHERE created_at < $1 ORDER BY created_at`, cutoff)
+}
+
+func (r *attachmentRepo) DeleteByPlayer(ctx context.Context, roomCode, playerID string) (int64, error) {
+	return exec(ctx, r.pool, `DELETE FROM attachments WHERE room_code = $1 AND player_id = $2`, roomCode, playerID)
+}
+
+func (r *attachmentRepo) DeleteByRoom(ctx context.Context, roomCode string) (int64, error) {
+	return exec(ctx, r.pool, `DELETE FROM attachments WHERE room_code = $1`, roomCode)
+}
+
+// list decodes rows of (doc, storage_key); the storage key is not part of the document
+func (r *attachmentRepo) list(ctx context.Context, sql string, args ...interface{}) ([]*model.Attachment, error) {
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := []*model.Attachment{}
+	for rows.Next() {
+		var raw []byte
+		var attachment model.Attachment
+		if err := rows.Scan(&raw, &attachment.StorageKey); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &attachment); err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, &attachment)
+	}
+	return attachments, rows.Err()
+}
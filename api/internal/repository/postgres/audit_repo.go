@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type auditRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewAuditRepo creates a new audit repository
+func NewAuditRepo(pool *pgxpool.Pool) repository.AuditRepo {
+	return &auditRepo{pool: pool}
+}
+
+func (r *auditRepo) Append(ctx context.Context, entry *model.AuditEntry) error {
+	stored := *entry
+	if stored.ID == "" {
+		stored.ID = newID()
+	}
+	doc, err := marshal(&stored)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO room_audit (id, room_code, at, doc) VALUES ($1, $2, $3, $4)`,
+		stored.ID, stored.RoomCode, stored.At, doc)
+	return err
+}
+
+// GetByRoom returns a room's audit trail, oldest first
+func (r *auditRepo) GetByRoom(ctx context.Context, roomCode string) ([]*model.AuditEntry, error) {
+	return listDocs[model.AuditEntry](ctx, r.pool, `SELECT doc FROM room_audit WHERE room_code = $1 ORDER BY at, id`, roomCode)
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type comparisonRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewComparisonRepo creates a new comparison repository
+func NewComparisonRepo(pool *pgxpool.Pool) repository.ComparisonRepo {
+	return &comparisonRepo{pool: pool}
+}
+
+func (r *comparisonRepo) Create(ctx context.Context, report *model.ComparisonReport) (string, error) {
+	report.ID = newID()
+	doc, err := marshal(report)
+	if err != nil {
+		return "", err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO comparison_reports (id, host_id, survey_id, created_at, doc) VALUES ($1, $2, $3, $4, $5)`,
+		report.ID, report.HostID, report.SurveyID, report.CreatedAt, doc)
+	if err != nil {
+		return "", err
+	}
+	return report.ID, nil
+}
+
+func (r *comparisonRepo) Get(ctx context.Context, hostID, id string) (*model.ComparisonReport, error) {
+	if parseID(id) != nil {
+		return nil, nil
+	}
+	return getDoc[model.ComparisonReport](ctx, r.pool, `SELECT doc FROM comparison_reports WHERE id = $1 AND host_id = $2`, id, hostID)
+}
+
+func (r *comparisonRepo) ListByHost(ctx context.Context, hostID, surveyID string) ([]*model.ComparisonReport, error) {
+	return listDocs[model.ComparisonReport](ctx, r.pool, `SELECT doc FROM comparison_reports
+		WHERE host_id = $1 AND ($2 = '' OR survey_id = $2) ORDER BY created_at DESC`, hostID, surveyID)
+}
+
+func (r *comparisonRepo) SetResult(ctx context.Context, report *model.ComparisonReport) error {
+	if err := parseID(report.ID); err != nil {
+		return err
+	}
+	set, err := patch{
+		"status":             report.Status,
+		"stats":              report.Stats,
+		"themeShifts":        report.ThemeShifts,
+		"satisfactionDeltas": report.SatisfactionDeltas,
+		"newFrictionPoints":  report.NewFrictionPoints,
+		"summary":            report.Summary,
+		"verdict":            report.Verdict,
+		"recommendations":    report.Recommendations,
+		"readyAt":            report.ReadyAt,
+	}.json()
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `UPDATE comparison_reports SET doc = doc || $2::jsonb WHERE id = $1`, report.ID, set)
+	return err
+}
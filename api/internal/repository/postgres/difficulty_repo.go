@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type difficultyRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewDifficultyRepo creates a new difficulty repository
+func NewDifficultyRepo(pool *pgxpool.Pool) repository.DifficultyRepo {
+	return &difficultyRepo{pool: pool}
+}
+
+// Upsert stores the survey's calibration, replacing the previous one
+func (r *difficultyRepo) Upsert(ctx context.Context, report *model.DifficultyReport) error {
+	doc, err := marshal(report)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO survey_difficulty (survey_id, doc) VALUES ($1, $2)
+		ON CONFLICT (survey_id) DO UPDATE SET doc = EXCLUDED.doc`, report.SurveyID, doc)
+	return err
+}
+
+func (r *difficultyRepo) Get(ctx context.Context, surveyID string) (*model.DifficultyReport, error) {
+	return getDoc[model.DifficultyReport](ctx, r.pool, `SELECT doc FROM survey_difficulty WHERE survey_id = $1`, surveyID)
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type digestRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewDigestRepo creates a new digest repository
+func NewDigestRepo(pool *pgxpool.Pool) repository.DigestRepo {
+	return &digestRepo{pool: pool}
+}
+
+func (r *digestRepo) Get(ctx context.Context, hostID string) (*model.DigestSettings, error) {
+	return getDoc[model.DigestSettings](ctx, r.pool, `SELECT doc FROM digest_settings WHERE host_id = $1`, hostID)
+}
+
+func (r *digestRepo) Save(ctx context.Context, settings *model.DigestSettings) error {
+	settings.UpdatedAt = time.Now()
+	set := patch{
+		"enabled":    settings.Enabled,
+		"recipients": settings.Recipients,
+		"updatedAt":  settings.UpdatedAt,
+	}
+	update, err := set.json()
+	if err != nil {
+		return err
+	}
+	set["hostId"] = settings.HostID
+	insert, err := set.json()
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO digest_settings (host_id, enabled, doc) VALUES ($1, $2, $3)
+		ON CONFLICT (host_id) DO UPDATE SET enabled = EXCLUDED.enabled, doc = digest_settings.doc || $4::jsonb`,
+		settings.HostID, settings.Enabled, insert, update)
+	return err
+}
+
+func (r *digestRepo) ListEnabled(ctx context.Context) ([]*model.DigestSettings, error) {
+	return listDocs[model.DigestSettings](ctx, r.pool, `SELECT doc FROM digest_settings WHERE enabled ORDER BY host_id`)
+}
+
+func (r *digestRepo) ClaimWeek(ctx context.Context, hostID string, weekStart time.Time) (bool, error) {
+	set, err := patch{"lastWeek": weekStart}.json()
+	if err != nil {
+		return false, err
+	}
+	n, err := exec(ctx, r.pool, `UPDATE digest_settings SET last_week = $2, doc = doc || $3::jsonb
+		WHERE host_id = $1 AND enabled AND (last_week IS NULL OR last_week < $2)`, hostID, weekStart, set)
+	return n > 0, err
+}
+
+func (r *digestRepo) RecordSend(ctx context.Context, hostID string, at time.Time, lastError string) error {
+	set, err := patch{"lastSentAt": at, "lastError": lastError}.json()
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `UPDATE digest_settings SET doc = doc || $2::jsonb WHERE host_id = $1`, hostID, set)
+	return err
+}
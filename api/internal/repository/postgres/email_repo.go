@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type emailRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewEmailRepo creates a new email delivery repository
+func NewEmailRepo(pool *pgxpool.Pool) repository.EmailRepo {
+	return &emailRepo{pool: pool}
+}
+
+func (r *emailRepo) Create(ctx context.Context, delivery *model.EmailDelivery) (string, error) {
+	delivery.ID = newID()
+	delivery.CreatedAt = time.Now()
+	doc, err := marshal(delivery)
+	if err != nil {
+		return "", err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO email_deliveries (id, room_code, created_at, doc) VALUES ($1, $2, $3, $4)`,
+		delivery.ID, delivery.RoomCode, delivery.CreatedAt, doc)
+	if err != nil {
+		return "", err
+	}
+	return delivery.ID, nil
+}
+
+func (r *emailRepo) ListByRoom(ctx context.Context, roomCode string) ([]*model.EmailDelivery, error) {
+	return listDocs[model.EmailDelivery](ctx, r.pool,
+		`SELECT doc FROM email_deliveries WHERE room_code = $1 ORDER BY created_at DESC`, roomCode)
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type eventGroupRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewEventGroupRepo creates a new event repository
+func NewEventGroupRepo(pool *pgxpool.Pool) repository.EventGroupRepo {
+	return &eventGroupRepo{pool: pool}
+}
+
+func (r *eventGroupRepo) Create(ctx context.Context, group *model.EventGroup) (string, error) {
+	group.ID = newID()
+	group.CreatedAt = time.Now()
+	group.UpdatedAt = group.CreatedAt
+	doc, err := marshal(group)
+	if err != nil {
+		return "", err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO event_groups (id, host_id, created_at, doc) VALUES ($1, $2, $3, $4)`,
+		group.ID, group.HostID, group.CreatedAt, doc)
+	if err != nil {
+		return "", err
+	}
+	return group.ID, nil
+}
+
+func (r *eventGroupRepo) Get(ctx context.Context, hostID, id string) (*model.EventGroup, error) {
+	if parseID(id) != nil {
+		return nil, nil
+	}
+	return getDoc[model.EventGroup](ctx, r.pool, `SELECT doc FROM event_groups WHERE id = $1 AND host_id = $2`, id, hostID)
+}
+
+func (r *eventGroupRepo) ListByHost(ctx context.Context, hostID string) ([]*model.EventGroup, error) {
+	return listDocs[model.EventGroup](ctx, r.pool, `SELECT doc FROM event_groups WHERE host_id = $1 ORDER BY created_at DESC`, hostID)
+}
+
+// SetRooms stores the event's room list and survey
+func (r *eventGroupRepo) SetRooms(ctx context.Context, group *model.EventGroup) error {
+	if err := parseID(group.ID); err != nil {
+		return err
+	}
+	group.UpdatedAt = time.Now()
+	set, err := patch{
+		"roomCodes": group.RoomCodes,
+		"surveyId":  group.SurveyID,
+		"updatedAt": group.UpdatedAt,
+	}.json()
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `UPDATE event_groups SET doc = doc || $3::jsonb WHERE id = $1 AND host_id = $2`, group.ID, group.HostID, set)
+	return err
+}
+
+// SetReport stores the event's merged AI report
+func (r *eventGroupRepo) SetReport(ctx context.Context, id string, report *model.AIReport) error {
+	if err := parseID(id); err != nil {
+		return err
+	}
+	set, err := patch{"report": report}.json()
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `UPDATE event_groups SET doc = doc || $2::jsonb WHERE id = $1`, id, set)
+	return err
+}
+
+// Delete removes a host's event (its rooms are kept); returns false if none matched
+func (r *eventGroupRepo) Delete(ctx context.Context, hostID, id string) (bool, error) {
+	if parseID(id) != nil {
+		return false, nil
+	}
+	n, err := exec(ctx, r.pool, `DELETE FROM event_groups WHERE id = $1 AND host_id = $2`, id, hostID)
+	return n > 0, err
+}
@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type eventRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewEventRepo creates a new event repository. Unlike the capped Mongo
+// collection, the table is not bounded; the retention purge does not cover it.
+func NewEventRepo(pool *pgxpool.Pool) repository.EventRepo {
+	return &eventRepo{pool: pool}
+}
+
+func (r *eventRepo) Append(ctx context.Context, events []*model.RoomEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	batch := &pgx.Batch{}
+	for _, e := range events {
+		doc, err := marshal(e)
+		if err != nil {
+			return err
+		}
+		batch.Queue(`INSERT INTO room_events (room_code, seq, doc) VALUES ($1, $2, $3)`, e.RoomCode, e.Seq, doc)
+	}
+	return r.pool.SendBatch(ctx, batch).Close()
+}
+
+// ListAfter returns up to limit of a room's events with seq > afterSeq, in seq order
+func (r *eventRepo) ListAfter(ctx context.Context, roomCode string, afterSeq int64, limit int) ([]*model.RoomEvent, error) {
+	return listDocs[model.RoomEvent](ctx, r.pool,
+		`SELECT doc FROM room_events WHERE room_code = $1 AND seq > $2 ORDER BY seq LIMIT $3`, roomCode, afterSeq, limit)
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type experimentRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewExperimentRepo creates a new experiment repository
+func NewExperimentRepo(pool *pgxpool.Pool) repository.ExperimentRepo {
+	return &experimentRepo{pool: pool}
+}
+
+func (r *experimentRepo) Create(ctx context.Context, experiment *model.Experiment) (string, error) {
+	experiment.ID = newID()
+	experiment.CreatedAt = time.Now()
+	doc, err := marshal(experiment)
+	if err != nil {
+		return "", err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO experiments (id, host_id, status, created_at, doc) VALUES ($1, $2, $3, $4, $5)`,
+		experiment.ID, experiment.HostID, experiment.Status, experiment.CreatedAt, doc)
+	if err != nil {
+		return "", err
+	}
+	return experiment.ID, nil
+}
+
+func (r *experimentRepo) GetByID(ctx context.Context, id string) (*model.Experiment, error) {
+	if parseID(id) != nil {
+		return nil, nil
+	}
+	return getDoc[model.Experiment](ctx, r.pool, `SELECT doc FROM experiments WHERE id = $1`, id)
+}
+
+func (r *experimentRepo) ListByHost(ctx context.Context, hostID string) ([]*model.Experiment, error) {
+	return listDocs[model.Experiment](ctx, r.pool, `SELECT doc FROM experiments WHERE host_id = $1 ORDER BY created_at DESC`, hostID)
+}
+
+// Stop marks a host's active experiment stopped; returns false if none matched
+func (r *experimentRepo) Stop(ctx context.Context, hostID, id string, at time.Time) (bool, error) {
+	if parseID(id) != nil {
+		return false, nil
+	}
+	set, err := patch{"status": model.ExperimentStatusStopped, "stoppedAt": at}.json()
+	if err != nil {
+		return false, err
+	}
+	n, err := exec(ctx, r.pool, `UPDATE experiments SET status = $4, doc = doc || $5::jsonb
+		WHERE id = $1 AND host_id = $2 AND status = $3`,
+		id, hostID, model.ExperimentStatusActive, model.ExperimentStatusStopped, set)
+	return n > 0, err
+}
+
+// Assign records an assignment; assigning the same room or player again keeps the first variant
+func (r *experimentRepo) Assign(ctx context.Context, assignment *model.ExperimentAssignment) error {
+	doc, err := marshal(assignment)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO experiment_assignments (experiment_id, room_code, player_id, doc) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (experiment_id, room_code, player_id) DO NOTHING`,
+		assignment.ExperimentID, assignment.RoomCode, assignment.PlayerID, doc)
+	return err
+}
+
+func (r *experimentRepo) GetAssignments(ctx context.Context, experimentID string) ([]*model.ExperimentAssignment, error) {
+	return listDocs[model.ExperimentAssignment](ctx, r.pool,
+		`SELECT doc FROM experiment_assignments WHERE experiment_id = $1 ORDER BY room_code, player_id`, experimentID)
+}
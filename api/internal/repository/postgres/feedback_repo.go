@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type feedbackRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewFeedbackRepo creates a new feedback repository
+func NewFeedbackRepo(pool *pgxpool.Pool) repository.FeedbackRepo {
+	return &feedbackRepo{pool: pool}
+}
+
+// Upsert stores the player's feedback, replacing an earlier one for the same room
+func (r *feedbackRepo) Upsert(ctx context.Context, feedback *model.PlayerFeedback) error {
+	doc, err := marshal(feedback)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO player_feedback (room_code, player_id, created_at, doc) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (room_code, player_id) DO UPDATE SET created_at = EXCLUDED.created_at, doc = EXCLUDED.doc`,
+		feedback.RoomCode, feedback.PlayerID, feedback.CreatedAt, doc)
+	return err
+}
+
+func (r *feedbackRepo) Get(ctx context.Context, roomCode, playerID string) (*model.PlayerFeedback, error) {
+	return getDoc[model.PlayerFeedback](ctx, r.pool,
+		`SELECT doc FROM player_feedback WHERE room_code = $1 AND player_id = $2`, roomCode, playerID)
+}
+
+func (r *feedbackRepo) Delete(ctx context.Context, roomCode, playerID string) (bool, error) {
+	n, err := exec(ctx, r.pool, `DELETE FROM player_feedback WHERE room_code = $1 AND player_id = $2`, roomCode, playerID)
+	return n > 0, err
+}
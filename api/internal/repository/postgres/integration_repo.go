@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type integrationRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewIntegrationRepo creates a new integration repository. Webhook URLs are
+// secrets that are never serialized, so they live in their own column.
+func NewIntegrationRepo(pool *pgxpool.Pool) repository.IntegrationRepo {
+	return &integrationRepo{pool: pool}
+}
+
+func (r *integrationRepo) Create(ctx context.Context, integration *model.Integration) (string, error) {
+	integration.ID = newID()
+	integration.CreatedAt = time.Now()
+	doc, err := marshal(integration)
+	if err != nil {
+		return "", err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO integrations (id, host_id, webhook_url, created_at, doc) VALUES ($1, $2, $3, $4, $5)`,
+		integration.ID, integration.HostID, integration.WebhookURL, integration.CreatedAt, doc)
+	if err != nil {
+		return "", err
+	}
+	return integration.ID, nil
+}
+
+func (r *integrationRepo) ListByHost(ctx context.Context, hostID string) ([]*model.Integration, error) {
+	rows, err := r.pool.Query(ctx, `SELECT doc, webhook_url FROM integrations WHERE host_id = $1 ORDER BY created_at DESC`, hostID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	integrations := []*model.Integration{}
+	for rows.Next() {
+		var raw []byte
+		var integration model.Integration
+		if err := rows.Scan(&raw, &integration.WebhookURL); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &integration); err != nil {
+			return nil, err
+		}
+		integrations = append(integrations, &integration)
+	}
+	return integrations, rows.Err()
+}
+
+// Delete removes a host's integration; returns false if none matched
+func (r *integrationRepo) Delete(ctx context.Context, hostID, integrationID string) (bool, error) {
+	if parseID(integrationID) != nil {
+		return false, nil
+	}
+	n, err := exec(ctx, r.pool, `DELETE FROM integrations WHERE id = $1 AND host_id = $2`, integrationID, hostID)
+	return n > 0, err
+}
+
+// RecordDelivery stores the outcome of the latest webhook post; an empty deliveryErr clears the last error
+func (r *integrationRepo) RecordDelivery(ctx context.Context, integrationID string, at time.Time, deliveryErr string) error {
+	if err := parseID(integrationID); err != nil {
+		return err
+	}
+	set := patch{"lastDeliveryAt": at}
+	unset := []string{"lastError"}
+	if deliveryErr != "" {
+		set["lastError"] = deliveryErr
+		unset = []string{}
+	}
+	doc, err := set.json()
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `UPDATE integrations SET doc = (doc || $2::jsonb) - $3::text[] WHERE id = $1`, integrationID, doc, unset)
+	return err
+}
@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type planRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewPlanRepo creates a new plan repository
+func NewPlanRepo(pool *pgxpool.Pool) repository.PlanRepo {
+	return &planRepo{pool: pool}
+}
+
+func (r *planRepo) Get(ctx context.Context, hostID string) (*model.HostPlan, error) {
+	return getDoc[model.HostPlan](ctx, r.pool, `SELECT doc FROM host_plans WHERE host_id = $1`, hostID)
+}
+
+func (r *planRepo) Set(ctx context.Context, hostID, plan string) error {
+	doc, err := marshal(&model.HostPlan{HostID: hostID, Plan: plan, UpdatedAt: time.Now()})
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO host_plans (host_id, doc) VALUES ($1, $2)
+		ON CONFLICT (host_id) DO UPDATE SET doc = EXCLUDED.doc`, hostID, doc)
+	return err
+}
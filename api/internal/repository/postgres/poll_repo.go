@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pollRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewPollRepo creates a new poll repository
+func NewPollRepo(pool *pgxpool.Pool) repository.PollRepo {
+	return &pollRepo{pool: pool}
+}
+
+func (r *pollRepo) Save(ctx context.Context, poll *model.Poll) error {
+	doc, err := marshal(poll)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO polls (id, room_code, started_at, doc) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET room_code = EXCLUDED.room_code, started_at = EXCLUDED.started_at, doc = EXCLUDED.doc`,
+		poll.ID, poll.RoomCode, poll.StartedAt, doc)
+	return err
+}
+
+func (r *pollRepo) GetByRoom(ctx context.Context, roomCode string) ([]*model.Poll, error) {
+	return listDocs[model.Poll](ctx, r.pool, `SELECT doc FROM polls WHERE room_code = $1 ORDER BY started_at`, roomCode)
+}
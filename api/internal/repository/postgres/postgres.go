@@ -0,0 +1,101 @@
+// Package postgres implements the repository interfaces on PostgreSQL. Every
+// table keeps the columns its queries filter and sort on next to the whole
+// model as a JSONB document (in its JSON form), so nested data such as signals
+// and profiles is stored as is. Tables and indexes are created by the
+// PostgreSQL migrations in package migrations.
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Connect opens a connection pool and pings the server
+func Connect(ctx context.Context, uri string) (*pgxpool.Pool, error) {
+	pool, err := pgxpool.New(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return pool, nil
+}
+
+// newID returns a new ObjectID in hex, so IDs look the same on either backend
+func newID() string {
+	return primitive.NewObjectID().Hex()
+}
+
+// parseID rejects IDs the Mongo repositories would fail to parse
+func parseID(id string) error {
+	_, err := primitive.ObjectIDFromHex(id)
+	return err
+}
+
+// marshal encodes a model as its JSONB document
+func marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// patch is the JSON object merged into a document by a partial update
+type patch map[string]interface{}
+
+func (p patch) json() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// getDoc decodes the document in the first column of the query's first row; nil when there is no row
+func getDoc[T any](ctx context.Context, pool *pgxpool.Pool, sql string, args ...interface{}) (*T, error) {
+	var raw []byte
+	err := pool.QueryRow(ctx, sql, args...).Scan(&raw)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var v T
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// listDocs decodes the document in the first column of every row
+func listDocs[T any](ctx context.Context, pool *pgxpool.Pool, sql string, args ...interface{}) ([]*T, error) {
+	rows, err := pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	docs := []*T{}
+	for rows.Next() {
+		var raw []byte
+		if err := rows.Scan(&raw); err != nil {
+			return nil, err
+		}
+		var v T
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, err
+		}
+		docs = append(docs, &v)
+	}
+	return docs, rows.Err()
+}
+
+// exec runs a write and returns how many rows it touched
+func exec(ctx context.Context, pool *pgxpool.Pool, sql string, args ...interface{}) (int64, error) {
+	tag, err := pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type reportRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewReportRepo creates a new report repository
+func NewReportRepo(pool *pgxpool.Pool) repository.ReportRepo {
+	return &reportRepo{pool: pool}
+}
+
+func (r *reportRepo) SaveSnapshot(ctx context.Context, snapshot *model.RoomSnapshot) error {
+	doc, err := marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO room_snapshots (room_code, ended_at, doc) VALUES ($1, $2, $3)
+		ON CONFLICT (room_code) DO UPDATE SET ended_at = EXCLUDED.ended_at, doc = EXCLUDED.doc`,
+		snapshot.RoomCode, snapshot.EndedAt, doc)
+	return err
+}
+
+func (r *reportRepo) GetSnapshot(ctx context.Context, roomCode string) (*model.RoomSnapshot, error) {
+	return getDoc[model.RoomSnapshot](ctx, r.pool, `SELECT doc FROM room_snapshots WHERE room_code = $1`, roomCode)
+}
+
+func (r *reportRepo) SaveAIReport(ctx context.Context, report *model.AIReport) error {
+	doc, err := marshal(report)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO ai_reports (room_code, created_at, doc) VALUES ($1, $2, $3)
+		ON CONFLICT (room_code) DO UPDATE SET created_at = EXCLUDED.created_at, doc = EXCLUDED.doc`,
+		report.RoomCode, report.CreatedAt, doc)
+	return err
+}
+
+func (r *reportRepo) GetAIReport(ctx context.Context, roomCode string) (*model.AIReport, error) {
+	return getDoc[model.AIReport](ctx, r.pool, `SELECT doc FROM ai_reports WHERE room_code = $1`, roomCode)
+}
+
+// GetSnapshots returns the snapshots of the given rooms that have one
+func (r *reportRepo) GetSnapshots(ctx context.Context, roomCodes []string) ([]*model.RoomSnapshot, error) {
+	if len(roomCodes) == 0 {
+		return []*model.RoomSnapshot{}, nil
+	}
+	return listDocs[model.RoomSnapshot](ctx, r.pool, `SELECT doc FROM room_snapshots WHERE room_code = ANY($1)`, roomCodes)
+}
+
+// GetAIReports returns the AI reports of the given rooms that have one
+func (r *reportRepo) GetAIReports(ctx context.Context, roomCodes []string) ([]*model.AIReport, error) {
+	if len(roomCodes) == 0 {
+		return []*model.AIReport{}, nil
+	}
+	return listDocs[model.AIReport](ctx, r.pool, `SELECT doc FROM ai_reports WHERE room_code = ANY($1)`, roomCodes)
+}
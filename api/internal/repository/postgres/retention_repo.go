@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"2026champs/internal/repository"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// retentionColumns maps the collection and date field of each retention target
+// to the timestamp column of its table
+var retentionColumns = map[string]string{
+	"answers.createdAt":              "created_at",
+	"player_feedback.createdAt":      "created_at",
+	"room_snapshots.endedAt":         "ended_at",
+	"ai_reports.createdAt":           "created_at",
+	"sm_responses_raw.date_modified": "date_modified",
+	"voice_clips.createdAt":          "created_at",
+	"attachments.createdAt":          "created_at",
+}
+
+type retentionRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewRetentionRepo creates a new retention repository
+func NewRetentionRepo(pool *pgxpool.Pool) repository.RetentionRepo {
+	return &retentionRepo{pool: pool}
+}
+
+func (r *retentionRepo) CountOlderThan(ctx context.Context, collection, dateField string, cutoff time.Time) (int64, error) {
+	table, column, err := retentionTarget(collection, dateField)
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	err = r.pool.QueryRow(ctx, fmt.Sprintf(`SELECT count(*) FROM %s WHERE %s < $1`, table, column), cutoff).Scan(&n)
+	return n, err
+}
+
+func (r *retentionRepo) DeleteOlderThan(ctx context.Context, collection, dateField string, cutoff time.Time) (int64, error) {
+	table, column, err := retentionTarget(collection, dateField)
+	if err != nil {
+		return 0, err
+	}
+	return exec(ctx, r.pool, fmt.Sprintf(`DELETE FROM %s WHERE %s < $1`, table, column), cutoff)
+}
+
+// EnsureTTLIndex only checks the target exists: PostgreSQL has no TTL indexes,
+// so expired rows are removed by the daily purge alone
+func (r *retentionRepo) EnsureTTLIndex(ctx context.Context, collection, dateField string, expireAfter time.Duration) error {
+	_, _, err := retentionTarget(collection, dateField)
+	return err
+}
+
+// retentionTarget returns the quoted table and column for a retention target
+func retentionTarget(collection, dateField string) (string, string, error) {
+	column, ok := retentionColumns[collection+"."+dateField]
+	if !ok {
+		return "", "", fmt.Errorf("no retention column for %s.%s", collection, dateField)
+	}
+	return pgx.Identifier{collection}.Sanitize(), pgx.Identifier{column}.Sanitize(), nil
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type roomRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewRoomRepo creates a new room repository
+func NewRoomRepo(pool *pgxpool.Pool) repository.RoomRepo {
+	return &roomRepo{pool: pool}
+}
+
+func (r *roomRepo) Create(ctx context.Context, room *model.Room) error {
+	room.CreatedAt = time.Now()
+	doc, err := roomDoc(room)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx,
+		`INSERT INTO rooms (code, survey_id, host_id, status, created_at, doc) VALUES ($1, $2, $3, $4, $5, $6)`,
+		room.Code, room.SurveyID, room.HostID, room.Status, room.CreatedAt, doc)
+	return err
+}
+
+func (r *roomRepo) GetByCode(ctx context.Context, code string) (*model.Room, error) {
+	return getDoc[model.Room](ctx, r.pool, `SELECT doc FROM rooms WHERE code = $1`, code)
+}
+
+func (r *roomRepo) Update(ctx context.Context, room *model.Room) error {
+	doc, err := roomDoc(room)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx,
+		`UPDATE rooms SET survey_id = $2, host_id = $3, status = $4, doc = $5 WHERE code = $1`,
+		room.Code, room.SurveyID, room.HostID, room.Status, doc)
+	return err
+}
+
+func (r *roomRepo) Delete(ctx context.Context, code string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM rooms WHERE code = $1`, code)
+	return err
+}
+
+func (r *roomRepo) GetBySurveyID(ctx context.Context, surveyID string) ([]*model.Room, error) {
+	if err := parseID(surveyID); err != nil {
+		return nil, err
+	}
+	return listDocs[model.Room](ctx, r.pool, `SELECT doc FROM rooms WHERE survey_id = $1 ORDER BY created_at`, surveyID)
+}
+
+func (r *roomRepo) CountOpenByHost(ctx context.Context, hostID string) (int64, error) {
+	var n int64
+	err := r.pool.QueryRow(ctx, `SELECT count(*) FROM rooms WHERE host_id = $1 AND status = ANY($2)`,
+		hostID, []string{string(model.RoomStatusLobby), string(model.RoomStatusActive)}).Scan(&n)
+	return n, err
+}
+
+func (r *roomRepo) GetByHostID(ctx context.Context, hostID string) ([]*model.Room, error) {
+	return listDocs[model.Room](ctx, r.pool, `SELECT doc FROM rooms WHERE host_id = $1 ORDER BY created_at`, hostID)
+}
+
+// roomDoc encodes a room as the Mongo repository stores it: the experiment's
+// variants are only carried in the room meta
+func roomDoc(room *model.Room) ([]byte, error) {
+	if room.Experiment != nil && room.Experiment.Variants != nil {
+		stored, experiment := *room, *room.Experiment
+		experiment.Variants = nil
+		stored.Experiment = &experiment
+		room = &stored
+	}
+	return marshal(room)
+}
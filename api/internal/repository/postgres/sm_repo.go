@@ -0,0 +1,296 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type smRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewSMRepo creates a new SurveyMonkey repository
+func NewSMRepo(pool *pgxpool.Pool) repository.SMRepo {
+	return &smRepo{pool: pool}
+}
+
+// Collector methods
+
+func (r *smRepo) UpsertCollector(ctx context.Context, collector *model.SMCollector) error {
+	c := *collector
+	if c.ID.IsZero() {
+		c.ID = primitive.NewObjectID()
+	}
+	doc, err := marshal(&c)
+	if err != nil {
+		return err
+	}
+	// A replaced collector keeps its ID, as with a Mongo replacement
+	_, err = r.pool.Exec(ctx, `INSERT INTO sm_collectors (collector_id, survey_id, doc) VALUES ($1, $2, $3)
+		ON CONFLICT (collector_id) DO UPDATE SET survey_id = EXCLUDED.survey_id,
+			doc = jsonb_set(EXCLUDED.doc, '{id}', sm_collectors.doc -> 'id')`,
+		c.CollectorID, c.SurveyID, doc)
+	return err
+}
+
+func (r *smRepo) GetCollectorByID(ctx context.Context, collectorID string) (*model.SMCollector, error) {
+	return getDoc[model.SMCollector](ctx, r.pool, `SELECT doc FROM sm_collectors WHERE collector_id = $1`, collectorID)
+}
+
+func (r *smRepo) GetCollectorsBySurvey(ctx context.Context, surveyID string) ([]*model.SMCollector, error) {
+	return listDocs[model.SMCollector](ctx, r.pool, `SELECT doc FROM sm_collectors WHERE survey_id = $1 ORDER BY collector_id`, surveyID)
+}
+
+func (r *smRepo) DeleteCollector(ctx context.Context, collectorID string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM sm_collectors WHERE collector_id = $1`, collectorID)
+	return err
+}
+
+// Raw response methods (Layer 1)
+
+func (r *smRepo) UpsertRawResponse(ctx context.Context, response *model.SMResponseRaw) error {
+	response.LastSeenAt = time.Now()
+	raw := *response
+	if raw.ID.IsZero() {
+		raw.ID = primitive.NewObjectID()
+	}
+	doc, err := marshal(&raw)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO sm_responses_raw (response_id, survey_id, date_modified, doc) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (response_id) DO UPDATE SET survey_id = EXCLUDED.survey_id, date_modified = EXCLUDED.date_modified,
+			doc = jsonb_set(EXCLUDED.doc, '{id}', sm_responses_raw.doc -> 'id')`,
+		raw.ResponseID, raw.SurveyID, raw.DateModified, doc)
+	return err
+}
+
+func (r *smRepo) GetRawResponse(ctx context.Context, responseID string) (*model.SMResponseRaw, error) {
+	return getDoc[model.SMResponseRaw](ctx, r.pool, `SELECT doc FROM sm_responses_raw WHERE response_id = $1`, responseID)
+}
+
+func (r *smRepo) GetRawResponsesBySurvey(ctx context.Context, surveyID string, limit int) ([]*model.SMResponseRaw, error) {
+	var max *int
+	if limit > 0 {
+		max = &limit
+	}
+	return listDocs[model.SMResponseRaw](ctx, r.pool,
+		`SELECT doc FROM sm_responses_raw WHERE survey_id = $1 ORDER BY date_modified DESC LIMIT $2`, surveyID, max)
+}
+
+// Answer methods (Layer 2)
+
+func (r *smRepo) DeleteAnswersByResponseID(ctx context.Context, responseID string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM sm_answers WHERE response_id = $1`, responseID)
+	return err
+}
+
+func (r *smRepo) InsertAnswers(ctx context.Context, answers []*model.SMAnswer) error {
+	if len(answers) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, a := range answers {
+		answer := *a
+		if answer.ID.IsZero() {
+			answer.ID = primitive.NewObjectID()
+		}
+		doc, err := marshal(&answer)
+		if err != nil {
+			return err
+		}
+		batch.Queue(`INSERT INTO sm_answers (id, response_id, survey_id, question_id, doc) VALUES ($1, $2, $3, $4, $5)`,
+			answer.ID.Hex(), answer.ResponseID, answer.SurveyID, answer.QuestionID, doc)
+	}
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		return tx.SendBatch(ctx, batch).Close()
+	})
+}
+
+func (r *smRepo) GetAnswersBySurveyQuestion(ctx context.Context, surveyID, questionID string) ([]*model.SMAnswer, error) {
+	return listDocs[model.SMAnswer](ctx, r.pool,
+		`SELECT doc FROM sm_answers WHERE survey_id = $1 AND question_id = $2 ORDER BY id`, surveyID, questionID)
+}
+
+// Feature methods (Layer 3)
+
+func (r *smRepo) UpsertFeatures(ctx context.Context, features *model.SMResponseFeatures) error {
+	f := *features
+	if f.ID.IsZero() {
+		f.ID = primitive.NewObjectID()
+	}
+	doc, err := marshal(&f)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO sm_response_features (response_id, survey_id, submitted_at, doc) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (response_id) DO UPDATE SET survey_id = EXCLUDED.survey_id, submitted_at = EXCLUDED.submitted_at,
+			doc = jsonb_set(EXCLUDED.doc, '{id}', sm_response_features.doc -> 'id')`,
+		f.ResponseID, f.SurveyID, f.SubmittedAt, doc)
+	return err
+}
+
+func (r *smRepo) GetFeaturesByResponseID(ctx context.Context, responseID string) (*model.SMResponseFeatures, error) {
+	return getDoc[model.SMResponseFeatures](ctx, r.pool, `SELECT doc FROM sm_response_features WHERE response_id = $1`, responseID)
+}
+
+func (r *smRepo) GetFeaturesBySurvey(ctx context.Context, surveyID string) ([]*model.SMResponseFeatures, error) {
+	return listDocs[model.SMResponseFeatures](ctx, r.pool,
+		`SELECT doc FROM sm_response_features WHERE survey_id = $1 ORDER BY submitted_at DESC`, surveyID)
+}
+
+// Sync schedule methods
+
+func (r *smRepo) UpsertSchedule(ctx context.Context, schedule *model.SMSyncSchedule) error {
+	set := patch{
+		"intervalMinutes": schedule.IntervalMinutes,
+		"enabled":         schedule.Enabled,
+		"nextRunAt":       schedule.NextRunAt,
+		"updatedAt":       schedule.UpdatedAt,
+	}
+	update, err := set.json()
+	if err != nil {
+		return err
+	}
+	set["surveyId"] = schedule.SurveyID
+	set["createdAt"] = schedule.CreatedAt
+	set["consecutiveFailures"] = 0
+	insert, err := set.json()
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO sm_sync_schedules (survey_id, enabled, next_run_at, doc) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (survey_id) DO UPDATE SET enabled = EXCLUDED.enabled, next_run_at = EXCLUDED.next_run_at,
+			doc = sm_sync_schedules.doc || $5::jsonb`,
+		schedule.SurveyID, schedule.Enabled, schedule.NextRunAt, insert, update)
+	return err
+}
+
+func (r *smRepo) GetSchedule(ctx context.Context, surveyID string) (*model.SMSyncSchedule, error) {
+	schedules, err := r.schedules(ctx, `SELECT doc, locked_until FROM sm_sync_schedules WHERE survey_id = $1`, surveyID)
+	if err != nil || len(schedules) == 0 {
+		return nil, err
+	}
+	return schedules[0], nil
+}
+
+// DeleteSchedule stops automatic syncs; the last-sync status is kept
+func (r *smRepo) DeleteSchedule(ctx context.Context, surveyID string) error {
+	set, err := patch{"enabled": false, "intervalMinutes": 0, "updatedAt": time.Now()}.json()
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `UPDATE sm_sync_schedules SET enabled = false, next_run_at = NULL,
+		doc = (doc || $2::jsonb) - 'nextRunAt' WHERE survey_id = $1`, surveyID, set)
+	return err
+}
+
+// ListDueSchedules returns enabled schedules whose next run has come and that no instance is syncing
+func (r *smRepo) ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]*model.SMSyncSchedule, error) {
+	return r.schedules(ctx, `SELECT doc, locked_until FROM sm_sync_schedules
+		WHERE enabled AND next_run_at <= $1 AND (locked_until IS NULL OR locked_until <= $1)
+		ORDER BY next_run_at LIMIT $2`, now, limit)
+}
+
+// ClaimSync takes the survey's sync lock until the given time, creating the status
+// row for surveys that were never scheduled. False when another sync holds it.
+func (r *smRepo) ClaimSync(ctx context.Context, surveyID string, now, until time.Time) (bool, error) {
+	doc, err := patch{
+		"surveyId":            surveyID,
+		"intervalMinutes":     0,
+		"enabled":             false,
+		"consecutiveFailures": 0,
+		"createdAt":           now,
+		"updatedAt":           now,
+	}.json()
+	if err != nil {
+		return false, err
+	}
+	var claimed string
+	err = r.pool.QueryRow(ctx, `INSERT INTO sm_sync_schedules (survey_id, enabled, locked_until, doc) VALUES ($1, false, $3, $4)
+		ON CONFLICT (survey_id) DO UPDATE SET locked_until = EXCLUDED.locked_until
+			WHERE sm_sync_schedules.locked_until IS NULL OR sm_sync_schedules.locked_until <= $2
+		RETURNING survey_id`, surveyID, now, until, doc).Scan(&claimed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// CompleteSync stores the outcome of a sync and releases the lock
+func (r *smRepo) CompleteSync(ctx context.Context, schedule *model.SMSyncSchedule) error {
+	set := patch{
+		"lastRunAt":           schedule.LastRunAt,
+		"lastStatus":          schedule.LastStatus,
+		"lastError":           schedule.LastError,
+		"lastDurationMs":      schedule.LastDurationMs,
+		"consecutiveFailures": schedule.ConsecutiveFailures,
+		"updatedAt":           schedule.UpdatedAt,
+	}
+	if schedule.LastSuccessAt != nil {
+		set["lastSuccessAt"] = schedule.LastSuccessAt
+		set["lastResult"] = schedule.LastResult
+	}
+	if schedule.NextRunAt != nil {
+		set["nextRunAt"] = schedule.NextRunAt
+	}
+	doc, err := set.json()
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `UPDATE sm_sync_schedules SET locked_until = NULL, next_run_at = COALESCE($2, next_run_at),
+		doc = doc || $3::jsonb WHERE survey_id = $1`, schedule.SurveyID, schedule.NextRunAt, doc)
+	return err
+}
+
+// schedules decodes schedule rows of (doc, locked_until); the lock is not part of the document
+func (r *smRepo) schedules(ctx context.Context, sql string, args ...interface{}) ([]*model.SMSyncSchedule, error) {
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []*model.SMSyncSchedule
+	for rows.Next() {
+		var raw []byte
+		var lockedUntil *time.Time
+		if err := rows.Scan(&raw, &lockedUntil); err != nil {
+			return nil, err
+		}
+		var schedule model.SMSyncSchedule
+		if err := json.Unmarshal(raw, &schedule); err != nil {
+			return nil, err
+		}
+		schedule.LockedUntil = lockedUntil
+		schedules = append(schedules, &schedule)
+	}
+	return schedules, rows.Err()
+}
+
+// Analytics aggregations
+
+func (r *smRepo) GetSurveySummary(ctx context.Context, surveyID string) (*model.SMSurveySummary, error) {
+	features, err := r.GetFeaturesBySurvey(ctx, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	return repository.SummarizeSMFeatures(features), nil
+}
+
+func (r *smRepo) GetDistribution(ctx context.Context, surveyID, metric string) (*model.SMDistribution, error) {
+	features, err := r.GetFeaturesBySurvey(ctx, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	return repository.SMFeatureDistribution(features, metric), nil
+}
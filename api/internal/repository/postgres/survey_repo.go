@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type surveyRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewSurveyRepo creates a new survey repository
+func NewSurveyRepo(pool *pgxpool.Pool) repository.SurveyRepo {
+	return &surveyRepo{pool: pool}
+}
+
+func (r *surveyRepo) Create(ctx context.Context, survey *model.Survey) (string, error) {
+	survey.ID = newID()
+	survey.CreatedAt = time.Now()
+	survey.UpdatedAt = time.Now()
+
+	doc, err := marshal(survey)
+	if err != nil {
+		return "", err
+	}
+	_, err = r.pool.Exec(ctx,
+		`INSERT INTO surveys (id, host_id, created_at, deleted_at, doc) VALUES ($1, $2, $3, $4, $5)`,
+		survey.ID, survey.HostID, survey.CreatedAt, survey.DeletedAt, doc)
+	if err != nil {
+		return "", err
+	}
+	return survey.ID, nil
+}
+
+func (r *surveyRepo) GetByID(ctx context.Context, id string) (*model.Survey, error) {
+	if err := parseID(id); err != nil {
+		return nil, err
+	}
+	return getDoc[model.Survey](ctx, r.pool, `SELECT doc FROM surveys WHERE id = $1`, id)
+}
+
+// GetByHostID lists a host's surveys, leaving out those in the trash
+func (r *surveyRepo) GetByHostID(ctx context.Context, hostID string) ([]*model.Survey, error) {
+	return listDocs[model.Survey](ctx, r.pool,
+		`SELECT doc FROM surveys WHERE host_id = $1 AND deleted_at IS NULL ORDER BY created_at`, hostID)
+}
+
+// GetDeletedByHostID lists a host's surveys in the trash
+func (r *surveyRepo) GetDeletedByHostID(ctx context.Context, hostID string) ([]*model.Survey, error) {
+	return listDocs[model.Survey](ctx, r.pool,
+		`SELECT doc FROM surveys WHERE host_id = $1 AND deleted_at IS NOT NULL ORDER BY created_at`, hostID)
+}
+
+func (r *surveyRepo) CountByHost(ctx context.Context, hostID string) (int64, error) {
+	var n int64
+	err := r.pool.QueryRow(ctx, `SELECT count(*) FROM surveys WHERE host_id = $1 AND deleted_at IS NULL`, hostID).Scan(&n)
+	return n, err
+}
+
+func (r *surveyRepo) Update(ctx context.Context, survey *model.Survey) error {
+	if err := parseID(survey.ID); err != nil {
+		return err
+	}
+
+	survey.UpdatedAt = time.Now()
+	set, err := patch{
+		"hostId":     survey.HostID,
+		"title":      survey.Title,
+		"intent":     survey.Intent,
+		"settings":   survey.Settings,
+		"questions":  survey.Questions,
+		"branching":  survey.Branching,
+		"branding":   survey.Branding,
+		"guardrails": survey.Guardrails,
+		"smSurveyId": survey.SMSurveyID,
+		"smWebLink":  survey.SMWebLink,
+		"updatedAt":  survey.UpdatedAt,
+	}.json()
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `UPDATE surveys SET host_id = $2, doc = doc || $3::jsonb WHERE id = $1`, survey.ID, survey.HostID, set)
+	return err
+}
+
+func (r *surveyRepo) SetThemeTaxonomy(ctx context.Context, id string, taxonomy *model.ThemeTaxonomy) error {
+	if err := parseID(id); err != nil {
+		return err
+	}
+	set := patch{"updatedAt": time.Now()}
+	unset := []string{}
+	if taxonomy != nil {
+		set["themeTaxonomy"] = taxonomy
+	} else {
+		unset = []string{"themeTaxonomy"}
+	}
+	doc, err := set.json()
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `UPDATE surveys SET doc = (doc || $2::jsonb) - $3::text[] WHERE id = $1`, id, doc, unset)
+	return err
+}
+
+// SoftDelete moves a survey to the trash; returns false if it was missing or already there.
+// Rooms and reports keep resolving the survey through GetByID.
+func (r *surveyRepo) SoftDelete(ctx context.Context, id string, at time.Time) (bool, error) {
+	if parseID(id) != nil {
+		return false, nil
+	}
+	set, err := patch{"deletedAt": at}.json()
+	if err != nil {
+		return false, err
+	}
+	n, err := exec(ctx, r.pool,
+		`UPDATE surveys SET deleted_at = $2, doc = doc || $3::jsonb WHERE id = $1 AND deleted_at IS NULL`, id, at, set)
+	return n > 0, err
+}
+
+// Restore takes a survey out of the trash; returns false if it was not there
+func (r *surveyRepo) Restore(ctx context.Context, id string) (bool, error) {
+	if parseID(id) != nil {
+		return false, nil
+	}
+	n, err := exec(ctx, r.pool,
+		`UPDATE surveys SET deleted_at = NULL, doc = doc - 'deletedAt' WHERE id = $1 AND deleted_at IS NOT NULL`, id)
+	return n > 0, err
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type templateRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewTemplateRepo creates a new template repository
+func NewTemplateRepo(pool *pgxpool.Pool) repository.TemplateRepo {
+	return &templateRepo{pool: pool}
+}
+
+func (r *templateRepo) Create(ctx context.Context, template *model.RoomTemplate) (string, error) {
+	template.ID = newID()
+	template.CreatedAt = time.Now()
+	template.UpdatedAt = template.CreatedAt
+	doc, err := marshal(template)
+	if err != nil {
+		return "", err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO room_templates (id, host_id, name, doc) VALUES ($1, $2, $3, $4)`,
+		template.ID, template.HostID, template.Name, doc)
+	if err != nil {
+		return "", err
+	}
+	return template.ID, nil
+}
+
+func (r *templateRepo) Get(ctx context.Context, hostID, id string) (*model.RoomTemplate, error) {
+	if parseID(id) != nil {
+		return nil, nil
+	}
+	return getDoc[model.RoomTemplate](ctx, r.pool, `SELECT doc FROM room_templates WHERE id = $1 AND host_id = $2`, id, hostID)
+}
+
+func (r *templateRepo) ListByHost(ctx context.Context, hostID string) ([]*model.RoomTemplate, error) {
+	return listDocs[model.RoomTemplate](ctx, r.pool, `SELECT doc FROM room_templates WHERE host_id = $1 ORDER BY name`, hostID)
+}
+
+func (r *templateRepo) CountByHost(ctx context.Context, hostID string) (int64, error) {
+	var n int64
+	err := r.pool.QueryRow(ctx, `SELECT count(*) FROM room_templates WHERE host_id = $1`, hostID).Scan(&n)
+	return n, err
+}
+
+// Replace overwrites the editable fields of a host's template; returns false if none matched
+func (r *templateRepo) Replace(ctx context.Context, template *model.RoomTemplate) (bool, error) {
+	if parseID(template.ID) != nil {
+		return false, nil
+	}
+	template.UpdatedAt = time.Now()
+	set := patch{
+		"name":      template.Name,
+		"surveyId":  template.SurveyID,
+		"settings":  template.Settings,
+		"hostNotes": template.HostNotes,
+		"updatedAt": template.UpdatedAt,
+	}
+	unset := []string{}
+	if template.Branding != nil {
+		set["branding"] = template.Branding
+	} else {
+		unset = []string{"branding"}
+	}
+	doc, err := set.json()
+	if err != nil {
+		return false, err
+	}
+	n, err := exec(ctx, r.pool, `UPDATE room_templates SET name = $3, doc = (doc || $4::jsonb) - $5::text[]
+		WHERE id = $1 AND host_id = $2`, template.ID, template.HostID, template.Name, doc, unset)
+	return n > 0, err
+}
+
+// Delete removes a host's template; returns false if none matched
+func (r *templateRepo) Delete(ctx context.Context, hostID, id string) (bool, error) {
+	if parseID(id) != nil {
+		return false, nil
+	}
+	n, err := exec(ctx, r.pool, `DELETE FROM room_templates WHERE id = $1 AND host_id = $2`, id, hostID)
+	return n > 0, err
+}
+
+// MarkUsed counts a room opened from the template
+func (r *templateRepo) MarkUsed(ctx context.Context, id string, at time.Time) error {
+	if err := parseID(id); err != nil {
+		return err
+	}
+	set, err := patch{"lastUsedAt": at}.json()
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `UPDATE room_templates SET doc = doc || $2::jsonb
+		|| jsonb_build_object('useCount', COALESCE((doc ->> 'useCount')::int, 0) + 1) WHERE id = $1`, id, set)
+	return err
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type voiceRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewVoiceRepo creates a new voice clip repository
+func NewVoiceRepo(pool *pgxpool.Pool) repository.VoiceRepo {
+	return &voiceRepo{pool: pool}
+}
+
+func (r *voiceRepo) Create(ctx context.Context, clip *model.VoiceClip) error {
+	clip.CreatedAt = time.Now()
+	doc, err := marshal(clip)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO voice_clips (id, room_code, player_id, storage_key, created_at, doc)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		clip.ID, clip.RoomCode, clip.PlayerID, clip.StorageKey, clip.CreatedAt, doc)
+	return err
+}
+
+func (r *voiceRepo) GetByID(ctx context.Context, id string) (*model.VoiceClip, error) {
+	clips, err := r.list(ctx, `SELECT doc, storage_key FROM voice_clips WHERE id = $1`, id)
+	if err != nil || len(clips) == 0 {
+		return nil, err
+	}
+	return clips[0], nil
+}
+
+func (r *voiceRepo) ListOlderThan(ctx context.Context, cutoff time.Time) ([]*model.VoiceClip, error) {
+	return r.list(ctx, `SELECT doc, storage_key FROM voice_clips WHERE created_at < $1`, cutoff)
+}
+
+// ListByPlayer returns a player's clips in a room, oldest first
+func (r *voiceRepo) ListByPlayer(ctx context.Context, roomCode, playerID string) ([]*model.VoiceClip, error) {
+	return r.list(ctx, `SELECT doc, storage_key FROM voice_clips WHERE room_code = $1 AND player_id = $2 ORDER BY created_at`, roomCode, playerID)
+}
+
+func (r *voiceRepo) DeleteByPlayer(ctx context.Context, roomCode, playerID string) (int64, error) {
+	return exec(ctx, r.pool, `DELETE FROM voice_clips WHERE room_code = $1 AND player_id = $2`, roomCode, playerID)
+}
+
+// list decodes rows of (doc, storage_key); the storage key is not part of the document
+func (r *voiceRepo) list(ctx context.Context, sql string, args ...interface{}) ([]*model.VoiceClip, error) {
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	clips := []*model.VoiceClip{}
+	for rows.Next() {
+		var raw []byte
+		var clip model.VoiceClip
+		if err := rows.Scan(&raw, &clip.StorageKey); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &clip); err != nil {
+			return nil, err
+		}
+		clips = append(clips, &clip)
+	}
+	return clips, rows.Err()
+}
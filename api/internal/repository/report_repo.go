@@ -15,6 +15,10 @@ type ReportRepo interface {
 	GetSnapshot(ctx context.Context, roomCode string) (*model.RoomSnapshot, error)
 	SaveAIReport(ctx context.Context, report *model.AIReport) error
 	GetAIReport(ctx context.Context, roomCode string) (*model.AIReport, error)
+
+	// Batch reads for dashboards listing many rooms
+	GetSnapshots(ctx context.Context, roomCodes []string) ([]*model.RoomSnapshot, error)
+	GetAIReports(ctx context.Context, roomCodes []string) ([]*model.AIReport, error)
 }
 
 type reportRepo struct {
@@ -65,3 +69,41 @@ func (r *reportRepo) GetAIReport(ctx context.Context, roomCode string) (*model.A
 	}
 	return &report, nil
 }
+
+// GetSnapshots returns the snapshots of the given rooms that have one
+func (r *reportRepo) GetSnapshots(ctx context.Context, roomCodes []string) ([]*model.RoomSnapshot, error) {
+	snapshots := []*model.RoomSnapshot{}
+	if len(roomCodes) == 0 {
+		return snapshots, nil
+	}
+
+	cursor, err := r.snapshots.Find(ctx, bson.M{"roomCode": bson.M{"$in": roomCodes}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &snapshots); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// GetAIReports returns the AI reports of the given rooms that have one
+func (r *reportRepo) GetAIReports(ctx context.Context, roomCodes []string) ([]*model.AIReport, error) {
+	reports := []*model.AIReport{}
+	if len(roomCodes) == 0 {
+		return reports, nil
+	}
+
+	cursor, err := r.aiReports.Find(ctx, bson.M{"roomCode": bson.M{"$in": roomCodes}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RetentionRepo counts and deletes expired documents across collections
+type RetentionRepo interface {
+	CountOlderThan(ctx context.Context, collection, dateField string, cutoff time.Time) (int64, error)
+	DeleteOlderThan(ctx context.Context, collection, dateField string, cutoff time.Time) (int64, error)
+	EnsureTTLIndex(ctx context.Context, collection, dateField string, expireAfter time.Duration) error
+}
+
+type retentionRepo struct {
+	db *mongo.Database
+}
+
+// NewRetentionRepo creates a new retention repository
+func NewRetentionRepo(db *mongo.Database) RetentionRepo {
+	return &retentionRepo{db: db}
+}
+
+func (r *retentionRepo) CountOlderThan(ctx context.Context, collection, dateField string, cutoff time.Time) (int64, error) {
+	return r.db.Collection(collection).CountDocuments(ctx, bson.M{dateField: bson.M{"$lt": cutoff}})
+}
+
+func (r *retentionRepo) DeleteOlderThan(ctx context.Context, collection, dateField string, cutoff time.Time) (int64, error) {
+	result, err := r.db.Collection(collection).DeleteMany(ctx, bson.M{dateField: bson.M{"$lt": cutoff}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// EnsureTTLIndex creates a TTL index on dateField, or updates its expiry if one already exists
+func (r *retentionRepo) EnsureTTLIndex(ctx context.Context, collection, dateField string, expireAfter time.Duration) error {
+	seconds := int32(expireAfter / time.Second)
+	keys := bson.D{{Key: dateField, Value: 1}}
+	opts := options.Index().SetExpireAfterSeconds(seconds)
+
+	_, err := r.db.Collection(collection).Indexes().CreateOne(ctx, mongo.IndexModel{Keys: keys, Options: opts})
+	if err == nil {
+		return nil
+	}
+
+	// An index on the field with a different expiry can be changed in place
+	var cmdErr mongo.CommandError
+	if !errors.As(err, &cmdErr) || (cmdErr.Name != "IndexOptionsConflict" && cmdErr.Name != "IndexKeySpecsConflict") {
+		return err
+	}
+	err = r.db.RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: collection},
+		{Key: "index", Value: bson.D{
+			{Key: "keyPattern", Value: keys},
+			{Key: "expireAfterSeconds", Value: seconds},
+		}},
+	}).Err()
+	if err != nil {
+		return err
+	}
+	log.Printf("Updated TTL index on %s.%s to %ds", collection, dateField, seconds)
+	return nil
+}
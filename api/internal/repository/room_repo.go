@@ -18,6 +18,8 @@ type RoomRepo interface {
 	Delete(ctx context.Context, code string) error
 	GetBySurveyID(ctx context.Context, surveyID string) ([]*model.Room, error)
 	GetByHostID(ctx context.Context, hostID string) ([]*model.Room, error)
+	// CountOpenByHost counts the host's rooms that have not ended
+	CountOpenByHost(ctx context.Context, hostID string) (int64, error)
 }
 
 type roomRepo struct {
@@ -78,6 +80,13 @@ func (r *roomRepo) GetBySurveyID(ctx context.Context, surveyID string) ([]*model
 	return rooms, nil
 }
 
+func (r *roomRepo) CountOpenByHost(ctx context.Context, hostID string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{
+		"hostId": hostID,
+		"status": bson.M{"$in": []model.RoomStatus{model.RoomStatusLobby, model.RoomStatusActive}},
+	})
+}
+
 func (r *roomRepo) GetByHostID(ctx context.Context, hostID string) ([]*model.Room, error) {
 	cursor, err := r.collection.Find(ctx, bson.M{"hostId": hostID})
 	if err != nil {
@@ -3,7 +3,6 @@ package repository
 import (
 	"2026champs/internal/model"
 	"context"
-	"log"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -17,6 +16,7 @@ type SMRepo interface {
 	UpsertCollector(ctx context.Context, collector *model.SMCollector) error
 	GetCollectorByID(ctx context.Context, collectorID string) (*model.SMCollector, error)
 	GetCollectorsBySurvey(ctx context.Context, surveyID string) ([]*model.SMCollector, error)
+	DeleteCollector(ctx context.Context, collectorID string) error
 
 	// Raw responses (Layer 1)
 	UpsertRawResponse(ctx context.Context, response *model.SMResponseRaw) error
@@ -33,6 +33,14 @@ type SMRepo interface {
 	GetFeaturesByResponseID(ctx context.Context, responseID string) (*model.SMResponseFeatures, error)
 	GetFeaturesBySurvey(ctx context.Context, surveyID string) ([]*model.SMResponseFeatures, error)
 
+	// Sync schedules
+	UpsertSchedule(ctx context.Context, schedule *model.SMSyncSchedule) error
+	GetSchedule(ctx context.Context, surveyID string) (*model.SMSyncSchedule, error)
+	DeleteSchedule(ctx context.Context, surveyID string) error
+	ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]*model.SMSyncSchedule, error)
+	ClaimSync(ctx context.Context, surveyID string, now, until time.Time) (bool, error)
+	CompleteSync(ctx context.Context, schedule *model.SMSyncSchedule) error
+
 	// Analytics aggregations
 	GetSurveySummary(ctx context.Context, surveyID string) (*model.SMSurveySummary, error)
 	GetDistribution(ctx context.Context, surveyID, metric string) (*model.SMDistribution, error)
@@ -43,69 +51,18 @@ type smRepo struct {
 	answers      *mongo.Collection
 	features     *mongo.Collection
 	collectors   *mongo.Collection
+	schedules    *mongo.Collection
 }
 
-// NewSMRepo creates a new SurveyMonkey repository with indexes
+// NewSMRepo creates a new SurveyMonkey repository. Its indexes are created by
+// the sm_indexes migration.
 func NewSMRepo(db *mongo.Database) SMRepo {
-	repo := &smRepo{
+	return &smRepo{
 		rawResponses: db.Collection("sm_responses_raw"),
 		answers:      db.Collection("sm_answers"),
 		features:     db.Collection("sm_response_features"),
 		collectors:   db.Collection("sm_collectors"),
-	}
-
-	// Create indexes
-	repo.ensureIndexes(context.Background())
-
-	return repo
-}
-
-func (r *smRepo) ensureIndexes(ctx context.Context) {
-	// sm_responses_raw indexes
-	r.createIndex(ctx, r.rawResponses, bson.D{{Key: "response_id", Value: 1}}, true)
-	r.createIndex(ctx, r.rawResponses, bson.D{
-		{Key: "survey_id", Value: 1},
-		{Key: "date_modified", Value: -1},
-	}, false)
-	r.createIndex(ctx, r.rawResponses, bson.D{
-		{Key: "collector_id", Value: 1},
-		{Key: "date_modified", Value: -1},
-	}, false)
-
-	// sm_answers indexes
-	r.createIndex(ctx, r.answers, bson.D{{Key: "response_id", Value: 1}}, false)
-	r.createIndex(ctx, r.answers, bson.D{
-		{Key: "survey_id", Value: 1},
-		{Key: "question_id", Value: 1},
-	}, false)
-	r.createIndex(ctx, r.answers, bson.D{
-		{Key: "question_id", Value: 1},
-		{Key: "choice_id", Value: 1},
-	}, false)
-
-	// sm_response_features indexes
-	r.createIndex(ctx, r.features, bson.D{{Key: "response_id", Value: 1}}, true)
-	r.createIndex(ctx, r.features, bson.D{
-		{Key: "survey_id", Value: 1},
-		{Key: "submitted_at", Value: -1},
-	}, false)
-	r.createIndex(ctx, r.features, bson.D{
-		{Key: "survey_id", Value: 1},
-		{Key: "overall_satisfaction", Value: 1},
-	}, false)
-
-	// sm_collectors indexes
-	r.createIndex(ctx, r.collectors, bson.D{{Key: "collector_id", Value: 1}}, true)
-	r.createIndex(ctx, r.collectors, bson.D{{Key: "survey_id", Value: 1}}, false)
-
-	log.Println("SM indexes ensured")
-}
-
-func (r *smRepo) createIndex(ctx context.Context, coll *mongo.Collection, keys bson.D, unique bool) {
-	opts := options.Index().SetUnique(unique)
-	_, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: keys, Options: opts})
-	if err != nil {
-		log.Printf("Warning: failed to create index on %s: %v", coll.Name(), err)
+		schedules:    db.Collection("sm_sync_schedules"),
 	}
 }
 
@@ -147,6 +104,11 @@ func (r *smRepo) GetCollectorsBySurvey(ctx context.Context, surveyID string) ([]
 	return collectors, nil
 }
 
+func (r *smRepo) DeleteCollector(ctx context.Context, collectorID string) error {
+	_, err := r.collectors.DeleteOne(ctx, bson.M{"collector_id": collectorID})
+	return err
+}
+
 // Raw response methods (Layer 1)
 
 func (r *smRepo) UpsertRawResponse(ctx context.Context, response *model.SMResponseRaw) error {
@@ -268,6 +230,120 @@ func (r *smRepo) GetFeaturesBySurvey(ctx context.Context, surveyID string) ([]*m
 	return features, nil
 }
 
+// Sync schedule methods
+
+func (r *smRepo) UpsertSchedule(ctx context.Context, schedule *model.SMSyncSchedule) error {
+	opts := options.Update().SetUpsert(true)
+	_, err := r.schedules.UpdateOne(ctx,
+		bson.M{"survey_id": schedule.SurveyID},
+		bson.M{
+			"$set": bson.M{
+				"interval_minutes": schedule.IntervalMinutes,
+				"enabled":          schedule.Enabled,
+				"next_run_at":      schedule.NextRunAt,
+				"updated_at":       schedule.UpdatedAt,
+			},
+			"$setOnInsert": bson.M{"created_at": schedule.CreatedAt, "consecutive_failures": 0},
+		},
+		opts,
+	)
+	return err
+}
+
+func (r *smRepo) GetSchedule(ctx context.Context, surveyID string) (*model.SMSyncSchedule, error) {
+	var schedule model.SMSyncSchedule
+	err := r.schedules.FindOne(ctx, bson.M{"survey_id": surveyID}).Decode(&schedule)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// DeleteSchedule stops automatic syncs; the last-sync status is kept
+func (r *smRepo) DeleteSchedule(ctx context.Context, surveyID string) error {
+	_, err := r.schedules.UpdateOne(ctx,
+		bson.M{"survey_id": surveyID},
+		bson.M{
+			"$set":   bson.M{"enabled": false, "interval_minutes": 0, "updated_at": time.Now()},
+			"$unset": bson.M{"next_run_at": ""},
+		},
+	)
+	return err
+}
+
+// ListDueSchedules returns enabled schedules whose next run has come and that no instance is syncing
+func (r *smRepo) ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]*model.SMSyncSchedule, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "next_run_at", Value: 1}}).SetLimit(int64(limit))
+	cursor, err := r.schedules.Find(ctx, bson.M{
+		"enabled":     true,
+		"next_run_at": bson.M{"$lte": now},
+		"$or": bson.A{
+			bson.M{"locked_until": bson.M{"$exists": false}},
+			bson.M{"locked_until": bson.M{"$lte": now}},
+		},
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var schedules []*model.SMSyncSchedule
+	if err := cursor.All(ctx, &schedules); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// ClaimSync takes the survey's sync lock until the given time, creating the status
+// document for surveys that were never scheduled. False when another sync holds it.
+func (r *smRepo) ClaimSync(ctx context.Context, surveyID string, now, until time.Time) (bool, error) {
+	opts := options.Update().SetUpsert(true)
+	_, err := r.schedules.UpdateOne(ctx,
+		bson.M{
+			"survey_id": surveyID,
+			"$or": bson.A{
+				bson.M{"locked_until": bson.M{"$exists": false}},
+				bson.M{"locked_until": bson.M{"$lte": now}},
+			},
+		},
+		bson.M{
+			"$set":         bson.M{"locked_until": until},
+			"$setOnInsert": bson.M{"created_at": now, "updated_at": now, "enabled": false, "interval_minutes": 0, "consecutive_failures": 0},
+		},
+		opts,
+	)
+	// A held lock makes the filter miss, so the upsert collides with the existing document
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// CompleteSync stores the outcome of a sync and releases the lock
+func (r *smRepo) CompleteSync(ctx context.Context, schedule *model.SMSyncSchedule) error {
+	set := bson.M{
+		"last_run_at":          schedule.LastRunAt,
+		"last_status":          schedule.LastStatus,
+		"last_error":           schedule.LastError,
+		"last_duration_ms":     schedule.LastDurationMs,
+		"consecutive_failures": schedule.ConsecutiveFailures,
+		"updated_at":           schedule.UpdatedAt,
+	}
+	if schedule.LastSuccessAt != nil {
+		set["last_success_at"] = schedule.LastSuccessAt
+		set["last_result"] = schedule.LastResult
+	}
+	if schedule.NextRunAt != nil {
+		set["next_run_at"] = schedule.NextRunAt
+	}
+	update := bson.M{"$set": set, "$unset": bson.M{"locked_until": ""}}
+	_, err := r.schedules.UpdateOne(ctx, bson.M{"survey_id": schedule.SurveyID}, update)
+	return err
+}
+
 // Analytics aggregations
 
 func (r *smRepo) GetSurveySummary(ctx context.Context, surveyID string) (*model.SMSurveySummary, error) {
@@ -276,14 +352,27 @@ func (r *smRepo) GetSurveySummary(ctx context.Context, surveyID string) (*model.
 	if err != nil {
 		return nil, err
 	}
+	return SummarizeSMFeatures(features), nil
+}
 
+func (r *smRepo) GetDistribution(ctx context.Context, surveyID, metric string) (*model.SMDistribution, error) {
+	features, err := r.GetFeaturesBySurvey(ctx, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	return SMFeatureDistribution(features, metric), nil
+}
+
+// SummarizeSMFeatures computes a survey's summary from its response features;
+// every SMRepo implementation aggregates the same way
+func SummarizeSMFeatures(features []*model.SMResponseFeatures) *model.SMSurveySummary {
 	summary := &model.SMSurveySummary{
 		TotalResponses:   len(features),
 		TopFeatureCounts: []model.SMFeatureCount{},
 	}
 
 	if len(features) == 0 {
-		return summary, nil
+		return summary
 	}
 
 	// Calculate avg satisfaction and top features
@@ -333,15 +422,11 @@ func (r *smRepo) GetSurveySummary(ctx context.Context, surveyID string) (*model.
 		})
 	}
 
-	return summary, nil
+	return summary
 }
 
-func (r *smRepo) GetDistribution(ctx context.Context, surveyID, metric string) (*model.SMDistribution, error) {
-	features, err := r.GetFeaturesBySurvey(ctx, surveyID)
-	if err != nil {
-		return nil, err
-	}
-
+// SMFeatureDistribution builds the histogram of one rating metric over response features
+func SMFeatureDistribution(features []*model.SMResponseFeatures, metric string) *model.SMDistribution {
 	dist := &model.SMDistribution{
 		Metric:    metric,
 		Histogram: make(map[int]int),
@@ -364,5 +449,5 @@ func (r *smRepo) GetDistribution(ctx context.Context, surveyID, metric string) (
 		}
 	}
 
-	return dist, nil
+	return dist
 }
@@ -15,8 +15,14 @@ type SurveyRepo interface {
 	Create(ctx context.Context, survey *model.Survey) (string, error)
 	GetByID(ctx context.Context, id string) (*model.Survey, error)
 	GetByHostID(ctx context.Context, hostID string) ([]*model.Survey, error)
+	GetDeletedByHostID(ctx context.Context, hostID string) ([]*model.Survey, error)
+	// CountByHost counts the host's surveys outside the trash
+	CountByHost(ctx context.Context, hostID string) (int64, error)
 	Update(ctx context.Context, survey *model.Survey) error
-	Delete(ctx context.Context, id string) error
+	// SetThemeTaxonomy replaces a survey's theme taxonomy; nil removes it
+	SetThemeTaxonomy(ctx context.Context, id string, taxonomy *model.ThemeTaxonomy) error
+	SoftDelete(ctx context.Context, id string, at time.Time) (bool, error)
+	Restore(ctx context.Context, id string) (bool, error)
 }
 
 type surveyRepo struct {
@@ -64,8 +70,22 @@ func (r *surveyRepo) GetByID(ctx context.Context, id string) (*model.Survey, err
 	return &survey, nil
 }
 
+// GetByHostID lists a host's surveys, leaving out those in the trash
 func (r *surveyRepo) GetByHostID(ctx context.Context, hostID string) ([]*model.Survey, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{"hostId": hostID})
+	return r.find(ctx, bson.M{"hostId": hostID, "deletedAt": nil})
+}
+
+// GetDeletedByHostID lists a host's surveys in the trash
+func (r *surveyRepo) GetDeletedByHostID(ctx context.Context, hostID string) ([]*model.Survey, error) {
+	return r.find(ctx, bson.M{"hostId": hostID, "deletedAt": bson.M{"$ne": nil}})
+}
+
+func (r *surveyRepo) CountByHost(ctx context.Context, hostID string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"hostId": hostID, "deletedAt": nil})
+}
+
+func (r *surveyRepo) find(ctx context.Context, filter bson.M) ([]*model.Survey, error) {
+	cursor, err := r.collection.Find(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -94,6 +114,9 @@ func (r *surveyRepo) Update(ctx context.Context, survey *model.Survey) error {
 			"intent":     survey.Intent,
 			"settings":   survey.Settings,
 			"questions":  survey.Questions,
+			"branching":  survey.Branching,
+			"branding":   survey.Branding,
+			"guardrails": survey.Guardrails,
 			"smSurveyId": survey.SMSurveyID,
 			"smWebLink":  survey.SMWebLink,
 			"updatedAt":  survey.UpdatedAt,
@@ -104,12 +127,47 @@ func (r *surveyRepo) Update(ctx context.Context, survey *model.Survey) error {
 	return err
 }
 
-func (r *surveyRepo) Delete(ctx context.Context, id string) error {
+func (r *surveyRepo) SetThemeTaxonomy(ctx context.Context, id string, taxonomy *model.ThemeTaxonomy) error {
 	oid, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return err
 	}
 
-	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": oid})
+	update := bson.M{"$set": bson.M{"themeTaxonomy": taxonomy, "updatedAt": time.Now()}}
+	if taxonomy == nil {
+		update = bson.M{"$unset": bson.M{"themeTaxonomy": ""}, "$set": bson.M{"updatedAt": time.Now()}}
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, update)
 	return err
 }
+
+// SoftDelete moves a survey to the trash; returns false if it was missing or already there.
+// Rooms and reports keep resolving the survey through GetByID.
+func (r *surveyRepo) SoftDelete(ctx context.Context, id string, at time.Time) (bool, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, nil
+	}
+
+	filter := bson.M{"_id": oid, "deletedAt": nil}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"deletedAt": at}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// Restore takes a survey out of the trash; returns false if it was not there
+func (r *surveyRepo) Restore(ctx context.Context, id string) (bool, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, nil
+	}
+
+	filter := bson.M{"_id": oid, "deletedAt": bson.M{"$ne": nil}}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$unset": bson.M{"deletedAt": ""}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TemplateRepo handles MongoDB operations for room templates
+type TemplateRepo interface {
+	Create(ctx context.Context, template *model.RoomTemplate) (string, error)
+	// Get returns one of the host's templates; nil if it does not exist or is someone else's
+	Get(ctx context.Context, hostID, id string) (*model.RoomTemplate, error)
+	ListByHost(ctx context.Context, hostID string) ([]*model.RoomTemplate, error)
+	CountByHost(ctx context.Context, hostID string) (int64, error)
+	Replace(ctx context.Context, template *model.RoomTemplate) (bool, error)
+	Delete(ctx context.Context, hostID, id string) (bool, error)
+	MarkUsed(ctx context.Context, id string, at time.Time) error
+}
+
+type templateRepo struct {
+	collection *mongo.Collection
+}
+
+// NewTemplateRepo creates a new template repository; indexes are created by migrations
+func NewTemplateRepo(db *mongo.Database) TemplateRepo {
+	return &templateRepo{
+		collection: db.Collection("room_templates"),
+	}
+}
+
+func (r *templateRepo) Create(ctx context.Context, template *model.RoomTemplate) (string, error) {
+	template.CreatedAt = time.Now()
+	template.UpdatedAt = template.CreatedAt
+	result, err := r.collection.InsertOne(ctx, template)
+	if err != nil {
+		return "", err
+	}
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", nil
+	}
+	return oid.Hex(), nil
+}
+
+func (r *templateRepo) Get(ctx context.Context, hostID, id string) (*model.RoomTemplate, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	var template model.RoomTemplate
+	err = r.collection.FindOne(ctx, bson.M{"_id": oid, "hostId": hostID}).Decode(&template)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *templateRepo) ListByHost(ctx context.Context, hostID string) ([]*model.RoomTemplate, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"hostId": hostID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	templates := []*model.RoomTemplate{}
+	if err := cursor.All(ctx, &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (r *templateRepo) CountByHost(ctx context.Context, hostID string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"hostId": hostID})
+}
+
+// Replace overwrites the editable fields of a host's template; returns false if none matched
+func (r *templateRepo) Replace(ctx context.Context, template *model.RoomTemplate) (bool, error) {
+	oid, err := primitive.ObjectIDFromHex(template.ID)
+	if err != nil {
+		return false, nil
+	}
+	template.UpdatedAt = time.Now()
+	set := bson.M{
+		"name":      template.Name,
+		"surveyId":  template.SurveyID,
+		"settings":  template.Settings,
+		"hostNotes": template.HostNotes,
+		"updatedAt": template.UpdatedAt,
+	}
+	update := bson.M{"$set": set}
+	if template.Branding != nil {
+		set["branding"] = template.Branding
+	} else {
+		update["$unset"] = bson.M{"branding": ""}
+	}
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": oid, "hostId": template.HostID}, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// Delete removes a host's template; returns false if none matched
+func (r *templateRepo) Delete(ctx context.Context, hostID, id string) (bool, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, nil
+	}
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": oid, "hostId": hostID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// MarkUsed counts a room opened from the template
+func (r *templateRepo) MarkUsed(ctx context.Context, id string, at time.Time) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{
+		"$inc": bson.M{"useCount": 1},
+		"$set": bson.M{"lastUsedAt": at},
+	})
+	return err
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// VoiceRepo handles MongoDB operations for recorded voice answers
+type VoiceRepo interface {
+	Create(ctx context.Context, clip *model.VoiceClip) error
+	GetByID(ctx context.Context, id string) (*model.VoiceClip, error)
+	ListOlderThan(ctx context.Context, cutoff time.Time) ([]*model.VoiceClip, error)
+	ListByPlayer(ctx context.Context, roomCode, playerID string) ([]*model.VoiceClip, error)
+	DeleteByPlayer(ctx context.Context, roomCode, playerID string) (int64, error)
+}
+
+type voiceRepo struct {
+	collection *mongo.Collection
+}
+
+// NewVoiceRepo creates a new voice clip repository
+func NewVoiceRepo(db *mongo.Database) VoiceRepo {
+	repo := &voiceRepo{
+		collection: db.Collection("voice_clips"),
+	}
+	repo.ensureIndexes(context.Background())
+	return repo
+}
+
+func (r *voiceRepo) ensureIndexes(ctx context.Context) {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "createdAt", Value: 1}}},
+		{Keys: bson.D{{Key: "roomCode", Value: 1}, {Key: "playerId", Value: 1}}},
+	}
+	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		log.Printf("Warning: failed to create index on %s: %v", r.collection.Name(), err)
+	}
+}
+
+func (r *voiceRepo) Create(ctx context.Context, clip *model.VoiceClip) error {
+	clip.CreatedAt = time.Now()
+	_, err := r.collection.InsertOne(ctx, clip)
+	return err
+}
+
+func (r *voiceRepo) GetByID(ctx context.Context, id string) (*model.VoiceClip, error) {
+	var clip model.VoiceClip
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&clip)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &clip, nil
+}
+
+func (r *voiceRepo) ListOlderThan(ctx context.Context, cutoff time.Time) ([]*model.VoiceClip, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"createdAt": bson.M{"$lt": cutoff}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	clips := []*model.VoiceClip{}
+	if err := cursor.All(ctx, &clips); err != nil {
+		return nil, err
+	}
+	return clips, nil
+}
+
+// ListByPlayer returns a player's clips in a room, oldest first
+func (r *voiceRepo) ListByPlayer(ctx context.Context, roomCode, playerID string) ([]*model.VoiceClip, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"roomCode": roomCode, "playerId": playerID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	clips := []*model.VoiceClip{}
+	if err := cursor.All(ctx, &clips); err != nil {
+		return nil, err
+	}
+	return clips, nil
+}
+
+func (r *voiceRepo) DeleteByPlayer(ctx context.Context, roomCode, playerID string) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"roomCode": roomCode, "playerId": playerID})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"2026champs/internal/events"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrGuardrailViolation is returned when a generated follow-up touches a survey guardrail
+var ErrGuardrailViolation = errors.New("follow-up violates survey guardrails")
+
+// ErrAIDegraded is returned by callGeminiTuned when the budget is exhausted or the circuit breaker is open
+var ErrAIDegraded = errors.New("ai degraded: budget exhausted or circuit open")
+
+// Degradation reasons reported in the ai_degraded event
+const (
+	DegradedRoomBudget   = "room_budget_exhausted"
+	DegradedGlobalBudget = "global_budget_exhausted"
+	DegradedCircuitOpen  = "circuit_open"
+	DegradedHostPlan     = "host_plan_exhausted" // The host's monthly AI calls are used up
+)
+
+type aiRoomKey struct{}
+
+// WithAIRoom tags a context with the room an AI call is made for (budgets + degraded events)
+func WithAIRoom(ctx context.Context, roomCode string) context.Context {
+	return context.WithValue(ctx, aiRoomKey{}, roomCode)
+}
+
+func aiRoomFrom(ctx context.Context) string {
+	if v, ok := ctx.Value(aiRoomKey{}).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// circuitBreaker opens after N consecutive failures and half-opens after a cooldown
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// allow reports whether a call may proceed
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Now().After(b.openUntil)
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+}
+
+// recordFailure returns true if this failure tripped the breaker
+func (b *circuitBreaker) recordFailure() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.threshold > 0 && b.failures >= b.threshold {
+		b.failures = 0
+		b.openUntil = time.Now().Add(b.cooldown)
+		return true
+	}
+	return false
+}
+
+// estimateTokens is a rough chars/4 heuristic for budget accounting
+func estimateTokens(text string) int {
+	return len(text) / 4
+}
+
+// checkBudget returns a degradation reason if the call must not proceed
+func (s *EvaluatorService) checkBudget(ctx context.Context, roomCode string) string {
+	if !s.breaker.allow() {
+		return DegradedCircuitOpen
+	}
+	if s.usage == nil {
+		return ""
+	}
+
+	budget := s.config.Budget
+	if budget.MaxGlobalCalls > 0 || budget.MaxGlobalTokens > 0 {
+		if usage, err := s.usage.GetGlobalUsage(ctx); err == nil {
+			if (budget.MaxGlobalCalls > 0 && usage.Calls >= budget.MaxGlobalCalls) ||
+				(budget.MaxGlobalTokens > 0 && usage.EstimatedTokens >= budget.MaxGlobalTokens) {
+				return DegradedGlobalBudget
+			}
+		}
+	}
+	if roomCode != "" && (budget.MaxRoomCalls > 0 || budget.MaxRoomTokens > 0) {
+		if usage, err := s.usage.GetRoomUsage(ctx, roomCode); err == nil {
+			if (budget.MaxRoomCalls > 0 && usage.Calls >= budget.MaxRoomCalls) ||
+				(budget.MaxRoomTokens > 0 && usage.EstimatedTokens >= budget.MaxRoomTokens) {
+				return DegradedRoomBudget
+			}
+		}
+	}
+	if s.plans != nil {
+		if hostID := s.roomHost(ctx, roomCode); hostID != "" && !s.plans.AICallsLeft(ctx, hostID) {
+			return DegradedHostPlan
+		}
+	}
+	return ""
+}
+
+// roomHost returns the host of the room an AI call is made for; calls outside a
+// room count towards no host's plan
+func (s *EvaluatorService) roomHost(ctx context.Context, roomCode string) string {
+	if roomCode == "" || s.roomCache == nil {
+		return ""
+	}
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil || meta == nil {
+		return ""
+	}
+	return meta.HostID
+}
+
+// guardedCall enforces budgets and the circuit breaker around a Gemini call
+func (s *EvaluatorService) guardedCall(ctx context.Context, modelName, prompt string, call func() (string, error)) (string, error) {
+	roomCode := aiRoomFrom(ctx)
+	if reason := s.checkBudget(ctx, roomCode); reason != "" {
+		s.setDegraded(roomCode, reason)
+		return "", ErrAIDegraded
+	}
+
+	response, err := call()
+	if err != nil {
+		if s.breaker.recordFailure() {
+			fmt.Printf("[Gemini] Circuit breaker tripped after consecutive failures\n")
+			s.setDegraded(roomCode, DegradedCircuitOpen)
+		}
+		return "", err
+	}
+
+	s.breaker.recordSuccess()
+	s.recordUsage(ctx, roomCode, modelName, estimateTokens(prompt), estimateTokens(response))
+	s.setDegraded(roomCode, "")
+	return response, nil
+}
+
+// recordUsage adds a completed call to the room and global budgets
+func (s *EvaluatorService) recordUsage(ctx context.Context, roomCode, modelName string, inputTokens, outputTokens int) {
+	if s.usage == nil {
+		return
+	}
+	if err := s.usage.AddGlobalUsage(ctx, modelName, inputTokens, outputTokens); err != nil {
+		fmt.Printf("[Gemini] Failed to record global usage: %v\n", err)
+	}
+	if roomCode != "" {
+		if err := s.usage.AddRoomUsage(ctx, roomCode, modelName, inputTokens, outputTokens); err != nil {
+			fmt.Printf("[Gemini] Failed to record room usage: %v\n", err)
+		}
+	}
+	if hostID := s.roomHost(ctx, roomCode); hostID != "" {
+		if err := s.usage.AddHostUsage(ctx, hostID, modelName, inputTokens, outputTokens); err != nil {
+			fmt.Printf("[Gemini] Failed to record host usage: %v\n", err)
+		}
+	}
+}
+
+// setDegraded notifies the host when a room's AI mode changes (reason "" means recovered)
+func (s *EvaluatorService) setDegraded(roomCode, reason string) {
+	if roomCode == "" {
+		return
+	}
+	s.degradedMu.Lock()
+	prev := s.degraded[roomCode]
+	if prev == reason {
+		s.degradedMu.Unlock()
+		return
+	}
+	if reason == "" {
+		delete(s.degraded, roomCode)
+	} else {
+		s.degraded[roomCode] = reason
+	}
+	s.degradedMu.Unlock()
+
+	fmt.Printf("[Gemini] Room %s AI mode changed: %q -> %q\n", roomCode, prev, reason)
+	if s.broadcaster != nil {
+		s.broadcaster.ToHost(roomCode, events.AIDegraded, events.AIDegradedPayload{
+			Degraded: reason != "",
+			Reason:   reason,
+		})
+	}
+}
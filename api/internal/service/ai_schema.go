@@ -0,0 +1,541 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrAISchema is returned when a Gemini response still fails its schema after the corrective retry
+var ErrAISchema = errors.New("AI response does not match its schema")
+
+// Prompt templates whose responses are checked against a schema
+const (
+	aiTemplateEvaluation      = "evaluation"
+	aiTemplateBatchEvaluation = "batch_evaluation"
+	aiTemplateFollowUp        = "follow_up"
+	aiTemplateScopeAnchor     = "scope_anchor"
+	aiTemplateScopeCheck      = "scope_check"
+	aiTemplatePool            = "follow_up_pool"
+	aiTemplateProfileRefresh  = "profile_refresh"
+	aiTemplateRoomMemory      = "room_memory"
+	aiTemplateReport          = "report"
+	aiTemplatePlayerFeedback  = "player_feedback"
+	aiTemplateCopilot         = "copilot"
+	aiTemplateComparison      = "comparison"
+	aiTemplateDigest          = "digest"
+	aiTemplateCondenseProbes  = "condense_probes"
+)
+
+const (
+	maxSchemaErrors      = 8    // Schema errors reported per response
+	maxCorrectionExcerpt = 2000 // Characters of the invalid response quoted in the corrective prompt
+)
+
+// JSON value types a schema can require
+const (
+	schemaObject  = "object"
+	schemaArray   = "array"
+	schemaString  = "string"
+	schemaNumber  = "number"
+	schemaInteger = "integer"
+	schemaBoolean = "boolean"
+)
+
+// jsonSchema is the subset of JSON Schema the AI responses are checked against.
+// Fields that are not required may be absent or null.
+type jsonSchema struct {
+	Type        string
+	Description string // Sent to Gemini with structured output
+	Properties  map[string]*jsonSchema
+	Required    []string
+	Values      *jsonSchema // Object values under keys not listed in Properties (maps)
+	Items       *jsonSchema
+	MinItems    int
+	Enum        []string
+	Minimum     *float64
+	Maximum     *float64
+}
+
+func stringSchema() *jsonSchema { return &jsonSchema{Type: schemaString} }
+
+func enumSchema(values ...string) *jsonSchema {
+	return &jsonSchema{Type: schemaString, Enum: values}
+}
+
+func numberSchema(min, max float64) *jsonSchema {
+	return &jsonSchema{Type: schemaNumber, Minimum: &min, Maximum: &max}
+}
+
+func arraySchema(items *jsonSchema) *jsonSchema {
+	return &jsonSchema{Type: schemaArray, Items: items}
+}
+
+func objectSchema(required []string, properties map[string]*jsonSchema) *jsonSchema {
+	return &jsonSchema{Type: schemaObject, Required: required, Properties: properties}
+}
+
+// describe sets the description Gemini sees for the field
+func (sc *jsonSchema) describe(description string) *jsonSchema {
+	sc.Description = description
+	return sc
+}
+
+// validate appends the path-qualified mismatches between v and the schema to errs
+func (sc *jsonSchema) validate(path string, v interface{}, errs *[]string) {
+	if sc == nil || v == nil {
+		return
+	}
+	if kind := jsonKind(v); kind != sc.Type && !(sc.Type == schemaInteger && kind == schemaNumber) {
+		*errs = append(*errs, fmt.Sprintf("%s: expected %s, got %s", path, sc.Type, kind))
+		return
+	}
+
+	switch sc.Type {
+	case schemaObject:
+		obj := v.(map[string]interface{})
+		for _, key := range sc.Required {
+			if obj[key] == nil {
+				*errs = append(*errs, fmt.Sprintf("%s: missing required field %q", path, key))
+			}
+		}
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if prop, ok := sc.Properties[key]; ok {
+				prop.validate(path+"."+key, obj[key], errs)
+			} else if sc.Values != nil {
+				sc.Values.validate(path+"."+key, obj[key], errs)
+			}
+		}
+	case schemaArray:
+		arr := v.([]interface{})
+		if len(arr) < sc.MinItems {
+			*errs = append(*errs, fmt.Sprintf("%s: expected at least %d items, got %d", path, sc.MinItems, len(arr)))
+		}
+		for i, item := range arr {
+			sc.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, errs)
+		}
+	case schemaString:
+		if len(sc.Enum) > 0 && !containsString(sc.Enum, v.(string)) {
+			*errs = append(*errs, fmt.Sprintf("%s: %q is not one of %s", path, v, strings.Join(sc.Enum, ", ")))
+		}
+	case schemaNumber, schemaInteger:
+		n := v.(float64)
+		if sc.Type == schemaInteger && n != math.Trunc(n) {
+			*errs = append(*errs, fmt.Sprintf("%s: expected integer, got %v", path, n))
+		}
+		if (sc.Minimum != nil && n < *sc.Minimum) || (sc.Maximum != nil && n > *sc.Maximum) {
+			*errs = append(*errs, fmt.Sprintf("%s: %v is outside %v to %v", path, n, *sc.Minimum, *sc.Maximum))
+		}
+	}
+}
+
+// jsonKind names the JSON type of a decoded value
+func jsonKind(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}:
+		return schemaObject
+	case []interface{}:
+		return schemaArray
+	case string:
+		return schemaString
+	case float64:
+		return schemaNumber
+	case bool:
+		return schemaBoolean
+	default:
+		return "null"
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluationProperties are the fields of one L1 evaluation
+func evaluationProperties() map[string]*jsonSchema {
+	return map[string]*jsonSchema{
+		"resolution":   enumSchema(string(model.ResolutionSat), string(model.ResolutionUnsat)),
+		"qualityScore": numberSchema(0, 1).describe("Answer quality on the grading scale"),
+		"signals": objectSchema([]string{"themes", "missing", "specificity", "clarity", "sentiment", "confidence_language", "summary"}, map[string]*jsonSchema{
+			"themes":              arraySchema(stringSchema()).describe("Key themes mentioned"),
+			"emergent_themes":     arraySchema(stringSchema()).describe("Themes outside the theme taxonomy, if one is given"),
+			"missing":             arraySchema(stringSchema()).describe("Truly missing required data based on the rubric"),
+			"specificity":         numberSchema(0, 1),
+			"clarity":             numberSchema(0, 1),
+			"sentiment":           numberSchema(-1, 1),
+			"confidence_language": numberSchema(0, 1),
+			"summary":             stringSchema().describe("One sentence summary of the answer"),
+			"cluster_hint":        stringSchema().describe("Optional grouping hint"),
+			"risk_flags":          arraySchema(stringSchema()).describe("e.g. toxicity, spam, irrelevant; empty if none"),
+		}),
+		"followup_hint":  stringSchema().describe("Kind of follow-up that would help most: clarify, deepen, branch or challenge"),
+		"notes_for_host": stringSchema().describe("Optional private note for the host"),
+	}
+}
+
+// questionSchema is a generated question (follow-ups, pools, condensed probes)
+func questionSchema(required ...string) *jsonSchema {
+	return objectSchema(required, map[string]*jsonSchema{
+		"key":       stringSchema(),
+		"parentKey": stringSchema(),
+		"type":      stringSchema(),
+		"prompt":    stringSchema(),
+		"rubric":    stringSchema(),
+		"pointsMax": {Type: schemaInteger},
+		"threshold": numberSchema(0, 1),
+		"scaleMin":  {Type: schemaInteger},
+		"scaleMax":  {Type: schemaInteger},
+		"options":   arraySchema(stringSchema()),
+	})
+}
+
+// aiSchemas maps every prompt template to the schema its response must match
+var aiSchemas = func() map[string]*jsonSchema {
+	evaluation := objectSchema([]string{"signals", "resolution", "qualityScore"}, evaluationProperties())
+
+	batchItem := objectSchema([]string{"index", "signals", "resolution", "qualityScore"}, evaluationProperties())
+	batchItem.Properties["index"] = (&jsonSchema{Type: schemaInteger}).describe("The index of the answer evaluated")
+
+	followUp := objectSchema([]string{"type", "prompt", "pointsMax", "reason_in_scope"}, map[string]*jsonSchema{
+		"type":            enumSchema(string(model.QuestionTypeEssay), string(model.QuestionTypeMCQ)),
+		"prompt":          stringSchema().describe("Targeted, energetic follow-up question"),
+		"options":         arraySchema(stringSchema()).describe("Answer choices, MCQ only"),
+		"rubric":          stringSchema().describe("Short rubric"),
+		"pointsMax":       &jsonSchema{Type: schemaInteger},
+		"threshold":       numberSchema(0, 1),
+		"reason_in_scope": stringSchema().describe("Brief reason the follow-up is within the survey scope"),
+	})
+
+	pool := arraySchema(questionSchema("prompt", "type"))
+
+	return map[string]*jsonSchema{
+		aiTemplateEvaluation: evaluation,
+		aiTemplateBatchEvaluation: objectSchema([]string{"results"}, map[string]*jsonSchema{
+			"results": arraySchema(batchItem),
+		}),
+		aiTemplateFollowUp: objectSchema([]string{"followUps"}, map[string]*jsonSchema{
+			"followUps": arraySchema(followUp).describe("At most one follow-up; empty if the answer is already sufficiently narrow"),
+		}),
+		aiTemplateScopeAnchor: objectSchema([]string{"summary", "inScope", "outOfScope"}, map[string]*jsonSchema{
+			"summary":    stringSchema(),
+			"inScope":    arraySchema(stringSchema()),
+			"outOfScope": arraySchema(stringSchema()),
+		}),
+		aiTemplateScopeCheck: objectSchema([]string{"inScope"}, map[string]*jsonSchema{
+			"inScope": {Type: schemaBoolean},
+			"reason":  stringSchema(),
+		}),
+		aiTemplatePool: objectSchema(nil, map[string]*jsonSchema{
+			"clarify":   pool,
+			"deepen":    pool,
+			"branch":    pool,
+			"challenge": pool,
+			"compare":   pool,
+		}),
+		aiTemplateProfileRefresh: objectSchema([]string{"misunderstandings", "bestProbes"}, map[string]*jsonSchema{
+			"misunderstandings": arraySchema(stringSchema()),
+			"bestProbes":        arraySchema(stringSchema()),
+		}),
+		aiTemplateRoomMemory: objectSchema([]string{"contrasts"}, map[string]*jsonSchema{
+			"contrasts": arraySchema(objectSchema([]string{"axis", "sideA", "sideB"}, map[string]*jsonSchema{
+				"axis":       stringSchema(),
+				"sideA":      stringSchema(),
+				"sideB":      stringSchema(),
+				"sideACount": {Type: schemaInteger},
+				"sideBCount": {Type: schemaInteger},
+			})),
+			"frictionReasons":   {Type: schemaObject, Values: stringSchema()},
+			"recommendedProbes": arraySchema(stringSchema()),
+		}),
+		aiTemplateReport: objectSchema([]string{"executiveSummary", "keyThemes", "contrasts", "perQuestionInsights", "frictionAnalysis", "recommendedQuestions", "recommendedEdits"}, map[string]*jsonSchema{
+			"executiveSummary": (&jsonSchema{Type: schemaArray, Items: stringSchema(), MinItems: 1}).describe("About 5 key findings"),
+			"keyThemes": arraySchema(objectSchema([]string{"name", "meaning", "percentage", "evidenceSnippets"}, map[string]*jsonSchema{
+				"name":             stringSchema(),
+				"meaning":          stringSchema().describe("What the theme means for the survey"),
+				"percentage":       (&jsonSchema{Type: schemaNumber}).describe("Share of players raising the theme"),
+				"evidenceSnippets": arraySchema(stringSchema()).describe("Short quotes from the evidence samples, without markers"),
+			})),
+			"contrasts": arraySchema(objectSchema([]string{"axis", "sideA", "sideB"}, map[string]*jsonSchema{
+				"axis":      stringSchema().describe("What players disagree on"),
+				"sideA":     stringSchema(),
+				"sideB":     stringSchema(),
+				"predictor": stringSchema().describe("What predicts each side"),
+			})),
+			"perQuestionInsights": arraySchema(objectSchema([]string{"questionKey"}, map[string]*jsonSchema{
+				"questionKey":       stringSchema(),
+				"whatWorked":        arraySchema(stringSchema()),
+				"misunderstandings": arraySchema(stringSchema()),
+				"missingDetails":    arraySchema(stringSchema()),
+				"bestFollowUps":     arraySchema(stringSchema()),
+			})),
+			"frictionAnalysis": arraySchema(objectSchema([]string{"questionKey"}, map[string]*jsonSchema{
+				"questionKey":        stringSchema(),
+				"issueDescription":   stringSchema(),
+				"hypothesizedReason": stringSchema(),
+			})),
+			"recommendedQuestions": arraySchema(stringSchema()).describe("New questions worth asking next time"),
+			"recommendedEdits": arraySchema(objectSchema([]string{"questionKey", "suggestedText", "reason"}, map[string]*jsonSchema{
+				"questionKey":   stringSchema(),
+				"currentText":   stringSchema(),
+				"suggestedText": stringSchema(),
+				"reason":        stringSchema(),
+			})),
+		}),
+		aiTemplatePlayerFeedback: objectSchema([]string{"message"}, map[string]*jsonSchema{
+			"message": stringSchema(),
+		}),
+		aiTemplateCopilot: objectSchema([]string{"hints"}, map[string]*jsonSchema{
+			"hints": arraySchema(objectSchema([]string{"text"}, map[string]*jsonSchema{
+				"text":        stringSchema(),
+				"questionKey": stringSchema(),
+			})),
+		}),
+		aiTemplateComparison: objectSchema([]string{"summary", "verdict"}, map[string]*jsonSchema{
+			"summary": {Type: schemaArray, Items: stringSchema(), MinItems: 1},
+			"verdict": enumSchema(model.ComparisonImproved, model.ComparisonMixed, model.ComparisonWorse, model.ComparisonUnchanged),
+			"themeNotes": arraySchema(objectSchema([]string{"theme", "note"}, map[string]*jsonSchema{
+				"theme": stringSchema(),
+				"note":  stringSchema(),
+			})),
+			"frictionReasons": arraySchema(objectSchema([]string{"questionKey", "hypothesizedReason"}, map[string]*jsonSchema{
+				"questionKey":        stringSchema(),
+				"hypothesizedReason": stringSchema(),
+			})),
+			"recommendations": arraySchema(stringSchema()),
+		}),
+		aiTemplateDigest: objectSchema([]string{"highlights"}, map[string]*jsonSchema{
+			"highlights": {Type: schemaArray, Items: stringSchema(), MinItems: 1},
+		}),
+		aiTemplateCondenseProbes: objectSchema([]string{"questions"}, map[string]*jsonSchema{
+			"questions": arraySchema(questionSchema("key", "type", "prompt")),
+		}),
+	}
+}()
+
+// structuredTemplates send their schema to Gemini as the responseSchema, so the
+// model is constrained to it and the prompts describe the task, not the format
+var structuredTemplates = []string{aiTemplateEvaluation, aiTemplateBatchEvaluation, aiTemplateFollowUp, aiTemplateReport}
+
+// responseSchemas are the Gemini responseSchemas of the structured templates
+var responseSchemas = func() map[string]map[string]interface{} {
+	out := make(map[string]map[string]interface{}, len(structuredTemplates))
+	for _, template := range structuredTemplates {
+		out[template] = aiSchemas[template].geminiSchema()
+	}
+	return out
+}()
+
+// geminiSchema renders the schema in Gemini's responseSchema format (an OpenAPI 3.0
+// subset). Required fields come first in the output, in the order listed, so
+// templates list their reasoning fields before their scores. Map values (Values)
+// cannot be expressed; templates using them are not structured.
+func (sc *jsonSchema) geminiSchema() map[string]interface{} {
+	out := map[string]interface{}{"type": strings.ToUpper(sc.Type)}
+	if sc.Description != "" {
+		out["description"] = sc.Description
+	}
+	if len(sc.Enum) > 0 {
+		out["format"] = "enum"
+		out["enum"] = sc.Enum
+	}
+	if sc.Minimum != nil {
+		out["minimum"] = *sc.Minimum
+	}
+	if sc.Maximum != nil {
+		out["maximum"] = *sc.Maximum
+	}
+	if sc.Items != nil {
+		out["items"] = sc.Items.geminiSchema()
+	}
+	if sc.MinItems > 0 {
+		out["minItems"] = sc.MinItems
+	}
+	if len(sc.Properties) > 0 {
+		properties := make(map[string]interface{}, len(sc.Properties))
+		optional := make([]string, 0, len(sc.Properties))
+		for name, prop := range sc.Properties {
+			properties[name] = prop.geminiSchema()
+			if !containsString(sc.Required, name) {
+				optional = append(optional, name)
+			}
+		}
+		sort.Strings(optional)
+		out["properties"] = properties
+		out["propertyOrdering"] = append(append([]string{}, sc.Required...), optional...)
+	}
+	if len(sc.Required) > 0 {
+		out["required"] = sc.Required
+	}
+	return out
+}
+
+// checkAIResponse decodes a response into out after checking it against the
+// template's schema; it returns the problems found, nil when out was filled
+func checkAIResponse(template, response string, out interface{}) []string {
+	var raw interface{}
+	if err := json.Unmarshal([]byte(response), &raw); err != nil {
+		return []string{"$: invalid JSON: " + err.Error()}
+	}
+
+	var errs []string
+	aiSchemas[template].validate("$", raw, &errs)
+	if len(errs) > maxSchemaErrors {
+		errs = append(errs[:maxSchemaErrors], fmt.Sprintf("... and %d more", len(errs)-maxSchemaErrors))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	if err := json.Unmarshal([]byte(response), out); err != nil {
+		return []string{"$: " + err.Error()}
+	}
+	return nil
+}
+
+// callGeminiJSON makes a Gemini call and decodes its response into out through
+// decodeAIResponse
+func (s *EvaluatorService) callGeminiJSON(ctx context.Context, template, modelName, prompt string, temperature *float64, out interface{}) error {
+	response, err := s.callGeminiTuned(ctx, modelName, prompt, temperature, responseSchemas[template])
+	if err != nil {
+		return err
+	}
+	return s.decodeAIResponse(ctx, template, modelName, prompt, temperature, response, out)
+}
+
+// decodeAIResponse decodes a response to prompt into out. A response that does
+// not match the template's schema is sent back once with its errors and a
+// "fix your JSON" instruction; if the retry fails too, ErrAISchema is returned
+// with the errors so the caller can fall back.
+func (s *EvaluatorService) decodeAIResponse(ctx context.Context, template, modelName, prompt string, temperature *float64, response string, out interface{}) error {
+	errs := checkAIResponse(template, response, out)
+	s.schemaMetrics.record(template, errs)
+	if errs == nil {
+		return nil
+	}
+	fmt.Printf("[AISchema] %s response invalid, retrying: %s\n", template, strings.Join(errs, "; "))
+
+	s.schemaMetrics.retry(template)
+	retried, err := s.callGeminiTuned(ctx, modelName, buildCorrectionPrompt(prompt, response, errs), temperature, responseSchemas[template])
+	if err != nil {
+		s.schemaMetrics.giveUp(template, errs)
+		return fmt.Errorf("%w: %s: %s (retry failed: %v)", ErrAISchema, template, strings.Join(errs, "; "), err)
+	}
+	if retryErrs := checkAIResponse(template, retried, out); retryErrs != nil {
+		s.schemaMetrics.giveUp(template, retryErrs)
+		fmt.Printf("[AISchema] %s retry still invalid: %s\n", template, strings.Join(retryErrs, "; "))
+		return fmt.Errorf("%w: %s: %s", ErrAISchema, template, strings.Join(retryErrs, "; "))
+	}
+	s.schemaMetrics.recovered(template)
+	return nil
+}
+
+// buildCorrectionPrompt repeats the original prompt with the invalid response and its schema errors
+func buildCorrectionPrompt(prompt, response string, errs []string) string {
+	return fmt.Sprintf(`%s
+
+---
+Your previous reply to the request above did not match the required JSON format:
+%s
+
+Problems found:
+- %s
+
+Fix your JSON. Return ONLY the corrected JSON object in the format requested above, with no markdown and no commentary.`,
+		prompt, truncateForPrompt(response, maxCorrectionExcerpt), strings.Join(errs, "\n- "))
+}
+
+// aiSchemaMetrics counts schema failures per prompt template since the process started
+type aiSchemaMetrics struct {
+	mu         sync.Mutex
+	since      time.Time
+	byTemplate map[string]*model.AISchemaStats
+}
+
+func newAISchemaMetrics() *aiSchemaMetrics {
+	return &aiSchemaMetrics{since: time.Now(), byTemplate: make(map[string]*model.AISchemaStats)}
+}
+
+// stats returns the template's counters; the caller holds mu
+func (m *aiSchemaMetrics) stats(template string) *model.AISchemaStats {
+	st, ok := m.byTemplate[template]
+	if !ok {
+		st = &model.AISchemaStats{}
+		m.byTemplate[template] = st
+	}
+	return st
+}
+
+// record counts a first response and whether it failed the schema
+func (m *aiSchemaMetrics) record(template string, errs []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	st := m.stats(template)
+	st.Responses++
+	if errs != nil {
+		st.Invalid++
+		m.setLastError(st, errs)
+	}
+}
+
+func (m *aiSchemaMetrics) retry(template string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stats(template).Retries++
+}
+
+func (m *aiSchemaMetrics) recovered(template string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stats(template).Recovered++
+}
+
+func (m *aiSchemaMetrics) giveUp(template string, errs []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	st := m.stats(template)
+	st.Failed++
+	m.setLastError(st, errs)
+}
+
+func (m *aiSchemaMetrics) setLastError(st *model.AISchemaStats, errs []string) {
+	now := time.Now()
+	st.LastError = strings.Join(errs, "; ")
+	st.LastErrorAt = &now
+}
+
+// Snapshot returns a copy of the counters with each template's failure rate
+func (m *aiSchemaMetrics) Snapshot() model.AISchemaReport {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	report := model.AISchemaReport{Since: m.since, Templates: make(map[string]model.AISchemaStats, len(m.byTemplate))}
+	for template, st := range m.byTemplate {
+		stats := *st
+		if stats.Responses > 0 {
+			stats.FailureRate = float64(stats.Invalid) / float64(stats.Responses)
+		}
+		report.Templates[template] = stats
+	}
+	return report
+}
+
+// SchemaMetrics returns how often each prompt template's responses failed their schema on this instance
+func (s *EvaluatorService) SchemaMetrics() model.AISchemaReport {
+	return s.schemaMetrics.Snapshot()
+}
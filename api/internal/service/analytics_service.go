@@ -4,12 +4,30 @@ import (
 	"2026champs/internal/cache"
 	"2026champs/internal/model"
 	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// frictionMinAnswers is the fewest answers before a question can be a friction point
+	frictionMinAnswers = 3
+	// frictionThreshold is the combined skip+unsat rate that marks a friction point
+	frictionThreshold = 0.4
+	// maxOutlierThemes caps the rare themes kept in room memory
+	maxOutlierThemes = 5
 )
 
 // AnalyticsService manages L2-L4 analytics updates
 type AnalyticsService struct {
 	analyticsCache cache.AnalyticsCache
 	evaluator      *EvaluatorService
+	broadcaster    Broadcaster
+
+	roomsMu     sync.Mutex
+	activeRooms map[string][]string // roomCode -> question keys
 }
 
 // NewAnalyticsService creates a new analytics service
@@ -17,9 +35,15 @@ func NewAnalyticsService(analyticsCache cache.AnalyticsCache, evaluator *Evaluat
 	return &AnalyticsService{
 		analyticsCache: analyticsCache,
 		evaluator:      evaluator,
+		activeRooms:    make(map[string][]string),
 	}
 }
 
+// SetBroadcaster sets the broadcaster for analytics_update events
+func (s *AnalyticsService) SetBroadcaster(b Broadcaster) {
+	s.broadcaster = b
+}
+
 // UpdatePlayerProfile updates L2 analytics after an answer
 func (s *AnalyticsService) UpdatePlayerProfile(ctx context.Context, roomCode, playerID string, signals *model.Signals, resolution model.AnswerResolution) error {
 	profile, err := s.analyticsCache.GetPlayerProfile(ctx, roomCode, playerID)
@@ -80,7 +104,7 @@ func (s *AnalyticsService) UpdatePlayerProfile(ctx context.Context, roomCode, pl
 }
 
 // UpdateQuestionProfile updates L3 analytics after an answer
-func (s *AnalyticsService) UpdateQuestionProfile(ctx context.Context, roomCode, questionKey string, signals *model.Signals, resolution model.AnswerResolution, degreeValue int, optionIndex *int) error {
+func (s *AnalyticsService) UpdateQuestionProfile(ctx context.Context, roomCode, questionKey string, signals *model.Signals, resolution model.AnswerResolution, degreeValue int, optionIndex *int, matrixValues []int, ranking []int) error {
 	profile, err := s.analyticsCache.GetQuestionProfile(ctx, roomCode, questionKey)
 	if err != nil {
 		return err
@@ -120,11 +144,47 @@ func (s *AnalyticsService) UpdateQuestionProfile(ctx context.Context, roomCode,
 		profile.OptionHist[*optionIndex]++
 	}
 
+	// Update per-row column counts (for MATRIX type)
+	if len(matrixValues) > 0 {
+		if profile.MatrixHist == nil {
+			profile.MatrixHist = make(map[int]map[int]int)
+		}
+		for row, col := range matrixValues {
+			if profile.MatrixHist[row] == nil {
+				profile.MatrixHist[row] = make(map[int]int)
+			}
+			profile.MatrixHist[row][col]++
+		}
+	}
+
+	// Update Borda scores and mean positions (for RANKING type)
+	if len(ranking) > 0 {
+		if profile.RankPositionSum == nil {
+			profile.RankPositionSum = make(map[int]int)
+			profile.BordaScores = make(map[int]int)
+		}
+		profile.RankCount++
+		for pos, opt := range ranking {
+			profile.RankPositionSum[opt] += pos + 1
+			profile.BordaScores[opt] += len(ranking) - 1 - pos
+		}
+		profile.AvgPosition = make(map[int]float64, len(profile.RankPositionSum))
+		for opt, sum := range profile.RankPositionSum {
+			profile.AvgPosition[opt] = float64(sum) / float64(profile.RankCount)
+		}
+	}
+
 	// Update theme and missing counts from signals
 	if signals != nil {
 		for _, theme := range signals.Themes {
 			profile.ThemeCounts[theme]++
 		}
+		for _, theme := range signals.EmergentThemes {
+			if profile.EmergentCounts == nil {
+				profile.EmergentCounts = make(map[string]int)
+			}
+			profile.EmergentCounts[theme]++
+		}
 		for _, missing := range signals.Missing {
 			profile.MissingCounts[missing]++
 		}
@@ -133,6 +193,93 @@ func (s *AnalyticsService) UpdateQuestionProfile(ctx context.Context, roomCode,
 	return s.analyticsCache.SetQuestionProfile(ctx, profile)
 }
 
+// RecordResponseTime adds one serve-to-submit time to the question's samples
+// and refreshes the percentiles in its profile
+func (s *AnalyticsService) RecordResponseTime(ctx context.Context, roomCode, questionKey string, ms int64) error {
+	samples, err := s.analyticsCache.AddResponseTime(ctx, roomCode, questionKey, ms)
+	if err != nil {
+		return err
+	}
+	profile, err := s.analyticsCache.GetQuestionProfile(ctx, roomCode, questionKey)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		profile = &model.QuestionProfile{
+			RoomCode:      roomCode,
+			QuestionKey:   questionKey,
+			ThemeCounts:   make(map[string]int),
+			MissingCounts: make(map[string]int),
+			RatingHist:    make(map[int]int),
+			OptionHist:    make(map[int]int),
+		}
+	}
+	profile.ResponseTime = responseTimeStats(samples)
+	return s.analyticsCache.SetQuestionProfile(ctx, profile)
+}
+
+// RecordSkipLocked counts a skip attempt refused by skip gating
+func (s *AnalyticsService) RecordSkipLocked(ctx context.Context, roomCode, questionKey string) error {
+	profile, err := s.analyticsCache.GetQuestionProfile(ctx, roomCode, questionKey)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		profile = &model.QuestionProfile{
+			RoomCode:      roomCode,
+			QuestionKey:   questionKey,
+			ThemeCounts:   make(map[string]int),
+			MissingCounts: make(map[string]int),
+			RatingHist:    make(map[int]int),
+			OptionHist:    make(map[int]int),
+		}
+	}
+	profile.SkipLockedCount++
+	return s.analyticsCache.SetQuestionProfile(ctx, profile)
+}
+
+// RecordDuplicate counts an ESSAY answer flagged as a copy of another player's
+func (s *AnalyticsService) RecordDuplicate(ctx context.Context, roomCode, questionKey string) error {
+	profile, err := s.analyticsCache.GetQuestionProfile(ctx, roomCode, questionKey)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		profile = &model.QuestionProfile{
+			RoomCode:      roomCode,
+			QuestionKey:   questionKey,
+			ThemeCounts:   make(map[string]int),
+			MissingCounts: make(map[string]int),
+			RatingHist:    make(map[int]int),
+			OptionHist:    make(map[int]int),
+		}
+	}
+	profile.DuplicateCount++
+	return s.analyticsCache.SetQuestionProfile(ctx, profile)
+}
+
+// responseTimeStats computes nearest-rank percentiles; nil without samples
+func responseTimeStats(samples []int64) *model.ResponseTimeStats {
+	if len(samples) == 0 {
+		return nil
+	}
+	sorted := append([]int64(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := func(p float64) int64 {
+		i := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return sorted[i]
+	}
+	return &model.ResponseTimeStats{
+		Count:    len(sorted),
+		MedianMs: rank(0.5),
+		P75Ms:    rank(0.75),
+		P90Ms:    rank(0.9),
+	}
+}
+
 // UpdateRoomMemory updates L4 analytics
 func (s *AnalyticsService) UpdateRoomMemory(ctx context.Context, roomCode string, signals *model.Signals) error {
 	memory, err := s.analyticsCache.GetRoomMemory(ctx, roomCode)
@@ -185,6 +332,16 @@ func (s *AnalyticsService) GetQuestionProfile(ctx context.Context, roomCode, que
 	return s.analyticsCache.GetQuestionProfile(ctx, roomCode, questionKey)
 }
 
+// GetPlayerProfile returns the player's live L2 profile
+func (s *AnalyticsService) GetPlayerProfile(ctx context.Context, roomCode, playerID string) (*model.PlayerProfile, error) {
+	return s.analyticsCache.GetPlayerProfile(ctx, roomCode, playerID)
+}
+
+// ListQuestionProfiles returns the room's live question profiles, follow-ups included
+func (s *AnalyticsService) ListQuestionProfiles(ctx context.Context, roomCode string) ([]*model.QuestionProfile, error) {
+	return s.analyticsCache.ListQuestionProfiles(ctx, roomCode)
+}
+
 // GetRoomMemory returns L4 room memory
 func (s *AnalyticsService) GetRoomMemory(ctx context.Context, roomCode string) (*model.RoomMemory, error) {
 	return s.analyticsCache.GetRoomMemory(ctx, roomCode)
@@ -202,10 +359,153 @@ func (s *AnalyticsService) RefreshL3(ctx context.Context, roomCode, questionKey
 		return nil
 	}
 
-	updated, err := s.evaluator.RefreshQuestionProfile(ctx, profile, recentSummaries)
+	updated, err := s.evaluator.RefreshQuestionProfile(WithAIRoom(ctx, roomCode), profile, recentSummaries)
 	if err != nil {
 		return err
 	}
 
 	return s.analyticsCache.SetQuestionProfile(ctx, updated)
 }
+
+// TrackRoom registers a live room for the periodic L4 job
+func (s *AnalyticsService) TrackRoom(roomCode string, questionKeys []string) {
+	s.roomsMu.Lock()
+	defer s.roomsMu.Unlock()
+	s.activeRooms[roomCode] = questionKeys
+}
+
+// UntrackRoom removes a room from the periodic L4 job
+func (s *AnalyticsService) UntrackRoom(roomCode string) {
+	s.roomsMu.Lock()
+	defer s.roomsMu.Unlock()
+	delete(s.activeRooms, roomCode)
+}
+
+// TrackedRooms returns a copy of the live rooms and their question keys
+func (s *AnalyticsService) TrackedRooms() map[string][]string {
+	s.roomsMu.Lock()
+	defer s.roomsMu.Unlock()
+	rooms := make(map[string][]string, len(s.activeRooms))
+	for code, keys := range s.activeRooms {
+		rooms[code] = keys
+	}
+	return rooms
+}
+
+// StartL4Job refreshes room memory for every tracked room on each interval until ctx is done
+func (s *AnalyticsService) StartL4Job(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				for code, keys := range s.TrackedRooms() {
+					if err := s.RefreshL4(ctx, code, keys); err != nil {
+						fmt.Printf("[L4Refresh] Room %s: %v\n", code, err)
+					}
+				}
+			}
+		}
+	}()
+}
+
+// RefreshL4 derives friction points and outlier themes from L3 profiles and
+// asks the AI for contrast axes and recommended probes
+func (s *AnalyticsService) RefreshL4(ctx context.Context, roomCode string, questionKeys []string) error {
+	profiles := make([]*model.QuestionProfile, 0, len(questionKeys))
+	for _, qKey := range questionKeys {
+		profile, err := s.analyticsCache.GetQuestionProfile(ctx, roomCode, qKey)
+		if err != nil {
+			return err
+		}
+		if profile != nil && profile.AnswerCount > 0 {
+			profiles = append(profiles, profile)
+		}
+	}
+	if len(profiles) == 0 {
+		return nil
+	}
+
+	memory, err := s.analyticsCache.GetRoomMemory(ctx, roomCode)
+	if err != nil {
+		return err
+	}
+	if memory == nil {
+		memory = &model.RoomMemory{RoomCode: roomCode}
+	}
+
+	memory.FrictionPoints = frictionPoints(profiles)
+	memory.OutlierThemes = outlierThemes(profiles)
+
+	refreshed, err := s.evaluator.RefreshRoomMemory(WithAIRoom(ctx, roomCode), memory, profiles)
+	if err != nil {
+		return err
+	}
+
+	// Re-read so answer-driven counters written during the AI call are not lost
+	latest, err := s.analyticsCache.GetRoomMemory(ctx, roomCode)
+	if err != nil {
+		return err
+	}
+	if latest == nil {
+		latest = refreshed
+	}
+	latest.FrictionPoints = refreshed.FrictionPoints
+	latest.OutlierThemes = refreshed.OutlierThemes
+	latest.Contrasts = refreshed.Contrasts
+	latest.RecommendedProbes = refreshed.RecommendedProbes
+	latest.UpdatedAt = time.Now()
+
+	return s.analyticsCache.SetRoomMemory(ctx, latest)
+}
+
+// frictionPoints returns questions whose skip+unsat rate crosses the threshold, worst first
+func frictionPoints(profiles []*model.QuestionProfile) []model.FrictionPoint {
+	points := []model.FrictionPoint{}
+	for _, p := range profiles {
+		if p.AnswerCount < frictionMinAnswers {
+			continue
+		}
+		skipRate := float64(p.SkipCount) / float64(p.AnswerCount)
+		unsatRate := float64(p.UnsatCount) / float64(p.AnswerCount)
+		if skipRate+unsatRate >= frictionThreshold {
+			points = append(points, model.FrictionPoint{
+				QuestionKey: p.QuestionKey,
+				SkipRate:    skipRate,
+				UnsatRate:   unsatRate,
+			})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].SkipRate+points[i].UnsatRate > points[j].SkipRate+points[j].UnsatRate
+	})
+	return points
+}
+
+// outlierThemes returns themes mentioned exactly once across the room
+func outlierThemes(profiles []*model.QuestionProfile) []string {
+	counts := make(map[string]int)
+	for _, p := range profiles {
+		for theme, count := range p.ThemeCounts {
+			counts[theme] += count
+		}
+	}
+
+	outliers := []string{}
+	for theme, count := range counts {
+		if count == 1 {
+			outliers = append(outliers, theme)
+		}
+	}
+	sort.Strings(outliers)
+	if len(outliers) > maxOutlierThemes {
+		outliers = outliers[:maxOutlierThemes]
+	}
+	return outliers
+}
@@ -2,13 +2,19 @@ package service
 
 import (
 	"2026champs/internal/cache"
+	"2026champs/internal/events"
 	"2026champs/internal/model"
 	"2026champs/internal/repository"
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 )
 
 // AnswerService handles answer submission, drafts, and skips
@@ -23,6 +29,16 @@ type AnswerService struct {
 	evaluator    *EvaluatorService
 	broadcaster  Broadcaster
 	analyticsSvc *AnalyticsService
+	evalCache    cache.EvalCache
+	duplicates   cache.DuplicateCache
+	evalBatcher  *EvalBatcher
+	quotaSvc     *QuotaService
+	attachSvc    *AttachmentService
+	voiceSvc     *VoiceService
+	auditSvc     *AuditService
+	timeseries   *TimeseriesService
+	changes      *ChangeNotifier // Tells hosts about stored answers while its stream is open
+	inFlight     sync.WaitGroup  // async evaluation jobs, waited on during drain
 }
 
 // NewAnswerService creates a new answer service
@@ -56,6 +72,464 @@ func (s *AnswerService) SetAnalyticsService(svc *AnalyticsService) {
 	s.analyticsSvc = svc
 }
 
+// SetEvalCache enables reuse of evaluations for near-identical short answers
+func (s *AnswerService) SetEvalCache(c cache.EvalCache) {
+	s.evalCache = c
+}
+
+// SetDuplicateCache enables flagging of ESSAY answers copied from other players
+func (s *AnswerService) SetDuplicateCache(c cache.DuplicateCache) {
+	s.duplicates = c
+}
+
+// SetEvalBatcher routes L1 evaluations through the batcher
+func (s *AnswerService) SetEvalBatcher(b *EvalBatcher) {
+	s.evalBatcher = b
+}
+
+// SetQuotaService enables screening quotas on MCQ answers
+func (s *AnswerService) SetQuotaService(q *QuotaService) {
+	s.quotaSvc = q
+}
+
+// SetAttachmentService enables image attachments on questions that allow them
+func (s *AnswerService) SetAttachmentService(a *AttachmentService) {
+	s.attachSvc = a
+}
+
+// SetVoiceService enables recorded answers on ESSAY questions that allow them
+func (s *AnswerService) SetVoiceService(v *VoiceService) {
+	s.voiceSvc = v
+}
+
+// SetAuditService enables recording host overrides in the room audit trail
+func (s *AnswerService) SetAuditService(a *AuditService) {
+	s.auditSvc = a
+}
+
+// SetTimeseriesService counts submissions, skips and evaluation latency per minute
+func (s *AnswerService) SetTimeseriesService(t *TimeseriesService) {
+	s.timeseries = t
+}
+
+// SetChangeNotifier hands host notifications for stored answers to the change stream
+func (s *AnswerService) SetChangeNotifier(c *ChangeNotifier) {
+	s.changes = c
+}
+
+// WaitInFlight blocks until running evaluation jobs finish or ctx expires
+func (s *AnswerService) WaitInFlight(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("evaluations still running: %w", ctx.Err())
+	}
+}
+
+// ErrNotRoomHost is returned when a host acts on a room they do not own
+var ErrNotRoomHost = errors.New("unauthorized: not room host")
+
+// OverrideEvaluation lets the room host replace the AI resolution and points of an
+// evaluated answer. The original values are kept on the answer; the leaderboard is
+// adjusted by the points delta while the room is still active.
+func (s *AnswerService) OverrideEvaluation(ctx context.Context, roomCode, hostID, answerID string, req *model.OverrideRequest) (*model.OverrideResponse, error) {
+	if req.Resolution != model.ResolutionSat && req.Resolution != model.ResolutionUnsat {
+		return nil, fmt.Errorf("resolution must be SAT or UNSAT")
+	}
+
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room meta: %w", err)
+	}
+	if meta == nil {
+		return nil, nil
+	}
+	if meta.HostID != hostID {
+		return nil, ErrNotRoomHost
+	}
+
+	answer, err := s.answerRepo.GetByID(ctx, answerID)
+	if err != nil {
+		return nil, err
+	}
+	if answer == nil || answer.RoomCode != roomCode {
+		return nil, nil
+	}
+	if answer.Status != model.AnswerStatusEvaluated {
+		return nil, fmt.Errorf("answer has not been evaluated")
+	}
+
+	pointsMax := 0
+	if meta.HasSurvey() {
+		if q := meta.Question(answer.QuestionKey); q != nil {
+			pointsMax = q.PointsMax
+		}
+	} else {
+		survey, err := s.surveyRepo.GetByID(ctx, meta.SurveyID)
+		if err != nil {
+			return nil, err
+		}
+		if survey != nil {
+			if q := findBaseQuestion(survey, answer.QuestionKey); q != nil {
+				pointsMax = q.PointsMax
+			}
+		}
+	}
+	if q, _ := s.playerCache.GetQuestionMap(ctx, roomCode, answer.PlayerID, answer.QuestionKey); q != nil {
+		pointsMax = q.PointsMax
+	}
+
+	// Default points: full marks for SAT, none for UNSAT
+	points := 0
+	if req.Resolution == model.ResolutionSat {
+		points = pointsMax
+	}
+	if req.Points != nil {
+		points = *req.Points
+	}
+	if points < 0 || (pointsMax > 0 && points > pointsMax) {
+		return nil, fmt.Errorf("points must be between 0 and %d", pointsMax)
+	}
+
+	// Keep the AI's values from the first override
+	original := &model.AnswerOverride{
+		OriginalResolution: answer.Resolution,
+		OriginalPoints:     answer.PointsEarned,
+	}
+	if answer.Override != nil {
+		original = answer.Override
+	}
+	prevResolution, prevPoints := answer.Resolution, answer.PointsEarned
+	delta := points - prevPoints
+
+	answer.Override = &model.AnswerOverride{
+		HostID:             hostID,
+		OriginalResolution: original.OriginalResolution,
+		OriginalPoints:     original.OriginalPoints,
+		Resolution:         req.Resolution,
+		Points:             points,
+		PointsMax:          pointsMax,
+		Reason:             req.Reason,
+		OverriddenAt:       time.Now(),
+	}
+	answer.Resolution = req.Resolution
+	answer.PointsEarned = points
+	if err := s.answerRepo.Update(ctx, answer); err != nil {
+		return nil, fmt.Errorf("failed to save override: %w", err)
+	}
+	if s.auditSvc != nil {
+		s.auditSvc.Record(ctx, roomCode, hostID, model.AuditAnswerOverridden, map[string]interface{}{
+			"answerId":       answer.ID,
+			"playerId":       answer.PlayerID,
+			"questionKey":    answer.QuestionKey,
+			"fromResolution": string(prevResolution),
+			"fromPoints":     prevPoints,
+			"toResolution":   string(req.Resolution),
+			"toPoints":       points,
+			"reason":         req.Reason,
+		})
+	}
+
+	resp := &model.OverrideResponse{Answer: answer}
+	if meta.Status == model.RoomStatusActive {
+		score, err := s.playerSvc.UpdateScore(ctx, roomCode, answer.PlayerID, delta)
+		if err != nil {
+			fmt.Printf("[Override] Leaderboard update failed for %s: %v\n", answer.PlayerID, err)
+		} else {
+			resp.LeaderboardAdjusted = true
+			resp.PlayerScore = score
+		}
+	}
+
+	if s.broadcaster != nil {
+		s.broadcaster.ToPlayer(roomCode, answer.PlayerID, events.EvalOverridden, events.EvalOverriddenPayload{
+			AnswerID:     answer.ID,
+			QuestionKey:  answer.QuestionKey,
+			Resolution:   string(answer.Resolution),
+			PointsEarned: answer.PointsEarned,
+		})
+	}
+
+	return resp, nil
+}
+
+// StarAnswer lets the room host star an answer with an optional note, during or
+// after the session. Starring again replaces the note. Starred answers are listed
+// in the report snapshot and lead the evidence samples in the AI report.
+func (s *AnswerService) StarAnswer(ctx context.Context, roomCode, hostID, answerID string, req *model.StarRequest) (*model.Answer, error) {
+	note := strings.TrimSpace(req.Note)
+	if utf8.RuneCountInString(note) > model.MaxStarNoteChars {
+		return nil, fmt.Errorf("note must be at most %d characters", model.MaxStarNoteChars)
+	}
+
+	answer, err := s.hostAnswer(ctx, roomCode, hostID, answerID)
+	if err != nil || answer == nil {
+		return nil, err
+	}
+
+	star := &model.AnswerStar{HostID: hostID, Note: note, StarredAt: time.Now()}
+	if answer.Star != nil {
+		// Editing the note keeps the answer's place in the starred list
+		star.StarredAt = answer.Star.StarredAt
+	}
+	if err := s.answerRepo.SetStar(ctx, answer.ID, star); err != nil {
+		return nil, fmt.Errorf("failed to star answer: %w", err)
+	}
+	answer.Star = star
+
+	if s.auditSvc != nil {
+		s.auditSvc.Record(ctx, roomCode, hostID, model.AuditAnswerStarred, map[string]interface{}{
+			"answerId":    answer.ID,
+			"playerId":    answer.PlayerID,
+			"questionKey": answer.QuestionKey,
+			"note":        note,
+		})
+	}
+	return answer, nil
+}
+
+// UnstarAnswer removes the host's star and note from an answer
+func (s *AnswerService) UnstarAnswer(ctx context.Context, roomCode, hostID, answerID string) (*model.Answer, error) {
+	answer, err := s.hostAnswer(ctx, roomCode, hostID, answerID)
+	if err != nil || answer == nil {
+		return nil, err
+	}
+	if answer.Star == nil {
+		return answer, nil
+	}
+	if err := s.answerRepo.SetStar(ctx, answer.ID, nil); err != nil {
+		return nil, fmt.Errorf("failed to unstar answer: %w", err)
+	}
+	answer.Star = nil
+
+	if s.auditSvc != nil {
+		s.auditSvc.Record(ctx, roomCode, hostID, model.AuditAnswerUnstarred, map[string]interface{}{
+			"answerId":    answer.ID,
+			"playerId":    answer.PlayerID,
+			"questionKey": answer.QuestionKey,
+		})
+	}
+	return answer, nil
+}
+
+// ListRoomAnswers returns the room's answers, oldest first; with questionKey set
+// only the answers to that question. Callers check the room belongs to the host.
+func (s *AnswerService) ListRoomAnswers(ctx context.Context, roomCode, questionKey string) ([]*model.Answer, error) {
+	var answers []*model.Answer
+	var err error
+	if questionKey != "" {
+		answers, err = s.answerRepo.GetByRoomAndQuestion(ctx, roomCode, questionKey)
+	} else {
+		answers, err = s.answerRepo.GetByRoomCode(ctx, roomCode)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(answers, func(i, j int) bool {
+		return answers[i].CreatedAt.Before(answers[j].CreatedAt)
+	})
+	return answers, nil
+}
+
+// ListAnswersForRooms returns the answers of several rooms in one query, keyed by
+// room code, oldest first. Callers check the rooms belong to the host.
+func (s *AnswerService) ListAnswersForRooms(ctx context.Context, roomCodes []string) (map[string][]*model.Answer, error) {
+	answers, err := s.answerRepo.GetByRoomCodes(ctx, roomCodes)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(answers, func(i, j int) bool {
+		return answers[i].CreatedAt.Before(answers[j].CreatedAt)
+	})
+	byRoom := make(map[string][]*model.Answer, len(roomCodes))
+	for _, a := range answers {
+		byRoom[a.RoomCode] = append(byRoom[a.RoomCode], a)
+	}
+	return byRoom, nil
+}
+
+// hostAnswer loads an answer in the room after checking the caller hosts it;
+// nil when the room or answer does not exist
+func (s *AnswerService) hostAnswer(ctx context.Context, roomCode, hostID, answerID string) (*model.Answer, error) {
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room meta: %w", err)
+	}
+	if meta == nil {
+		return nil, nil
+	}
+	if meta.HostID != hostID {
+		return nil, ErrNotRoomHost
+	}
+
+	answer, err := s.answerRepo.GetByID(ctx, answerID)
+	if err != nil {
+		return nil, err
+	}
+	if answer == nil || answer.RoomCode != roomCode {
+		return nil, nil
+	}
+	return answer, nil
+}
+
+// applyStrictness returns a copy of an ESSAY question carrying the room's
+// strictness preset and the SAT threshold it implies
+func (s *AnswerService) applyStrictness(ctx context.Context, roomCode string, question *model.Question) *model.Question {
+	if question.Type != model.QuestionTypeEssay {
+		return question
+	}
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil || meta == nil {
+		return question
+	}
+	strictness := meta.Settings().StrictnessPreset()
+	if strictness == model.StrictnessStandard {
+		return question
+	}
+	q := *question
+	q.Strictness = strictness
+	q.Threshold = strictness.Threshold(question.Threshold)
+	return &q
+}
+
+// applyThemeTaxonomy returns a copy of an ESSAY question carrying the theme
+// taxonomy the room copied from its survey
+func (s *AnswerService) applyThemeTaxonomy(ctx context.Context, roomCode string, question *model.Question) *model.Question {
+	if question.Type != model.QuestionTypeEssay {
+		return question
+	}
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil || meta == nil || meta.Taxonomy.IsEmpty() {
+		return question
+	}
+	q := *question
+	q.Taxonomy = meta.Taxonomy
+	return &q
+}
+
+// essayMaxTries returns the room's submissions per ESSAY question
+func (s *AnswerService) essayMaxTries(ctx context.Context, roomCode string) int {
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil || meta == nil {
+		return model.DefaultMaxTries
+	}
+	return meta.Settings().EssayMaxTries()
+}
+
+// retryPartialShare is the share of full credit an improved UNSAT retry can earn
+const retryPartialShare = 0.5
+
+// maxRetryHints caps the missing details listed in retry guidance
+const maxRetryHints = 3
+
+// retryGuidance tells the player what to add before trying again, from the
+// details the evaluation found missing
+func retryGuidance(missing []string, triesLeft int) *model.RetryGuidance {
+	if len(missing) > maxRetryHints {
+		missing = missing[:maxRetryHints]
+	}
+	guidance := &model.RetryGuidance{TriesLeft: triesLeft, Missing: missing}
+	if len(missing) == 0 {
+		guidance.Guidance = "Your answer needs a bit more detail. Try adding a concrete example or explaining why."
+	} else {
+		guidance.Guidance = "Almost there! Try adding: " + strings.Join(missing, "; ") + "."
+	}
+	return guidance
+}
+
+// findBaseQuestion looks up a survey question by key (follow-ups resolve to nil)
+func findBaseQuestion(survey *model.Survey, key string) *model.BaseQuestion {
+	for i := range survey.Questions {
+		if survey.Questions[i].Key == key {
+			return &survey.Questions[i]
+		}
+	}
+	return nil
+}
+
+// AbandonUnanswered records an ABANDONED answer for every question still queued
+// (or current) for players who did not finish, in one write, and returns them.
+// Questions with a submission still being evaluated are left to that submission.
+func (s *AnswerService) AbandonUnanswered(ctx context.Context, roomCode string) ([]*model.Answer, error) {
+	players, err := s.playerCache.GetAllPlayers(ctx, roomCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get players: %w", err)
+	}
+	doneIDs, err := s.playerCache.GetDonePlayers(ctx, roomCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get done players: %w", err)
+	}
+	done := make(map[string]bool, len(doneIDs))
+	for _, id := range doneIDs {
+		done[id] = true
+	}
+
+	existing, err := s.answerRepo.GetByRoomCode(ctx, roomCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answers: %w", err)
+	}
+	answered := make(map[string]bool, len(existing))
+	for _, a := range existing {
+		answered[a.PlayerID+"|"+a.QuestionKey] = true
+	}
+
+	var abandoned []*model.Answer
+	for playerID := range players {
+		if done[playerID] {
+			continue
+		}
+
+		keys := []string{}
+		if current, _ := s.playerCache.GetCurrent(ctx, roomCode, playerID); current != "" {
+			keys = append(keys, current)
+		}
+		queue, _ := s.playerCache.GetQueue(ctx, roomCode, playerID)
+		keys = append(keys, queue...)
+		states, _ := s.playerCache.GetAttemptStates(ctx, roomCode, playerID, keys)
+
+		for _, key := range keys {
+			if answered[playerID+"|"+key] {
+				continue
+			}
+			if st := states[key]; st != nil && st.Status == model.AnswerStatusSubmitted {
+				continue
+			}
+			answered[playerID+"|"+key] = true
+			abandoned = append(abandoned, &model.Answer{
+				RoomCode:    roomCode,
+				PlayerID:    playerID,
+				QuestionKey: key,
+				Status:      model.AnswerStatusSubmitted,
+				Resolution:  model.ResolutionAbandoned,
+			})
+		}
+	}
+
+	if err := s.answerRepo.CreateMany(ctx, abandoned); err != nil {
+		return nil, fmt.Errorf("failed to save abandoned answers: %w", err)
+	}
+	return abandoned, nil
+}
+
+// DiscardAbandoned deletes answers recorded by AbandonUnanswered when ending the room fails
+func (s *AnswerService) DiscardAbandoned(ctx context.Context, abandoned []*model.Answer) error {
+	ids := make([]string, 0, len(abandoned))
+	for _, a := range abandoned {
+		if a.ID != "" {
+			ids = append(ids, a.ID)
+		}
+	}
+	_, err := s.answerRepo.DeleteByIDs(ctx, ids)
+	return err
+}
+
 // checkRoomActive helper
 func (s *AnswerService) checkRoomActive(ctx context.Context, roomCode string) error {
 	meta, err := s.roomCache.GetMeta(ctx, roomCode)
@@ -71,43 +545,227 @@ func (s *AnswerService) checkRoomActive(ctx context.Context, roomCode string) er
 	return nil
 }
 
-// SaveDraft saves a draft answer
-func (s *AnswerService) SaveDraft(ctx context.Context, roomCode, playerID, questionKey, draft string) error {
+// ErrInvalidAnswer is returned when a submission does not fit its question
+var ErrInvalidAnswer = errors.New("invalid answer")
+
+// validateSubmission checks structured answers against the question's shape
+func validateSubmission(q *model.Question, req *model.SubmitAnswerRequest) error {
+	if len(req.AttachmentIDs) > 0 && !q.AllowAttachments {
+		return fmt.Errorf("%w: question does not accept attachments", ErrInvalidAnswer)
+	}
+	if len(req.AttachmentIDs) > model.MaxAttachmentsPerAnswer {
+		return fmt.Errorf("%w: at most %d attachments per answer", ErrInvalidAnswer, model.MaxAttachmentsPerAnswer)
+	}
+	if req.VoiceClipID != "" && !q.AllowVoice {
+		return fmt.Errorf("%w: question does not accept voice answers", ErrInvalidAnswer)
+	}
+
+	if n := utf8.RuneCountInString(req.TextAnswer); n > q.AnswerLimit() {
+		return fmt.Errorf("%w: answer is %d characters, the limit is %d", ErrInvalidAnswer, n, q.AnswerLimit())
+	}
+
+	switch q.Type {
+	case model.QuestionTypeEssay:
+		// A voice answer is checked against its transcript once it is known
+		if req.VoiceClipID == "" {
+			if err := validateEssayLength(q, req.TextAnswer); err != nil {
+				return err
+			}
+		}
+	case model.QuestionTypeMatrix:
+		if len(req.MatrixValues) != len(q.Rows) {
+			return fmt.Errorf("%w: expected a value for each of %d rows", ErrInvalidAnswer, len(q.Rows))
+		}
+		for row, col := range req.MatrixValues {
+			if col < 0 || col >= len(q.Columns) {
+				return fmt.Errorf("%w: row %d: column %d out of range", ErrInvalidAnswer, row, col)
+			}
+		}
+	case model.QuestionTypeWords:
+		words := len(strings.Fields(req.TextAnswer))
+		if words == 0 {
+			return fmt.Errorf("%w: enter at least one word", ErrInvalidAnswer)
+		}
+		if words > maxWordsAnswer {
+			return fmt.Errorf("%w: at most %d words", ErrInvalidAnswer, maxWordsAnswer)
+		}
+	case model.QuestionTypeRanking:
+		if len(req.Ranking) != len(q.Options) {
+			return fmt.Errorf("%w: expected all %d options in order", ErrInvalidAnswer, len(q.Options))
+		}
+		seen := make(map[int]bool, len(req.Ranking))
+		for _, opt := range req.Ranking {
+			if opt < 0 || opt >= len(q.Options) || seen[opt] {
+				return fmt.Errorf("%w: ranking must list each option exactly once", ErrInvalidAnswer)
+			}
+			seen[opt] = true
+		}
+	}
+	return nil
+}
+
+// validateEssayLength checks an essay answer against the question's minimum length
+func validateEssayLength(q *model.Question, text string) error {
+	if n := utf8.RuneCountInString(strings.TrimSpace(text)); n < q.MinLength {
+		return fmt.Errorf("%w: answer is %d characters, at least %d are required", ErrInvalidAnswer, n, q.MinLength)
+	}
+	return nil
+}
+
+// ErrDraftConflict is returned when a draft save is based on a stale version
+var ErrDraftConflict = errors.New("draft was modified by another session")
+
+// SaveDraft saves a draft answer. If baseVersion is set and does not match the
+// stored version, the save is rejected with ErrDraftConflict and the current
+// draft is returned so the client can reconcile.
+func (s *AnswerService) SaveDraft(ctx context.Context, roomCode, playerID, questionKey, draft string, baseVersion *int) (*model.DraftState, error) {
 	if err := s.checkRoomActive(ctx, roomCode); err != nil {
-		return err
+		return nil, err
+	}
+	if utf8.RuneCountInString(draft) > model.MaxAnswerChars {
+		return nil, fmt.Errorf("%w: draft exceeds %d characters", ErrInvalidAnswer, model.MaxAnswerChars)
+	}
+	if err := s.checkNotLeft(ctx, roomCode, playerID); err != nil {
+		return nil, err
+	}
+	s.playerSvc.TouchActivity(ctx, roomCode, playerID)
+
+	// The version check and write are one atomic update, so of two saves based on
+	// the same version exactly one wins
+	var current *model.AttemptState
+	state, err := s.playerCache.UpdateAttempt(ctx, roomCode, playerID, questionKey, func(state *model.AttemptState) (*model.AttemptState, error) {
+		if state == nil {
+			state = &model.AttemptState{
+				Status: model.AnswerStatusDraft,
+				Tries:  0,
+			}
+		}
+		if baseVersion != nil && *baseVersion != state.DraftVersion {
+			current = state
+			return nil, ErrDraftConflict
+		}
+
+		now := time.Now()
+		state.DraftAnswer = draft
+		state.DraftVersion++
+		state.DraftUpdatedAt = &now
+		state.UpdatedAt = now
+		return state, nil
+	})
+	if errors.Is(err, ErrDraftConflict) {
+		return model.DraftFromAttempt(questionKey, current), err
+	}
+	if err != nil {
+		return nil, err
 	}
+	return model.DraftFromAttempt(questionKey, state), nil
+}
+
+// GetDraft returns the saved draft for a question (empty draft at version 0 if none)
+func (s *AnswerService) GetDraft(ctx context.Context, roomCode, playerID, questionKey string) (*model.DraftState, error) {
 	state, err := s.playerCache.GetAttempt(ctx, roomCode, playerID, questionKey)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if state == nil {
-		state = &model.AttemptState{
-			Status: model.AnswerStatusDraft,
-			Tries:  0,
+	return model.DraftFromAttempt(questionKey, state), nil
+}
+
+// branchRules returns the room's branch rules, from the room meta or the survey
+func (s *AnswerService) branchRules(ctx context.Context, roomCode string) []model.BranchRule {
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil || meta == nil {
+		return nil
+	}
+	if meta.HasSurvey() {
+		return meta.Branching
+	}
+	survey, err := s.surveyRepo.GetByID(ctx, meta.SurveyID)
+	if err != nil || survey == nil {
+		return nil
+	}
+	return survey.Branching
+}
+
+// applyBranching queues the questions of every branch rule the answer matches
+func (s *AnswerService) applyBranching(ctx context.Context, roomCode, playerID string, answer *model.Answer) {
+	var keys []string
+	for _, rule := range s.branchRules(ctx, roomCode) {
+		if rule.Matches(answer) {
+			keys = append(keys, rule.Ask...)
 		}
 	}
-	state.DraftAnswer = draft
-	state.UpdatedAt = time.Now()
-	return s.playerCache.SetAttempt(ctx, roomCode, playerID, questionKey, state)
+	if len(keys) == 0 {
+		return
+	}
+	if err := s.playerSvc.InsertBranch(ctx, roomCode, playerID, keys); err != nil {
+		fmt.Printf("Branching failed for %s in %s: %v\n", playerID, roomCode, err)
+	}
+}
+
+// applyThemeBranching queues the questions of every theme rule the player's
+// themes match; it runs after the player profile took in the answer's signals
+func (s *AnswerService) applyThemeBranching(ctx context.Context, roomCode, playerID string, answer *model.Answer) {
+	if answer.Signals == nil || len(answer.Signals.Themes) == 0 {
+		return
+	}
+	var rules []model.BranchRule
+	for _, rule := range s.branchRules(ctx, roomCode) {
+		if rule.IsThemeRule() {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == 0 {
+		return
+	}
+
+	profile, err := s.analyticsSvc.GetPlayerProfile(ctx, roomCode, playerID)
+	if err != nil {
+		return
+	}
+	var playerThemes map[string]int
+	if profile != nil {
+		playerThemes = profile.TopicAffinity
+	}
+
+	var keys []string
+	for _, rule := range rules {
+		if rule.MatchesThemes(answer.QuestionKey, answer.Signals.Themes, playerThemes) {
+			keys = append(keys, rule.Ask...)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	if err := s.playerSvc.InsertBranch(ctx, roomCode, playerID, keys); err != nil {
+		fmt.Printf("Theme branching failed for %s in %s: %v\n", playerID, roomCode, err)
+	}
 }
 
 // SubmitAnswer handles answer submission with idempotency and evaluation
-func (s *AnswerService) SubmitAnswer(ctx context.Context, roomCode, playerID string, req *model.SubmitAnswerRequest) (*model.SubmitAnswerResponse, error) {
+func (s *AnswerService) SubmitAnswer(ctx context.Context, roomCode, playerID string, req *model.SubmitAnswerRequest) (resp *model.SubmitAnswerResponse, err error) {
 	if err := s.checkRoomActive(ctx, roomCode); err != nil {
 		return nil, err
 	}
-	// Idempotency check
-	exists, err := s.answerRepo.CheckIdempotency(ctx, roomCode, playerID, req.QuestionKey, req.ClientAttemptID)
-	if err != nil {
-		return nil, fmt.Errorf("idempotency check failed: %w", err)
+	if err := s.checkNotLeft(ctx, roomCode, playerID); err != nil {
+		return nil, err
 	}
-	if exists {
-		// Already processed, return pending if it was recent or evaluated if done
-		// For simplicity, if it exists, we assume it's being processed or done.
-		// Ideally we check state.
-		return &model.SubmitAnswerResponse{
-			Status: model.AnswerStatusSubmitted, // Or Evaluated if we checked
-		}, nil
+	s.playerSvc.TouchActivity(ctx, roomCode, playerID)
+	// Idempotency check: replay the original result for a repeated clientAttemptId
+	if req.ClientAttemptID != "" {
+		prev, replayErr := s.replaySubmit(ctx, roomCode, playerID, req)
+		if replayErr != nil {
+			return nil, fmt.Errorf("idempotency check failed: %w", replayErr)
+		}
+		if prev != nil {
+			return prev, nil
+		}
+		// The attempt is now claimed; release it if the submission is rejected
+		// below, so the client can retry with the same ID
+		defer func() {
+			if err != nil {
+				s.playerCache.DeleteSubmitResult(ctx, roomCode, playerID, req.QuestionKey, req.ClientAttemptID)
+			}
+		}()
 	}
 
 	// Get current question
@@ -118,6 +776,56 @@ func (s *AnswerService) SubmitAnswer(ctx context.Context, roomCode, playerID str
 	if question == nil {
 		return nil, fmt.Errorf("question not found")
 	}
+	if err := validateSubmission(question, req); err != nil {
+		return nil, err
+	}
+	if len(req.AttachmentIDs) > 0 {
+		if s.attachSvc == nil {
+			return nil, fmt.Errorf("%w: attachments are not enabled", ErrInvalidAnswer)
+		}
+		if err := s.attachSvc.ValidateForAnswer(ctx, roomCode, playerID, req.QuestionKey, req.AttachmentIDs); err != nil {
+			return nil, err
+		}
+	}
+	// A recorded answer is evaluated as its transcript
+	if req.VoiceClipID != "" {
+		if s.voiceSvc == nil {
+			return nil, fmt.Errorf("%w: voice answers are not enabled", ErrInvalidAnswer)
+		}
+		transcript, err := s.voiceSvc.TranscriptFor(ctx, roomCode, playerID, req.QuestionKey, req.VoiceClipID)
+		if err != nil {
+			return nil, err
+		}
+		req.TextAnswer = truncateRunes(transcript, question.AnswerLimit())
+		if err := validateEssayLength(question, req.TextAnswer); err != nil {
+			return nil, err
+		}
+	}
+	// Evaluate against the prompt the player actually saw, at the room's strictness
+	piped := hasPipes(question)
+	question = s.playerSvc.ResolvePrompt(ctx, roomCode, playerID, question)
+	question = s.applyStrictness(ctx, roomCode, question)
+	question = s.applyThemeTaxonomy(ctx, roomCode, question)
+	maxTries := s.essayMaxTries(ctx, roomCode)
+
+	// Screening quotas turn players away before anything is recorded
+	if s.quotaSvc != nil && question.Type == model.QuestionTypeMCQ && req.OptionIndex != nil {
+		admitted, err := s.quotaSvc.Admit(ctx, roomCode, playerID, req.QuestionKey, *req.OptionIndex)
+		if err != nil {
+			return nil, err
+		}
+		if !admitted {
+			screened := &model.SubmitAnswerResponse{
+				Status:  model.AnswerStatusScreened,
+				Message: model.ScreenedOutMessage,
+			}
+			// A retry of the attempt gets the same verdict
+			if req.ClientAttemptID != "" {
+				s.playerCache.SetSubmitResult(ctx, roomCode, playerID, req.QuestionKey, req.ClientAttemptID, screened)
+			}
+			return screened, nil
+		}
+	}
 
 	// Get/create attempt state
 	state, err := s.playerCache.GetAttempt(ctx, roomCode, playerID, req.QuestionKey)
@@ -130,36 +838,54 @@ func (s *AnswerService) SubmitAnswer(ctx context.Context, roomCode, playerID str
 			Tries:  0,
 		}
 	}
+	if question.Type == model.QuestionTypeEssay && state.Resolution == model.ResolutionUnsat && state.Tries >= maxTries {
+		return nil, fmt.Errorf("%w: no tries left for this question", ErrInvalidAnswer)
+	}
 	state.Tries++
 	state.SubmittedAnswer = req.TextAnswer
+	state.PipedValue = pipedValue(question, req)
 	state.Status = model.AnswerStatusSubmitted // Mark as submitted
 	state.UpdatedAt = time.Now()
+	if state.ServedAt != nil {
+		state.ResponseMs = state.UpdatedAt.Sub(*state.ServedAt).Milliseconds()
+	}
 
 	// Update attempt state immediately to indicate "Submitted"
 	if err := s.playerCache.SetAttempt(ctx, roomCode, playerID, req.QuestionKey, state); err != nil {
 		return nil, err
 	}
+	if s.timeseries != nil {
+		s.timeseries.Record(ctx, roomCode, cache.MetricSubmissions)
+	}
+
+	var duplicate *model.DuplicateFlag
+	if question.Type == model.QuestionTypeEssay {
+		duplicate = s.flagDuplicate(ctx, roomCode, playerID, req.QuestionKey, req.TextAnswer)
+	}
 
 	// BROADCAST IMMEDIATE ACK/THINKING
 	if s.broadcaster != nil {
 		// 1. Tell Host that player has submitted
-		s.broadcaster.BroadcastToHost(roomCode, "player_progress_update", map[string]interface{}{
-			"playerId":    playerID,
-			"questionKey": req.QuestionKey,
-			"status":      string(model.AnswerStatusSubmitted),
-			"optionIndex": req.OptionIndex,
+		s.broadcaster.ToHost(roomCode, events.PlayerProgressUpdate, events.PlayerProgressPayload{
+			PlayerID:    playerID,
+			QuestionKey: req.QuestionKey,
+			Status:      string(model.AnswerStatusSubmitted),
+			OptionIndex: req.OptionIndex,
+			Attachments: len(req.AttachmentIDs),
+			Duplicate:   duplicate,
 		})
 
 		// 2. Tell Player that AI is thinking (The immediate feedback requested)
-		s.broadcaster.BroadcastToPlayer(roomCode, playerID, "ai_thinking", map[string]interface{}{
-			"questionKey": req.QuestionKey,
-		})
+		s.broadcaster.ToPlayer(roomCode, playerID, events.AIThinking, events.AIThinkingPayload{QuestionKey: req.QuestionKey})
 	}
 
 	// ASYNC PROCESSING
 	// Create a detached context for the goroutine
 	// Note: In production, use a proper background context with timeout or worker pool
+	s.inFlight.Add(1)
 	go func(asyncCtx context.Context, rCode, pID string, request model.SubmitAnswerRequest, q *model.Question, st *model.AttemptState) {
+		defer s.inFlight.Done()
+
 		// Recover from panics in goroutine
 		defer func() {
 			if r := recover(); r != nil {
@@ -176,22 +902,42 @@ func (s *AnswerService) SubmitAnswer(ctx context.Context, roomCode, playerID str
 			TextAnswer:      request.TextAnswer,
 			DegreeValue:     request.DegreeValue,
 			Tries:           st.Tries,
+			ResponseMs:      st.ResponseMs,
 			Status:          model.AnswerStatusSubmitted,
 			OptionIndex:     request.OptionIndex,
+			MatrixValues:    request.MatrixValues,
+			Ranking:         request.Ranking,
+			Attachments:     request.AttachmentIDs,
+			VoiceClipID:     request.VoiceClipID,
+			Duplicate:       duplicate,
 		}
 
 		var response model.SubmitAnswerResponse
+		triesExhausted := false // A final UNSAT answer moves the player on
+
+		// Host-defined branching runs before any AI follow-up logic
+		if q.Type == model.QuestionTypeDegree || q.Type == model.QuestionTypeMCQ {
+			s.applyBranching(asyncCtx, rCode, pID, answer)
+		}
 
 		// Evaluate based on question type
 		switch q.Type {
 		case model.QuestionTypeEssay:
 			// AI evaluation (Slow)
-			evalResult, err := s.evaluator.EvaluateAnswer(asyncCtx, q, answer)
+			evalStarted := time.Now()
+			evalResult, err := s.evaluateAnswer(asyncCtx, rCode, q, answer, piped)
+			if err == nil && s.timeseries != nil {
+				s.timeseries.RecordEvaluation(asyncCtx, rCode, time.Since(evalStarted))
+			}
 			if err != nil {
 				fmt.Printf("Evaluation failed: %v\n", err)
+				// Release the attempt so the client can retry with the same ID
+				if request.ClientAttemptID != "" {
+					s.playerCache.DeleteSubmitResult(asyncCtx, rCode, pID, request.QuestionKey, request.ClientAttemptID)
+				}
 				// Broadcast error?
 				if s.broadcaster != nil {
-					s.broadcaster.BroadcastToPlayer(rCode, pID, "error", map[string]string{"message": "Evaluation failed"})
+					s.broadcaster.ToPlayer(rCode, pID, events.Error, events.ErrorPayload{Message: "Evaluation failed"})
 				}
 				return
 			}
@@ -200,11 +946,24 @@ func (s *AnswerService) SubmitAnswer(ctx context.Context, roomCode, playerID str
 			answer.Resolution = model.AnswerResolution(evalResult.Resolution)
 			answer.Signals = &evalResult.Signals
 			answer.EvalSummary = evalResult.Signals.Summary
+			answer.QualityScore = evalResult.QualityScore
 
-			// Calculate points
+			// Full credit for SAT; an UNSAT retry that beats the best answer so far
+			// earns partial credit. Points already awarded for the question count.
+			target := st.PointsAwarded
+			switch {
+			case answer.Resolution == model.ResolutionSat:
+				target = int(evalResult.QualityScore * float64(q.PointsMax))
+			case st.Tries > 1 && evalResult.QualityScore > st.BestQuality:
+				target = int(evalResult.QualityScore * float64(q.PointsMax) * retryPartialShare)
+			}
 			points := 0
-			if model.AnswerResolution(evalResult.Resolution) == model.ResolutionSat {
-				points = int(evalResult.QualityScore * float64(q.PointsMax))
+			if target > st.PointsAwarded {
+				points = target - st.PointsAwarded
+			}
+			st.PointsAwarded += points
+			if evalResult.QualityScore > st.BestQuality {
+				st.BestQuality = evalResult.QualityScore
 			}
 
 			answer.PointsEarned = points
@@ -228,8 +987,17 @@ func (s *AnswerService) SubmitAnswer(ctx context.Context, roomCode, playerID str
 				}
 			}
 
-		case model.QuestionTypeDegree, model.QuestionTypeMCQ:
-			// Degree and MCQ questions give fixed points (half of max)
+			// UNSAT keeps the question current while tries remain
+			if answer.Resolution == model.ResolutionUnsat {
+				if left := maxTries - st.Tries; left > 0 {
+					response.Retry = retryGuidance(evalResult.Signals.Missing, left)
+				} else {
+					triesExhausted = true
+				}
+			}
+
+		case model.QuestionTypeDegree, model.QuestionTypeMCQ, model.QuestionTypeMatrix, model.QuestionTypeRanking, model.QuestionTypeWords:
+			// Structured questions give fixed points (half of max)
 			points := q.PointsMax / 2
 			answer.PointsEarned = points
 			answer.Status = model.AnswerStatusEvaluated
@@ -243,13 +1011,19 @@ func (s *AnswerService) SubmitAnswer(ctx context.Context, roomCode, playerID str
 			response.PointsEarned = points
 		}
 
-		// Save attempt state
-		s.playerCache.SetAttempt(asyncCtx, rCode, pID, request.QuestionKey, st)
-
 		// Persist answer
 		now := time.Now()
 		answer.EvaluatedAt = &now
-		s.answerRepo.Create(asyncCtx, answer)
+		answerID, persistErr := s.answerRepo.Create(asyncCtx, answer)
+		if errors.Is(persistErr, repository.ErrDuplicateAnswer) {
+			// A concurrent submit of the same attempt already persisted, scored and broadcast it
+			return
+		}
+
+		// Save attempt state once the answer is stored, so a losing duplicate never overwrites it
+		if persistErr == nil {
+			s.playerCache.SetAttempt(asyncCtx, rCode, pID, request.QuestionKey, st)
+		}
 
 		// Update score & Host Broadcast
 		if answer.PointsEarned > 0 {
@@ -257,35 +1031,63 @@ func (s *AnswerService) SubmitAnswer(ctx context.Context, roomCode, playerID str
 		}
 
 		if s.broadcaster != nil {
-			// Notify Host
-			s.broadcaster.BroadcastToHost(rCode, "player_progress_update", map[string]interface{}{
-				"playerId":    pID,
-				"questionKey": request.QuestionKey,
-				"status":      string(answer.Status),
-				"resolution":  string(answer.Resolution),
-				"optionIndex": answer.OptionIndex,
-			})
+			// Notify Host, unless the answers change stream does for the stored answer
+			if persistErr != nil || !s.changes.AnswersActive() {
+				s.broadcaster.ToHost(rCode, events.PlayerProgressUpdate, events.PlayerProgressPayload{
+					PlayerID:    pID,
+					QuestionKey: request.QuestionKey,
+					Status:      string(answer.Status),
+					Resolution:  string(answer.Resolution),
+					OptionIndex: answer.OptionIndex,
+					Attachments: len(answer.Attachments),
+					Duplicate:   answer.Duplicate,
+				})
+			}
 
 			// Notify Player (The "ACK" that work is done)
 
-			// If satisfactory, advance
-			if answer.Resolution == model.ResolutionSat {
+			// If satisfactory or out of tries, advance
+			if answer.Resolution == model.ResolutionSat || triesExhausted {
 				nextQ, _ := s.playerSvc.AdvanceToNextQuestion(asyncCtx, rCode, pID)
 				response.NextQuestion = nextQ
 			}
+			response.Progress, _ = s.playerSvc.GetProgress(asyncCtx, rCode, pID)
+
+			// Remember the full result so duplicate submissions can replay it,
+			// on the answer too for when Redis no longer holds it
+			if request.ClientAttemptID != "" {
+				s.playerCache.SetSubmitResult(asyncCtx, rCode, pID, request.QuestionKey, request.ClientAttemptID, &response)
+				if persistErr == nil {
+					if err := s.answerRepo.SetReplay(asyncCtx, answerID, &response); err != nil {
+						fmt.Printf("Failed to store submit replay for %s: %v\n", answerID, err)
+					}
+				}
+			}
 
 			// Broadcast Result to Player
-			s.broadcaster.BroadcastToPlayer(rCode, pID, "evaluation_result", response)
+			s.broadcaster.ToPlayer(rCode, pID, events.EvaluationResult, response)
 
-			// Update Analytics (L2/L3/L4)
+			// Update Analytics (L2/L3/L4); copied answers don't add to the room's themes
 			if s.analyticsSvc != nil {
 				s.analyticsSvc.UpdatePlayerProfile(asyncCtx, rCode, pID, answer.Signals, answer.Resolution)
-				s.analyticsSvc.UpdateQuestionProfile(asyncCtx, rCode, request.QuestionKey, answer.Signals, answer.Resolution, answer.DegreeValue, answer.OptionIndex)
-				s.analyticsSvc.UpdateRoomMemory(asyncCtx, rCode, answer.Signals)
+				s.applyThemeBranching(asyncCtx, rCode, pID, answer)
+				themeSignals := answer.Signals
+				if answer.Duplicate != nil {
+					themeSignals = nil
+					s.analyticsSvc.RecordDuplicate(asyncCtx, rCode, request.QuestionKey)
+				}
+				s.analyticsSvc.UpdateQuestionProfile(asyncCtx, rCode, request.QuestionKey, themeSignals, answer.Resolution, answer.DegreeValue, answer.OptionIndex, answer.MatrixValues, answer.Ranking)
+				if answer.ResponseMs > 0 {
+					s.analyticsSvc.RecordResponseTime(asyncCtx, rCode, request.QuestionKey, answer.ResponseMs)
+				}
+				s.analyticsSvc.UpdateRoomMemory(asyncCtx, rCode, themeSignals)
+				if q.Type == model.QuestionTypeWords {
+					s.analyticsSvc.UpdateWordCloud(asyncCtx, rCode, request.QuestionKey, answer.TextAnswer)
+				}
 			}
 		}
 
-	}(context.Background(), roomCode, playerID, *req, question, state)
+	}(WithAIRoom(context.Background(), roomCode), roomCode, playerID, *req, question, state)
 
 	// Return immediate ACK
 	return &model.SubmitAnswerResponse{
@@ -293,16 +1095,121 @@ func (s *AnswerService) SubmitAnswer(ctx context.Context, roomCode, playerID str
 	}, nil
 }
 
+// evaluateAnswer runs L1 evaluation, reusing a cached result for near-identical short answers.
+// A piped question's prompt differs per player, so its answers are never cached or batched
+// together under the shared question key.
+func (s *AnswerService) evaluateAnswer(ctx context.Context, roomCode string, question *model.Question, answer *model.Answer, piped bool) (*model.EvaluationResult, error) {
+	if piped {
+		return s.evaluator.EvaluateAnswer(ctx, question, answer)
+	}
+
+	cfg := s.evaluator.config.EvalCache
+	normalized := normalizeAnswer(answer.TextAnswer)
+	words := len(strings.Fields(normalized))
+	if s.evalCache == nil || words == 0 || words > cfg.MaxWords {
+		return s.evaluateUncached(ctx, roomCode, question, answer)
+	}
+
+	if cached, err := s.evalCache.Get(ctx, roomCode, question.Key, normalized); err == nil && cached != nil {
+		return cached, nil
+	}
+	if cfg.SimilarityThreshold < 1 {
+		if entries, err := s.evalCache.GetAll(ctx, roomCode, question.Key); err == nil {
+			var best *model.EvaluationResult
+			bestScore := 0.0
+			for text, result := range entries {
+				if !sameNegation(normalized, text) {
+					continue
+				}
+				if score := answerSimilarity(normalized, text); score >= cfg.SimilarityThreshold && score > bestScore {
+					best, bestScore = result, score
+				}
+			}
+			if best != nil {
+				return best, nil
+			}
+		}
+	}
+
+	result, err := s.evaluateUncached(ctx, roomCode, question, answer)
+	if err != nil {
+		return nil, err
+	}
+	// Offline results stand in for an unavailable model; the next answer should try it again
+	if !result.Fallback {
+		s.evalCache.Set(ctx, roomCode, question.Key, normalized, result)
+	}
+	return result, nil
+}
+
+// evaluateUncached calls the evaluator directly or via the batcher when configured
+func (s *AnswerService) evaluateUncached(ctx context.Context, roomCode string, question *model.Question, answer *model.Answer) (*model.EvaluationResult, error) {
+	if s.evalBatcher != nil {
+		return s.evalBatcher.Evaluate(ctx, roomCode, question, answer)
+	}
+	return s.evaluator.EvaluateAnswer(ctx, question, answer)
+}
+
+// replaySubmit returns the original response for a duplicate submission, or nil if the attempt is new
+func (s *AnswerService) replaySubmit(ctx context.Context, roomCode, playerID string, req *model.SubmitAnswerRequest) (*model.SubmitAnswerResponse, error) {
+	claimed, err := s.playerCache.ClaimSubmit(ctx, roomCode, playerID, req.QuestionKey, req.ClientAttemptID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claimed {
+		prev, err := s.playerCache.GetSubmitResult(ctx, roomCode, playerID, req.QuestionKey, req.ClientAttemptID)
+		if err != nil {
+			return nil, err
+		}
+		if prev == nil {
+			// Claim expired between calls; treat as still in flight
+			return &model.SubmitAnswerResponse{Status: model.AnswerStatusSubmitted}, nil
+		}
+		return prev, nil
+	}
+
+	// Redis has no record (new attempt or expired cache); fall back to the persisted answer
+	answer, err := s.answerRepo.GetByClientAttempt(ctx, roomCode, playerID, req.QuestionKey, req.ClientAttemptID)
+	if err != nil {
+		s.playerCache.DeleteSubmitResult(ctx, roomCode, playerID, req.QuestionKey, req.ClientAttemptID)
+		return nil, err
+	}
+	if answer == nil {
+		return nil, nil
+	}
+
+	// Answers stored before replays were kept only have their evaluation
+	resp := answer.Replay
+	if resp == nil {
+		resp = &model.SubmitAnswerResponse{
+			Status:       answer.Status,
+			Resolution:   answer.Resolution,
+			PointsEarned: answer.PointsEarned,
+			EvalSummary:  answer.EvalSummary,
+		}
+	}
+	s.playerCache.SetSubmitResult(ctx, roomCode, playerID, req.QuestionKey, req.ClientAttemptID, resp)
+	return resp, nil
+}
+
 // Skip marks a question as skipped and closes its follow-up chain
 func (s *AnswerService) Skip(ctx context.Context, roomCode, playerID, questionKey string) (*model.Question, error) {
 	if err := s.checkRoomActive(ctx, roomCode); err != nil {
 		return nil, err
 	}
+	if err := s.checkNotLeft(ctx, roomCode, playerID); err != nil {
+		return nil, err
+	}
+	s.playerSvc.TouchActivity(ctx, roomCode, playerID)
 	// Get question to find parent
 	question, err := s.playerCache.GetQuestionMap(ctx, roomCode, playerID, questionKey)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.checkSkipGate(ctx, roomCode, playerID, questionKey, question); err != nil {
+		return nil, err
+	}
 
 	// Determine which parent to close
 	parentToClose := questionKey
@@ -336,11 +1243,77 @@ func (s *AnswerService) Skip(ctx context.Context, roomCode, playerID, questionKe
 	if _, err := s.answerRepo.Create(ctx, answer); err != nil {
 		return nil, err
 	}
+	if s.timeseries != nil {
+		s.timeseries.Record(ctx, roomCode, cache.MetricSkips)
+	}
 
 	// Advance to next question
 	return s.playerSvc.AdvanceToNextQuestion(ctx, roomCode, playerID)
 }
 
+// ErrSkipLocked is returned when a question cannot be skipped yet
+var ErrSkipLocked = errors.New("skip locked")
+
+// SkipLockedError says what a player still has to do before skipping a question; it wraps ErrSkipLocked
+type SkipLockedError struct {
+	QuestionKey      string `json:"questionKey"`
+	TriesRemaining   int    `json:"triesRemaining"`   // Submissions still required
+	SecondsRemaining int    `json:"secondsRemaining"` // Time still required on the question
+}
+
+func (e *SkipLockedError) Error() string {
+	var needed []string
+	if e.TriesRemaining > 0 {
+		needed = append(needed, fmt.Sprintf("%d more tries", e.TriesRemaining))
+	}
+	if e.SecondsRemaining > 0 {
+		needed = append(needed, fmt.Sprintf("%d more seconds", e.SecondsRemaining))
+	}
+	return fmt.Sprintf("%s: %s needs %s", ErrSkipLocked, e.QuestionKey, strings.Join(needed, " and "))
+}
+
+func (e *SkipLockedError) Unwrap() error { return ErrSkipLocked }
+
+// checkSkipGate refuses skips before the room's required tries (ESSAY questions) or
+// time on the question, and counts each refusal in the question's analytics
+func (s *AnswerService) checkSkipGate(ctx context.Context, roomCode, playerID, questionKey string, question *model.Question) error {
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil || meta == nil {
+		return err
+	}
+	gate := meta.Settings().SkipGate(meta.SkipSettings)
+	if gate == (model.SkipGate{}) {
+		return nil
+	}
+	state, err := s.playerCache.GetAttempt(ctx, roomCode, playerID, questionKey)
+	if err != nil {
+		return err
+	}
+
+	locked := &SkipLockedError{QuestionKey: questionKey}
+	if gate.MinTries > 0 && question != nil && question.Type == model.QuestionTypeEssay {
+		tries := 0
+		if state != nil {
+			tries = state.Tries
+		}
+		locked.TriesRemaining = max(gate.MinTries-tries, 0)
+	}
+	if gate.MinSeconds > 0 && state != nil && state.ServedAt != nil {
+		remaining := time.Duration(gate.MinSeconds)*time.Second - time.Since(*state.ServedAt)
+		locked.SecondsRemaining = max(int(math.Ceil(remaining.Seconds())), 0)
+	}
+	if locked.TriesRemaining == 0 && locked.SecondsRemaining == 0 {
+		return nil
+	}
+
+	if s.analyticsSvc != nil {
+		if err := s.analyticsSvc.RecordSkipLocked(ctx, roomCode, questionKey); err != nil {
+			fmt.Printf("[Skip] Failed to count locked skip of %s in %s: %v\n", questionKey, roomCode, err)
+		}
+	}
+	return locked
+}
+
 // getOrGenerateFollowUp retrieves from pool or generates on-demand
 func (s *AnswerService) getOrGenerateFollowUp(ctx context.Context, roomCode, playerID string, question *model.Question, evalResult *model.EvaluationResult, answerText string) (*model.Question, error) {
 	// Depth check - don't go too deep!
@@ -401,37 +1374,45 @@ func (s *AnswerService) getOrGenerateFollowUp(ctx context.Context, roomCode, pla
 		}
 	}
 
-	nextNum := maxNum + 1
-	nextKey := fmt.Sprintf("%s.%d", base, nextNum)
+	// Experiment variants can change the follow-up cap and prompt
+	roomMeta, _ := s.roomCache.GetMeta(ctx, roomCode)
+	player, _ := s.playerSvc.GetPlayer(ctx, roomCode, playerID)
+	variant := experimentVariant(roomMeta, player)
+	var guardrails *model.Guardrails
+	if roomMeta != nil {
+		guardrails = roomMeta.Guardrails
+	}
 
-	// Try pool first
-	pool, err := s.poolCache.GetPool(ctx, roomCode, question.Key)
-	if err != nil {
-		return nil, err
+	// Per-question follow-up cap, lowered by a lenient room, unless the variant sets its own
+	var maxFollowUps *int
+	if question.AI != nil {
+		maxFollowUps = question.AI.MaxFollowUps
+	}
+	if preset := question.Strictness.MaxFollowUps(); preset != nil && (maxFollowUps == nil || *preset < *maxFollowUps) {
+		maxFollowUps = preset
+	}
+	if variant != nil && variant.MaxFollowUps != nil {
+		maxFollowUps = variant.MaxFollowUps
+	}
+	// Players rating follow-ups as irrelevant lower the cap further
+	if s.analyticsSvc != nil {
+		if throttle := s.analyticsSvc.FollowUpCap(ctx, roomCode, followUpHint(evalResult)); throttle != nil && (maxFollowUps == nil || *throttle < *maxFollowUps) {
+			maxFollowUps = throttle
+		}
+	}
+	if maxFollowUps != nil && maxNum >= *maxFollowUps {
+		fmt.Printf("[FollowUp] Follow-up cap %d reached for %s. Stopping.\n", *maxFollowUps, base)
+		return nil, nil
 	}
 
-	if pool != nil {
-		// Pick based on follow-up hint
-		var candidates []model.Question
-		switch evalResult.FollowUpHint {
-		case "clarify":
-			candidates = pool.Clarify
-		case "deepen":
-			candidates = pool.Deepen
-		default:
-			candidates = pool.Clarify // Default to clarify
-		}
-
-		if len(candidates) > 0 {
-			fu := candidates[0]
-			// Remove used follow-up from pool
-			if evalResult.FollowUpHint == "clarify" && len(pool.Clarify) > 0 {
-				pool.Clarify = pool.Clarify[1:]
-			} else if len(pool.Deepen) > 0 {
-				pool.Deepen = pool.Deepen[1:]
-			}
-			s.poolCache.SetPool(ctx, roomCode, question.Key, pool)
-			return &fu, nil
+	nextNum := maxNum + 1
+	nextKey := fmt.Sprintf("%s.%d", base, nextNum)
+
+	// Try pool first; pooled follow-ups use the default prompt, so variants and
+	// strictness presets that change it always generate their own
+	if (variant == nil || !variant.ChangesPrompt()) && question.Strictness.FollowUpStyle() == model.FollowUpStyleDefault {
+		if fu, err := s.takePoolFollowUp(ctx, roomCode, question, followUpHint(evalResult), guardrails); err != nil || fu != nil {
+			return fu, err
 		}
 	}
 
@@ -459,16 +1440,73 @@ func (s *AnswerService) getOrGenerateFollowUp(ctx context.Context, roomCode, pla
 
 	// Fetch Survey Intent
 	surveyIntent := "Gather general feedback"
+	var scope *model.ScopeAnchor
 
-	roomMeta, err := s.roomCache.GetMeta(ctx, roomCode)
-	if err == nil && roomMeta != nil {
-		survey, err := s.surveyRepo.GetByID(ctx, roomMeta.SurveyID)
-		if err == nil && survey != nil && survey.Intent != "" {
-			surveyIntent = survey.Intent
+	if roomMeta != nil {
+		scope = roomMeta.ScopeAnchor
+		intent := roomMeta.SurveyIntent
+		if !roomMeta.HasSurvey() {
+			if survey, err := s.surveyRepo.GetByID(ctx, roomMeta.SurveyID); err == nil && survey != nil {
+				intent = survey.Intent
+				guardrails = survey.Guardrails
+			}
+		}
+		if intent != "" {
+			surveyIntent = intent
 		}
 	}
 
-	// Generate on-demand
-	player, _ := s.playerSvc.GetPlayer(ctx, roomCode, playerID)
-	return s.evaluator.GenerateFollowUp(ctx, question, player, evalResult, answerText, qProfile, roomMemory, history, surveyIntent, nextKey, base)
+	// Generate on-demand, streaming the prompt to the player where the evaluator allows it
+	var onPartial func(string)
+	if s.broadcaster != nil {
+		onPartial = func(prompt string) {
+			s.broadcaster.ToPlayer(roomCode, playerID, events.FollowUpPartial, events.FollowUpPartialPayload{
+				QuestionKey: nextKey,
+				ParentKey:   base,
+				Prompt:      prompt,
+			})
+		}
+	}
+	followUp, err := s.evaluator.GenerateFollowUpStreaming(ctx, question, player, evalResult, answerText, qProfile, roomMemory, history, surveyIntent, scope, guardrails, nextKey, base, variant, onPartial)
+	if errors.Is(err, ErrGuardrailViolation) {
+		return s.takePoolFollowUp(ctx, roomCode, question, followUpHint(evalResult), guardrails)
+	}
+	if followUp != nil {
+		followUp.Source = model.FollowUpSourceGenerated
+		followUp.Hint = followUpHint(evalResult)
+	}
+	return followUp, err
+}
+
+// takePoolFollowUp pops the next pooled follow-up matching the evaluation hint,
+// discarding any that touch the survey's guardrails; nil when none is left
+func (s *AnswerService) takePoolFollowUp(ctx context.Context, roomCode string, question *model.Question, hint string, guardrails *model.Guardrails) (*model.Question, error) {
+	pool, err := s.poolCache.GetPool(ctx, roomCode, question.Key)
+	if err != nil || pool == nil {
+		return nil, err
+	}
+
+	candidates := &pool.Clarify // Default to clarify
+	if hint == "deepen" {
+		candidates = &pool.Deepen
+	}
+	if len(*candidates) == 0 {
+		return nil, nil
+	}
+	for len(*candidates) > 0 {
+		fu := (*candidates)[0]
+		*candidates = (*candidates)[1:]
+		if hit := guardrails.Violation(fu.Prompt + " " + strings.Join(fu.Options, " ")); hit != "" {
+			fmt.Printf("[FollowUp] Dropped pooled follow-up %s touching guardrail %q\n", fu.Key, hit)
+			continue
+		}
+		s.poolCache.SetPool(ctx, roomCode, question.Key, pool)
+		fu.AI = question.AI
+		fu.Media = question.Media
+		fu.Source = model.FollowUpSourcePool
+		fu.Hint = hint
+		return &fu, nil
+	}
+	s.poolCache.SetPool(ctx, roomCode, question.Key, pool)
+	return nil, nil
 }
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/model"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// Two saves based on the same draft version race; exactly one must be rejected
+func TestSaveDraftConcurrentConflict(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	backends := map[string]cache.PlayerCache{
+		"redis":  cache.NewPlayerCache(client),
+		"memory": cache.NewMemoryPlayerCache(),
+	}
+	for name, playerCache := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			roomCache := cache.NewMemoryRoomCache()
+			if err := roomCache.SetMeta(ctx, "ROOM1", &model.RoomMeta{Status: model.RoomStatusActive}); err != nil {
+				t.Fatal(err)
+			}
+			playerSvc := NewPlayerService(nil, roomCache, playerCache, nil, nil)
+			svc := NewAnswerService(nil, nil, roomCache, playerCache, nil, playerSvc, nil)
+
+			base := 0
+			for round, questionKey := range []string{"Q1", "Q2", "Q3", "Q4", "Q5", "Q6", "Q7", "Q8"} {
+				start := make(chan struct{})
+				errs := make([]error, 2)
+				var wg sync.WaitGroup
+				for i := range errs {
+					wg.Add(1)
+					go func(i int) {
+						defer wg.Done()
+						<-start
+						_, errs[i] = svc.SaveDraft(ctx, "ROOM1", "p1", questionKey, "draft", &base)
+					}(i)
+				}
+				close(start)
+				wg.Wait()
+
+				conflicts := 0
+				for _, err := range errs {
+					switch {
+					case errors.Is(err, ErrDraftConflict):
+						conflicts++
+					case err != nil:
+						t.Fatalf("SaveDraft: %v", err)
+					}
+				}
+				if conflicts != 1 {
+					t.Fatalf("round %d: %d conflicts, want exactly 1", round, conflicts)
+				}
+				draft, err := svc.GetDraft(ctx, "ROOM1", "p1", questionKey)
+				if err != nil || draft.Version != 1 {
+					t.Fatalf("round %d: stored %+v, %v; want version 1", round, draft, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/model"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Duplicate answer detection
+const (
+	duplicateMinWords   = 6   // Shorter answers ("price", "too slow") match by chance
+	duplicateSimilarity = 0.9 // Word overlap that counts as a copy
+)
+
+// normalizeAnswer lowercases, strips punctuation and collapses whitespace so
+// trivially different answers ("Price!", "price") share a cache entry
+func normalizeAnswer(text string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// answerSimilarity returns the Jaccard overlap (0-1) of the word sets of two normalized answers
+func answerSimilarity(a, b string) float64 {
+	setA := make(map[string]bool)
+	for _, w := range strings.Fields(a) {
+		setA[w] = true
+	}
+	setB := make(map[string]bool)
+	for _, w := range strings.Fields(b) {
+		setB[w] = true
+	}
+	if len(setA) == 0 && len(setB) == 0 {
+		return 1
+	}
+
+	intersection := 0
+	for w := range setA {
+		if setB[w] {
+			intersection++
+		}
+	}
+	union := len(setA) + len(setB) - intersection
+	return float64(intersection) / float64(union)
+}
+
+// negationWords flip an answer's meaning; "t" is what normalizeAnswer leaves of "n't"
+var negationWords = map[string]bool{
+	"not": true, "no": true, "never": true, "t": true, "cannot": true, "nothing": true,
+	"none": true, "nobody": true, "nor": true, "neither": true, "without": true, "hardly": true,
+}
+
+// sameNegation reports whether two normalized answers use the same negation words,
+// so "it is useful" never reuses the evaluation of "it is not useful"
+func sameNegation(a, b string) bool {
+	negations := func(text string) map[string]bool {
+		set := make(map[string]bool)
+		for _, w := range strings.Fields(text) {
+			if negationWords[w] {
+				set[w] = true
+			}
+		}
+		return set
+	}
+	setA, setB := negations(a), negations(b)
+	if len(setA) != len(setB) {
+		return false
+	}
+	for w := range setA {
+		if !setB[w] {
+			return false
+		}
+	}
+	return true
+}
+
+// answerHash fingerprints a normalized answer
+func answerHash(normalized string) string {
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:8])
+}
+
+// flagDuplicate compares an ESSAY answer with the question's recent answers from
+// other players and keeps it for later ones. It returns the closest match at or
+// above duplicateSimilarity, or nil; short answers are never flagged.
+func (s *AnswerService) flagDuplicate(ctx context.Context, roomCode, playerID, questionKey, text string) *model.DuplicateFlag {
+	normalized := normalizeAnswer(text)
+	if s.duplicates == nil || len(strings.Fields(normalized)) < duplicateMinWords {
+		return nil
+	}
+	fp := cache.AnswerFingerprint{PlayerID: playerID, Hash: answerHash(normalized), Text: normalized, At: time.Now()}
+
+	recent, err := s.duplicates.Recent(ctx, roomCode, questionKey)
+	if err != nil {
+		fmt.Printf("[Duplicates] Recent answers to %s in %s: %v\n", questionKey, roomCode, err)
+	}
+	var flag *model.DuplicateFlag
+	for _, other := range recent {
+		if other.PlayerID == playerID {
+			continue // Retries of the same player
+		}
+		score := 1.0
+		if other.Hash != fp.Hash {
+			score = answerSimilarity(normalized, other.Text)
+		}
+		if score >= duplicateSimilarity && (flag == nil || score > flag.Similarity) {
+			flag = &model.DuplicateFlag{OfPlayerID: other.PlayerID, Similarity: score}
+		}
+		if score == 1 {
+			break
+		}
+	}
+
+	if err := s.duplicates.Add(ctx, roomCode, questionKey, fp); err != nil {
+		fmt.Printf("[Duplicates] Keep answer to %s in %s: %v\n", questionKey, roomCode, err)
+	}
+	return flag
+}
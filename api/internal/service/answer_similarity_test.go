@@ -0,0 +1,22 @@
+package service
+
+import "testing"
+
+func TestSameNegation(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "it is useful", b: "it is really useful", want: true},
+		{a: "it is not useful", b: "it is useful", want: false},
+		{a: "it isn't useful", b: "it is useful", want: false},
+		{a: "not useful at all", b: "it is not useful", want: true},
+		{a: "never useful", b: "not useful", want: false},
+		{a: "no", b: "no", want: true},
+	}
+	for _, tt := range tests {
+		if got := sameNegation(normalizeAnswer(tt.a), normalizeAnswer(tt.b)); got != tt.want {
+			t.Errorf("sameNegation(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	apiKeyPrefix         = "chz_"
+	apiKeyTouchInterval  = time.Minute // Throttle lastUsedAt writes
+	maxAPIKeysPerHost    = 20
+	apiKeyDisplayedChars = 8
+)
+
+var (
+	ErrInvalidAPIKey = errors.New("invalid or revoked API key")
+	ErrInvalidScope  = errors.New("invalid API key scope")
+)
+
+// APIKeyService issues and validates host API keys
+type APIKeyService struct {
+	apiKeyRepo repository.APIKeyRepo
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepo) *APIKeyService {
+	return &APIKeyService{apiKeyRepo: apiKeyRepo}
+}
+
+// Create issues a new key for the host. The plaintext key is only returned here.
+func (s *APIKeyService) Create(ctx context.Context, hostID string, req *model.CreateAPIKeyRequest) (*model.CreateAPIKeyResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if len(req.Scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", ErrInvalidScope)
+	}
+	for _, scope := range req.Scopes {
+		if !validScope(scope) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidScope, scope)
+		}
+	}
+
+	existing, err := s.apiKeyRepo.ListByHost(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	active := 0
+	for _, k := range existing {
+		if k.RevokedAt == nil {
+			active++
+		}
+	}
+	if active >= maxAPIKeysPerHost {
+		return nil, fmt.Errorf("host already has %d active API keys", maxAPIKeysPerHost)
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	plaintext := apiKeyPrefix + hex.EncodeToString(buf)
+
+	key := &model.APIKey{
+		HostID: hostID,
+		Name:   strings.TrimSpace(req.Name),
+		Prefix: plaintext[:len(apiKeyPrefix)+apiKeyDisplayedChars],
+		Hash:   hashAPIKey(plaintext),
+		Scopes: req.Scopes,
+	}
+	id, err := s.apiKeyRepo.Create(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store key: %w", err)
+	}
+	key.ID = id
+
+	return &model.CreateAPIKeyResponse{Key: plaintext, APIKey: key}, nil
+}
+
+// List returns the host's keys (without hashes)
+func (s *APIKeyService) List(ctx context.Context, hostID string) ([]*model.APIKey, error) {
+	return s.apiKeyRepo.ListByHost(ctx, hostID)
+}
+
+// Revoke disables one of the host's keys; returns false if it was not found
+func (s *APIKeyService) Revoke(ctx context.Context, hostID, keyID string) (bool, error) {
+	return s.apiKeyRepo.Revoke(ctx, hostID, keyID)
+}
+
+// Validate resolves a plaintext key to its active record
+func (s *APIKeyService) Validate(ctx context.Context, plaintext string) (*model.APIKey, error) {
+	if !strings.HasPrefix(plaintext, apiKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+	key, err := s.apiKeyRepo.GetByHash(ctx, hashAPIKey(plaintext))
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || key.RevokedAt != nil {
+		return nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > apiKeyTouchInterval {
+		go func(id string) {
+			if err := s.apiKeyRepo.TouchLastUsed(context.Background(), id, now); err != nil {
+				fmt.Printf("[APIKey] Failed to record use of key %s: %v\n", id, err)
+			}
+		}(key.ID)
+	}
+	return key, nil
+}
+
+func hashAPIKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+func validScope(scope string) bool {
+	for _, s := range model.AllScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/repository"
+	"context"
+	"fmt"
+	"time"
+)
+
+// ArchiveService persists Redis analytics (L2-L4) to Mongo before they expire
+type ArchiveService struct {
+	analyticsCache cache.AnalyticsCache
+	analyticsRepo  repository.AnalyticsRepo
+}
+
+// NewArchiveService creates a new archive service
+func NewArchiveService(analyticsCache cache.AnalyticsCache, analyticsRepo repository.AnalyticsRepo) *ArchiveService {
+	return &ArchiveService{
+		analyticsCache: analyticsCache,
+		analyticsRepo:  analyticsRepo,
+	}
+}
+
+// ArchiveRoom copies a room's player profiles, question profiles and room memory to Mongo
+func (s *ArchiveService) ArchiveRoom(ctx context.Context, roomCode string) error {
+	players, err := s.analyticsCache.ListPlayerProfiles(ctx, roomCode)
+	if err != nil {
+		return fmt.Errorf("failed to list player profiles: %w", err)
+	}
+	if err := s.analyticsRepo.SavePlayerProfiles(ctx, players); err != nil {
+		return fmt.Errorf("failed to archive player profiles: %w", err)
+	}
+
+	questions, err := s.analyticsCache.ListQuestionProfiles(ctx, roomCode)
+	if err != nil {
+		return fmt.Errorf("failed to list question profiles: %w", err)
+	}
+	if err := s.analyticsRepo.SaveQuestionProfiles(ctx, questions); err != nil {
+		return fmt.Errorf("failed to archive question profiles: %w", err)
+	}
+
+	memory, err := s.analyticsCache.GetRoomMemory(ctx, roomCode)
+	if err != nil {
+		return fmt.Errorf("failed to get room memory: %w", err)
+	}
+	if memory != nil {
+		if err := s.analyticsRepo.SaveRoomMemory(ctx, memory); err != nil {
+			return fmt.Errorf("failed to archive room memory: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ArchiveAll archives every room that still has analytics in Redis
+func (s *ArchiveService) ArchiveAll(ctx context.Context) (int, error) {
+	codes, err := s.analyticsCache.ListRoomCodes(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	archived := 0
+	for _, code := range codes {
+		if err := s.ArchiveRoom(ctx, code); err != nil {
+			fmt.Printf("[Archive] Room %s: %v\n", code, err)
+			continue
+		}
+		archived++
+	}
+	return archived, nil
+}
+
+// StartNightly runs ArchiveAll once a day at hourUTC until ctx is done
+func (s *ArchiveService) StartNightly(ctx context.Context, hourUTC int) {
+	go func() {
+		for {
+			wait := time.Until(nextRunAt(time.Now().UTC(), hourUTC))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+				n, err := s.ArchiveAll(ctx)
+				if err != nil {
+					fmt.Printf("[Archive] Nightly run failed: %v\n", err)
+					continue
+				}
+				fmt.Printf("[Archive] Nightly run archived %d rooms\n", n)
+			}
+		}
+	}()
+}
+
+// nextRunAt returns the next time at hourUTC strictly after now
+func nextRunAt(now time.Time, hourUTC int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hourUTC, 0, 0, 0, time.UTC)
+	if !next.After(now) {
+		next = next.Add(24 * time.Hour)
+	}
+	return next
+}
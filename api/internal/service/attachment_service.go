@@ -0,0 +1,229 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxUploadsPerQuestion caps uploads by one player for one question, used or not
+const maxUploadsPerQuestion = 10
+
+var (
+	// ErrInvalidAttachment is returned for uploads the question or file type does not allow
+	ErrInvalidAttachment = errors.New("invalid attachment")
+	// ErrAttachmentTooLarge is returned for files over model.MaxAttachmentBytes
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+)
+
+// AttachmentService stores images players upload with their answers
+type AttachmentService struct {
+	attachmentRepo repository.AttachmentRepo
+	roomRepo       repository.RoomRepo
+	roomCache      cache.RoomCache
+	playerCache    cache.PlayerCache
+	store          AttachmentStore
+}
+
+// NewAttachmentService creates a new attachment service
+func NewAttachmentService(
+	attachmentRepo repository.AttachmentRepo,
+	roomRepo repository.RoomRepo,
+	roomCache cache.RoomCache,
+	playerCache cache.PlayerCache,
+	store AttachmentStore,
+) *AttachmentService {
+	return &AttachmentService{
+		attachmentRepo: attachmentRepo,
+		roomRepo:       roomRepo,
+		roomCache:      roomCache,
+		playerCache:    playerCache,
+		store:          store,
+	}
+}
+
+// Upload validates and stores an image for the player's question. Size is
+// enforced while reading and the type is sniffed from the content, so the
+// client's declared Content-Type is ignored.
+func (s *AttachmentService) Upload(ctx context.Context, roomCode, playerID, questionKey, fileName string, r io.Reader) (*model.Attachment, error) {
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room meta: %w", err)
+	}
+	if meta == nil || meta.Status != model.RoomStatusActive {
+		return nil, fmt.Errorf("%w: room is not active", ErrInvalidAttachment)
+	}
+
+	question, err := s.playerCache.GetQuestionMap(ctx, roomCode, playerID, questionKey)
+	if err != nil {
+		return nil, err
+	}
+	if question == nil || !question.AllowAttachments {
+		return nil, fmt.Errorf("%w: question %s does not accept attachments", ErrInvalidAttachment, questionKey)
+	}
+
+	count, err := s.attachmentRepo.CountByQuestion(ctx, roomCode, playerID, questionKey)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxUploadsPerQuestion {
+		return nil, fmt.Errorf("%w: at most %d uploads per question", ErrInvalidAttachment, maxUploadsPerQuestion)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, model.MaxAttachmentBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if len(data) > model.MaxAttachmentBytes {
+		return nil, fmt.Errorf("%w: limit is %d MB", ErrAttachmentTooLarge, model.MaxAttachmentBytes>>20)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidAttachment)
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := model.AttachmentContentTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported file type %s (PNG, JPEG, GIF or WebP only)", ErrInvalidAttachment, contentType)
+	}
+
+	id := uuid.New().String()
+	attachment := &model.Attachment{
+		ID:          id,
+		RoomCode:    roomCode,
+		PlayerID:    playerID,
+		QuestionKey: questionKey,
+		FileName:    cleanFileName(fileName, ext),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		StorageKey:  roomCode + "/" + playerID + "/" + id + ext,
+	}
+	if err := s.store.Put(ctx, attachment.StorageKey, contentType, data); err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+	if err := s.attachmentRepo.Create(ctx, attachment); err != nil {
+		s.store.Delete(ctx, attachment.StorageKey)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// ValidateForAnswer checks that every ID is an upload by this player for this question
+func (s *AttachmentService) ValidateForAnswer(ctx context.Context, roomCode, playerID, questionKey string, ids []string) error {
+	for _, id := range ids {
+		attachment, err := s.attachmentRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if attachment == nil || attachment.RoomCode != roomCode || attachment.PlayerID != playerID || attachment.QuestionKey != questionKey {
+			return fmt.Errorf("%w: unknown attachment %s", ErrInvalidAnswer, id)
+		}
+	}
+	return nil
+}
+
+// ListByRoom returns the metadata of every upload in the host's room
+func (s *AttachmentService) ListByRoom(ctx context.Context, roomCode, hostID string) ([]*model.Attachment, error) {
+	if err := s.checkHost(ctx, roomCode, hostID); err != nil {
+		return nil, err
+	}
+	return s.attachmentRepo.ListByRoom(ctx, roomCode)
+}
+
+// Open returns an attachment and its content for the room's host. The caller closes the reader.
+func (s *AttachmentService) Open(ctx context.Context, roomCode, hostID, id string) (*model.Attachment, io.ReadCloser, error) {
+	if err := s.checkHost(ctx, roomCode, hostID); err != nil {
+		return nil, nil, err
+	}
+	attachment, err := s.attachmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if attachment == nil || attachment.RoomCode != roomCode {
+		return nil, nil, ErrAttachmentNotFound
+	}
+	body, err := s.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, body, nil
+}
+
+// ListPlayerFiles returns the metadata of a player's uploads in a room
+func (s *AttachmentService) ListPlayerFiles(ctx context.Context, roomCode, playerID string) ([]*model.Attachment, error) {
+	return s.attachmentRepo.ListByPlayer(ctx, roomCode, playerID)
+}
+
+// DeletePlayerFiles deletes a player's uploads in a room, files first so a
+// failed request can be retried. Returns the number of uploads deleted.
+func (s *AttachmentService) DeletePlayerFiles(ctx context.Context, roomCode, playerID string) (int64, error) {
+	attachments, err := s.attachmentRepo.ListByPlayer(ctx, roomCode, playerID)
+	if err != nil {
+		return 0, err
+	}
+	for _, attachment := range attachments {
+		if err := s.store.Delete(ctx, attachment.StorageKey); err != nil {
+			return 0, fmt.Errorf("failed to delete attachment %s: %w", attachment.ID, err)
+		}
+	}
+	return s.attachmentRepo.DeleteByPlayer(ctx, roomCode, playerID)
+}
+
+// PurgeFilesOlderThan deletes the files of uploads made before cutoff.
+// The retention job removes the attachment documents afterwards.
+func (s *AttachmentService) PurgeFilesOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	attachments, err := s.attachmentRepo.ListOlderThan(ctx, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	var deleted int64
+	for _, attachment := range attachments {
+		if err := s.store.Delete(ctx, attachment.StorageKey); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+func (s *AttachmentService) checkHost(ctx context.Context, roomCode, hostID string) error {
+	room, err := s.roomRepo.GetByCode(ctx, roomCode)
+	if err != nil {
+		return err
+	}
+	if room == nil {
+		return ErrAttachmentNotFound
+	}
+	if room.HostID != hostID {
+		return ErrNotRoomHost
+	}
+	return nil
+}
+
+// cleanFileName keeps a short, header-safe base name with an extension matching the content
+func cleanFileName(name, ext string) string {
+	base := strings.TrimSuffix(filepath.Base(strings.ReplaceAll(name, "\\", "/")), filepath.Ext(name))
+	base = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == '"' || r == '/' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, base)
+	if runes := []rune(base); len(runes) > 100 {
+		base = string(runes[:100])
+	}
+	if base == "" || base == "." {
+		base = "attachment"
+	}
+	return base + ext
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"fmt"
+	"time"
+)
+
+// AuditService records host actions on rooms so disputes can be traced afterwards
+type AuditService struct {
+	auditRepo repository.AuditRepo
+	roomRepo  repository.RoomRepo
+}
+
+// NewAuditService creates a new audit service
+func NewAuditService(auditRepo repository.AuditRepo, roomRepo repository.RoomRepo) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+		roomRepo:  roomRepo,
+	}
+}
+
+// Record appends a host action to the room's trail. The action has already
+// happened, so a failed write is logged rather than returned.
+func (s *AuditService) Record(ctx context.Context, roomCode, hostID string, action model.AuditAction, payload map[string]interface{}) {
+	entry := &model.AuditEntry{
+		RoomCode: roomCode,
+		HostID:   hostID,
+		Action:   action,
+		Payload:  payload,
+		At:       time.Now(),
+	}
+	if err := s.auditRepo.Append(ctx, entry); err != nil {
+		fmt.Printf("[Audit] Failed to record %s on %s by %s: %v\n", action, roomCode, hostID, err)
+	}
+}
+
+// List returns a room's audit trail, oldest first; nil when the room does not exist
+func (s *AuditService) List(ctx context.Context, roomCode, hostID string) ([]*model.AuditEntry, error) {
+	room, err := s.roomRepo.GetByCode(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, nil
+	}
+	if room.HostID != hostID {
+		return nil, ErrNotRoomHost
+	}
+	return s.auditRepo.GetByRoom(ctx, roomCode)
+}
@@ -81,8 +81,10 @@ func (s *AuthService) ValidateHostToken(tokenString string) (*model.HostClaims,
 		return nil, ErrInvalidToken
 	}
 
+	// Player, share and embed tokens are signed with the same key; only host tokens
+	// carry a hostId without an audience
 	claims, ok := token.Claims.(*model.HostClaims)
-	if !ok || !token.Valid {
+	if !ok || !token.Valid || claims.HostID == "" || len(claims.Audience) > 0 {
 		return nil, ErrInvalidToken
 	}
 
@@ -114,7 +116,61 @@ func (s *AuthService) ValidatePlayerToken(tokenString string) (*model.PlayerClai
 	}
 
 	claims, ok := token.Claims.(*model.PlayerClaims)
-	if !ok || !token.Valid {
+	if !ok || !token.Valid || claims.PlayerID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// GenerateShareToken creates a read-only token for a room's results
+func (s *AuthService) GenerateShareToken(roomCode, hostID string, ttl time.Duration) (string, time.Time, error) {
+	return s.generateRoomToken(model.ShareAudience, roomCode, hostID, ttl)
+}
+
+// ValidateShareToken validates a share token and returns claims
+func (s *AuthService) ValidateShareToken(tokenString string) (*model.ShareClaims, error) {
+	return s.validateRoomToken(model.ShareAudience, tokenString)
+}
+
+// GenerateEmbedToken creates a read-only token for a room's live results widget
+func (s *AuthService) GenerateEmbedToken(roomCode, hostID string, ttl time.Duration) (string, time.Time, error) {
+	return s.generateRoomToken(model.EmbedAudience, roomCode, hostID, ttl)
+}
+
+// ValidateEmbedToken validates an embed token and returns claims
+func (s *AuthService) ValidateEmbedToken(tokenString string) (*model.ShareClaims, error) {
+	return s.validateRoomToken(model.EmbedAudience, tokenString)
+}
+
+// generateRoomToken signs an expiring, read-only token for one audience
+func (s *AuthService) generateRoomToken(audience, roomCode, hostID string, ttl time.Duration) (string, time.Time, error) {
+	expiresAt := time.Now().Add(ttl)
+	claims := &model.ShareClaims{
+		RoomCode: roomCode,
+		HostID:   hostID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{audience},
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString(s.jwtSecret)
+	return tokenString, expiresAt, err
+}
+
+func (s *AuthService) validateRoomToken(audience, tokenString string) (*model.ShareClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &model.ShareClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return s.jwtSecret, nil
+	}, jwt.WithAudience(audience), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*model.ShareClaims)
+	if !ok || !token.Valid || claims.RoomCode == "" {
 		return nil, ErrInvalidToken
 	}
 
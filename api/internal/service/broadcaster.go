@@ -1,9 +1,13 @@
 package service
 
-// Broadcaster interface for WebSocket broadcasting (avoids import cycle)
+import "2026champs/internal/events"
+
+// Broadcaster publishes typed room events to connected clients (implemented by events.Bus)
 type Broadcaster interface {
-	BroadcastToHost(roomCode string, msgType string, payload interface{})
-	BroadcastToPlayer(roomCode, playerID string, msgType string, payload interface{})
-	BroadcastToAllPlayers(roomCode string, msgType string, payload interface{})
+	ToHost(roomCode string, t events.Type, payload interface{})
+	ToPlayer(roomCode, playerID string, t events.Type, payload interface{})
+	ToPlayers(roomCode string, t events.Type, payload interface{})
+	ToRoom(roomCode string, t events.Type, payload interface{})
 	DisconnectRoom(roomCode string)
+	DisconnectPlayer(roomCode, playerID string)
 }
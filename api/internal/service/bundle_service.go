@@ -0,0 +1,253 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// bundleEventPage is how many events are read per query while exporting
+const bundleEventPage = 1000
+
+// Bundle import errors
+var (
+	ErrBundleVersion = errors.New("unsupported bundle version")
+	ErrInvalidBundle = errors.New("invalid bundle")
+	ErrRoomExists    = errors.New("a room with this code already exists")
+)
+
+// BundleImportOptions rewrite a bundle as it is imported
+type BundleImportOptions struct {
+	RoomCode string // Import under this code instead of the exported one
+	HostID   string // Give the room and survey to this host instead of the exported one
+}
+
+// BundleService exports everything stored about a room as one bundle and imports
+// bundles into another database, so support can reproduce issues locally and
+// researchers can archive studies. Live Redis state is not part of a bundle.
+type BundleService struct {
+	roomRepo      repository.RoomRepo
+	surveyRepo    repository.SurveyRepo
+	answerRepo    repository.AnswerRepo
+	reportRepo    repository.ReportRepo
+	analyticsRepo repository.AnalyticsRepo
+	eventRepo     repository.EventRepo
+}
+
+// NewBundleService creates a new bundle service
+func NewBundleService(
+	roomRepo repository.RoomRepo,
+	surveyRepo repository.SurveyRepo,
+	answerRepo repository.AnswerRepo,
+	reportRepo repository.ReportRepo,
+	analyticsRepo repository.AnalyticsRepo,
+	eventRepo repository.EventRepo,
+) *BundleService {
+	return &BundleService{
+		roomRepo:      roomRepo,
+		surveyRepo:    surveyRepo,
+		answerRepo:    answerRepo,
+		reportRepo:    reportRepo,
+		analyticsRepo: analyticsRepo,
+		eventRepo:     eventRepo,
+	}
+}
+
+// Export gathers the host's room into a bundle; nil if the room does not exist
+func (s *BundleService) Export(ctx context.Context, hostID, roomCode string) (*model.RoomBundle, error) {
+	room, err := s.roomRepo.GetByCode(ctx, roomCode)
+	if err != nil || room == nil {
+		return nil, err
+	}
+	if room.HostID != hostID {
+		return nil, ErrNotRoomHost
+	}
+
+	bundle := &model.RoomBundle{
+		Version:    model.BundleVersion,
+		ExportedAt: time.Now(),
+		Room:       room,
+	}
+	if bundle.Survey, err = s.surveyRepo.GetByID(ctx, room.SurveyID); err != nil {
+		return nil, fmt.Errorf("survey: %w", err)
+	}
+	if bundle.Answers, err = s.answerRepo.GetByRoomCode(ctx, roomCode); err != nil {
+		return nil, fmt.Errorf("answers: %w", err)
+	}
+	if bundle.QuestionProfiles, err = s.analyticsRepo.GetQuestionProfiles(ctx, roomCode); err != nil {
+		return nil, fmt.Errorf("question profiles: %w", err)
+	}
+	if bundle.PlayerProfiles, err = s.analyticsRepo.GetPlayerProfiles(ctx, roomCode); err != nil {
+		return nil, fmt.Errorf("player profiles: %w", err)
+	}
+	if bundle.Memory, err = s.analyticsRepo.GetRoomMemory(ctx, roomCode); err != nil {
+		return nil, fmt.Errorf("room memory: %w", err)
+	}
+	if bundle.Snapshot, err = s.reportRepo.GetSnapshot(ctx, roomCode); err != nil {
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+	if bundle.AIReport, err = s.reportRepo.GetAIReport(ctx, roomCode); err != nil {
+		return nil, fmt.Errorf("AI report: %w", err)
+	}
+	if bundle.Events, err = s.allEvents(ctx, roomCode); err != nil {
+		return nil, fmt.Errorf("events: %w", err)
+	}
+
+	// Keep the arrays present in the JSON even when empty
+	if bundle.Answers == nil {
+		bundle.Answers = []*model.Answer{}
+	}
+	if bundle.QuestionProfiles == nil {
+		bundle.QuestionProfiles = []*model.QuestionProfile{}
+	}
+	if bundle.PlayerProfiles == nil {
+		bundle.PlayerProfiles = []*model.PlayerProfile{}
+	}
+	return bundle, nil
+}
+
+// allEvents reads the room's whole persisted event stream in seq order
+func (s *BundleService) allEvents(ctx context.Context, roomCode string) ([]*model.RoomEvent, error) {
+	all := []*model.RoomEvent{}
+	var afterSeq int64
+	for {
+		page, err := s.eventRepo.ListAfter(ctx, roomCode, afterSeq, bundleEventPage)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < bundleEventPage {
+			return all, nil
+		}
+		afterSeq = page[len(page)-1].Seq
+	}
+}
+
+// Import writes a bundle's room, survey, answers, analytics, reports and events.
+// The survey gets a new ID and answers new IDs; the room code must be free.
+func (s *BundleService) Import(ctx context.Context, bundle *model.RoomBundle, opts BundleImportOptions) (*model.BundleImport, error) {
+	if bundle.Version != model.BundleVersion {
+		return nil, fmt.Errorf("%w: %d (expected %d)", ErrBundleVersion, bundle.Version, model.BundleVersion)
+	}
+	if bundle.Room == nil || bundle.Room.Code == "" {
+		return nil, fmt.Errorf("%w: room is missing", ErrInvalidBundle)
+	}
+
+	code := bundle.Room.Code
+	if opts.RoomCode != "" {
+		code = opts.RoomCode
+	}
+	hostID := bundle.Room.HostID
+	if opts.HostID != "" {
+		hostID = opts.HostID
+	}
+	existing, err := s.roomRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRoomExists, code)
+	}
+	rebindBundle(bundle, code, hostID)
+
+	result := &model.BundleImport{RoomCode: code, HostID: hostID}
+	room := bundle.Room
+	if bundle.Survey != nil {
+		bundle.Survey.ID = ""
+		surveyID, err := s.surveyRepo.Create(ctx, bundle.Survey)
+		if err != nil {
+			return nil, fmt.Errorf("survey: %w", err)
+		}
+		room.SurveyID = surveyID
+		result.SurveyID = surveyID
+	}
+
+	// Create stamps the current time; put the exported one back
+	createdAt := room.CreatedAt
+	if err := s.roomRepo.Create(ctx, room); err != nil {
+		return nil, fmt.Errorf("room: %w", err)
+	}
+	room.CreatedAt = createdAt
+	if err := s.roomRepo.Update(ctx, room); err != nil {
+		return nil, fmt.Errorf("room: %w", err)
+	}
+
+	for _, a := range bundle.Answers {
+		a.ID = ""
+	}
+	if err := s.answerRepo.Restore(ctx, bundle.Answers); err != nil {
+		return nil, fmt.Errorf("answers: %w", err)
+	}
+	result.Answers = len(bundle.Answers)
+
+	if err := s.analyticsRepo.SaveQuestionProfiles(ctx, bundle.QuestionProfiles); err != nil {
+		return nil, fmt.Errorf("question profiles: %w", err)
+	}
+	if err := s.analyticsRepo.SavePlayerProfiles(ctx, bundle.PlayerProfiles); err != nil {
+		return nil, fmt.Errorf("player profiles: %w", err)
+	}
+	if bundle.Memory != nil {
+		if err := s.analyticsRepo.SaveRoomMemory(ctx, bundle.Memory); err != nil {
+			return nil, fmt.Errorf("room memory: %w", err)
+		}
+	}
+	if bundle.Snapshot != nil {
+		bundle.Snapshot.SurveyID = room.SurveyID
+		if err := s.reportRepo.SaveSnapshot(ctx, bundle.Snapshot); err != nil {
+			return nil, fmt.Errorf("snapshot: %w", err)
+		}
+	}
+	if bundle.AIReport != nil {
+		if err := s.reportRepo.SaveAIReport(ctx, bundle.AIReport); err != nil {
+			return nil, fmt.Errorf("AI report: %w", err)
+		}
+	}
+
+	for _, e := range bundle.Events {
+		e.ID = ""
+	}
+	if err := s.eventRepo.Append(ctx, bundle.Events); err != nil {
+		return nil, fmt.Errorf("events: %w", err)
+	}
+	result.Events = len(bundle.Events)
+
+	fmt.Printf("[Bundle] Imported room %s (%d answers, %d events)\n", code, result.Answers, result.Events)
+	return result, nil
+}
+
+// rebindBundle points every record in the bundle at the room code and host it is imported under
+func rebindBundle(bundle *model.RoomBundle, code, hostID string) {
+	bundle.Room.Code = code
+	bundle.Room.HostID = hostID
+	if bundle.Survey != nil {
+		bundle.Survey.HostID = hostID
+	}
+	for _, a := range bundle.Answers {
+		a.RoomCode = code
+	}
+	for _, p := range bundle.QuestionProfiles {
+		p.RoomCode = code
+	}
+	for _, p := range bundle.PlayerProfiles {
+		p.RoomCode = code
+	}
+	if bundle.Memory != nil {
+		bundle.Memory.RoomCode = code
+	}
+	if bundle.Snapshot != nil {
+		bundle.Snapshot.RoomCode = code
+		bundle.Snapshot.Memory.RoomCode = code
+		for i := range bundle.Snapshot.QuestionProfiles {
+			bundle.Snapshot.QuestionProfiles[i].RoomCode = code
+		}
+	}
+	if bundle.AIReport != nil {
+		bundle.AIReport.RoomCode = code
+	}
+	for _, e := range bundle.Events {
+		e.RoomCode = code
+	}
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Calibration tuning
+const (
+	minCalibrationSamples = 5    // per rubric/bucket before drawing conclusions
+	biasFindingThreshold  = 0.15 // |human - AI| quality gap worth reporting
+	minThresholdGain      = 0.10 // agreement gain required to suggest a new threshold
+)
+
+// lengthBuckets split samples by answer length in words
+var lengthBuckets = []model.CalibrationBucket{
+	{Label: "short", MinWords: 0, MaxWords: 8},
+	{Label: "medium", MinWords: 9, MaxWords: 30},
+	{Label: "long", MinWords: 31},
+}
+
+// CalibrationService compares AI evaluations with host overrides and audits
+type CalibrationService struct {
+	answerRepo repository.AnswerRepo
+	roomRepo   repository.RoomRepo
+	surveyRepo repository.SurveyRepo
+}
+
+// NewCalibrationService creates a new calibration service
+func NewCalibrationService(answerRepo repository.AnswerRepo, roomRepo repository.RoomRepo, surveyRepo repository.SurveyRepo) *CalibrationService {
+	return &CalibrationService{
+		answerRepo: answerRepo,
+		roomRepo:   roomRepo,
+		surveyRepo: surveyRepo,
+	}
+}
+
+// AuditAnswer records an independent human judgment of an evaluated answer
+func (s *CalibrationService) AuditAnswer(ctx context.Context, hostID, answerID string, req *model.AuditRequest) (*model.Answer, error) {
+	if req.Resolution != model.ResolutionSat && req.Resolution != model.ResolutionUnsat {
+		return nil, fmt.Errorf("resolution must be SAT or UNSAT")
+	}
+	if req.QualityScore < 0 || req.QualityScore > 1 {
+		return nil, fmt.Errorf("qualityScore must be between 0 and 1")
+	}
+
+	answer, err := s.answerRepo.GetByID(ctx, answerID)
+	if err != nil {
+		return nil, err
+	}
+	if answer == nil {
+		return nil, nil
+	}
+	room, err := s.roomRepo.GetByCode(ctx, answer.RoomCode)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil || room.HostID != hostID {
+		return nil, ErrNotRoomHost
+	}
+	if answer.Status != model.AnswerStatusEvaluated {
+		return nil, fmt.Errorf("answer has not been evaluated")
+	}
+
+	answer.Audit = &model.AnswerAudit{
+		AuditorID:    hostID,
+		Resolution:   req.Resolution,
+		QualityScore: req.QualityScore,
+		Notes:        req.Notes,
+		AuditedAt:    time.Now(),
+	}
+	if err := s.answerRepo.Update(ctx, answer); err != nil {
+		return nil, fmt.Errorf("failed to save audit: %w", err)
+	}
+	return answer, nil
+}
+
+// calibrationSample is one AI judgment paired with a human one
+type calibrationSample struct {
+	words         int
+	aiSat         bool
+	aiQuality     float64
+	humanSat      bool
+	humanQuality  float64
+	hasHumanScore bool
+}
+
+// rubricKey identifies a survey question across rooms
+type rubricKey struct {
+	surveyID    string
+	questionKey string
+}
+
+// GetCalibration builds the calibration report over all of a host's rooms
+func (s *CalibrationService) GetCalibration(ctx context.Context, hostID string) (*model.CalibrationReport, error) {
+	rooms, err := s.roomRepo.GetByHostID(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	codes := make([]string, 0, len(rooms))
+	roomSurvey := make(map[string]string, len(rooms))
+	for _, r := range rooms {
+		if r.Settings.Practice {
+			continue
+		}
+		codes = append(codes, r.Code)
+		roomSurvey[r.Code] = r.SurveyID
+	}
+
+	answers, err := s.answerRepo.GetReviewed(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.CalibrationReport{
+		HostID:        hostID,
+		LengthBuckets: []model.CalibrationBucket{},
+		Rubrics:       []model.RubricCalibration{},
+		Findings:      []string{},
+	}
+
+	all := []calibrationSample{}
+	byRubric := map[rubricKey][]calibrationSample{}
+	for _, a := range answers {
+		sample, ok := toCalibrationSample(a)
+		if !ok {
+			continue
+		}
+		if a.Audit != nil {
+			report.Audits++
+		} else {
+			report.Overrides++
+		}
+		all = append(all, sample)
+		key := rubricKey{surveyID: roomSurvey[a.RoomCode], questionKey: baseQuestionKey(a.QuestionKey)}
+		byRubric[key] = append(byRubric[key], sample)
+	}
+	report.Samples = len(all)
+	if len(all) == 0 {
+		return report, nil
+	}
+
+	agree, bias, aiMean, humanMean := summarizeSamples(all)
+	report.AgreementRate = agree
+	report.MeanBias = bias
+	report.MeanAIQuality = aiMean
+	report.MeanHumanQuality = humanMean
+
+	// Bias by answer length
+	for _, b := range lengthBuckets {
+		bucket := b
+		var in []calibrationSample
+		for _, smp := range all {
+			if smp.words >= b.MinWords && (b.MaxWords == 0 || smp.words <= b.MaxWords) {
+				in = append(in, smp)
+			}
+		}
+		bucket.Samples = len(in)
+		_, bucket.MeanBias, _, _ = summarizeSamples(in)
+		bucket.HarshFlips, bucket.LenientFlips = countFlips(in)
+		report.LengthBuckets = append(report.LengthBuckets, bucket)
+
+		if bucket.Samples >= minCalibrationSamples {
+			if bucket.MeanBias >= biasFindingThreshold {
+				report.Findings = append(report.Findings, fmt.Sprintf("AI is too harsh on %s answers: humans score them %.2f higher on average (%d samples)", bucket.Label, bucket.MeanBias, bucket.Samples))
+			} else if bucket.MeanBias <= -biasFindingThreshold {
+				report.Findings = append(report.Findings, fmt.Sprintf("AI is too lenient on %s answers: humans score them %.2f lower on average (%d samples)", bucket.Label, -bucket.MeanBias, bucket.Samples))
+			}
+		}
+	}
+
+	// Per-rubric agreement and threshold suggestions
+	surveys := map[string]*model.Survey{}
+	for key, samples := range byRubric {
+		survey, ok := surveys[key.surveyID]
+		if !ok {
+			survey, _ = s.surveyRepo.GetByID(ctx, key.surveyID)
+			surveys[key.surveyID] = survey
+		}
+
+		rc := model.RubricCalibration{
+			SurveyID:    key.surveyID,
+			QuestionKey: key.questionKey,
+			Samples:     len(samples),
+		}
+		if survey != nil {
+			rc.SurveyTitle = survey.Title
+			rc.CurrentThreshold = survey.Settings.SatisfactoryThreshold
+			if q := findBaseQuestion(survey, key.questionKey); q != nil {
+				rc.Prompt = q.Prompt
+				if q.Threshold > 0 {
+					rc.CurrentThreshold = q.Threshold
+				}
+			}
+		}
+		rc.AgreementRate, rc.MeanBias, _, _ = summarizeSamples(samples)
+		rc.HarshFlips, rc.LenientFlips = countFlips(samples)
+		rc.ThresholdAgreement = thresholdAgreement(samples, rc.CurrentThreshold)
+
+		if len(samples) >= minCalibrationSamples {
+			best, bestAgree := suggestThreshold(samples, rc.CurrentThreshold)
+			if bestAgree-rc.ThresholdAgreement >= minThresholdGain {
+				rc.SuggestedThreshold = &best
+				rc.SuggestedAgreement = bestAgree
+				report.Findings = append(report.Findings, fmt.Sprintf("%s %s: moving the threshold from %.2f to %.2f would match human judgments %.0f%% of the time instead of %.0f%%",
+					rc.SurveyTitle, rc.QuestionKey, rc.CurrentThreshold, best, bestAgree*100, rc.ThresholdAgreement*100))
+			}
+		}
+		report.Rubrics = append(report.Rubrics, rc)
+	}
+	sort.Slice(report.Rubrics, func(i, j int) bool {
+		if report.Rubrics[i].SurveyID != report.Rubrics[j].SurveyID {
+			return report.Rubrics[i].SurveyID < report.Rubrics[j].SurveyID
+		}
+		return report.Rubrics[i].QuestionKey < report.Rubrics[j].QuestionKey
+	})
+
+	return report, nil
+}
+
+// toCalibrationSample pairs the AI judgment with the audit, or else the override
+func toCalibrationSample(a *model.Answer) (calibrationSample, bool) {
+	smp := calibrationSample{
+		words:     len(strings.Fields(a.TextAnswer)),
+		aiQuality: a.QualityScore,
+	}
+	switch {
+	case a.Audit != nil:
+		smp.aiSat = a.Resolution == model.ResolutionSat
+		if a.Override != nil {
+			smp.aiSat = a.Override.OriginalResolution == model.ResolutionSat
+		}
+		smp.humanSat = a.Audit.Resolution == model.ResolutionSat
+		smp.humanQuality = a.Audit.QualityScore
+		smp.hasHumanScore = true
+	case a.Override != nil:
+		smp.aiSat = a.Override.OriginalResolution == model.ResolutionSat
+		smp.humanSat = a.Override.Resolution == model.ResolutionSat
+		if a.Override.PointsMax > 0 {
+			smp.humanQuality = float64(a.Override.Points) / float64(a.Override.PointsMax)
+			smp.hasHumanScore = true
+		}
+	default:
+		return smp, false
+	}
+	return smp, true
+}
+
+// summarizeSamples returns resolution agreement, mean human-AI bias and mean scores
+func summarizeSamples(samples []calibrationSample) (agree, bias, aiMean, humanMean float64) {
+	if len(samples) == 0 {
+		return 0, 0, 0, 0
+	}
+	matches, scored := 0, 0
+	for _, smp := range samples {
+		if smp.aiSat == smp.humanSat {
+			matches++
+		}
+		if smp.hasHumanScore {
+			scored++
+			aiMean += smp.aiQuality
+			humanMean += smp.humanQuality
+		}
+	}
+	agree = float64(matches) / float64(len(samples))
+	if scored > 0 {
+		aiMean /= float64(scored)
+		humanMean /= float64(scored)
+		bias = humanMean - aiMean
+	}
+	return agree, bias, aiMean, humanMean
+}
+
+// countFlips counts resolutions humans reversed in each direction
+func countFlips(samples []calibrationSample) (harsh, lenient int) {
+	for _, smp := range samples {
+		if !smp.aiSat && smp.humanSat {
+			harsh++
+		} else if smp.aiSat && !smp.humanSat {
+			lenient++
+		}
+	}
+	return harsh, lenient
+}
+
+// thresholdAgreement is how often "AI quality >= threshold" matches the human resolution
+func thresholdAgreement(samples []calibrationSample, threshold float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	matches := 0
+	for _, smp := range samples {
+		if (smp.aiQuality >= threshold) == smp.humanSat {
+			matches++
+		}
+	}
+	return float64(matches) / float64(len(samples))
+}
+
+// suggestThreshold scans thresholds in 0.05 steps for the best agreement,
+// preferring the one closest to the current threshold on ties
+func suggestThreshold(samples []calibrationSample, current float64) (float64, float64) {
+	best, bestAgree := current, thresholdAgreement(samples, current)
+	for i := 1; i < 20; i++ {
+		t := float64(i) * 0.05
+		agree := thresholdAgreement(samples, t)
+		if agree > bestAgree || (agree == bestAgree && math.Abs(t-current) < math.Abs(best-current)) {
+			best, bestAgree = t, agree
+		}
+	}
+	return math.Round(best*100) / 100, bestAgree
+}
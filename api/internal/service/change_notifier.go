@@ -0,0 +1,192 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/events"
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// Reconnect backoff for a failed change stream
+const (
+	changeStreamMinBackoff = time.Second
+	changeStreamMaxBackoff = time.Minute
+)
+
+// ChangeNotifier turns writes to answers and AI reports into host events,
+// whoever made them: request handlers, background jobs, other instances or
+// admin tools. It follows MongoDB change streams (replica sets only). Every
+// instance runs one; the first to claim a change in Redis publishes it.
+type ChangeNotifier struct {
+	changes     repository.ChangeStreamRepo
+	claims      cache.ChangeCache
+	roomCache   cache.RoomCache
+	broadcaster Broadcaster
+
+	answersOpen atomic.Bool
+}
+
+// NewChangeNotifier creates a new change notifier; call Start to follow the streams
+func NewChangeNotifier(changes repository.ChangeStreamRepo, claims cache.ChangeCache, roomCache cache.RoomCache) *ChangeNotifier {
+	return &ChangeNotifier{
+		changes:   changes,
+		claims:    claims,
+		roomCache: roomCache,
+	}
+}
+
+// SetBroadcaster sets the broadcaster for host events
+func (s *ChangeNotifier) SetBroadcaster(b Broadcaster) {
+	s.broadcaster = b
+}
+
+// AnswersActive reports whether the answers stream is open. Services skip their
+// own host notifications for stored answers while it is, so hosts get each once.
+func (s *ChangeNotifier) AnswersActive() bool {
+	return s != nil && s.answersOpen.Load()
+}
+
+// Start follows the answers and AI reports streams until ctx is done
+func (s *ChangeNotifier) Start(ctx context.Context) {
+	go s.follow(ctx, "answers", s.changes.WatchAnswers, s.answerChanged, &s.answersOpen)
+	go s.follow(ctx, "ai_reports", s.changes.WatchAIReports, s.aiReportChanged, nil)
+}
+
+// follow reads one stream, reopening it after the last change seen when it
+// fails. It gives up when MongoDB does not support change streams.
+func (s *ChangeNotifier) follow(ctx context.Context, name string,
+	open func(context.Context, repository.ResumeToken) (*repository.ChangeStream, error),
+	handle func(context.Context, *repository.Change), active *atomic.Bool) {
+
+	var token repository.ResumeToken
+	backoff := changeStreamMinBackoff
+	for {
+		stream, err := open(ctx, token)
+		if err == nil {
+			if active != nil {
+				active.Store(true)
+			}
+			backoff = changeStreamMinBackoff
+			var change *repository.Change
+			for change, err = stream.Next(ctx); err == nil; change, err = stream.Next(ctx) {
+				token = change.Token
+				handle(ctx, change)
+			}
+			stream.Close()
+			if active != nil {
+				active.Store(false)
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		switch {
+		case errors.Is(err, repository.ErrChangeStreamsUnsupported):
+			fmt.Printf("[Changes] %s: %v; services keep notifying hosts themselves\n", name, err)
+			return
+		case errors.Is(err, repository.ErrChangeHistoryLost):
+			// The changes since the token are gone; carry on from now
+			token = nil
+		}
+		fmt.Printf("[Changes] %s stream failed, reopening in %s: %v\n", name, backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, changeStreamMaxBackoff)
+	}
+}
+
+// publishable reports whether a change in the room should reach its host: the
+// room still has live state (imports and old rooms do not) and no other
+// instance claimed the change
+func (s *ChangeNotifier) publishable(ctx context.Context, roomCode string, change *repository.Change) bool {
+	if s.broadcaster == nil {
+		return false
+	}
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil || meta == nil {
+		return false
+	}
+	claimed, err := s.claims.Claim(ctx, change.ID())
+	if err != nil {
+		fmt.Printf("[Changes] Claim for room %s failed: %v\n", roomCode, err)
+		return false
+	}
+	return claimed
+}
+
+// answerChanged sends player_progress_update for new answers, as services do
+// after storing one, and answer_updated for later edits
+func (s *ChangeNotifier) answerChanged(ctx context.Context, change *repository.Change) {
+	var answer model.Answer
+	if err := change.Decode(&answer); err != nil {
+		fmt.Printf("[Changes] Undecodable answer change: %v\n", err)
+		return
+	}
+
+	var fields []string
+	for _, f := range change.UpdatedFields {
+		if f != "updatedAt" {
+			fields = append(fields, f)
+		}
+	}
+	if change.Op == repository.ChangeUpdate && len(fields) == 0 {
+		return
+	}
+	if !s.publishable(ctx, answer.RoomCode, change) {
+		return
+	}
+
+	if change.Op == repository.ChangeInsert {
+		s.broadcaster.ToHost(answer.RoomCode, events.PlayerProgressUpdate, events.PlayerProgressPayload{
+			PlayerID:    answer.PlayerID,
+			QuestionKey: answer.QuestionKey,
+			Status:      string(answer.Status),
+			Resolution:  string(answer.Resolution),
+			OptionIndex: answer.OptionIndex,
+			Attachments: len(answer.Attachments),
+			Duplicate:   answer.Duplicate,
+		})
+		return
+	}
+	s.broadcaster.ToHost(answer.RoomCode, events.AnswerUpdated, events.AnswerUpdatedPayload{
+		AnswerID:      answer.ID,
+		PlayerID:      answer.PlayerID,
+		QuestionKey:   answer.QuestionKey,
+		Status:        string(answer.Status),
+		Resolution:    string(answer.Resolution),
+		PointsEarned:  answer.PointsEarned,
+		Overridden:    answer.Override != nil,
+		Starred:       answer.Star != nil,
+		UpdatedFields: fields,
+	})
+}
+
+// aiReportChanged sends ai_report_status when a report is created or its status moves
+func (s *ChangeNotifier) aiReportChanged(ctx context.Context, change *repository.Change) {
+	var report model.AIReport
+	if err := change.Decode(&report); err != nil {
+		fmt.Printf("[Changes] Undecodable AI report change: %v\n", err)
+		return
+	}
+	if change.Op == repository.ChangeUpdate && !slices.Contains(change.UpdatedFields, "status") {
+		return
+	}
+	if !s.publishable(ctx, report.RoomCode, change) {
+		return
+	}
+
+	s.broadcaster.ToHost(report.RoomCode, events.AIReportStatus, events.AIReportStatusPayload{
+		Status:  report.Status,
+		ReadyAt: report.ReadyAt,
+	})
+}
@@ -0,0 +1,337 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// comparisonTimeout bounds generating one comparison report
+const comparisonTimeout = 2 * time.Minute
+
+// ErrInvalidComparison is returned when two rooms cannot be compared
+var ErrInvalidComparison = errors.New("invalid comparison")
+
+// ComparisonService compares two ended rooms of the same survey, e.g. sessions
+// before and after the host reworded questions, and stores the comparison so
+// the host can tell whether the changes had impact
+type ComparisonService struct {
+	compRepo   repository.ComparisonRepo
+	roomRepo   repository.RoomRepo
+	surveyRepo repository.SurveyRepo
+	reportSvc  *ReportService
+	evaluator  *EvaluatorService
+}
+
+// NewComparisonService creates a new comparison service
+func NewComparisonService(compRepo repository.ComparisonRepo, roomRepo repository.RoomRepo, surveyRepo repository.SurveyRepo, reportSvc *ReportService, evaluator *EvaluatorService) *ComparisonService {
+	return &ComparisonService{
+		compRepo:   compRepo,
+		roomRepo:   roomRepo,
+		surveyRepo: surveyRepo,
+		reportSvc:  reportSvc,
+		evaluator:  evaluator,
+	}
+}
+
+// Compare checks the two rooms, stores a pending comparison and generates it in the background
+func (s *ComparisonService) Compare(ctx context.Context, hostID string, req *model.ComparisonRequest) (*model.ComparisonReport, error) {
+	baseline, current := strings.TrimSpace(req.BaselineRoom), strings.TrimSpace(req.RoomCode)
+	if baseline == "" || current == "" {
+		return nil, fmt.Errorf("%w: baselineRoom and roomCode are required", ErrInvalidComparison)
+	}
+	if baseline == current {
+		return nil, fmt.Errorf("%w: compare two different rooms", ErrInvalidComparison)
+	}
+
+	surveyID := ""
+	for _, code := range []string{baseline, current} {
+		room, err := s.roomRepo.GetByCode(ctx, code)
+		if err != nil {
+			return nil, err
+		}
+		if room == nil || room.HostID != hostID {
+			return nil, fmt.Errorf("%w: room %s not found", ErrInvalidComparison, code)
+		}
+		if room.Settings.Practice {
+			return nil, fmt.Errorf("%w: practice room %s cannot be compared", ErrInvalidComparison, code)
+		}
+		if surveyID != "" && room.SurveyID != surveyID {
+			return nil, fmt.Errorf("%w: the rooms run different surveys", ErrInvalidComparison)
+		}
+		surveyID = room.SurveyID
+
+		snapshot, err := s.reportSvc.reportRepo.GetSnapshot(ctx, code)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot == nil {
+			return nil, fmt.Errorf("%w: room %s has not ended", ErrInvalidComparison, code)
+		}
+	}
+
+	report := &model.ComparisonReport{
+		HostID:       hostID,
+		SurveyID:     surveyID,
+		BaselineRoom: baseline,
+		RoomCode:     current,
+		Status:       "pending",
+		CreatedAt:    time.Now(),
+	}
+	id, err := s.compRepo.Create(ctx, report)
+	if err != nil {
+		return nil, err
+	}
+	report.ID = id
+	go s.generate(report)
+	return report, nil
+}
+
+// Get returns one of the host's comparisons; nil if it does not exist
+func (s *ComparisonService) Get(ctx context.Context, hostID, id string) (*model.ComparisonReport, error) {
+	return s.compRepo.Get(ctx, hostID, id)
+}
+
+// List returns the host's comparisons, newest first; surveyID narrows them to one survey
+func (s *ComparisonService) List(ctx context.Context, hostID, surveyID string) ([]*model.ComparisonReport, error) {
+	return s.compRepo.ListByHost(ctx, hostID, surveyID)
+}
+
+// generate measures the changes between the two snapshots and has the model read them
+func (s *ComparisonService) generate(report *model.ComparisonReport) {
+	ctx, cancel := context.WithTimeout(context.Background(), comparisonTimeout)
+	defer cancel()
+
+	fail := func(err error) {
+		fmt.Printf("[Compare] Comparison %s of %s with %s failed: %v\n", report.ID, report.RoomCode, report.BaselineRoom, err)
+		report.Status = "failed"
+		if err := s.compRepo.SetResult(ctx, report); err != nil {
+			fmt.Printf("[Compare] Failed to store comparison status for %s: %v\n", report.ID, err)
+		}
+	}
+
+	baseline, err := s.reportSvc.GetSnapshot(ctx, report.BaselineRoom)
+	if err == nil && baseline == nil {
+		err = fmt.Errorf("snapshot of %s not found", report.BaselineRoom)
+	}
+	if err != nil {
+		fail(err)
+		return
+	}
+	current, err := s.reportSvc.GetSnapshot(ctx, report.RoomCode)
+	if err == nil && current == nil {
+		err = fmt.Errorf("snapshot of %s not found", report.RoomCode)
+	}
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	measureComparison(report, baseline, current)
+
+	baselineEvidence, evidence := map[string][]string{}, map[string][]string{}
+	if answers, err := s.reportSvc.answerRepo.GetByRoomCode(ctx, report.BaselineRoom); err == nil {
+		baselineEvidence, _ = s.reportSvc.sampleEvidence(answers)
+	}
+	if answers, err := s.reportSvc.answerRepo.GetByRoomCode(ctx, report.RoomCode); err == nil {
+		evidence, _ = s.reportSvc.sampleEvidence(answers)
+	}
+	var guardrails *model.Guardrails
+	if survey, err := s.surveyRepo.GetByID(ctx, report.SurveyID); err == nil && survey != nil {
+		guardrails = survey.Guardrails
+	}
+	s.evaluator.GenerateComparison(WithAIRoom(ctx, report.RoomCode), report, baselineEvidence, evidence, guardrails)
+
+	report.Status = "ready"
+	now := time.Now()
+	report.ReadyAt = &now
+	if err := s.compRepo.SetResult(ctx, report); err != nil {
+		fmt.Printf("[Compare] Failed to store comparison %s: %v\n", report.ID, err)
+		return
+	}
+	fmt.Printf("[Compare] Comparison %s of %s with %s ready\n", report.ID, report.RoomCode, report.BaselineRoom)
+}
+
+// measureComparison fills the report's stats, theme shifts, per-question deltas
+// and new friction points from the two snapshots
+func measureComparison(report *model.ComparisonReport, baseline, current *model.RoomSnapshot) {
+	stats := &model.ComparisonStats{
+		Players:        model.NewMetricDelta(float64(baseline.TotalPlayers), float64(current.TotalPlayers)),
+		CompletionRate: model.NewMetricDelta(baseline.CompletionRate, current.CompletionRate),
+		SkipRate:       model.NewMetricDelta(baseline.OverallSkipRate, current.OverallSkipRate),
+	}
+	var baseTotals, totals model.QuestionProfile
+	for i := range baseline.QuestionProfiles {
+		mergeQuestionProfile(&baseTotals, &baseline.QuestionProfiles[i])
+	}
+	for i := range current.QuestionProfiles {
+		mergeQuestionProfile(&totals, &current.QuestionProfiles[i])
+	}
+	stats.SatRate = satRateDelta(&baseTotals, &totals)
+	stats.AvgRating = ratingDelta(&baseTotals, &totals)
+	report.Stats = stats
+
+	report.ThemeShifts = themeShifts(baseline.QuestionProfiles, current.QuestionProfiles)
+
+	baseProfiles := make(map[string]*model.QuestionProfile, len(baseline.QuestionProfiles))
+	for i := range baseline.QuestionProfiles {
+		baseProfiles[baseline.QuestionProfiles[i].QuestionKey] = &baseline.QuestionProfiles[i]
+	}
+	report.SatisfactionDeltas = []model.SatisfactionDelta{}
+	seen := make(map[string]bool)
+	for i := range current.QuestionProfiles {
+		p := &current.QuestionProfiles[i]
+		seen[p.QuestionKey] = true
+		base := baseProfiles[p.QuestionKey]
+		if base == nil {
+			base = &model.QuestionProfile{QuestionKey: p.QuestionKey}
+		}
+		report.SatisfactionDeltas = append(report.SatisfactionDeltas, questionDelta(base, p))
+	}
+	for i := range baseline.QuestionProfiles {
+		p := &baseline.QuestionProfiles[i]
+		if !seen[p.QuestionKey] {
+			report.SatisfactionDeltas = append(report.SatisfactionDeltas, questionDelta(p, &model.QuestionProfile{QuestionKey: p.QuestionKey}))
+		}
+	}
+
+	report.NewFrictionPoints = []model.NewFrictionPoint{}
+	baseFriction := make(map[string]bool)
+	for _, fp := range frictionPoints(profilePointers(baseline.QuestionProfiles)) {
+		baseFriction[fp.QuestionKey] = true
+	}
+	for _, fp := range frictionPoints(profilePointers(current.QuestionProfiles)) {
+		if baseFriction[fp.QuestionKey] {
+			continue
+		}
+		point := model.NewFrictionPoint{QuestionKey: fp.QuestionKey, SkipRate: fp.SkipRate, UnsatRate: fp.UnsatRate}
+		if base := baseProfiles[fp.QuestionKey]; base != nil {
+			point.BaselineSkipRate = questionRate(base, base.SkipCount)
+			point.BaselineUnsatRate = questionRate(base, base.UnsatCount)
+		}
+		report.NewFrictionPoints = append(report.NewFrictionPoints, point)
+	}
+}
+
+func profilePointers(profiles []model.QuestionProfile) []*model.QuestionProfile {
+	out := make([]*model.QuestionProfile, len(profiles))
+	for i := range profiles {
+		out[i] = &profiles[i]
+	}
+	return out
+}
+
+// questionDelta compares one question's profile in the two rooms
+func questionDelta(base, p *model.QuestionProfile) model.SatisfactionDelta {
+	return model.SatisfactionDelta{
+		QuestionKey: p.QuestionKey,
+		SkipRate:    model.NewMetricDelta(questionRate(base, base.SkipCount), questionRate(p, p.SkipCount)),
+		SatRate:     satRateDelta(base, p),
+		AvgRating:   ratingDelta(base, p),
+	}
+}
+
+// questionRate is n over the question's answers, skips included; 0 without answers
+func questionRate(p *model.QuestionProfile, n int) float64 {
+	if p.AnswerCount == 0 {
+		return 0
+	}
+	return float64(n) / float64(p.AnswerCount)
+}
+
+// satRateDelta compares the share of SAT answers; nil unless both profiles resolved answers
+func satRateDelta(base, p *model.QuestionProfile) *model.MetricDelta {
+	if base.SatCount+base.UnsatCount == 0 || p.SatCount+p.UnsatCount == 0 {
+		return nil
+	}
+	d := model.NewMetricDelta(
+		float64(base.SatCount)/float64(base.SatCount+base.UnsatCount),
+		float64(p.SatCount)/float64(p.SatCount+p.UnsatCount),
+	)
+	return &d
+}
+
+// ratingDelta compares the mean rating; nil unless both profiles have ratings
+func ratingDelta(base, p *model.QuestionProfile) *model.MetricDelta {
+	if base.RatingCount == 0 || p.RatingCount == 0 {
+		return nil
+	}
+	d := model.NewMetricDelta(float64(base.RatingSum)/float64(base.RatingCount), float64(p.RatingSum)/float64(p.RatingCount))
+	return &d
+}
+
+// themeShifts compares each theme's share of the theme mentions, ignoring case.
+// Themes that moved less than model.MinComparisonThemeMove are left out.
+func themeShifts(baseline, current []model.QuestionProfile) []model.ThemeShift {
+	baseShares, baseNames := themeShares(baseline)
+	shares, names := themeShares(current)
+
+	shifts := []model.ThemeShift{}
+	add := func(key, name string) {
+		shift := model.ThemeShift{Theme: name, BaselineShare: baseShares[key], Share: shares[key]}
+		shift.Delta = shift.Share - shift.BaselineShare
+		switch {
+		case shift.BaselineShare == 0:
+			shift.Change = model.ThemeShiftNew
+		case shift.Share == 0:
+			shift.Change = model.ThemeShiftGone
+		case shift.Delta >= model.MinComparisonThemeMove:
+			shift.Change = model.ThemeShiftUp
+		case shift.Delta <= -model.MinComparisonThemeMove:
+			shift.Change = model.ThemeShiftDown
+		default:
+			return
+		}
+		shifts = append(shifts, shift)
+	}
+	for key, name := range names {
+		add(key, name)
+	}
+	for key, name := range baseNames {
+		if _, ok := names[key]; !ok {
+			add(key, name)
+		}
+	}
+
+	sort.Slice(shifts, func(i, j int) bool {
+		if a, b := math.Abs(shifts[i].Delta), math.Abs(shifts[j].Delta); a != b {
+			return a > b
+		}
+		return shifts[i].Theme < shifts[j].Theme
+	})
+	if len(shifts) > model.MaxComparisonThemes {
+		shifts = shifts[:model.MaxComparisonThemes]
+	}
+	return shifts
+}
+
+// themeShares returns each lowercased theme's share of all theme mentions and its display name
+func themeShares(profiles []model.QuestionProfile) (map[string]float64, map[string]string) {
+	counts := make(map[string]int)
+	names := make(map[string]string)
+	total := 0
+	for _, p := range profiles {
+		for theme, n := range p.ThemeCounts {
+			key := strings.ToLower(strings.TrimSpace(theme))
+			if key == "" || n <= 0 {
+				continue
+			}
+			if _, ok := names[key]; !ok {
+				names[key] = strings.TrimSpace(theme)
+			}
+			counts[key] += n
+			total += n
+		}
+	}
+	shares := make(map[string]float64, len(counts))
+	for key, n := range counts {
+		shares[key] = float64(n) / float64(total)
+	}
+	return shares, names
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/events"
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// copilotCooldown is how often a host may ask for hints on demand
+const copilotCooldown = 15 * time.Second
+
+// ErrCopilotCooldown is returned when a host asks for hints again too soon
+var ErrCopilotCooldown = errors.New("co-pilot hints were requested too recently")
+
+// Co-pilot triggers reported in the copilot_hint event
+const (
+	CopilotTriggerScheduled = "scheduled"
+	CopilotTriggerRequested = "requested"
+)
+
+// CopilotService suggests facilitation moves to the host of a live room, from
+// its room memory and friction points, either on a timer or when the host asks
+type CopilotService struct {
+	analyticsSvc   *AnalyticsService
+	analyticsCache cache.AnalyticsCache
+	roomRepo       repository.RoomRepo
+	surveyRepo     repository.SurveyRepo
+	evaluator      *EvaluatorService
+	broadcaster    Broadcaster
+
+	mu        sync.Mutex
+	lastSent  map[string]time.Time // roomCode -> last hints sent
+	lastHints map[string]string    // roomCode -> digest of the last hints, to skip repeats
+}
+
+// NewCopilotService creates a new co-pilot service
+func NewCopilotService(
+	analyticsSvc *AnalyticsService,
+	analyticsCache cache.AnalyticsCache,
+	roomRepo repository.RoomRepo,
+	surveyRepo repository.SurveyRepo,
+	evaluator *EvaluatorService,
+) *CopilotService {
+	return &CopilotService{
+		analyticsSvc:   analyticsSvc,
+		analyticsCache: analyticsCache,
+		roomRepo:       roomRepo,
+		surveyRepo:     surveyRepo,
+		evaluator:      evaluator,
+		lastSent:       make(map[string]time.Time),
+		lastHints:      make(map[string]string),
+	}
+}
+
+// SetBroadcaster sets the broadcaster for copilot_hint events
+func (s *CopilotService) SetBroadcaster(b Broadcaster) {
+	s.broadcaster = b
+}
+
+// Start sends hints to the host of every live room on each interval until ctx is
+// done; an interval of 0 leaves hints on request only
+func (s *CopilotService) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.tick(ctx)
+			}
+		}
+	}()
+}
+
+func (s *CopilotService) tick(ctx context.Context) {
+	tracked := s.analyticsSvc.TrackedRooms()
+	s.mu.Lock()
+	for code := range s.lastSent {
+		if _, ok := tracked[code]; !ok {
+			delete(s.lastSent, code)
+			delete(s.lastHints, code)
+		}
+	}
+	s.mu.Unlock()
+
+	for code, keys := range tracked {
+		room, err := s.roomRepo.GetByCode(ctx, code)
+		if err != nil || room == nil || room.Status != model.RoomStatusActive {
+			continue
+		}
+		if err := s.send(ctx, room, keys, CopilotTriggerScheduled); err != nil {
+			fmt.Printf("[Copilot] Room %s: %v\n", code, err)
+		}
+	}
+}
+
+// Request sends fresh hints to the host of a live room right away
+func (s *CopilotService) Request(ctx context.Context, hostID, roomCode string) error {
+	room, err := s.roomRepo.GetByCode(ctx, roomCode)
+	if err != nil {
+		return err
+	}
+	if room == nil || room.HostID != hostID {
+		return ErrNotRoomHost
+	}
+
+	s.mu.Lock()
+	if time.Since(s.lastSent[roomCode]) < copilotCooldown {
+		s.mu.Unlock()
+		return ErrCopilotCooldown
+	}
+	s.lastSent[roomCode] = time.Now()
+	s.mu.Unlock()
+
+	return s.send(ctx, room, s.analyticsSvc.TrackedRooms()[roomCode], CopilotTriggerRequested)
+}
+
+// send generates hints for the room and pushes them to its host. Scheduled runs
+// stay quiet until there are answers and when nothing changed since the last hints.
+func (s *CopilotService) send(ctx context.Context, room *model.Room, questionKeys []string, trigger string) error {
+	memory, err := s.analyticsCache.GetRoomMemory(ctx, room.Code)
+	if err != nil {
+		return err
+	}
+	if memory == nil {
+		memory = &model.RoomMemory{RoomCode: room.Code}
+	}
+
+	profiles := make([]*model.QuestionProfile, 0, len(questionKeys))
+	answered := 0
+	for _, key := range questionKeys {
+		profile, err := s.analyticsCache.GetQuestionProfile(ctx, room.Code, key)
+		if err != nil || profile == nil {
+			continue
+		}
+		answered += profile.AnswerCount
+		profiles = append(profiles, profile)
+	}
+	if trigger == CopilotTriggerScheduled && answered == 0 {
+		return nil
+	}
+
+	prompts := make(map[string]string, len(questionKeys))
+	if survey, err := s.surveyRepo.GetByID(ctx, room.SurveyID); err == nil && survey != nil {
+		for _, q := range survey.Questions {
+			prompts[q.Key] = q.Prompt
+		}
+	}
+
+	hints, err := s.evaluator.GenerateCopilotHints(WithAIRoom(ctx, room.Code), memory, profiles, prompts)
+	if err != nil {
+		return err
+	}
+
+	texts := make([]string, len(hints))
+	for i, h := range hints {
+		texts[i] = h.Text
+	}
+	digest := strings.Join(texts, "\n")
+	s.mu.Lock()
+	if trigger == CopilotTriggerScheduled && s.lastHints[room.Code] == digest {
+		s.mu.Unlock()
+		return nil
+	}
+	s.lastHints[room.Code] = digest
+	s.lastSent[room.Code] = time.Now()
+	s.mu.Unlock()
+
+	if s.broadcaster != nil {
+		s.broadcaster.ToHost(room.Code, events.CopilotHint, events.CopilotHintPayload{
+			Hints:   hints,
+			Trigger: trigger,
+			At:      time.Now(),
+		})
+	}
+	return nil
+}
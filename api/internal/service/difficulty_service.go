@@ -0,0 +1,267 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Difficulty calibration tuning
+const (
+	minDifficultyRooms   = 3    // ended rooms before any suggestion is made
+	minDifficultyAnswers = 20   // answers + skips per question across those rooms
+	hardUnsatRate        = 0.6  // most answers fall short of the threshold
+	easyUnsatRate        = 0.05 // nearly everyone passes first time
+	highSkipRate         = 0.3  // many players give up on the question
+	difficultyStep       = 0.1  // threshold change per calibration
+	minCalibratedThresh  = 0.3
+	maxCalibratedThresh  = 0.9
+	difficultyPointsGain = 1.25 // points multiplier for hard or often-skipped questions
+)
+
+// DifficultyService scores how hard each survey question proved across the
+// survey's ended rooms and suggests threshold and points adjustments. It runs
+// after every non-practice room ends; hosts who set settings.autoCalibrate have
+// the suggestions written to the survey, so rooms created afterwards use them.
+// Once suggestions are applied only rooms created after that count, so a change
+// is judged on fresh data instead of being repeated.
+type DifficultyService struct {
+	difficultyRepo repository.DifficultyRepo
+	surveyRepo     repository.SurveyRepo
+	roomRepo       repository.RoomRepo
+	reportRepo     repository.ReportRepo
+}
+
+// NewDifficultyService creates a new difficulty service
+func NewDifficultyService(difficultyRepo repository.DifficultyRepo, surveyRepo repository.SurveyRepo, roomRepo repository.RoomRepo, reportRepo repository.ReportRepo) *DifficultyService {
+	return &DifficultyService{
+		difficultyRepo: difficultyRepo,
+		surveyRepo:     surveyRepo,
+		roomRepo:       roomRepo,
+		reportRepo:     reportRepo,
+	}
+}
+
+// RecalibrateAfterRoom recomputes the survey's difficulty once a room has ended and
+// applies the suggestions when the host opted in. Run it in its own goroutine.
+func (s *DifficultyService) RecalibrateAfterRoom(surveyID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	survey, err := s.surveyRepo.GetByID(ctx, surveyID)
+	if err != nil || survey == nil || survey.DeletedAt != nil {
+		return
+	}
+	if _, err := s.calibrate(ctx, survey, survey.Settings.AutoCalibrate); err != nil {
+		fmt.Printf("[Difficulty] Calibration of survey %s failed: %v\n", surveyID, err)
+	}
+}
+
+// Get returns the host's survey calibration, computing it when none is stored yet;
+// nil when the survey is not the host's
+func (s *DifficultyService) Get(ctx context.Context, hostID, surveyID string) (*model.DifficultyReport, error) {
+	survey, err := s.hostSurvey(ctx, hostID, surveyID)
+	if err != nil || survey == nil {
+		return nil, err
+	}
+	report, err := s.difficultyRepo.Get(ctx, surveyID)
+	if err != nil || report != nil {
+		return report, err
+	}
+	return s.calibrate(ctx, survey, false)
+}
+
+// Apply recomputes the calibration and writes its suggestions to the survey (host approval);
+// nil when the survey is not the host's
+func (s *DifficultyService) Apply(ctx context.Context, hostID, surveyID string) (*model.DifficultyReport, error) {
+	survey, err := s.hostSurvey(ctx, hostID, surveyID)
+	if err != nil || survey == nil {
+		return nil, err
+	}
+	return s.calibrate(ctx, survey, true)
+}
+
+// calibrate recomputes and stores the survey's calibration, applying its suggestions if asked
+func (s *DifficultyService) calibrate(ctx context.Context, survey *model.Survey, apply bool) (*model.DifficultyReport, error) {
+	prev, err := s.difficultyRepo.Get(ctx, survey.ID)
+	if err != nil {
+		return nil, err
+	}
+	var since time.Time
+	if prev != nil && prev.AppliedAt != nil {
+		since = *prev.AppliedAt
+	}
+
+	report, err := s.compute(ctx, survey, since)
+	if err != nil {
+		return nil, err
+	}
+	if prev != nil {
+		report.AppliedAt, report.Applied = prev.AppliedAt, prev.Applied
+	}
+	if apply {
+		if err := s.apply(ctx, survey, report); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.difficultyRepo.Upsert(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *DifficultyService) hostSurvey(ctx context.Context, hostID, surveyID string) (*model.Survey, error) {
+	survey, err := s.surveyRepo.GetByID(ctx, surveyID)
+	if err != nil || survey == nil {
+		return nil, err
+	}
+	if survey.HostID != hostID || survey.DeletedAt != nil {
+		return nil, nil
+	}
+	return survey, nil
+}
+
+// compute aggregates the question profiles of the survey's ended, non-practice rooms
+// created after since
+func (s *DifficultyService) compute(ctx context.Context, survey *model.Survey, since time.Time) (*model.DifficultyReport, error) {
+	rooms, err := s.roomRepo.GetBySurveyID(ctx, survey.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	type tally struct{ rooms, sat, unsat, skips int }
+	tallies := make(map[string]*tally, len(survey.Questions))
+	for _, q := range survey.Questions {
+		tallies[q.Key] = &tally{}
+	}
+
+	analysed := 0
+	for _, room := range rooms {
+		if room.Status != model.RoomStatusEnded || room.Settings.Practice || room.CreatedAt.Before(since) {
+			continue
+		}
+		snapshot, err := s.reportRepo.GetSnapshot(ctx, room.Code)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot == nil {
+			continue
+		}
+		analysed++
+		for _, p := range snapshot.QuestionProfiles {
+			t := tallies[p.QuestionKey]
+			if t == nil {
+				continue // AI follow-up or a question removed since
+			}
+			if p.SatCount+p.UnsatCount+p.SkipCount > 0 {
+				t.rooms++
+			}
+			t.sat += p.SatCount
+			t.unsat += p.UnsatCount
+			t.skips += p.SkipCount
+		}
+	}
+
+	report := &model.DifficultyReport{
+		SurveyID:   survey.ID,
+		HostID:     survey.HostID,
+		Rooms:      analysed,
+		Questions:  make([]model.QuestionDifficulty, 0, len(survey.Questions)),
+		ComputedAt: time.Now(),
+	}
+	for _, q := range survey.Questions {
+		t := tallies[q.Key]
+		d := model.QuestionDifficulty{
+			QuestionKey: q.Key,
+			Type:        q.Type,
+			Rooms:       t.rooms,
+			Answers:     t.sat + t.unsat,
+			Skips:       t.skips,
+			Threshold:   q.Threshold,
+			PointsMax:   q.PointsMax,
+		}
+		if d.Answers > 0 {
+			d.UnsatRate = float64(t.unsat) / float64(d.Answers)
+		}
+		if d.Answers+d.Skips > 0 {
+			d.SkipRate = float64(d.Skips) / float64(d.Answers+d.Skips)
+		}
+		d.Difficulty = math.Round((0.6*d.UnsatRate+0.4*d.SkipRate)*1000) / 1000
+		if analysed >= minDifficultyRooms && d.Answers+d.Skips >= minDifficultyAnswers {
+			suggestDifficultyChange(&d)
+		}
+		report.Questions = append(report.Questions, d)
+	}
+	return report, nil
+}
+
+// suggestDifficultyChange fills in threshold and points suggestions for one question
+func suggestDifficultyChange(d *model.QuestionDifficulty) {
+	morePoints := func() {
+		if d.PointsMax > 0 {
+			points := int(math.Round(float64(d.PointsMax) * difficultyPointsGain))
+			d.SuggestedPointsMax = &points
+		}
+	}
+
+	switch {
+	case d.Type == model.QuestionTypeEssay && d.UnsatRate >= hardUnsatRate:
+		if d.Threshold > minCalibratedThresh {
+			threshold := roundThreshold(math.Max(minCalibratedThresh, d.Threshold-difficultyStep))
+			d.SuggestedThreshold = &threshold
+		}
+		morePoints()
+		d.Reason = fmt.Sprintf("%.0f%% of answers fell short of the threshold", d.UnsatRate*100)
+	case d.SkipRate >= highSkipRate:
+		morePoints()
+		d.Reason = fmt.Sprintf("%.0f%% of players skipped the question", d.SkipRate*100)
+	case d.Type == model.QuestionTypeEssay && d.UnsatRate <= easyUnsatRate && d.Threshold < maxCalibratedThresh:
+		threshold := roundThreshold(math.Min(maxCalibratedThresh, d.Threshold+difficultyStep/2))
+		d.SuggestedThreshold = &threshold
+		d.Reason = fmt.Sprintf("only %.0f%% of answers fell short of the threshold", d.UnsatRate*100)
+	}
+}
+
+func roundThreshold(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// apply writes the report's suggestions to the survey and records what changed
+func (s *DifficultyService) apply(ctx context.Context, survey *model.Survey, report *model.DifficultyReport) error {
+	byKey := make(map[string]model.QuestionDifficulty, len(report.Questions))
+	for _, d := range report.Questions {
+		if d.HasSuggestion() {
+			byKey[d.QuestionKey] = d
+		}
+	}
+	if len(byKey) == 0 {
+		return nil
+	}
+
+	var applied []string
+	for i := range survey.Questions {
+		d, ok := byKey[survey.Questions[i].Key]
+		if !ok {
+			continue
+		}
+		if d.SuggestedThreshold != nil {
+			survey.Questions[i].Threshold = *d.SuggestedThreshold
+		}
+		if d.SuggestedPointsMax != nil {
+			survey.Questions[i].PointsMax = *d.SuggestedPointsMax
+		}
+		applied = append(applied, d.QuestionKey)
+	}
+	if err := s.surveyRepo.Update(ctx, survey); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	report.AppliedAt = &now
+	report.Applied = applied
+	fmt.Printf("[Difficulty] Applied calibration to %d question(s) of survey %s\n", len(applied), survey.ID)
+	return nil
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// digestTimeout bounds building and delivering one host's digest
+const digestTimeout = 2 * time.Minute
+
+// DigestService sends opted-in hosts a weekly digest of the rooms they ran:
+// participation, top themes and notable changes against the week before, with
+// highlights written by the report model. Weeks run Monday to Monday UTC.
+type DigestService struct {
+	digestRepo   repository.DigestRepo
+	roomRepo     repository.RoomRepo
+	surveyRepo   repository.SurveyRepo
+	reportSvc    *ReportService
+	evaluator    *EvaluatorService
+	email        *EmailService
+	integrations *IntegrationService
+}
+
+// NewDigestService creates a new digest service
+func NewDigestService(digestRepo repository.DigestRepo, roomRepo repository.RoomRepo, surveyRepo repository.SurveyRepo, reportSvc *ReportService, evaluator *EvaluatorService, email *EmailService, integrations *IntegrationService) *DigestService {
+	return &DigestService{
+		digestRepo:   digestRepo,
+		roomRepo:     roomRepo,
+		surveyRepo:   surveyRepo,
+		reportSvc:    reportSvc,
+		evaluator:    evaluator,
+		email:        email,
+		integrations: integrations,
+	}
+}
+
+// GetSettings returns the host's digest settings; hosts who never set them are opted out
+func (s *DigestService) GetSettings(ctx context.Context, hostID string) (*model.DigestSettings, error) {
+	settings, err := s.digestRepo.Get(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = &model.DigestSettings{HostID: hostID, Recipients: []string{}}
+	}
+	return settings, nil
+}
+
+// UpdateSettings opts the host in or out and sets the email recipients. Without
+// recipients the digest only goes to integrations subscribed to weekly_digest.
+func (s *DigestService) UpdateSettings(ctx context.Context, hostID string, req *model.DigestSettingsRequest) (*model.DigestSettings, error) {
+	recipients := []string{}
+	if len(req.Recipients) > 0 {
+		var err error
+		if recipients, err = normalizeRecipients(req.Recipients); err != nil {
+			return nil, err
+		}
+	}
+
+	settings, err := s.GetSettings(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	settings.Enabled = req.Enabled
+	settings.Recipients = recipients
+	if err := s.digestRepo.Save(ctx, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Preview builds the host's digest of the last complete week without sending it
+func (s *DigestService) Preview(ctx context.Context, hostID string) (*model.Digest, error) {
+	return s.Build(ctx, hostID, lastDigestWeek(time.Now()))
+}
+
+// lastDigestWeek returns the start (Monday 00:00 UTC) of the last complete week before now
+func lastDigestWeek(now time.Time) time.Time {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	sinceMonday := (int(today.Weekday()) + 6) % 7
+	return today.AddDate(0, 0, -sinceMonday-7)
+}
+
+// digestEntry is one ended room of a week with its snapshot (nil if it is gone)
+type digestEntry struct {
+	room     *model.Room
+	snapshot *model.RoomSnapshot
+}
+
+// Build summarizes the host's rooms that ended in the week starting at weekStart
+func (s *DigestService) Build(ctx context.Context, hostID string, weekStart time.Time) (*model.Digest, error) {
+	weekEnd := weekStart.AddDate(0, 0, 7)
+	rooms, err := s.roomRepo.GetByHostID(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	var week, previous []digestEntry
+	for _, room := range rooms {
+		if room.EndedAt == nil || room.Settings.Practice {
+			continue
+		}
+		switch {
+		case !room.EndedAt.Before(weekStart) && room.EndedAt.Before(weekEnd):
+			week = append(week, digestEntry{room: room})
+		case !room.EndedAt.Before(weekStart.AddDate(0, 0, -7)) && room.EndedAt.Before(weekStart):
+			previous = append(previous, digestEntry{room: room})
+		}
+	}
+	for _, entries := range [][]digestEntry{week, previous} {
+		for i := range entries {
+			snapshot, err := s.reportSvc.reportRepo.GetSnapshot(ctx, entries[i].room.Code)
+			if err != nil {
+				return nil, err
+			}
+			entries[i].snapshot = snapshot
+		}
+	}
+	sort.Slice(week, func(i, j int) bool { return week[i].room.EndedAt.Before(*week[j].room.EndedAt) })
+
+	digest := &model.Digest{
+		HostID:     hostID,
+		WeekStart:  weekStart,
+		WeekEnd:    weekEnd,
+		Rooms:      []model.DigestRoom{},
+		Changes:    []string{},
+		Highlights: []string{},
+	}
+	titles := make(map[string]string)
+	for _, e := range week {
+		dr := model.DigestRoom{RoomCode: e.room.Code, SurveyID: e.room.SurveyID, EndedAt: *e.room.EndedAt}
+		if title, ok := titles[e.room.SurveyID]; ok {
+			dr.SurveyTitle = title
+		} else if survey, err := s.surveyRepo.GetByID(ctx, e.room.SurveyID); err == nil && survey != nil {
+			titles[e.room.SurveyID] = survey.Title
+			dr.SurveyTitle = survey.Title
+		}
+		if e.snapshot != nil {
+			dr.Players = e.snapshot.TotalPlayers
+			dr.CompletionRate = e.snapshot.CompletionRate
+			dr.SkipRate = e.snapshot.OverallSkipRate
+			for _, t := range e.snapshot.Memory.GlobalThemesTop {
+				if len(dr.TopThemes) == 3 {
+					break
+				}
+				dr.TopThemes = append(dr.TopThemes, t.Theme)
+			}
+		}
+		digest.Rooms = append(digest.Rooms, dr)
+	}
+
+	totals, before := weekTotals(week), weekTotals(previous)
+	digest.Players = totals.players
+	digest.CompletionRate = totals.completionRate()
+	digest.TopThemes = totals.topThemes()
+	digest.Changes = digestChanges(len(week), len(previous), totals, before)
+
+	if len(digest.Rooms) > 0 {
+		digest.Highlights = s.evaluator.GenerateDigestHighlights(ctx, digest)
+	}
+	return digest, nil
+}
+
+// digestTotals adds up a week's rooms
+type digestTotals struct {
+	players   int
+	completed float64 // Players weighted by their room's completion rate
+	themes    map[string]int
+	names     map[string]string // Lowercased theme -> first spelling seen
+}
+
+func weekTotals(entries []digestEntry) *digestTotals {
+	t := &digestTotals{themes: make(map[string]int), names: make(map[string]string)}
+	for _, e := range entries {
+		if e.snapshot == nil {
+			continue
+		}
+		t.players += e.snapshot.TotalPlayers
+		t.completed += e.snapshot.CompletionRate * float64(e.snapshot.TotalPlayers)
+		for _, tc := range e.snapshot.Memory.GlobalThemesTop {
+			key := strings.ToLower(strings.TrimSpace(tc.Theme))
+			if key == "" {
+				continue
+			}
+			if _, ok := t.names[key]; !ok {
+				t.names[key] = strings.TrimSpace(tc.Theme)
+			}
+			t.themes[key] += tc.Count
+		}
+	}
+	return t
+}
+
+func (t *digestTotals) completionRate() float64 {
+	if t.players == 0 {
+		return 0
+	}
+	return t.completed / float64(t.players)
+}
+
+// topThemes returns the week's most mentioned themes, most first
+func (t *digestTotals) topThemes() []model.ThemeCount {
+	themes := make([]model.ThemeCount, 0, len(t.themes))
+	for key, n := range t.themes {
+		themes = append(themes, model.ThemeCount{Theme: t.names[key], Count: n})
+	}
+	sort.Slice(themes, func(i, j int) bool {
+		if themes[i].Count != themes[j].Count {
+			return themes[i].Count > themes[j].Count
+		}
+		return themes[i].Theme < themes[j].Theme
+	})
+	if len(themes) > model.DigestThemes {
+		themes = themes[:model.DigestThemes]
+	}
+	return themes
+}
+
+// digestChanges lists the notable changes of a week against the week before
+func digestChanges(rooms, previousRooms int, week, previous *digestTotals) []string {
+	if previousRooms == 0 {
+		return []string{"No rooms ended the week before, so there is nothing to compare with"}
+	}
+	changes := []string{}
+	if rooms != previousRooms {
+		changes = append(changes, fmt.Sprintf("%d rooms, %s from %d", rooms, upOrDown(rooms > previousRooms), previousRooms))
+	}
+	if week.players != previous.players {
+		changes = append(changes, fmt.Sprintf("%d players, %s from %d", week.players, upOrDown(week.players > previous.players), previous.players))
+	}
+	if delta := week.completionRate() - previous.completionRate(); math.Abs(delta) >= 0.05 {
+		verb := "rose"
+		if delta < 0 {
+			verb = "fell"
+		}
+		changes = append(changes, fmt.Sprintf("Completion %s from %.0f%% to %.0f%%", verb, previous.completionRate()*100, week.completionRate()*100))
+	}
+
+	before := make(map[string]bool)
+	for _, t := range previous.topThemes() {
+		before[strings.ToLower(t.Theme)] = true
+	}
+	var rising []string
+	for _, t := range week.topThemes() {
+		if !before[strings.ToLower(t.Theme)] {
+			rising = append(rising, t.Theme)
+		}
+	}
+	if len(rising) > 0 {
+		changes = append(changes, "New among the top themes: "+strings.Join(rising, ", "))
+	}
+	if len(changes) > model.MaxDigestChanges {
+		changes = changes[:model.MaxDigestChanges]
+	}
+	return changes
+}
+
+func upOrDown(up bool) string {
+	if up {
+		return "up"
+	}
+	return "down"
+}
+
+// SendAll sends the digest of the last complete week to every opted-in host not
+// sent it yet; hosts without rooms that week get nothing. Returns the digests sent.
+func (s *DigestService) SendAll(ctx context.Context) (int, error) {
+	hosts, err := s.digestRepo.ListEnabled(ctx)
+	if err != nil {
+		return 0, err
+	}
+	weekStart := lastDigestWeek(time.Now())
+
+	sent := 0
+	for _, settings := range hosts {
+		claimed, err := s.digestRepo.ClaimWeek(ctx, settings.HostID, weekStart)
+		if err != nil {
+			fmt.Printf("[Digest] Claiming week of %s for host %s failed: %v\n", weekStart.Format("2006-01-02"), settings.HostID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		if s.send(ctx, settings, weekStart) {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// send builds and delivers one host's digest; false if there was nothing to send
+func (s *DigestService) send(ctx context.Context, settings *model.DigestSettings, weekStart time.Time) bool {
+	ctx, cancel := context.WithTimeout(ctx, digestTimeout)
+	defer cancel()
+
+	digest, err := s.Build(ctx, settings.HostID, weekStart)
+	if err != nil {
+		fmt.Printf("[Digest] Building digest for host %s failed: %v\n", settings.HostID, err)
+		return false
+	}
+	if len(digest.Rooms) == 0 {
+		return false
+	}
+
+	var failures []string
+	if len(settings.Recipients) > 0 {
+		statuses, err := s.email.SendDigest(ctx, settings.Recipients, digest)
+		if err != nil {
+			failures = append(failures, err.Error())
+		}
+		for _, st := range statuses {
+			if st.Status == model.EmailStatusFailed {
+				failures = append(failures, st.Email+": "+st.Error)
+			}
+		}
+	}
+	if err := s.integrations.PostDigest(ctx, digest); err != nil {
+		failures = append(failures, err.Error())
+	}
+	if err := s.digestRepo.RecordSend(ctx, settings.HostID, time.Now(), strings.Join(failures, "; ")); err != nil {
+		fmt.Printf("[Digest] Recording send for host %s failed: %v\n", settings.HostID, err)
+	}
+	return true
+}
+
+// StartDaily runs SendAll once a day at hourUTC until ctx is done. Hosts already
+// sent last week's digest are skipped, so a run missed on Monday catches up later.
+func (s *DigestService) StartDaily(ctx context.Context, hourUTC int) {
+	go func() {
+		for {
+			wait := time.Until(nextRunAt(time.Now().UTC(), hourUTC))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+				n, err := s.SendAll(ctx)
+				if err != nil {
+					fmt.Printf("[Digest] Daily run failed: %v\n", err)
+					continue
+				}
+				if n > 0 {
+					fmt.Printf("[Digest] Sent %d weekly digests\n", n)
+				}
+			}
+		}
+	}()
+}
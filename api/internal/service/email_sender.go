@@ -0,0 +1,200 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strings"
+	"time"
+)
+
+// EmailMessage is a single rendered email to one recipient
+type EmailMessage struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// EmailSender delivers rendered emails through a provider
+type EmailSender interface {
+	Name() string
+	Send(ctx context.Context, msg *EmailMessage) error
+}
+
+// NewEmailSenderFromEnv picks the provider from EMAIL_PROVIDER (smtp, sendgrid).
+// Without one, emails are only logged.
+func NewEmailSenderFromEnv() EmailSender {
+	from := os.Getenv("EMAIL_FROM")
+	if from == "" {
+		from = "reports@champanzee.local"
+	}
+
+	switch os.Getenv("EMAIL_PROVIDER") {
+	case "smtp":
+		port := os.Getenv("SMTP_PORT")
+		if port == "" {
+			port = "587"
+		}
+		return &SMTPSender{
+			addr:     os.Getenv("SMTP_HOST") + ":" + port,
+			host:     os.Getenv("SMTP_HOST"),
+			username: os.Getenv("SMTP_USERNAME"),
+			password: os.Getenv("SMTP_PASSWORD"),
+			from:     from,
+		}
+	case "sendgrid":
+		baseURL := os.Getenv("SENDGRID_BASE_URL")
+		if baseURL == "" {
+			baseURL = "https://api.sendgrid.com"
+		}
+		return &SendGridSender{
+			baseURL:    strings.TrimRight(baseURL, "/"),
+			apiKey:     os.Getenv("SENDGRID_API_KEY"),
+			from:       from,
+			httpClient: &http.Client{Timeout: 30 * time.Second},
+		}
+	default:
+		log.Println("Warning: EMAIL_PROVIDER not set, report emails will only be logged")
+		return logSender{}
+	}
+}
+
+// SMTPSender sends email through an SMTP relay
+type SMTPSender struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+}
+
+// Name returns the provider name recorded on deliveries
+func (s *SMTPSender) Name() string { return "smtp" }
+
+// Send delivers msg as a multipart/alternative email
+func (s *SMTPSender) Send(ctx context.Context, msg *EmailMessage) error {
+	body, err := buildMIMEMessage(s.from, msg)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+
+	// net/smtp has no context support; bound the send by the caller's deadline instead
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(s.addr, auth, s.from, []string{msg.To}, body)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func buildMIMEMessage(from string, msg *EmailMessage) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", writer.Boundary())
+
+	parts := []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	}
+	for _, p := range parts {
+		w, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {p.contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(p.body)); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SendGridSender sends email through the SendGrid v3 API
+type SendGridSender struct {
+	baseURL    string
+	apiKey     string
+	from       string
+	httpClient HTTPDoer
+}
+
+// Name returns the provider name recorded on deliveries
+func (s *SendGridSender) Name() string { return "sendgrid" }
+
+// SetHTTPClient replaces the HTTP client used for SendGrid calls
+func (s *SendGridSender) SetHTTPClient(h HTTPDoer) {
+	s.httpClient = h
+}
+
+// Send delivers msg via POST /v3/mail/send
+func (s *SendGridSender) Send(ctx context.Context, msg *EmailMessage) error {
+	payload := map[string]interface{}{
+		"personalizations": []interface{}{
+			map[string]interface{}{"to": []map[string]string{{"email": msg.To}}},
+		},
+		"from":    map[string]string{"email": s.from},
+		"subject": msg.Subject,
+		"content": []map[string]string{
+			{"type": "text/plain", "value": msg.Text},
+			{"type": "text/html", "value": msg.HTML},
+		},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/v3/mail/send", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("sendgrid returned %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+// logSender stands in for a provider in development
+type logSender struct{}
+
+func (logSender) Name() string { return "log" }
+
+func (logSender) Send(ctx context.Context, msg *EmailMessage) error {
+	log.Printf("[Email] To %s: %s (%d bytes HTML)", msg.To, msg.Subject, len(msg.HTML))
+	return nil
+}
@@ -0,0 +1,320 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/mail"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+const (
+	maxEmailRecipients = 50
+	emailSendTimeout   = 20 * time.Second
+)
+
+var (
+	// ErrReportNotReady is returned when emailing a room whose AI report has not finished
+	ErrReportNotReady = errors.New("AI report is not ready yet")
+	// ErrInvalidRecipients is returned for empty, oversized or malformed recipient lists
+	ErrInvalidRecipients = errors.New("invalid recipients")
+)
+
+// EmailService renders AI reports into HTML emails and records their delivery
+type EmailService struct {
+	emailRepo  repository.EmailRepo
+	roomRepo   repository.RoomRepo
+	reportRepo repository.ReportRepo
+	reportSvc  *ReportService
+	sender     EmailSender
+}
+
+// NewEmailService creates a new email service
+func NewEmailService(emailRepo repository.EmailRepo, roomRepo repository.RoomRepo, reportRepo repository.ReportRepo, reportSvc *ReportService, sender EmailSender) *EmailService {
+	return &EmailService{
+		emailRepo:  emailRepo,
+		roomRepo:   roomRepo,
+		reportRepo: reportRepo,
+		reportSvc:  reportSvc,
+		sender:     sender,
+	}
+}
+
+// SendReport emails the room's AI report to each recipient separately
+func (s *EmailService) SendReport(ctx context.Context, roomCode, hostID string, req *model.EmailReportRequest) (*model.EmailDelivery, error) {
+	recipients, err := normalizeRecipients(req.Recipients)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.roomRepo.GetByCode(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, nil
+	}
+	if room.HostID != hostID {
+		return nil, ErrNotRoomHost
+	}
+
+	report, err := s.reportRepo.GetAIReport(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil || report.Status != "ready" {
+		return nil, ErrReportNotReady
+	}
+	snapshot, err := s.reportRepo.GetSnapshot(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Single line only, so the subject cannot inject headers
+	subject := strings.Join(strings.Fields(req.Subject), " ")
+	if subject == "" {
+		subject = "Results for room " + roomCode
+	}
+
+	view := &reportEmailView{
+		RoomCode: roomCode,
+		Message:  strings.TrimSpace(req.Message),
+		Report:   report,
+		Snapshot: snapshot,
+	}
+	if link, err := s.reportSvc.CreateShareLink(ctx, roomCode, hostID, model.ShareRequest{}); err == nil && link != nil {
+		view.ReportURL = link.URL
+	}
+
+	var html, text bytes.Buffer
+	if err := reportEmailHTML.Execute(&html, view); err != nil {
+		return nil, fmt.Errorf("failed to render report email: %w", err)
+	}
+	if err := reportEmailText.Execute(&text, view); err != nil {
+		return nil, fmt.Errorf("failed to render report email: %w", err)
+	}
+
+	delivery := &model.EmailDelivery{
+		RoomCode:   roomCode,
+		HostID:     hostID,
+		Provider:   s.sender.Name(),
+		Subject:    subject,
+		Recipients: make([]model.EmailRecipient, 0, len(recipients)),
+	}
+	for _, to := range recipients {
+		status := model.EmailRecipient{Email: to, Status: model.EmailStatusSent}
+
+		sendCtx, cancel := context.WithTimeout(ctx, emailSendTimeout)
+		err := s.sender.Send(sendCtx, &EmailMessage{To: to, Subject: subject, HTML: html.String(), Text: text.String()})
+		cancel()
+
+		if err != nil {
+			status.Status = model.EmailStatusFailed
+			status.Error = err.Error()
+		} else {
+			now := time.Now()
+			status.SentAt = &now
+		}
+		delivery.Recipients = append(delivery.Recipients, status)
+	}
+
+	id, err := s.emailRepo.Create(ctx, delivery)
+	if err != nil {
+		return nil, err
+	}
+	delivery.ID = id
+	return delivery, nil
+}
+
+// SendDigest emails a weekly digest to each recipient separately and returns
+// the status per recipient
+func (s *EmailService) SendDigest(ctx context.Context, recipients []string, digest *model.Digest) ([]model.EmailRecipient, error) {
+	view := &digestEmailView{Digest: digest, DashboardURL: s.reportSvc.publicURL + "/host/reports"}
+	var html, text bytes.Buffer
+	if err := digestEmailHTML.Execute(&html, view); err != nil {
+		return nil, fmt.Errorf("failed to render digest email: %w", err)
+	}
+	if err := digestEmailText.Execute(&text, view); err != nil {
+		return nil, fmt.Errorf("failed to render digest email: %w", err)
+	}
+	subject := fmt.Sprintf("Your week in rooms: %s - %s", digest.WeekStart.Format("Jan 2"), digest.WeekEnd.Add(-time.Second).Format("Jan 2"))
+
+	statuses := make([]model.EmailRecipient, 0, len(recipients))
+	for _, to := range recipients {
+		status := model.EmailRecipient{Email: to, Status: model.EmailStatusSent}
+
+		sendCtx, cancel := context.WithTimeout(ctx, emailSendTimeout)
+		err := s.sender.Send(sendCtx, &EmailMessage{To: to, Subject: subject, HTML: html.String(), Text: text.String()})
+		cancel()
+
+		if err != nil {
+			status.Status = model.EmailStatusFailed
+			status.Error = err.Error()
+		} else {
+			now := time.Now()
+			status.SentAt = &now
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// ListDeliveries returns the email history of a room, newest first
+func (s *EmailService) ListDeliveries(ctx context.Context, roomCode, hostID string) ([]*model.EmailDelivery, error) {
+	room, err := s.roomRepo.GetByCode(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, nil
+	}
+	if room.HostID != hostID {
+		return nil, ErrNotRoomHost
+	}
+	return s.emailRepo.ListByRoom(ctx, roomCode)
+}
+
+// normalizeRecipients validates addresses and drops duplicates
+func normalizeRecipients(raw []string) ([]string, error) {
+	seen := make(map[string]bool)
+	recipients := []string{}
+	for _, r := range raw {
+		addr, err := mail.ParseAddress(strings.TrimSpace(r))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRecipients, r)
+		}
+		email := strings.ToLower(addr.Address)
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+		recipients = append(recipients, email)
+	}
+
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("%w: at least one recipient is required", ErrInvalidRecipients)
+	}
+	if len(recipients) > maxEmailRecipients {
+		return nil, fmt.Errorf("%w: at most %d recipients", ErrInvalidRecipients, maxEmailRecipients)
+	}
+	return recipients, nil
+}
+
+// reportEmailView is the data behind the report email templates
+type reportEmailView struct {
+	RoomCode  string
+	Message   string
+	ReportURL string
+	Report    *model.AIReport
+	Snapshot  *model.RoomSnapshot
+}
+
+var reportEmailFuncs = template.FuncMap{
+	"percent": func(f float64) string { return fmt.Sprintf("%.0f%%", f*100) },
+}
+
+var reportEmailHTML = template.Must(template.New("report_email_html").Funcs(reportEmailFuncs).Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #1f2937; max-width: 640px; margin: 0 auto;">
+  <h1 style="color: #7c3aed;">Results for room {{.RoomCode}}</h1>
+  {{if .Message}}<p style="white-space: pre-line;">{{.Message}}</p>{{end}}
+  {{with .Snapshot}}<p><strong>{{.TotalPlayers}}</strong> players &middot; <strong>{{percent .CompletionRate}}</strong> completion &middot; <strong>{{percent .OverallSkipRate}}</strong> skipped</p>{{end}}
+  {{with .Report.ExecutiveSummary}}
+  <h2>Executive summary</h2>
+  <ul>{{range .}}<li>{{.}}</li>{{end}}</ul>
+  {{end}}
+  {{with .Report.KeyThemes}}
+  <h2>Key themes</h2>
+  {{range .}}<p><strong>{{.Name}}</strong> ({{printf "%.0f" .Percentage}}%)<br>{{.Meaning}}</p>{{end}}
+  {{end}}
+  {{with .Report.Contrasts}}
+  <h2>Where people disagree</h2>
+  <ul>{{range .}}<li><strong>{{.Axis}}</strong>: {{.SideA}} vs. {{.SideB}}</li>{{end}}</ul>
+  {{end}}
+  {{with .Report.FrictionAnalysis}}
+  <h2>Friction points</h2>
+  <ul>{{range .}}<li><strong>{{.QuestionKey}}</strong>: {{.IssueDescription}} &mdash; {{.HypothesizedReason}}</li>{{end}}</ul>
+  {{end}}
+  {{with .Report.RecommendedQuestions}}
+  <h2>Recommended next questions</h2>
+  <ol>{{range .}}<li>{{.}}</li>{{end}}</ol>
+  {{end}}
+  {{if .ReportURL}}<p><a href="{{.ReportURL}}" style="color: #7c3aed;">Open the full report</a></p>{{end}}
+</body>
+</html>
+`))
+
+var reportEmailText = texttemplate.Must(texttemplate.New("report_email_text").Funcs(texttemplate.FuncMap(reportEmailFuncs)).Parse(`Results for room {{.RoomCode}}
+{{if .Message}}
+{{.Message}}
+{{end}}{{with .Snapshot}}
+{{.TotalPlayers}} players, {{percent .CompletionRate}} completion, {{percent .OverallSkipRate}} skipped
+{{end}}{{with .Report.ExecutiveSummary}}
+Executive summary
+{{range .}}- {{.}}
+{{end}}{{end}}{{with .Report.KeyThemes}}
+Key themes
+{{range .}}- {{.Name}} ({{printf "%.0f" .Percentage}}%): {{.Meaning}}
+{{end}}{{end}}{{with .Report.RecommendedQuestions}}
+Recommended next questions
+{{range .}}- {{.}}
+{{end}}{{end}}{{if .ReportURL}}
+Full report: {{.ReportURL}}
+{{end}}`))
+
+// digestEmailView is the data behind the digest email templates
+type digestEmailView struct {
+	Digest       *model.Digest
+	DashboardURL string
+}
+
+var digestEmailHTML = template.Must(template.New("digest_email_html").Funcs(reportEmailFuncs).Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #1f2937; max-width: 640px; margin: 0 auto;">
+  {{with .Digest}}
+  <h1 style="color: #7c3aed;">Your week in rooms</h1>
+  <p><strong>{{len .Rooms}}</strong> rooms &middot; <strong>{{.Players}}</strong> players &middot; <strong>{{percent .CompletionRate}}</strong> completion</p>
+  {{with .Highlights}}
+  <h2>Highlights</h2>
+  <ul>{{range .}}<li>{{.}}</li>{{end}}</ul>
+  {{end}}
+  {{with .Changes}}
+  <h2>Compared with the week before</h2>
+  <ul>{{range .}}<li>{{.}}</li>{{end}}</ul>
+  {{end}}
+  {{with .TopThemes}}
+  <h2>Top themes</h2>
+  <ul>{{range .}}<li>{{.Theme}} ({{.Count}})</li>{{end}}</ul>
+  {{end}}
+  <h2>Rooms</h2>
+  <ul>{{range .Rooms}}<li><strong>{{.RoomCode}}</strong>{{if .SurveyTitle}} &middot; {{.SurveyTitle}}{{end}}: {{.Players}} players, {{percent .CompletionRate}} completion</li>{{end}}</ul>
+  {{end}}
+  <p><a href="{{.DashboardURL}}" style="color: #7c3aed;">Open your reports</a></p>
+</body>
+</html>
+`))
+
+var digestEmailText = texttemplate.Must(texttemplate.New("digest_email_text").Funcs(texttemplate.FuncMap(reportEmailFuncs)).Parse(`{{with .Digest}}Your week in rooms
+
+{{len .Rooms}} rooms, {{.Players}} players, {{percent .CompletionRate}} completion
+{{with .Highlights}}
+Highlights
+{{range .}}- {{.}}
+{{end}}{{end}}{{with .Changes}}
+Compared with the week before
+{{range .}}- {{.}}
+{{end}}{{end}}{{with .TopThemes}}
+Top themes
+{{range .}}- {{.Theme}} ({{.Count}})
+{{end}}{{end}}
+Rooms
+{{range .Rooms}}- {{.RoomCode}}{{if .SurveyTitle}} ({{.SurveyTitle}}){{end}}: {{.Players}} players, {{percent .CompletionRate}} completion
+{{end}}{{end}}
+Your reports: {{.DashboardURL}}
+`))
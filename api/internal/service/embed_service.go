@@ -0,0 +1,212 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultEmbedTTL  = 24 * time.Hour
+	maxEmbedTTL      = 30 * 24 * time.Hour
+	embedThemeCount  = 10
+	embedQuestionTop = 5 // Themes kept per essay question
+)
+
+// ErrInvalidEmbedToken is returned for expired, forged or mismatched embed tokens
+var ErrInvalidEmbedToken = errors.New("embed token is invalid or expired")
+
+// EmbedService serves read-only live results for embeddable widgets
+type EmbedService struct {
+	roomCache      cache.RoomCache
+	playerCache    cache.PlayerCache
+	analyticsCache cache.AnalyticsCache
+	surveyRepo     repository.SurveyRepo
+	authSvc        *AuthService
+	apiURL         string // Base URL of the API, for data and stream links
+	publicURL      string // Base URL of the web app, for the iframe page
+}
+
+// NewEmbedService creates a new embed service
+func NewEmbedService(
+	roomCache cache.RoomCache,
+	playerCache cache.PlayerCache,
+	analyticsCache cache.AnalyticsCache,
+	surveyRepo repository.SurveyRepo,
+	authSvc *AuthService,
+) *EmbedService {
+	return &EmbedService{
+		roomCache:      roomCache,
+		playerCache:    playerCache,
+		analyticsCache: analyticsCache,
+		surveyRepo:     surveyRepo,
+		authSvc:        authSvc,
+		apiURL:         "http://localhost:8080/v1",
+		publicURL:      "http://localhost:3000",
+	}
+}
+
+// SetPublicURL sets the web app base URL used for iframe links
+func (s *EmbedService) SetPublicURL(url string) {
+	s.publicURL = strings.TrimRight(url, "/")
+}
+
+// SetAPIURL sets the API base URL (including /v1) used for data and stream links
+func (s *EmbedService) SetAPIURL(url string) {
+	s.apiURL = strings.TrimRight(url, "/")
+}
+
+// CreateToken issues an embed token for the host's room
+func (s *EmbedService) CreateToken(ctx context.Context, roomCode, hostID string, req model.EmbedTokenRequest) (*model.EmbedToken, error) {
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room meta: %w", err)
+	}
+	if meta == nil {
+		return nil, nil
+	}
+	if meta.HostID != hostID {
+		return nil, ErrNotRoomHost
+	}
+
+	ttl := defaultEmbedTTL
+	if req.ExpiresInHours > 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+	if ttl > maxEmbedTTL {
+		ttl = maxEmbedTTL
+	}
+
+	token, expiresAt, err := s.authSvc.GenerateEmbedToken(roomCode, hostID, ttl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign embed token: %w", err)
+	}
+
+	query := "?token=" + url.QueryEscape(token)
+	code := url.PathEscape(roomCode)
+	return &model.EmbedToken{
+		Token:     token,
+		DataURL:   s.apiURL + "/rooms/" + code + "/embed" + query,
+		StreamURL: s.apiURL + "/rooms/" + code + "/embed/stream" + query,
+		IframeURL: s.publicURL + "/embed/" + code + query,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Authorize checks that token is a live embed token for roomCode
+func (s *EmbedService) Authorize(roomCode, token string) error {
+	claims, err := s.authSvc.ValidateEmbedToken(token)
+	if err != nil || claims.RoomCode != roomCode {
+		return ErrInvalidEmbedToken
+	}
+	return nil
+}
+
+// GetData aggregates the room's live results. Call Authorize first.
+func (s *EmbedService) GetData(ctx context.Context, roomCode string) (*model.EmbedData, error) {
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room meta: %w", err)
+	}
+	if meta == nil {
+		return nil, nil
+	}
+
+	data := &model.EmbedData{
+		RoomCode:  roomCode,
+		Status:    meta.Status,
+		Themes:    []model.ThemeCount{},
+		Questions: []model.EmbedQuestion{},
+		UpdatedAt: time.Now(),
+	}
+
+	players, err := s.playerCache.GetAllPlayers(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	done, err := s.playerCache.GetDonePlayers(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	data.Participation.Finished, data.Participation.Players = countCompletion(players, done)
+	if data.Participation.Players > 0 {
+		data.Participation.CompletionRate = float64(data.Participation.Finished) / float64(data.Participation.Players)
+	}
+
+	if memory, err := s.analyticsCache.GetRoomMemory(ctx, roomCode); err == nil && memory != nil && len(memory.GlobalThemesTop) > 0 {
+		data.Themes = topThemes(memory.GlobalThemesTop, embedThemeCount)
+	}
+
+	survey, err := s.surveyRepo.GetByID(ctx, meta.SurveyID)
+	if err != nil || survey == nil {
+		return data, nil
+	}
+	for _, q := range survey.Questions {
+		eq := model.EmbedQuestion{
+			Key:      q.Key,
+			Prompt:   q.Prompt,
+			Type:     q.Type,
+			ScaleMin: q.ScaleMin,
+			ScaleMax: q.ScaleMax,
+			Options:  q.Options,
+			Rows:     q.Rows,
+			Columns:  q.Columns,
+		}
+		profile, err := s.analyticsCache.GetQuestionProfile(ctx, roomCode, q.Key)
+		if err == nil && profile != nil {
+			eq.AnswerCount = profile.AnswerCount
+			eq.SkipCount = profile.SkipCount
+			data.Participation.Answers += profile.AnswerCount
+			switch q.Type {
+			case model.QuestionTypeDegree:
+				eq.RatingHist = profile.RatingHist
+				if profile.RatingCount > 0 {
+					eq.RatingMean = float64(profile.RatingSum) / float64(profile.RatingCount)
+				}
+			case model.QuestionTypeMCQ:
+				eq.OptionHist = profile.OptionHist
+			case model.QuestionTypeMatrix:
+				eq.MatrixHist = profile.MatrixHist
+			case model.QuestionTypeRanking:
+				eq.AvgPosition = profile.AvgPosition
+			case model.QuestionTypeWords:
+				eq.WordCloud = profile.WordCloud
+			default:
+				eq.Themes = themesFromCounts(profile.ThemeCounts, embedQuestionTop)
+			}
+		}
+		data.Questions = append(data.Questions, eq)
+	}
+
+	return data, nil
+}
+
+// topThemes returns at most n themes
+func topThemes(themes []model.ThemeCount, n int) []model.ThemeCount {
+	if len(themes) > n {
+		return themes[:n]
+	}
+	return themes
+}
+
+// themesFromCounts sorts a theme -> count map into the n most frequent themes
+func themesFromCounts(counts map[string]int, n int) []model.ThemeCount {
+	themes := make([]model.ThemeCount, 0, len(counts))
+	for theme, count := range counts {
+		themes = append(themes, model.ThemeCount{Theme: theme, Count: count})
+	}
+	sort.Slice(themes, func(i, j int) bool {
+		if themes[i].Count != themes[j].Count {
+			return themes[i].Count > themes[j].Count
+		}
+		return themes[i].Theme < themes[j].Theme
+	})
+	return topThemes(themes, n)
+}
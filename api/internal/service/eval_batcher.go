@@ -0,0 +1,115 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// EvalBatcher coalesces L1 evaluations for the same room+question that arrive
+// within a short window, so bursts of submissions share one Gemini call.
+type EvalBatcher struct {
+	evaluator *EvaluatorService
+	window    time.Duration
+	maxSize   int
+
+	mu      sync.Mutex
+	pending map[string]*evalBatch // roomCode:questionKey -> open batch
+}
+
+type evalBatch struct {
+	roomCode string
+	question *model.Question
+	jobs     []*evalJob
+	timer    *time.Timer
+}
+
+type evalJob struct {
+	answer *model.Answer
+	done   chan evalOutcome
+}
+
+type evalOutcome struct {
+	result *model.EvaluationResult
+	err    error
+}
+
+// NewEvalBatcher creates a new evaluation batcher
+func NewEvalBatcher(evaluator *EvaluatorService, window time.Duration, maxSize int) *EvalBatcher {
+	return &EvalBatcher{
+		evaluator: evaluator,
+		window:    window,
+		maxSize:   maxSize,
+		pending:   make(map[string]*evalBatch),
+	}
+}
+
+// Evaluate queues an answer and blocks until its batch has been evaluated
+func (b *EvalBatcher) Evaluate(ctx context.Context, roomCode string, question *model.Question, answer *model.Answer) (*model.EvaluationResult, error) {
+	if b.maxSize <= 1 {
+		return b.evaluator.EvaluateAnswer(ctx, question, answer)
+	}
+
+	job := &evalJob{answer: answer, done: make(chan evalOutcome, 1)}
+	b.enqueue(roomCode, question, job)
+
+	select {
+	case out := <-job.done:
+		return out.result, out.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (b *EvalBatcher) enqueue(roomCode string, question *model.Question, job *evalJob) {
+	key := fmt.Sprintf("%s:%s", roomCode, question.Key)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	batch, ok := b.pending[key]
+	if !ok {
+		batch = &evalBatch{roomCode: roomCode, question: question}
+		batch.timer = time.AfterFunc(b.window, func() { b.flushKey(key, batch) })
+		b.pending[key] = batch
+	}
+	batch.jobs = append(batch.jobs, job)
+
+	if len(batch.jobs) >= b.maxSize {
+		batch.timer.Stop()
+		delete(b.pending, key)
+		go b.flush(batch)
+	}
+}
+
+func (b *EvalBatcher) flushKey(key string, batch *evalBatch) {
+	b.mu.Lock()
+	if b.pending[key] != batch {
+		// Already flushed because it filled up
+		b.mu.Unlock()
+		return
+	}
+	delete(b.pending, key)
+	b.mu.Unlock()
+
+	b.flush(batch)
+}
+
+func (b *EvalBatcher) flush(batch *evalBatch) {
+	answers := make([]*model.Answer, len(batch.jobs))
+	for i, job := range batch.jobs {
+		answers[i] = job.answer
+	}
+
+	ctx := WithAIRoom(context.Background(), batch.roomCode)
+	results, err := b.evaluator.EvaluateAnswerBatch(ctx, batch.question, answers)
+	for i, job := range batch.jobs {
+		if err != nil {
+			job.done <- evalOutcome{err: err}
+			continue
+		}
+		job.done <- evalOutcome{result: results[i]}
+	}
+}
@@ -1,22 +1,37 @@
 package service
 
 import (
+	"2026champs/internal/cache"
 	"2026champs/internal/config"
 	"2026champs/internal/model"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"io"
+	"math"
 	"net/http"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
 // EvaluatorService handles AI evaluation via Gemini API with multiple models
 type EvaluatorService struct {
-	config *config.AIConfig
-	client *http.Client
+	config      *config.AIConfig
+	client      HTTPDoer
+	usage       cache.AIUsageCache
+	roomCache   cache.RoomCache // optional, recognises practice rooms and their hosts
+	plans       *PlanService    // optional, enforces monthly AI calls per host
+	breaker     *circuitBreaker
+	broadcaster Broadcaster
+
+	schemaMetrics *aiSchemaMetrics
+
+	degradedMu sync.Mutex
+	degraded   map[string]string // roomCode -> active degradation reason
 }
 
 // NewEvaluatorService creates a new evaluator service
@@ -27,40 +42,196 @@ func NewEvaluatorService() *EvaluatorService {
 		client: &http.Client{
 			Timeout: time.Duration(cfg.TimeoutMS) * time.Millisecond,
 		},
+		breaker:       newCircuitBreaker(cfg.Budget.BreakerThreshold, time.Duration(cfg.Budget.BreakerCooldownSeconds)*time.Second),
+		degraded:      make(map[string]string),
+		schemaMetrics: newAISchemaMetrics(),
 	}
 }
 
+// SetHTTPClient replaces the HTTP client used for Gemini calls (e.g. to point at a mock server)
+func (s *EvaluatorService) SetHTTPClient(c HTTPDoer) {
+	s.client = c
+}
+
+// SetUsageCache enables per-room and global AI budget tracking
+func (s *EvaluatorService) SetUsageCache(c cache.AIUsageCache) {
+	s.usage = c
+}
+
+// SetRoomCache lets the evaluator recognise practice rooms, which always get mock results
+func (s *EvaluatorService) SetRoomCache(c cache.RoomCache) {
+	s.roomCache = c
+}
+
+// SetPlanService enforces the monthly AI calls of each host's plan
+func (s *EvaluatorService) SetPlanService(p *PlanService) {
+	s.plans = p
+}
+
+// aiEnabled reports whether a call for the room in ctx may use the AI
+func (s *EvaluatorService) aiEnabled(ctx context.Context) bool {
+	return s.config.IsEnabled() && !s.isPracticeRoom(ctx)
+}
+
+// isPracticeRoom reports whether ctx is tagged with a practice room (see WithAIRoom)
+func (s *EvaluatorService) isPracticeRoom(ctx context.Context) bool {
+	roomCode := aiRoomFrom(ctx)
+	if roomCode == "" || s.roomCache == nil {
+		return false
+	}
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	return err == nil && meta != nil && meta.Settings().Practice
+}
+
+// SetBroadcaster sets the broadcaster for ai_degraded events
+func (s *EvaluatorService) SetBroadcaster(b Broadcaster) {
+	s.broadcaster = b
+}
+
 // EvaluateAnswer evaluates an essay answer and extracts signals (L1)
 func (s *EvaluatorService) EvaluateAnswer(ctx context.Context, question *model.Question, answer *model.Answer) (*model.EvaluationResult, error) {
-	if !s.config.IsEnabled() {
-		return s.mockEvaluate(question, answer), nil
+	result := s.evaluateAnswer(ctx, question, answer)
+	question.Taxonomy.Apply(&result.Signals)
+	return result, nil
+}
+
+// evaluateAnswer runs one L1 evaluation, falling back to a mock result
+func (s *EvaluatorService) evaluateAnswer(ctx context.Context, question *model.Question, answer *model.Answer) *model.EvaluationResult {
+	if !s.aiEnabled(ctx) {
+		return s.mockEvaluate(question, answer)
 	}
 
 	prompt := s.buildEvaluationPrompt(question, answer)
-	response, err := s.callGemini(ctx, s.config.Models.L1Eval, prompt)
-	if err != nil {
+	modelName, temperature := s.questionModel(question, s.config.Models.L1Eval)
+	var result model.EvaluationResult
+	if err := s.callGeminiJSON(ctx, aiTemplateEvaluation, modelName, prompt, temperature, &result); err != nil {
 		// Fallback to mock on error
-		return s.mockEvaluate(question, answer), nil
+		return s.mockEvaluate(question, answer)
 	}
 
-	var result model.EvaluationResult
-	if err := json.Unmarshal([]byte(response), &result); err != nil {
-		return s.mockEvaluate(question, answer), nil
+	return &result
+}
+
+// EvaluateAnswerBatch evaluates several answers to the same question in one call (L1).
+// Results are returned in the same order as answers.
+func (s *EvaluatorService) EvaluateAnswerBatch(ctx context.Context, question *model.Question, answers []*model.Answer) ([]*model.EvaluationResult, error) {
+	if len(answers) == 1 {
+		result, err := s.EvaluateAnswer(ctx, question, answers[0])
+		if err != nil {
+			return nil, err
+		}
+		return []*model.EvaluationResult{result}, nil
+	}
+
+	results := make([]*model.EvaluationResult, len(answers))
+	if !s.aiEnabled(ctx) {
+		for i, a := range answers {
+			results[i] = s.mockEvaluate(question, a)
+			question.Taxonomy.Apply(&results[i].Signals)
+		}
+		return results, nil
+	}
+
+	fmt.Printf("[L1 Batch] Evaluating %d answers for %s in one call\n", len(answers), question.Key)
+	prompt := s.buildBatchEvaluationPrompt(question, answers)
+	modelName, temperature := s.questionModel(question, s.config.Models.L1Eval)
+	var batch batchEvaluation
+	if err := s.callGeminiJSON(ctx, aiTemplateBatchEvaluation, modelName, prompt, temperature, &batch); err != nil {
+		fmt.Printf("[L1 Batch] Call Error: %v\n", err)
+	} else {
+		results = batch.byIndex(len(answers))
 	}
 
-	return &result, nil
+	// Fallback to mock for anything the batch call did not cover
+	for i, a := range answers {
+		if results[i] == nil {
+			results[i] = s.mockEvaluate(question, a)
+		}
+		question.Taxonomy.Apply(&results[i].Signals)
+	}
+	return results, nil
+}
+
+// ReEvaluateBatch evaluates answers like EvaluateAnswerBatch but never substitutes
+// mock results for a failed call; answers the AI did not cover are nil. When the AI
+// is not configured every result is a mock.
+func (s *EvaluatorService) ReEvaluateBatch(ctx context.Context, question *model.Question, answers []*model.Answer) ([]*model.EvaluationResult, error) {
+	if !s.aiEnabled(ctx) {
+		results := make([]*model.EvaluationResult, len(answers))
+		for i, a := range answers {
+			results[i] = s.mockEvaluate(question, a)
+		}
+		return results, nil
+	}
+
+	prompt := s.buildBatchEvaluationPrompt(question, answers)
+	modelName, temperature := s.questionModel(question, s.config.Models.L1Eval)
+	var batch batchEvaluation
+	if err := s.callGeminiJSON(ctx, aiTemplateBatchEvaluation, modelName, prompt, temperature, &batch); err != nil {
+		return nil, err
+	}
+	return batch.byIndex(len(answers)), nil
+}
+
+// batchEvaluation is the batch evaluation prompt's output
+type batchEvaluation struct {
+	Results []struct {
+		Index int `json:"index"`
+		model.EvaluationResult
+	} `json:"results"`
+}
+
+// byIndex maps the batch results to n answers by index; missing entries are nil
+func (b *batchEvaluation) byIndex(n int) []*model.EvaluationResult {
+	results := make([]*model.EvaluationResult, n)
+	for _, r := range b.Results {
+		if r.Index >= 0 && r.Index < n && results[r.Index] == nil {
+			result := r.EvaluationResult
+			results[r.Index] = &result
+		}
+	}
+	return results
 }
 
 // GenerateFollowUp generates a personalized follow-up question (fast model)
-func (s *EvaluatorService) GenerateFollowUp(ctx context.Context, question *model.Question, player *model.Player, evalResult *model.EvaluationResult, answerText string, qProfile *model.QuestionProfile, roomMemory *model.RoomMemory, history []model.Answer, surveyIntent string, nextKey string, baseKey string) (*model.Question, error) {
-	if !s.config.IsEnabled() {
+func (s *EvaluatorService) GenerateFollowUp(ctx context.Context, question *model.Question, player *model.Player, evalResult *model.EvaluationResult, answerText string, qProfile *model.QuestionProfile, roomMemory *model.RoomMemory, history []model.Answer, surveyIntent string, scope *model.ScopeAnchor, guardrails *model.Guardrails, nextKey string, baseKey string) (*model.Question, error) {
+	return s.GenerateFollowUpStreaming(ctx, question, player, evalResult, answerText, qProfile, roomMemory, history, surveyIntent, scope, guardrails, nextKey, baseKey, nil, nil)
+}
+
+// GenerateFollowUpStreaming generates a follow-up, calling onPartial with the
+// follow-up prompt text as it streams in (when streaming is enabled). A follow-up
+// that touches one of the survey's guardrails is rejected with ErrGuardrailViolation.
+// When guardrails or a scope anchor are set, partials are held back until the
+// finished follow-up passes those checks, so a rejected prompt is never shown.
+func (s *EvaluatorService) GenerateFollowUpStreaming(ctx context.Context, question *model.Question, player *model.Player, evalResult *model.EvaluationResult, answerText string, qProfile *model.QuestionProfile, roomMemory *model.RoomMemory, history []model.Answer, surveyIntent string, scope *model.ScopeAnchor, guardrails *model.Guardrails, nextKey string, baseKey string, variant *model.ExperimentVariant, onPartial func(prompt string)) (*model.Question, error) {
+	if !s.aiEnabled(ctx) {
 		fmt.Println("[FollowUp] Config disabled, using mock")
 		return s.mockFollowUp(question, nextKey, baseKey), nil
 	}
 
 	fmt.Printf("[FollowUp] Generating for Q: %s | Answer: %.50s...\n", question.Key, answerText)
-	prompt := s.buildFollowUpPrompt(question, player, evalResult, answerText, qProfile, roomMemory, history, surveyIntent, baseKey)
-	response, err := s.callGemini(ctx, s.config.Models.FollowUp, prompt)
+	prompt := s.buildFollowUpPrompt(question, player, evalResult, answerText, qProfile, roomMemory, history, surveyIntent, scope, guardrails, baseKey, variant)
+	modelName, temperature := s.questionModel(question, s.config.Models.FollowUp)
+	var response string
+	var err error
+	// Partials held back until the scope and guardrail checks pass
+	var held []string
+	if onPartial != nil && s.config.StreamFollowUps {
+		checked := scope != nil || !guardrails.IsEmpty()
+		lastPrompt := ""
+		response, err = s.streamGemini(ctx, modelName, prompt, temperature, responseSchemas[aiTemplateFollowUp], func(text string) {
+			if partial, ok := partialJSONString(text, "prompt"); ok && len(partial) > len(lastPrompt) {
+				lastPrompt = partial
+				if checked {
+					held = append(held, partial)
+				} else {
+					onPartial(partial)
+				}
+			}
+		})
+	} else {
+		response, err = s.callGeminiTuned(ctx, modelName, prompt, temperature, responseSchemas[aiTemplateFollowUp])
+	}
 	if err != nil {
 		fmt.Printf("[FollowUp] Call Error: %v\n", err)
 		return nil, err // Don't generate mock on error
@@ -68,13 +239,24 @@ func (s *EvaluatorService) GenerateFollowUp(ctx context.Context, question *model
 	fmt.Printf("[FollowUp] Raw Response: %s\n", response)
 
 	var gen model.FollowUpGeneration
-	if err := json.Unmarshal([]byte(response), &gen); err != nil {
+	if err := s.decodeAIResponse(ctx, aiTemplateFollowUp, modelName, prompt, temperature, response, &gen); err != nil {
 		fmt.Printf("[FollowUp] JSON Error: %v\n", err)
 		return nil, err // Don't generate mock on parse error
 	}
 
 	if len(gen.FollowUps) > 0 {
 		fu := gen.FollowUps[0]
+		if hit := guardrails.Violation(fu.Prompt + " " + strings.Join(fu.Options, " ")); hit != "" {
+			fmt.Printf("[FollowUp] Rejected follow-up touching guardrail %q: %.80s\n", hit, fu.Prompt)
+			return nil, fmt.Errorf("%w: %s", ErrGuardrailViolation, hit)
+		}
+		if !s.ValidateFollowUpScope(ctx, scope, surveyIntent, fu.Prompt) {
+			fmt.Printf("[FollowUp] Rejected out-of-scope follow-up: %.80s\n", fu.Prompt)
+			return nil, nil
+		}
+		for _, partial := range held {
+			onPartial(partial)
+		}
 		return &model.Question{
 			Key:       nextKey,
 			ParentKey: baseKey,
@@ -84,6 +266,8 @@ func (s *EvaluatorService) GenerateFollowUp(ctx context.Context, question *model
 			PointsMax: fu.PointsMax,
 			Threshold: fu.Threshold,
 			Options:   fu.Options, // Add options for MCQ
+			Media:     question.Media,
+			AI:        question.AI,
 		}, nil
 	}
 
@@ -91,20 +275,50 @@ func (s *EvaluatorService) GenerateFollowUp(ctx context.Context, question *model
 	return nil, nil // No follow-up needed
 }
 
+// GenerateScopeAnchor builds the room scope anchor from the survey intent and host notes (quality model)
+func (s *EvaluatorService) GenerateScopeAnchor(ctx context.Context, survey *model.Survey, hostNotes string) (*model.ScopeAnchor, error) {
+	if !s.aiEnabled(ctx) {
+		return s.mockScopeAnchor(survey, hostNotes), nil
+	}
+
+	prompt := s.buildScopeAnchorPrompt(survey, hostNotes)
+	var anchor model.ScopeAnchor
+	if err := s.callGeminiJSON(ctx, aiTemplateScopeAnchor, s.config.Models.ScopeAnchor, prompt, nil, &anchor); err != nil || anchor.Summary == "" {
+		fmt.Printf("[ScopeAnchor] Call Error: %v\n", err)
+		return s.mockScopeAnchor(survey, hostNotes), nil
+	}
+
+	return &anchor, nil
+}
+
+// ValidateFollowUpScope checks a generated follow-up against the scope anchor (fast model).
+// Fails open: if the check itself cannot run, the follow-up is allowed.
+func (s *EvaluatorService) ValidateFollowUpScope(ctx context.Context, scope *model.ScopeAnchor, surveyIntent, followUpPrompt string) bool {
+	if scope == nil || !s.aiEnabled(ctx) {
+		return true
+	}
+
+	prompt := s.buildScopeCheckPrompt(scope, surveyIntent, followUpPrompt)
+	var check model.ScopeCheck
+	if err := s.callGeminiJSON(ctx, aiTemplateScopeCheck, s.config.Models.L1Eval, prompt, nil, &check); err != nil {
+		fmt.Printf("[ScopeCheck] Call Error: %v\n", err)
+		return true
+	}
+	if !check.InScope {
+		fmt.Printf("[ScopeCheck] Out of scope: %s\n", check.Reason)
+	}
+	return check.InScope
+}
+
 // GenerateFollowUpPool generates a pool of follow-up questions (quality model)
 func (s *EvaluatorService) GenerateFollowUpPool(ctx context.Context, question *model.Question, surveyIntent string) (*model.FollowUpPool, error) {
-	if !s.config.IsEnabled() {
+	if !s.aiEnabled(ctx) {
 		return s.mockPool(question), nil
 	}
 
 	prompt := s.buildPoolPrompt(question, surveyIntent)
-	response, err := s.callGemini(ctx, s.config.Models.PoolGen, prompt)
-	if err != nil {
-		return s.mockPool(question), nil
-	}
-
 	var pool model.FollowUpPool
-	if err := json.Unmarshal([]byte(response), &pool); err != nil {
+	if err := s.callGeminiJSON(ctx, aiTemplatePool, s.config.Models.PoolGen, prompt, nil, &pool); err != nil {
 		return s.mockPool(question), nil
 	}
 
@@ -113,21 +327,16 @@ func (s *EvaluatorService) GenerateFollowUpPool(ctx context.Context, question *m
 
 // RefreshQuestionProfile refreshes misunderstandings for a question (L3)
 func (s *EvaluatorService) RefreshQuestionProfile(ctx context.Context, profile *model.QuestionProfile, recentSummaries []string) (*model.QuestionProfile, error) {
-	if !s.config.IsEnabled() {
+	if !s.aiEnabled(ctx) {
 		return profile, nil
 	}
 
 	prompt := s.buildL3RefreshPrompt(profile, recentSummaries)
-	response, err := s.callGemini(ctx, s.config.Models.L3Refresh, prompt)
-	if err != nil {
-		return profile, nil
-	}
-
 	var result struct {
 		Misunderstandings []string `json:"misunderstandings"`
 		BestProbes        []string `json:"bestProbes"`
 	}
-	if err := json.Unmarshal([]byte(response), &result); err != nil {
+	if err := s.callGeminiJSON(ctx, aiTemplateProfileRefresh, s.config.Models.L3Refresh, prompt, nil, &result); err != nil {
 		return profile, nil
 	}
 
@@ -136,20 +345,42 @@ func (s *EvaluatorService) RefreshQuestionProfile(ctx context.Context, profile *
 	return profile, nil
 }
 
-// GenerateAIReport generates the full AI insight report (deep model)
-func (s *EvaluatorService) GenerateAIReport(ctx context.Context, snapshot *model.RoomSnapshot, evidenceSamples map[string][]string) (*model.AIReport, error) {
-	if !s.config.IsEnabled() {
-		return s.mockReport(snapshot), nil
+// RefreshRoomMemory derives contrast axes, friction reasons and recommended probes for L4 (call periodically)
+func (s *EvaluatorService) RefreshRoomMemory(ctx context.Context, memory *model.RoomMemory, profiles []*model.QuestionProfile) (*model.RoomMemory, error) {
+	if !s.aiEnabled(ctx) {
+		return memory, nil
 	}
 
-	prompt := s.buildReportPrompt(snapshot, evidenceSamples)
-	response, err := s.callGemini(ctx, s.config.Models.Report, prompt)
-	if err != nil {
+	prompt := s.buildL4RefreshPrompt(memory, profiles)
+	var result struct {
+		Contrasts         []model.Contrast  `json:"contrasts"`
+		FrictionReasons   map[string]string `json:"frictionReasons"`
+		RecommendedProbes []string          `json:"recommendedProbes"`
+	}
+	if err := s.callGeminiJSON(ctx, aiTemplateRoomMemory, s.config.Models.L3Refresh, prompt, nil, &result); err != nil {
+		fmt.Printf("[L4Refresh] Call Error: %v\n", err)
+		return memory, nil
+	}
+
+	memory.Contrasts = result.Contrasts
+	memory.RecommendedProbes = result.RecommendedProbes
+	for i := range memory.FrictionPoints {
+		if reason, ok := result.FrictionReasons[memory.FrictionPoints[i].QuestionKey]; ok {
+			memory.FrictionPoints[i].Reason = reason
+		}
+	}
+	return memory, nil
+}
+
+// GenerateAIReport generates the full AI insight report (deep model)
+func (s *EvaluatorService) GenerateAIReport(ctx context.Context, snapshot *model.RoomSnapshot, evidenceSamples map[string][]string, funnel *model.FunnelReport, guardrails *model.Guardrails) (*model.AIReport, error) {
+	if !s.aiEnabled(ctx) {
 		return s.mockReport(snapshot), nil
 	}
 
+	prompt := s.buildReportPrompt(snapshot, evidenceSamples, funnel, guardrails)
 	var report model.AIReport
-	if err := json.Unmarshal([]byte(response), &report); err != nil {
+	if err := s.callGeminiJSON(ctx, aiTemplateReport, s.config.Models.Report, prompt, nil, &report); err != nil {
 		return s.mockReport(snapshot), nil
 	}
 
@@ -161,8 +392,192 @@ func (s *EvaluatorService) GenerateAIReport(ctx context.Context, snapshot *model
 	return &report, nil
 }
 
-// callGemini makes a request to the Gemini API
-func (s *EvaluatorService) callGemini(ctx context.Context, modelName, prompt string) (string, error) {
+// GeneratePlayerFeedback writes the thank-you/insight paragraph of a player's end-of-room summary
+func (s *EvaluatorService) GeneratePlayerFeedback(ctx context.Context, feedback *model.PlayerFeedback, survey *model.Survey) (string, error) {
+	if !s.aiEnabled(ctx) {
+		return s.mockPlayerFeedback(feedback), nil
+	}
+
+	prompt := s.buildPlayerFeedbackPrompt(feedback, survey)
+	var result struct {
+		Message string `json:"message"`
+	}
+	if err := s.callGeminiJSON(ctx, aiTemplatePlayerFeedback, s.config.Models.Report, prompt, nil, &result); err != nil || strings.TrimSpace(result.Message) == "" {
+		return s.mockPlayerFeedback(feedback), nil
+	}
+	return strings.TrimSpace(result.Message), nil
+}
+
+// maxCopilotHints caps the suggestions in one co-pilot update
+const maxCopilotHints = 3
+
+// GenerateCopilotHints suggests 2-3 things the host could do right now, from the live
+// room memory and question profiles; prompts maps question keys to their text
+func (s *EvaluatorService) GenerateCopilotHints(ctx context.Context, memory *model.RoomMemory, profiles []*model.QuestionProfile, prompts map[string]string) ([]model.CopilotHint, error) {
+	if !s.aiEnabled(ctx) {
+		return s.mockCopilotHints(memory), nil
+	}
+
+	prompt := s.buildCopilotPrompt(memory, profiles, prompts)
+	var result struct {
+		Hints []model.CopilotHint `json:"hints"`
+	}
+	if err := s.callGeminiJSON(ctx, aiTemplateCopilot, s.config.Models.L3Refresh, prompt, nil, &result); err != nil {
+		return s.mockCopilotHints(memory), nil
+	}
+	hints := make([]model.CopilotHint, 0, maxCopilotHints)
+	for _, h := range result.Hints {
+		h.Text = strings.TrimSpace(h.Text)
+		if h.Text == "" {
+			continue
+		}
+		if _, ok := prompts[h.QuestionKey]; !ok {
+			h.QuestionKey = ""
+		}
+		hints = append(hints, h)
+		if len(hints) == maxCopilotHints {
+			break
+		}
+	}
+	if len(hints) == 0 {
+		return s.mockCopilotHints(memory), nil
+	}
+	return hints, nil
+}
+
+// comparisonResult is the comparison prompt's output
+type comparisonResult struct {
+	Summary    []string `json:"summary"`
+	Verdict    string   `json:"verdict"`
+	ThemeNotes []struct {
+		Theme string `json:"theme"`
+		Note  string `json:"note"`
+	} `json:"themeNotes"`
+	FrictionReasons []struct {
+		QuestionKey        string `json:"questionKey"`
+		HypothesizedReason string `json:"hypothesizedReason"`
+	} `json:"frictionReasons"`
+	Recommendations []string `json:"recommendations"`
+}
+
+// GenerateComparison writes the summary, verdict, theme notes, friction reasons and
+// recommendations of a measured comparison report; it falls back to a canned reading
+func (s *EvaluatorService) GenerateComparison(ctx context.Context, report *model.ComparisonReport, baselineEvidence, evidence map[string][]string, guardrails *model.Guardrails) {
+	if !s.aiEnabled(ctx) {
+		s.mockComparison(report)
+		return
+	}
+
+	prompt := s.buildComparisonPrompt(report, baselineEvidence, evidence, guardrails)
+	var result comparisonResult
+	if err := s.callGeminiJSON(ctx, aiTemplateComparison, s.config.Models.Report, prompt, nil, &result); err != nil {
+		s.mockComparison(report)
+		return
+	}
+
+	report.Summary = result.Summary
+	report.Recommendations = result.Recommendations
+	switch result.Verdict {
+	case model.ComparisonImproved, model.ComparisonMixed, model.ComparisonWorse, model.ComparisonUnchanged:
+		report.Verdict = result.Verdict
+	default:
+		report.Verdict = mockComparisonVerdict(report.Stats)
+	}
+	for _, n := range result.ThemeNotes {
+		for i := range report.ThemeShifts {
+			if strings.EqualFold(report.ThemeShifts[i].Theme, n.Theme) {
+				report.ThemeShifts[i].Note = strings.TrimSpace(n.Note)
+			}
+		}
+	}
+	for _, r := range result.FrictionReasons {
+		for i := range report.NewFrictionPoints {
+			if report.NewFrictionPoints[i].QuestionKey == r.QuestionKey {
+				report.NewFrictionPoints[i].HypothesizedReason = strings.TrimSpace(r.HypothesizedReason)
+			}
+		}
+	}
+}
+
+// maxDigestHighlights caps the highlights of a weekly digest
+const maxDigestHighlights = 5
+
+// GenerateDigestHighlights writes the highlights of a host's weekly digest with the
+// report model; it falls back to canned highlights from the week's totals
+func (s *EvaluatorService) GenerateDigestHighlights(ctx context.Context, digest *model.Digest) []string {
+	if !s.aiEnabled(ctx) {
+		return s.mockDigestHighlights(digest)
+	}
+
+	var result struct {
+		Highlights []string `json:"highlights"`
+	}
+	if err := s.callGeminiJSON(ctx, aiTemplateDigest, s.config.Models.Report, s.buildDigestPrompt(digest), nil, &result); err != nil {
+		return s.mockDigestHighlights(digest)
+	}
+	highlights := make([]string, 0, maxDigestHighlights)
+	for _, h := range result.Highlights {
+		if h = strings.TrimSpace(h); h != "" && len(highlights) < maxDigestHighlights {
+			highlights = append(highlights, h)
+		}
+	}
+	if len(highlights) == 0 {
+		return s.mockDigestHighlights(digest)
+	}
+	return highlights
+}
+
+// callGeminiTuned makes a budgeted, circuit-broken request to the Gemini API with an
+// optional sampling temperature (nil = model default) and response schema (nil = free-form JSON)
+func (s *EvaluatorService) callGeminiTuned(ctx context.Context, modelName, prompt string, temperature *float64, responseSchema map[string]interface{}) (string, error) {
+	return s.guardedCall(ctx, modelName, prompt, func() (string, error) {
+		return s.doGeminiRequest(ctx, modelName, prompt, temperature, responseSchema)
+	})
+}
+
+// questionModel resolves the model and temperature for a question's AI calls,
+// honoring its per-question overrides
+func (s *EvaluatorService) questionModel(question *model.Question, defaultModel string) (string, *float64) {
+	if question == nil || question.AI == nil {
+		return defaultModel, nil
+	}
+	modelName := defaultModel
+	if question.AI.ModelTier == model.ModelTierQuality && s.config.Models.Quality != "" {
+		modelName = s.config.Models.Quality
+	}
+	return modelName, question.AI.Temperature
+}
+
+// geminiResponse is the generateContent response structure (also each SSE chunk when streaming)
+type geminiResponse struct {
+	Candidates []struct {
+		Content struct {
+			Parts []struct {
+				Text string `json:"text"`
+			} `json:"parts"`
+		} `json:"content"`
+		FinishReason string `json:"finishReason"`
+	} `json:"candidates"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error"`
+}
+
+// geminiRequestBody builds the JSON request body for a single-prompt call; a
+// response schema turns on Gemini's structured output
+func geminiRequestBody(prompt string, temperature *float64, responseSchema map[string]interface{}) ([]byte, error) {
+	generationConfig := map[string]interface{}{
+		"responseMimeType": "application/json",
+	}
+	if temperature != nil {
+		generationConfig["temperature"] = *temperature
+	}
+	if responseSchema != nil {
+		generationConfig["responseSchema"] = responseSchema
+	}
+
 	reqBody := map[string]interface{}{
 		"contents": []map[string]interface{}{
 			{
@@ -171,12 +586,14 @@ func (s *EvaluatorService) callGemini(ctx context.Context, modelName, prompt str
 				},
 			},
 		},
-		"generationConfig": map[string]interface{}{
-			"responseMimeType": "application/json",
-		},
+		"generationConfig": generationConfig,
 	}
+	return json.Marshal(reqBody)
+}
 
-	jsonBody, err := json.Marshal(reqBody)
+// doGeminiRequest performs the raw HTTP call to the Gemini API
+func (s *EvaluatorService) doGeminiRequest(ctx context.Context, modelName, prompt string, temperature *float64, responseSchema map[string]interface{}) (string, error) {
+	jsonBody, err := geminiRequestBody(prompt, temperature, responseSchema)
 	if err != nil {
 		return "", err
 	}
@@ -205,21 +622,7 @@ func (s *EvaluatorService) callGemini(ctx context.Context, modelName, prompt str
 	}
 
 	// Parse Gemini response structure
-	var geminiResp struct {
-		Candidates []struct {
-			Content struct {
-				Parts []struct {
-					Text string `json:"text"`
-				} `json:"parts"`
-			} `json:"content"`
-			FinishReason string `json:"finishReason"`
-		} `json:"candidates"`
-		Error *struct {
-			Code    int    `json:"code"`
-			Message string `json:"message"`
-			Status  string `json:"status"`
-		} `json:"error"`
-	}
+	var geminiResp geminiResponse
 
 	if err := json.Unmarshal(body, &geminiResp); err != nil {
 		fmt.Printf("[Gemini] JSON Unmarshal Error: %v | Body: %s\n", err, string(body))
@@ -245,45 +648,83 @@ func (s *EvaluatorService) callGemini(ctx context.Context, modelName, prompt str
 	return "", fmt.Errorf("empty response from Gemini")
 }
 
+// specificityGuideline is shared by every strictness preset of the L1 prompts
+const specificityGuideline = `- Narrow vs. Broad: If they named a narrow technical feature (e.g. "OLED", "4K"), do NOT mark "specifics" as missing. If they gave a broad/subjective reason (e.g. "price", "it's fast", "looks good"), you MAY mark "specifics" as missing to trigger one targeted drill-down.`
+
+// gradingScales is the minimal-answer rule and grading scale of the L1 prompts per strictness preset
+var gradingScales = map[model.Strictness]string{
+	model.StrictnessLenient: `- Casual Session: Any sincere answer that touches the question is SAT, however short. Only irrelevant or gibberish answers are UNSAT.
+- Grading Scale: 
+  - 0.8-1.0: Thoughtful, or gives any concrete detail.
+  - 0.5-0.8: Sincere and on-topic, even if brief (e.g. "the price is good").
+  - 0.2-0.5: Minimal effort but related (e.g. "it is food").
+  - 0.0-0.2: Irrelevant or gibberish.`,
+	model.StrictnessStandard: `- Leniency on Minimal Answers: If an answer is very short (3-8 words) but addresses the question, mark it as SAT but give it a low quality score (e.g. 0.3-0.5).
+- Grading Scale: 
+  - 0.9-1.0: Excellent, detailed, insights provided.
+  - 0.6-0.9: Good, solid answer with a clear data point.
+  - 0.3-0.6: Mid / Broad - technically answers but lacks depth (e.g. "the price is good").
+  - 0.1-0.3: Minimalist / Horrible effort (e.g. "it is food").
+  - 0.0: Irrelevant or gibberish.`,
+	model.StrictnessStrict: `- Research Rigor: SAT requires a concrete reason, example or data point that the rubric asks for. Short or generic answers are UNSAT even if on-topic.
+- Grading Scale: 
+  - 0.9-1.0: Detailed, specific and backed by an example or number.
+  - 0.7-0.9: Clear answer with at least one concrete data point.
+  - 0.4-0.7: Answers the question but stays generic (e.g. "the price is good").
+  - 0.1-0.4: Minimal effort (e.g. "it is food").
+  - 0.0: Irrelevant or gibberish.`,
+}
+
+// evaluationGuidelines is the grading guidance shared by single and batch L1 prompts
+func evaluationGuidelines(strictness model.Strictness) string {
+	scale, ok := gradingScales[strictness]
+	if !ok {
+		scale = gradingScales[model.StrictnessStandard]
+	}
+	return specificityGuideline + "\n" + scale
+}
+
 // Prompt builders
 func (s *EvaluatorService) buildEvaluationPrompt(question *model.Question, answer *model.Answer) string {
-	return fmt.Sprintf(`You are evaluating a survey response. Return ONLY valid JSON matching this schema:
-{
-  "resolution": "SAT" or "UNSAT",
-  "qualityScore": 0.0 to 1.0,
-  "signals": {
-    "themes": ["theme1", "theme2"],
-    "missing": ["list of truly missing required data based on rubric"],
-    "specificity": 0.0 to 1.0,
-    "clarity": 0.0 to 1.0,
-    "sentiment": -1.0 to 1.0,
-    "confidence_language": 0.0 to 1.0,
-    "summary": "one sentence summary",
-    "cluster_hint": "optional grouping hint",
-    "risk_flags": []
-  },
-  "followup_hint": "clarify" or "deepen" or "branch" or "challenge",
-  "notes_for_host": "optional private note"
-}
+	return fmt.Sprintf(`You are evaluating a survey response. Fill in the evaluation of the response schema.
 
 Question: %s
-Rubric: %s
+%sRubric: %s
 Threshold for SAT: %.2f
 Player's Answer: %s
 
 Evaluate the answer.
-- Narrow vs. Broad: If they named a narrow technical feature (e.g. "OLED", "4K"), do NOT mark "specifics" as missing. If they gave a broad/subjective reason (e.g. "price", "it's fast", "looks good"), you MAY mark "specifics" as missing to trigger one targeted drill-down.
-- Leniency on Minimal Answers: If an answer is very short (3-8 words) but addresses the question, mark it as SAT but give it a low quality score (e.g. 0.3-0.5).
-- Grading Scale: 
-  - 0.9-1.0: Excellent, detailed, insights provided.
-  - 0.6-0.9: Good, solid answer with a clear data point.
-  - 0.3-0.6: Mid / Broad - technically answers but lacks depth (e.g. "the price is good").
-  - 0.1-0.3: Minimalist / Horrible effort (e.g. "it is food").
-  - 0.0: Irrelevant or gibberish.`,
-		question.Prompt, question.Rubric, question.Threshold, answer.TextAnswer)
+%s%s`,
+		question.Prompt, formatQuestionMedia(question.Media), question.Rubric, question.Threshold, s.promptAnswer(answer.TextAnswer), evaluationGuidelines(question.Strictness), formatThemeTaxonomy(question.Taxonomy))
+}
+
+// promptAnswer bounds a player's answer before it is embedded in a prompt
+func (s *EvaluatorService) promptAnswer(text string) string {
+	return truncateForPrompt(text, s.config.PromptAnswerChars)
 }
 
-func (s *EvaluatorService) buildFollowUpPrompt(question *model.Question, player *model.Player, evalResult *model.EvaluationResult, answerText string, qProfile *model.QuestionProfile, roomMemory *model.RoomMemory, history []model.Answer, surveyIntent string, baseKey string) string {
+func (s *EvaluatorService) buildBatchEvaluationPrompt(question *model.Question, answers []*model.Answer) string {
+	items := make([]map[string]interface{}, len(answers))
+	for i, a := range answers {
+		items[i] = map[string]interface{}{"index": i, "answer": s.promptAnswer(a.TextAnswer)}
+	}
+	answersJSON, _ := json.MarshalIndent(items, "", "  ")
+
+	return fmt.Sprintf(`You are evaluating several independent survey responses to the same question. Fill in the results of the response schema.
+Return exactly one result per answer, echoing its "index". Evaluate each answer on its own merits.
+
+Question: %s
+%sRubric: %s
+Threshold for SAT: %.2f
+Answers:
+%s
+
+Evaluate each answer.
+%s%s`,
+		question.Prompt, formatQuestionMedia(question.Media), question.Rubric, question.Threshold, string(answersJSON), evaluationGuidelines(question.Strictness), formatThemeTaxonomy(question.Taxonomy))
+}
+
+func (s *EvaluatorService) buildFollowUpPrompt(question *model.Question, player *model.Player, evalResult *model.EvaluationResult, answerText string, qProfile *model.QuestionProfile, roomMemory *model.RoomMemory, history []model.Answer, surveyIntent string, scope *model.ScopeAnchor, guardrails *model.Guardrails, baseKey string, variant *model.ExperimentVariant) string {
 	missingStr := strings.Join(evalResult.Signals.Missing, ", ")
 
 	// Context construction
@@ -337,7 +778,7 @@ func (s *EvaluatorService) buildFollowUpPrompt(question *model.Question, player
 			start = len(history) - 3
 		}
 		for _, ans := range history[start:] {
-			sb.WriteString(fmt.Sprintf("- Said: \"%s\"\n", ans.TextAnswer))
+			sb.WriteString(fmt.Sprintf("- Said: \"%s\"\n", s.promptAnswer(ans.TextAnswer)))
 		}
 		historyStr = sb.String()
 	}
@@ -346,8 +787,8 @@ func (s *EvaluatorService) buildFollowUpPrompt(question *model.Question, player
 
 SURVEY CONTEXT:
 Intent: "%s"
-Current Question: "%s"
-
+%s%sCurrent Question: "%s"
+%s
 PLAYER DATA:
 Answer: "%s"
 Initial Analysis: %s (Missing: %s)
@@ -363,22 +804,136 @@ TASK:
    - Acknowledge their response with energy (e.g. "Price is always a huge factor!", "Speed is king!").
    - Ask ONE short question to get a concrete detail (e.g. "What price range are you aiming for?" or "Which part of the design really caught your eye?").
    - KEEP IT CONCISE: 1-2 short sentences max.
+   - STAY IN SCOPE: never ask about anything listed as out of scope.
+%s
+Fill in the followUps of the response schema: one follow-up with pointsMax %d and threshold %.2f, or none if the answer is already sufficiently narrow.`,
+		surveyIntent, formatScopeAnchor(scope), formatGuardrails(guardrails), question.Prompt,
+		formatQuestionMedia(question.Media), s.promptAnswer(answerText), evalResult.Resolution, missingStr, historyStr,
+		followUpStrategy(variant, question.Strictness), question.PointsMax/2, question.Threshold)
+}
+
+// followUpStrategy renders the follow-up instructions of an experiment variant,
+// falling back to the room's strictness preset for the style
+func followUpStrategy(variant *model.ExperimentVariant, strictness model.Strictness) string {
+	style := strictness.FollowUpStyle()
+	if variant != nil && variant.FollowUpStyle != model.FollowUpStyleDefault {
+		style = variant.FollowUpStyle
+	}
+	var sb strings.Builder
+	switch style {
+	case model.FollowUpStyleGentle:
+		sb.WriteString("STYLE: Be gentle. Only follow up when the answer is clearly vague, and phrase it as an easy, optional invitation.\n")
+	case model.FollowUpStyleAggressive:
+		sb.WriteString("STYLE: Be thorough. Follow up whenever the answer lacks a concrete detail, example or number.\n")
+	}
+	if variant != nil && variant.PromptNote != "" {
+		sb.WriteString("HOST INSTRUCTION: " + variant.PromptNote + "\n")
+	}
+	return sb.String()
+}
+
+func (s *EvaluatorService) buildScopeAnchorPrompt(survey *model.Survey, hostNotes string) string {
+	var questions strings.Builder
+	for _, q := range survey.Questions {
+		questions.WriteString(fmt.Sprintf("- %s: %s\n", q.Key, q.Prompt))
+	}
+	if hostNotes == "" {
+		hostNotes = "(none)"
+	}
 
+	return fmt.Sprintf(`You are defining the scope for AI follow-up questions in a live survey.
 Return ONLY valid JSON:
 {
-  "followUps": [{
-    "type": "ESSAY" or "MCQ",
-    "prompt": "Targeted, energetic question here",
-    "options": ["A", "B"], // if MCQ
-    "rubric": "Short rubric",
-    "pointsMax": %d,
-    "threshold": %.2f,
-    "reason_in_scope": "Brief reason"
-  }] // Return [] if the answer is already sufficiently narrow.
-}`,
-		surveyIntent, question.Prompt,
-		answerText, evalResult.Resolution, missingStr, historyStr,
-		question.PointsMax/2, question.Threshold)
+  "summary": "1-2 sentence description of what this survey is trying to learn",
+  "inScope": ["topic", ...],
+  "outOfScope": ["topic", ...]
+}
+
+Survey Title: "%s"
+Survey Intent: "%s"
+Host Notes: "%s"
+Questions:
+%s
+Keep each list to at most 6 short topics. Out of scope should include personal/sensitive data and anything unrelated to the intent.`,
+		survey.Title, survey.Intent, hostNotes, questions.String())
+}
+
+func (s *EvaluatorService) buildScopeCheckPrompt(scope *model.ScopeAnchor, surveyIntent, followUpPrompt string) string {
+	return fmt.Sprintf(`Decide whether a generated survey follow-up question stays within the survey scope.
+Return ONLY valid JSON:
+{"inScope": true or false, "reason": "short reason"}
+
+Survey Intent: "%s"
+%s
+Follow-up Question: "%s"`,
+		surveyIntent, formatScopeAnchor(scope), followUpPrompt)
+}
+
+// formatScopeAnchor renders the scope anchor as prompt lines (empty if none)
+func formatScopeAnchor(scope *model.ScopeAnchor) string {
+	if scope == nil {
+		return ""
+	}
+	out := fmt.Sprintf("Scope: %s\n", scope.Summary)
+	if len(scope.InScope) > 0 {
+		out += fmt.Sprintf("In scope: %s\n", strings.Join(scope.InScope, ", "))
+	}
+	if len(scope.OutOfScope) > 0 {
+		out += fmt.Sprintf("Out of scope: %s\n", strings.Join(scope.OutOfScope, ", "))
+	}
+	return out
+}
+
+// formatGuardrails renders the host's guardrails for a prompt; empty when none are set
+func formatGuardrails(g *model.Guardrails) string {
+	if g.IsEmpty() {
+		return ""
+	}
+	out := "HOST GUARDRAILS (must be followed):\n"
+	if len(g.BannedTopics) > 0 {
+		out += fmt.Sprintf("- Never ask about or mention: %s\n", strings.Join(g.BannedTopics, ", "))
+	}
+	if len(g.ForbiddenPhrases) > 0 {
+		out += fmt.Sprintf("- Never use these phrasings: %s\n", strings.Join(g.ForbiddenPhrases, "; "))
+	}
+	if notes := strings.TrimSpace(g.ComplianceNotes); notes != "" {
+		out += fmt.Sprintf("- Compliance notes: %s\n", notes)
+	}
+	return out
+}
+
+// formatQuestionMedia describes the image or video players saw with the question,
+// one line ending in a newline; empty without media
+func formatQuestionMedia(m *model.QuestionMedia) string {
+	if m == nil {
+		return ""
+	}
+	kind := "an image"
+	if m.Type == model.MediaTypeVideo {
+		kind = "a video"
+	}
+	desc := "no description given"
+	if alt := strings.TrimSpace(m.AltText); alt != "" {
+		desc = alt
+	}
+	return fmt.Sprintf("Shown with the question: %s (%s). Players are reacting to it; references to what it shows are on topic.\n", kind, desc)
+}
+
+// formatThemeTaxonomy tells the evaluator which theme names to use; empty without a taxonomy
+func formatThemeTaxonomy(t *model.ThemeTaxonomy) string {
+	if t.IsEmpty() {
+		return ""
+	}
+	out := "\nTHEME TAXONOMY: report signals.themes using these names only:\n"
+	for _, theme := range t.Themes {
+		if len(theme.Synonyms) > 0 {
+			out += fmt.Sprintf("- %s (also: %s)\n", theme.Name, strings.Join(theme.Synonyms, ", "))
+		} else {
+			out += fmt.Sprintf("- %s\n", theme.Name)
+		}
+	}
+	out += fmt.Sprintf("Write a theme that fits none of them as \"%s: <short theme>\".\n", model.ThemeOther)
+	return out
 }
 
 func (s *EvaluatorService) buildPoolPrompt(question *model.Question, surveyIntent string) string {
@@ -421,37 +976,324 @@ Identify the top 3 misunderstandings and suggest 2 best follow-up angles.`,
 		profile.AnswerCount, themesStr, summariesStr)
 }
 
-func (s *EvaluatorService) buildReportPrompt(snapshot *model.RoomSnapshot, evidenceSamples map[string][]string) string {
+func (s *EvaluatorService) buildL4RefreshPrompt(memory *model.RoomMemory, profiles []*model.QuestionProfile) string {
+	var clusters strings.Builder
+	for _, p := range profiles {
+		themes := make([]string, 0, len(p.ThemeCounts))
+		for theme, count := range p.ThemeCounts {
+			themes = append(themes, fmt.Sprintf("%s (%d)", theme, count))
+		}
+		clusters.WriteString(fmt.Sprintf("- %s: %s\n", p.QuestionKey, strings.Join(themes, ", ")))
+		for _, c := range p.Clusters {
+			clusters.WriteString(fmt.Sprintf("  - cluster \"%s\" (%d players): %s\n", c.Label, c.PlayerCount, strings.Join(c.Keywords, ", ")))
+		}
+	}
+
+	var friction strings.Builder
+	for _, fp := range memory.FrictionPoints {
+		friction.WriteString(fmt.Sprintf("- %s: skip %.0f%%, unsat %.0f%%\n", fp.QuestionKey, fp.SkipRate*100, fp.UnsatRate*100))
+	}
+	if friction.Len() == 0 {
+		friction.WriteString("- none\n")
+	}
+
+	return fmt.Sprintf(`Analyze the themes from a live survey room. Return ONLY valid JSON:
+{
+  "contrasts": [{"axis": "what people disagree on", "sideA": "view A", "sideB": "view B", "sideACount": 0, "sideBCount": 0}],
+  "frictionReasons": {"Q1": "why players skip or struggle with this question"},
+  "recommendedProbes": ["best next question to ask this room"]
+}
+
+Themes per question (with counts):
+%s
+Friction points:
+%s
+Return 2-5 contrasts only where themes genuinely pull in opposite directions (estimate side counts from theme counts), a reason for every friction point listed, and up to 3 recommended probes.`,
+		clusters.String(), friction.String())
+}
+
+func (s *EvaluatorService) buildCopilotPrompt(memory *model.RoomMemory, profiles []*model.QuestionProfile, prompts map[string]string) string {
+	var questions strings.Builder
+	for _, p := range profiles {
+		questions.WriteString(fmt.Sprintf("- %s \"%s\": %d answers, %d SAT, %d UNSAT, %d skipped\n",
+			p.QuestionKey, prompts[p.QuestionKey], p.AnswerCount, p.SatCount, p.UnsatCount, p.SkipCount))
+	}
+
+	var friction strings.Builder
+	for _, fp := range memory.FrictionPoints {
+		friction.WriteString(fmt.Sprintf("- %s: skip %.0f%%, unsat %.0f%%", fp.QuestionKey, fp.SkipRate*100, fp.UnsatRate*100))
+		if fp.Reason != "" {
+			friction.WriteString(" (likely: " + fp.Reason + ")")
+		}
+		friction.WriteString("\n")
+	}
+	if friction.Len() == 0 {
+		friction.WriteString("- none\n")
+	}
+
+	themes := make([]string, 0, len(memory.GlobalThemesTop))
+	for _, t := range memory.GlobalThemesTop {
+		themes = append(themes, fmt.Sprintf("%s (%d)", t.Theme, t.Count))
+	}
+	contrasts := make([]string, 0, len(memory.Contrasts))
+	for _, c := range memory.Contrasts {
+		contrasts = append(contrasts, fmt.Sprintf("%s: %s vs %s", c.Axis, c.SideA, c.SideB))
+	}
+
+	return fmt.Sprintf(`You are a co-pilot for the host of a live survey session. Suggest what the host could do right now. Return ONLY valid JSON:
+{"hints": [{"text": "one short, concrete suggestion", "questionKey": "Q3 or empty"}]}
+
+Players: %d, completion so far: %.0f%%
+Questions:
+%sFriction points:
+%sThemes: %s
+Contrasts: %s
+
+Give 2-3 hints of at most 25 words, most urgent first, e.g. "Q3 is being skipped a lot - consider restating it".
+Refer to questions by key; set questionKey when a hint is about one question. Do not invent numbers.`,
+		memory.TotalPlayers, memory.CompletionRate*100, questions.String(), friction.String(),
+		strings.Join(themes, ", "), strings.Join(contrasts, "; "))
+}
+
+func (s *EvaluatorService) buildReportPrompt(snapshot *model.RoomSnapshot, evidenceSamples map[string][]string, funnel *model.FunnelReport, guardrails *model.Guardrails) string {
 	evidenceStr := ""
 	for qKey, samples := range evidenceSamples {
 		evidenceStr += fmt.Sprintf("\n%s:\n- %s", qKey, strings.Join(samples, "\n- "))
 	}
 
-	return fmt.Sprintf(`Generate an AI insight report for this survey room. Return ONLY valid JSON:
-{
-  "executiveSummary": ["finding 1", "finding 2", "finding 3", "finding 4", "finding 5"],
-  "keyThemes": [{"name": "theme", "meaning": "explanation", "percentage": 0.0, "evidenceSnippets": ["snippet"]}],
-  "contrasts": [{"axis": "axis name", "sideA": "view A", "sideB": "view B", "predictor": "what predicts each"}],
-  "perQuestionInsights": [{"questionKey": "Q1", "whatWorked": [], "misunderstandings": [], "missingDetails": [], "bestFollowUps": []}],
-  "frictionAnalysis": [{"questionKey": "Q1", "issueDescription": "...", "hypothesizedReason": "..."}],
-  "recommendedQuestions": ["new question 1", "new question 2"],
-  "recommendedEdits": [{"questionKey": "Q1", "currentText": "...", "suggestedText": "...", "reason": "..."}]
-}
+	funnelStr := "\n- not available"
+	if funnel != nil && len(funnel.Steps) > 0 {
+		funnelStr = ""
+		for _, step := range funnel.Steps {
+			funnelStr += fmt.Sprintf("\n- #%d %s: reached %d, answered %d, skipped %d, abandoned %d",
+				step.Position, step.QuestionKey, step.Reached, step.Answered, step.Skipped, step.Abandoned)
+		}
+	}
+
+	return fmt.Sprintf(`Generate an AI insight report for this survey room in the response schema.
 
 Room Stats:
 - Total players: %d
 - Completion rate: %.1f%%
 - Skip rate: %.1f%%
 
-Evidence samples:%s
+Question funnel (survey order; use drop-off between steps for friction analysis):%s
+
+Evidence samples (samples marked [HOST STARRED] were flagged by the host as noteworthy; reflect them in the findings and evidence snippets. Other markers say why a sample was picked: quality extremes, contrasting sentiment or a theme few players raised; do not copy markers into snippets):%s
 
-Generate a comprehensive but concise insight report.`,
-		snapshot.TotalPlayers, snapshot.CompletionRate*100, snapshot.OverallSkipRate*100, evidenceStr)
+%sGenerate a comprehensive but concise insight report. Recommended questions and edits must follow the host guardrails, if any.`,
+		snapshot.TotalPlayers, snapshot.CompletionRate*100, snapshot.OverallSkipRate*100, funnelStr, evidenceStr, formatGuardrails(guardrails))
+}
+
+func (s *EvaluatorService) buildPlayerFeedbackPrompt(feedback *model.PlayerFeedback, survey *model.Survey) string {
+	topic := ""
+	if survey != nil {
+		topic = survey.Title
+		if survey.Intent != "" {
+			topic += " - " + survey.Intent
+		}
+	}
+
+	answersStr := "\n- none"
+	if len(feedback.StrongestAnswers) > 0 {
+		answersStr = ""
+		for _, a := range feedback.StrongestAnswers {
+			answersStr += fmt.Sprintf("\n- [%s] %s\n  Answer: %s", a.QuestionKey, a.Prompt, s.promptAnswer(a.Excerpt))
+		}
+	}
+	themesStr := "none"
+	if len(feedback.Themes) > 0 {
+		themesStr = strings.Join(feedback.Themes, ", ")
+	}
+
+	return fmt.Sprintf(`Write the closing message a survey participant sees when the session ends. Return ONLY valid JSON:
+{"message": "one paragraph"}
+
+Survey: %s
+Participant nickname: %s
+Questions answered: %d
+
+Their strongest answers:%s
+
+Themes they raised: %s
+
+Write 2-4 warm, plain sentences addressed to the participant by nickname: thank them, point to one specific
+thing they contributed, and say what that input helps the organizers understand. Do not mention points,
+scores or ranks. No markdown.`,
+		topic, feedback.Nickname, feedback.Answered, answersStr, themesStr)
+}
+
+func (s *EvaluatorService) buildComparisonPrompt(report *model.ComparisonReport, baselineEvidence, evidence map[string][]string, guardrails *model.Guardrails) string {
+	stats := report.Stats
+	statsStr := fmt.Sprintf("\n- Players: %.0f -> %.0f\n- Completion rate: %.1f%% -> %.1f%%\n- Skip rate: %.1f%% -> %.1f%%",
+		stats.Players.Baseline, stats.Players.Value, stats.CompletionRate.Baseline*100, stats.CompletionRate.Value*100,
+		stats.SkipRate.Baseline*100, stats.SkipRate.Value*100)
+	if stats.SatRate != nil {
+		statsStr += fmt.Sprintf("\n- Satisfactory answers: %.1f%% -> %.1f%%", stats.SatRate.Baseline*100, stats.SatRate.Value*100)
+	}
+	if stats.AvgRating != nil {
+		statsStr += fmt.Sprintf("\n- Average rating: %.2f -> %.2f", stats.AvgRating.Baseline, stats.AvgRating.Value)
+	}
+
+	questionsStr := "\n- none"
+	if len(report.SatisfactionDeltas) > 0 {
+		questionsStr = ""
+		for _, d := range report.SatisfactionDeltas {
+			questionsStr += fmt.Sprintf("\n- %s: skip rate %.1f%% -> %.1f%%", d.QuestionKey, d.SkipRate.Baseline*100, d.SkipRate.Value*100)
+			if d.SatRate != nil {
+				questionsStr += fmt.Sprintf(", satisfactory %.1f%% -> %.1f%%", d.SatRate.Baseline*100, d.SatRate.Value*100)
+			}
+			if d.AvgRating != nil {
+				questionsStr += fmt.Sprintf(", rating %.2f -> %.2f", d.AvgRating.Baseline, d.AvgRating.Value)
+			}
+		}
+	}
+
+	themesStr := "\n- none"
+	if len(report.ThemeShifts) > 0 {
+		themesStr = ""
+		for _, t := range report.ThemeShifts {
+			themesStr += fmt.Sprintf("\n- %s (%s): %.1f%% -> %.1f%% of theme mentions", t.Theme, t.Change, t.BaselineShare*100, t.Share*100)
+		}
+	}
+
+	frictionStr := "\n- none"
+	if len(report.NewFrictionPoints) > 0 {
+		frictionStr = ""
+		for _, f := range report.NewFrictionPoints {
+			frictionStr += fmt.Sprintf("\n- %s: skip %.1f%% (was %.1f%%), unsatisfactory %.1f%% (was %.1f%%)",
+				f.QuestionKey, f.SkipRate*100, f.BaselineSkipRate*100, f.UnsatRate*100, f.BaselineUnsatRate*100)
+		}
+	}
+
+	formatEvidence := func(samples map[string][]string) string {
+		keys := make([]string, 0, len(samples))
+		for k := range samples {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		out := ""
+		for _, k := range keys {
+			out += fmt.Sprintf("\n%s:\n- %s", k, strings.Join(samples[k], "\n- "))
+		}
+		if out == "" {
+			return "\n- none"
+		}
+		return out
+	}
+
+	return fmt.Sprintf(`Compare two sessions of the same survey: a baseline session and a later one run after the host made changes. Return ONLY valid JSON:
+{
+  "summary": ["finding 1", "finding 2", "finding 3"],
+  "verdict": "improved|mixed|worse|unchanged",
+  "themeNotes": [{"theme": "theme from the shifts below", "note": "what the shift likely means"}],
+  "frictionReasons": [{"questionKey": "Q1", "hypothesizedReason": "why this question now causes friction"}],
+  "recommendations": ["what to change or keep next time"]
+}
+
+Overall (baseline -> later):%s
+
+Per question (baseline -> later):%s
+
+Theme shifts (share of all theme mentions, baseline -> later):%s
+
+New friction points (high skip or unsatisfactory rate in the later session only):%s
+
+Baseline evidence samples:%s
+
+Later evidence samples:%s
+
+%sThe measured numbers above are final; explain them rather than restate them. Say whether the changes had impact,
+noting when player counts are too small to tell. Only write notes for listed themes and reasons for listed friction
+points. Recommendations must follow the host guardrails, if any.`,
+		statsStr, questionsStr, themesStr, frictionStr, formatEvidence(baselineEvidence), formatEvidence(evidence), formatGuardrails(guardrails))
+}
+
+func (s *EvaluatorService) buildDigestPrompt(digest *model.Digest) string {
+	roomsStr := ""
+	for _, r := range digest.Rooms {
+		survey := r.SurveyTitle
+		if survey == "" {
+			survey = r.SurveyID
+		}
+		roomsStr += fmt.Sprintf("\n- %s (%s, ended %s): %d players, %.0f%% completion, %.0f%% skipped",
+			r.RoomCode, survey, r.EndedAt.Format("Mon Jan 2"), r.Players, r.CompletionRate*100, r.SkipRate*100)
+		if len(r.TopThemes) > 0 {
+			roomsStr += "; themes: " + strings.Join(r.TopThemes, ", ")
+		}
+	}
+	themes := make([]string, 0, len(digest.TopThemes))
+	for _, t := range digest.TopThemes {
+		themes = append(themes, fmt.Sprintf("%s (%d)", t.Theme, t.Count))
+	}
+	themesStr := "none"
+	if len(themes) > 0 {
+		themesStr = strings.Join(themes, ", ")
+	}
+	changesStr := "\n- none"
+	if len(digest.Changes) > 0 {
+		changesStr = "\n- " + strings.Join(digest.Changes, "\n- ")
+	}
+
+	return fmt.Sprintf(`Write the highlights of a weekly executive digest of survey sessions. Return ONLY valid JSON:
+{"highlights": ["highlight 1", "highlight 2", "highlight 3"]}
+
+Week: %s to %s
+Totals: %d rooms, %d players, %.0f%% completion
+Top themes: %s
+
+Rooms:%s
+
+Changes against the week before:%s
+
+Write 3-5 short, plain highlights for a busy executive: what stood out across the rooms, which themes
+dominated and what changed since last week. Use only the numbers above. No markdown.`,
+		digest.WeekStart.Format("Jan 2"), digest.WeekEnd.Add(-time.Second).Format("Jan 2"),
+		len(digest.Rooms), digest.Players, digest.CompletionRate*100, themesStr, roomsStr, changesStr)
+}
+
+// Mock implementations. Results vary with the answer text (but are stable for
+// the same text) so practice rooms and demos look like a real session.
+
+// Canned variety for mock evaluations and follow-ups
+var (
+	mockThemes = []string{"ease of use", "pricing", "performance", "support experience", "onboarding",
+		"reliability", "missing features", "design", "team workflow", "documentation"}
+	mockMissing = [][]string{
+		{"specifics", "examples"},
+		{"concrete example"},
+		{"impact on your work"},
+		{"how often it happens"},
+		{"what you tried instead"},
+	}
+	mockSummaries = []string{
+		"Shares a clear opinion with some supporting detail.",
+		"Points to a specific pain point in their day-to-day use.",
+		"Gives a general impression without much detail.",
+		"Compares the experience with an alternative they used before.",
+		"Describes a recent situation that shaped their view.",
+	}
+	mockFollowUps = []string{
+		"Could you please elaborate with more specific details?",
+		"Can you walk us through the last time that happened?",
+		"What would have made that experience better for you?",
+		"How does that affect the rest of your work?",
+		"What did you try before, and how did it compare?",
+	}
+	mockPositiveWords = []string{"good", "great", "love", "like", "easy", "fast", "helpful", "nice", "excellent", "enjoy"}
+	mockNegativeWords = []string{"bad", "slow", "hate", "hard", "confusing", "broken", "annoying", "expensive", "poor", "difficult"}
+)
+
+// mockPick returns a stable index into a list of n items for text
+func mockPick(text string, salt uint32, n int) int {
+	h := fnv.New32a()
+	h.Write([]byte(text))
+	return int((h.Sum32() ^ salt) % uint32(n))
 }
 
-// Mock implementations
 func (s *EvaluatorService) mockEvaluate(question *model.Question, answer *model.Answer) *model.EvaluationResult {
-	wordCount := len(strings.Fields(answer.TextAnswer))
+	words := strings.Fields(strings.ToLower(answer.TextAnswer))
+	wordCount := len(words)
 	// Leniency adjustment: Basic answer (5-10 words) gets ~0.5-0.7, elaboration gets higher
 	quality := float64(wordCount) / 15.0
 	if quality > 1.0 {
@@ -464,19 +1306,51 @@ func (s *EvaluatorService) mockEvaluate(question *model.Question, answer *model.
 		resolution = "SAT"
 	}
 
+	// Crude sentiment from a few telltale words
+	sentiment := 0.0
+	for _, w := range words {
+		w = strings.Trim(w, ".,!?;:")
+		for _, p := range mockPositiveWords {
+			if w == p {
+				sentiment += 0.3
+			}
+		}
+		for _, n := range mockNegativeWords {
+			if w == n {
+				sentiment -= 0.3
+			}
+		}
+	}
+	sentiment = math.Max(-1, math.Min(1, sentiment))
+
+	text := answer.TextAnswer
+	themes := []string{mockThemes[mockPick(text, 0, len(mockThemes))]}
+	if wordCount >= 10 {
+		if second := mockThemes[mockPick(text, 0x9e3779b9, len(mockThemes))]; second != themes[0] {
+			themes = append(themes, second)
+		}
+	}
+	missing := mockMissing[mockPick(text, 0x85ebca6b, len(mockMissing))]
+	hint := "clarify"
+	if resolution == "SAT" {
+		missing = nil
+		hint = "deepen"
+	}
+
 	return &model.EvaluationResult{
 		Resolution:   resolution,
 		QualityScore: quality,
 		Signals: model.Signals{
-			Themes:             []string{"general response"},
-			Missing:            []string{"specifics", "examples"},
+			Themes:             themes,
+			Missing:            missing,
 			Specificity:        quality,
-			Clarity:            quality,
-			Sentiment:          0.0,
+			Clarity:            math.Min(1, quality+0.1),
+			Sentiment:          sentiment,
 			ConfidenceLanguage: quality,
-			Summary:            "Mock evaluation based on response length.",
+			Summary:            mockSummaries[mockPick(text, 0xc2b2ae35, len(mockSummaries))],
 		},
-		FollowUpHint: "clarify",
+		FollowUpHint: hint,
+		Fallback:     true,
 	}
 }
 
@@ -485,11 +1359,24 @@ func (s *EvaluatorService) mockFollowUp(question *model.Question, nextKey string
 		Key:       nextKey,
 		ParentKey: baseKey,
 		Type:      model.QuestionTypeEssay,
-		Prompt:    "Could you please elaborate with more specific details?",
+		Prompt:    mockFollowUps[mockPick(nextKey+question.Prompt, 0, len(mockFollowUps))],
 		Rubric:    "Looking for concrete examples.",
 		PointsMax: question.PointsMax / 2,
 		Threshold: question.Threshold,
+		Media:     question.Media,
+		AI:        question.AI,
+	}
+}
+
+func (s *EvaluatorService) mockScopeAnchor(survey *model.Survey, hostNotes string) *model.ScopeAnchor {
+	summary := survey.Intent
+	if summary == "" {
+		summary = survey.Title
+	}
+	if hostNotes != "" {
+		summary = strings.TrimSpace(summary + " " + hostNotes)
 	}
+	return &model.ScopeAnchor{Summary: summary}
 }
 
 func (s *EvaluatorService) mockPool(question *model.Question) *model.FollowUpPool {
@@ -518,6 +1405,122 @@ func (s *EvaluatorService) mockReport(snapshot *model.RoomSnapshot) *model.AIRep
 	}
 }
 
+// mockComparison reads the measured comparison without AI
+func (s *EvaluatorService) mockComparison(report *model.ComparisonReport) {
+	stats := report.Stats
+	report.Verdict = mockComparisonVerdict(stats)
+	report.Summary = []string{
+		fmt.Sprintf("Completion went from %.0f%% to %.0f%% and skips from %.0f%% to %.0f%%",
+			stats.CompletionRate.Baseline*100, stats.CompletionRate.Value*100, stats.SkipRate.Baseline*100, stats.SkipRate.Value*100),
+	}
+	if stats.SatRate != nil {
+		report.Summary = append(report.Summary, fmt.Sprintf("Satisfactory answers went from %.0f%% to %.0f%%", stats.SatRate.Baseline*100, stats.SatRate.Value*100))
+	}
+	if len(report.NewFrictionPoints) > 0 {
+		report.Summary = append(report.Summary, fmt.Sprintf("%d question(s) show new friction, starting with %s", len(report.NewFrictionPoints), report.NewFrictionPoints[0].QuestionKey))
+	}
+	report.Summary = append(report.Summary, "Mock comparison - enable Gemini for real insights")
+	report.Recommendations = []string{}
+	for _, f := range report.NewFrictionPoints {
+		report.Recommendations = append(report.Recommendations, fmt.Sprintf("Review the changes to %s", f.QuestionKey))
+	}
+}
+
+// mockComparisonVerdict weighs completion, skip and satisfactory-answer changes
+func mockComparisonVerdict(stats *model.ComparisonStats) string {
+	const noise = 0.05
+	better, worse := 0, 0
+	tally := func(delta float64) {
+		if delta >= noise {
+			better++
+		} else if delta <= -noise {
+			worse++
+		}
+	}
+	tally(stats.CompletionRate.Delta)
+	tally(-stats.SkipRate.Delta)
+	if stats.SatRate != nil {
+		tally(stats.SatRate.Delta)
+	}
+	switch {
+	case better > 0 && worse > 0:
+		return model.ComparisonMixed
+	case better > 0:
+		return model.ComparisonImproved
+	case worse > 0:
+		return model.ComparisonWorse
+	}
+	return model.ComparisonUnchanged
+}
+
+// mockDigestHighlights builds digest highlights from the week's totals without AI
+func (s *EvaluatorService) mockDigestHighlights(digest *model.Digest) []string {
+	highlights := []string{fmt.Sprintf("%d rooms reached %d players with %.0f%% completion", len(digest.Rooms), digest.Players, digest.CompletionRate*100)}
+	if len(digest.TopThemes) > 0 {
+		highlights = append(highlights, fmt.Sprintf("Most raised theme: %s", digest.TopThemes[0].Theme))
+	}
+	if len(digest.Rooms) > 1 {
+		largest := digest.Rooms[0]
+		for _, r := range digest.Rooms[1:] {
+			if r.Players > largest.Players {
+				largest = r
+			}
+		}
+		highlights = append(highlights, fmt.Sprintf("Largest room: %s with %d players", largest.RoomCode, largest.Players))
+	}
+	return highlights
+}
+
+func (s *EvaluatorService) mockPlayerFeedback(feedback *model.PlayerFeedback) string {
+	message := fmt.Sprintf("Thanks for taking part, %s!", feedback.Nickname)
+	switch {
+	case len(feedback.Themes) > 0:
+		message += fmt.Sprintf(" Your thoughts on %s help the organizers see what matters most to participants.", feedback.Themes[0])
+	case feedback.Answered > 0:
+		message += fmt.Sprintf(" Your %d answers help the organizers see what matters most to participants.", feedback.Answered)
+	default:
+		message += " We hope to hear more from you next time."
+	}
+	return message
+}
+
+// mockCopilotHints turns friction points, contrasts and probes into hints without AI
+func (s *EvaluatorService) mockCopilotHints(memory *model.RoomMemory) []model.CopilotHint {
+	hints := []model.CopilotHint{}
+	for _, fp := range memory.FrictionPoints {
+		if len(hints) == 2 {
+			break
+		}
+		if fp.SkipRate >= fp.UnsatRate {
+			hints = append(hints, model.CopilotHint{
+				Text:        fmt.Sprintf("%s is being skipped a lot (%.0f%%) - consider restating it or explaining why it matters", fp.QuestionKey, fp.SkipRate*100),
+				QuestionKey: fp.QuestionKey,
+			})
+		} else {
+			hints = append(hints, model.CopilotHint{
+				Text:        fmt.Sprintf("Many answers to %s fall short (%.0f%%) - consider giving an example of the detail you are after", fp.QuestionKey, fp.UnsatRate*100),
+				QuestionKey: fp.QuestionKey,
+			})
+		}
+	}
+	if len(memory.Contrasts) > 0 {
+		c := memory.Contrasts[0]
+		hints = append(hints, model.CopilotHint{Text: fmt.Sprintf("The room is split on %s (%s vs %s) - invite both sides to explain", c.Axis, c.SideA, c.SideB)})
+	} else if len(memory.RecommendedProbes) > 0 {
+		hints = append(hints, model.CopilotHint{Text: "Worth raising with the room: " + memory.RecommendedProbes[0]})
+	}
+	if len(hints) == 0 {
+		hints = append(hints, model.CopilotHint{Text: "Answers are coming in without friction - keep the current pace"})
+	}
+	if len(hints) > maxCopilotHints {
+		hints = hints[:maxCopilotHints]
+	}
+	return hints
+}
+
+// maxCondenseProbes caps the candidate probes sent to the condensation prompt
+const maxCondenseProbes = 30
+
 // CondenseProbes takes a list of raw follow-up suggestions and selects the best ones for a new survey
 func (s *EvaluatorService) CondenseProbes(ctx context.Context, probes []string, intent string) ([]model.BaseQuestion, error) {
 	if !s.config.IsEnabled() {
@@ -525,15 +1528,11 @@ func (s *EvaluatorService) CondenseProbes(ctx context.Context, probes []string,
 	}
 
 	prompt := s.buildCondenseProbesPrompt(probes, intent)
-	response, err := s.callGemini(ctx, s.config.Models.PoolGen, prompt) // Use PoolGen model or similar
-	if err != nil {
-		return s.mockCondenseProbes(), nil
-	}
-
 	var result struct {
 		Questions []model.BaseQuestion `json:"questions"`
 	}
-	if err := json.Unmarshal([]byte(response), &result); err != nil {
+	// Use PoolGen model or similar
+	if err := s.callGeminiJSON(ctx, aiTemplateCondenseProbes, s.config.Models.PoolGen, prompt, nil, &result); err != nil {
 		fmt.Printf("[CondenseProbes] JSON Error: %v\n", err)
 		return s.mockCondenseProbes(), nil
 	}
@@ -543,8 +1542,8 @@ func (s *EvaluatorService) CondenseProbes(ctx context.Context, probes []string,
 
 func (s *EvaluatorService) buildCondenseProbesPrompt(probes []string, intent string) string {
 	probesStr := ""
-	if len(probes) > 20 {
-		probes = probes[:20] // Limit context window
+	if len(probes) > maxCondenseProbes {
+		probes = probes[:maxCondenseProbes] // Limit context window
 	}
 	probesStr = strings.Join(probes, "\n- ")
 
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// geminiStub answers scope anchor and scope check calls and streams a follow-up
+type geminiStub struct {
+	inScope bool
+}
+
+func (g *geminiStub) Do(req *http.Request) (*http.Response, error) {
+	body, _ := io.ReadAll(req.Body)
+	prompt := string(body)
+
+	if strings.Contains(req.URL.Path, ":streamGenerateContent") {
+		full := `{"followUps":[{"type":"ESSAY","prompt":"Which part of the battery life matters most to you?","pointsMax":50,"threshold":0.5,"reason_in_scope":"about the battery"}]}`
+		var sse strings.Builder
+		for _, chunk := range []string{full[:40], full[40:70], full[70:]} {
+			fmt.Fprintf(&sse, "data: %s\r\n\r\n", geminiText(chunk))
+		}
+		return stubResponse(sse.String()), nil
+	}
+
+	switch {
+	case strings.Contains(prompt, "defining the scope"):
+		return stubResponse(geminiText(`{"summary":"How players feel about the phone","inScope":["battery"],"outOfScope":["politics"]}`)), nil
+	case strings.Contains(prompt, "stays within the survey scope"):
+		return stubResponse(geminiText(fmt.Sprintf(`{"inScope":%t,"reason":"stub"}`, g.inScope))), nil
+	}
+	return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+}
+
+// geminiText wraps text as a compact Gemini generateContent response
+func geminiText(text string) string {
+	out, _ := json.Marshal(map[string]interface{}{
+		"candidates": []interface{}{map[string]interface{}{
+			"content": map[string]interface{}{"parts": []interface{}{map[string]string{"text": text}}},
+		}},
+	})
+	return string(out)
+}
+
+func stubResponse(body string) *http.Response {
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+type stubSurveyRepo struct {
+	repository.SurveyRepo
+	survey *model.Survey
+}
+
+func (r *stubSurveyRepo) GetByID(ctx context.Context, id string) (*model.Survey, error) {
+	return r.survey, nil
+}
+
+type stubRoomRepo struct {
+	repository.RoomRepo
+}
+
+func (r *stubRoomRepo) Create(ctx context.Context, room *model.Room) error {
+	return nil
+}
+
+func TestFollowUpPartialsInScopedRoom(t *testing.T) {
+	tests := []struct {
+		name     string
+		inScope  bool
+		partials bool // partials reach the player
+	}{
+		{name: "in scope", inScope: true, partials: true},
+		{name: "out of scope", inScope: false, partials: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GEMINI_API_KEY", "test-key")
+			t.Setenv("GEMINI_STREAM_FOLLOWUPS", "true")
+			evaluator := NewEvaluatorService()
+			evaluator.SetHTTPClient(&geminiStub{inScope: tt.inScope})
+
+			question := &model.Question{Key: "Q1", Type: model.QuestionTypeEssay, Prompt: "What do you like about the phone?", Threshold: 0.5, PointsMax: 100}
+			survey := &model.Survey{Title: "Phone", Intent: "Learn what players like about the phone", Questions: []model.BaseQuestion{{Key: "Q1", Type: model.QuestionTypeEssay, Prompt: question.Prompt}}}
+			roomSvc := NewRoomService(&stubRoomRepo{}, &stubSurveyRepo{survey: survey}, cache.NewMemoryRoomCache(), nil, nil)
+			roomSvc.SetEvaluator(evaluator)
+
+			room, err := roomSvc.CreateRoom(context.Background(), "survey-1", "host-1", &model.RoomSettings{}, "", "", nil)
+			if err != nil {
+				t.Fatalf("CreateRoom: %v", err)
+			}
+			if room.ScopeAnchor == nil {
+				t.Fatal("room has no scope anchor")
+			}
+
+			var partials []string
+			followUp, err := evaluator.GenerateFollowUpStreaming(WithAIRoom(context.Background(), room.Code), question, &model.Player{}, &model.EvaluationResult{},
+				"The battery lasts two days", nil, nil, nil, survey.Intent, room.ScopeAnchor, nil, "Q1.1", "Q1", nil,
+				func(prompt string) { partials = append(partials, prompt) })
+			if err != nil {
+				t.Fatalf("GenerateFollowUpStreaming: %v", err)
+			}
+
+			if !tt.partials {
+				if followUp != nil || len(partials) != 0 {
+					t.Fatalf("rejected follow-up: got %v with partials %q, want none", followUp, partials)
+				}
+				return
+			}
+			if followUp == nil {
+				t.Fatal("no follow-up generated")
+			}
+			if len(partials) == 0 {
+				t.Fatal("no partials arrived")
+			}
+			if last := partials[len(partials)-1]; last != followUp.Prompt {
+				t.Errorf("last partial = %q, want %q", last, followUp.Prompt)
+			}
+		})
+	}
+}
@@ -0,0 +1,600 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Event limits
+const (
+	defaultEventLeaderboard = 100
+	maxEventLeaderboard     = 1000
+	maxEventThemes          = 10
+	maxEventProbes          = 5 // misunderstandings and best probes kept per merged question
+	eventReportTimeout      = 2 * time.Minute
+)
+
+// ErrInvalidEventGroup is returned for events with a bad name or rooms
+var ErrInvalidEventGroup = errors.New("invalid event")
+
+// EventGroupService groups rooms of one survey into an event, e.g. conference
+// breakout groups, and combines their leaderboards, analytics and AI report.
+// Ended rooms are read from their snapshots, live rooms from Redis.
+type EventGroupService struct {
+	groupRepo      repository.EventGroupRepo
+	roomRepo       repository.RoomRepo
+	surveyRepo     repository.SurveyRepo
+	reportRepo     repository.ReportRepo
+	answerRepo     repository.AnswerRepo
+	analyticsCache cache.AnalyticsCache
+	leaderboard    cache.LeaderboardCache
+	playerCache    cache.PlayerCache
+	reportSvc      *ReportService
+	evaluator      *EvaluatorService
+}
+
+// NewEventGroupService creates a new event service
+func NewEventGroupService(
+	groupRepo repository.EventGroupRepo,
+	roomRepo repository.RoomRepo,
+	surveyRepo repository.SurveyRepo,
+	reportRepo repository.ReportRepo,
+	answerRepo repository.AnswerRepo,
+	analyticsCache cache.AnalyticsCache,
+	leaderboard cache.LeaderboardCache,
+	playerCache cache.PlayerCache,
+	reportSvc *ReportService,
+	evaluator *EvaluatorService,
+) *EventGroupService {
+	return &EventGroupService{
+		groupRepo:      groupRepo,
+		roomRepo:       roomRepo,
+		surveyRepo:     surveyRepo,
+		reportRepo:     reportRepo,
+		answerRepo:     answerRepo,
+		analyticsCache: analyticsCache,
+		leaderboard:    leaderboard,
+		playerCache:    playerCache,
+		reportSvc:      reportSvc,
+		evaluator:      evaluator,
+	}
+}
+
+// Create saves a new event over the host's rooms
+func (s *EventGroupService) Create(ctx context.Context, hostID string, req *model.EventGroupRequest) (*model.EventGroup, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || utf8.RuneCountInString(name) > model.MaxEventGroupNameChars {
+		return nil, fmt.Errorf("%w: name is required (max %d characters)", ErrInvalidEventGroup, model.MaxEventGroupNameChars)
+	}
+	group := &model.EventGroup{HostID: hostID, Name: name, RoomCodes: []string{}}
+	for _, code := range req.RoomCodes {
+		if err := s.addRoom(ctx, group, code); err != nil {
+			return nil, err
+		}
+	}
+
+	id, err := s.groupRepo.Create(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	group.ID = id
+	return group, nil
+}
+
+// Get returns one of the host's events; nil if it does not exist
+func (s *EventGroupService) Get(ctx context.Context, hostID, groupID string) (*model.EventGroup, error) {
+	return s.groupRepo.Get(ctx, hostID, groupID)
+}
+
+// List returns the host's events, newest first
+func (s *EventGroupService) List(ctx context.Context, hostID string) ([]*model.EventGroup, error) {
+	return s.groupRepo.ListByHost(ctx, hostID)
+}
+
+// Delete removes one of the host's events; its rooms are kept. Returns false if none matched.
+func (s *EventGroupService) Delete(ctx context.Context, hostID, groupID string) (bool, error) {
+	return s.groupRepo.Delete(ctx, hostID, groupID)
+}
+
+// AddRoom adds one of the host's rooms to the event; nil if the event does not exist
+func (s *EventGroupService) AddRoom(ctx context.Context, hostID, groupID, roomCode string) (*model.EventGroup, error) {
+	group, err := s.groupRepo.Get(ctx, hostID, groupID)
+	if err != nil || group == nil {
+		return nil, err
+	}
+	if err := s.addRoom(ctx, group, roomCode); err != nil {
+		return nil, err
+	}
+	if err := s.groupRepo.SetRooms(ctx, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// RemoveRoom takes a room out of the event; nil if the event does not exist
+func (s *EventGroupService) RemoveRoom(ctx context.Context, hostID, groupID, roomCode string) (*model.EventGroup, error) {
+	group, err := s.groupRepo.Get(ctx, hostID, groupID)
+	if err != nil || group == nil {
+		return nil, err
+	}
+	codes := make([]string, 0, len(group.RoomCodes))
+	for _, code := range group.RoomCodes {
+		if code != roomCode {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == len(group.RoomCodes) {
+		return nil, fmt.Errorf("%w: room %s is not part of the event", ErrInvalidEventGroup, roomCode)
+	}
+	group.RoomCodes = codes
+	if len(codes) == 0 {
+		group.SurveyID = ""
+	}
+	if err := s.groupRepo.SetRooms(ctx, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// addRoom checks a room belongs to the host and runs the event's survey, then appends it
+func (s *EventGroupService) addRoom(ctx context.Context, group *model.EventGroup, roomCode string) error {
+	roomCode = strings.TrimSpace(roomCode)
+	for _, code := range group.RoomCodes {
+		if code == roomCode {
+			return fmt.Errorf("%w: room %s is already part of the event", ErrInvalidEventGroup, roomCode)
+		}
+	}
+	if len(group.RoomCodes) >= model.MaxEventGroupRooms {
+		return fmt.Errorf("%w: at most %d rooms per event", ErrInvalidEventGroup, model.MaxEventGroupRooms)
+	}
+
+	room, err := s.roomRepo.GetByCode(ctx, roomCode)
+	if err != nil {
+		return err
+	}
+	if room == nil || room.HostID != group.HostID {
+		return fmt.Errorf("%w: room %s not found", ErrInvalidEventGroup, roomCode)
+	}
+	if room.Settings.Practice {
+		return fmt.Errorf("%w: practice room %s cannot join an event", ErrInvalidEventGroup, roomCode)
+	}
+	if group.SurveyID != "" && room.SurveyID != group.SurveyID {
+		return fmt.Errorf("%w: room %s runs a different survey", ErrInvalidEventGroup, roomCode)
+	}
+	group.SurveyID = room.SurveyID
+	group.RoomCodes = append(group.RoomCodes, roomCode)
+	return nil
+}
+
+// eventRoom is one room's data as the event sees it
+type eventRoom struct {
+	room     *model.Room
+	snapshot *model.RoomSnapshot // nil while the room is live
+}
+
+// loadRooms reads the event's rooms with the snapshots of the ended ones
+func (s *EventGroupService) loadRooms(ctx context.Context, group *model.EventGroup) ([]eventRoom, error) {
+	rooms := make([]eventRoom, 0, len(group.RoomCodes))
+	for _, code := range group.RoomCodes {
+		room, err := s.roomRepo.GetByCode(ctx, code)
+		if err != nil {
+			return nil, err
+		}
+		if room == nil {
+			continue // deleted since it was added
+		}
+		er := eventRoom{room: room}
+		if room.Status == model.RoomStatusEnded {
+			if er.snapshot, err = s.reportRepo.GetSnapshot(ctx, code); err != nil {
+				return nil, err
+			}
+		}
+		rooms = append(rooms, er)
+	}
+	return rooms, nil
+}
+
+// Leaderboard ranks every player of the event's rooms together; nil if the event does not exist.
+// Player IDs are prefixed with their room code, since IDs are only unique per room.
+func (s *EventGroupService) Leaderboard(ctx context.Context, hostID, groupID string, limit int) (*model.EventLeaderboard, error) {
+	group, err := s.groupRepo.Get(ctx, hostID, groupID)
+	if err != nil || group == nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultEventLeaderboard
+	}
+	if limit > maxEventLeaderboard {
+		limit = maxEventLeaderboard
+	}
+	rooms, err := s.loadRooms(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []model.EventLeaderboardEntry
+	for _, er := range rooms {
+		code := er.room.Code
+		nicknames := make(map[string]string)
+		var standings []model.LeaderboardEntry
+		if er.snapshot != nil {
+			for _, c := range er.snapshot.PlayerCompletion {
+				nicknames[c.PlayerID] = c.Nickname
+			}
+			standings = er.snapshot.Leaderboard
+		} else {
+			top, err := s.leaderboard.GetTop(ctx, code, maxEventLeaderboard)
+			if err != nil {
+				return nil, err
+			}
+			for _, e := range top {
+				standings = append(standings, model.LeaderboardEntry{PlayerID: e.PlayerID, Score: e.Score, CompletionSec: e.CompletionSec})
+			}
+		}
+		if players, err := s.playerCache.GetAllPlayers(ctx, code); err == nil {
+			for id, p := range players {
+				nicknames[id] = p.Nickname
+			}
+		}
+		for _, e := range standings {
+			nickname := e.Nickname
+			if nickname == "" {
+				nickname = nicknames[e.PlayerID]
+			}
+			entries = append(entries, model.EventLeaderboardEntry{
+				PlayerID:      code + ":" + e.PlayerID,
+				RoomCode:      code,
+				Nickname:      nickname,
+				Score:         e.Score,
+				CompletionSec: e.CompletionSec,
+			})
+		}
+	}
+
+	// Points first, then the faster finish, like the room leaderboard
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		if entries[i].CompletionSec != entries[j].CompletionSec {
+			return entries[i].CompletionSec < entries[j].CompletionSec
+		}
+		return entries[i].PlayerID < entries[j].PlayerID
+	})
+	for i := range entries {
+		entries[i].Rank = i + 1
+		if i > 0 && entries[i-1].Score == entries[i].Score {
+			entries[i-1].Tied = true
+			entries[i].Tied = true
+		}
+	}
+
+	board := &model.EventLeaderboard{EventID: group.ID, TotalPlayers: len(entries), Entries: entries}
+	if len(board.Entries) > limit {
+		board.Entries = board.Entries[:limit]
+	}
+	if board.Entries == nil {
+		board.Entries = []model.EventLeaderboardEntry{}
+	}
+	return board, nil
+}
+
+// Analytics aggregates the event's rooms; nil if the event does not exist
+func (s *EventGroupService) Analytics(ctx context.Context, hostID, groupID string) (*model.EventAnalytics, error) {
+	group, err := s.groupRepo.Get(ctx, hostID, groupID)
+	if err != nil || group == nil {
+		return nil, err
+	}
+	analytics, _, err := s.aggregate(ctx, group)
+	return analytics, err
+}
+
+// aggregate merges the rooms' question profiles, themes and completion; it also
+// returns the merged room memory for the report prompt
+func (s *EventGroupService) aggregate(ctx context.Context, group *model.EventGroup) (*model.EventAnalytics, *model.RoomMemory, error) {
+	rooms, err := s.loadRooms(ctx, group)
+	if err != nil {
+		return nil, nil, err
+	}
+	var questionKeys []string
+	if group.SurveyID != "" {
+		if survey, err := s.surveyRepo.GetByID(ctx, group.SurveyID); err == nil && survey != nil {
+			for _, q := range survey.Questions {
+				questionKeys = append(questionKeys, q.Key)
+			}
+		}
+	}
+
+	analytics := &model.EventAnalytics{
+		EventID:   group.ID,
+		SurveyID:  group.SurveyID,
+		Rooms:     []model.EventRoomSummary{},
+		Questions: []model.QuestionProfile{},
+		Themes:    []model.ThemeCount{},
+	}
+	memory := &model.RoomMemory{RoomCode: group.ID}
+	merged := make(map[string]*model.QuestionProfile)
+	themes := make(map[string]int)
+	var completed float64
+	var answers, skips int
+
+	for _, er := range rooms {
+		summary := model.EventRoomSummary{RoomCode: er.room.Code, Status: er.room.Status}
+		var profiles []model.QuestionProfile
+		var roomMemory *model.RoomMemory
+		if er.snapshot != nil {
+			profiles = er.snapshot.QuestionProfiles
+			roomMemory = &er.snapshot.Memory
+			summary.Players = er.snapshot.TotalPlayers
+			summary.CompletionRate = er.snapshot.CompletionRate
+			summary.SkipRate = er.snapshot.OverallSkipRate
+		} else {
+			for _, key := range questionKeys {
+				if p, err := s.analyticsCache.GetQuestionProfile(ctx, er.room.Code, key); err == nil && p != nil {
+					profiles = append(profiles, *p)
+				}
+			}
+			roomMemory, _ = s.analyticsCache.GetRoomMemory(ctx, er.room.Code)
+			if players, err := s.playerCache.GetAllPlayers(ctx, er.room.Code); err == nil {
+				summary.Players = len(players)
+			}
+			if roomMemory != nil {
+				summary.CompletionRate = roomMemory.CompletionRate
+			}
+			summary.SkipRate = profileSkipRate(profiles)
+		}
+
+		for i := range profiles {
+			p := &profiles[i]
+			answers += p.AnswerCount
+			skips += p.SkipCount
+			if merged[p.QuestionKey] == nil {
+				merged[p.QuestionKey] = &model.QuestionProfile{RoomCode: group.ID, QuestionKey: p.QuestionKey}
+			}
+			mergeQuestionProfile(merged[p.QuestionKey], p)
+		}
+		if roomMemory != nil {
+			for _, t := range roomMemory.GlobalThemesTop {
+				themes[t.Theme] += t.Count
+			}
+			memory.Contrasts = append(memory.Contrasts, roomMemory.Contrasts...)
+			memory.FrictionPoints = append(memory.FrictionPoints, roomMemory.FrictionPoints...)
+			memory.OutlierThemes = appendUnique(memory.OutlierThemes, roomMemory.OutlierThemes, maxEventThemes)
+			memory.RecommendedProbes = appendUnique(memory.RecommendedProbes, roomMemory.RecommendedProbes, maxEventThemes)
+			memory.TotalAnswers += roomMemory.TotalAnswers
+		}
+
+		analytics.TotalPlayers += summary.Players
+		completed += summary.CompletionRate * float64(summary.Players)
+		analytics.Rooms = append(analytics.Rooms, summary)
+	}
+
+	if analytics.TotalPlayers > 0 {
+		analytics.CompletionRate = completed / float64(analytics.TotalPlayers)
+	}
+	if answers > 0 {
+		analytics.OverallSkipRate = float64(skips) / float64(answers)
+	}
+
+	// Survey order first, then keys no longer in the survey
+	seen := make(map[string]bool, len(merged))
+	for _, key := range questionKeys {
+		if p := merged[key]; p != nil {
+			analytics.Questions = append(analytics.Questions, *p)
+			seen[key] = true
+		}
+	}
+	var extra []string
+	for key := range merged {
+		if !seen[key] {
+			extra = append(extra, key)
+		}
+	}
+	sort.Strings(extra)
+	for _, key := range extra {
+		analytics.Questions = append(analytics.Questions, *merged[key])
+	}
+
+	for theme, count := range themes {
+		analytics.Themes = append(analytics.Themes, model.ThemeCount{Theme: theme, Count: count})
+	}
+	sort.Slice(analytics.Themes, func(i, j int) bool {
+		if analytics.Themes[i].Count != analytics.Themes[j].Count {
+			return analytics.Themes[i].Count > analytics.Themes[j].Count
+		}
+		return analytics.Themes[i].Theme < analytics.Themes[j].Theme
+	})
+	if len(analytics.Themes) > maxEventThemes {
+		analytics.Themes = analytics.Themes[:maxEventThemes]
+	}
+
+	memory.GlobalThemesTop = analytics.Themes
+	memory.TotalPlayers = analytics.TotalPlayers
+	memory.CompletionRate = analytics.CompletionRate
+	memory.UpdatedAt = time.Now()
+	return analytics, memory, nil
+}
+
+// profileSkipRate is skips over answers across a room's question profiles
+func profileSkipRate(profiles []model.QuestionProfile) float64 {
+	var answers, skips int
+	for _, p := range profiles {
+		answers += p.AnswerCount
+		skips += p.SkipCount
+	}
+	if answers == 0 {
+		return 0
+	}
+	return float64(skips) / float64(answers)
+}
+
+// mergeQuestionProfile adds src's counters to dst. Response-time percentiles
+// and clusters cannot be combined and are left out.
+func mergeQuestionProfile(dst, src *model.QuestionProfile) {
+	dst.SatCount += src.SatCount
+	dst.UnsatCount += src.UnsatCount
+	dst.SkipCount += src.SkipCount
+	dst.AnswerCount += src.AnswerCount
+	dst.FollowUpTriggered += src.FollowUpTriggered
+	dst.FollowUpHelped += src.FollowUpHelped
+	dst.RatingSum += src.RatingSum
+	dst.RatingCount += src.RatingCount
+
+	dst.ThemeCounts = addCounts(dst.ThemeCounts, src.ThemeCounts)
+	dst.MissingCounts = addCounts(dst.MissingCounts, src.MissingCounts)
+	dst.RatingHist = addCounts(dst.RatingHist, src.RatingHist)
+	dst.OptionHist = addCounts(dst.OptionHist, src.OptionHist)
+	for row, cols := range src.MatrixHist {
+		if dst.MatrixHist == nil {
+			dst.MatrixHist = make(map[int]map[int]int)
+		}
+		dst.MatrixHist[row] = addCounts(dst.MatrixHist[row], cols)
+	}
+
+	if src.RankCount > 0 {
+		dst.RankCount += src.RankCount
+		dst.RankPositionSum = addCounts(dst.RankPositionSum, src.RankPositionSum)
+		dst.BordaScores = addCounts(dst.BordaScores, src.BordaScores)
+		dst.AvgPosition = make(map[int]float64, len(dst.RankPositionSum))
+		for opt, sum := range dst.RankPositionSum {
+			dst.AvgPosition[opt] = float64(sum) / float64(dst.RankCount)
+		}
+	}
+
+	if len(src.WordCounts) > 0 {
+		dst.WordCounts = addCounts(dst.WordCounts, src.WordCounts)
+		if dst.WordForms == nil {
+			dst.WordForms = make(map[string]string)
+		}
+		for stem, form := range src.WordForms {
+			if existing, ok := dst.WordForms[stem]; !ok || len(form) < len(existing) {
+				dst.WordForms[stem] = form
+			}
+		}
+		dst.WordCloud = wordCloud(dst.WordCounts, dst.WordForms, maxWordCloud)
+	}
+
+	dst.Misunderstandings = appendUnique(dst.Misunderstandings, src.Misunderstandings, maxEventProbes)
+	dst.BestProbes = appendUnique(dst.BestProbes, src.BestProbes, maxEventProbes)
+	if src.UpdatedAt.After(dst.UpdatedAt) {
+		dst.UpdatedAt = src.UpdatedAt
+	}
+}
+
+// addCounts adds src to dst, creating dst when needed
+func addCounts[K comparable](dst, src map[K]int) map[K]int {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[K]int, len(src))
+	}
+	for k, n := range src {
+		dst[k] += n
+	}
+	return dst
+}
+
+// appendUnique appends the items of src not yet in dst, up to limit items in total
+func appendUnique(dst, src []string, limit int) []string {
+	for _, item := range src {
+		if len(dst) >= limit {
+			break
+		}
+		dup := false
+		for _, existing := range dst {
+			if strings.EqualFold(existing, item) {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			dst = append(dst, item)
+		}
+	}
+	return dst
+}
+
+// TriggerReport marks the event's merged AI report as pending and generates it in
+// the background; nil if the event does not exist
+func (s *EventGroupService) TriggerReport(ctx context.Context, hostID, groupID string) (*model.AIReport, error) {
+	group, err := s.groupRepo.Get(ctx, hostID, groupID)
+	if err != nil || group == nil {
+		return nil, err
+	}
+	if len(group.RoomCodes) == 0 {
+		return nil, fmt.Errorf("%w: the event has no rooms", ErrInvalidEventGroup)
+	}
+	if group.Report != nil && (group.Report.Status == "pending" || group.Report.Status == "generating") {
+		return group.Report, nil
+	}
+
+	pending := &model.AIReport{RoomCode: group.ID, Status: "pending", CreatedAt: time.Now()}
+	if err := s.groupRepo.SetReport(ctx, group.ID, pending); err != nil {
+		return nil, err
+	}
+	go s.generateReport(group)
+	return pending, nil
+}
+
+// generateReport writes one AI report over the merged analytics and evidence of every room
+func (s *EventGroupService) generateReport(group *model.EventGroup) {
+	ctx, cancel := context.WithTimeout(context.Background(), eventReportTimeout)
+	defer cancel()
+
+	fail := func(err error) {
+		fmt.Printf("[Event] Report for event %s failed: %v\n", group.ID, err)
+		failed := &model.AIReport{RoomCode: group.ID, Status: "failed", CreatedAt: time.Now()}
+		if err := s.groupRepo.SetReport(ctx, group.ID, failed); err != nil {
+			fmt.Printf("[Event] Failed to store report status for %s: %v\n", group.ID, err)
+		}
+	}
+
+	analytics, memory, err := s.aggregate(ctx, group)
+	if err != nil {
+		fail(err)
+		return
+	}
+	snapshot := &model.RoomSnapshot{
+		RoomCode:         group.ID,
+		SurveyID:         group.SurveyID,
+		EndedAt:          time.Now(),
+		QuestionProfiles: analytics.Questions,
+		Memory:           *memory,
+		TotalPlayers:     analytics.TotalPlayers,
+		CompletionRate:   analytics.CompletionRate,
+		OverallSkipRate:  analytics.OverallSkipRate,
+	}
+
+	answers, err := s.answerRepo.GetByRoomCodes(ctx, group.RoomCodes)
+	if err != nil {
+		fail(err)
+		return
+	}
+	evidence, sampling := s.reportSvc.sampleEvidence(answers)
+
+	var guardrails *model.Guardrails
+	if survey, err := s.surveyRepo.GetByID(ctx, group.SurveyID); err == nil && survey != nil {
+		guardrails = survey.Guardrails
+	}
+	report, err := s.evaluator.GenerateAIReport(ctx, snapshot, evidence, nil, guardrails)
+	if err != nil {
+		fail(err)
+		return
+	}
+	report.RoomCode = group.ID
+	report.Sampling = sampling
+	if err := s.groupRepo.SetReport(ctx, group.ID, report); err != nil {
+		fmt.Printf("[Event] Failed to store report for %s: %v\n", group.ID, err)
+		return
+	}
+	fmt.Printf("[Event] Merged report for event %s ready (%d rooms)\n", group.ID, len(analytics.Rooms))
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/events"
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Event log page sizes for GET /v1/rooms/{code}/events
+const (
+	DefaultEventPageSize = 200
+	MaxEventPageSize     = 1000
+)
+
+// Event log write batching
+const (
+	eventLogQueueSize   = 2048
+	eventLogBatchSize   = 100
+	eventLogFlushPeriod = 500 * time.Millisecond
+)
+
+// EventLogService persists every published room event with a per-room sequence
+// number so the stream can be read back for debugging, late-joining host
+// dashboards and session replays. It is wired as event bus middleware.
+type EventLogService struct {
+	eventRepo  repository.EventRepo
+	eventCache cache.EventCache
+	roomRepo   repository.RoomRepo
+
+	queue chan *model.RoomEvent
+}
+
+// NewEventLogService creates a new event log service; call Start to begin writing
+func NewEventLogService(eventRepo repository.EventRepo, eventCache cache.EventCache, roomRepo repository.RoomRepo) *EventLogService {
+	return &EventLogService{
+		eventRepo:  eventRepo,
+		eventCache: eventCache,
+		roomRepo:   roomRepo,
+		queue:      make(chan *model.RoomEvent, eventLogQueueSize),
+	}
+}
+
+// Record stamps the event's sequence number before it is delivered, so clients
+// see the same seq live as in the log, and queues it for writing (implements
+// events.Recorder). Events are dropped with a log line if Redis or the queue fails.
+func (s *EventLogService) Record(e *events.Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	seq, err := s.eventCache.NextSeq(ctx, e.RoomCode)
+	cancel()
+	if err != nil {
+		fmt.Printf("[EventLog] No sequence for %s %s: %v\n", e.RoomCode, e.Type, err)
+		return
+	}
+	e.Seq = seq
+
+	record := &model.RoomEvent{
+		RoomCode: e.RoomCode,
+		Seq:      seq,
+		Type:     string(e.Type),
+		Audience: string(e.Audience),
+		PlayerID: e.PlayerID,
+		Payload:  storablePayload(e.Payload),
+		At:       e.At,
+	}
+	select {
+	case s.queue <- record:
+	default:
+		fmt.Printf("[EventLog] Queue full, dropped %s #%d (%s)\n", e.RoomCode, seq, e.Type)
+	}
+}
+
+// storablePayload converts a payload struct to its JSON shape, so the stored
+// document and the REST response use the same field names clients see live
+func storablePayload(payload interface{}) interface{} {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil
+	}
+	return out
+}
+
+// Start writes queued events in batches until ctx is cancelled, then flushes what is left
+func (s *EventLogService) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(eventLogFlushPeriod)
+		defer ticker.Stop()
+
+		batch := make([]*model.RoomEvent, 0, eventLogBatchSize)
+		flush := func() {
+			if len(batch) == 0 {
+				return
+			}
+			writeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := s.eventRepo.Append(writeCtx, batch); err != nil {
+				fmt.Printf("[EventLog] Failed to write %d events: %v\n", len(batch), err)
+			}
+			cancel()
+			batch = batch[:0]
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				for {
+					select {
+					case e := <-s.queue:
+						batch = append(batch, e)
+					default:
+						flush()
+						return
+					}
+				}
+			case e := <-s.queue:
+				batch = append(batch, e)
+				if len(batch) >= eventLogBatchSize {
+					flush()
+				}
+			case <-ticker.C:
+				flush()
+			}
+		}
+	}()
+}
+
+// List returns the room's events after afterSeq, oldest first; nil when the room does not exist
+func (s *EventLogService) List(ctx context.Context, roomCode, hostID string, afterSeq int64, limit int) (*model.RoomEventPage, error) {
+	room, err := s.roomRepo.GetByCode(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, nil
+	}
+	if room.HostID != hostID {
+		return nil, ErrNotRoomHost
+	}
+
+	if limit <= 0 {
+		limit = DefaultEventPageSize
+	}
+	if limit > MaxEventPageSize {
+		limit = MaxEventPageSize
+	}
+	// Fetch one extra to tell whether another page follows
+	list, err := s.eventRepo.ListAfter(ctx, roomCode, afterSeq, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.RoomEventPage{RoomCode: roomCode, Events: list, LastSeq: afterSeq}
+	if len(list) > limit {
+		page.Events = list[:limit]
+		page.HasMore = true
+	}
+	if n := len(page.Events); n > 0 {
+		page.LastSeq = page.Events[n-1].Seq
+	}
+	return page, nil
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// defaultEvidenceSamples caps the samples per question when the config leaves it unset
+const defaultEvidenceSamples = 5
+
+// evidencePick is one sampled answer and the stratum it was picked for
+type evidencePick struct {
+	answer  *model.Answer
+	stratum string
+	marker  string // Prefix telling the model why it was picked
+}
+
+// sampleEvidence picks answer excerpts for the report prompt, per question:
+// host-starred answers first, then summaries from signals under the configured
+// strategy. Answers flagged as duplicates are left out unless the host starred
+// them. The returned metadata records the strategy and what each stratum got.
+func (s *ReportService) sampleEvidence(answers []*model.Answer) (map[string][]string, *model.EvidenceSampling) {
+	strategy := s.evaluator.config.Evidence.Strategy
+	if strategy != model.EvidenceStrategyFirst {
+		strategy = model.EvidenceStrategyStratified
+	}
+	perQuestion := s.evaluator.config.Evidence.PerQuestion
+	if perQuestion <= 0 {
+		perQuestion = defaultEvidenceSamples
+	}
+
+	byQuestion := make(map[string][]*model.Answer)
+	for _, ans := range answers {
+		byQuestion[ans.QuestionKey] = append(byQuestion[ans.QuestionKey], ans)
+	}
+	keys := make([]string, 0, len(byQuestion))
+	for key := range byQuestion {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	evidenceSamples := make(map[string][]string)
+	sampling := &model.EvidenceSampling{
+		Strategy:    strategy,
+		PerQuestion: perQuestion,
+		Questions:   []model.QuestionEvidenceSampling{},
+	}
+	for _, key := range keys {
+		group := byQuestion[key]
+		sort.SliceStable(group, func(i, j int) bool { return group[i].CreatedAt.Before(group[j].CreatedAt) })
+
+		stats := model.QuestionEvidenceSampling{QuestionKey: key, Strata: map[string]int{}}
+		var samples []string
+		var pool []*model.Answer
+		for _, ans := range group {
+			if ans.Star != nil {
+				if len(samples) < perQuestion {
+					samples = append(samples, s.starredSample(ans))
+					stats.Strata[model.EvidenceStarred]++
+				}
+				continue
+			}
+			if ans.Duplicate == nil && ans.Signals != nil && ans.Signals.Summary != "" {
+				pool = append(pool, ans)
+			}
+		}
+		stats.Eligible = len(pool)
+
+		var picks []evidencePick
+		if strategy == model.EvidenceStrategyFirst {
+			picks = firstEvidence(pool, perQuestion-len(samples))
+		} else {
+			picks = stratifiedEvidence(pool, perQuestion-len(samples))
+		}
+		for _, p := range picks {
+			samples = append(samples, p.marker+p.answer.Signals.Summary)
+			stats.Strata[p.stratum]++
+		}
+		if len(samples) == 0 {
+			continue
+		}
+		evidenceSamples[key] = samples
+		sampling.Questions = append(sampling.Questions, stats)
+	}
+	return evidenceSamples, sampling
+}
+
+// starredSample is a host-starred answer with the host's note
+func (s *ReportService) starredSample(ans *model.Answer) string {
+	sample := "[HOST STARRED] "
+	if ans.Signals != nil && ans.Signals.Summary != "" {
+		sample += ans.Signals.Summary
+	} else {
+		sample += s.evaluator.promptAnswer(ans.TextAnswer)
+	}
+	if ans.Star.Note != "" {
+		sample += " (host note: " + ans.Star.Note + ")"
+	}
+	return sample
+}
+
+// firstEvidence takes the first slots answers in submission order
+func firstEvidence(pool []*model.Answer, slots int) []evidencePick {
+	var picks []evidencePick
+	for i := 0; i < len(pool) && i < slots; i++ {
+		picks = append(picks, evidencePick{answer: pool[i], stratum: model.EvidenceFirst})
+	}
+	return picks
+}
+
+// stratifiedEvidence fills slots with the best and worst answer by quality, the
+// most positive and most negative by sentiment and one raising a rare theme,
+// then spreads the remaining slots evenly over the room in submission order
+func stratifiedEvidence(pool []*model.Answer, slots int) []evidencePick {
+	if slots <= 0 || len(pool) == 0 {
+		return nil
+	}
+	picked := make([]bool, len(pool))
+	var picks []evidencePick
+	take := func(i int, stratum, marker string) {
+		if i < 0 || picked[i] || len(picks) >= slots {
+			return
+		}
+		picked[i] = true
+		picks = append(picks, evidencePick{answer: pool[i], stratum: stratum, marker: marker})
+	}
+	// best returns the earliest unpicked answer scoring highest, -1 when all are picked
+	best := func(score func(a *model.Answer) float64) int {
+		at := -1
+		for i, a := range pool {
+			if !picked[i] && (at < 0 || score(a) > score(pool[at])) {
+				at = i
+			}
+		}
+		return at
+	}
+
+	high := best(func(a *model.Answer) float64 { return a.QualityScore })
+	take(high, model.EvidenceHighQuality, "[HIGH QUALITY] ")
+	if low := best(func(a *model.Answer) float64 { return -a.QualityScore }); low >= 0 && pool[low].QualityScore < pool[high].QualityScore {
+		take(low, model.EvidenceLowQuality, "[LOW QUALITY] ")
+	}
+	if pos := best(func(a *model.Answer) float64 { return a.Signals.Sentiment }); pos >= 0 && pool[pos].Signals.Sentiment > 0 {
+		take(pos, model.EvidencePositive, "[POSITIVE] ")
+	}
+	if neg := best(func(a *model.Answer) float64 { return -a.Signals.Sentiment }); neg >= 0 && pool[neg].Signals.Sentiment < 0 {
+		take(neg, model.EvidenceNegative, "[NEGATIVE] ")
+	}
+	if i, theme := outlierTheme(pool, picked); i >= 0 {
+		take(i, model.EvidenceOutlierTheme, fmt.Sprintf("[RARE THEME: %s] ", theme))
+	}
+
+	var rest []int
+	for i := range pool {
+		if !picked[i] {
+			rest = append(rest, i)
+		}
+	}
+	n := slots - len(picks)
+	if n > len(rest) {
+		n = len(rest)
+	}
+	for j := 0; j < n; j++ {
+		take(rest[(2*j+1)*len(rest)/(2*n)], model.EvidenceSpread, "")
+	}
+	return picks
+}
+
+// outlierTheme finds the earliest unpicked answer raising the rarest theme of
+// the pool, counting each theme once per answer. Themes as common as the most
+// common one are not outliers. Returns -1 when there is none.
+func outlierTheme(pool []*model.Answer, picked []bool) (int, string) {
+	counts := make(map[string]int)
+	for _, a := range pool {
+		for theme := range answerThemes(a) {
+			counts[theme]++
+		}
+	}
+	most := 0
+	for _, n := range counts {
+		if n > most {
+			most = n
+		}
+	}
+
+	at, rarest, name := -1, most, ""
+	for i, a := range pool {
+		if picked[i] {
+			continue
+		}
+		for theme, display := range answerThemes(a) {
+			if n := counts[theme]; n < rarest || (n == rarest && at == i && display < name) {
+				at, rarest, name = i, n, display
+			}
+		}
+	}
+	return at, name
+}
+
+// answerThemes maps an answer's lowercased themes, emergent ones included, to their display form
+func answerThemes(a *model.Answer) map[string]string {
+	themes := make(map[string]string)
+	for _, t := range append(append([]string{}, a.Signals.Themes...), a.Signals.EmergentThemes...) {
+		if key := strings.ToLower(strings.TrimSpace(t)); key != "" {
+			if _, ok := themes[key]; !ok {
+				themes[key] = strings.TrimSpace(t)
+			}
+		}
+	}
+	return themes
+}
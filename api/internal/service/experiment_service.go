@@ -0,0 +1,385 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"strings"
+	"time"
+)
+
+// Experiment limits and comparison tuning
+const (
+	maxExperimentVariants  = 4
+	maxPromptNoteChars     = 500
+	minComparisonAnswers   = 20   // per variant before findings are drawn
+	skipRateFindingDelta   = 0.05 // skip rate gap worth reporting
+	qualityFindingDelta    = 0.10 // mean quality gap worth reporting
+	completionFindingDelta = 0.10 // completion rate gap worth reporting
+	followUpFindingDelta   = 0.5  // follow-ups per player gap worth reporting
+)
+
+// ErrInvalidExperiment is returned for malformed experiments and rooms that cannot join one
+var ErrInvalidExperiment = errors.New("invalid experiment")
+
+// ExperimentService assigns rooms and players to prompt/follow-up variants and compares outcomes
+type ExperimentService struct {
+	experimentRepo repository.ExperimentRepo
+	answerRepo     repository.AnswerRepo
+	reportRepo     repository.ReportRepo
+}
+
+// NewExperimentService creates a new experiment service
+func NewExperimentService(experimentRepo repository.ExperimentRepo, answerRepo repository.AnswerRepo, reportRepo repository.ReportRepo) *ExperimentService {
+	return &ExperimentService{
+		experimentRepo: experimentRepo,
+		answerRepo:     answerRepo,
+		reportRepo:     reportRepo,
+	}
+}
+
+// Create validates and stores a new active experiment
+func (s *ExperimentService) Create(ctx context.Context, hostID string, req *model.CreateExperimentRequest) (*model.Experiment, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidExperiment)
+	}
+	unit := req.Unit
+	if unit == "" {
+		unit = model.ExperimentUnitRoom
+	}
+	if unit != model.ExperimentUnitRoom && unit != model.ExperimentUnitPlayer {
+		return nil, fmt.Errorf("%w: unit must be room or player", ErrInvalidExperiment)
+	}
+	if len(req.Variants) < 2 || len(req.Variants) > maxExperimentVariants {
+		return nil, fmt.Errorf("%w: between 2 and %d variants are required", ErrInvalidExperiment, maxExperimentVariants)
+	}
+
+	seen := make(map[string]bool, len(req.Variants))
+	variants := make([]model.ExperimentVariant, 0, len(req.Variants))
+	for _, v := range req.Variants {
+		v.Key = strings.TrimSpace(v.Key)
+		if v.Key == "" || seen[v.Key] {
+			return nil, fmt.Errorf("%w: variant keys must be unique and non-empty", ErrInvalidExperiment)
+		}
+		seen[v.Key] = true
+		if v.Weight < 0 {
+			return nil, fmt.Errorf("%w: variant %s: weight must not be negative", ErrInvalidExperiment, v.Key)
+		}
+		switch v.FollowUpStyle {
+		case model.FollowUpStyleDefault, model.FollowUpStyleGentle, model.FollowUpStyleAggressive:
+		default:
+			return nil, fmt.Errorf("%w: variant %s: unknown follow-up style %q", ErrInvalidExperiment, v.Key, v.FollowUpStyle)
+		}
+		if v.MaxFollowUps != nil && *v.MaxFollowUps < 0 {
+			return nil, fmt.Errorf("%w: variant %s: maxFollowUps must not be negative", ErrInvalidExperiment, v.Key)
+		}
+		v.PromptNote = strings.TrimSpace(v.PromptNote)
+		if len([]rune(v.PromptNote)) > maxPromptNoteChars {
+			return nil, fmt.Errorf("%w: variant %s: promptNote is limited to %d characters", ErrInvalidExperiment, v.Key, maxPromptNoteChars)
+		}
+		variants = append(variants, v)
+	}
+
+	experiment := &model.Experiment{
+		HostID:      hostID,
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Unit:        unit,
+		Variants:    variants,
+		Status:      model.ExperimentStatusActive,
+	}
+	id, err := s.experimentRepo.Create(ctx, experiment)
+	if err != nil {
+		return nil, err
+	}
+	experiment.ID = id
+	return experiment, nil
+}
+
+// List returns the host's experiments, newest first
+func (s *ExperimentService) List(ctx context.Context, hostID string) ([]*model.Experiment, error) {
+	return s.experimentRepo.ListByHost(ctx, hostID)
+}
+
+// Get returns one of the host's experiments, or nil
+func (s *ExperimentService) Get(ctx context.Context, hostID, id string) (*model.Experiment, error) {
+	experiment, err := s.experimentRepo.GetByID(ctx, id)
+	if err != nil || experiment == nil || experiment.HostID != hostID {
+		return nil, err
+	}
+	return experiment, nil
+}
+
+// Stop ends assignment of new rooms; returns false if the host has no such active experiment
+func (s *ExperimentService) Stop(ctx context.Context, hostID, id string) (bool, error) {
+	return s.experimentRepo.Stop(ctx, hostID, id, time.Now())
+}
+
+// ForRoom checks the host can run the experiment in a new room and picks the
+// room's variant for room-unit experiments. Call RecordRoom once the room exists.
+func (s *ExperimentService) ForRoom(ctx context.Context, hostID, experimentID, roomCode string) (*model.RoomExperiment, error) {
+	experiment, err := s.Get(ctx, hostID, experimentID)
+	if err != nil {
+		return nil, err
+	}
+	if experiment == nil {
+		return nil, fmt.Errorf("%w: experiment not found", ErrInvalidExperiment)
+	}
+	if experiment.Status != model.ExperimentStatusActive {
+		return nil, fmt.Errorf("%w: experiment is stopped", ErrInvalidExperiment)
+	}
+
+	assigned := &model.RoomExperiment{
+		ID:       experiment.ID,
+		Unit:     experiment.Unit,
+		Variants: experiment.Variants,
+	}
+	if experiment.Unit == model.ExperimentUnitRoom {
+		assigned.Variant = pickVariant(experiment.Variants, experiment.ID, roomCode)
+	}
+	return assigned, nil
+}
+
+// RecordRoom stores the room's assignment so the comparison can find its answers
+func (s *ExperimentService) RecordRoom(ctx context.Context, roomCode string, experiment *model.RoomExperiment) error {
+	return s.experimentRepo.Assign(ctx, &model.ExperimentAssignment{
+		ExperimentID: experiment.ID,
+		RoomCode:     roomCode,
+		Variant:      experiment.Variant,
+		AssignedAt:   time.Now(),
+	})
+}
+
+// AssignPlayer returns the variant a joining player gets, recording per-player assignments
+func (s *ExperimentService) AssignPlayer(ctx context.Context, roomCode, playerID string, experiment *model.RoomExperiment) string {
+	if experiment.Unit != model.ExperimentUnitPlayer {
+		return experiment.Variant
+	}
+	variant := pickVariant(experiment.Variants, experiment.ID, playerID)
+	err := s.experimentRepo.Assign(ctx, &model.ExperimentAssignment{
+		ExperimentID: experiment.ID,
+		RoomCode:     roomCode,
+		PlayerID:     playerID,
+		Variant:      variant,
+		AssignedAt:   time.Now(),
+	})
+	if err != nil {
+		log.Printf("Warning: failed to record experiment assignment for %s/%s: %v", roomCode, playerID, err)
+	}
+	return variant
+}
+
+// pickVariant deterministically maps a unit to a variant by weight, so retries
+// and other instances agree
+func pickVariant(variants []model.ExperimentVariant, experimentID, unitID string) string {
+	total := 0
+	for _, v := range variants {
+		total += variantWeight(v)
+	}
+	if total == 0 {
+		return ""
+	}
+	h := fnv.New32a()
+	h.Write([]byte(experimentID + ":" + unitID))
+	n := int(h.Sum32() % uint32(total))
+	for _, v := range variants {
+		if n < variantWeight(v) {
+			return v.Key
+		}
+		n -= variantWeight(v)
+	}
+	return variants[len(variants)-1].Key
+}
+
+func variantWeight(v model.ExperimentVariant) int {
+	if v.Weight <= 0 {
+		return 1
+	}
+	return v.Weight
+}
+
+// experimentVariant returns the variant that applies to a player, or nil outside experiments
+func experimentVariant(meta *model.RoomMeta, player *model.Player) *model.ExperimentVariant {
+	if meta == nil || meta.Experiment == nil {
+		return nil
+	}
+	key := meta.Experiment.Variant
+	if player != nil && player.Variant != "" {
+		key = player.Variant
+	}
+	for i := range meta.Experiment.Variants {
+		if meta.Experiment.Variants[i].Key == key {
+			return &meta.Experiment.Variants[i]
+		}
+	}
+	return nil
+}
+
+// variantTally accumulates one variant's outcomes
+type variantTally struct {
+	outcome     model.VariantOutcome
+	rooms       map[string]bool
+	players     map[string]bool
+	evaluated   int
+	sat         int
+	qualitySum  float64
+	completions int
+}
+
+// Compare aggregates skip rate, answer quality, follow-ups and completion per variant
+func (s *ExperimentService) Compare(ctx context.Context, hostID, id string) (*model.ExperimentComparison, error) {
+	experiment, err := s.Get(ctx, hostID, id)
+	if err != nil || experiment == nil {
+		return nil, err
+	}
+	assignments, err := s.experimentRepo.GetAssignments(ctx, experiment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tallies := make(map[string]*variantTally, len(experiment.Variants))
+	for _, v := range experiment.Variants {
+		tallies[v.Key] = &variantTally{
+			outcome: model.VariantOutcome{Variant: v.Key},
+			rooms:   map[string]bool{},
+			players: map[string]bool{},
+		}
+	}
+
+	// Room-unit experiments assign whole rooms; player-unit ones assign each player
+	roomVariant := map[string]string{}
+	playerVariant := map[string]string{} // roomCode/playerId -> variant
+	roomCodes := []string{}
+	for _, a := range assignments {
+		if a.PlayerID == "" {
+			roomVariant[a.RoomCode] = a.Variant
+			roomCodes = append(roomCodes, a.RoomCode)
+			continue
+		}
+		playerVariant[a.RoomCode+"/"+a.PlayerID] = a.Variant
+	}
+	variantOf := func(roomCode, playerID string) *variantTally {
+		if experiment.Unit == model.ExperimentUnitPlayer {
+			return tallies[playerVariant[roomCode+"/"+playerID]]
+		}
+		return tallies[roomVariant[roomCode]]
+	}
+
+	answers, err := s.answerRepo.GetByRoomCodes(ctx, roomCodes)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range answers {
+		t := variantOf(a.RoomCode, a.PlayerID)
+		if t == nil || a.Resolution == model.ResolutionAbandoned {
+			continue
+		}
+		t.rooms[a.RoomCode] = true
+		t.players[a.RoomCode+"/"+a.PlayerID] = true
+		t.outcome.Answers++
+		if strings.Contains(a.QuestionKey, ".") {
+			t.outcome.FollowUpAnswers++
+		}
+		switch {
+		case a.Resolution == model.ResolutionSkipped:
+			t.outcome.Skipped++
+		case a.Signals != nil && a.Status == model.AnswerStatusEvaluated:
+			// Only ESSAY answers carry AI signals
+			t.evaluated++
+			t.qualitySum += a.QualityScore
+			if a.Resolution == model.ResolutionSat {
+				t.sat++
+			}
+		}
+	}
+
+	// Completion comes from the snapshots of ended rooms
+	for _, code := range roomCodes {
+		snapshot, err := s.reportRepo.GetSnapshot(ctx, code)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot == nil {
+			continue
+		}
+		for _, pc := range snapshot.PlayerCompletion {
+			t := variantOf(code, pc.PlayerID)
+			if t == nil {
+				continue
+			}
+			t.outcome.CompletionBase++
+			if pc.Completed {
+				t.completions++
+			}
+		}
+	}
+
+	comparison := &model.ExperimentComparison{
+		ExperimentID: experiment.ID,
+		Name:         experiment.Name,
+		Unit:         experiment.Unit,
+		Status:       experiment.Status,
+		Variants:     make([]model.VariantOutcome, 0, len(experiment.Variants)),
+		Findings:     []string{},
+	}
+	for _, v := range experiment.Variants {
+		t := tallies[v.Key]
+		o := t.outcome
+		o.Rooms = len(t.rooms)
+		o.Players = len(t.players)
+		if o.Answers > 0 {
+			o.SkipRate = float64(o.Skipped) / float64(o.Answers)
+		}
+		if t.evaluated > 0 {
+			o.SatRate = float64(t.sat) / float64(t.evaluated)
+			o.MeanQuality = t.qualitySum / float64(t.evaluated)
+		}
+		if o.Players > 0 {
+			o.FollowUpsPerPlayer = float64(o.FollowUpAnswers) / float64(o.Players)
+		}
+		if o.CompletionBase > 0 {
+			o.CompletionRate = float64(t.completions) / float64(o.CompletionBase)
+		}
+		comparison.Variants = append(comparison.Variants, o)
+	}
+	comparison.Findings = experimentFindings(comparison.Variants)
+	return comparison, nil
+}
+
+// experimentFindings compares every variant with the first one (the control)
+func experimentFindings(outcomes []model.VariantOutcome) []string {
+	findings := []string{}
+	if len(outcomes) == 0 {
+		return findings
+	}
+	control := outcomes[0]
+	if control.Answers < minComparisonAnswers {
+		return append(findings, fmt.Sprintf("Not enough data yet: %s has %d answers, %d are needed per variant.", control.Variant, control.Answers, minComparisonAnswers))
+	}
+	for _, o := range outcomes[1:] {
+		if o.Answers < minComparisonAnswers {
+			findings = append(findings, fmt.Sprintf("Not enough data yet: %s has %d answers, %d are needed per variant.", o.Variant, o.Answers, minComparisonAnswers))
+			continue
+		}
+		if d := o.SkipRate - control.SkipRate; d >= skipRateFindingDelta || d <= -skipRateFindingDelta {
+			findings = append(findings, fmt.Sprintf("%s skip rate is %.0f%% vs %.0f%% for %s.", o.Variant, o.SkipRate*100, control.SkipRate*100, control.Variant))
+		}
+		if d := o.MeanQuality - control.MeanQuality; d >= qualityFindingDelta || d <= -qualityFindingDelta {
+			findings = append(findings, fmt.Sprintf("%s mean answer quality is %.2f vs %.2f for %s.", o.Variant, o.MeanQuality, control.MeanQuality, control.Variant))
+		}
+		if d := o.FollowUpsPerPlayer - control.FollowUpsPerPlayer; d >= followUpFindingDelta || d <= -followUpFindingDelta {
+			findings = append(findings, fmt.Sprintf("%s asks %.1f follow-ups per player vs %.1f for %s.", o.Variant, o.FollowUpsPerPlayer, control.FollowUpsPerPlayer, control.Variant))
+		}
+		if o.CompletionBase > 0 && control.CompletionBase > 0 {
+			if d := o.CompletionRate - control.CompletionRate; d >= completionFindingDelta || d <= -completionFindingDelta {
+				findings = append(findings, fmt.Sprintf("%s completion is %.0f%% vs %.0f%% for %s.", o.Variant, o.CompletionRate*100, control.CompletionRate*100, control.Variant))
+			}
+		}
+	}
+	return findings
+}
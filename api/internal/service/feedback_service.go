@@ -0,0 +1,206 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/events"
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// End-of-room feedback limits
+const (
+	maxFeedbackAnswers    = 3
+	maxFeedbackThemes     = 5
+	feedbackExcerptChars  = 280
+	feedbackWorkers       = 4
+	feedbackDeliveryLimit = 2 * time.Minute // Players stay connected at most this long after the room ends
+)
+
+// FeedbackService builds each player's end-of-room summary: score, strongest
+// answers, themes and an AI-written thank-you paragraph
+type FeedbackService struct {
+	feedbackRepo repository.FeedbackRepo
+	answerRepo   repository.AnswerRepo
+	surveyRepo   repository.SurveyRepo
+	playerCache  cache.PlayerCache
+	playerSvc    *PlayerService
+	evaluator    *EvaluatorService
+	broadcaster  Broadcaster // optional
+}
+
+// NewFeedbackService creates a new feedback service
+func NewFeedbackService(
+	feedbackRepo repository.FeedbackRepo,
+	answerRepo repository.AnswerRepo,
+	surveyRepo repository.SurveyRepo,
+	playerCache cache.PlayerCache,
+	playerSvc *PlayerService,
+	evaluator *EvaluatorService,
+) *FeedbackService {
+	return &FeedbackService{
+		feedbackRepo: feedbackRepo,
+		answerRepo:   answerRepo,
+		surveyRepo:   surveyRepo,
+		playerCache:  playerCache,
+		playerSvc:    playerSvc,
+		evaluator:    evaluator,
+	}
+}
+
+// SetBroadcaster sets the broadcaster for player_feedback events
+func (s *FeedbackService) SetBroadcaster(b Broadcaster) {
+	s.broadcaster = b
+}
+
+// DeliverRoomFeedback builds, stores and sends every player's summary once the
+// room has ended, then disconnects the room. Run it in its own goroutine.
+func (s *FeedbackService) DeliverRoomFeedback(roomCode, surveyID string) {
+	ctx, cancel := context.WithTimeout(WithAIRoom(context.Background(), roomCode), feedbackDeliveryLimit)
+	defer cancel()
+	if s.broadcaster != nil {
+		defer s.broadcaster.DisconnectRoom(roomCode)
+	}
+
+	players, err := s.playerCache.GetAllPlayers(ctx, roomCode)
+	if err != nil {
+		fmt.Printf("[Feedback] Players for %s unavailable: %v\n", roomCode, err)
+		return
+	}
+	answers, err := s.answerRepo.GetByRoomCode(ctx, roomCode)
+	if err != nil {
+		fmt.Printf("[Feedback] Answers for %s unavailable: %v\n", roomCode, err)
+		return
+	}
+	survey, _ := s.surveyRepo.GetByID(ctx, surveyID)
+
+	byPlayer := make(map[string][]*model.Answer)
+	for _, a := range answers {
+		byPlayer[a.PlayerID] = append(byPlayer[a.PlayerID], a)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, feedbackWorkers)
+	for playerID, player := range players {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(playerID string, player *model.Player) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			feedback := s.buildFeedback(ctx, roomCode, playerID, player, byPlayer[playerID], survey, len(players))
+			// Stored with a fresh context so the late-fetch copy survives a delivery timeout
+			saveCtx, saveCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := s.feedbackRepo.Upsert(saveCtx, feedback); err != nil {
+				fmt.Printf("[Feedback] Failed to store feedback for %s in %s: %v\n", playerID, roomCode, err)
+			}
+			saveCancel()
+			if s.broadcaster != nil && !player.HasLeft() {
+				s.broadcaster.ToPlayer(roomCode, playerID, events.PlayerFeedback, feedback)
+			}
+		}(playerID, player)
+	}
+	wg.Wait()
+	fmt.Printf("[Feedback] Delivered end-of-room feedback to %d players in %s\n", len(players), roomCode)
+}
+
+// buildFeedback assembles one player's summary and asks the evaluator for its message
+func (s *FeedbackService) buildFeedback(ctx context.Context, roomCode, playerID string, player *model.Player, answers []*model.Answer, survey *model.Survey, totalPlayers int) *model.PlayerFeedback {
+	feedback := &model.PlayerFeedback{
+		RoomCode:     roomCode,
+		PlayerID:     playerID,
+		Nickname:     player.Nickname,
+		Score:        player.Score,
+		TotalPlayers: totalPlayers,
+		CreatedAt:    time.Now(),
+	}
+	if standing, err := s.playerSvc.GetStanding(ctx, roomCode, playerID); err == nil && standing != nil {
+		feedback.Score = standing.Score
+		feedback.Rank = standing.Rank
+	}
+
+	answered := make(map[string]bool)
+	themeCounts := make(map[string]int)
+	themeOrder := []string{}
+	var candidates []*model.Answer
+	for _, a := range answers {
+		if a.Resolution == model.ResolutionSkipped || a.Resolution == model.ResolutionAbandoned {
+			continue
+		}
+		answered[a.QuestionKey] = true
+		if a.TextAnswer != "" {
+			candidates = append(candidates, a)
+		}
+		if a.Signals == nil {
+			continue
+		}
+		for _, theme := range a.Signals.Themes {
+			if themeCounts[theme] == 0 {
+				themeOrder = append(themeOrder, theme)
+			}
+			themeCounts[theme]++
+		}
+	}
+	feedback.Answered = len(answered)
+
+	// Most frequent themes first; ties keep the order they came up in
+	sort.SliceStable(themeOrder, func(i, j int) bool {
+		return themeCounts[themeOrder[i]] > themeCounts[themeOrder[j]]
+	})
+	if len(themeOrder) > maxFeedbackThemes {
+		themeOrder = themeOrder[:maxFeedbackThemes]
+	}
+	feedback.Themes = themeOrder
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].QualityScore != candidates[j].QualityScore {
+			return candidates[i].QualityScore > candidates[j].QualityScore
+		}
+		return candidates[i].PointsEarned > candidates[j].PointsEarned
+	})
+	feedback.StrongestAnswers = []model.FeedbackAnswer{}
+	for _, a := range candidates {
+		if len(feedback.StrongestAnswers) == maxFeedbackAnswers {
+			break
+		}
+		feedback.StrongestAnswers = append(feedback.StrongestAnswers, model.FeedbackAnswer{
+			QuestionKey:  a.QuestionKey,
+			Prompt:       s.questionPrompt(ctx, roomCode, playerID, a.QuestionKey, survey),
+			Excerpt:      truncateForPrompt(a.TextAnswer, feedbackExcerptChars),
+			QualityScore: a.QualityScore,
+			PointsEarned: a.PointsEarned,
+		})
+	}
+
+	message, err := s.evaluator.GeneratePlayerFeedback(ctx, feedback, survey)
+	if err != nil {
+		message = s.evaluator.mockPlayerFeedback(feedback)
+	}
+	feedback.Message = message
+	return feedback
+}
+
+// questionPrompt finds a question's prompt in the survey, falling back to the
+// player's question map for AI follow-ups
+func (s *FeedbackService) questionPrompt(ctx context.Context, roomCode, playerID, key string, survey *model.Survey) string {
+	if survey != nil {
+		for _, q := range survey.Questions {
+			if q.Key == key {
+				return q.Prompt
+			}
+		}
+	}
+	if q, err := s.playerCache.GetQuestionMap(ctx, roomCode, playerID, key); err == nil && q != nil {
+		return q.Prompt
+	}
+	return ""
+}
+
+// Get returns a player's stored end-of-room feedback; nil until it has been generated
+func (s *FeedbackService) Get(ctx context.Context, roomCode, playerID string) (*model.PlayerFeedback, error) {
+	return s.feedbackRepo.Get(ctx, roomCode, playerID)
+}
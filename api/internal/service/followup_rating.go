@@ -0,0 +1,95 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Follow-up throttling from player relevance ratings
+const (
+	minRelevanceRatings  = 10  // ratings before they can throttle follow-ups
+	lowRelevanceRate     = 0.4 // below this, at most one follow-up per question
+	veryLowRelevanceRate = 0.2 // below this, no more follow-ups of that kind
+)
+
+// Follow-up rating errors
+var (
+	ErrNotFollowUp  = errors.New("question is not an AI follow-up served to this player")
+	ErrAlreadyRated = errors.New("follow-up already rated")
+)
+
+// RateFollowUp records a player's thumbs up/down on whether a follow-up they were
+// asked was relevant
+func (s *AnswerService) RateFollowUp(ctx context.Context, roomCode, playerID, questionKey string, relevant bool) error {
+	if err := s.checkNotLeft(ctx, roomCode, playerID); err != nil {
+		return err
+	}
+	question, err := s.playerCache.GetQuestionMap(ctx, roomCode, playerID, questionKey)
+	if err != nil {
+		return err
+	}
+	if question == nil || question.ParentKey == "" || question.Source == "" {
+		return ErrNotFollowUp
+	}
+	if s.analyticsSvc == nil {
+		return nil
+	}
+	return s.analyticsSvc.RateFollowUp(ctx, roomCode, playerID, question, relevant)
+}
+
+// RateFollowUp adds a player's rating to the room's relevance counters
+func (s *AnalyticsService) RateFollowUp(ctx context.Context, roomCode, playerID string, question *model.Question, relevant bool) error {
+	rated, err := s.analyticsCache.RateFollowUp(ctx, roomCode, playerID, question.Key, question.Source, question.Hint, relevant)
+	if err != nil {
+		return err
+	}
+	if !rated {
+		return ErrAlreadyRated
+	}
+	return nil
+}
+
+// GetFollowUpRelevance returns the room's follow-up ratings; nil when none were given
+func (s *AnalyticsService) GetFollowUpRelevance(ctx context.Context, roomCode string) (*model.FollowUpRelevance, error) {
+	return s.analyticsCache.GetFollowUpRelevance(ctx, roomCode)
+}
+
+// FollowUpCap returns a cap on follow-ups per question when players keep rating
+// follow-ups for this hint (or, with too few of those, all follow-ups) as irrelevant;
+// nil when there is no reason to throttle
+func (s *AnalyticsService) FollowUpCap(ctx context.Context, roomCode, hint string) *int {
+	relevance, err := s.analyticsCache.GetFollowUpRelevance(ctx, roomCode)
+	if err != nil || relevance == nil {
+		return nil
+	}
+	stats := relevance.ByHint[hint]
+	if stats.Total() < minRelevanceRatings {
+		stats = relevance.Overall
+	}
+	if stats.Total() < minRelevanceRatings {
+		return nil
+	}
+
+	var limit int
+	switch {
+	case stats.Rate < veryLowRelevanceRate:
+		limit = 0
+	case stats.Rate < lowRelevanceRate:
+		limit = 1
+	default:
+		return nil
+	}
+	fmt.Printf("[FollowUp] Throttling %s follow-ups in %s to %d per question (relevance %.0f%% of %d)\n",
+		hint, roomCode, limit, stats.Rate*100, stats.Total())
+	return &limit
+}
+
+// followUpHint is the evaluation hint a follow-up answers; pools default to clarify
+func followUpHint(evalResult *model.EvaluationResult) string {
+	if evalResult == nil || evalResult.FollowUpHint == "" {
+		return "clarify"
+	}
+	return evalResult.FollowUpHint
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// streamGemini makes a budgeted, circuit-broken streaming request to the Gemini API.
+// onText receives the accumulated response text after every chunk.
+func (s *EvaluatorService) streamGemini(ctx context.Context, modelName, prompt string, temperature *float64, responseSchema map[string]interface{}, onText func(string)) (string, error) {
+	return s.guardedCall(ctx, modelName, prompt, func() (string, error) {
+		return s.doGeminiStream(ctx, modelName, prompt, temperature, responseSchema, onText)
+	})
+}
+
+// doGeminiStream performs the raw streamGenerateContent call and reads the SSE body
+func (s *EvaluatorService) doGeminiStream(ctx context.Context, modelName, prompt string, temperature *float64, responseSchema map[string]interface{}, onText func(string)) (string, error) {
+	jsonBody, err := geminiRequestBody(prompt, temperature, responseSchema)
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s&key=%s", s.config.ModelStreamEndpoint(modelName), s.config.APIKey)
+	fmt.Printf("[Gemini] Streaming %s...\n", modelName)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		var geminiResp geminiResponse
+		if json.Unmarshal(body, &geminiResp) == nil && geminiResp.Error != nil {
+			return "", fmt.Errorf("gemini api error: %s", geminiResp.Error.Message)
+		}
+		return "", fmt.Errorf("gemini stream failed: status %d", resp.StatusCode)
+	}
+
+	var text strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "" {
+			continue
+		}
+
+		var chunk geminiResponse
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			fmt.Printf("[Gemini] Stream chunk parse error: %v\n", err)
+			continue
+		}
+		if chunk.Error != nil {
+			return "", fmt.Errorf("gemini api error: %s", chunk.Error.Message)
+		}
+		if len(chunk.Candidates) == 0 {
+			continue
+		}
+		for _, part := range chunk.Candidates[0].Content.Parts {
+			text.WriteString(part.Text)
+		}
+		if onText != nil {
+			onText(text.String())
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	if text.Len() == 0 {
+		return "", fmt.Errorf("empty response from Gemini")
+	}
+	return text.String(), nil
+}
+
+// partialJSONString extracts the (possibly unterminated) value of the first
+// string field named field from incomplete JSON. ok is false until the value starts.
+func partialJSONString(raw, field string) (string, bool) {
+	idx := strings.Index(raw, `"`+field+`"`)
+	if idx < 0 {
+		return "", false
+	}
+	rest := strings.TrimLeft(raw[idx+len(field)+2:], " \t\r\n")
+	if !strings.HasPrefix(rest, ":") {
+		return "", false
+	}
+	rest = strings.TrimLeft(rest[1:], " \t\r\n")
+	if !strings.HasPrefix(rest, `"`) {
+		return "", false
+	}
+	rest = rest[1:]
+
+	var b strings.Builder
+	for i := 0; i < len(rest); i++ {
+		c := rest[i]
+		switch {
+		case c == '"':
+			return b.String(), true
+		case c == '\\':
+			if i+1 >= len(rest) {
+				return b.String(), true
+			}
+			i++
+			switch rest[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case 'u':
+				// Skip unicode escapes that are not yet complete
+				if i+4 >= len(rest) {
+					return b.String(), true
+				}
+				var r rune
+				if _, err := fmt.Sscanf(rest[i+1:i+5], "%04x", &r); err == nil {
+					b.WriteRune(r)
+				}
+				i += 4
+			default:
+				b.WriteByte(rest[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), true
+}
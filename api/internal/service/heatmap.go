@@ -0,0 +1,132 @@
+package service
+
+import (
+	"2026champs/internal/events"
+	"2026champs/internal/model"
+	"context"
+	"fmt"
+	"time"
+)
+
+const defaultStuckAfter = 2 * time.Minute
+
+// SetStuckAfter sets how long a player can sit on one question before the heatmap counts them as stuck
+func (s *PlayerService) SetStuckAfter(d time.Duration) {
+	if d > 0 {
+		s.stuckAfter = d
+	}
+}
+
+// buildHeatmap counts, per base question, the players on it, stuck on it, done
+// with it or who skipped it. A player on a follow-up counts towards its base
+// question; players who left are not counted.
+func (s *PlayerService) buildHeatmap(ctx context.Context, roomCode string, meta *model.RoomMeta, now time.Time) (*model.QuestionHeatmap, error) {
+	players, err := s.playerCache.GetAllPlayers(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+
+	heatmap := &model.QuestionHeatmap{
+		RoomCode:      roomCode,
+		StuckAfterSec: int(s.stuckAfter / time.Second),
+		Questions:     make([]model.HeatmapQuestion, len(meta.Questions)),
+		At:            now,
+	}
+	index := make(map[string]int, len(meta.Questions))
+	baseKeys := make([]string, len(meta.Questions))
+	for i, q := range meta.Questions {
+		heatmap.Questions[i].QuestionKey = q.Key
+		index[q.Key] = i
+		baseKeys[i] = q.Key
+	}
+
+	for playerID, p := range players {
+		if p.HasLeft() {
+			continue
+		}
+		heatmap.Players++
+
+		// Resolve the base question the player is on
+		current, currentBase := "", ""
+		if key, err := s.playerCache.GetCurrent(ctx, roomCode, playerID); err == nil && key != "" {
+			current = key
+			if _, ok := index[key]; ok {
+				currentBase = key
+			} else if q, err := s.playerCache.GetQuestionMap(ctx, roomCode, playerID, key); err == nil && q != nil {
+				currentBase = q.ParentKey
+			}
+		}
+
+		keys := baseKeys
+		if current != "" && current != currentBase {
+			keys = append(append([]string{}, baseKeys...), current)
+		}
+		attempts, err := s.playerCache.GetAttemptStates(ctx, roomCode, playerID, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range baseKeys {
+			cell := &heatmap.Questions[index[key]]
+			if key == currentBase {
+				cell.Current++
+				if a := attempts[current]; a != nil && a.ServedAt != nil && now.Sub(*a.ServedAt) > s.stuckAfter {
+					cell.Stuck++
+				}
+				continue
+			}
+			a := attempts[key]
+			switch {
+			case a == nil:
+			case a.Resolution == model.ResolutionSkipped:
+				cell.Skipped++
+			case a.Status == model.AnswerStatusEvaluated:
+				cell.Done++
+			}
+		}
+	}
+	return heatmap, nil
+}
+
+// StartHeatmapUpdates sends hosts the question heatmap every interval for ACTIVE
+// rooms that have players connected to this instance
+func (s *PlayerService) StartHeatmapUpdates(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				s.broadcastHeatmaps(ctx, now)
+			}
+		}
+	}()
+}
+
+func (s *PlayerService) broadcastHeatmaps(ctx context.Context, now time.Time) {
+	if s.broadcaster == nil {
+		return
+	}
+
+	s.presenceMu.Lock()
+	rooms := make([]string, 0, len(s.presence))
+	for roomCode := range s.presence {
+		rooms = append(rooms, roomCode)
+	}
+	s.presenceMu.Unlock()
+
+	for _, roomCode := range rooms {
+		meta, err := s.roomCache.GetMeta(ctx, roomCode)
+		if err != nil || meta == nil || meta.Status != model.RoomStatusActive {
+			continue
+		}
+		heatmap, err := s.buildHeatmap(ctx, roomCode, meta, now)
+		if err != nil {
+			fmt.Printf("[Heatmap] Heatmap for %s failed: %v\n", roomCode, err)
+			continue
+		}
+		s.broadcaster.ToHost(roomCode, events.QuestionHeatmap, heatmap)
+	}
+}
@@ -4,14 +4,30 @@ import (
 	"2026champs/internal/model"
 	"2026champs/internal/repository"
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 )
 
+// ErrInvalidInsightRequest is returned for generate-from-insights requests that cannot be served
+var ErrInvalidInsightRequest = errors.New("invalid insight request")
+
+const (
+	insightMaxRooms     = 20 // Most recent ended rooms mined for probes
+	insightMinProbeLen  = 10 // Shorter suggestions are fragments, not questions
+	insightMaxQuestions = 5
+)
+
+// InsightService turns follow-up probes from a host's past rooms into new survey questions
 type InsightService struct {
 	roomRepo   repository.RoomRepo
 	reportRepo repository.ReportRepo
 	evaluator  *EvaluatorService
 }
 
+// NewInsightService creates a new insight service
 func NewInsightService(roomRepo repository.RoomRepo, reportRepo repository.ReportRepo, evaluator *EvaluatorService) *InsightService {
 	return &InsightService{
 		roomRepo:   roomRepo,
@@ -20,34 +36,179 @@ func NewInsightService(roomRepo repository.RoomRepo, reportRepo repository.Repor
 	}
 }
 
-func (s *InsightService) GenerateQuestionsFromInsights(ctx context.Context, hostID string, intent string) ([]model.BaseQuestion, error) {
-	// 1. Get Room Codes for Host
+// GenerateQuestionsFromInsights collects RecommendedProbes and BestProbes from the host's
+// most recent ended rooms (optionally only those run from surveyID), selects the most
+// common ones and condenses them into a few permanent questions
+func (s *InsightService) GenerateQuestionsFromInsights(ctx context.Context, hostID, intent, surveyID string) (*model.InsightQuestions, error) {
+	intent = strings.TrimSpace(intent)
+	if intent == "" {
+		return nil, fmt.Errorf("%w: intent is required", ErrInvalidInsightRequest)
+	}
+
 	rooms, err := s.roomRepo.GetByHostID(ctx, hostID)
 	if err != nil {
 		return nil, err
 	}
+	rooms = insightRooms(rooms, surveyID)
 
-	// 2. Aggregate Probes
-	var probes []string
+	var perRoom [][]string
 	for _, room := range rooms {
+		snapshot, err := s.reportRepo.GetSnapshot(ctx, room.Code)
+		if err != nil {
+			return nil, err
+		}
 		report, err := s.reportRepo.GetAIReport(ctx, room.Code)
-		if err != nil || report == nil {
+		if err != nil {
+			return nil, err
+		}
+		perRoom = append(perRoom, roomProbes(snapshot, report))
+	}
+
+	probes := selectProbes(perRoom, maxCondenseProbes)
+	questions, err := s.evaluator.CondenseProbes(ctx, probeTexts(probes), intent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.InsightQuestions{
+		Questions:   normalizeInsightQuestions(questions),
+		SourceRooms: len(rooms),
+		Probes:      probes,
+	}, nil
+}
+
+// insightRooms keeps the ended rooms, optionally of one survey, most recently ended first
+func insightRooms(rooms []*model.Room, surveyID string) []*model.Room {
+	var ended []*model.Room
+	for _, room := range rooms {
+		if room.Status != model.RoomStatusEnded || room.Settings.Practice {
 			continue
 		}
+		if surveyID != "" && room.SurveyID != surveyID {
+			continue
+		}
+		ended = append(ended, room)
+	}
+	endedAt := func(r *model.Room) time.Time {
+		if r.EndedAt != nil {
+			return *r.EndedAt
+		}
+		return r.CreatedAt
+	}
+	sort.SliceStable(ended, func(i, j int) bool {
+		return endedAt(ended[i]).After(endedAt(ended[j]))
+	})
+	if len(ended) > insightMaxRooms {
+		ended = ended[:insightMaxRooms]
+	}
+	return ended
+}
 
+// roomProbes gathers every follow-up suggestion one room produced: L4 recommended probes
+// and L3 best probes from the snapshot, plus the AI report's recommendations
+func roomProbes(snapshot *model.RoomSnapshot, report *model.AIReport) []string {
+	var probes []string
+	if snapshot != nil {
+		probes = append(probes, snapshot.Memory.RecommendedProbes...)
+		for _, profile := range snapshot.QuestionProfiles {
+			probes = append(probes, profile.BestProbes...)
+		}
+	}
+	if report != nil {
 		probes = append(probes, report.RecommendedQuestions...)
 		for _, pq := range report.PerQuestionInsights {
 			probes = append(probes, pq.BestFollowUps...)
 		}
 	}
+	return probes
+}
+
+// selectProbes dedupes probes across rooms and ranks them by the number of rooms that
+// suggested them; ties keep the order of the most recent room. perRoom is newest first.
+func selectProbes(perRoom [][]string, limit int) []model.InsightProbe {
+	index := make(map[string]int)
+	var probes []model.InsightProbe
+	for _, room := range perRoom {
+		seen := make(map[string]bool)
+		for _, text := range room {
+			text = strings.Join(strings.Fields(text), " ")
+			if len([]rune(text)) < insightMinProbeLen {
+				continue
+			}
+			key := probeKey(text)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if i, ok := index[key]; ok {
+				probes[i].Rooms++
+				continue
+			}
+			index[key] = len(probes)
+			probes = append(probes, model.InsightProbe{Text: text, Rooms: 1})
+		}
+	}
+
+	sort.SliceStable(probes, func(i, j int) bool {
+		return probes[i].Rooms > probes[j].Rooms
+	})
+	if len(probes) > limit {
+		probes = probes[:limit]
+	}
+	return probes
+}
 
-	// If no probes found (fresh host), return mock/empty?
-	// The evaluator will return mock if config is disabled, but if probes are empty it might produce poor results.
-	// But let's pass it to evaluator anyway, it might hallucinate something useful or use the intent.
+// probeTexts returns the texts to condense. A fresh host, or one whose rooms only
+// produced fragments, has nothing to mine yet; the intent alone still yields a useful draft.
+func probeTexts(probes []model.InsightProbe) []string {
 	if len(probes) == 0 {
-		probes = append(probes, "General follow-up about satisfaction", "Specific details about feature usage")
+		return []string{"General follow-up about satisfaction", "Specific details about feature usage"}
 	}
+	texts := make([]string, len(probes))
+	for i, p := range probes {
+		texts[i] = p.Text
+	}
+	return texts
+}
+
+// probeKey folds case and trailing punctuation so rephrasings of the same probe collide
+func probeKey(text string) string {
+	return strings.TrimRight(strings.ToLower(text), "?.!… ")
+}
 
-	// 3. Call Evaluator
-	return s.evaluator.CondenseProbes(ctx, probes, intent)
+// normalizeInsightQuestions drops unusable questions from the model's output and fills
+// the defaults a survey needs, with keys that cannot collide with hand-written ones
+func normalizeInsightQuestions(questions []model.BaseQuestion) []model.BaseQuestion {
+	out := []model.BaseQuestion{}
+	for _, q := range questions {
+		q.Prompt = strings.TrimSpace(q.Prompt)
+		if q.Prompt == "" {
+			continue
+		}
+		switch q.Type {
+		case model.QuestionTypeEssay:
+			if q.Threshold <= 0 || q.Threshold > 1 {
+				q.Threshold = 0.6
+			}
+		case model.QuestionTypeDegree:
+			if q.ScaleMin >= q.ScaleMax {
+				q.ScaleMin, q.ScaleMax = 1, 5
+			}
+		case model.QuestionTypeMCQ:
+			if len(q.Options) < 2 {
+				continue
+			}
+		default:
+			continue
+		}
+		if q.PointsMax <= 0 {
+			q.PointsMax = 50
+		}
+		q.Key = fmt.Sprintf("Q_Auto%d", len(out)+1)
+		out = append(out, q)
+		if len(out) == insightMaxQuestions {
+			break
+		}
+	}
+	return out
 }
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"reflect"
+	"testing"
+)
+
+func TestSelectProbes(t *testing.T) {
+	tests := []struct {
+		name    string
+		perRoom [][]string // Newest room first
+		limit   int
+		want    []model.InsightProbe
+	}{
+		{
+			name:  "no rooms",
+			limit: 5,
+			want:  nil,
+		},
+		{
+			name:    "rooms without probes",
+			perRoom: [][]string{{}, nil},
+			limit:   5,
+			want:    nil,
+		},
+		{
+			name:    "only fragments",
+			perRoom: [][]string{{"Why?", "  ok   then ", ""}, {"Say more"}},
+			limit:   5,
+			want:    nil,
+		},
+		{
+			name: "ranked by rooms that suggested them",
+			perRoom: [][]string{
+				{"What would you change first?"},
+				{"Which feature do you use most?", "What would you change first?"},
+				{"Which feature do you use most?", "What would you change first?"},
+			},
+			limit: 5,
+			want: []model.InsightProbe{
+				{Text: "What would you change first?", Rooms: 3},
+				{Text: "Which feature do you use most?", Rooms: 2},
+			},
+		},
+		{
+			name: "ties keep the newest room's order",
+			perRoom: [][]string{
+				{"How did onboarding feel?", "What nearly made you quit?"},
+				{"What nearly made you quit?", "How did onboarding feel?"},
+			},
+			limit: 5,
+			want: []model.InsightProbe{
+				{Text: "How did onboarding feel?", Rooms: 2},
+				{Text: "What nearly made you quit?", Rooms: 2},
+			},
+		},
+		{
+			name: "case, spacing and trailing punctuation collide",
+			perRoom: [][]string{
+				{"What   would you change first?"},
+				{"what would you change first", "WHAT WOULD YOU CHANGE FIRST?!"},
+			},
+			limit: 5,
+			want:  []model.InsightProbe{{Text: "What would you change first?", Rooms: 2}},
+		},
+		{
+			name: "repeats within a room count once",
+			perRoom: [][]string{
+				{"Which feature do you use most?", "Which feature do you use most?", "Which feature do you use most."},
+			},
+			limit: 5,
+			want:  []model.InsightProbe{{Text: "Which feature do you use most?", Rooms: 1}},
+		},
+		{
+			name: "limited after ranking",
+			perRoom: [][]string{
+				{"First suggestion here", "Second suggestion here", "Third suggestion here"},
+				{"Third suggestion here"},
+			},
+			limit: 2,
+			want: []model.InsightProbe{
+				{Text: "Third suggestion here", Rooms: 2},
+				{Text: "First suggestion here", Rooms: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selectProbes(tt.perRoom, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selectProbes() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProbeTexts(t *testing.T) {
+	fallback := []string{"General follow-up about satisfaction", "Specific details about feature usage"}
+	tests := []struct {
+		name   string
+		probes []model.InsightProbe
+		want   []string
+	}{
+		{name: "nothing mined falls back", probes: nil, want: fallback},
+		{name: "empty selection falls back", probes: []model.InsightProbe{}, want: fallback},
+		{
+			name:   "selected probes in rank order",
+			probes: []model.InsightProbe{{Text: "What would you change first?", Rooms: 3}, {Text: "Which feature do you use most?", Rooms: 1}},
+			want:   []string{"What would you change first?", "Which feature do you use most?"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := probeTexts(tt.probes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("probeTexts() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	maxIntegrationsPerHost = 10
+	integrationTimeout     = 15 * time.Second
+	summaryThemeCount      = 5
+)
+
+// ErrInvalidIntegration is returned for unsupported providers, events or webhook URLs
+var ErrInvalidIntegration = errors.New("invalid integration")
+
+// IntegrationService posts room summaries to hosts' Slack and Teams channels
+type IntegrationService struct {
+	integrationRepo repository.IntegrationRepo
+	roomRepo        repository.RoomRepo
+	reportSvc       *ReportService
+	httpClient      HTTPDoer
+}
+
+// NewIntegrationService creates a new integration service
+func NewIntegrationService(integrationRepo repository.IntegrationRepo, roomRepo repository.RoomRepo, reportSvc *ReportService) *IntegrationService {
+	return &IntegrationService{
+		integrationRepo: integrationRepo,
+		roomRepo:        roomRepo,
+		reportSvc:       reportSvc,
+		httpClient:      &http.Client{Timeout: integrationTimeout},
+	}
+}
+
+// SetHTTPClient replaces the HTTP client used for webhook posts
+func (s *IntegrationService) SetHTTPClient(h HTTPDoer) {
+	s.httpClient = h
+}
+
+// Create connects a webhook for the host
+func (s *IntegrationService) Create(ctx context.Context, hostID string, req *model.CreateIntegrationRequest) (*model.Integration, error) {
+	if req.Provider != model.IntegrationSlack && req.Provider != model.IntegrationTeams {
+		return nil, fmt.Errorf("%w: provider must be slack or teams", ErrInvalidIntegration)
+	}
+	webhook, err := url.Parse(strings.TrimSpace(req.WebhookURL))
+	if err != nil || webhook.Scheme != "https" || webhook.Host == "" {
+		return nil, fmt.Errorf("%w: webhookUrl must be an https URL", ErrInvalidIntegration)
+	}
+
+	events := req.Events
+	if len(events) == 0 {
+		events = []string{model.IntegrationEventRoomEnded, model.IntegrationEventReportReady, model.IntegrationEventDigest}
+	}
+	for _, e := range events {
+		if e != model.IntegrationEventRoomEnded && e != model.IntegrationEventReportReady && e != model.IntegrationEventDigest {
+			return nil, fmt.Errorf("%w: unknown event %s", ErrInvalidIntegration, e)
+		}
+	}
+
+	existing, err := s.integrationRepo.ListByHost(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxIntegrationsPerHost {
+		return nil, fmt.Errorf("host already has %d integrations", maxIntegrationsPerHost)
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = req.Provider
+	}
+	integration := &model.Integration{
+		HostID:      hostID,
+		Provider:    req.Provider,
+		Name:        name,
+		WebhookURL:  webhook.String(),
+		WebhookHint: webhook.Host,
+		Events:      events,
+	}
+	id, err := s.integrationRepo.Create(ctx, integration)
+	if err != nil {
+		return nil, err
+	}
+	integration.ID = id
+	return integration, nil
+}
+
+// List returns the host's integrations
+func (s *IntegrationService) List(ctx context.Context, hostID string) ([]*model.Integration, error) {
+	return s.integrationRepo.ListByHost(ctx, hostID)
+}
+
+// Delete disconnects one of the host's integrations
+func (s *IntegrationService) Delete(ctx context.Context, hostID, integrationID string) (bool, error) {
+	return s.integrationRepo.Delete(ctx, hostID, integrationID)
+}
+
+// NotifyRoomEnded posts the room's snapshot summary in the background
+func (s *IntegrationService) NotifyRoomEnded(hostID string, snapshot *model.RoomSnapshot) {
+	go s.deliver(hostID, snapshot.RoomCode, model.IntegrationEventRoomEnded, snapshot, nil)
+}
+
+// NotifyReportReady posts the AI report summary in the background
+func (s *IntegrationService) NotifyReportReady(roomCode string, snapshot *model.RoomSnapshot, report *model.AIReport) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), integrationTimeout)
+		room, err := s.roomRepo.GetByCode(ctx, roomCode)
+		cancel()
+		if err != nil || room == nil {
+			return
+		}
+		s.deliver(room.HostID, roomCode, model.IntegrationEventReportReady, snapshot, report)
+	}()
+}
+
+func (s *IntegrationService) deliver(hostID, roomCode, event string, snapshot *model.RoomSnapshot, report *model.AIReport) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*integrationTimeout)
+	defer cancel()
+
+	integrations, err := s.integrationRepo.ListByHost(ctx, hostID)
+	if err != nil {
+		log.Printf("[Integrations] Listing for host %s failed: %v", hostID, err)
+		return
+	}
+
+	var summary *roomSummaryMessage
+	for _, integration := range integrations {
+		if !integration.Wants(event) {
+			continue
+		}
+		if summary == nil {
+			summary = s.buildSummary(ctx, hostID, roomCode, event, snapshot, report)
+		}
+
+		deliveryErr := ""
+		if err := s.post(ctx, integration, summary); err != nil {
+			log.Printf("[Integrations] %s delivery of %s to %s failed: %v", integration.Provider, event, integration.WebhookHint, err)
+			deliveryErr = err.Error()
+		}
+		if err := s.integrationRepo.RecordDelivery(ctx, integration.ID, time.Now(), deliveryErr); err != nil {
+			log.Printf("[Integrations] Recording delivery for %s failed: %v", integration.ID, err)
+		}
+	}
+}
+
+// PostDigest posts the weekly digest to the host's integrations subscribed to it;
+// the error lists the failed deliveries
+func (s *IntegrationService) PostDigest(ctx context.Context, digest *model.Digest) error {
+	integrations, err := s.integrationRepo.ListByHost(ctx, digest.HostID)
+	if err != nil {
+		return err
+	}
+
+	msg := &roomSummaryMessage{
+		Title: fmt.Sprintf("Weekly digest: %s - %s", digest.WeekStart.Format("Jan 2"), digest.WeekEnd.Add(-time.Second).Format("Jan 2")),
+		Lines: []string{fmt.Sprintf("%d rooms, %d players, %.0f%% completion", len(digest.Rooms), digest.Players, digest.CompletionRate*100)},
+		// Digests cover several rooms, so they link the host's report list
+		ReportURL: s.reportSvc.publicURL + "/host/reports",
+	}
+	msg.Lines = append(msg.Lines, digest.Highlights...)
+	for _, t := range digest.TopThemes {
+		msg.Themes = append(msg.Themes, fmt.Sprintf("%s (%d)", t.Theme, t.Count))
+	}
+
+	var failed []string
+	for _, integration := range integrations {
+		if !integration.Wants(model.IntegrationEventDigest) {
+			continue
+		}
+		deliveryErr := ""
+		if err := s.post(ctx, integration, msg); err != nil {
+			log.Printf("[Integrations] %s delivery of %s to %s failed: %v", integration.Provider, model.IntegrationEventDigest, integration.WebhookHint, err)
+			deliveryErr = err.Error()
+			failed = append(failed, integration.WebhookHint+": "+deliveryErr)
+		}
+		if err := s.integrationRepo.RecordDelivery(ctx, integration.ID, time.Now(), deliveryErr); err != nil {
+			log.Printf("[Integrations] Recording delivery for %s failed: %v", integration.ID, err)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// roomSummaryMessage is the provider-neutral content of a chat post
+type roomSummaryMessage struct {
+	Title     string
+	Lines     []string
+	Themes    []string
+	ReportURL string
+}
+
+func (s *IntegrationService) buildSummary(ctx context.Context, hostID, roomCode, event string, snapshot *model.RoomSnapshot, report *model.AIReport) *roomSummaryMessage {
+	msg := &roomSummaryMessage{Title: fmt.Sprintf("Room %s ended", roomCode)}
+	if event == model.IntegrationEventReportReady {
+		msg.Title = fmt.Sprintf("AI report ready for room %s", roomCode)
+	}
+
+	if snapshot != nil {
+		msg.Lines = append(msg.Lines, fmt.Sprintf("%d players, %.0f%% completion", snapshot.TotalPlayers, snapshot.CompletionRate*100))
+		if line := satisfactionLine(snapshot.QuestionProfiles); line != "" {
+			msg.Lines = append(msg.Lines, line)
+		}
+		for _, t := range snapshot.Memory.GlobalThemesTop {
+			if len(msg.Themes) == summaryThemeCount {
+				break
+			}
+			msg.Themes = append(msg.Themes, fmt.Sprintf("%s (%d)", t.Theme, t.Count))
+		}
+	}
+
+	// The AI report's themes are better labelled than raw theme counts
+	if report != nil {
+		if len(report.KeyThemes) > 0 {
+			msg.Themes = msg.Themes[:0]
+			for _, t := range report.KeyThemes {
+				if len(msg.Themes) == summaryThemeCount {
+					break
+				}
+				msg.Themes = append(msg.Themes, fmt.Sprintf("%s (%.0f%%)", t.Name, t.Percentage))
+			}
+		}
+		if len(report.ExecutiveSummary) > 0 {
+			msg.Lines = append(msg.Lines, report.ExecutiveSummary[0])
+		}
+	}
+
+	// Channel members are usually not hosts, so link the read-only share view
+	if link, err := s.reportSvc.CreateShareLink(ctx, roomCode, hostID, model.ShareRequest{}); err == nil && link != nil {
+		msg.ReportURL = link.URL
+	} else {
+		msg.ReportURL = s.reportSvc.publicURL + "/host/reports/" + url.PathEscape(roomCode)
+	}
+
+	return msg
+}
+
+// satisfactionLine summarizes rating questions and SAT resolutions across the room
+func satisfactionLine(profiles []model.QuestionProfile) string {
+	ratingSum, ratingCount, sat, unsat := 0, 0, 0, 0
+	for _, p := range profiles {
+		ratingSum += p.RatingSum
+		ratingCount += p.RatingCount
+		sat += p.SatCount
+		unsat += p.UnsatCount
+	}
+
+	var parts []string
+	if ratingCount > 0 {
+		parts = append(parts, fmt.Sprintf("average rating %.1f", float64(ratingSum)/float64(ratingCount)))
+	}
+	if sat+unsat > 0 {
+		parts = append(parts, fmt.Sprintf("%.0f%% satisfactory answers", float64(sat)*100/float64(sat+unsat)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "Satisfaction: " + strings.Join(parts, ", ")
+}
+
+func (s *IntegrationService) post(ctx context.Context, integration *model.Integration, msg *roomSummaryMessage) error {
+	var payload interface{}
+	switch integration.Provider {
+	case model.IntegrationTeams:
+		payload = teamsPayload(msg)
+	default:
+		payload = slackPayload(msg)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, integration.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+// slackPayload renders the summary as Slack Block Kit, with a plain-text fallback
+func slackPayload(msg *roomSummaryMessage) map[string]interface{} {
+	text := "*" + msg.Title + "*\n" + strings.Join(msg.Lines, "\n")
+	if len(msg.Themes) > 0 {
+		text += "\n*Top themes:* " + strings.Join(msg.Themes, ", ")
+	}
+
+	return map[string]interface{}{
+		"text": msg.Title,
+		"blocks": []interface{}{
+			map[string]interface{}{
+				"type": "section",
+				"text": map[string]string{"type": "mrkdwn", "text": text},
+			},
+			map[string]interface{}{
+				"type": "actions",
+				"elements": []interface{}{
+					map[string]interface{}{
+						"type": "button",
+						"text": map[string]string{"type": "plain_text", "text": "Open full report"},
+						"url":  msg.ReportURL,
+					},
+				},
+			},
+		},
+	}
+}
+
+// teamsPayload renders the summary as an Office 365 connector card
+func teamsPayload(msg *roomSummaryMessage) map[string]interface{} {
+	text := strings.Join(msg.Lines, "<br>")
+	if len(msg.Themes) > 0 {
+		text += "<br>**Top themes:** " + strings.Join(msg.Themes, ", ")
+	}
+
+	return map[string]interface{}{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    msg.Title,
+		"title":      msg.Title,
+		"themeColor": "7C3AED",
+		"text":       text,
+		"potentialAction": []interface{}{
+			map[string]interface{}{
+				"@type":   "OpenUri",
+				"name":    "Open full report",
+				"targets": []map[string]string{{"os": "default", "uri": msg.ReportURL}},
+			},
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/qrcode"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// localePattern accepts BCP 47 style tags like "fr" or "pt-BR"
+var localePattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})?$`)
+
+// joinQRScale is the pixel size of one QR module in the embedded PNG
+const joinQRScale = 8
+
+// JoinLinks builds the full and short join URLs for a room.
+// locale is an optional deep-link hint for the player UI.
+func (s *RoomService) JoinLinks(code, locale string) (joinURL, shortURL string, err error) {
+	if locale != "" && !localePattern.MatchString(locale) {
+		return "", "", fmt.Errorf("invalid locale: %s", locale)
+	}
+
+	full := url.Values{"code": {code}}
+	short := url.Values{}
+	if locale != "" {
+		full.Set("locale", locale)
+		short.Set("l", locale)
+	}
+
+	joinURL = s.publicURL + "/?" + full.Encode()
+	shortURL = s.publicURL + "/j/" + url.PathEscape(code)
+	if len(short) > 0 {
+		shortURL += "?" + short.Encode()
+	}
+	return joinURL, shortURL, nil
+}
+
+// GetJoinInfo returns join links and a QR code for the host to project
+func (s *RoomService) GetJoinInfo(ctx context.Context, code, hostID, locale string) (*model.JoinInfo, *qrcode.Code, error) {
+	room, err := s.roomRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, nil, err
+	}
+	if room == nil {
+		return nil, nil, nil
+	}
+	if room.HostID != hostID {
+		return nil, nil, ErrNotRoomHost
+	}
+
+	joinURL, shortURL, err := s.JoinLinks(code, locale)
+	if err != nil {
+		return nil, nil, err
+	}
+	qr, err := qrcode.Encode(shortURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	png, err := qr.PNG(joinQRScale)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to render QR code: %w", err)
+	}
+
+	return &model.JoinInfo{
+		RoomCode: code,
+		JoinURL:  joinURL,
+		ShortURL: shortURL,
+		QRSVG:    qr.SVG(),
+		QRPNG:    "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+	}, qr, nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"2026champs/internal/events"
+	"2026champs/internal/model"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrPlayerLeft is returned for player actions after the player left the room
+var ErrPlayerLeft = errors.New("player has left the room")
+
+// LeaveRoom takes a player out of a room for good: they are marked ABANDONED, their
+// unanswered questions are recorded as ABANDONED answers, they stop counting towards
+// live completion and their connection is closed. Rooms with
+// RemoveLeaversFromLeaderboard also drop them from the leaderboard. Leaving twice
+// returns the first result; nil means the player does not exist.
+func (s *AnswerService) LeaveRoom(ctx context.Context, roomCode, playerID, reason string) (*model.LeaveResult, error) {
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room meta: %w", err)
+	}
+	if meta == nil {
+		return nil, nil
+	}
+	if meta.Status == model.RoomStatusEnded {
+		return nil, fmt.Errorf("room has ended")
+	}
+
+	player, err := s.playerCache.GetPlayer(ctx, roomCode, playerID)
+	if err != nil || player == nil {
+		return nil, err
+	}
+	if player.HasLeft() {
+		return &model.LeaveResult{
+			PlayerID: playerID,
+			Status:   player.Status,
+			Reason:   reason,
+			LeftAt:   *player.LeftAt,
+		}, nil
+	}
+
+	now := time.Now()
+	player.Status = model.PlayerStatusAbandoned
+	player.LeftAt = &now
+	if err := s.playerCache.SetPlayer(ctx, roomCode, playerID, player); err != nil {
+		return nil, fmt.Errorf("failed to mark player: %w", err)
+	}
+	result := &model.LeaveResult{
+		PlayerID: playerID,
+		Status:   player.Status,
+		Reason:   reason,
+		LeftAt:   now,
+	}
+
+	if result.AbandonedQuestions, err = s.abandonPlayer(ctx, roomCode, playerID); err != nil {
+		return nil, err
+	}
+
+	if meta.Settings().RemoveLeaversFromLeaderboard {
+		if err := s.playerSvc.RemoveFromLeaderboard(ctx, roomCode, playerID); err != nil {
+			fmt.Printf("[Leave] Leaderboard removal for %s failed: %v\n", playerID, err)
+		} else {
+			result.RemovedFromLeaderboard = true
+		}
+	}
+
+	s.playerSvc.ForgetDeparture(roomCode, playerID)
+	if s.broadcaster != nil {
+		s.broadcaster.ToHost(roomCode, events.PlayerAbandoned, events.PlayerAbandonedPayload{
+			PlayerID:               playerID,
+			Reason:                 reason,
+			AbandonedQuestions:     result.AbandonedQuestions,
+			RemovedFromLeaderboard: result.RemovedFromLeaderboard,
+		})
+		s.broadcaster.ToPlayer(roomCode, playerID, events.LeftRoom, events.LeftRoomPayload{Reason: reason})
+		s.broadcaster.DisconnectPlayer(roomCode, playerID)
+	}
+	if err := s.playerSvc.broadcastCompletion(ctx, roomCode, playerID); err != nil {
+		fmt.Printf("[Leave] Completion update for %s failed: %v\n", roomCode, err)
+	}
+
+	fmt.Printf("[Leave] Player %s left room %s (%s, %d questions abandoned)\n",
+		playerID, roomCode, reason, result.AbandonedQuestions)
+	return result, nil
+}
+
+// abandonPlayer records an ABANDONED answer for the player's current and queued
+// questions that have no answer yet, then clears their queue. Returns the count.
+func (s *AnswerService) abandonPlayer(ctx context.Context, roomCode, playerID string) (int, error) {
+	keys := []string{}
+	if current, _ := s.playerCache.GetCurrent(ctx, roomCode, playerID); current != "" {
+		keys = append(keys, current)
+	}
+	queue, err := s.playerCache.GetQueue(ctx, roomCode, playerID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get queue: %w", err)
+	}
+	keys = append(keys, queue...)
+
+	existing, err := s.answerRepo.GetByRoomAndPlayer(ctx, roomCode, playerID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get answers: %w", err)
+	}
+	answered := make(map[string]bool, len(existing))
+	for _, a := range existing {
+		answered[a.QuestionKey] = true
+	}
+
+	var abandoned []*model.Answer
+	for _, key := range keys {
+		if answered[key] {
+			continue
+		}
+		answered[key] = true
+		abandoned = append(abandoned, &model.Answer{
+			RoomCode:    roomCode,
+			PlayerID:    playerID,
+			QuestionKey: key,
+			Status:      model.AnswerStatusSubmitted,
+			Resolution:  model.ResolutionAbandoned,
+		})
+	}
+	if err := s.answerRepo.CreateMany(ctx, abandoned); err != nil {
+		return 0, fmt.Errorf("failed to save abandoned answers: %w", err)
+	}
+
+	if err := s.playerCache.SetQueue(ctx, roomCode, playerID, nil); err != nil {
+		return 0, err
+	}
+	if err := s.playerCache.SetCurrent(ctx, roomCode, playerID, ""); err != nil {
+		return 0, err
+	}
+	return len(abandoned), nil
+}
+
+// checkNotLeft rejects actions from players who left the room
+func (s *AnswerService) checkNotLeft(ctx context.Context, roomCode, playerID string) error {
+	player, err := s.playerCache.GetPlayer(ctx, roomCode, playerID)
+	if err != nil {
+		return err
+	}
+	if player != nil && player.HasLeft() {
+		return ErrPlayerLeft
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"2026champs/internal/events"
+	"2026champs/internal/model"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// GetRoster lists the players in a room with progress and connection status.
+// hostID is checked against the room owner.
+func (s *PlayerService) GetRoster(ctx context.Context, roomCode, hostID string) (*model.LobbyRoster, error) {
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room meta: %w", err)
+	}
+	if meta == nil {
+		return nil, nil
+	}
+	if meta.HostID != hostID {
+		return nil, ErrNotRoomHost
+	}
+	return s.buildRoster(ctx, roomCode, meta.Status)
+}
+
+// buildRoster reads every player in the room from the cache
+func (s *PlayerService) buildRoster(ctx context.Context, roomCode string, status model.RoomStatus) (*model.LobbyRoster, error) {
+	players, err := s.playerCache.GetAllPlayers(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	done, err := s.playerCache.GetDonePlayers(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	doneSet := make(map[string]bool, len(done))
+	for _, id := range done {
+		doneSet[id] = true
+	}
+
+	roster := &model.LobbyRoster{
+		RoomCode: roomCode,
+		Status:   status,
+		Players:  make([]model.LobbyPlayer, 0, len(players)),
+	}
+	for id, p := range players {
+		entry := model.LobbyPlayer{
+			PlayerID:     id,
+			Nickname:     p.Nickname,
+			JoinedAt:     p.JoinedAt,
+			LastActiveAt: p.LastActiveAt,
+			Score:        p.Score,
+			CurrentKey:   p.CurrentKey,
+			Done:         doneSet[id],
+			Connection:   s.connectionStatus(roomCode, id),
+			Left:         p.HasLeft(),
+		}
+		if queue, err := s.playerCache.GetQueue(ctx, roomCode, id); err == nil {
+			entry.Remaining = len(queue)
+		}
+		if entry.Connection != model.ConnectionDisconnected {
+			roster.Connected++
+		}
+		roster.Players = append(roster.Players, entry)
+	}
+	sort.Slice(roster.Players, func(i, j int) bool {
+		return roster.Players[i].JoinedAt.Before(roster.Players[j].JoinedAt)
+	})
+	return roster, nil
+}
+
+// connectionStatus reports the player's presence on this instance
+func (s *PlayerService) connectionStatus(roomCode, playerID string) string {
+	s.presenceMu.Lock()
+	defer s.presenceMu.Unlock()
+	state, ok := s.presence[roomCode][playerID]
+	switch {
+	case !ok:
+		return model.ConnectionDisconnected
+	case state.idle:
+		return model.ConnectionIdle
+	default:
+		return model.ConnectionConnected
+	}
+}
+
+// StartLobbyUpdates broadcasts the roster every interval for rooms still in LOBBY
+// that have players connected to this instance
+func (s *PlayerService) StartLobbyUpdates(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.broadcastLobbies(ctx)
+			}
+		}
+	}()
+}
+
+func (s *PlayerService) broadcastLobbies(ctx context.Context) {
+	if s.broadcaster == nil {
+		return
+	}
+
+	s.presenceMu.Lock()
+	rooms := make([]string, 0, len(s.presence))
+	for roomCode := range s.presence {
+		rooms = append(rooms, roomCode)
+	}
+	s.presenceMu.Unlock()
+
+	for _, roomCode := range rooms {
+		meta, err := s.roomCache.GetMeta(ctx, roomCode)
+		if err != nil || meta == nil || meta.Status != model.RoomStatusLobby {
+			continue
+		}
+		roster, err := s.buildRoster(ctx, roomCode, meta.Status)
+		if err != nil {
+			fmt.Printf("[Lobby] Roster for %s failed: %v\n", roomCode, err)
+			continue
+		}
+		s.broadcaster.ToRoom(roomCode, events.LobbyUpdate, roster)
+	}
+}
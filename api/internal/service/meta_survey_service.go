@@ -0,0 +1,177 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Meta-survey errors
+var (
+	ErrInvalidMetaFeedback = errors.New("invalid meta feedback")
+	ErrMetaSurveyOff       = errors.New("meta-survey is not enabled for this room")
+	ErrMetaSurveyClosed    = errors.New("meta-survey is closed: the room is not active")
+	ErrNotFinished         = errors.New("finish the survey first")
+	ErrMetaAnswered        = errors.New("meta-survey already answered")
+)
+
+// MetaSurveyService runs the optional meta-survey: after their last question
+// players rate the survey's clarity and length. Answers are aggregated per room
+// into the snapshot and per survey across its ended rooms.
+type MetaSurveyService struct {
+	metaCache   cache.MetaFeedbackCache
+	roomCache   cache.RoomCache
+	playerCache cache.PlayerCache
+	roomRepo    repository.RoomRepo
+	reportRepo  repository.ReportRepo
+	surveyRepo  repository.SurveyRepo
+}
+
+// NewMetaSurveyService creates a new meta-survey service
+func NewMetaSurveyService(
+	metaCache cache.MetaFeedbackCache,
+	roomCache cache.RoomCache,
+	playerCache cache.PlayerCache,
+	roomRepo repository.RoomRepo,
+	reportRepo repository.ReportRepo,
+	surveyRepo repository.SurveyRepo,
+) *MetaSurveyService {
+	return &MetaSurveyService{
+		metaCache:   metaCache,
+		roomCache:   roomCache,
+		playerCache: playerCache,
+		roomRepo:    roomRepo,
+		reportRepo:  reportRepo,
+		surveyRepo:  surveyRepo,
+	}
+}
+
+// Pending returns the meta-survey questions for a player who finished their
+// queue and has not answered them yet; nil otherwise
+func (s *MetaSurveyService) Pending(ctx context.Context, meta *model.RoomMeta, roomCode, playerID string) []*model.Question {
+	if meta == nil || !meta.MetaSurvey || meta.Status != model.RoomStatusActive {
+		return nil
+	}
+	answered, err := s.metaCache.Has(ctx, roomCode, playerID)
+	if err != nil || answered {
+		return nil
+	}
+	return model.MetaQuestions()
+}
+
+// Submit records a finished player's meta-survey answers, once per player
+func (s *MetaSurveyService) Submit(ctx context.Context, roomCode, playerID string, req *model.MetaFeedbackRequest) error {
+	if req.Clarity == nil && req.Length == nil {
+		return fmt.Errorf("%w: answer clarity, length or both", ErrInvalidMetaFeedback)
+	}
+	for _, v := range []*int{req.Clarity, req.Length} {
+		if v != nil && (*v < model.MetaScaleMin || *v > model.MetaScaleMax) {
+			return fmt.Errorf("%w: answers must be between %d and %d", ErrInvalidMetaFeedback, model.MetaScaleMin, model.MetaScaleMax)
+		}
+	}
+
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil {
+		return err
+	}
+	if meta == nil || !meta.MetaSurvey {
+		return ErrMetaSurveyOff
+	}
+	if meta.Status != model.RoomStatusActive {
+		return ErrMetaSurveyClosed
+	}
+	player, err := s.playerCache.GetPlayer(ctx, roomCode, playerID)
+	if err != nil {
+		return err
+	}
+	if player != nil && player.HasLeft() {
+		return ErrPlayerLeft
+	}
+	current, err := s.playerCache.GetCurrent(ctx, roomCode, playerID)
+	if err != nil {
+		return err
+	}
+	if current != "" {
+		return ErrNotFinished
+	}
+
+	saved, err := s.metaCache.Save(ctx, roomCode, playerID, req)
+	if err != nil {
+		return err
+	}
+	if !saved {
+		return ErrMetaAnswered
+	}
+	return nil
+}
+
+// ForSnapshot aggregates the room's meta-survey answers; nil when there are none
+func (s *MetaSurveyService) ForSnapshot(ctx context.Context, roomCode string) *model.MetaFeedback {
+	answers, err := s.metaCache.GetAll(ctx, roomCode)
+	if err != nil {
+		fmt.Printf("[MetaSurvey] Answers of %s unavailable for the snapshot: %v\n", roomCode, err)
+		return nil
+	}
+	if len(answers) == 0 {
+		return nil
+	}
+	out := &model.MetaFeedback{Responses: len(answers)}
+	for _, a := range answers {
+		if a.Clarity != nil {
+			out.Clarity.Add(*a.Clarity)
+		}
+		if a.Length != nil {
+			out.Length.Add(*a.Length)
+		}
+	}
+	return out
+}
+
+// ForSurvey collects the meta-feedback of a host's survey from the snapshots of
+// its ended, non-practice rooms. Nil if the survey is not the host's.
+func (s *MetaSurveyService) ForSurvey(ctx context.Context, surveyID, hostID string) (*model.SurveyMetaFeedback, error) {
+	survey, err := s.surveyRepo.GetByID(ctx, surveyID)
+	if err != nil || survey == nil {
+		return nil, err
+	}
+	if survey.HostID != hostID || survey.DeletedAt != nil {
+		return nil, nil
+	}
+
+	rooms, err := s.roomRepo.GetBySurveyID(ctx, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	codes := make([]string, 0, len(rooms))
+	for _, room := range rooms {
+		if room.Status == model.RoomStatusEnded && !room.Settings.Practice {
+			codes = append(codes, room.Code)
+		}
+	}
+
+	out := &model.SurveyMetaFeedback{SurveyID: surveyID, Rooms: []model.RoomMetaFeedback{}}
+	if len(codes) == 0 {
+		return out, nil
+	}
+	snapshots, err := s.reportRepo.GetSnapshots(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+	for _, snap := range snapshots {
+		if snap.MetaFeedback == nil {
+			continue
+		}
+		out.Rooms = append(out.Rooms, model.RoomMetaFeedback{
+			RoomCode:     snap.RoomCode,
+			EndedAt:      snap.EndedAt,
+			MetaFeedback: *snap.MetaFeedback,
+		})
+		out.Overall.Merge(snap.MetaFeedback)
+	}
+	sort.Slice(out.Rooms, func(i, j int) bool { return out.Rooms[i].EndedAt.Before(out.Rooms[j].EndedAt) })
+	return out, nil
+}
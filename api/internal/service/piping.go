@@ -0,0 +1,106 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxPipedLength caps how much of an essay answer is piped into a later prompt
+const maxPipedLength = 200
+
+// pipePattern matches {{Q2.answer}} and {{Q2.answer|fallback}} placeholders
+var pipePattern = regexp.MustCompile(`\{\{\s*([^{}|\s]+)\.answer\s*(?:\|([^{}]*))?\}\}`)
+
+// pipedValue is the form of an answer shown when a later prompt references it
+func pipedValue(q *model.Question, req *model.SubmitAnswerRequest) string {
+	switch q.Type {
+	case model.QuestionTypeMCQ:
+		if req.OptionIndex != nil && *req.OptionIndex >= 0 && *req.OptionIndex < len(q.Options) {
+			return q.Options[*req.OptionIndex]
+		}
+		return ""
+	case model.QuestionTypeDegree:
+		return strconv.Itoa(req.DegreeValue)
+	case model.QuestionTypeMatrix:
+		cells := make([]string, 0, len(req.MatrixValues))
+		for row, col := range req.MatrixValues {
+			if row < len(q.Rows) && col >= 0 && col < len(q.Columns) {
+				cells = append(cells, q.Rows[row]+": "+q.Columns[col])
+			}
+		}
+		return strings.Join(cells, ", ")
+	case model.QuestionTypeRanking:
+		ordered := make([]string, 0, len(req.Ranking))
+		for _, opt := range req.Ranking {
+			if opt >= 0 && opt < len(q.Options) {
+				ordered = append(ordered, q.Options[opt])
+			}
+		}
+		return strings.Join(ordered, " > ")
+	default:
+		text := strings.Join(strings.Fields(req.TextAnswer), " ")
+		if runes := []rune(text); len(runes) > maxPipedLength {
+			text = strings.TrimSpace(string(runes[:maxPipedLength])) + "…"
+		}
+		return text
+	}
+}
+
+// hasPipes reports whether q's prompt has placeholders, so it reads differently per player
+func hasPipes(q *model.Question) bool {
+	return q != nil && pipePattern.MatchString(q.Prompt)
+}
+
+// ResolvePrompt returns q with {{KEY.answer}} placeholders replaced by the
+// player's earlier answers. Unanswered references use the placeholder's
+// fallback, or nothing. The stored question keeps its template.
+func (s *PlayerService) ResolvePrompt(ctx context.Context, roomCode, playerID string, q *model.Question) *model.Question {
+	if q == nil || !strings.Contains(q.Prompt, "{{") {
+		return q
+	}
+
+	// Read every referenced answer in one round trip
+	var keys []string
+	for _, m := range pipePattern.FindAllStringSubmatch(q.Prompt, -1) {
+		keys = append(keys, m[1])
+	}
+	states, err := s.playerCache.GetAttemptStates(ctx, roomCode, playerID, keys)
+	if err != nil {
+		states = nil // Fall back to every placeholder's default
+	}
+
+	prompt := pipePattern.ReplaceAllStringFunc(q.Prompt, func(match string) string {
+		parts := pipePattern.FindStringSubmatch(match)
+		key, fallback := parts[1], strings.TrimSpace(parts[2])
+		value := ""
+		if state := states[key]; state != nil {
+			value = state.PipedValue
+		}
+		if value == "" {
+			return fallback
+		}
+		return value
+	})
+
+	resolved := *q
+	resolved.Prompt = prompt
+	return &resolved
+}
+
+// validatePiping checks that prompts only pipe answers of earlier questions
+func validatePiping(questions []model.BaseQuestion) error {
+	seen := make(map[string]bool, len(questions))
+	for _, q := range questions {
+		for _, m := range pipePattern.FindAllStringSubmatch(q.Prompt, -1) {
+			if !seen[m[1]] {
+				return fmt.Errorf("%w: question %s: %s must refer to an earlier question", ErrInvalidSurvey, q.Key, m[0])
+			}
+		}
+		seen[q.Key] = true
+	}
+	return nil
+}
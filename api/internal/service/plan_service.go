@@ -0,0 +1,239 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/config"
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// planCacheTTL is how long a host's plan assignment is reused before Mongo is read again
+const planCacheTTL = time.Minute
+
+// Plan errors
+var (
+	ErrPlanLimit   = errors.New("plan limit reached")
+	ErrUnknownPlan = errors.New("unknown plan")
+)
+
+// PlanLimitError says which limit of which plan a request ran into; it wraps ErrPlanLimit
+type PlanLimitError struct {
+	Plan     string `json:"plan"`
+	Resource string `json:"resource"` // model.PlanResource*
+	Limit    int64  `json:"limit"`
+	Used     int64  `json:"used"`
+}
+
+func (e *PlanLimitError) Error() string {
+	return fmt.Sprintf("%s: the %s plan allows %d %s (%d in use)", ErrPlanLimit, e.Plan, e.Limit, e.Resource, e.Used)
+}
+
+func (e *PlanLimitError) Unwrap() error { return ErrPlanLimit }
+
+type cachedPlan struct {
+	name      string
+	fetchedAt time.Time
+}
+
+// PlanService enforces per-host plan limits: surveys, concurrent rooms, players
+// per room and monthly AI calls. Plans are configured in config.PlanConfig and
+// assigned to hosts in Mongo; AI calls are counted in Redis per calendar month.
+type PlanService struct {
+	planRepo    repository.PlanRepo
+	surveyRepo  repository.SurveyRepo
+	roomRepo    repository.RoomRepo
+	roomCache   cache.RoomCache
+	playerCache cache.PlayerCache
+	usage       cache.AIUsageCache
+	config      *config.PlanConfig
+
+	mu    sync.Mutex
+	plans map[string]cachedPlan // hostID -> assigned plan
+}
+
+// NewPlanService creates a new plan service
+func NewPlanService(
+	planRepo repository.PlanRepo,
+	surveyRepo repository.SurveyRepo,
+	roomRepo repository.RoomRepo,
+	roomCache cache.RoomCache,
+	playerCache cache.PlayerCache,
+	usage cache.AIUsageCache,
+	cfg *config.PlanConfig,
+) *PlanService {
+	return &PlanService{
+		planRepo:    planRepo,
+		surveyRepo:  surveyRepo,
+		roomRepo:    roomRepo,
+		roomCache:   roomCache,
+		playerCache: playerCache,
+		usage:       usage,
+		config:      cfg,
+		plans:       make(map[string]cachedPlan),
+	}
+}
+
+// Plans returns the configured plan names, sorted
+func (s *PlanService) Plans() []string {
+	names := make([]string, 0, len(s.config.Plans))
+	for name := range s.config.Plans {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PlanOf returns the host's plan and its limits. A plan assignment that is no
+// longer configured falls back to the default plan.
+func (s *PlanService) PlanOf(ctx context.Context, hostID string) (string, model.PlanLimits, error) {
+	s.mu.Lock()
+	cached, ok := s.plans[hostID]
+	s.mu.Unlock()
+
+	name := cached.name
+	if !ok || time.Since(cached.fetchedAt) > planCacheTTL {
+		assigned, err := s.planRepo.Get(ctx, hostID)
+		if err != nil {
+			return "", model.PlanLimits{}, err
+		}
+		name = ""
+		if assigned != nil {
+			name = assigned.Plan
+		}
+		s.mu.Lock()
+		s.plans[hostID] = cachedPlan{name: name, fetchedAt: time.Now()}
+		s.mu.Unlock()
+	}
+
+	limits, ok := s.config.Plans[name]
+	if !ok {
+		name = s.config.DefaultPlan
+		limits = s.config.Plans[name]
+	}
+	return name, limits, nil
+}
+
+// SetPlan puts the host on a configured plan
+func (s *PlanService) SetPlan(ctx context.Context, hostID, plan string) error {
+	if _, ok := s.config.Plans[plan]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownPlan, plan)
+	}
+	if err := s.planRepo.Set(ctx, hostID, plan); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	delete(s.plans, hostID)
+	s.mu.Unlock()
+	fmt.Printf("[Plan] Host %s is now on the %s plan\n", hostID, plan)
+	return nil
+}
+
+// CheckSurveys returns a *PlanLimitError when the host cannot add another survey
+func (s *PlanService) CheckSurveys(ctx context.Context, hostID string) error {
+	plan, limits, err := s.PlanOf(ctx, hostID)
+	if err != nil || limits.MaxSurveys <= 0 {
+		return err
+	}
+	n, err := s.surveyRepo.CountByHost(ctx, hostID)
+	if err != nil {
+		return err
+	}
+	return checkLimit(plan, model.PlanResourceSurveys, int64(limits.MaxSurveys), n)
+}
+
+// CheckRooms returns a *PlanLimitError when the host cannot open another room
+func (s *PlanService) CheckRooms(ctx context.Context, hostID string) error {
+	plan, limits, err := s.PlanOf(ctx, hostID)
+	if err != nil || limits.MaxConcurrentRooms <= 0 {
+		return err
+	}
+	n, err := s.roomRepo.CountOpenByHost(ctx, hostID)
+	if err != nil {
+		return err
+	}
+	return checkLimit(plan, model.PlanResourceRooms, int64(limits.MaxConcurrentRooms), n)
+}
+
+// CheckPlayers returns a *PlanLimitError when the room's host cannot take another
+// player in it. Unknown rooms pass; joining reports them.
+func (s *PlanService) CheckPlayers(ctx context.Context, roomCode string) error {
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil || meta == nil {
+		return err
+	}
+	plan, limits, err := s.PlanOf(ctx, meta.HostID)
+	if err != nil || limits.MaxPlayersPerRoom <= 0 {
+		return err
+	}
+	players, err := s.playerCache.GetAllPlayers(ctx, roomCode)
+	if err != nil {
+		return err
+	}
+	var n int64
+	for _, p := range players {
+		if !p.HasLeft() {
+			n++
+		}
+	}
+	return checkLimit(plan, model.PlanResourcePlayers, int64(limits.MaxPlayersPerRoom), n)
+}
+
+// AICallsLeft reports whether the host's plan still allows AI calls this month;
+// errors allow the call, the global and room budgets still apply
+func (s *PlanService) AICallsLeft(ctx context.Context, hostID string) bool {
+	_, limits, err := s.PlanOf(ctx, hostID)
+	if err != nil || limits.MonthlyAICalls <= 0 {
+		return true
+	}
+	usage, err := s.usage.GetHostUsage(ctx, hostID, time.Now())
+	if err != nil {
+		return true
+	}
+	return usage.Calls < limits.MonthlyAICalls
+}
+
+// Usage reports the host's plan, its limits and how much of them is in use
+func (s *PlanService) Usage(ctx context.Context, hostID string) (*model.HostUsage, error) {
+	plan, limits, err := s.PlanOf(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	surveys, err := s.surveyRepo.CountByHost(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	rooms, err := s.roomRepo.CountOpenByHost(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	ai, err := s.usage.GetHostUsage(ctx, hostID, now)
+	if err != nil {
+		return nil, err
+	}
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return &model.HostUsage{
+		HostID:           hostID,
+		Plan:             plan,
+		Limits:           limits,
+		Surveys:          int(surveys),
+		ConcurrentRooms:  int(rooms),
+		AICallsThisMonth: ai.Calls,
+		Month:            month.Format("2006-01"),
+		ResetsAt:         month.AddDate(0, 1, 0),
+	}, nil
+}
+
+func checkLimit(plan, resource string, limit, used int64) error {
+	if used < limit {
+		return nil
+	}
+	return &PlanLimitError{Plan: plan, Resource: resource, Limit: limit, Used: used}
+}
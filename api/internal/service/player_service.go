@@ -2,10 +2,15 @@ package service
 
 import (
 	"2026champs/internal/cache"
+	"2026champs/internal/events"
 	"2026champs/internal/model"
 	"2026champs/internal/repository"
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,6 +24,25 @@ type PlayerService struct {
 	leaderboard cache.LeaderboardCache
 	authSvc     *AuthService
 	broadcaster Broadcaster
+	draining    atomic.Bool
+
+	experimentSvc *ExperimentService
+	answerSvc     *AnswerService // Finalizes players who stay disconnected
+	timeseries    *TimeseriesService
+	metaSurvey    *MetaSurveyService
+
+	// Presence of players connected to this instance
+	presenceMu sync.Mutex
+	presence   map[string]map[string]*presenceState // roomCode -> playerID -> state
+	departed   map[string]map[string]time.Time      // roomCode -> playerID -> disconnected at
+	idleAfter  time.Duration
+
+	stuckAfter time.Duration // Heatmap: time on one question before a player counts as stuck
+
+	// Progress estimates: room averages, recomputed at most every paceTTL
+	analyticsSvc *AnalyticsService
+	paceMu       sync.Mutex
+	paces        map[string]*roomPace
 }
 
 // NewPlayerService creates a new player service
@@ -35,6 +59,11 @@ func NewPlayerService(
 		playerCache: playerCache,
 		leaderboard: leaderboard,
 		authSvc:     authSvc,
+		presence:    make(map[string]map[string]*presenceState),
+		departed:    make(map[string]map[string]time.Time),
+		idleAfter:   defaultIdleAfter,
+		stuckAfter:  defaultStuckAfter,
+		paces:       make(map[string]*roomPace),
 	}
 }
 
@@ -43,6 +72,44 @@ func (s *PlayerService) SetBroadcaster(b Broadcaster) {
 	s.broadcaster = b
 }
 
+// SetExperimentService assigns joining players to variants in rooms running an experiment
+func (s *PlayerService) SetExperimentService(e *ExperimentService) {
+	s.experimentSvc = e
+}
+
+// SetAnswerService lets the idle monitor finalize players who stay disconnected
+func (s *PlayerService) SetAnswerService(a *AnswerService) {
+	s.answerSvc = a
+}
+
+// SetAnalyticsService enables follow-up and time estimates in player progress
+func (s *PlayerService) SetAnalyticsService(a *AnalyticsService) {
+	s.analyticsSvc = a
+}
+
+// SetMetaSurveyService offers the meta-survey to players who finished their queue
+func (s *PlayerService) SetMetaSurveyService(m *MetaSurveyService) {
+	s.metaSurvey = m
+}
+
+// SetTimeseriesService counts joins per minute for the participation curve
+func (s *PlayerService) SetTimeseriesService(t *TimeseriesService) {
+	s.timeseries = t
+}
+
+// ErrDraining is returned for new joins while the server drains for a deploy
+var ErrDraining = errors.New("server is draining, please reconnect")
+
+// StartDrain stops accepting new joins
+func (s *PlayerService) StartDrain() {
+	s.draining.Store(true)
+}
+
+// IsDraining reports whether the server is draining
+func (s *PlayerService) IsDraining() bool {
+	return s.draining.Load()
+}
+
 // checkRoomActive helper
 func (s *PlayerService) checkRoomActive(ctx context.Context, roomCode string) error {
 	meta, err := s.roomCache.GetMeta(ctx, roomCode)
@@ -60,6 +127,10 @@ func (s *PlayerService) checkRoomActive(ctx context.Context, roomCode string) er
 
 // JoinRoom handles player joining a room
 func (s *PlayerService) JoinRoom(ctx context.Context, roomCode, nickname string) (*model.PlayerJoinResponse, error) {
+	if s.draining.Load() {
+		return nil, ErrDraining
+	}
+
 	// Get room meta
 	meta, err := s.roomCache.GetMeta(ctx, roomCode)
 	if err != nil {
@@ -91,6 +162,9 @@ func (s *PlayerService) JoinRoom(ctx context.Context, roomCode, nickname string)
 		LastActiveAt:  now,
 		JoinedAt:      now,
 	}
+	if meta.Experiment != nil && s.experimentSvc != nil {
+		player.Variant = s.experimentSvc.AssignPlayer(ctx, roomCode, playerID, meta.Experiment)
+	}
 
 	// Store in Redis
 	if err := s.playerCache.SetPlayer(ctx, roomCode, playerID, player); err != nil {
@@ -98,7 +172,7 @@ func (s *PlayerService) JoinRoom(ctx context.Context, roomCode, nickname string)
 	}
 
 	// Initialize leaderboard entry
-	if err := s.leaderboard.UpdateScore(ctx, roomCode, playerID, 0); err != nil {
+	if err := s.leaderboard.UpdateScore(ctx, roomCode, playerID, 0, 0); err != nil {
 		return nil, fmt.Errorf("failed to init leaderboard: %w", err)
 	}
 
@@ -111,25 +185,18 @@ func (s *PlayerService) JoinRoom(ctx context.Context, roomCode, nickname string)
 		return nil, fmt.Errorf("survey not found")
 	}
 
-	// Initialize player queue with base questions
+	// Base questions are stored once per room; the player's qmap only gains follow-ups
+	if err := s.ensureBaseQuestions(ctx, roomCode, survey); err != nil {
+		return nil, fmt.Errorf("failed to set base questions: %w", err)
+	}
+
+	// Initialize player queue with base questions; branch targets wait in the base
+	// questions until a branch rule queues them
+	branchTargets := survey.BranchTargets()
 	var questionKeys []string
 	for _, q := range survey.Questions {
-		questionKeys = append(questionKeys, q.Key)
-
-		// Store question in player's qmap
-		question := &model.Question{
-			Key:       q.Key,
-			Type:      q.Type,
-			Prompt:    q.Prompt,
-			Rubric:    q.Rubric,
-			PointsMax: q.PointsMax,
-			Threshold: q.Threshold,
-			ScaleMin:  q.ScaleMin,
-			ScaleMax:  q.ScaleMax,
-			Options:   q.Options,
-		}
-		if err := s.playerCache.SetQuestionMap(ctx, roomCode, playerID, q.Key, question); err != nil {
-			return nil, fmt.Errorf("failed to set question map: %w", err)
+		if !branchTargets[q.Key] {
+			questionKeys = append(questionKeys, q.Key)
 		}
 	}
 
@@ -149,6 +216,8 @@ func (s *PlayerService) JoinRoom(ctx context.Context, roomCode, nickname string)
 			return nil, err
 		}
 		firstQuestion, _ = s.playerCache.GetQuestionMap(ctx, roomCode, playerID, firstKey)
+		firstQuestion = s.ResolvePrompt(ctx, roomCode, playerID, firstQuestion)
+		s.markServed(ctx, roomCode, playerID, firstKey, nil)
 	} else if len(questionKeys) > 0 {
 		// Initialize current key but don't return question yet if in lobby
 		firstKey := questionKeys[0]
@@ -161,43 +230,173 @@ func (s *PlayerService) JoinRoom(ctx context.Context, roomCode, nickname string)
 		}
 	}
 
+	if s.timeseries != nil {
+		s.timeseries.Record(ctx, roomCode, cache.MetricJoins)
+	}
+
 	return &model.PlayerJoinResponse{
 		PlayerID:      playerID,
 		Token:         token,
 		RoomMeta:      meta,
 		FirstQuestion: firstQuestion,
+		Branding:      meta.Branding,
+		Practice:      meta.Settings().Practice,
 	}, nil
 }
 
+// ensureBaseQuestions stores the survey's questions once per room on the first join
+func (s *PlayerService) ensureBaseQuestions(ctx context.Context, roomCode string, survey *model.Survey) error {
+	exists, err := s.playerCache.HasBaseQuestions(ctx, roomCode)
+	if err != nil || exists {
+		return err
+	}
+
+	questions := make([]*model.Question, 0, len(survey.Questions))
+	for _, q := range survey.Questions {
+		question := &model.Question{
+			Key:       q.Key,
+			Type:      q.Type,
+			Prompt:    q.Prompt,
+			Rubric:    q.Rubric,
+			PointsMax: q.PointsMax,
+			Threshold: q.Threshold,
+			ScaleMin:  q.ScaleMin,
+			ScaleMax:  q.ScaleMax,
+			Options:   q.Options,
+			Rows:      q.Rows,
+			Columns:   q.Columns,
+			AI:        q.AI,
+		}
+		question.Media = q.Media
+		question.AllowAttachments = q.AllowAttachments
+		question.AllowVoice = q.AllowVoice
+		question.MinLength = q.MinLength
+		question.MaxLength = q.MaxLength
+		questions = append(questions, question)
+	}
+	return s.playerCache.SetBaseQuestions(ctx, roomCode, questions)
+}
+
 // GetCurrentQuestion retrieves the player's current question and details
 func (s *PlayerService) GetCurrentQuestion(ctx context.Context, roomCode, playerID string) (*model.Question, *model.Player, error) {
+	state, err := s.GetCurrentState(ctx, roomCode, playerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return state.Question, state.Player, nil
+}
+
+// GetCurrentState reads the player, their current question (prompt resolved) and
+// its attempt state with pipelined Redis reads. Question is nil in the lobby and
+// once the queue is exhausted.
+func (s *PlayerService) GetCurrentState(ctx context.Context, roomCode, playerID string) (*model.CurrentState, error) {
 	// Check room status
 	meta, err := s.roomCache.GetMeta(ctx, roomCode)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get room meta: %w", err)
+		return nil, fmt.Errorf("failed to get room meta: %w", err)
 	}
 	if meta == nil {
-		return nil, nil, fmt.Errorf("room not found")
+		return nil, fmt.Errorf("room not found")
 	}
-	// Fetch the player so we can return their current score
-	player, err := s.playerCache.GetPlayer(ctx, roomCode, playerID)
+
+	state, err := s.playerCache.GetPlayerState(ctx, roomCode, playerID)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-
 	if meta.Status == model.RoomStatusLobby {
-		return nil, player, nil // Waiting for host
+		// Waiting for host; only the score is shown
+		return &model.CurrentState{Player: state.Player}, nil
+	}
+	state.Question = s.ResolvePrompt(ctx, roomCode, playerID, state.Question)
+	if state.Question != nil {
+		state.Attempt = s.markServed(ctx, roomCode, playerID, state.CurrentKey, state.Attempt)
+	} else if s.metaSurvey != nil {
+		state.MetaQuestions = s.metaSurvey.Pending(ctx, meta, roomCode, playerID)
 	}
+	return state, nil
+}
 
-	currentKey, err := s.playerCache.GetCurrent(ctx, roomCode, playerID)
+// markServed stamps the first time a question is shown to the player, the start
+// of its response time. attempt is the current state if already read; the
+// stamped state is returned. Failures only cost the response time.
+func (s *PlayerService) markServed(ctx context.Context, roomCode, playerID, questionKey string, attempt *model.AttemptState) *model.AttemptState {
+	if attempt == nil {
+		attempt, _ = s.playerCache.GetAttempt(ctx, roomCode, playerID, questionKey)
+	}
+	if attempt != nil && attempt.ServedAt != nil {
+		return attempt
+	}
+	if attempt == nil {
+		attempt = &model.AttemptState{Status: model.AnswerStatusDraft}
+	}
+	now := time.Now()
+	attempt.ServedAt = &now
+	attempt.UpdatedAt = now
+	if err := s.playerCache.SetAttempt(ctx, roomCode, playerID, questionKey, attempt); err != nil {
+		fmt.Printf("[Player] Failed to mark %s served for %s in %s: %v\n", questionKey, playerID, roomCode, err)
+	}
+	return attempt
+}
+
+// GetCompletion returns how many players in the room have finished their queue;
+// players who left count towards neither number
+func (s *PlayerService) GetCompletion(ctx context.Context, roomCode string) (completed, total int, err error) {
+	done, err := s.playerCache.GetDonePlayers(ctx, roomCode)
 	if err != nil {
-		return nil, nil, err
+		return 0, 0, err
+	}
+	players, err := s.playerCache.GetAllPlayers(ctx, roomCode)
+	if err != nil {
+		return 0, 0, err
+	}
+	completed, total = countCompletion(players, done)
+	return completed, total, nil
+}
+
+// countCompletion counts finished and total players, leaving out players who left
+func countCompletion(players map[string]*model.Player, done []string) (completed, total int) {
+	for _, p := range players {
+		if !p.HasLeft() {
+			total++
+		}
+	}
+	for _, id := range done {
+		if p, ok := players[id]; ok && !p.HasLeft() {
+			completed++
+		}
+	}
+	return completed, total
+}
+
+// markDone flags the player as finished and pushes live completion to the host
+func (s *PlayerService) markDone(ctx context.Context, roomCode, playerID string) error {
+	if err := s.playerCache.MarkDone(ctx, roomCode, playerID); err != nil {
+		return err
 	}
-	if currentKey == "" {
-		return nil, player, nil // No more questions
+	return s.broadcastCompletion(ctx, roomCode, playerID)
+}
+
+// broadcastCompletion pushes live completion to the host after playerID finished or left
+func (s *PlayerService) broadcastCompletion(ctx context.Context, roomCode, playerID string) error {
+	if s.broadcaster == nil {
+		return nil
+	}
+
+	completed, total, err := s.GetCompletion(ctx, roomCode)
+	if err != nil {
+		return err
 	}
-	q, err := s.playerCache.GetQuestionMap(ctx, roomCode, playerID, currentKey)
-	return q, player, err
+	rate := 0.0
+	if total > 0 {
+		rate = float64(completed) / float64(total)
+	}
+	s.broadcaster.ToHost(roomCode, events.CompletionUpdate, events.CompletionUpdatePayload{
+		PlayerID:       playerID,
+		Completed:      completed,
+		Total:          total,
+		CompletionRate: rate,
+	})
+	return nil
 }
 
 // AdvanceToNextQuestion moves to the next question in queue
@@ -222,6 +421,9 @@ func (s *PlayerService) AdvanceToNextQuestion(ctx context.Context, roomCode, pla
 		if err := s.playerCache.SetCurrent(ctx, roomCode, playerID, ""); err != nil {
 			return nil, err
 		}
+		if err := s.markDone(ctx, roomCode, playerID); err != nil {
+			return nil, err
+		}
 		return nil, nil
 	}
 
@@ -260,8 +462,9 @@ func (s *PlayerService) AdvanceToNextQuestion(ctx context.Context, roomCode, pla
 			return nil, err
 		}
 	}
+	s.markServed(ctx, roomCode, playerID, nextKey, nil)
 
-	return q, nil
+	return s.ResolvePrompt(ctx, roomCode, playerID, q), nil
 }
 
 // InsertFollowUp inserts a follow-up question after the current question
@@ -283,6 +486,46 @@ func (s *PlayerService) InsertFollowUp(ctx context.Context, roomCode, playerID s
 	return s.playerCache.InsertInQueue(ctx, roomCode, playerID, currentKey, followUp.Key)
 }
 
+// InsertBranch queues branch target questions after the current question.
+// Targets are already in the player's qmap; ones answered before are skipped,
+// and players who finished their queue get no more questions.
+func (s *PlayerService) InsertBranch(ctx context.Context, roomCode, playerID string, keys []string) error {
+	if err := s.checkRoomActive(ctx, roomCode); err != nil {
+		return err
+	}
+	currentKey, err := s.playerCache.GetCurrent(ctx, roomCode, playerID)
+	if err != nil || currentKey == "" {
+		return err
+	}
+
+	var pending []string
+	for _, k := range keys {
+		state, err := s.playerCache.GetAttempt(ctx, roomCode, playerID, k)
+		if err != nil {
+			return err
+		}
+		if state == nil {
+			pending = append(pending, k)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	return s.playerCache.InsertInQueue(ctx, roomCode, playerID, currentKey, pending...)
+}
+
+// EndEarly clears the player's remaining questions and marks them finished,
+// e.g. when a screening quota turns them away
+func (s *PlayerService) EndEarly(ctx context.Context, roomCode, playerID string) error {
+	if err := s.playerCache.SetQueue(ctx, roomCode, playerID, nil); err != nil {
+		return err
+	}
+	if err := s.playerCache.SetCurrent(ctx, roomCode, playerID, ""); err != nil {
+		return err
+	}
+	return s.markDone(ctx, roomCode, playerID)
+}
+
 // GetPlayer retrieves a player by ID
 func (s *PlayerService) GetPlayer(ctx context.Context, roomCode, playerID string) (*model.Player, error) {
 	return s.playerCache.GetPlayer(ctx, roomCode, playerID)
@@ -307,21 +550,62 @@ func (s *PlayerService) UpdateScore(ctx context.Context, roomCode, playerID stri
 	if err := s.playerCache.SetPlayer(ctx, roomCode, playerID, player); err != nil {
 		return 0, err
 	}
-	if err := s.leaderboard.UpdateScore(ctx, roomCode, playerID, newScore); err != nil {
+	if err := s.leaderboard.UpdateScore(ctx, roomCode, playerID, newScore, time.Since(player.JoinedAt)); err != nil {
 		return 0, err
 	}
 
 	// Broadcast leaderboard update to host
 	if s.broadcaster != nil {
 		entries, _ := s.GetLeaderboard(ctx, roomCode, 20)
-		s.broadcaster.BroadcastToHost(roomCode, "leaderboard_update", map[string]interface{}{
-			"leaderboard": entries,
-		})
+		s.broadcaster.ToHost(roomCode, events.LeaderboardUpdate, events.LeaderboardUpdatePayload{Leaderboard: entries})
 	}
 
 	return newScore, nil
 }
 
+// RemoveFromLeaderboard drops a player's leaderboard entry and pushes the new standings to the host
+func (s *PlayerService) RemoveFromLeaderboard(ctx context.Context, roomCode, playerID string) error {
+	if err := s.leaderboard.Remove(ctx, roomCode, playerID); err != nil {
+		return err
+	}
+	if s.broadcaster != nil {
+		entries, _ := s.GetLeaderboard(ctx, roomCode, 20)
+		s.broadcaster.ToHost(roomCode, events.LeaderboardUpdate, events.LeaderboardUpdatePayload{Leaderboard: entries})
+	}
+	return nil
+}
+
+// GetStanding returns the player's rank, score, gap to the player directly ahead and
+// percentile, computed from the leaderboard ZSET; nil if the player has no entry
+func (s *PlayerService) GetStanding(ctx context.Context, roomCode, playerID string) (*model.PlayerStanding, error) {
+	lb, err := s.leaderboard.GetStanding(ctx, roomCode, playerID)
+	if err != nil || lb == nil {
+		return nil, err
+	}
+
+	standing := &model.PlayerStanding{
+		PlayerID:     playerID,
+		Rank:         lb.Rank,
+		Score:        lb.Score,
+		TotalPlayers: lb.Total,
+		Percentile:   100,
+		Tied:         lb.Tied,
+	}
+	if lb.Total > 1 {
+		standing.Percentile = math.Round(float64(lb.Total-lb.Rank)/float64(lb.Total-1)*1000) / 10
+	}
+	if p, err := s.playerCache.GetPlayer(ctx, roomCode, playerID); err == nil && p != nil {
+		standing.Nickname = p.Nickname
+	}
+	if lb.AheadID != "" {
+		standing.PointsToNext = lb.AheadScore - lb.Score
+		if p, err := s.playerCache.GetPlayer(ctx, roomCode, lb.AheadID); err == nil && p != nil {
+			standing.AheadNickname = p.Nickname
+		}
+	}
+	return standing, nil
+}
+
 // GetLeaderboard retrieves and enriches the leaderboard
 func (s *PlayerService) GetLeaderboard(ctx context.Context, roomCode string, limit int) ([]cache.LeaderboardEntry, error) {
 	entries, err := s.leaderboard.GetTop(ctx, roomCode, limit)
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/events"
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Live poll errors
+var (
+	ErrInvalidPoll  = errors.New("invalid poll")
+	ErrPollOpen     = errors.New("another poll is still open")
+	ErrPollClosed   = errors.New("poll is closed")
+	ErrAlreadyVoted = errors.New("already voted in this poll")
+)
+
+// PollService runs live polls: ephemeral MCQ/DEGREE questions the host launches
+// at any time during a room. Votes are kept in Redis while the poll is open and
+// the closed poll is stored with its results, apart from survey answers.
+type PollService struct {
+	pollCache   cache.PollCache
+	pollRepo    repository.PollRepo
+	roomCache   cache.RoomCache
+	playerCache cache.PlayerCache
+	broadcaster Broadcaster
+}
+
+// NewPollService creates a new poll service
+func NewPollService(pollCache cache.PollCache, pollRepo repository.PollRepo, roomCache cache.RoomCache, playerCache cache.PlayerCache) *PollService {
+	return &PollService{
+		pollCache:   pollCache,
+		pollRepo:    pollRepo,
+		roomCache:   roomCache,
+		playerCache: playerCache,
+	}
+}
+
+// SetBroadcaster sets the broadcaster for poll events
+func (s *PollService) SetBroadcaster(b Broadcaster) {
+	s.broadcaster = b
+}
+
+// Launch puts a poll to every player in the room for model.PollDuration. Only one
+// poll is open at a time; one left open past its window is closed first.
+// Returns nil if the room does not exist.
+func (s *PollService) Launch(ctx context.Context, roomCode, hostID string, req *model.LaunchPollRequest) (*model.Poll, error) {
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil || meta == nil {
+		return nil, err
+	}
+	if meta.HostID != hostID {
+		return nil, ErrNotRoomHost
+	}
+	if meta.Status == model.RoomStatusEnded {
+		return nil, fmt.Errorf("%w: the room has ended", ErrInvalidPoll)
+	}
+	if err := validatePoll(req); err != nil {
+		return nil, err
+	}
+
+	if open, err := s.pollCache.GetOpen(ctx, roomCode); err != nil {
+		return nil, err
+	} else if open != nil {
+		if time.Now().Before(open.ClosesAt) {
+			return nil, ErrPollOpen
+		}
+		s.close(ctx, open) // Its timer ran on an instance that is gone
+	}
+
+	now := time.Now()
+	poll := &model.Poll{
+		ID:        uuid.New().String(),
+		RoomCode:  roomCode,
+		Type:      req.Type,
+		Prompt:    strings.TrimSpace(req.Prompt),
+		StartedAt: now,
+		ClosesAt:  now.Add(model.PollDuration),
+	}
+	if req.Type == model.QuestionTypeMCQ {
+		poll.Options = req.Options
+	} else {
+		poll.ScaleMin, poll.ScaleMax = req.ScaleMin, req.ScaleMax
+	}
+
+	opened, err := s.pollCache.Open(ctx, roomCode, poll)
+	if err != nil {
+		return nil, err
+	}
+	if !opened {
+		return nil, ErrPollOpen
+	}
+
+	if s.broadcaster != nil {
+		s.broadcaster.ToRoom(roomCode, events.PollStarted, events.PollStartedPayload{Poll: *poll})
+	}
+	time.AfterFunc(model.PollDuration, func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		s.close(closeCtx, poll)
+	})
+	return poll, nil
+}
+
+// validatePoll checks a poll's prompt and choices
+func validatePoll(req *model.LaunchPollRequest) error {
+	prompt := strings.TrimSpace(req.Prompt)
+	if prompt == "" || utf8.RuneCountInString(prompt) > model.MaxPollPromptChars {
+		return fmt.Errorf("%w: prompt must be 1-%d characters", ErrInvalidPoll, model.MaxPollPromptChars)
+	}
+	switch req.Type {
+	case model.QuestionTypeMCQ:
+		if len(req.Options) < 2 || len(req.Options) > model.MaxPollOptions {
+			return fmt.Errorf("%w: MCQ polls take 2-%d options", ErrInvalidPoll, model.MaxPollOptions)
+		}
+		for _, o := range req.Options {
+			if strings.TrimSpace(o) == "" {
+				return fmt.Errorf("%w: options cannot be empty", ErrInvalidPoll)
+			}
+		}
+	case model.QuestionTypeDegree:
+		if req.ScaleMax <= req.ScaleMin || req.ScaleMax-req.ScaleMin > 10 {
+			return fmt.Errorf("%w: DEGREE polls take a scale of 2-11 values (scaleMin < scaleMax)", ErrInvalidPoll)
+		}
+	default:
+		return fmt.Errorf("%w: type must be MCQ or DEGREE", ErrInvalidPoll)
+	}
+	return nil
+}
+
+// Vote records a player's answer to the open poll; players vote once
+func (s *PollService) Vote(ctx context.Context, roomCode, playerID, pollID string, req *model.PollVoteRequest) error {
+	poll, err := s.pollCache.GetOpen(ctx, roomCode)
+	if err != nil {
+		return err
+	}
+	if poll == nil || poll.ID != pollID || !time.Now().Before(poll.ClosesAt) {
+		return ErrPollClosed
+	}
+	player, err := s.playerCache.GetPlayer(ctx, roomCode, playerID)
+	if err != nil {
+		return err
+	}
+	if player != nil && player.HasLeft() {
+		return ErrPlayerLeft
+	}
+
+	var choice int
+	switch {
+	case poll.Type == model.QuestionTypeMCQ && req.OptionIndex != nil:
+		choice = *req.OptionIndex
+	case poll.Type == model.QuestionTypeDegree && req.DegreeValue != nil:
+		choice = *req.DegreeValue - poll.ScaleMin
+	default:
+		return fmt.Errorf("%w: %s polls take %s", ErrInvalidPoll, poll.Type, pollVoteField(poll.Type))
+	}
+	if choice < 0 || choice >= poll.Choices() {
+		return fmt.Errorf("%w: %s is out of range", ErrInvalidPoll, pollVoteField(poll.Type))
+	}
+
+	voted, err := s.pollCache.Vote(ctx, roomCode, pollID, playerID, choice)
+	if err != nil {
+		return err
+	}
+	if !voted {
+		return ErrAlreadyVoted
+	}
+
+	if s.broadcaster != nil {
+		if results, err := s.tally(ctx, poll); err == nil {
+			s.broadcaster.ToHost(roomCode, events.PollVotes, events.PollVotesPayload{
+				PollID:  poll.ID,
+				Votes:   results.Votes,
+				Counts:  results.Counts,
+				Players: results.Players,
+			})
+		}
+	}
+	return nil
+}
+
+func pollVoteField(t model.QuestionType) string {
+	if t == model.QuestionTypeDegree {
+		return "degreeValue"
+	}
+	return "optionIndex"
+}
+
+// List returns a room's closed polls and the open one, in launch order
+func (s *PollService) List(ctx context.Context, roomCode, hostID string) ([]*model.Poll, error) {
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	if meta != nil && meta.HostID != hostID {
+		return nil, ErrNotRoomHost
+	}
+
+	polls, err := s.pollRepo.GetByRoom(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	if open, err := s.pollCache.GetOpen(ctx, roomCode); err == nil && open != nil {
+		polls = append(polls, open)
+	}
+	return polls, nil
+}
+
+// ForSnapshot closes a poll still open when the room ends and returns every
+// closed poll of the room, for the session snapshot
+func (s *PollService) ForSnapshot(ctx context.Context, roomCode string) []model.Poll {
+	if open, err := s.pollCache.GetOpen(ctx, roomCode); err == nil && open != nil {
+		s.close(ctx, open)
+	}
+	polls, err := s.pollRepo.GetByRoom(ctx, roomCode)
+	if err != nil {
+		fmt.Printf("[Polls] Polls of %s unavailable for the snapshot: %v\n", roomCode, err)
+		return []model.Poll{}
+	}
+	out := make([]model.Poll, 0, len(polls))
+	for _, p := range polls {
+		out = append(out, *p)
+	}
+	return out
+}
+
+// close tallies and stores the poll and sends the results to the room. Only the
+// caller that takes the poll out of Redis does this.
+func (s *PollService) close(ctx context.Context, poll *model.Poll) {
+	closed, err := s.pollCache.Close(ctx, poll.RoomCode, poll.ID)
+	if err != nil {
+		fmt.Printf("[Polls] Close %s in %s: %v\n", poll.ID, poll.RoomCode, err)
+		return
+	}
+	if !closed {
+		return
+	}
+
+	results, err := s.tally(ctx, poll)
+	if err != nil {
+		fmt.Printf("[Polls] Tally %s in %s: %v\n", poll.ID, poll.RoomCode, err)
+		results = &model.PollResults{Counts: make([]int, poll.Choices())}
+	}
+	now := time.Now()
+	poll.ClosedAt = &now
+	poll.Results = results
+	if err := s.pollRepo.Save(ctx, poll); err != nil {
+		fmt.Printf("[Polls] Save %s in %s: %v\n", poll.ID, poll.RoomCode, err)
+	}
+	if s.broadcaster != nil {
+		s.broadcaster.ToRoom(poll.RoomCode, events.PollResults, events.PollResultsPayload{Poll: *poll})
+	}
+}
+
+// tally counts the poll's votes so far
+func (s *PollService) tally(ctx context.Context, poll *model.Poll) (*model.PollResults, error) {
+	votes, err := s.pollCache.Votes(ctx, poll.RoomCode, poll.ID)
+	if err != nil {
+		return nil, err
+	}
+	results := &model.PollResults{Counts: make([]int, poll.Choices())}
+	sum := 0
+	for _, choice := range votes {
+		if choice < 0 || choice >= len(results.Counts) {
+			continue
+		}
+		results.Counts[choice]++
+		results.Votes++
+		sum += choice + poll.ScaleMin
+	}
+	if poll.Type == model.QuestionTypeDegree && results.Votes > 0 {
+		avg := float64(sum) / float64(results.Votes)
+		results.Average = &avg
+	}
+	if players, err := s.playerCache.GetAllPlayers(ctx, poll.RoomCode); err == nil {
+		for _, p := range players {
+			if !p.HasLeft() {
+				results.Players++
+			}
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"2026champs/internal/events"
+	"2026champs/internal/model"
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultIdleAfter     = 45 * time.Second
+	activityPersistEvery = 15 * time.Second // Throttle LastActiveAt writes from activity pings
+)
+
+// presenceState tracks a connected player's activity on this instance
+type presenceState struct {
+	lastSeen    time.Time
+	lastPersist time.Time
+	idle        bool
+}
+
+// SetIdleTimeout sets how long a player can go without activity before the host sees them as idle
+func (s *PlayerService) SetIdleTimeout(d time.Duration) {
+	if d > 0 {
+		s.idleAfter = d
+	}
+}
+
+// TrackPresence starts presence tracking when a player connects
+func (s *PlayerService) TrackPresence(roomCode, playerID string) {
+	s.presenceMu.Lock()
+	defer s.presenceMu.Unlock()
+	if s.presence[roomCode] == nil {
+		s.presence[roomCode] = make(map[string]*presenceState)
+	}
+	s.presence[roomCode][playerID] = &presenceState{lastSeen: time.Now()}
+	s.forgetDepartureLocked(roomCode, playerID)
+}
+
+// ForgetPresence stops presence tracking when a player disconnects and starts
+// the clock after which they are treated as having left
+func (s *PlayerService) ForgetPresence(roomCode, playerID string) {
+	s.presenceMu.Lock()
+	defer s.presenceMu.Unlock()
+	if players, ok := s.presence[roomCode]; ok {
+		delete(players, playerID)
+		if len(players) == 0 {
+			delete(s.presence, roomCode)
+		}
+	}
+	if s.departed[roomCode] == nil {
+		s.departed[roomCode] = make(map[string]time.Time)
+	}
+	s.departed[roomCode][playerID] = time.Now()
+}
+
+// ForgetDeparture stops waiting for a disconnected player, e.g. once they left
+func (s *PlayerService) ForgetDeparture(roomCode, playerID string) {
+	s.presenceMu.Lock()
+	defer s.presenceMu.Unlock()
+	s.forgetDepartureLocked(roomCode, playerID)
+}
+
+func (s *PlayerService) forgetDepartureLocked(roomCode, playerID string) {
+	if players, ok := s.departed[roomCode]; ok {
+		delete(players, playerID)
+		if len(players) == 0 {
+			delete(s.departed, roomCode)
+		}
+	}
+}
+
+// TouchActivity records player activity (pings, drafts, submissions) and
+// tells the host when an idle player comes back
+func (s *PlayerService) TouchActivity(ctx context.Context, roomCode, playerID string) error {
+	now := time.Now()
+
+	s.presenceMu.Lock()
+	if s.presence[roomCode] == nil {
+		s.presence[roomCode] = make(map[string]*presenceState)
+	}
+	state, ok := s.presence[roomCode][playerID]
+	if !ok {
+		state = &presenceState{}
+		s.presence[roomCode][playerID] = state
+	}
+	wasIdle := state.idle
+	persist := now.Sub(state.lastPersist) >= activityPersistEvery
+	state.lastSeen = now
+	state.idle = false
+	if persist {
+		state.lastPersist = now
+	}
+	s.presenceMu.Unlock()
+
+	if wasIdle && s.broadcaster != nil {
+		s.broadcaster.ToHost(roomCode, events.PlayerActive, events.PlayerActivePayload{PlayerID: playerID})
+	}
+	if !persist {
+		return nil
+	}
+
+	player, err := s.playerCache.GetPlayer(ctx, roomCode, playerID)
+	if err != nil || player == nil {
+		return err
+	}
+	player.LastActiveAt = now
+	return s.playerCache.SetPlayer(ctx, roomCode, playerID, player)
+}
+
+// StartIdleMonitor checks connected players every interval and tells the
+// host when one has gone quiet for longer than the idle timeout. Players
+// disconnected for longer than their room allows are treated as having left.
+func (s *PlayerService) StartIdleMonitor(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				now := time.Now()
+				s.markIdlePlayers(now)
+				s.abandonDeparted(ctx, now)
+			}
+		}
+	}()
+}
+
+// abandonDeparted makes players who stayed disconnected past their room's
+// AbandonAfter leave. Activity recorded in Redis by another instance (the
+// player reconnected elsewhere) restarts the clock.
+func (s *PlayerService) abandonDeparted(ctx context.Context, now time.Time) {
+	if s.answerSvc == nil {
+		return
+	}
+
+	s.presenceMu.Lock()
+	departed := make(map[string]map[string]time.Time, len(s.departed))
+	for roomCode, players := range s.departed {
+		departed[roomCode] = make(map[string]time.Time, len(players))
+		for playerID, at := range players {
+			departed[roomCode][playerID] = at
+		}
+	}
+	s.presenceMu.Unlock()
+
+	for roomCode, players := range departed {
+		meta, err := s.roomCache.GetMeta(ctx, roomCode)
+		if err != nil {
+			continue
+		}
+		after := time.Duration(0)
+		if meta != nil && meta.Status == model.RoomStatusActive {
+			after = meta.Settings().AbandonAfter()
+		}
+		for playerID, at := range players {
+			if after == 0 {
+				// Room ended, still in the lobby or never abandons: stop watching
+				if meta == nil || meta.Status != model.RoomStatusLobby {
+					s.ForgetDeparture(roomCode, playerID)
+				}
+				continue
+			}
+			if now.Sub(at) < after {
+				continue
+			}
+			player, err := s.playerCache.GetPlayer(ctx, roomCode, playerID)
+			if err != nil {
+				continue
+			}
+			if player == nil || player.HasLeft() {
+				s.ForgetDeparture(roomCode, playerID)
+				continue
+			}
+			if now.Sub(player.LastActiveAt) < after {
+				continue
+			}
+			if _, err := s.answerSvc.LeaveRoom(ctx, roomCode, playerID, model.LeaveReasonDisconnected); err != nil {
+				fmt.Printf("[Presence] Failed to abandon %s in %s: %v\n", playerID, roomCode, err)
+			}
+		}
+	}
+}
+
+type idleEvent struct {
+	roomCode string
+	playerID string
+	lastSeen time.Time
+}
+
+// markIdlePlayers flags players past the idle timeout and notifies their hosts
+func (s *PlayerService) markIdlePlayers(now time.Time) {
+	var idle []idleEvent
+
+	s.presenceMu.Lock()
+	for roomCode, players := range s.presence {
+		for playerID, state := range players {
+			if !state.idle && now.Sub(state.lastSeen) > s.idleAfter {
+				state.idle = true
+				idle = append(idle, idleEvent{roomCode, playerID, state.lastSeen})
+			}
+		}
+	}
+	s.presenceMu.Unlock()
+
+	if s.broadcaster == nil {
+		return
+	}
+	for _, e := range idle {
+		s.broadcaster.ToHost(e.roomCode, events.PlayerIdle, events.PlayerIdlePayload{
+			PlayerID:     e.playerID,
+			LastActiveAt: e.lastSeen,
+			IdleSeconds:  int(now.Sub(e.lastSeen).Seconds()),
+		})
+	}
+	if len(idle) > 0 {
+		fmt.Printf("[Presence] Marked %d players idle\n", len(idle))
+	}
+}
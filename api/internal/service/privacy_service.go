@@ -0,0 +1,296 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// PrivacyService handles data subject access and deletion requests for players
+type PrivacyService struct {
+	roomRepo       repository.RoomRepo
+	answerRepo     repository.AnswerRepo
+	reportRepo     repository.ReportRepo
+	analyticsRepo  repository.AnalyticsRepo
+	playerCache    cache.PlayerCache
+	leaderboard    cache.LeaderboardCache
+	analyticsCache cache.AnalyticsCache
+	evalCache      cache.EvalCache // optional
+	auditSvc       *AuditService   // optional
+
+	feedbackRepo repository.FeedbackRepo // optional
+	voiceSvc     *VoiceService           // optional
+	attachSvc    *AttachmentService      // optional
+}
+
+// NewPrivacyService creates a new privacy service
+func NewPrivacyService(
+	roomRepo repository.RoomRepo,
+	answerRepo repository.AnswerRepo,
+	reportRepo repository.ReportRepo,
+	analyticsRepo repository.AnalyticsRepo,
+	playerCache cache.PlayerCache,
+	leaderboard cache.LeaderboardCache,
+	analyticsCache cache.AnalyticsCache,
+) *PrivacyService {
+	return &PrivacyService{
+		roomRepo:       roomRepo,
+		answerRepo:     answerRepo,
+		reportRepo:     reportRepo,
+		analyticsRepo:  analyticsRepo,
+		playerCache:    playerCache,
+		leaderboard:    leaderboard,
+		analyticsCache: analyticsCache,
+	}
+}
+
+// SetEvalCache lets deletion drop cached evaluations keyed by the player's answer text
+func (s *PrivacyService) SetEvalCache(evalCache cache.EvalCache) {
+	s.evalCache = evalCache
+}
+
+// SetAuditService enables recording deletions in the room audit trail
+func (s *PrivacyService) SetAuditService(a *AuditService) {
+	s.auditSvc = a
+}
+
+// SetFeedbackRepo includes end-of-room feedback in exports and deletions
+func (s *PrivacyService) SetFeedbackRepo(r repository.FeedbackRepo) {
+	s.feedbackRepo = r
+}
+
+// SetVoiceService includes recorded answers and their audio in exports and deletions
+func (s *PrivacyService) SetVoiceService(v *VoiceService) {
+	s.voiceSvc = v
+}
+
+// SetAttachmentService includes uploaded attachments and their files in exports and deletions
+func (s *PrivacyService) SetAttachmentService(a *AttachmentService) {
+	s.attachSvc = a
+}
+
+// checkHost verifies the room exists and belongs to the host
+func (s *PrivacyService) checkHost(ctx context.Context, hostID, roomCode string) (bool, error) {
+	room, err := s.roomRepo.GetByCode(ctx, roomCode)
+	if err != nil || room == nil {
+		return false, err
+	}
+	if room.HostID != hostID {
+		return false, ErrNotRoomHost
+	}
+	return true, nil
+}
+
+// ExportPlayerData collects everything stored about a player in a room
+func (s *PrivacyService) ExportPlayerData(ctx context.Context, hostID, roomCode, playerID string) (*model.PlayerDataExport, error) {
+	if found, err := s.checkHost(ctx, hostID, roomCode); !found {
+		return nil, err
+	}
+
+	answers, err := s.answerRepo.GetByRoomAndPlayer(ctx, roomCode, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load answers: %w", err)
+	}
+	export := &model.PlayerDataExport{
+		RoomCode:   roomCode,
+		PlayerID:   playerID,
+		Answers:    answers,
+		ExportedAt: time.Now(),
+	}
+
+	if player, err := s.playerCache.GetPlayer(ctx, roomCode, playerID); err == nil {
+		export.Player = player
+	}
+	if drafts, err := s.playerCache.GetAttempts(ctx, roomCode, playerID); err == nil && len(drafts) > 0 {
+		export.Drafts = drafts
+	}
+
+	// Live profile first, archived copy once the room has been flushed
+	if profile, err := s.analyticsCache.GetPlayerProfile(ctx, roomCode, playerID); err == nil && profile != nil {
+		export.Profile = profile
+	} else if profile, err := s.analyticsRepo.GetPlayerProfile(ctx, roomCode, playerID); err == nil {
+		export.Profile = profile
+	}
+
+	if snapshot, err := s.reportRepo.GetSnapshot(ctx, roomCode); err == nil && snapshot != nil {
+		for i := range snapshot.Leaderboard {
+			if snapshot.Leaderboard[i].PlayerID == playerID {
+				export.Leaderboard = &snapshot.Leaderboard[i]
+				break
+			}
+		}
+	}
+
+	if s.feedbackRepo != nil {
+		if feedback, err := s.feedbackRepo.Get(ctx, roomCode, playerID); err == nil {
+			export.Feedback = feedback
+		}
+	}
+
+	if s.voiceSvc != nil {
+		clips, err := s.voiceSvc.ListPlayerClips(ctx, roomCode, playerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load voice clips: %w", err)
+		}
+		if len(clips) > 0 {
+			export.VoiceClips = clips
+		}
+	}
+
+	if s.attachSvc != nil {
+		attachments, err := s.attachSvc.ListPlayerFiles(ctx, roomCode, playerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load attachments: %w", err)
+		}
+		if len(attachments) > 0 {
+			export.Attachments = attachments
+		}
+	}
+
+	if export.Player == nil && len(answers) == 0 && export.Profile == nil && export.Leaderboard == nil && export.Feedback == nil &&
+		export.VoiceClips == nil && export.Attachments == nil {
+		return nil, nil
+	}
+	return export, nil
+}
+
+// DeletePlayerData scrubs a player's answers, recordings, uploads, profiles, leaderboard entries and cached state
+func (s *PrivacyService) DeletePlayerData(ctx context.Context, hostID, roomCode, playerID string) (*model.PlayerDataDeletion, error) {
+	if found, err := s.checkHost(ctx, hostID, roomCode); !found {
+		return nil, err
+	}
+
+	answers, err := s.answerRepo.GetByRoomAndPlayer(ctx, roomCode, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load answers: %w", err)
+	}
+
+	result := &model.PlayerDataDeletion{
+		RoomCode: roomCode,
+		PlayerID: playerID,
+		Notes: []string{
+			"AI-generated insight reports may quote answers verbatim and are not rewritten",
+			"Aggregated question statistics keep their counts but no longer reference the player",
+		},
+	}
+
+	// Cached evaluations are keyed by normalized answer text
+	if s.evalCache != nil {
+		for _, a := range answers {
+			if a.TextAnswer == "" {
+				continue
+			}
+			if err := s.evalCache.Delete(ctx, roomCode, a.QuestionKey, normalizeAnswer(a.TextAnswer)); err != nil {
+				fmt.Printf("[Privacy] Failed to drop eval cache entry for %s/%s: %v\n", roomCode, a.QuestionKey, err)
+			}
+		}
+	}
+
+	if result.AnswersDeleted, err = s.answerRepo.DeleteByRoomAndPlayer(ctx, roomCode, playerID); err != nil {
+		return nil, fmt.Errorf("failed to delete answers: %w", err)
+	}
+
+	if result.CacheKeysDeleted, err = s.playerCache.DeletePlayer(ctx, roomCode, playerID); err != nil {
+		return nil, fmt.Errorf("failed to delete cached player state: %w", err)
+	}
+	if err := s.leaderboard.Remove(ctx, roomCode, playerID); err != nil {
+		return nil, fmt.Errorf("failed to remove leaderboard entry: %w", err)
+	}
+	if err := s.analyticsCache.DeletePlayerProfile(ctx, roomCode, playerID); err != nil {
+		return nil, fmt.Errorf("failed to delete cached profile: %w", err)
+	}
+	if err := s.analyticsRepo.DeletePlayerProfile(ctx, roomCode, playerID); err != nil {
+		return nil, fmt.Errorf("failed to delete archived profile: %w", err)
+	}
+	result.ProfileDeleted = true
+
+	if s.feedbackRepo != nil {
+		if result.FeedbackDeleted, err = s.feedbackRepo.Delete(ctx, roomCode, playerID); err != nil {
+			return nil, fmt.Errorf("failed to delete feedback: %w", err)
+		}
+	}
+
+	if s.voiceSvc != nil {
+		if result.VoiceClipsDeleted, err = s.voiceSvc.DeletePlayerClips(ctx, roomCode, playerID); err != nil {
+			return nil, fmt.Errorf("failed to delete voice clips: %w", err)
+		}
+	}
+	if s.attachSvc != nil {
+		if result.AttachmentsDeleted, err = s.attachSvc.DeletePlayerFiles(ctx, roomCode, playerID); err != nil {
+			return nil, fmt.Errorf("failed to delete attachments: %w", err)
+		}
+	}
+
+	scrubbed, err := s.scrubSnapshot(ctx, roomCode, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrub snapshot: %w", err)
+	}
+	result.SnapshotScrubbed = scrubbed
+
+	result.DeletedAt = time.Now()
+	if s.auditSvc != nil {
+		s.auditSvc.Record(ctx, roomCode, hostID, model.AuditPlayerDataDeleted, map[string]interface{}{
+			"playerId":           playerID,
+			"answersDeleted":     result.AnswersDeleted,
+			"voiceClipsDeleted":  result.VoiceClipsDeleted,
+			"attachmentsDeleted": result.AttachmentsDeleted,
+		})
+	}
+	fmt.Printf("[Privacy] Deleted data for player %s in room %s (%d answers, %d keys)\n",
+		playerID, roomCode, result.AnswersDeleted, result.CacheKeysDeleted)
+	return result, nil
+}
+
+// scrubSnapshot removes the player from the room-end snapshot and recomputes its stats
+func (s *PrivacyService) scrubSnapshot(ctx context.Context, roomCode, playerID string) (bool, error) {
+	snapshot, err := s.reportRepo.GetSnapshot(ctx, roomCode)
+	if err != nil || snapshot == nil {
+		return false, err
+	}
+
+	changed := false
+	leaderboard := snapshot.Leaderboard[:0]
+	for _, e := range snapshot.Leaderboard {
+		if e.PlayerID == playerID {
+			changed = true
+			continue
+		}
+		leaderboard = append(leaderboard, e)
+	}
+	completion := snapshot.PlayerCompletion[:0]
+	for _, c := range snapshot.PlayerCompletion {
+		if c.PlayerID == playerID {
+			changed = true
+			continue
+		}
+		completion = append(completion, c)
+	}
+	if !changed {
+		return false, nil
+	}
+
+	sort.SliceStable(leaderboard, func(i, j int) bool { return leaderboard[i].Score > leaderboard[j].Score })
+	for i := range leaderboard {
+		leaderboard[i].Rank = i + 1
+	}
+	snapshot.Leaderboard = leaderboard
+	snapshot.PlayerCompletion = completion
+
+	snapshot.TotalPlayers = len(completion)
+	snapshot.CompletionRate = 0
+	if len(completion) > 0 {
+		done := 0
+		for _, c := range completion {
+			if c.Completed {
+				done++
+			}
+		}
+		snapshot.CompletionRate = float64(done) / float64(len(completion))
+	}
+
+	return true, s.reportRepo.SaveSnapshot(ctx, snapshot)
+}
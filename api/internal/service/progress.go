@@ -0,0 +1,163 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"math"
+	"strings"
+	"time"
+)
+
+// Progress estimate tuning
+const (
+	paceTTL             = 15 * time.Second // how long room averages are reused
+	minPaceSamples      = 3                // responses before a question's own averages are trusted
+	defaultAnswerTimeMs = 45000            // per question until the room has response times
+)
+
+// roomPace holds the room averages behind progress estimates
+type roomPace struct {
+	followUpRate map[string]float64 // base key -> follow-ups answered per response
+	medianMs     map[string]int64   // base key -> median response time
+	followUpMs   int64              // median response time of follow-ups
+	roomMs       int64              // median of all question medians
+	computedAt   time.Time
+}
+
+// GetProgress estimates how far along the survey the player is; nil when the
+// room is gone or in the lobby. Estimates are best effort: missing analytics
+// fall back to defaults.
+func (s *PlayerService) GetProgress(ctx context.Context, roomCode, playerID string) (*model.Progress, error) {
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil || meta == nil || meta.Status == model.RoomStatusLobby {
+		return nil, err
+	}
+	queue, err := s.playerCache.GetQueue(ctx, roomCode, playerID)
+	if err != nil {
+		return nil, err
+	}
+	followUpKeys, err := s.playerCache.GetQuestionMapKeys(ctx, roomCode, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	base := make(map[string]bool, len(meta.Questions))
+	keys := make([]string, 0, len(meta.Questions)+len(followUpKeys))
+	for _, q := range meta.Questions {
+		base[q.Key] = true
+		keys = append(keys, q.Key)
+	}
+	keys = append(keys, followUpKeys...)
+	attempts, err := s.playerCache.GetAttemptStates(ctx, roomCode, playerID, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	queued := make(map[string]bool, len(queue))
+	for _, key := range queue {
+		queued[key] = true
+	}
+	progress := &model.Progress{BaseTotal: len(meta.Questions)}
+	for _, key := range keys {
+		if a := attempts[key]; a != nil && a.Status == model.AnswerStatusEvaluated && !queued[key] {
+			progress.Answered++
+		}
+	}
+
+	// Base questions still queued may bring follow-ups at the room's rate
+	pace := s.roomPace(ctx, roomCode, base)
+	var expected float64
+	var leftMs int64
+	queuedFollowUps := 0
+	for _, key := range queue {
+		if base[key] {
+			progress.BaseRemaining++
+			expected += pace.followUpRate[key]
+			leftMs += pace.answerMs(key)
+			continue
+		}
+		queuedFollowUps++
+		leftMs += pace.followUpMs
+	}
+	leftMs += int64(expected * float64(pace.followUpMs))
+	progress.EstimatedFollowUps = queuedFollowUps + int(math.Round(expected))
+	progress.EstimatedMinutesLeft = int(math.Ceil(float64(leftMs) / float64(time.Minute.Milliseconds())))
+	return progress, nil
+}
+
+// answerMs is the expected time for a base question
+func (p *roomPace) answerMs(key string) int64 {
+	if ms, ok := p.medianMs[key]; ok {
+		return ms
+	}
+	return p.roomMs
+}
+
+// roomPace returns the room's cached averages, recomputing them once stale
+func (s *PlayerService) roomPace(ctx context.Context, roomCode string, base map[string]bool) *roomPace {
+	s.paceMu.Lock()
+	pace := s.paces[roomCode]
+	s.paceMu.Unlock()
+	if pace != nil && time.Since(pace.computedAt) < paceTTL {
+		return pace
+	}
+
+	pace = &roomPace{
+		followUpRate: make(map[string]float64),
+		medianMs:     make(map[string]int64),
+		roomMs:       defaultAnswerTimeMs,
+		computedAt:   time.Now(),
+	}
+	var profiles []*model.QuestionProfile
+	if s.analyticsSvc != nil {
+		profiles, _ = s.analyticsSvc.ListQuestionProfiles(ctx, roomCode)
+	}
+
+	responses := make(map[string]int) // base key -> responses
+	followUps := make(map[string]int) // base key -> follow-up responses
+	var medians, followUpMedians []int64
+	for _, p := range profiles {
+		n := p.SatCount + p.UnsatCount + p.SkipCount
+		baseKey, _, isFollowUp := strings.Cut(p.QuestionKey, ".")
+		if !base[baseKey] {
+			continue
+		}
+		if isFollowUp {
+			followUps[baseKey] += n
+		} else {
+			responses[baseKey] = n
+		}
+		if p.ResponseTime == nil || p.ResponseTime.Count < minPaceSamples {
+			continue
+		}
+		if isFollowUp {
+			followUpMedians = append(followUpMedians, p.ResponseTime.MedianMs)
+		} else {
+			pace.medianMs[baseKey] = p.ResponseTime.MedianMs
+			medians = append(medians, p.ResponseTime.MedianMs)
+		}
+	}
+	for key, n := range responses {
+		if n >= minPaceSamples {
+			pace.followUpRate[key] = float64(followUps[key]) / float64(n)
+		}
+	}
+	if stats := responseTimeStats(medians); stats != nil {
+		pace.roomMs = stats.MedianMs
+	}
+	pace.followUpMs = pace.roomMs
+	if stats := responseTimeStats(followUpMedians); stats != nil {
+		pace.followUpMs = stats.MedianMs
+	}
+
+	// Rooms nobody asked about for a while have ended or emptied
+	s.paceMu.Lock()
+	for code, p := range s.paces {
+		if time.Since(p.computedAt) > 4*paceTTL {
+			delete(s.paces, code)
+		}
+	}
+	s.paces[roomCode] = pace
+	s.paceMu.Unlock()
+	return pace
+}
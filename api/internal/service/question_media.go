@@ -0,0 +1,112 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// File extensions that give away a media link's type when the host leaves it out
+var (
+	imageExtensions = map[string]bool{".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".webp": true, ".svg": true, ".avif": true}
+	videoExtensions = map[string]bool{".mp4": true, ".webm": true, ".mov": true, ".m4v": true, ".ogv": true}
+)
+
+// videoHosts serve videos whatever the link's path
+var videoHosts = map[string]bool{"youtube.com": true, "youtu.be": true, "vimeo.com": true, "player.vimeo.com": true}
+
+// normalizeQuestionMedia checks each question's media link, fills in a missing
+// type from the link and stores YouTube links in their embed form
+func normalizeQuestionMedia(questions []model.BaseQuestion) error {
+	for i := range questions {
+		m := questions[i].Media
+		if m == nil {
+			continue
+		}
+		key := questions[i].Key
+
+		raw := strings.TrimSpace(m.URL)
+		if raw == "" || len(raw) > model.MaxMediaURLChars {
+			return fmt.Errorf("%w: question %s: media url must be 1-%d characters", ErrInvalidSurvey, key, model.MaxMediaURLChars)
+		}
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" || u.User != nil {
+			return fmt.Errorf("%w: question %s: media url must be an absolute http(s) link", ErrInvalidSurvey, key)
+		}
+		u.Host = strings.ToLower(u.Host)
+		if embed := youTubeEmbed(u); embed != nil {
+			u = embed
+		}
+
+		if m.Type == "" {
+			m.Type = mediaTypeOf(u)
+		}
+		switch m.Type {
+		case model.MediaTypeImage, model.MediaTypeVideo:
+		case "":
+			return fmt.Errorf("%w: question %s: media type cannot be told from the url, set IMAGE or VIDEO", ErrInvalidSurvey, key)
+		default:
+			return fmt.Errorf("%w: question %s: media type must be IMAGE or VIDEO", ErrInvalidSurvey, key)
+		}
+
+		m.AltText = strings.TrimSpace(m.AltText)
+		if utf8.RuneCountInString(m.AltText) > model.MaxMediaAltTextChars {
+			return fmt.Errorf("%w: question %s: media altText exceeds %d characters", ErrInvalidSurvey, key, model.MaxMediaAltTextChars)
+		}
+		m.URL = u.String()
+	}
+	return nil
+}
+
+// mediaHost is the link's host without "www." or "m."
+func mediaHost(u *url.URL) string {
+	host := u.Hostname()
+	for _, prefix := range []string{"www.", "m."} {
+		host = strings.TrimPrefix(host, prefix)
+	}
+	return host
+}
+
+// mediaTypeOf guesses a link's media type from its host or file extension; empty if unknown
+func mediaTypeOf(u *url.URL) model.MediaType {
+	if videoHosts[mediaHost(u)] {
+		return model.MediaTypeVideo
+	}
+	ext := strings.ToLower(path.Ext(u.Path))
+	switch {
+	case imageExtensions[ext]:
+		return model.MediaTypeImage
+	case videoExtensions[ext]:
+		return model.MediaTypeVideo
+	}
+	return ""
+}
+
+// youTubeEmbed rewrites watch, short and youtu.be links to the embeddable
+// player URL, keeping a start time; nil for other links
+func youTubeEmbed(u *url.URL) *url.URL {
+	var id string
+	switch host := mediaHost(u); {
+	case host == "youtu.be":
+		id = strings.Trim(u.Path, "/")
+	case host == "youtube.com" && u.Path == "/watch":
+		id = u.Query().Get("v")
+	case host == "youtube.com" && strings.HasPrefix(u.Path, "/shorts/"):
+		id = strings.TrimPrefix(u.Path, "/shorts/")
+	}
+	if id == "" || strings.Contains(id, "/") {
+		return nil
+	}
+
+	embed := &url.URL{Scheme: "https", Host: "www.youtube.com", Path: "/embed/" + id}
+	if t := strings.TrimSuffix(u.Query().Get("t"), "s"); t != "" {
+		if secs, err := strconv.Atoi(t); err == nil && secs > 0 {
+			embed.RawQuery = url.Values{"start": {strconv.Itoa(secs)}}.Encode()
+		}
+	}
+	return embed
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/events"
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"fmt"
+)
+
+// QuotaService enforces per-option screening quotas on MCQ questions
+type QuotaService struct {
+	quotaCache  cache.QuotaCache
+	roomCache   cache.RoomCache
+	surveyRepo  repository.SurveyRepo
+	playerSvc   *PlayerService
+	broadcaster Broadcaster
+}
+
+// NewQuotaService creates a new quota service
+func NewQuotaService(quotaCache cache.QuotaCache, roomCache cache.RoomCache, surveyRepo repository.SurveyRepo, playerSvc *PlayerService) *QuotaService {
+	return &QuotaService{
+		quotaCache: quotaCache,
+		roomCache:  roomCache,
+		surveyRepo: surveyRepo,
+		playerSvc:  playerSvc,
+	}
+}
+
+// SetBroadcaster sets the broadcaster for screen-out events
+func (s *QuotaService) SetBroadcaster(b Broadcaster) {
+	s.broadcaster = b
+}
+
+// Admit claims a quota slot for the player's MCQ choice. When the option's
+// quota is already full the player is screened out: their remaining questions
+// are dropped and false is returned.
+func (s *QuotaService) Admit(ctx context.Context, roomCode, playerID, questionKey string, option int) (bool, error) {
+	quota, err := s.findQuota(ctx, roomCode, questionKey, option)
+	if err != nil || quota == nil {
+		return true, err
+	}
+
+	ok, err := s.quotaCache.Claim(ctx, roomCode, questionKey, option, quota.Limit, playerID)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim quota: %w", err)
+	}
+	if ok {
+		return true, nil
+	}
+
+	if err := s.quotaCache.MarkScreenedOut(ctx, roomCode, playerID); err != nil {
+		return false, err
+	}
+	if err := s.playerSvc.EndEarly(ctx, roomCode, playerID); err != nil {
+		return false, err
+	}
+	if s.broadcaster != nil {
+		s.broadcaster.ToHost(roomCode, events.PlayerScreenedOut, events.PlayerScreenedOutPayload{
+			PlayerID:    playerID,
+			QuestionKey: questionKey,
+			Option:      option,
+		})
+		s.broadcaster.ToPlayer(roomCode, playerID, events.ScreenedOut, events.ScreenedOutPayload{
+			Message: model.ScreenedOutMessage,
+		})
+	}
+	return false, nil
+}
+
+// findQuota returns the quota configured on the question's option, if any
+func (s *QuotaService) findQuota(ctx context.Context, roomCode, questionKey string, option int) (*model.OptionQuota, error) {
+	survey, err := s.roomSurvey(ctx, roomCode)
+	if err != nil || survey == nil {
+		return nil, err
+	}
+	for _, q := range survey.Questions {
+		if q.Key != questionKey {
+			continue
+		}
+		for i := range q.Quotas {
+			if q.Quotas[i].Option == option {
+				return &q.Quotas[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// GetStatus returns fill levels of every quota in the room's survey
+func (s *QuotaService) GetStatus(ctx context.Context, roomCode, hostID string) (*model.RoomQuotas, error) {
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room meta: %w", err)
+	}
+	if meta == nil {
+		return nil, nil
+	}
+	if meta.HostID != hostID {
+		return nil, ErrNotRoomHost
+	}
+
+	status := &model.RoomQuotas{RoomCode: roomCode, Quotas: []model.QuotaStatus{}}
+	survey, err := s.surveyRepo.GetByID(ctx, meta.SurveyID)
+	if err != nil {
+		return nil, err
+	}
+	if survey != nil {
+		for _, q := range survey.Questions {
+			for _, quota := range q.Quotas {
+				count, err := s.quotaCache.Count(ctx, roomCode, q.Key, quota.Option)
+				if err != nil {
+					return nil, err
+				}
+				label := ""
+				if quota.Option >= 0 && quota.Option < len(q.Options) {
+					label = q.Options[quota.Option]
+				}
+				status.Quotas = append(status.Quotas, model.QuotaStatus{
+					QuestionKey: q.Key,
+					Option:      quota.Option,
+					Label:       label,
+					Limit:       quota.Limit,
+					Count:       count,
+					Full:        count >= quota.Limit,
+				})
+			}
+		}
+	}
+
+	if status.ScreenedOut, err = s.quotaCache.CountScreenedOut(ctx, roomCode); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+func (s *QuotaService) roomSurvey(ctx context.Context, roomCode string) (*model.Survey, error) {
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil || meta == nil {
+		return nil, err
+	}
+	return s.surveyRepo.GetByID(ctx, meta.SurveyID)
+}
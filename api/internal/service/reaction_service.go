@@ -0,0 +1,127 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/events"
+	"2026champs/internal/model"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidReaction is returned for an emoji outside model.ReactionEmojis
+var ErrInvalidReaction = errors.New("invalid reaction")
+
+// reactionFlushDelay lets taps that raced the window end land before it is read
+const reactionFlushDelay = 500 * time.Millisecond
+
+// ReactionService counts players' emoji reactions per model.ReactionWindow and
+// sends each window to the host as a reaction_pulse. Pulses go through the event
+// bus, so they are kept in the room event stream; the per-minute count joins the
+// participation curve and the room totals join the snapshot.
+type ReactionService struct {
+	reactionCache cache.ReactionCache
+	roomCache     cache.RoomCache
+	playerCache   cache.PlayerCache
+	timeseries    *TimeseriesService
+	broadcaster   Broadcaster
+}
+
+// NewReactionService creates a new reaction service
+func NewReactionService(reactionCache cache.ReactionCache, roomCache cache.RoomCache, playerCache cache.PlayerCache) *ReactionService {
+	return &ReactionService{
+		reactionCache: reactionCache,
+		roomCache:     roomCache,
+		playerCache:   playerCache,
+	}
+}
+
+// SetBroadcaster sets the broadcaster for reaction pulses
+func (s *ReactionService) SetBroadcaster(b Broadcaster) {
+	s.broadcaster = b
+}
+
+// SetTimeseriesService counts reactions in the participation curve
+func (s *ReactionService) SetTimeseriesService(t *TimeseriesService) {
+	s.timeseries = t
+}
+
+// React counts a player's reaction in the current window. Taps past
+// model.MaxReactionsPerWindow in a window and taps outside an ACTIVE room are
+// dropped silently. The instance that counts a window's first reaction sends its pulse.
+func (s *ReactionService) React(ctx context.Context, roomCode, playerID, emoji string) error {
+	if !model.IsReactionEmoji(emoji) {
+		return ErrInvalidReaction
+	}
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil {
+		return err
+	}
+	if meta == nil || meta.Status != model.RoomStatusActive {
+		return nil
+	}
+	player, err := s.playerCache.GetPlayer(ctx, roomCode, playerID)
+	if err != nil {
+		return err
+	}
+	if player == nil || player.HasLeft() {
+		return ErrPlayerLeft
+	}
+
+	window := time.Now().Truncate(model.ReactionWindow)
+	outcome, err := s.reactionCache.Add(ctx, roomCode, playerID, emoji, window, model.MaxReactionsPerWindow)
+	if err != nil {
+		return err
+	}
+	if outcome == cache.ReactionDropped {
+		return nil
+	}
+	if s.timeseries != nil {
+		s.timeseries.Record(ctx, roomCode, cache.MetricReactions)
+	}
+	if outcome == cache.ReactionFirst {
+		time.AfterFunc(time.Until(window.Add(model.ReactionWindow))+reactionFlushDelay, func() {
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			s.pulse(flushCtx, roomCode, window)
+		})
+	}
+	return nil
+}
+
+// pulse sends the window's reactions to the host
+func (s *ReactionService) pulse(ctx context.Context, roomCode string, window time.Time) {
+	if s.broadcaster == nil {
+		return
+	}
+	counts, players, err := s.reactionCache.Window(ctx, roomCode, window)
+	if err != nil {
+		fmt.Printf("[Reactions] Window %d of %s unavailable: %v\n", window.Unix(), roomCode, err)
+		return
+	}
+	var total int64
+	for _, n := range counts {
+		total += n
+	}
+	if total == 0 {
+		return
+	}
+	s.broadcaster.ToHost(roomCode, events.ReactionPulse, events.ReactionPulsePayload{
+		WindowStart: window.UTC(),
+		WindowEnd:   window.Add(model.ReactionWindow).UTC(),
+		Counts:      counts,
+		Total:       total,
+		Players:     players,
+	})
+}
+
+// ForSnapshot returns the room's reaction totals per emoji
+func (s *ReactionService) ForSnapshot(ctx context.Context, roomCode string) map[string]int64 {
+	totals, err := s.reactionCache.Totals(ctx, roomCode)
+	if err != nil {
+		fmt.Printf("[Reactions] Totals of %s unavailable for the snapshot: %v\n", roomCode, err)
+		return map[string]int64{}
+	}
+	return totals
+}
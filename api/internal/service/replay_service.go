@@ -0,0 +1,364 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Replay limits
+const (
+	defaultReplayAICalls = 20
+	maxReplayAICalls     = 100
+	maxReplayDiffs       = 200 // answers listed in one report
+)
+
+// ErrInvalidReplay is returned for replays of rooms or edits that cannot be replayed
+var ErrInvalidReplay = errors.New("invalid replay")
+
+// ReplayService re-evaluates the stored answers of a finished room against edited
+// question definitions and reports what would have changed
+type ReplayService struct {
+	roomRepo   repository.RoomRepo
+	surveyRepo repository.SurveyRepo
+	answerRepo repository.AnswerRepo
+	evaluator  *EvaluatorService
+}
+
+// NewReplayService creates a new replay service
+func NewReplayService(roomRepo repository.RoomRepo, surveyRepo repository.SurveyRepo, answerRepo repository.AnswerRepo, evaluator *EvaluatorService) *ReplayService {
+	return &ReplayService{
+		roomRepo:   roomRepo,
+		surveyRepo: surveyRepo,
+		answerRepo: answerRepo,
+		evaluator:  evaluator,
+	}
+}
+
+// replayBefore is an answer's stored AI judgment
+type replayBefore struct {
+	result     model.ReplayResult
+	overridden bool
+}
+
+// Replay compares the room's stored ESSAY evaluations with a replay against the
+// candidate survey. Questions whose rubric, prompt or AI settings changed are
+// re-evaluated within the AI call budget; threshold and points changes re-score
+// the stored quality scores without AI calls. The room's survey as it is now is
+// the baseline the candidate is compared with.
+func (s *ReplayService) Replay(ctx context.Context, roomCode, hostID string, req *model.ReplayRequest) (*model.ReplayReport, error) {
+	room, err := s.roomRepo.GetByCode(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, nil
+	}
+	if room.HostID != hostID {
+		return nil, ErrNotRoomHost
+	}
+	if room.Status != model.RoomStatusEnded {
+		return nil, fmt.Errorf("%w: only ended rooms can be replayed", ErrInvalidReplay)
+	}
+
+	budget := req.MaxAICalls
+	if budget == 0 {
+		budget = defaultReplayAICalls
+	}
+	if budget < 0 || budget > maxReplayAICalls {
+		return nil, fmt.Errorf("%w: maxAiCalls must be between 1 and %d", ErrInvalidReplay, maxReplayAICalls)
+	}
+
+	baseline, err := s.surveyRepo.GetByID(ctx, room.SurveyID)
+	if err != nil {
+		return nil, err
+	}
+	if baseline == nil {
+		return nil, fmt.Errorf("%w: the room's survey no longer exists", ErrInvalidReplay)
+	}
+	candidate := baseline
+	if req.SurveyID != "" && req.SurveyID != room.SurveyID {
+		candidate, err = s.surveyRepo.GetByID(ctx, req.SurveyID)
+		if err != nil {
+			return nil, err
+		}
+		if candidate == nil || candidate.HostID != hostID {
+			return nil, fmt.Errorf("%w: survey %s not found", ErrInvalidReplay, req.SurveyID)
+		}
+	}
+	candidates, err := replayCandidates(candidate, req.Questions)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := s.answerRepo.GetByRoomCode(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	byQuestion := map[string][]*model.Answer{}
+	for _, a := range answers {
+		if a.Status != model.AnswerStatusEvaluated || a.TextAnswer == "" {
+			continue
+		}
+		if a.Resolution != model.ResolutionSat && a.Resolution != model.ResolutionUnsat {
+			continue
+		}
+		byQuestion[a.QuestionKey] = append(byQuestion[a.QuestionKey], a)
+	}
+
+	onlyChanges := req.OnlyChanges == nil || *req.OnlyChanges
+	report := &model.ReplayReport{
+		RoomCode:   roomCode,
+		SurveyID:   candidate.ID,
+		Mock:       !s.evaluator.config.IsEnabled(),
+		MaxAICalls: budget,
+		Questions:  []model.QuestionReplay{},
+		Players:    []model.PlayerReplayDiff{},
+		Answers:    []model.AnswerReplayDiff{},
+	}
+	players := map[string]*model.PlayerReplayDiff{}
+
+	// Base ESSAY questions in survey order; follow-ups have no definition to replay
+	for _, base := range baseline.Questions {
+		q, ok := candidates[base.Key]
+		if !ok || base.Type != model.QuestionTypeEssay || q.Type != model.QuestionTypeEssay {
+			continue
+		}
+		qAnswers := byQuestion[base.Key]
+
+		summary := model.QuestionReplay{
+			QuestionKey: q.Key,
+			Mode:        model.ReplayModeSignals,
+			Rubric:      q.Rubric,
+			Threshold:   q.Threshold,
+			PointsMax:   q.PointsMax,
+			Answers:     len(qAnswers),
+		}
+		if req.ForceAI || base.Prompt != q.Prompt || base.Rubric != q.Rubric || !reflect.DeepEqual(base.AI, q.AI) {
+			summary.Mode = model.ReplayModeAI
+		}
+
+		var after []*model.ReplayResult
+		if summary.Mode == model.ReplayModeAI {
+			after = s.reEvaluate(ctx, q, qAnswers, budget, report)
+		} else {
+			after = make([]*model.ReplayResult, len(qAnswers))
+			for i, a := range qAnswers {
+				after[i] = rescoreReplay(a, base.Threshold, q)
+			}
+		}
+
+		qualityBefore, qualityAfter := 0.0, 0.0
+		for i, a := range qAnswers {
+			if after[i] == nil {
+				summary.NotReplayed++
+				continue
+			}
+			before := replayBeforeOf(a)
+			summary.Replayed++
+			summary.PointsBefore += before.result.Points
+			summary.PointsAfter += after[i].Points
+			qualityBefore += before.result.QualityScore
+			qualityAfter += after[i].QualityScore
+			switch {
+			case before.result.Resolution == model.ResolutionUnsat && after[i].Resolution == model.ResolutionSat:
+				summary.UnsatToSat++
+			case before.result.Resolution == model.ResolutionSat && after[i].Resolution == model.ResolutionUnsat:
+				summary.SatToUnsat++
+			}
+
+			p := players[a.PlayerID]
+			if p == nil {
+				p = &model.PlayerReplayDiff{PlayerID: a.PlayerID}
+				players[a.PlayerID] = p
+			}
+			p.PointsBefore += before.result.Points
+			p.PointsAfter += after[i].Points
+
+			changed := before.result.Resolution != after[i].Resolution || before.result.Points != after[i].Points
+			if onlyChanges && !changed {
+				continue
+			}
+			if len(report.Answers) >= maxReplayDiffs {
+				report.Truncated = true
+				continue
+			}
+			report.Answers = append(report.Answers, model.AnswerReplayDiff{
+				AnswerID:    a.ID,
+				PlayerID:    a.PlayerID,
+				QuestionKey: a.QuestionKey,
+				TextAnswer:  a.TextAnswer,
+				Overridden:  before.overridden,
+				Before:      before.result,
+				After:       *after[i],
+			})
+		}
+		if summary.Replayed > 0 {
+			summary.MeanQualityBefore = qualityBefore / float64(summary.Replayed)
+			summary.MeanQualityAfter = qualityAfter / float64(summary.Replayed)
+		}
+		report.Questions = append(report.Questions, summary)
+	}
+
+	for _, p := range players {
+		if p.PointsBefore != p.PointsAfter {
+			report.Players = append(report.Players, *p)
+		}
+	}
+	sort.Slice(report.Players, func(i, j int) bool {
+		di, dj := replayPointsChange(report.Players[i]), replayPointsChange(report.Players[j])
+		if di != dj {
+			return di > dj
+		}
+		return report.Players[i].PlayerID < report.Players[j].PlayerID
+	})
+	return report, nil
+}
+
+// replayPointsChange is the size of a player's points change
+func replayPointsChange(p model.PlayerReplayDiff) int {
+	d := p.PointsAfter - p.PointsBefore
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// reEvaluate runs the answers through L1 evaluation in batches until the budget
+// is spent. Entries are nil for answers left out or not covered by the AI.
+func (s *ReplayService) reEvaluate(ctx context.Context, q *model.Question, answers []*model.Answer, budget int, report *model.ReplayReport) []*model.ReplayResult {
+	results := make([]*model.ReplayResult, len(answers))
+	batchSize := s.evaluator.config.Batch.MaxSize
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	for start := 0; start < len(answers); start += batchSize {
+		if !report.Mock && report.AICalls >= budget {
+			break
+		}
+		end := start + batchSize
+		if end > len(answers) {
+			end = len(answers)
+		}
+		if !report.Mock {
+			report.AICalls++
+		}
+		evals, err := s.evaluator.ReEvaluateBatch(ctx, q, answers[start:end])
+		if err != nil {
+			fmt.Printf("[Replay] Batch for %s failed: %v\n", q.Key, err)
+			continue
+		}
+		for i, e := range evals {
+			if e == nil {
+				continue
+			}
+			result := &model.ReplayResult{
+				Resolution:   model.AnswerResolution(e.Resolution),
+				QualityScore: e.QualityScore,
+				Summary:      e.Signals.Summary,
+			}
+			if result.Resolution == model.ResolutionSat {
+				result.Points = int(e.QualityScore * float64(q.PointsMax))
+			}
+			results[start+i] = result
+		}
+	}
+	return results
+}
+
+// rescoreReplay applies a new threshold and points maximum to a stored evaluation.
+// Only answers whose quality lies between the old and new threshold flip.
+func rescoreReplay(a *model.Answer, oldThreshold float64, q *model.Question) *model.ReplayResult {
+	resolution := replayBeforeOf(a).result.Resolution
+	switch {
+	case q.Threshold > oldThreshold && a.QualityScore < q.Threshold:
+		resolution = model.ResolutionUnsat
+	case q.Threshold < oldThreshold && a.QualityScore >= q.Threshold:
+		resolution = model.ResolutionSat
+	}
+	result := &model.ReplayResult{
+		Resolution:   resolution,
+		QualityScore: a.QualityScore,
+		Summary:      a.EvalSummary,
+	}
+	if resolution == model.ResolutionSat {
+		result.Points = int(a.QualityScore * float64(q.PointsMax))
+	}
+	return result
+}
+
+// replayBeforeOf returns the AI's original judgment, undoing any host override
+func replayBeforeOf(a *model.Answer) replayBefore {
+	before := replayBefore{result: model.ReplayResult{
+		Resolution:   a.Resolution,
+		QualityScore: a.QualityScore,
+		Points:       a.PointsEarned,
+		Summary:      a.EvalSummary,
+	}}
+	if a.Override != nil {
+		before.result.Resolution = a.Override.OriginalResolution
+		before.result.Points = a.Override.OriginalPoints
+		before.overridden = true
+	}
+	return before
+}
+
+// replayCandidates builds the candidate question definitions with the edits applied
+func replayCandidates(survey *model.Survey, edits map[string]model.ReplayQuestionEdit) (map[string]*model.Question, error) {
+	candidates := make(map[string]*model.Question, len(survey.Questions))
+	for _, b := range survey.Questions {
+		candidates[b.Key] = &model.Question{
+			Key:       b.Key,
+			Type:      b.Type,
+			Prompt:    b.Prompt,
+			Rubric:    b.Rubric,
+			PointsMax: b.PointsMax,
+			Threshold: b.Threshold,
+			Media:     b.Media,
+			AI:        b.AI,
+		}
+	}
+
+	for key, edit := range edits {
+		q, ok := candidates[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: question %s not found in survey", ErrInvalidReplay, key)
+		}
+		if q.Type != model.QuestionTypeEssay {
+			return nil, fmt.Errorf("%w: question %s: only ESSAY questions are AI-evaluated", ErrInvalidReplay, key)
+		}
+		if edit.Prompt != nil {
+			if strings.TrimSpace(*edit.Prompt) == "" {
+				return nil, fmt.Errorf("%w: question %s: prompt must not be empty", ErrInvalidReplay, key)
+			}
+			q.Prompt = *edit.Prompt
+		}
+		if edit.Rubric != nil {
+			q.Rubric = *edit.Rubric
+		}
+		if edit.Threshold != nil {
+			if *edit.Threshold < 0 || *edit.Threshold > 1 {
+				return nil, fmt.Errorf("%w: question %s: threshold must be between 0 and 1", ErrInvalidReplay, key)
+			}
+			q.Threshold = *edit.Threshold
+		}
+		if edit.PointsMax != nil {
+			if *edit.PointsMax < 0 {
+				return nil, fmt.Errorf("%w: question %s: pointsMax must not be negative", ErrInvalidReplay, key)
+			}
+			q.PointsMax = *edit.PointsMax
+		}
+		if edit.AI != nil {
+			q.AI = edit.AI
+			if err := validateQuestionAI([]model.BaseQuestion{{Key: key, AI: q.AI}}); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidReplay, err)
+			}
+		}
+	}
+	return candidates, nil
+}
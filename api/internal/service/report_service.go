@@ -5,6 +5,10 @@ import (
 	"2026champs/internal/model"
 	"2026champs/internal/repository"
 	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -16,7 +20,17 @@ type ReportService struct {
 	surveyRepo     repository.SurveyRepo
 	analyticsCache cache.AnalyticsCache
 	leaderboard    cache.LeaderboardCache
+	playerCache    cache.PlayerCache
+	usageCache     cache.AIUsageCache
 	evaluator      *EvaluatorService
+	authSvc        *AuthService // Signs share links
+	publicURL      string       // Base URL of the web app, for share links
+	integrations   *IntegrationService
+	auditSvc       *AuditService
+	timeseries     *TimeseriesService
+	polls          *PollService
+	reactions      *ReactionService
+	metaSurvey     *MetaSurveyService
 }
 
 // NewReportService creates a new report service
@@ -37,9 +51,50 @@ func NewReportService(
 		analyticsCache: analyticsCache,
 		leaderboard:    leaderboard,
 		evaluator:      evaluator,
+		publicURL:      "http://localhost:3000",
 	}
 }
 
+// SetPlayerCache sets the player cache used for completion tracking in snapshots
+func (s *ReportService) SetPlayerCache(c cache.PlayerCache) {
+	s.playerCache = c
+}
+
+// SetIntegrationService sets the service that posts ready AI reports to chat webhooks
+func (s *ReportService) SetIntegrationService(i *IntegrationService) {
+	s.integrations = i
+}
+
+// SetUsageCache sets the AI usage cache used for per-room cost reports
+func (s *ReportService) SetUsageCache(c cache.AIUsageCache) {
+	s.usageCache = c
+}
+
+// SetAuditService enables recording share links in the room audit trail
+func (s *ReportService) SetAuditService(a *AuditService) {
+	s.auditSvc = a
+}
+
+// SetTimeseriesService adds the participation curve to snapshots
+func (s *ReportService) SetTimeseriesService(t *TimeseriesService) {
+	s.timeseries = t
+}
+
+// SetPollService adds the room's live polls to snapshots
+func (s *ReportService) SetPollService(p *PollService) {
+	s.polls = p
+}
+
+// SetMetaSurveyService adds the room's meta-survey answers to snapshots
+func (s *ReportService) SetMetaSurveyService(m *MetaSurveyService) {
+	s.metaSurvey = m
+}
+
+// SetReactionService adds the room's reaction totals to snapshots
+func (s *ReportService) SetReactionService(r *ReactionService) {
+	s.reactions = r
+}
+
 // CreateSnapshot creates the instant dashboard snapshot on room end
 func (s *ReportService) CreateSnapshot(ctx context.Context, roomCode string, questionKeys []string) (*model.RoomSnapshot, error) {
 	// Get room info
@@ -55,11 +110,14 @@ func (s *ReportService) CreateSnapshot(ctx context.Context, roomCode string, que
 	}
 
 	leaderboard := []model.LeaderboardEntry{}
-	for i, e := range entries {
+	for _, e := range entries {
 		leaderboard = append(leaderboard, model.LeaderboardEntry{
-			PlayerID: e.PlayerID,
-			Score:    int(e.Score),
-			Rank:     i + 1,
+			PlayerID:      e.PlayerID,
+			Score:         e.Score,
+			Rank:          e.Rank,
+			Tied:          e.Tied,
+			CompletionSec: e.CompletionSec,
+			JoinOrder:     e.JoinOrder,
 		})
 	}
 
@@ -90,15 +148,47 @@ func (s *ReportService) CreateSnapshot(ctx context.Context, roomCode string, que
 		skipRate = float64(totalSkips) / float64(totalAnswers)
 	}
 
+	// Completion and drop-off per player
+	completion, completionRate := s.playerCompletion(ctx, roomCode)
+	memory.CompletionRate = completionRate
+
 	snapshot := &model.RoomSnapshot{
 		RoomCode:         roomCode,
 		SurveyID:         room.SurveyID,
 		EndedAt:          time.Now(),
+		Practice:         room.Settings.Practice,
 		Leaderboard:      leaderboard,
 		QuestionProfiles: profiles,
 		Memory:           *memory,
+		PlayerCompletion: completion,
 		TotalPlayers:     len(leaderboard),
+		CompletionRate:   completionRate,
 		OverallSkipRate:  skipRate,
+		AIUsage:          s.liveUsage(ctx, roomCode),
+		StarredAnswers:   s.starredAnswers(ctx, roomCode),
+		Participation:    []model.ParticipationPoint{},
+		SlowQuestions:    slowQuestions(profiles),
+		Polls:            []model.Poll{},
+		Reactions:        map[string]int64{},
+	}
+	if s.polls != nil {
+		snapshot.Polls = s.polls.ForSnapshot(ctx, roomCode)
+	}
+	if s.reactions != nil {
+		snapshot.Reactions = s.reactions.ForSnapshot(ctx, roomCode)
+	}
+	if s.metaSurvey != nil {
+		snapshot.MetaFeedback = s.metaSurvey.ForSnapshot(ctx, roomCode)
+	}
+	if s.timeseries != nil {
+		if points, err := s.timeseries.Series(ctx, roomCode, snapshot.EndedAt); err == nil {
+			snapshot.Participation = points
+		} else {
+			fmt.Printf("[Report] Participation curve for %s unavailable: %v\n", roomCode, err)
+		}
+	}
+	if relevance, err := s.analyticsCache.GetFollowUpRelevance(ctx, roomCode); err == nil {
+		snapshot.FollowUpRelevance = relevance
 	}
 
 	// Save snapshot
@@ -109,6 +199,109 @@ func (s *ReportService) CreateSnapshot(ctx context.Context, roomCode string, que
 	return snapshot, nil
 }
 
+// slowQuestionRatio is how many times the room's median response time a
+// question's median must reach to be flagged as a friction candidate
+const slowQuestionRatio = 2.0
+
+// slowQuestions flags questions whose median response time is far above the
+// median of all question medians; questions need frictionMinAnswers samples
+func slowQuestions(profiles []model.QuestionProfile) []model.SlowQuestion {
+	slow := []model.SlowQuestion{}
+	var medians []int64
+	for _, p := range profiles {
+		if p.ResponseTime != nil && p.ResponseTime.Count >= frictionMinAnswers {
+			medians = append(medians, p.ResponseTime.MedianMs)
+		}
+	}
+	if len(medians) < 2 {
+		return slow
+	}
+	roomMedian := responseTimeStats(medians).MedianMs
+	if roomMedian <= 0 {
+		return slow
+	}
+
+	for _, p := range profiles {
+		if p.ResponseTime == nil || p.ResponseTime.Count < frictionMinAnswers {
+			continue
+		}
+		ratio := float64(p.ResponseTime.MedianMs) / float64(roomMedian)
+		if ratio >= slowQuestionRatio {
+			slow = append(slow, model.SlowQuestion{
+				QuestionKey:  p.QuestionKey,
+				MedianMs:     p.ResponseTime.MedianMs,
+				RoomMedianMs: roomMedian,
+				Ratio:        math.Round(ratio*100) / 100,
+			})
+		}
+	}
+	sort.Slice(slow, func(i, j int) bool { return slow[i].Ratio > slow[j].Ratio })
+	return slow
+}
+
+// playerCompletion aggregates done flags into per-player completion and the room completion rate
+func (s *ReportService) playerCompletion(ctx context.Context, roomCode string) ([]model.PlayerCompletion, float64) {
+	completion := []model.PlayerCompletion{}
+	if s.playerCache == nil {
+		return completion, 0
+	}
+
+	players, err := s.playerCache.GetAllPlayers(ctx, roomCode)
+	if err != nil || len(players) == 0 {
+		return completion, 0
+	}
+	doneIDs, _ := s.playerCache.GetDonePlayers(ctx, roomCode)
+	done := make(map[string]bool, len(doneIDs))
+	for _, id := range doneIDs {
+		done[id] = true
+	}
+
+	completed := 0
+	for id, p := range players {
+		pc := model.PlayerCompletion{
+			PlayerID:  id,
+			Nickname:  p.Nickname,
+			Completed: done[id],
+		}
+		if pc.Completed {
+			completed++
+		} else {
+			pc.DropOffKey = p.CurrentKey
+		}
+		completion = append(completion, pc)
+	}
+	sort.Slice(completion, func(i, j int) bool {
+		return completion[i].PlayerID < completion[j].PlayerID
+	})
+
+	return completion, float64(completed) / float64(len(players))
+}
+
+// starredAnswers lists the room's host-starred answers for the snapshot
+func (s *ReportService) starredAnswers(ctx context.Context, roomCode string) []model.StarredAnswer {
+	starred := []model.StarredAnswer{}
+	answers, err := s.answerRepo.GetStarred(ctx, roomCode)
+	if err != nil {
+		fmt.Printf("[Report] Starred answers for %s unavailable: %v\n", roomCode, err)
+		return starred
+	}
+	for _, a := range answers {
+		sa := model.StarredAnswer{
+			AnswerID:    a.ID,
+			PlayerID:    a.PlayerID,
+			QuestionKey: a.QuestionKey,
+			TextAnswer:  a.TextAnswer,
+			Note:        a.Star.Note,
+			StarredAt:   a.Star.StarredAt,
+		}
+		if a.Signals != nil {
+			sa.EvalSummary = a.Signals.Summary
+		}
+		starred = append(starred, sa)
+	}
+	return starred
+}
+
 // GetSnapshot retrieves the instant dashboard snapshot
 func (s *ReportService) GetSnapshot(ctx context.Context, roomCode string) (*model.RoomSnapshot, error) {
 	snapshot, err := s.reportRepo.GetSnapshot(ctx, roomCode)
@@ -128,6 +321,9 @@ func (s *ReportService) GetSnapshot(ctx context.Context, roomCode string) (*mode
 		}
 	}
 
+	// Hosts can keep starring after the room ends
+	snapshot.StarredAnswers = s.starredAnswers(ctx, roomCode)
+
 	return snapshot, nil
 }
 
@@ -150,37 +346,319 @@ func (s *ReportService) GenerateAIReport(ctx context.Context, roomCode string) (
 		return nil, err
 	}
 
-	// Sample evidence from answers (simplified - just get summaries from signals)
 	evidenceSamples := make(map[string][]string)
+	var sampling *model.EvidenceSampling
+	if answers, err := s.answerRepo.GetByRoomCode(ctx, roomCode); err == nil {
+		evidenceSamples, sampling = s.sampleEvidence(answers)
+	}
+
+	// Funnel gives the model drop-off context for friction analysis
+	funnel, err := s.GetFunnel(ctx, roomCode)
+	if err != nil {
+		fmt.Printf("[Report] Funnel for %s unavailable: %v\n", roomCode, err)
+	}
+
+	// Generate AI report; practice rooms never spend AI quota, even after their meta expired
+	var report *model.AIReport
+	if snapshot.Practice {
+		report = s.evaluator.mockReport(snapshot)
+	} else {
+		var guardrails *model.Guardrails
+		if survey, err := s.surveyRepo.GetByID(ctx, snapshot.SurveyID); err == nil && survey != nil {
+			guardrails = survey.Guardrails
+		}
+		report, err = s.evaluator.GenerateAIReport(WithAIRoom(ctx, roomCode), snapshot, evidenceSamples, funnel, guardrails)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report.Sampling = sampling
+
+	// Save report
+	if err := s.reportRepo.SaveAIReport(ctx, report); err != nil {
+		return nil, err
+	}
+
+	if s.integrations != nil && report.Status == "ready" && !snapshot.Practice {
+		s.integrations.NotifyReportReady(roomCode, snapshot, report)
+	}
+
+	return report, nil
+}
+
+// GetEvalQuality summarizes host overrides of AI evaluations in a room
+func (s *ReportService) GetEvalQuality(ctx context.Context, roomCode string) (*model.EvalQualityReport, error) {
+	room, err := s.roomRepo.GetByCode(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, nil
+	}
 	answers, err := s.answerRepo.GetByRoomCode(ctx, roomCode)
-	if err == nil {
-		for _, ans := range answers {
-			if ans.Signals != nil && ans.Signals.Summary != "" {
-				if evidenceSamples[ans.QuestionKey] == nil {
-					evidenceSamples[ans.QuestionKey] = []string{}
-				}
-				if len(evidenceSamples[ans.QuestionKey]) < 5 {
-					evidenceSamples[ans.QuestionKey] = append(evidenceSamples[ans.QuestionKey], ans.Signals.Summary)
-				}
-			}
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.EvalQualityReport{
+		RoomCode:    roomCode,
+		Questions:   []model.EvalQualityQuestion{},
+		Corrections: []model.EvalCorrection{},
+	}
+	perQuestion := map[string]*model.EvalQualityQuestion{}
+	order := []string{}
+	totalDelta := 0
+
+	for _, a := range answers {
+		if a.Status != model.AnswerStatusEvaluated {
+			continue
+		}
+		q, ok := perQuestion[a.QuestionKey]
+		if !ok {
+			q = &model.EvalQualityQuestion{QuestionKey: a.QuestionKey}
+			perQuestion[a.QuestionKey] = q
+			order = append(order, a.QuestionKey)
+		}
+		q.Evaluated++
+		report.Evaluated++
+
+		o := a.Override
+		if o == nil {
+			continue
+		}
+		q.Overridden++
+		report.Overridden++
+		totalDelta += o.Points - o.OriginalPoints
+		switch {
+		case o.OriginalResolution == model.ResolutionUnsat && o.Resolution == model.ResolutionSat:
+			report.UnsatToSat++
+		case o.OriginalResolution == model.ResolutionSat && o.Resolution == model.ResolutionUnsat:
+			report.SatToUnsat++
+		default:
+			report.PointsOnly++
 		}
+		report.Corrections = append(report.Corrections, model.EvalCorrection{
+			AnswerID:           a.ID,
+			QuestionKey:        a.QuestionKey,
+			TextAnswer:         a.TextAnswer,
+			EvalSummary:        a.EvalSummary,
+			OriginalResolution: o.OriginalResolution,
+			Resolution:         o.Resolution,
+			OriginalPoints:     o.OriginalPoints,
+			Points:             o.Points,
+			Reason:             o.Reason,
+		})
+	}
+
+	sort.Strings(order)
+	for _, key := range order {
+		q := perQuestion[key]
+		if q.Evaluated > 0 {
+			q.OverrideRate = float64(q.Overridden) / float64(q.Evaluated)
+		}
+		report.Questions = append(report.Questions, *q)
+	}
+	if report.Evaluated > 0 {
+		report.OverrideRate = float64(report.Overridden) / float64(report.Evaluated)
 	}
+	if report.Overridden > 0 {
+		report.AvgPointsDelta = float64(totalDelta) / float64(report.Overridden)
+	}
+	return report, nil
+}
 
-	// Generate AI report
-	report, err := s.evaluator.GenerateAIReport(ctx, snapshot, evidenceSamples)
+// GetUsage returns the AI calls, tokens and estimated cost of a room.
+// Live Redis counters are used while they exist (they include post-room AI reports);
+// after they expire the copy frozen into the snapshot is returned.
+func (s *ReportService) GetUsage(ctx context.Context, roomCode string) (*model.RoomUsageReport, error) {
+	room, err := s.roomRepo.GetByCode(ctx, roomCode)
 	if err != nil {
 		return nil, err
 	}
+	if room == nil {
+		return nil, nil
+	}
 
-	// Save report
-	if err := s.reportRepo.SaveAIReport(ctx, report); err != nil {
+	if usage := s.liveUsage(ctx, roomCode); usage != nil && usage.Calls > 0 {
+		return usage, nil
+	}
+	snapshot, err := s.reportRepo.GetSnapshot(ctx, roomCode)
+	if err != nil {
 		return nil, err
 	}
+	if snapshot != nil && snapshot.AIUsage != nil {
+		return snapshot.AIUsage, nil
+	}
+	return &model.RoomUsageReport{RoomCode: roomCode, Models: []model.ModelCostReport{}, GeneratedAt: time.Now()}, nil
+}
+
+// liveUsage builds the usage report from the Redis counters (nil if unavailable)
+func (s *ReportService) liveUsage(ctx context.Context, roomCode string) *model.RoomUsageReport {
+	if s.usageCache == nil {
+		return nil
+	}
+	usage, err := s.usageCache.GetRoomUsage(ctx, roomCode)
+	if err != nil {
+		fmt.Printf("[Usage] Failed to read usage for %s: %v\n", roomCode, err)
+		return nil
+	}
+
+	report := &model.RoomUsageReport{
+		RoomCode:        roomCode,
+		Calls:           usage.Calls,
+		InputTokens:     usage.InputTokens,
+		OutputTokens:    usage.OutputTokens,
+		EstimatedTokens: usage.EstimatedTokens,
+		Models:          []model.ModelCostReport{},
+		GeneratedAt:     time.Now(),
+	}
+	for name, mu := range usage.Models {
+		mc := model.ModelCostReport{Model: name, ModelUsage: mu}
+		if s.evaluator != nil {
+			mc.EstimatedCostUSD, mc.Priced = s.evaluator.config.EstimateCost(name, mu.InputTokens, mu.OutputTokens)
+		}
+		report.EstimatedCostUSD += mc.EstimatedCostUSD
+		report.Models = append(report.Models, mc)
+	}
+	sort.Slice(report.Models, func(i, j int) bool {
+		return report.Models[i].Model < report.Models[j].Model
+	})
+	return report
+}
+
+// GetFunnel builds the per-question reached/answered/skipped/abandoned funnel for a room
+func (s *ReportService) GetFunnel(ctx context.Context, roomCode string) (*model.FunnelReport, error) {
+	room, err := s.roomRepo.GetByCode(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, nil
+	}
+	survey, err := s.surveyRepo.GetByID(ctx, room.SurveyID)
+	if err != nil {
+		return nil, err
+	}
+	if survey == nil {
+		return nil, fmt.Errorf("survey not found")
+	}
+
+	answers, err := s.answerRepo.GetByRoomCode(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prefer the frozen completion data; fall back to live player state
+	var completion []model.PlayerCompletion
+	snapshot, err := s.reportRepo.GetSnapshot(ctx, roomCode)
+	if err == nil && snapshot != nil {
+		completion = snapshot.PlayerCompletion
+	}
+	if len(completion) == 0 {
+		completion, _ = s.playerCompletion(ctx, roomCode)
+	}
+
+	// Per base question: player -> answered / skipped
+	answered := make(map[string]map[string]bool)
+	skipped := make(map[string]map[string]bool)
+	for _, ans := range answers {
+		base := baseQuestionKey(ans.QuestionKey)
+		if ans.Resolution == model.ResolutionSkipped {
+			if skipped[base] == nil {
+				skipped[base] = make(map[string]bool)
+			}
+			skipped[base][ans.PlayerID] = true
+			continue
+		}
+		if answered[base] == nil {
+			answered[base] = make(map[string]bool)
+		}
+		answered[base][ans.PlayerID] = true
+	}
+
+	abandoned := make(map[string]map[string]bool)
+	for _, pc := range completion {
+		if pc.Completed || pc.DropOffKey == "" {
+			continue
+		}
+		base := baseQuestionKey(pc.DropOffKey)
+		if abandoned[base] == nil {
+			abandoned[base] = make(map[string]bool)
+		}
+		abandoned[base][pc.PlayerID] = true
+	}
+
+	report := &model.FunnelReport{
+		RoomCode:     roomCode,
+		TotalPlayers: len(completion),
+		Steps:        []model.FunnelStep{},
+	}
+	for i, q := range survey.Questions {
+		reached := make(map[string]bool)
+		for _, set := range []map[string]bool{answered[q.Key], skipped[q.Key], abandoned[q.Key]} {
+			for id := range set {
+				reached[id] = true
+			}
+		}
+		// A skip only counts if the player never answered the question
+		skippedOnly := 0
+		for id := range skipped[q.Key] {
+			if !answered[q.Key][id] {
+				skippedOnly++
+			}
+		}
+		report.Steps = append(report.Steps, model.FunnelStep{
+			QuestionKey: q.Key,
+			Position:    i + 1,
+			Prompt:      q.Prompt,
+			Reached:     len(reached),
+			Answered:    len(answered[q.Key]),
+			Skipped:     skippedOnly,
+			Abandoned:   len(abandoned[q.Key]),
+		})
+	}
 
 	return report, nil
 }
 
+// baseQuestionKey strips follow-up suffixes (Q1.2 -> Q1)
+func baseQuestionKey(key string) string {
+	if idx := strings.Index(key, "."); idx > 0 {
+		return key[:idx]
+	}
+	return key
+}
+
 // GetAIReport retrieves the AI report
 func (s *ReportService) GetAIReport(ctx context.Context, roomCode string) (*model.AIReport, error) {
 	return s.reportRepo.GetAIReport(ctx, roomCode)
 }
+
+// GetSnapshots returns the stored snapshots of several rooms in one query, keyed
+// by room code. Unlike GetSnapshot they are not patched with SurveyMonkey links
+// or the current starred answers.
+func (s *ReportService) GetSnapshots(ctx context.Context, roomCodes []string) (map[string]*model.RoomSnapshot, error) {
+	snapshots, err := s.reportRepo.GetSnapshots(ctx, roomCodes)
+	if err != nil {
+		return nil, err
+	}
+	byRoom := make(map[string]*model.RoomSnapshot, len(snapshots))
+	for _, snap := range snapshots {
+		byRoom[snap.RoomCode] = snap
+	}
+	return byRoom, nil
+}
+
+// GetAIReports returns the AI reports of several rooms in one query, keyed by room code
+func (s *ReportService) GetAIReports(ctx context.Context, roomCodes []string) (map[string]*model.AIReport, error) {
+	reports, err := s.reportRepo.GetAIReports(ctx, roomCodes)
+	if err != nil {
+		return nil, err
+	}
+	byRoom := make(map[string]*model.AIReport, len(reports))
+	for _, report := range reports {
+		byRoom[report.RoomCode] = report
+	}
+	return byRoom, nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"2026champs/internal/config"
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"fmt"
+	"time"
+)
+
+// FilePurger deletes the stored files behind documents that are about to expire
+type FilePurger interface {
+	PurgeFilesOlderThan(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// retentionTarget is a collection governed by a retention policy
+type retentionTarget struct {
+	collection string
+	dateField  string
+	days       int
+	files      FilePurger // Optional; such collections get no TTL index so files are never orphaned
+}
+
+// RetentionService purges data older than the configured retention periods
+type RetentionService struct {
+	retentionRepo repository.RetentionRepo
+	config        *config.RetentionConfig
+	targets       []retentionTarget
+}
+
+// NewRetentionService creates a new retention service
+func NewRetentionService(retentionRepo repository.RetentionRepo, cfg *config.RetentionConfig) *RetentionService {
+	return &RetentionService{
+		retentionRepo: retentionRepo,
+		config:        cfg,
+		targets: []retentionTarget{
+			{collection: "answers", dateField: "createdAt", days: cfg.AnswersDays},
+			{collection: "player_feedback", dateField: "createdAt", days: cfg.AnswersDays}, // Quotes answers
+			{collection: "room_snapshots", dateField: "endedAt", days: cfg.SnapshotsDays},
+			{collection: "ai_reports", dateField: "createdAt", days: cfg.AIReportsDays},
+			{collection: "sm_responses_raw", dateField: "date_modified", days: cfg.SMRawDays},
+		},
+	}
+}
+
+// SetVoicePurger puts recorded voice answers under the voice retention period
+func (s *RetentionService) SetVoicePurger(p FilePurger) {
+	s.targets = append(s.targets, retentionTarget{collection: "voice_clips", dateField: "createdAt", days: s.config.VoiceDays, files: p})
+}
+
+// SetAttachmentPurger puts answer attachments under the answers retention period
+func (s *RetentionService) SetAttachmentPurger(p FilePurger) {
+	s.targets = append(s.targets, retentionTarget{collection: "attachments", dateField: "createdAt", days: s.config.AnswersDays, files: p})
+}
+
+// EnsureTTLIndexes lets MongoDB expire documents on its own. Skipped in dry-run mode,
+// since a TTL index deletes regardless of what the purge job reports.
+func (s *RetentionService) EnsureTTLIndexes(ctx context.Context) {
+	if !s.config.TTLIndexes || s.config.DryRun {
+		return
+	}
+	for _, t := range s.targets {
+		if t.days <= 0 || t.files != nil {
+			continue
+		}
+		if err := s.retentionRepo.EnsureTTLIndex(ctx, t.collection, t.dateField, retentionPeriod(t.days)); err != nil {
+			fmt.Printf("[Retention] Failed to ensure TTL index on %s.%s: %v\n", t.collection, t.dateField, err)
+		}
+	}
+}
+
+// Purge deletes expired documents; with dryRun it only counts them
+func (s *RetentionService) Purge(ctx context.Context, dryRun bool) *model.RetentionReport {
+	now := time.Now()
+	report := &model.RetentionReport{DryRun: dryRun, RanAt: now}
+
+	for _, t := range s.targets {
+		if t.days <= 0 {
+			continue
+		}
+		item := model.RetentionItem{
+			Collection:    t.collection,
+			DateField:     t.dateField,
+			RetentionDays: t.days,
+			Cutoff:        now.Add(-retentionPeriod(t.days)),
+			TTLIndex:      s.config.TTLIndexes && !s.config.DryRun && t.files == nil,
+		}
+
+		expired, err := s.retentionRepo.CountOlderThan(ctx, t.collection, t.dateField, item.Cutoff)
+		if err != nil {
+			item.Error = err.Error()
+			report.Items = append(report.Items, item)
+			continue
+		}
+		item.Expired = expired
+
+		if !dryRun && expired > 0 && t.files != nil {
+			files, err := t.files.PurgeFilesOlderThan(ctx, item.Cutoff)
+			item.FilesDeleted = files
+			if err != nil {
+				// Keep the documents so the next run can retry the remaining files
+				item.Error = err.Error()
+				report.Items = append(report.Items, item)
+				continue
+			}
+		}
+
+		if !dryRun && expired > 0 {
+			deleted, err := s.retentionRepo.DeleteOlderThan(ctx, t.collection, t.dateField, item.Cutoff)
+			if err != nil {
+				item.Error = err.Error()
+			}
+			item.Deleted = deleted
+		}
+		report.Items = append(report.Items, item)
+	}
+	return report
+}
+
+// StartDaily runs Purge once a day at the configured hour until ctx is done
+func (s *RetentionService) StartDaily(ctx context.Context) {
+	go func() {
+		for {
+			wait := time.Until(nextRunAt(time.Now().UTC(), s.config.PurgeHourUTC))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+				report := s.Purge(ctx, s.config.DryRun)
+				for _, item := range report.Items {
+					switch {
+					case item.Error != "":
+						fmt.Printf("[Retention] %s purge failed: %s\n", item.Collection, item.Error)
+					case report.DryRun:
+						fmt.Printf("[Retention] Dry run: would delete %d from %s (older than %s)\n",
+							item.Expired, item.Collection, item.Cutoff.Format(time.RFC3339))
+					default:
+						fmt.Printf("[Retention] Deleted %d from %s\n", item.Deleted, item.Collection)
+					}
+				}
+			}
+		}
+	}()
+}
+
+func retentionPeriod(days int) time.Duration {
+	return time.Duration(days) * 24 * time.Hour
+}
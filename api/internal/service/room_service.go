@@ -2,13 +2,20 @@ package service
 
 import (
 	"2026champs/internal/cache"
+	"2026champs/internal/events"
 	"2026champs/internal/model"
 	"2026champs/internal/repository"
 	"context"
 	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // RoomService handles room lifecycle operations
@@ -19,6 +26,18 @@ type RoomService struct {
 	authSvc     *AuthService
 	reportSvc   *ReportService
 	broadcaster Broadcaster
+	evaluator   *EvaluatorService
+	analytics   *AnalyticsService
+	archiveSvc  *ArchiveService
+	webhooks    *IntegrationService
+	answerSvc   *AnswerService
+	leaderboard cache.LeaderboardCache
+	publicURL   string // Base URL of the web app, for join links
+
+	experimentSvc *ExperimentService
+	auditSvc      *AuditService
+	feedbackSvc   *FeedbackService
+	difficultySvc *DifficultyService
 }
 
 // NewRoomService creates a new room service
@@ -35,6 +54,7 @@ func NewRoomService(
 		roomCache:  roomCache,
 		authSvc:    authSvc,
 		reportSvc:  reportSvc,
+		publicURL:  "http://localhost:3000",
 	}
 }
 
@@ -43,14 +63,124 @@ func (s *RoomService) SetBroadcaster(b Broadcaster) {
 	s.broadcaster = b
 }
 
+// SetEvaluator sets the evaluator used to build the room scope anchor
+func (s *RoomService) SetEvaluator(e *EvaluatorService) {
+	s.evaluator = e
+}
+
+// SetAnalyticsService sets the analytics service whose L4 job tracks live rooms
+func (s *RoomService) SetAnalyticsService(a *AnalyticsService) {
+	s.analytics = a
+}
+
+// SetIntegrationService sets the service that posts room summaries to chat webhooks
+func (s *RoomService) SetIntegrationService(i *IntegrationService) {
+	s.webhooks = i
+}
+
+// SetArchiveService sets the service that flushes room analytics to Mongo on room end
+func (s *RoomService) SetArchiveService(a *ArchiveService) {
+	s.archiveSvc = a
+}
+
+// SetAnswerService sets the answer service used to record abandoned questions on room end
+func (s *RoomService) SetAnswerService(a *AnswerService) {
+	s.answerSvc = a
+}
+
+// SetLeaderboard sets the leaderboard frozen on room end
+func (s *RoomService) SetLeaderboard(lb cache.LeaderboardCache) {
+	s.leaderboard = lb
+}
+
+// SetFeedbackService enables end-of-room player feedback; the room's clients are
+// then disconnected once their feedback has been delivered
+func (s *RoomService) SetFeedbackService(f *FeedbackService) {
+	s.feedbackSvc = f
+}
+
+// SetDifficultyService recalibrates the survey's question difficulty after each room ends
+func (s *RoomService) SetDifficultyService(d *DifficultyService) {
+	s.difficultySvc = d
+}
+
+// SetExperimentService enables running rooms as part of A/B experiments
+func (s *RoomService) SetExperimentService(e *ExperimentService) {
+	s.experimentSvc = e
+}
+
+// SetAuditService enables recording lifecycle actions in the room audit trail
+func (s *RoomService) SetAuditService(a *AuditService) {
+	s.auditSvc = a
+}
+
+// SetPublicURL sets the web app base URL used in join links and QR codes
+func (s *RoomService) SetPublicURL(url string) {
+	s.publicURL = strings.TrimRight(url, "/")
+}
+
+// ErrInvalidRoomSettings is returned when a room's settings override is out of range
+var ErrInvalidRoomSettings = errors.New("invalid room settings")
+
+// validateRoomSettings checks a settings override and branding before they are stored
+func validateRoomSettings(settings *model.RoomSettings, branding *model.Branding) error {
+	if !settings.Strictness.Valid() {
+		return fmt.Errorf("%w: strictness must be lenient, standard or strict", ErrInvalidRoomSettings)
+	}
+	if settings.MaxTries != nil && (*settings.MaxTries < 1 || *settings.MaxTries > model.MaxMaxTries) {
+		return fmt.Errorf("%w: maxTries must be between 1 and %d", ErrInvalidRoomSettings, model.MaxMaxTries)
+	}
+	if settings.AllowSkipAfter != nil && (*settings.AllowSkipAfter < 0 || *settings.AllowSkipAfter > model.MaxMaxTries) {
+		return fmt.Errorf("%w: allowSkipAfter must be between 0 and %d", ErrInvalidRoomSettings, model.MaxMaxTries)
+	}
+	if settings.SkipAfterSeconds != nil && (*settings.SkipAfterSeconds < 0 || *settings.SkipAfterSeconds > model.MaxSkipAfterSeconds) {
+		return fmt.Errorf("%w: skipAfterSeconds must be between 0 and %d", ErrInvalidRoomSettings, model.MaxSkipAfterSeconds)
+	}
+	return validateBranding(branding, ErrInvalidRoomSettings)
+}
+
+// validateBranding checks survey or room branding, wrapping failures in invalid
+func validateBranding(branding *model.Branding, invalid error) error {
+	if branding == nil {
+		return nil
+	}
+	if utf8.RuneCountInString(branding.Title) > model.MaxBrandingTitleChars {
+		return fmt.Errorf("%w: branding title exceeds %d characters", invalid, model.MaxBrandingTitleChars)
+	}
+	if utf8.RuneCountInString(branding.WelcomeMessage) > model.MaxBrandingMessageChars {
+		return fmt.Errorf("%w: welcome message exceeds %d characters", invalid, model.MaxBrandingMessageChars)
+	}
+	if utf8.RuneCountInString(branding.ThankYouMessage) > model.MaxBrandingMessageChars {
+		return fmt.Errorf("%w: thank-you message exceeds %d characters", invalid, model.MaxBrandingMessageChars)
+	}
+	if branding.LogoURL != "" {
+		u, err := url.Parse(branding.LogoURL)
+		if err != nil || u.Scheme != "https" || u.Host == "" || len(branding.LogoURL) > model.MaxBrandingURLChars {
+			return fmt.Errorf("%w: logoUrl must be an https URL of at most %d characters", invalid, model.MaxBrandingURLChars)
+		}
+	}
+	for _, color := range []string{branding.PrimaryColor, branding.AccentColor, branding.BackgroundColor, branding.TextColor} {
+		if color != "" && !hexColorPattern.MatchString(color) {
+			return fmt.Errorf("%w: colors must be #RRGGBB", invalid)
+		}
+	}
+	return nil
+}
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
 // CreateRoom creates a new room from a survey
-func (s *RoomService) CreateRoom(ctx context.Context, surveyID, hostID string, settings *model.RoomSettings) (*model.Room, error) {
+func (s *RoomService) CreateRoom(ctx context.Context, surveyID, hostID string, settings *model.RoomSettings, hostNotes, experimentID string, branding *model.Branding) (*model.Room, error) {
+	if err := validateRoomSettings(settings, branding); err != nil {
+		return nil, err
+	}
+
 	// Verify survey exists
 	survey, err := s.surveyRepo.GetByID(ctx, surveyID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get survey: %w", err)
 	}
-	if survey == nil {
+	if survey == nil || survey.DeletedAt != nil {
 		return nil, fmt.Errorf("survey not found")
 	}
 
@@ -61,17 +191,46 @@ func (s *RoomService) CreateRoom(ctx context.Context, surveyID, hostID string, s
 	}
 
 	room := &model.Room{
-		Code:     code,
-		SurveyID: surveyID,
-		HostID:   hostID,
-		Status:   model.RoomStatusLobby,
-		Settings: *settings,
+		Code:      code,
+		SurveyID:  surveyID,
+		HostID:    hostID,
+		Status:    model.RoomStatusLobby,
+		Settings:  *settings,
+		HostNotes: hostNotes,
+		Branding:  branding,
+	}
+
+	if experimentID != "" {
+		if settings.Practice {
+			return nil, fmt.Errorf("%w: practice rooms cannot take part in experiments", ErrInvalidRoomSettings)
+		}
+		if s.experimentSvc == nil {
+			return nil, fmt.Errorf("%w: experiments are not enabled", ErrInvalidExperiment)
+		}
+		room.Experiment, err = s.experimentSvc.ForRoom(ctx, hostID, experimentID, code)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Anchor follow-up generation to the survey scope
+	if s.evaluator != nil {
+		anchor, err := s.evaluator.GenerateScopeAnchor(WithAIRoom(ctx, code), survey, hostNotes)
+		if err == nil && anchor != nil {
+			room.ScopeAnchor = anchor
+			room.ScopeSummary = anchor.Summary
+		}
 	}
 
 	// Persist to MongoDB
 	if err := s.roomRepo.Create(ctx, room); err != nil {
 		return nil, fmt.Errorf("failed to create room: %w", err)
 	}
+	if room.Experiment != nil {
+		if err := s.experimentSvc.RecordRoom(ctx, code, room.Experiment); err != nil {
+			return nil, fmt.Errorf("failed to record experiment assignment: %w", err)
+		}
+	}
 
 	// Cache in Redis
 	settingsJSON, _ := json.Marshal(settings)
@@ -81,11 +240,24 @@ func (s *RoomService) CreateRoom(ctx context.Context, surveyID, hostID string, s
 		Status:       model.RoomStatusLobby,
 		CreatedAt:    room.CreatedAt,
 		SettingsJSON: string(settingsJSON),
+		ScopeSummary: room.ScopeSummary,
+		ScopeAnchor:  room.ScopeAnchor,
+		Experiment:   room.Experiment,
+		Branding:     survey.Branding.Merge(branding),
 	}
+	meta.SetSurvey(survey)
 	if err := s.roomCache.SetMeta(ctx, code, meta); err != nil {
 		return nil, fmt.Errorf("failed to cache room: %w", err)
 	}
 
+	if s.auditSvc != nil {
+		payload := map[string]interface{}{"surveyId": surveyID, "settings": settings}
+		if room.Experiment != nil {
+			payload["experimentId"] = room.Experiment.ID
+		}
+		s.auditSvc.Record(ctx, code, hostID, model.AuditRoomCreated, payload)
+	}
+
 	return room, nil
 }
 
@@ -94,6 +266,46 @@ func (s *RoomService) GetRoom(ctx context.Context, code string) (*model.Room, er
 	return s.roomRepo.GetByCode(ctx, code)
 }
 
+// ListHostRooms returns the host's rooms, newest first
+func (s *RoomService) ListHostRooms(ctx context.Context, hostID string) ([]*model.Room, error) {
+	rooms, err := s.roomRepo.GetByHostID(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(rooms, func(i, j int) bool {
+		return rooms[i].CreatedAt.After(rooms[j].CreatedAt)
+	})
+	return rooms, nil
+}
+
+// GetBranding returns the player UI branding for a room, empty when none is set;
+// nil when the room does not exist. Ended rooms fall back to Mongo.
+func (s *RoomService) GetBranding(ctx context.Context, code string) (*model.Branding, error) {
+	meta, err := s.roomCache.GetMeta(ctx, code)
+	if err == nil && meta != nil {
+		if meta.Branding == nil {
+			return &model.Branding{}, nil
+		}
+		return meta.Branding, nil
+	}
+
+	room, err := s.roomRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, nil
+	}
+	var base *model.Branding
+	if survey, err := s.surveyRepo.GetByID(ctx, room.SurveyID); err == nil && survey != nil {
+		base = survey.Branding
+	}
+	if branding := base.Merge(room.Branding); branding != nil {
+		return branding, nil
+	}
+	return &model.Branding{}, nil
+}
+
 // GetRoomMeta retrieves room metadata from Redis
 func (s *RoomService) GetRoomMeta(ctx context.Context, code string) (*model.RoomMeta, error) {
 	return s.roomCache.GetMeta(ctx, code)
@@ -125,16 +337,29 @@ func (s *RoomService) StartRoom(ctx context.Context, code, hostID string) error
 	if err := s.roomCache.SetStatus(ctx, code, model.RoomStatusActive); err != nil {
 		return err
 	}
+	if s.auditSvc != nil {
+		s.auditSvc.Record(ctx, code, hostID, model.AuditRoomStarted, nil)
+	}
+
+	if s.analytics != nil {
+		survey, err := s.surveyRepo.GetByID(ctx, room.SurveyID)
+		if err == nil && survey != nil {
+			s.analytics.TrackRoom(code, surveyQuestionKeys(survey))
+		}
+	}
 
 	// Notify all players that room has started
 	if s.broadcaster != nil {
-		s.broadcaster.BroadcastToAllPlayers(code, "room_started", map[string]string{"status": "ACTIVE"})
+		s.broadcaster.ToPlayers(code, events.RoomStarted, events.RoomStartedPayload{Status: string(model.RoomStatusActive)})
 	}
 
 	return nil
 }
 
-// EndRoom transitions room to ENDED status
+// EndRoom ends a room: it closes the room to writes, freezes the leaderboard,
+// records unanswered questions as ABANDONED and builds the snapshot. If any
+// step fails the room is reopened so the host can retry; the steps before the
+// room update are safe to repeat.
 func (s *RoomService) EndRoom(ctx context.Context, code, hostID string) error {
 	room, err := s.roomRepo.GetByCode(ctx, code)
 	if err != nil {
@@ -146,49 +371,133 @@ func (s *RoomService) EndRoom(ctx context.Context, code, hostID string) error {
 	if room.HostID != hostID {
 		return fmt.Errorf("unauthorized: not room host")
 	}
-
-	now := time.Now()
-	room.Status = model.RoomStatusEnded
-	room.EndedAt = &now
-
-	if err := s.roomRepo.Update(ctx, room); err != nil {
-		return err
+	if room.Status == model.RoomStatusEnded {
+		return fmt.Errorf("room already ended")
 	}
+	prevStatus := room.Status
 
-	// Create snapshot
 	// Get survey to get question keys
 	survey, err := s.surveyRepo.GetByID(ctx, room.SurveyID)
-	// If survey missing, we can still try to create snapshot with empty keys, or just log error?
-	// For robustnes, if survey missing, maybe we skip snapshot or create with 0 keys.
-	// But survey should exist if room exists.
 	var questionKeys []string
 	if err == nil && survey != nil {
-		for _, q := range survey.Questions {
-			questionKeys = append(questionKeys, q.Key)
+		questionKeys = surveyQuestionKeys(survey)
+	}
+
+	// Close the room to joins and submissions first
+	if err := s.roomCache.SetStatus(ctx, code, model.RoomStatusEnded); err != nil {
+		return err
+	}
+	var abandoned []*model.Answer
+	fail := func(err error) error {
+		if len(abandoned) > 0 {
+			if derr := s.answerSvc.DiscardAbandoned(ctx, abandoned); derr != nil {
+				fmt.Printf("[EndRoom] Failed to discard abandoned answers for %s: %v\n", code, derr)
+			}
+		}
+		s.reopenRoom(ctx, code, prevStatus)
+		if s.analytics != nil && prevStatus == model.RoomStatusActive {
+			s.analytics.TrackRoom(code, questionKeys)
+		}
+		return err
+	}
+	if s.leaderboard != nil {
+		if err := s.leaderboard.Freeze(ctx, code); err != nil {
+			return fail(fmt.Errorf("failed to freeze leaderboard: %w", err))
+		}
+	}
+
+	// Before the snapshot, so it counts them; they are deleted again if ending fails
+	if s.answerSvc != nil {
+		if abandoned, err = s.answerSvc.AbandonUnanswered(ctx, code); err != nil {
+			return fail(fmt.Errorf("failed to record abandoned answers: %w", err))
 		}
 	}
 
-	if _, err := s.reportSvc.CreateSnapshot(ctx, code, questionKeys); err != nil {
-		// Log error but don't fail the request? Or fail?
-		// Better to just log. But we don't have logger here easily accessible.
-		// For now return error as it is important.
-		return fmt.Errorf("failed to create snapshot: %w", err)
+	// Final L4 pass so the snapshot carries up-to-date friction and contrasts
+	if s.analytics != nil {
+		s.analytics.UntrackRoom(code)
+		if err := s.analytics.RefreshL4(ctx, code, questionKeys); err != nil {
+			fmt.Printf("[L4Refresh] Final refresh for %s failed: %v\n", code, err)
+		}
 	}
 
-	if err := s.roomCache.SetStatus(ctx, code, model.RoomStatusEnded); err != nil {
-		return err
+	// The snapshot is upserted, so a retry replaces it
+	snapshot, err := s.reportSvc.CreateSnapshot(ctx, code, questionKeys)
+	if err != nil {
+		return fail(fmt.Errorf("failed to create snapshot: %w", err))
+	}
+
+	room.Status = model.RoomStatusEnded
+	room.EndedAt = &snapshot.EndedAt
+	if err := s.roomRepo.Update(ctx, room); err != nil {
+		return fail(fmt.Errorf("failed to update room: %w", err))
+	}
+	if s.auditSvc != nil {
+		s.auditSvc.Record(ctx, code, hostID, model.AuditRoomEnded, map[string]interface{}{
+			"previousStatus": string(prevStatus),
+			"totalPlayers":   snapshot.TotalPlayers,
+			"abandoned":      len(abandoned),
+		})
+	}
+
+	// Keep analytics beyond the Redis TTL
+	if s.archiveSvc != nil {
+		if err := s.archiveSvc.ArchiveRoom(ctx, code); err != nil {
+			fmt.Printf("[Archive] Flush for %s failed: %v\n", code, err)
+		}
 	}
 
-	// Notify and disconnect all clients
+	if s.webhooks != nil && !room.Settings.Practice {
+		s.webhooks.NotifyRoomEnded(room.HostID, snapshot)
+	}
+	if s.difficultySvc != nil && !room.Settings.Practice {
+		go s.difficultySvc.RecalibrateAfterRoom(room.SurveyID)
+	}
+
+	// Notify and disconnect all clients; with feedback enabled players stay
+	// connected until their summary has been sent
 	if s.broadcaster != nil {
-		s.broadcaster.BroadcastToAllPlayers(code, "room_ended", map[string]string{"status": "ENDED"})
-		s.broadcaster.BroadcastToHost(code, "room_ended", map[string]string{"status": "ENDED"})
-		s.broadcaster.DisconnectRoom(code)
+		s.broadcaster.ToRoom(code, events.RoomEnded, roomEndedSummary(snapshot, len(abandoned)))
+		if s.feedbackSvc == nil {
+			s.broadcaster.DisconnectRoom(code)
+		}
+	}
+	if s.feedbackSvc != nil {
+		go s.feedbackSvc.DeliverRoomFeedback(code, room.SurveyID)
 	}
 
 	return nil
 }
 
+// reopenRoom undoes the early close when ending a room fails part-way
+func (s *RoomService) reopenRoom(ctx context.Context, code string, status model.RoomStatus) {
+	if err := s.roomCache.SetStatus(ctx, code, status); err != nil {
+		fmt.Printf("[EndRoom] Failed to restore status for %s: %v\n", code, err)
+	}
+	if s.leaderboard != nil {
+		if err := s.leaderboard.Unfreeze(ctx, code); err != nil {
+			fmt.Printf("[EndRoom] Failed to unfreeze leaderboard for %s: %v\n", code, err)
+		}
+	}
+}
+
+// roomEndedSummary is the room_ended payload: final stats and the podium
+func roomEndedSummary(snapshot *model.RoomSnapshot, abandoned int) events.RoomEndedPayload {
+	top := snapshot.Leaderboard
+	if len(top) > 3 {
+		top = top[:3]
+	}
+	return events.RoomEndedPayload{
+		Status:          string(model.RoomStatusEnded),
+		EndedAt:         snapshot.EndedAt,
+		TotalPlayers:    snapshot.TotalPlayers,
+		CompletionRate:  snapshot.CompletionRate,
+		OverallSkipRate: snapshot.OverallSkipRate,
+		Abandoned:       abandoned,
+		TopPlayers:      top,
+	}
+}
+
 // generateRoomCode creates a 6-char alphanumeric code
 func (s *RoomService) generateRoomCode(ctx context.Context) (string, error) {
 	const chars = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
@@ -218,3 +527,12 @@ func (s *RoomService) generateRoomCode(ctx context.Context) (string, error) {
 
 	return "", fmt.Errorf("failed to generate unique room code")
 }
+
+// surveyQuestionKeys returns the base question keys of a survey
+func surveyQuestionKeys(survey *model.Survey) []string {
+	keys := make([]string, 0, len(survey.Questions))
+	for _, q := range survey.Questions {
+		keys = append(keys, q.Key)
+	}
+	return keys
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	defaultShareTTL = 7 * 24 * time.Hour
+	maxShareTTL     = 30 * 24 * time.Hour
+)
+
+var (
+	// ErrShareNotReady is returned when sharing a room that has no snapshot yet
+	ErrShareNotReady = errors.New("room results are not available yet")
+	// ErrInvalidShareLink is returned for expired, forged or dangling share tokens
+	ErrInvalidShareLink = errors.New("share link is invalid or expired")
+)
+
+// SetShareSigner enables public share links, signed by authSvc
+func (s *ReportService) SetShareSigner(authSvc *AuthService) {
+	s.authSvc = authSvc
+}
+
+// SetPublicURL sets the web app base URL used in share links
+func (s *ReportService) SetPublicURL(url string) {
+	s.publicURL = strings.TrimRight(url, "/")
+}
+
+// CreateShareLink creates a signed, expiring read-only link to a room's results
+func (s *ReportService) CreateShareLink(ctx context.Context, roomCode, hostID string, req model.ShareRequest) (*model.ShareLink, error) {
+	if s.authSvc == nil {
+		return nil, fmt.Errorf("share links are not configured")
+	}
+
+	room, err := s.roomRepo.GetByCode(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, nil
+	}
+	if room.HostID != hostID {
+		return nil, ErrNotRoomHost
+	}
+
+	snapshot, err := s.reportRepo.GetSnapshot(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, ErrShareNotReady
+	}
+
+	ttl := defaultShareTTL
+	if req.ExpiresInHours > 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+	if ttl > maxShareTTL {
+		ttl = maxShareTTL
+	}
+
+	token, expiresAt, err := s.authSvc.GenerateShareToken(roomCode, hostID, ttl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign share link: %w", err)
+	}
+	if s.auditSvc != nil {
+		s.auditSvc.Record(ctx, roomCode, hostID, model.AuditReportShared, map[string]interface{}{"expiresAt": expiresAt})
+	}
+
+	return &model.ShareLink{
+		Token:     token,
+		URL:       s.publicURL + "/shared/" + token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// GetSharedReport resolves a share token into the sanitized results of its room
+func (s *ReportService) GetSharedReport(ctx context.Context, token string) (*model.SharedReport, error) {
+	if s.authSvc == nil {
+		return nil, ErrInvalidShareLink
+	}
+
+	claims, err := s.authSvc.ValidateShareToken(token)
+	if err != nil {
+		return nil, ErrInvalidShareLink
+	}
+
+	// Links die with their room, e.g. after the host deletes it
+	room, err := s.roomRepo.GetByCode(ctx, claims.RoomCode)
+	if err != nil || room == nil || room.HostID != claims.HostID {
+		return nil, ErrInvalidShareLink
+	}
+
+	snapshot, err := s.reportRepo.GetSnapshot(ctx, claims.RoomCode)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, ErrInvalidShareLink
+	}
+
+	shared := &model.SharedReport{
+		RoomCode:         snapshot.RoomCode,
+		EndedAt:          snapshot.EndedAt,
+		TotalPlayers:     snapshot.TotalPlayers,
+		CompletionRate:   snapshot.CompletionRate,
+		OverallSkipRate:  snapshot.OverallSkipRate,
+		TopScores:        make([]int, 0, len(snapshot.Leaderboard)),
+		QuestionProfiles: snapshot.QuestionProfiles,
+		Memory:           snapshot.Memory,
+		ExpiresAt:        claims.ExpiresAt.Time,
+	}
+	for _, entry := range snapshot.Leaderboard {
+		shared.TopScores = append(shared.TopScores, entry.Score)
+	}
+
+	if snapshot.SurveyID != "" {
+		if survey, err := s.surveyRepo.GetByID(ctx, snapshot.SurveyID); err == nil && survey != nil {
+			shared.SurveyTitle = survey.Title
+		}
+	}
+
+	if report, err := s.reportRepo.GetAIReport(ctx, claims.RoomCode); err == nil && report != nil && report.Status == "ready" {
+		shared.AIReport = report
+	}
+
+	return shared, nil
+}
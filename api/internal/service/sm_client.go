@@ -1,6 +1,7 @@
 package service
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,12 +13,18 @@ import (
 	"time"
 )
 
+// HTTPDoer is the subset of *http.Client used by the external API clients
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
 // SMClient wraps SurveyMonkey API calls
 type SMClient struct {
 	baseURL    string
 	token      string
-	httpClient *http.Client
+	httpClient HTTPDoer
 	maxRetries int
+	backoff    time.Duration // Base delay for 429 retries (doubles each attempt)
 }
 
 // NewSMClient creates a new SurveyMonkey API client
@@ -29,23 +36,55 @@ func NewSMClient() *SMClient {
 		log.Printf("SM_ACCESS_TOKEN loaded: %s", token)
 	}
 
+	baseURL := os.Getenv("SM_BASE_URL")
+	if baseURL == "" {
+		baseURL = "https://api.surveymonkey.com/v3"
+	}
+
 	return &SMClient{
-		baseURL: "https://api.surveymonkey.com/v3",
+		baseURL: baseURL,
 		token:   token,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 		maxRetries: 5,
+		backoff:    time.Second,
 	}
 }
 
-// SMCollectorResponse is the API response for collector creation
+// SetHTTPClient replaces the HTTP client used for SurveyMonkey calls
+func (c *SMClient) SetHTTPClient(h HTTPDoer) {
+	c.httpClient = h
+}
+
+// SetRetryPolicy overrides the retry count and base 429 backoff
+func (c *SMClient) SetRetryPolicy(maxRetries int, backoff time.Duration) {
+	c.maxRetries = maxRetries
+	c.backoff = backoff
+}
+
+// SetBaseURL points the client at a different API root (e.g. a mock server)
+func (c *SMClient) SetBaseURL(baseURL string) {
+	c.baseURL = strings.TrimRight(baseURL, "/")
+}
+
+// SMCollectorResponse is the API response for a collector
 type SMCollectorResponse struct {
-	ID     string `json:"id"`
-	Name   string `json:"name"`
-	Type   string `json:"type"`
-	URL    string `json:"url"`
-	Status string `json:"status"`
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	URL           string `json:"url"`
+	Status        string `json:"status"`
+	ResponseCount int    `json:"response_count"`
+	DateCreated   string `json:"date_created,omitempty"`
+}
+
+// SMCollectorList is the API response for listing a survey's collectors
+type SMCollectorList struct {
+	Data    []SMCollectorResponse `json:"data"`
+	Page    int                   `json:"page"`
+	PerPage int                   `json:"per_page"`
+	Total   int                   `json:"total"`
 }
 
 // SMBulkResponseList is the API response for listing responses
@@ -105,13 +144,26 @@ func (c *SMClient) doRequest(method, path string, body io.Reader) ([]byte, error
 	url := c.baseURL + path
 	log.Printf("[SM Client] %s %s", method, path)
 
+	// Buffer the body so retries resend it instead of an exhausted reader
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
 	var lastErr error
 	for attempt := 0; attempt < c.maxRetries; attempt++ {
 		if attempt > 0 {
 			log.Printf("[SM Client] Retry attempt %d/%d for %s %s", attempt, c.maxRetries, method, path)
 		}
 
-		req, err := http.NewRequest(method, url, body)
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequest(method, url, reqBody)
 		if err != nil {
 			log.Printf("[SM Client] ERROR: Failed to create request: %v", err)
 			return nil, fmt.Errorf("failed to create request: %w", err)
@@ -139,7 +191,7 @@ func (c *SMClient) doRequest(method, path string, body io.Reader) ([]byte, error
 
 		// Handle rate limiting (429)
 		if resp.StatusCode == 429 {
-			backoff := time.Duration(math.Pow(2, float64(attempt))) * time.Second
+			backoff := time.Duration(math.Pow(2, float64(attempt))) * c.backoff
 			log.Printf("[SM Client] RATE LIMITED: Retry %d/%d in %v", attempt+1, c.maxRetries, backoff)
 			time.Sleep(backoff)
 			lastErr = fmt.Errorf("rate limited")
@@ -178,6 +230,51 @@ func (c *SMClient) CreateCollector(surveyID, name string) (*SMCollectorResponse,
 	return &collector, nil
 }
 
+// ListCollectors lists every collector of a survey with its status and response count
+func (c *SMClient) ListCollectors(surveyID string) ([]SMCollectorResponse, error) {
+	const perPage = 100
+	var collectors []SMCollectorResponse
+	for page := 1; ; page++ {
+		path := fmt.Sprintf("/surveys/%s/collectors?include=type,status,response_count,date_created,url&per_page=%d&page=%d", surveyID, perPage, page)
+		respBody, err := c.doRequest("GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list SMCollectorList
+		if err := json.Unmarshal(respBody, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse collector list: %w", err)
+		}
+		collectors = append(collectors, list.Data...)
+		if len(list.Data) == 0 || len(collectors) >= list.Total {
+			return collectors, nil
+		}
+	}
+}
+
+// UpdateCollectorStatus opens or closes a collector
+func (c *SMClient) UpdateCollectorStatus(collectorID, status string) (*SMCollectorResponse, error) {
+	payload, _ := json.Marshal(map[string]string{"status": status})
+	path := fmt.Sprintf("/collectors/%s", collectorID)
+
+	respBody, err := c.doRequest("PATCH", path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	var collector SMCollectorResponse
+	if err := json.Unmarshal(respBody, &collector); err != nil {
+		return nil, fmt.Errorf("failed to parse collector response: %w", err)
+	}
+	return &collector, nil
+}
+
+// DeleteCollector deletes a collector and the responses it gathered
+func (c *SMClient) DeleteCollector(collectorID string) error {
+	_, err := c.doRequest("DELETE", fmt.Sprintf("/collectors/%s", collectorID), nil)
+	return err
+}
+
 // ListResponses lists all responses for a survey (bulk, no answer data)
 func (c *SMClient) ListResponses(surveyID string, modifiedSince *time.Time) (*SMBulkResponseList, error) {
 	path := fmt.Sprintf("/surveys/%s/responses/bulk", surveyID)
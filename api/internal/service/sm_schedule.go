@@ -0,0 +1,176 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Automatic SM sync limits
+const (
+	MinSMSyncIntervalMinutes = 5
+	MaxSMSyncIntervalMinutes = 7 * 24 * 60
+
+	smSyncLease      = 30 * time.Minute // A crashed sync's lock expires after this
+	smSyncMaxBackoff = 6 * time.Hour    // Longest wait after repeated failures
+	smSyncBatch      = 20               // Due surveys synced per scheduler tick
+)
+
+// ErrSMSyncInProgress is returned when another sync of the survey is still running
+var ErrSMSyncInProgress = errors.New("a sync of this survey is already running")
+
+// SetSchedule stores a survey's automatic sync cadence; the first run is due now
+// unless the survey synced successfully within the interval
+func (s *SMSyncService) SetSchedule(ctx context.Context, surveyID string, req *model.SMScheduleRequest) (*model.SMSyncSchedule, error) {
+	if req.IntervalMinutes < MinSMSyncIntervalMinutes || req.IntervalMinutes > MaxSMSyncIntervalMinutes {
+		return nil, fmt.Errorf("intervalMinutes must be between %d and %d", MinSMSyncIntervalMinutes, MaxSMSyncIntervalMinutes)
+	}
+	existing, err := s.repo.GetSchedule(ctx, surveyID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	interval := time.Duration(req.IntervalMinutes) * time.Minute
+	next := now
+	if existing != nil && existing.LastSuccessAt != nil && existing.LastSuccessAt.Add(interval).After(now) {
+		next = existing.LastSuccessAt.Add(interval)
+	}
+	schedule := &model.SMSyncSchedule{
+		SurveyID:        surveyID,
+		IntervalMinutes: req.IntervalMinutes,
+		Enabled:         req.Enabled == nil || *req.Enabled,
+		NextRunAt:       &next,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := s.repo.UpsertSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSchedule(ctx, surveyID)
+}
+
+// DeleteSchedule stops automatic syncs of a survey
+func (s *SMSyncService) DeleteSchedule(ctx context.Context, surveyID string) error {
+	return s.repo.DeleteSchedule(ctx, surveyID)
+}
+
+// GetSyncStatus reports a survey's schedule and the outcome of its last sync
+func (s *SMSyncService) GetSyncStatus(ctx context.Context, surveyID string) (*model.SMSyncStatus, error) {
+	schedule, err := s.repo.GetSchedule(ctx, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	status := &model.SMSyncStatus{SurveyID: surveyID, Schedule: schedule, Stale: true}
+	if schedule == nil {
+		return status, nil
+	}
+
+	now := time.Now()
+	status.Running = schedule.LockedUntil != nil && schedule.LockedUntil.After(now)
+	if schedule.LastSuccessAt != nil {
+		status.Stale = schedule.IntervalMinutes > 0 &&
+			now.Sub(*schedule.LastSuccessAt) > 2*time.Duration(schedule.IntervalMinutes)*time.Minute
+	}
+	return status, nil
+}
+
+// SyncTracked runs Sync under the survey's lock and records the outcome for the
+// status endpoint. Returns ErrSMSyncInProgress if a sync is already running.
+func (s *SMSyncService) SyncTracked(ctx context.Context, surveyID string) (*model.SMSyncResult, error) {
+	now := time.Now()
+	claimed, err := s.repo.ClaimSync(ctx, surveyID, now, now.Add(smSyncLease))
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrSMSyncInProgress
+	}
+	schedule, err := s.repo.GetSchedule(ctx, surveyID)
+	if err != nil || schedule == nil {
+		schedule = &model.SMSyncSchedule{SurveyID: surveyID}
+	}
+
+	result, syncErr := s.Sync(ctx, surveyID)
+
+	finished := time.Now()
+	schedule.LastRunAt = &now
+	schedule.LastDurationMs = finished.Sub(now).Milliseconds()
+	schedule.UpdatedAt = finished
+	schedule.NextRunAt = nil
+	if syncErr != nil {
+		schedule.LastStatus = model.SMSyncStatusFailed
+		schedule.LastError = syncErr.Error()
+		schedule.ConsecutiveFailures++
+	} else {
+		schedule.LastStatus = model.SMSyncStatusOK
+		schedule.LastError = ""
+		schedule.LastSuccessAt = &finished
+		schedule.LastResult = result
+		schedule.ConsecutiveFailures = 0
+	}
+	if schedule.Enabled && schedule.IntervalMinutes > 0 {
+		next := finished.Add(smSyncDelay(schedule.IntervalMinutes, schedule.ConsecutiveFailures))
+		schedule.NextRunAt = &next
+	}
+
+	// The outcome is stored even if the caller's request was cancelled
+	saveCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.repo.CompleteSync(saveCtx, schedule); err != nil {
+		log.Printf("Warning: failed to record SM sync status for %s: %v", surveyID, err)
+	}
+	return result, syncErr
+}
+
+// smSyncDelay is the wait before the next scheduled sync: the interval, doubled
+// for each consecutive failure; failing surveys retry at least every smSyncMaxBackoff
+func smSyncDelay(intervalMinutes, failures int) time.Duration {
+	delay := time.Duration(intervalMinutes) * time.Minute
+	for i := 0; i < failures; i++ {
+		delay *= 2
+		if delay >= smSyncMaxBackoff {
+			return smSyncMaxBackoff
+		}
+	}
+	return delay
+}
+
+// StartScheduler syncs due surveys every tick until ctx is cancelled. Surveys
+// are synced one at a time to stay within SurveyMonkey's rate limits.
+func (s *SMSyncService) StartScheduler(ctx context.Context, tick time.Duration) {
+	if !s.client.IsConfigured() {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.runDueSyncs(ctx)
+			}
+		}
+	}()
+}
+
+func (s *SMSyncService) runDueSyncs(ctx context.Context) {
+	due, err := s.repo.ListDueSchedules(ctx, time.Now(), smSyncBatch)
+	if err != nil {
+		log.Printf("Warning: failed to list due SM syncs: %v", err)
+		return
+	}
+	for _, schedule := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		if _, err := s.SyncTracked(ctx, schedule.SurveyID); err != nil && !errors.Is(err, ErrSMSyncInProgress) {
+			log.Printf("Scheduled SM sync of %s failed (%d in a row): %v", schedule.SurveyID, schedule.ConsecutiveFailures+1, err)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
 	"time"
 )
@@ -57,6 +58,7 @@ func (s *SMSyncService) CreateCollector(ctx context.Context, surveyID, name stri
 		Type:        resp.Type,
 		WebLinkURL:  resp.URL,
 		CreatedAt:   time.Now(),
+		Status:      resp.Status,
 	}
 
 	if err := s.repo.UpsertCollector(ctx, collector); err != nil {
@@ -66,6 +68,106 @@ func (s *SMSyncService) CreateCollector(ctx context.Context, surveyID, name stri
 	return collector, nil
 }
 
+// ListCollectors returns a survey's collectors as SurveyMonkey reports them, with
+// response counts. Local records are refreshed, and removed for collectors that
+// no longer exist in SurveyMonkey.
+func (s *SMSyncService) ListCollectors(ctx context.Context, surveyID string) ([]*model.SMCollector, error) {
+	if !s.client.IsConfigured() {
+		return nil, fmt.Errorf("SM_ACCESS_TOKEN not configured")
+	}
+
+	remote, err := s.client.ListCollectors(surveyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collectors: %w", err)
+	}
+	local, err := s.repo.GetCollectorsBySurvey(ctx, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]*model.SMCollector, len(local))
+	for _, c := range local {
+		known[c.CollectorID] = c
+	}
+
+	collectors := make([]*model.SMCollector, 0, len(remote))
+	for i := range remote {
+		collector := s.syncCollector(ctx, surveyID, known[remote[i].ID], &remote[i])
+		delete(known, remote[i].ID)
+		collectors = append(collectors, collector)
+	}
+	for id := range known {
+		if err := s.repo.DeleteCollector(ctx, id); err != nil {
+			log.Printf("Warning: failed to remove stale collector %s: %v", id, err)
+		}
+	}
+	return collectors, nil
+}
+
+// SetCollectorStatus closes (stops accepting responses) or reopens a collector
+func (s *SMSyncService) SetCollectorStatus(ctx context.Context, collectorID, status string) (*model.SMCollector, error) {
+	if status != model.SMCollectorOpen && status != model.SMCollectorClosed {
+		return nil, fmt.Errorf("status must be open or closed")
+	}
+	if !s.client.IsConfigured() {
+		return nil, fmt.Errorf("SM_ACCESS_TOKEN not configured")
+	}
+
+	resp, err := s.client.UpdateCollectorStatus(collectorID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update collector: %w", err)
+	}
+	existing, err := s.repo.GetCollectorByID(ctx, collectorID)
+	if err != nil {
+		return nil, err
+	}
+	surveyID := ""
+	if existing != nil {
+		surveyID = existing.SurveyID
+	}
+	return s.syncCollector(ctx, surveyID, existing, resp), nil
+}
+
+// DeleteCollector deletes a collector in SurveyMonkey, then its local record
+func (s *SMSyncService) DeleteCollector(ctx context.Context, collectorID string) error {
+	if !s.client.IsConfigured() {
+		return fmt.Errorf("SM_ACCESS_TOKEN not configured")
+	}
+	if err := s.client.DeleteCollector(collectorID); err != nil {
+		return fmt.Errorf("failed to delete collector: %w", err)
+	}
+	return s.repo.DeleteCollector(ctx, collectorID)
+}
+
+// syncCollector merges a SurveyMonkey collector into its local record and stores it
+func (s *SMSyncService) syncCollector(ctx context.Context, surveyID string, existing *model.SMCollector, resp *SMCollectorResponse) *model.SMCollector {
+	now := time.Now()
+	collector := &model.SMCollector{
+		SurveyID:    surveyID,
+		CollectorID: resp.ID,
+		CreatedAt:   now,
+	}
+	if existing != nil {
+		collector = existing
+	} else if created, err := time.Parse(time.RFC3339, resp.DateCreated); err == nil {
+		collector.CreatedAt = created
+	}
+	collector.Name = resp.Name
+	if resp.Type != "" {
+		collector.Type = resp.Type
+	}
+	if resp.URL != "" {
+		collector.WebLinkURL = resp.URL
+	}
+	collector.Status = resp.Status
+	collector.ResponseCount = resp.ResponseCount
+	collector.SyncedAt = &now
+
+	if err := s.repo.UpsertCollector(ctx, collector); err != nil {
+		log.Printf("Warning: failed to store collector %s: %v", resp.ID, err)
+	}
+	return collector
+}
+
 // Sync fetches and processes responses for a survey
 func (s *SMSyncService) Sync(ctx context.Context, surveyID string) (*model.SMSyncResult, error) {
 	if !s.client.IsConfigured() {
@@ -160,6 +262,71 @@ func (s *SMSyncService) Sync(ctx context.Context, surveyID string) (*model.SMSyn
 	return result, nil
 }
 
+// DryRunSync reports which responses a sync would add or overwrite and which
+// question IDs have no mapping, reading from SurveyMonkey without writing any layer
+func (s *SMSyncService) DryRunSync(ctx context.Context, surveyID string) (*model.SMSyncDryRun, error) {
+	if !s.client.IsConfigured() {
+		return nil, fmt.Errorf("SM_ACCESS_TOKEN not configured")
+	}
+
+	list, err := s.client.ListResponses(surveyID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list responses: %w", err)
+	}
+
+	report := &model.SMSyncDryRun{
+		SurveyID:            surveyID,
+		Fetched:             len(list.Data),
+		New:                 []string{},
+		Modified:            []model.SMResponseChange{},
+		UnmappedQuestionIDs: []string{},
+	}
+	unmapped := make(map[string]bool)
+	for _, bulk := range list.Data {
+		existing, err := s.repo.GetRawResponse(ctx, bulk.ID)
+		if err != nil {
+			return nil, err
+		}
+		bulkModified, _ := time.Parse(time.RFC3339, bulk.DateModified)
+		if existing != nil && !bulkModified.After(existing.DateModified) {
+			report.Unchanged++
+			continue
+		}
+
+		// Details are read for changed responses only, as a real sync would
+		details, _, err := s.client.GetResponseDetails(surveyID, bulk.ID)
+		if err != nil {
+			log.Printf("Warning: dry run could not fetch response %s: %v", bulk.ID, err)
+			report.Failed = append(report.Failed, bulk.ID)
+			continue
+		}
+		if existing == nil {
+			report.New = append(report.New, bulk.ID)
+		} else {
+			report.Modified = append(report.Modified, model.SMResponseChange{
+				ResponseID:     bulk.ID,
+				StoredModified: existing.DateModified,
+				RemoteModified: bulkModified,
+				StoredStatus:   existing.Status,
+				RemoteStatus:   details.ResponseStatus,
+			})
+		}
+
+		answers, _ := s.parseAnswers(details, nil)
+		report.ParsedAnswers += len(answers)
+		for _, page := range details.Pages {
+			for _, q := range page.Questions {
+				if _, ok := s.questionMappings[q.ID]; !ok && !unmapped[q.ID] {
+					unmapped[q.ID] = true
+					report.UnmappedQuestionIDs = append(report.UnmappedQuestionIDs, q.ID)
+				}
+			}
+		}
+	}
+	sort.Strings(report.UnmappedQuestionIDs)
+	return report, nil
+}
+
 // parseAnswers converts response details to normalized answer cells
 func (s *SMSyncService) parseAnswers(details *SMResponseDetails, submittedAt *time.Time) ([]*model.SMAnswer, error) {
 	var answers []*model.SMAnswer
@@ -406,6 +573,11 @@ func (s *SMSyncService) convertQuestion(q model.BaseQuestion, position int) SMQu
 		req.Family = "open_ended"
 		req.Subtype = "essay"
 
+	case model.QuestionTypeWords:
+		log.Printf("[SM Sync] Converting WORDS → open_ended single line")
+		req.Family = "open_ended"
+		req.Subtype = "single"
+
 	case model.QuestionTypeDegree:
 		log.Printf("[SM Sync] Converting DEGREE → rating scale (%d-%d)", q.ScaleMin, q.ScaleMax)
 		req.Family = "single_choice"
@@ -439,6 +611,40 @@ func (s *SMSyncService) convertQuestion(q model.BaseQuestion, position int) SMQu
 			"choices": choices,
 		}
 		log.Printf("[SM Sync] Created %d choice options", len(choices))
+
+	case model.QuestionTypeMatrix:
+		log.Printf("[SM Sync] Converting MATRIX → matrix rating (%d rows x %d columns)", len(q.Rows), len(q.Columns))
+		req.Family = "matrix"
+		req.Subtype = "rating"
+
+		rows := make([]map[string]interface{}, 0, len(q.Rows))
+		for _, row := range q.Rows {
+			rows = append(rows, map[string]interface{}{"text": row})
+		}
+		choices := make([]map[string]interface{}, 0, len(q.Columns))
+		for _, col := range q.Columns {
+			choices = append(choices, map[string]interface{}{"text": col})
+		}
+		req.Answers = map[string]interface{}{
+			"rows":    rows,
+			"choices": choices,
+		}
+
+	case model.QuestionTypeRanking:
+		log.Printf("[SM Sync] Converting RANKING → matrix ranking (%d options)", len(q.Options))
+		req.Family = "matrix"
+		req.Subtype = "ranking"
+
+		rows := make([]map[string]interface{}, 0, len(q.Options))
+		choices := make([]map[string]interface{}, 0, len(q.Options))
+		for i, opt := range q.Options {
+			rows = append(rows, map[string]interface{}{"text": opt})
+			choices = append(choices, map[string]interface{}{"text": fmt.Sprintf("%d", i+1)})
+		}
+		req.Answers = map[string]interface{}{
+			"rows":    rows,
+			"choices": choices,
+		}
 	}
 
 	return req
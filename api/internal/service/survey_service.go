@@ -4,25 +4,47 @@ import (
 	"2026champs/internal/model"
 	"2026champs/internal/repository"
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
+// ErrInvalidSurvey is wrapped by validation failures on create/update
+var ErrInvalidSurvey = errors.New("invalid survey")
+
+// ErrSurveyInUse is returned when deleting a survey that rooms are still running
+var ErrSurveyInUse = errors.New("survey has rooms in the lobby or in progress")
+
 // SurveyService handles survey CRUD operations
 type SurveyService struct {
 	surveyRepo repository.SurveyRepo
+	roomRepo   repository.RoomRepo
+	evaluator  *EvaluatorService
 }
 
 // NewSurveyService creates a new survey service
-func NewSurveyService(surveyRepo repository.SurveyRepo) *SurveyService {
+func NewSurveyService(surveyRepo repository.SurveyRepo, roomRepo repository.RoomRepo) *SurveyService {
 	return &SurveyService{
 		surveyRepo: surveyRepo,
+		roomRepo:   roomRepo,
 	}
 }
 
 // Create creates a new survey
 func (s *SurveyService) Create(ctx context.Context, survey *model.Survey) (string, error) {
+	if err := validateSurvey(survey); err != nil {
+		return "", err
+	}
 	return s.surveyRepo.Create(ctx, survey)
 }
 
+// SetEvaluator sets the evaluator used by the rubric sandbox
+func (s *SurveyService) SetEvaluator(e *EvaluatorService) {
+	s.evaluator = e
+}
+
 // GetByID retrieves a survey by ID
 func (s *SurveyService) GetByID(ctx context.Context, id string) (*model.Survey, error) {
 	return s.surveyRepo.GetByID(ctx, id)
@@ -35,10 +57,483 @@ func (s *SurveyService) GetByHostID(ctx context.Context, hostID string) ([]*mode
 
 // Update updates an existing survey
 func (s *SurveyService) Update(ctx context.Context, survey *model.Survey) error {
+	if err := validateSurvey(survey); err != nil {
+		return err
+	}
 	return s.surveyRepo.Update(ctx, survey)
 }
 
-// Delete deletes a survey
-func (s *SurveyService) Delete(ctx context.Context, id string) error {
-	return s.surveyRepo.Delete(ctx, id)
+// Delete moves a host's survey to the trash. Rooms in the lobby or in progress
+// still read it, so it cannot be deleted until they end. Returns nil if the
+// survey is not the host's or is already in the trash.
+func (s *SurveyService) Delete(ctx context.Context, id, hostID string) (*model.Survey, error) {
+	survey, err := s.hostSurvey(ctx, id, hostID)
+	if err != nil || survey == nil || survey.DeletedAt != nil {
+		return nil, err
+	}
+
+	rooms, err := s.roomRepo.GetBySurveyID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	live := 0
+	for _, room := range rooms {
+		if room.Status != model.RoomStatusEnded {
+			live++
+		}
+	}
+	if live > 0 {
+		return nil, fmt.Errorf("%w: %d room(s) must end first", ErrSurveyInUse, live)
+	}
+
+	now := time.Now()
+	deleted, err := s.surveyRepo.SoftDelete(ctx, id, now)
+	if err != nil || !deleted {
+		return nil, err
+	}
+	survey.DeletedAt = &now
+	return survey, nil
+}
+
+// Restore takes a host's survey out of the trash; nil if it is not there
+func (s *SurveyService) Restore(ctx context.Context, id, hostID string) (*model.Survey, error) {
+	survey, err := s.hostSurvey(ctx, id, hostID)
+	if err != nil || survey == nil || survey.DeletedAt == nil {
+		return nil, err
+	}
+
+	restored, err := s.surveyRepo.Restore(ctx, id)
+	if err != nil || !restored {
+		return nil, err
+	}
+	survey.DeletedAt = nil
+	return survey, nil
+}
+
+// SetThemeTaxonomy replaces the theme taxonomy of a host's survey; an empty one
+// removes it. Rooms created afterwards use it. Returns nil if the survey is not the host's.
+func (s *SurveyService) SetThemeTaxonomy(ctx context.Context, id, hostID string, taxonomy *model.ThemeTaxonomy) (*model.Survey, error) {
+	if err := validateThemeTaxonomy(taxonomy); err != nil {
+		return nil, err
+	}
+	survey, err := s.hostSurvey(ctx, id, hostID)
+	if err != nil || survey == nil {
+		return nil, err
+	}
+	if taxonomy.IsEmpty() {
+		taxonomy = nil
+	}
+	if err := s.surveyRepo.SetThemeTaxonomy(ctx, id, taxonomy); err != nil {
+		return nil, err
+	}
+	survey.ThemeTaxonomy = taxonomy
+	return survey, nil
+}
+
+// ListTrash lists a host's deleted surveys
+func (s *SurveyService) ListTrash(ctx context.Context, hostID string) ([]*model.Survey, error) {
+	return s.surveyRepo.GetDeletedByHostID(ctx, hostID)
+}
+
+// hostSurvey loads a survey owned by hostID; nil if missing or owned by someone else
+func (s *SurveyService) hostSurvey(ctx context.Context, id, hostID string) (*model.Survey, error) {
+	survey, err := s.surveyRepo.GetByID(ctx, id)
+	if err != nil || survey == nil {
+		return nil, err
+	}
+	if survey.HostID != hostID {
+		return nil, nil
+	}
+	return survey, nil
+}
+
+// maxTestEvalAnswers caps sample answers per sandbox run
+const maxTestEvalAnswers = 10
+
+// TestEvaluate runs sample answers through L1 evaluation for one survey question
+// without a room, so hosts can tune rubrics and thresholds before a session.
+func (s *SurveyService) TestEvaluate(ctx context.Context, surveyID, hostID, questionKey string, req *model.TestEvalRequest) (*model.TestEvalResponse, error) {
+	if s.evaluator == nil {
+		return nil, fmt.Errorf("evaluator not configured")
+	}
+	if len(req.Answers) == 0 {
+		return nil, fmt.Errorf("%w: at least one sample answer is required", ErrInvalidSurvey)
+	}
+	if len(req.Answers) > maxTestEvalAnswers {
+		return nil, fmt.Errorf("%w: at most %d sample answers per run", ErrInvalidSurvey, maxTestEvalAnswers)
+	}
+
+	survey, err := s.surveyRepo.GetByID(ctx, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	if survey == nil || survey.HostID != hostID {
+		return nil, nil
+	}
+
+	var base *model.BaseQuestion
+	for i := range survey.Questions {
+		if survey.Questions[i].Key == questionKey {
+			base = &survey.Questions[i]
+			break
+		}
+	}
+	if base == nil {
+		return nil, nil
+	}
+	if base.Type != model.QuestionTypeEssay {
+		return nil, fmt.Errorf("%w: only ESSAY questions are AI-evaluated", ErrInvalidSurvey)
+	}
+
+	question := &model.Question{
+		Key:       base.Key,
+		Type:      base.Type,
+		Prompt:    base.Prompt,
+		Rubric:    base.Rubric,
+		PointsMax: base.PointsMax,
+		Threshold: base.Threshold,
+		Media:     base.Media,
+		AI:        base.AI,
+		Taxonomy:  survey.ThemeTaxonomy,
+	}
+	if req.Rubric != nil {
+		question.Rubric = *req.Rubric
+	}
+	if req.Threshold != nil {
+		question.Threshold = *req.Threshold
+	}
+	if req.AI != nil {
+		question.AI = req.AI
+	}
+	if err := validateQuestionAI([]model.BaseQuestion{{Key: question.Key, AI: question.AI}}); err != nil {
+		return nil, err
+	}
+
+	answers := make([]*model.Answer, len(req.Answers))
+	for i, text := range req.Answers {
+		answers[i] = &model.Answer{QuestionKey: question.Key, TextAnswer: text}
+	}
+	evals, err := s.evaluator.EvaluateAnswerBatch(ctx, question, answers)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &model.TestEvalResponse{
+		QuestionKey: question.Key,
+		Rubric:      question.Rubric,
+		Threshold:   question.Threshold,
+		Mock:        !s.evaluator.config.IsEnabled(),
+		Results:     make([]model.TestEvalResult, len(evals)),
+	}
+	for i, e := range evals {
+		// Same scoring as a live submission
+		points := 0
+		if model.AnswerResolution(e.Resolution) == model.ResolutionSat {
+			points = int(e.QualityScore * float64(question.PointsMax))
+		}
+		resp.Results[i] = model.TestEvalResult{
+			Answer:       req.Answers[i],
+			Resolution:   e.Resolution,
+			QualityScore: e.QualityScore,
+			PointsEarned: points,
+			Themes:       e.Signals.Themes,
+			Missing:      e.Signals.Missing,
+			Summary:      e.Signals.Summary,
+			FollowUpHint: e.FollowUpHint,
+			RiskFlags:    e.Signals.RiskFlags,
+		}
+	}
+	return resp, nil
+}
+
+// validateSurvey runs every structural check done on create/update
+func validateSurvey(survey *model.Survey) error {
+	if err := validateQuestionAI(survey.Questions); err != nil {
+		return err
+	}
+	if err := validateQuestionShapes(survey.Questions); err != nil {
+		return err
+	}
+	if err := normalizeQuestionMedia(survey.Questions); err != nil {
+		return err
+	}
+	if err := validateQuotas(survey.Questions); err != nil {
+		return err
+	}
+	if err := validateBranching(survey); err != nil {
+		return err
+	}
+	if err := validateBranding(survey.Branding, ErrInvalidSurvey); err != nil {
+		return err
+	}
+	if err := validateGuardrails(survey.Guardrails); err != nil {
+		return err
+	}
+	if err := validateSkipSettings(survey.Settings); err != nil {
+		return err
+	}
+	return validatePiping(survey.Questions)
+}
+
+// validateSkipSettings checks the tries and time skip gating can require
+func validateSkipSettings(settings model.SurveySettings) error {
+	if settings.AllowSkipAfter < 0 || settings.AllowSkipAfter > model.MaxMaxTries {
+		return fmt.Errorf("%w: allowSkipAfter must be between 0 and %d", ErrInvalidSurvey, model.MaxMaxTries)
+	}
+	if settings.SkipAfterSeconds < 0 || settings.SkipAfterSeconds > model.MaxSkipAfterSeconds {
+		return fmt.Errorf("%w: skipAfterSeconds must be between 0 and %d", ErrInvalidSurvey, model.MaxSkipAfterSeconds)
+	}
+	return nil
+}
+
+// validateGuardrails checks the size of a survey's guardrails
+func validateGuardrails(g *model.Guardrails) error {
+	if g == nil {
+		return nil
+	}
+	lists := []struct {
+		name  string
+		items []string
+	}{{"bannedTopics", g.BannedTopics}, {"forbiddenPhrases", g.ForbiddenPhrases}}
+	for _, l := range lists {
+		name, list := l.name, l.items
+		if len(list) > model.MaxGuardrailItems {
+			return fmt.Errorf("%w: guardrails: at most %d %s", ErrInvalidSurvey, model.MaxGuardrailItems, name)
+		}
+		for _, item := range list {
+			if strings.TrimSpace(item) == "" || utf8.RuneCountInString(item) > model.MaxGuardrailItemChars {
+				return fmt.Errorf("%w: guardrails: %s entries must be 1-%d characters", ErrInvalidSurvey, name, model.MaxGuardrailItemChars)
+			}
+		}
+	}
+	if utf8.RuneCountInString(g.ComplianceNotes) > model.MaxComplianceNotesChars {
+		return fmt.Errorf("%w: guardrails: complianceNotes exceed %d characters", ErrInvalidSurvey, model.MaxComplianceNotesChars)
+	}
+	return nil
+}
+
+// validateThemeTaxonomy checks a taxonomy's size and that each name or synonym
+// belongs to one theme only
+func validateThemeTaxonomy(t *model.ThemeTaxonomy) error {
+	if t == nil {
+		return nil
+	}
+	if len(t.Themes) > model.MaxTaxonomyThemes {
+		return fmt.Errorf("%w: themeTaxonomy: at most %d themes", ErrInvalidSurvey, model.MaxTaxonomyThemes)
+	}
+	owner := make(map[string]int) // Lowercased name or synonym -> theme index
+	for i, theme := range t.Themes {
+		if len(theme.Synonyms) > model.MaxThemeSynonyms {
+			return fmt.Errorf("%w: themeTaxonomy: theme %q has more than %d synonyms", ErrInvalidSurvey, theme.Name, model.MaxThemeSynonyms)
+		}
+		if strings.EqualFold(strings.TrimSpace(theme.Name), model.ThemeOther) {
+			return fmt.Errorf("%w: themeTaxonomy: %q is reserved for themes outside the taxonomy", ErrInvalidSurvey, model.ThemeOther)
+		}
+		for _, term := range append([]string{theme.Name}, theme.Synonyms...) {
+			key := strings.ToLower(strings.TrimSpace(term))
+			if key == "" || utf8.RuneCountInString(term) > model.MaxTaxonomyTermChars {
+				return fmt.Errorf("%w: themeTaxonomy: names and synonyms must be 1-%d characters", ErrInvalidSurvey, model.MaxTaxonomyTermChars)
+			}
+			if other, ok := owner[key]; ok && other != i {
+				return fmt.Errorf("%w: themeTaxonomy: %q is used by both %q and %q", ErrInvalidSurvey, term, t.Themes[other].Name, theme.Name)
+			}
+			owner[key] = i
+		}
+	}
+	return nil
+}
+
+// validateQuestionShapes checks the rows, columns and options structured types need,
+// and that voice answers and length limits are only set on ESSAY questions
+func validateQuestionShapes(questions []model.BaseQuestion) error {
+	for _, q := range questions {
+		if q.AllowVoice && q.Type != model.QuestionTypeEssay {
+			return fmt.Errorf("%w: question %s: voice answers are only available on ESSAY questions", ErrInvalidSurvey, q.Key)
+		}
+		if (q.MinLength != 0 || q.MaxLength != 0) && q.Type != model.QuestionTypeEssay {
+			return fmt.Errorf("%w: question %s: length limits are only available on ESSAY questions", ErrInvalidSurvey, q.Key)
+		}
+		if q.MinLength < 0 || q.MaxLength < 0 || q.MaxLength > model.MaxAnswerChars {
+			return fmt.Errorf("%w: question %s: length limits must be between 0 and %d", ErrInvalidSurvey, q.Key, model.MaxAnswerChars)
+		}
+		if q.MaxLength > 0 && q.MinLength > q.MaxLength {
+			return fmt.Errorf("%w: question %s: minLength exceeds maxLength", ErrInvalidSurvey, q.Key)
+		}
+		switch q.Type {
+		case model.QuestionTypeMatrix:
+			if len(q.Rows) == 0 {
+				return fmt.Errorf("%w: question %s: MATRIX needs at least one row", ErrInvalidSurvey, q.Key)
+			}
+			if len(q.Columns) < 2 {
+				return fmt.Errorf("%w: question %s: MATRIX needs at least two columns", ErrInvalidSurvey, q.Key)
+			}
+		case model.QuestionTypeRanking:
+			if len(q.Options) < 2 {
+				return fmt.Errorf("%w: question %s: RANKING needs at least two options", ErrInvalidSurvey, q.Key)
+			}
+		}
+	}
+	return nil
+}
+
+// validateQuestionAI checks per-question AI overrides
+func validateQuestionAI(questions []model.BaseQuestion) error {
+	for _, q := range questions {
+		if q.AI == nil {
+			continue
+		}
+		switch q.AI.ModelTier {
+		case "", model.ModelTierFast, model.ModelTierQuality:
+		default:
+			return fmt.Errorf("%w: question %s: unknown model tier %q", ErrInvalidSurvey, q.Key, q.AI.ModelTier)
+		}
+		if t := q.AI.Temperature; t != nil && (*t < 0 || *t > 2) {
+			return fmt.Errorf("%w: question %s: temperature must be between 0 and 2", ErrInvalidSurvey, q.Key)
+		}
+		if m := q.AI.MaxFollowUps; m != nil && *m < 0 {
+			return fmt.Errorf("%w: question %s: maxFollowUps must not be negative", ErrInvalidSurvey, q.Key)
+		}
+	}
+	return nil
+}
+
+// validateQuotas checks screening quotas: MCQ only, one per option, positive limits
+func validateQuotas(questions []model.BaseQuestion) error {
+	for _, q := range questions {
+		if len(q.Quotas) == 0 {
+			continue
+		}
+		if q.Type != model.QuestionTypeMCQ {
+			return fmt.Errorf("%w: question %s: quotas are only supported on MCQ questions", ErrInvalidSurvey, q.Key)
+		}
+		seen := make(map[int]bool)
+		for _, quota := range q.Quotas {
+			if quota.Option < 0 || quota.Option >= len(q.Options) {
+				return fmt.Errorf("%w: question %s: quota option %d out of range", ErrInvalidSurvey, q.Key, quota.Option)
+			}
+			if seen[quota.Option] {
+				return fmt.Errorf("%w: question %s: duplicate quota for option %d", ErrInvalidSurvey, q.Key, quota.Option)
+			}
+			if quota.Limit < 1 {
+				return fmt.Errorf("%w: question %s: quota limit must be at least 1", ErrInvalidSurvey, q.Key)
+			}
+			seen[quota.Option] = true
+		}
+	}
+	return nil
+}
+
+// validateThemeRule checks a branch rule that fires on detected themes; themes
+// come from ESSAY evaluations, so a source question must be an ESSAY
+func validateThemeRule(i int, r model.BranchRule, questions map[string]*model.BaseQuestion) error {
+	if len(r.Options) > 0 || r.Min != nil || r.Max != nil {
+		return fmt.Errorf("%w: branch rule %d: theme rules take themes only", ErrInvalidSurvey, i+1)
+	}
+	if len(r.Themes) > model.MaxBranchThemes {
+		return fmt.Errorf("%w: branch rule %d: at most %d themes", ErrInvalidSurvey, i+1, model.MaxBranchThemes)
+	}
+	for _, t := range r.Themes {
+		if strings.TrimSpace(t) == "" || len(t) > model.MaxBranchThemeChars {
+			return fmt.Errorf("%w: branch rule %d: themes must be 1-%d characters", ErrInvalidSurvey, i+1, model.MaxBranchThemeChars)
+		}
+	}
+	if r.When != "" {
+		src, ok := questions[r.When]
+		if !ok {
+			return fmt.Errorf("%w: branch rule %d: unknown question %q", ErrInvalidSurvey, i+1, r.When)
+		}
+		if src.Type != model.QuestionTypeEssay {
+			return fmt.Errorf("%w: branch rule %d: theme rules need an ESSAY source question", ErrInvalidSurvey, i+1)
+		}
+	}
+	if len(r.Ask) == 0 {
+		return fmt.Errorf("%w: branch rule %d: ask at least one question", ErrInvalidSurvey, i+1)
+	}
+	for _, k := range r.Ask {
+		if _, ok := questions[k]; !ok {
+			return fmt.Errorf("%w: branch rule %d: unknown question %q", ErrInvalidSurvey, i+1, k)
+		}
+		if k == r.When {
+			return fmt.Errorf("%w: branch rule %d: question %s cannot branch to itself", ErrInvalidSurvey, i+1, k)
+		}
+	}
+	return nil
+}
+
+// validateBranching checks that branch rules reference real questions with
+// matching conditions and cannot loop back on themselves
+func validateBranching(survey *model.Survey) error {
+	questions := make(map[string]*model.BaseQuestion, len(survey.Questions))
+	for i := range survey.Questions {
+		questions[survey.Questions[i].Key] = &survey.Questions[i]
+	}
+
+	edges := make(map[string][]string)
+	for i, r := range survey.Branching {
+		if r.IsThemeRule() {
+			if err := validateThemeRule(i, r, questions); err != nil {
+				return err
+			}
+			if r.When != "" {
+				edges[r.When] = append(edges[r.When], r.Ask...)
+			}
+			continue
+		}
+		src, ok := questions[r.When]
+		if !ok {
+			return fmt.Errorf("%w: branch rule %d: unknown question %q", ErrInvalidSurvey, i+1, r.When)
+		}
+		switch src.Type {
+		case model.QuestionTypeMCQ:
+			if len(r.Options) == 0 || r.Min != nil || r.Max != nil {
+				return fmt.Errorf("%w: branch rule %d: MCQ conditions take options only", ErrInvalidSurvey, i+1)
+			}
+			for _, o := range r.Options {
+				if o < 0 || o >= len(src.Options) {
+					return fmt.Errorf("%w: branch rule %d: option %d out of range", ErrInvalidSurvey, i+1, o)
+				}
+			}
+		case model.QuestionTypeDegree:
+			if len(r.Options) > 0 || (r.Min == nil && r.Max == nil) {
+				return fmt.Errorf("%w: branch rule %d: DEGREE conditions take min and/or max", ErrInvalidSurvey, i+1)
+			}
+			if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+				return fmt.Errorf("%w: branch rule %d: min is greater than max", ErrInvalidSurvey, i+1)
+			}
+		default:
+			return fmt.Errorf("%w: branch rule %d: only MCQ and DEGREE questions can branch", ErrInvalidSurvey, i+1)
+		}
+		if len(r.Ask) == 0 {
+			return fmt.Errorf("%w: branch rule %d: ask at least one question", ErrInvalidSurvey, i+1)
+		}
+		for _, k := range r.Ask {
+			if _, ok := questions[k]; !ok {
+				return fmt.Errorf("%w: branch rule %d: unknown question %q", ErrInvalidSurvey, i+1, k)
+			}
+			if k == r.When {
+				return fmt.Errorf("%w: branch rule %d: question %s cannot branch to itself", ErrInvalidSurvey, i+1, k)
+			}
+		}
+		edges[r.When] = append(edges[r.When], r.Ask...)
+	}
+
+	// Depth-first search for cycles (0 = unvisited, 1 = on stack, 2 = done)
+	state := make(map[string]int)
+	var visit func(key string) bool
+	visit = func(key string) bool {
+		state[key] = 1
+		for _, next := range edges[key] {
+			if state[next] == 1 || (state[next] == 0 && visit(next)) {
+				return true
+			}
+		}
+		state[key] = 2
+		return false
+	}
+	for key := range edges {
+		if state[key] == 0 && visit(key) {
+			return fmt.Errorf("%w: branch rules form a cycle through %s", ErrInvalidSurvey, key)
+		}
+	}
+	return nil
 }
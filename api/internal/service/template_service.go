@@ -0,0 +1,141 @@
+package service
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const maxTemplatesPerHost = 50
+
+// ErrInvalidTemplate is returned for templates with a bad name, survey or settings
+var ErrInvalidTemplate = errors.New("invalid template")
+
+// TemplateService manages room templates and opens rooms from them
+type TemplateService struct {
+	templateRepo repository.TemplateRepo
+	surveyRepo   repository.SurveyRepo
+	roomSvc      *RoomService
+}
+
+// NewTemplateService creates a new template service
+func NewTemplateService(templateRepo repository.TemplateRepo, surveyRepo repository.SurveyRepo, roomSvc *RoomService) *TemplateService {
+	return &TemplateService{
+		templateRepo: templateRepo,
+		surveyRepo:   surveyRepo,
+		roomSvc:      roomSvc,
+	}
+}
+
+// Create saves a new template for the host
+func (s *TemplateService) Create(ctx context.Context, hostID string, req *model.RoomTemplateRequest) (*model.RoomTemplate, error) {
+	template, err := s.build(ctx, hostID, req)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.templateRepo.CountByHost(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxTemplatesPerHost {
+		return nil, fmt.Errorf("%w: host already has %d templates", ErrInvalidTemplate, maxTemplatesPerHost)
+	}
+
+	id, err := s.templateRepo.Create(ctx, template)
+	if err != nil {
+		return nil, err
+	}
+	template.ID = id
+	return template, nil
+}
+
+// Replace overwrites one of the host's templates; nil if it does not exist
+func (s *TemplateService) Replace(ctx context.Context, hostID, templateID string, req *model.RoomTemplateRequest) (*model.RoomTemplate, error) {
+	existing, err := s.templateRepo.Get(ctx, hostID, templateID)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+	template, err := s.build(ctx, hostID, req)
+	if err != nil {
+		return nil, err
+	}
+	template.ID = existing.ID
+	template.UseCount = existing.UseCount
+	template.LastUsedAt = existing.LastUsedAt
+	template.CreatedAt = existing.CreatedAt
+	if ok, err := s.templateRepo.Replace(ctx, template); err != nil || !ok {
+		return nil, err
+	}
+	return template, nil
+}
+
+// build validates a request into a template owned by hostID
+func (s *TemplateService) build(ctx context.Context, hostID string, req *model.RoomTemplateRequest) (*model.RoomTemplate, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || utf8.RuneCountInString(name) > model.MaxTemplateNameChars {
+		return nil, fmt.Errorf("%w: name is required (max %d characters)", ErrInvalidTemplate, model.MaxTemplateNameChars)
+	}
+	survey, err := s.surveyRepo.GetByID(ctx, req.SurveyID)
+	if err != nil {
+		return nil, err
+	}
+	if survey == nil || survey.DeletedAt != nil || survey.HostID != hostID {
+		return nil, fmt.Errorf("%w: survey not found", ErrInvalidTemplate)
+	}
+
+	settings := model.RoomSettings{}
+	if req.Settings != nil {
+		settings = *req.Settings
+	}
+	if err := validateRoomSettings(&settings, req.Branding); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	return &model.RoomTemplate{
+		HostID:    hostID,
+		Name:      name,
+		SurveyID:  req.SurveyID,
+		Settings:  settings,
+		Branding:  req.Branding,
+		HostNotes: req.HostNotes,
+	}, nil
+}
+
+// Get returns one of the host's templates; nil if it does not exist
+func (s *TemplateService) Get(ctx context.Context, hostID, templateID string) (*model.RoomTemplate, error) {
+	return s.templateRepo.Get(ctx, hostID, templateID)
+}
+
+// List returns the host's templates by name
+func (s *TemplateService) List(ctx context.Context, hostID string) ([]*model.RoomTemplate, error) {
+	return s.templateRepo.ListByHost(ctx, hostID)
+}
+
+// Delete removes one of the host's templates; rooms opened from it are unaffected
+func (s *TemplateService) Delete(ctx context.Context, hostID, templateID string) (bool, error) {
+	return s.templateRepo.Delete(ctx, hostID, templateID)
+}
+
+// CreateRoom opens a room with the template's survey, settings, branding and
+// host notes; nil if the template does not exist
+func (s *TemplateService) CreateRoom(ctx context.Context, hostID, templateID string) (*model.Room, error) {
+	template, err := s.templateRepo.Get(ctx, hostID, templateID)
+	if err != nil || template == nil {
+		return nil, err
+	}
+
+	settings := template.Settings
+	room, err := s.roomSvc.CreateRoom(ctx, template.SurveyID, hostID, &settings, template.HostNotes, "", template.Branding)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.templateRepo.MarkUsed(ctx, template.ID, time.Now()); err != nil {
+		fmt.Printf("[Templates] Failed to count use of %s: %v\n", template.ID, err)
+	}
+	return room, nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// maxTimeseriesPoints bounds the curve to the most recent day of minutes
+const maxTimeseriesPoints = 24 * 60
+
+// TimeseriesService counts joins, submissions, skips, reactions and evaluation latency per
+// minute so hosts can see when a room's energy dropped
+type TimeseriesService struct {
+	tsCache  cache.TimeseriesCache
+	roomRepo repository.RoomRepo
+}
+
+// NewTimeseriesService creates a new timeseries service
+func NewTimeseriesService(tsCache cache.TimeseriesCache, roomRepo repository.RoomRepo) *TimeseriesService {
+	return &TimeseriesService{tsCache: tsCache, roomRepo: roomRepo}
+}
+
+// Record counts one occurrence of a metric (cache.MetricJoins, ...) in the current minute
+func (s *TimeseriesService) Record(ctx context.Context, roomCode, metric string) {
+	if err := s.tsCache.Incr(ctx, roomCode, metric, time.Now(), 1); err != nil {
+		fmt.Printf("[Timeseries] Failed to count %s for %s: %v\n", metric, roomCode, err)
+	}
+}
+
+// RecordEvaluation counts a finished evaluation and its latency in the current minute
+func (s *TimeseriesService) RecordEvaluation(ctx context.Context, roomCode string, latency time.Duration) {
+	now := time.Now()
+	if err := s.tsCache.Incr(ctx, roomCode, cache.MetricEvaluations, now, 1); err != nil {
+		fmt.Printf("[Timeseries] Failed to count evaluation for %s: %v\n", roomCode, err)
+		return
+	}
+	s.tsCache.Incr(ctx, roomCode, cache.MetricEvalLatencyMs, now, latency.Milliseconds())
+}
+
+// Series returns the room's per-minute points from its first activity until
+// until (or the last activity if later), quiet minutes included
+func (s *TimeseriesService) Series(ctx context.Context, roomCode string, until time.Time) ([]model.ParticipationPoint, error) {
+	counters, err := s.tsCache.GetAll(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	points := []model.ParticipationPoint{}
+	if len(counters) == 0 {
+		return points, nil
+	}
+
+	minutes := make([]int64, 0, len(counters))
+	for m := range counters {
+		minutes = append(minutes, m)
+	}
+	sort.Slice(minutes, func(i, j int) bool { return minutes[i] < minutes[j] })
+	first, last := minutes[0], minutes[len(minutes)-1]
+	if end := until.Truncate(time.Minute).Unix(); end > last {
+		last = end
+	}
+	if span := (last-first)/60 + 1; span > maxTimeseriesPoints {
+		first = last - (maxTimeseriesPoints-1)*60
+	}
+
+	for m := first; m <= last; m += 60 {
+		point := model.ParticipationPoint{Minute: time.Unix(m, 0).UTC()}
+		if c := counters[m]; c != nil {
+			point.Joins = c[cache.MetricJoins]
+			point.Submissions = c[cache.MetricSubmissions]
+			point.Skips = c[cache.MetricSkips]
+			point.Evaluations = c[cache.MetricEvaluations]
+			point.Reactions = c[cache.MetricReactions]
+			if point.Evaluations > 0 {
+				point.AvgEvalLatencyMs = float64(c[cache.MetricEvalLatencyMs]) / float64(point.Evaluations)
+			}
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+// Get returns the participation curve for the room host; running rooms extend to
+// the current minute. Nil when the room does not exist.
+func (s *TimeseriesService) Get(ctx context.Context, roomCode, hostID string) (*model.RoomTimeseries, error) {
+	room, err := s.roomRepo.GetByCode(ctx, roomCode)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, nil
+	}
+	if room.HostID != hostID {
+		return nil, ErrNotRoomHost
+	}
+
+	until := time.Time{}
+	switch {
+	case room.EndedAt != nil:
+		until = *room.EndedAt
+	case room.Status == model.RoomStatusActive:
+		until = time.Now()
+	}
+	points, err := s.Series(ctx, roomCode, until)
+	if err != nil {
+		return nil, err
+	}
+	return &model.RoomTimeseries{RoomCode: roomCode, Points: points}, nil
+}
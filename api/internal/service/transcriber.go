@@ -0,0 +1,210 @@
+package service
+
+import (
+	"2026champs/internal/config"
+	"2026champs/internal/model"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Transcriber turns a recorded answer into text
+type Transcriber interface {
+	Name() string
+	Transcribe(ctx context.Context, audio []byte, contentType string) (string, error)
+}
+
+// NewTranscriberFromEnv picks the provider from TRANSCRIPTION_PROVIDER (gemini, whisper).
+// Without one, Gemini is used when GEMINI_API_KEY is set and a mock otherwise.
+func NewTranscriberFromEnv() Transcriber {
+	provider := os.Getenv("TRANSCRIPTION_PROVIDER")
+	aiConfig := config.DefaultAIConfig()
+	if provider == "" && aiConfig.IsEnabled() {
+		provider = "gemini"
+	}
+
+	switch provider {
+	case "gemini":
+		model := os.Getenv("GEMINI_MODEL_TRANSCRIBE")
+		if model == "" {
+			model = aiConfig.Models.L1Eval
+		}
+		return &GeminiTranscriber{
+			endpoint:   aiConfig.ModelEndpoint(model),
+			apiKey:     aiConfig.APIKey,
+			httpClient: &http.Client{Timeout: 60 * time.Second},
+		}
+	case "whisper":
+		baseURL := os.Getenv("WHISPER_BASE_URL") // Any OpenAI-compatible /v1/audio/transcriptions server
+		if baseURL == "" {
+			baseURL = "https://api.openai.com"
+		}
+		model := os.Getenv("WHISPER_MODEL")
+		if model == "" {
+			model = "whisper-1"
+		}
+		return &WhisperTranscriber{
+			baseURL:    strings.TrimRight(baseURL, "/"),
+			apiKey:     os.Getenv("WHISPER_API_KEY"),
+			model:      model,
+			httpClient: &http.Client{Timeout: 60 * time.Second},
+		}
+	default:
+		log.Println("Warning: no transcription provider configured, voice answers get a placeholder transcript")
+		return mockTranscriber{}
+	}
+}
+
+// GeminiTranscriber sends the audio inline to a multimodal Gemini model
+type GeminiTranscriber struct {
+	endpoint   string
+	apiKey     string
+	httpClient HTTPDoer
+}
+
+// Name returns the provider name recorded on voice clips
+func (t *GeminiTranscriber) Name() string { return "gemini" }
+
+// SetHTTPClient replaces the HTTP client used for Gemini calls
+func (t *GeminiTranscriber) SetHTTPClient(h HTTPDoer) {
+	t.httpClient = h
+}
+
+// Transcribe asks Gemini for a verbatim transcript
+func (t *GeminiTranscriber) Transcribe(ctx context.Context, audio []byte, contentType string) (string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"contents": []map[string]interface{}{{
+			"parts": []map[string]interface{}{
+				{"text": "Transcribe this survey answer verbatim in its original language. Return only the transcript, with no commentary. If there is no speech, return an empty response."},
+				{"inline_data": map[string]string{"mime_type": contentType, "data": base64.StdEncoding.EncodeToString(audio)}},
+			},
+		}},
+		"generationConfig": map[string]interface{}{"temperature": 0},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint+"?key="+t.apiKey, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var geminiResp geminiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&geminiResp); err != nil {
+		return "", fmt.Errorf("failed to decode gemini response: %w", err)
+	}
+	if geminiResp.Error != nil {
+		return "", fmt.Errorf("gemini api error: %s", geminiResp.Error.Message)
+	}
+
+	if len(geminiResp.Candidates) == 0 {
+		return "", nil
+	}
+	var transcript strings.Builder
+	for _, p := range geminiResp.Candidates[0].Content.Parts {
+		transcript.WriteString(p.Text)
+	}
+	return strings.TrimSpace(transcript.String()), nil
+}
+
+// WhisperTranscriber calls an OpenAI-compatible transcription endpoint
+type WhisperTranscriber struct {
+	baseURL    string
+	apiKey     string
+	model      string
+	httpClient HTTPDoer
+}
+
+// Name returns the provider name recorded on voice clips
+func (t *WhisperTranscriber) Name() string { return "whisper" }
+
+// SetHTTPClient replaces the HTTP client used for transcription calls
+func (t *WhisperTranscriber) SetHTTPClient(h HTTPDoer) {
+	t.httpClient = h
+}
+
+// Transcribe uploads the audio to POST /v1/audio/transcriptions
+func (t *WhisperTranscriber) Transcribe(ctx context.Context, audio []byte, contentType string) (string, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	if err := writer.WriteField("model", t.model); err != nil {
+		return "", err
+	}
+	if err := writer.WriteField("response_format", "json"); err != nil {
+		return "", err
+	}
+	// The API infers the format from the file name
+	part, err := writer.CreateFormFile("file", "answer"+audioExtension(contentType))
+	if err != nil {
+		return "", err
+	}
+	if _, err := part.Write(audio); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.baseURL+"/v1/audio/transcriptions", &buf)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if t.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+t.apiKey)
+	}
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("transcription returned %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var result struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode transcription: %w", err)
+	}
+	return strings.TrimSpace(result.Text), nil
+}
+
+// audioExtension returns the file extension for a stored voice content type
+func audioExtension(contentType string) string {
+	for _, t := range model.VoiceContentTypes {
+		if t.ContentType == contentType {
+			return t.Ext
+		}
+	}
+	return ".webm"
+}
+
+// mockTranscriber stands in for a provider in development
+type mockTranscriber struct{}
+
+func (mockTranscriber) Name() string { return "mock" }
+
+func (mockTranscriber) Transcribe(ctx context.Context, audio []byte, contentType string) (string, error) {
+	return fmt.Sprintf("(voice answer, %d KB of %s; transcription is not configured)", len(audio)>>10, contentType), nil
+}
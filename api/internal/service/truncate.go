@@ -0,0 +1,24 @@
+package service
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// truncateRunes cuts s to at most limit characters without splitting a rune
+func truncateRunes(s string, limit int) string {
+	if limit <= 0 || utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	return string([]rune(s)[:limit])
+}
+
+// truncateForPrompt bounds player text before it is embedded in a Gemini prompt,
+// noting the cut so the model does not judge the answer as unfinished
+func truncateForPrompt(s string, limit int) string {
+	n := utf8.RuneCountInString(s)
+	if limit <= 0 || n <= limit {
+		return s
+	}
+	return fmt.Sprintf("%s [truncated: %d more characters omitted]", truncateRunes(s, limit), n-limit)
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/model"
+	"2026champs/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const transcribeTimeout = 45 * time.Second
+
+var (
+	// ErrInvalidVoice is returned for recordings the question or file type does not allow
+	ErrInvalidVoice = errors.New("invalid voice answer")
+	// ErrVoiceTooLarge is returned for recordings over model.MaxVoiceBytes
+	ErrVoiceTooLarge = errors.New("recording is too large")
+	// ErrTranscriptionFailed is returned when the provider could not transcribe a recording
+	ErrTranscriptionFailed = errors.New("transcription failed")
+)
+
+// VoiceService stores recorded answers and transcribes them into text answers
+type VoiceService struct {
+	voiceRepo   repository.VoiceRepo
+	roomRepo    repository.RoomRepo
+	roomCache   cache.RoomCache
+	playerCache cache.PlayerCache
+	store       AttachmentStore
+	transcriber Transcriber
+}
+
+// NewVoiceService creates a new voice service
+func NewVoiceService(
+	voiceRepo repository.VoiceRepo,
+	roomRepo repository.RoomRepo,
+	roomCache cache.RoomCache,
+	playerCache cache.PlayerCache,
+	store AttachmentStore,
+	transcriber Transcriber,
+) *VoiceService {
+	return &VoiceService{
+		voiceRepo:   voiceRepo,
+		roomRepo:    roomRepo,
+		roomCache:   roomCache,
+		playerCache: playerCache,
+		store:       store,
+		transcriber: transcriber,
+	}
+}
+
+// Upload stores a recording for the player's ESSAY question and transcribes it.
+// The player submits the answer afterwards with the returned clip ID.
+func (s *VoiceService) Upload(ctx context.Context, roomCode, playerID, questionKey string, r io.Reader) (*model.VoiceClip, error) {
+	meta, err := s.roomCache.GetMeta(ctx, roomCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room meta: %w", err)
+	}
+	if meta == nil || meta.Status != model.RoomStatusActive {
+		return nil, fmt.Errorf("%w: room is not active", ErrInvalidVoice)
+	}
+
+	question, err := s.playerCache.GetQuestionMap(ctx, roomCode, playerID, questionKey)
+	if err != nil {
+		return nil, err
+	}
+	if question == nil || !question.AllowVoice {
+		return nil, fmt.Errorf("%w: question %s does not accept voice answers", ErrInvalidVoice, questionKey)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, model.MaxVoiceBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if len(data) > model.MaxVoiceBytes {
+		return nil, fmt.Errorf("%w: limit is %d MB", ErrVoiceTooLarge, model.MaxVoiceBytes>>20)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: recording is empty", ErrInvalidVoice)
+	}
+
+	sniffed := http.DetectContentType(data)
+	format, ok := model.VoiceContentTypes[sniffed]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported audio type %s (WebM, Ogg, MP3, WAV or M4A only)", ErrInvalidVoice, sniffed)
+	}
+
+	transcribeCtx, cancel := context.WithTimeout(ctx, transcribeTimeout)
+	transcript, err := s.transcriber.Transcribe(transcribeCtx, data, format.ContentType)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTranscriptionFailed, err)
+	}
+	if transcript == "" {
+		return nil, fmt.Errorf("%w: no speech detected", ErrTranscriptionFailed)
+	}
+
+	id := uuid.New().String()
+	clip := &model.VoiceClip{
+		ID:          id,
+		RoomCode:    roomCode,
+		PlayerID:    playerID,
+		QuestionKey: questionKey,
+		ContentType: format.ContentType,
+		Size:        int64(len(data)),
+		StorageKey:  "voice/" + roomCode + "/" + playerID + "/" + id + format.Ext,
+		Transcript:  transcript,
+		Provider:    s.transcriber.Name(),
+	}
+	if err := s.store.Put(ctx, clip.StorageKey, clip.ContentType, data); err != nil {
+		return nil, fmt.Errorf("failed to store recording: %w", err)
+	}
+	if err := s.voiceRepo.Create(ctx, clip); err != nil {
+		s.store.Delete(ctx, clip.StorageKey)
+		return nil, err
+	}
+	return clip, nil
+}
+
+// TranscriptFor returns the transcript of a clip the player recorded for this question
+func (s *VoiceService) TranscriptFor(ctx context.Context, roomCode, playerID, questionKey, clipID string) (string, error) {
+	clip, err := s.voiceRepo.GetByID(ctx, clipID)
+	if err != nil {
+		return "", err
+	}
+	if clip == nil || clip.RoomCode != roomCode || clip.PlayerID != playerID || clip.QuestionKey != questionKey {
+		return "", fmt.Errorf("%w: unknown voice clip %s", ErrInvalidAnswer, clipID)
+	}
+	return clip.Transcript, nil
+}
+
+// Open returns a clip and its audio for the room's host. The caller closes the reader.
+func (s *VoiceService) Open(ctx context.Context, roomCode, hostID, clipID string) (*model.VoiceClip, io.ReadCloser, error) {
+	room, err := s.roomRepo.GetByCode(ctx, roomCode)
+	if err != nil {
+		return nil, nil, err
+	}
+	if room == nil {
+		return nil, nil, ErrAttachmentNotFound
+	}
+	if room.HostID != hostID {
+		return nil, nil, ErrNotRoomHost
+	}
+
+	clip, err := s.voiceRepo.GetByID(ctx, clipID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if clip == nil || clip.RoomCode != roomCode {
+		return nil, nil, ErrAttachmentNotFound
+	}
+	body, err := s.store.Get(ctx, clip.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return clip, body, nil
+}
+
+// PurgeFilesOlderThan deletes the audio of clips recorded before cutoff.
+// The retention job removes the clip documents afterwards.
+func (s *VoiceService) PurgeFilesOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	clips, err := s.voiceRepo.ListOlderThan(ctx, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	var deleted int64
+	for _, clip := range clips {
+		if err := s.store.Delete(ctx, clip.StorageKey); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// ListPlayerClips returns the clips a player recorded in a room
+func (s *VoiceService) ListPlayerClips(ctx context.Context, roomCode, playerID string) ([]*model.VoiceClip, error) {
+	return s.voiceRepo.ListByPlayer(ctx, roomCode, playerID)
+}
+
+// DeletePlayerClips deletes a player's recordings in a room, audio first so a
+// failed request can be retried. Returns the number of clips deleted.
+func (s *VoiceService) DeletePlayerClips(ctx context.Context, roomCode, playerID string) (int64, error) {
+	clips, err := s.voiceRepo.ListByPlayer(ctx, roomCode, playerID)
+	if err != nil {
+		return 0, err
+	}
+	for _, clip := range clips {
+		if err := s.store.Delete(ctx, clip.StorageKey); err != nil {
+			return 0, fmt.Errorf("failed to delete recording %s: %w", clip.ID, err)
+		}
+	}
+	return s.voiceRepo.DeleteByPlayer(ctx, roomCode, playerID)
+}
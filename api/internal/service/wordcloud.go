@@ -0,0 +1,158 @@
+package service
+
+import (
+	"2026champs/internal/events"
+	"2026champs/internal/model"
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// maxWordCloud caps the words kept in a question's word cloud
+	maxWordCloud = 50
+	// maxWordsAnswer is the longest WORDS answer accepted, in words
+	maxWordsAnswer = 10
+)
+
+// stopWords are dropped before counting
+var stopWords = map[string]bool{
+	"a": true, "about": true, "all": true, "also": true, "am": true, "an": true, "and": true, "any": true,
+	"are": true, "as": true, "at": true, "be": true, "because": true, "been": true, "but": true, "by": true,
+	"can": true, "could": true, "did": true, "do": true, "does": true, "for": true, "from": true, "get": true,
+	"had": true, "has": true, "have": true, "he": true, "her": true, "his": true, "how": true, "i": true,
+	"if": true, "in": true, "into": true, "is": true, "it": true, "its": true, "just": true, "like": true,
+	"me": true, "more": true, "most": true, "my": true, "no": true, "not": true, "of": true, "on": true,
+	"or": true, "our": true, "out": true, "really": true, "so": true, "some": true, "than": true, "that": true,
+	"the": true, "their": true, "them": true, "then": true, "there": true, "these": true, "they": true, "this": true,
+	"to": true, "too": true, "up": true, "us": true, "very": true, "was": true, "we": true, "were": true,
+	"what": true, "when": true, "which": true, "who": true, "will": true, "with": true, "would": true, "you": true,
+	"your": true,
+}
+
+// normalizeWords lowercases text, splits it into words and drops stop words.
+// Each result pairs the stem used for counting with the word as written.
+func normalizeWords(text string) (stems, forms []string) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '-'
+	})
+	for _, w := range words {
+		w = strings.Trim(w, "'-")
+		w = strings.TrimSuffix(w, "'s")
+		if len(w) < 2 || stopWords[w] {
+			continue
+		}
+		stems = append(stems, stemWord(w))
+		forms = append(forms, w)
+	}
+	return stems, forms
+}
+
+// stemWord strips common English inflections so "bugs", "buggy" and "bug"
+// count together. It is deliberately light: plurals, -ing, -ed and -ly.
+func stemWord(w string) string {
+	switch {
+	case len(w) > 4 && strings.HasSuffix(w, "ies"):
+		return w[:len(w)-3] + "y"
+	case strings.HasSuffix(w, "sses"):
+		return w[:len(w)-2]
+	case len(w) > 5 && strings.HasSuffix(w, "ing"):
+		return undouble(w[:len(w)-3])
+	case len(w) > 4 && strings.HasSuffix(w, "ed"):
+		return undouble(w[:len(w)-2])
+	case len(w) > 4 && strings.HasSuffix(w, "ly"):
+		return w[:len(w)-2]
+	case len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") &&
+		!strings.HasSuffix(w, "us") && !strings.HasSuffix(w, "is"):
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+// undouble turns "runn" into "run" after a suffix is removed
+func undouble(w string) string {
+	n := len(w)
+	if n >= 3 && w[n-1] == w[n-2] && !strings.ContainsRune("aeiouylsz", rune(w[n-1])) {
+		return w[:n-1]
+	}
+	return w
+}
+
+// wordCloud sorts stem counts into the n most frequent words
+func wordCloud(counts map[string]int, forms map[string]string, n int) []model.WordCount {
+	cloud := make([]model.WordCount, 0, len(counts))
+	for stem, count := range counts {
+		word := forms[stem]
+		if word == "" {
+			word = stem
+		}
+		cloud = append(cloud, model.WordCount{Word: word, Count: count})
+	}
+	sort.Slice(cloud, func(i, j int) bool {
+		if cloud[i].Count != cloud[j].Count {
+			return cloud[i].Count > cloud[j].Count
+		}
+		return cloud[i].Word < cloud[j].Word
+	})
+	if len(cloud) > n {
+		cloud = cloud[:n]
+	}
+	return cloud
+}
+
+// UpdateWordCloud counts the words of a WORDS answer into the question's word
+// cloud and pushes the refreshed cloud to the host.
+func (s *AnalyticsService) UpdateWordCloud(ctx context.Context, roomCode, questionKey, text string) error {
+	stems, forms := normalizeWords(text)
+	if len(stems) == 0 {
+		return nil
+	}
+
+	profile, err := s.analyticsCache.GetQuestionProfile(ctx, roomCode, questionKey)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		profile = &model.QuestionProfile{
+			RoomCode:      roomCode,
+			QuestionKey:   questionKey,
+			ThemeCounts:   make(map[string]int),
+			MissingCounts: make(map[string]int),
+			RatingHist:    make(map[int]int),
+			OptionHist:    make(map[int]int),
+		}
+	}
+	if profile.WordCounts == nil {
+		profile.WordCounts = make(map[string]int)
+		profile.WordForms = make(map[string]string)
+	}
+
+	// Count each word once per answer, so repeating a word does not inflate it
+	seen := make(map[string]bool, len(stems))
+	for i, stem := range stems {
+		if seen[stem] {
+			continue
+		}
+		seen[stem] = true
+		profile.WordCounts[stem]++
+		// Show the shortest spelling seen, usually the base form
+		if form, ok := profile.WordForms[stem]; !ok || len(forms[i]) < len(form) {
+			profile.WordForms[stem] = forms[i]
+		}
+	}
+	profile.WordCloud = wordCloud(profile.WordCounts, profile.WordForms, maxWordCloud)
+
+	if err := s.analyticsCache.SetQuestionProfile(ctx, profile); err != nil {
+		return err
+	}
+
+	if s.broadcaster != nil {
+		s.broadcaster.ToHost(roomCode, events.AnalyticsUpdate, events.AnalyticsUpdatePayload{
+			QuestionKey: questionKey,
+			Profile:     profile,
+			WordCloud:   profile.WordCloud,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Package gql serves the GraphQL endpoint the host dashboard uses to read a
+// room, its players, answers, question profiles and reports in one request.
+// Resolvers call the same services as the REST handlers; per-request loaders
+// batch the Mongo reads so listing rooms does not cost a query per room.
+package gql
+
+import (
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// maxRequestBytes bounds the size of a query document with its variables
+const maxRequestBytes = 64 << 10
+
+// Deps holds the services behind the resolvers
+type Deps struct {
+	SurveyService    *service.SurveyService
+	RoomService      *service.RoomService
+	PlayerService    *service.PlayerService
+	AnswerService    *service.AnswerService
+	ReportService    *service.ReportService
+	AnalyticsService *service.AnalyticsService
+}
+
+// Request is a GraphQL query over HTTP
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Handler serves POST /v1/graphql for authenticated hosts
+type Handler struct {
+	schema *graphql.Schema
+}
+
+// NewHandler parses the schema against the resolvers; it panics when they do not match
+func NewHandler(d *Deps) *Handler {
+	schema := graphql.MustParseSchema(schemaSDL, &queryResolver{deps: d},
+		graphql.MaxDepth(6),
+		graphql.MaxQueryLength(maxRequestBytes),
+	)
+	return &Handler{schema: schema}
+}
+
+// ServeHTTP runs the query as the host set by the auth middleware. Query errors
+// are returned in the response's errors list with status 200.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if middleware.GetHostID(r.Context()) == "" {
+		writeError(w, http.StatusUnauthorized, "missing host")
+		return
+	}
+
+	var req Request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Query == "" {
+		writeError(w, http.StatusBadRequest, "query is required")
+		return
+	}
+
+	resp := h.schema.Exec(r.Context(), req.Query, req.OperationName, req.Variables)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
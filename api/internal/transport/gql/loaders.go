@@ -0,0 +1,85 @@
+package gql
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"sync"
+)
+
+// batch runs a load once and shares its result between concurrent resolvers
+type batch[T any] struct {
+	once sync.Once
+	val  T
+	err  error
+}
+
+func (b *batch[T]) get(load func() (T, error)) (T, error) {
+	b.once.Do(func() { b.val, b.err = load() })
+	return b.val, b.err
+}
+
+// loaders batch the Mongo reads of the rooms one query field returned: the first
+// room to resolve answers, a snapshot, a report or its survey loads it for all of
+// them, so a list of N rooms costs one query per field instead of N
+type loaders struct {
+	deps  *Deps
+	rooms []*model.Room
+
+	answers   batch[map[string][]*model.Answer]
+	snapshots batch[map[string]*model.RoomSnapshot]
+	aiReports batch[map[string]*model.AIReport]
+	surveys   batch[map[string]*model.Survey]
+}
+
+func newLoaders(d *Deps, rooms []*model.Room) *loaders {
+	return &loaders{deps: d, rooms: rooms}
+}
+
+func (l *loaders) codes() []string {
+	codes := make([]string, len(l.rooms))
+	for i, room := range l.rooms {
+		codes[i] = room.Code
+	}
+	return codes
+}
+
+func (l *loaders) roomAnswers(ctx context.Context, code string) ([]*model.Answer, error) {
+	byRoom, err := l.answers.get(func() (map[string][]*model.Answer, error) {
+		return l.deps.AnswerService.ListAnswersForRooms(ctx, l.codes())
+	})
+	return byRoom[code], err
+}
+
+func (l *loaders) snapshot(ctx context.Context, code string) (*model.RoomSnapshot, error) {
+	byRoom, err := l.snapshots.get(func() (map[string]*model.RoomSnapshot, error) {
+		return l.deps.ReportService.GetSnapshots(ctx, l.codes())
+	})
+	return byRoom[code], err
+}
+
+func (l *loaders) aiReport(ctx context.Context, code string) (*model.AIReport, error) {
+	byRoom, err := l.aiReports.get(func() (map[string]*model.AIReport, error) {
+		return l.deps.ReportService.GetAIReports(ctx, l.codes())
+	})
+	return byRoom[code], err
+}
+
+// survey loads each distinct survey of the rooms once; most hosts run many rooms
+// of a few surveys
+func (l *loaders) survey(ctx context.Context, id string) (*model.Survey, error) {
+	byID, err := l.surveys.get(func() (map[string]*model.Survey, error) {
+		byID := make(map[string]*model.Survey)
+		for _, room := range l.rooms {
+			if _, ok := byID[room.SurveyID]; ok {
+				continue
+			}
+			survey, err := l.deps.SurveyService.GetByID(ctx, room.SurveyID)
+			if err != nil {
+				return nil, err
+			}
+			byID[room.SurveyID] = survey
+		}
+		return byID, nil
+	})
+	return byID[id], err
+}
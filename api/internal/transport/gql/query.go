@@ -0,0 +1,212 @@
+package gql
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/transport/rest/middleware"
+	"context"
+	"sort"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// maxRooms caps the rooms one query lists
+const maxRooms = 100
+
+type queryResolver struct {
+	deps *Deps
+}
+
+func (q *queryResolver) Room(ctx context.Context, args struct{ Code string }) (*roomResolver, error) {
+	room, err := q.deps.RoomService.GetRoom(ctx, args.Code)
+	if err != nil {
+		return nil, err
+	}
+	// Other hosts' rooms read as missing, so codes cannot be probed
+	if room == nil || room.HostID != middleware.GetHostID(ctx) {
+		return nil, nil
+	}
+	return &roomResolver{room: room, loaders: newLoaders(q.deps, []*model.Room{room})}, nil
+}
+
+func (q *queryResolver) Rooms(ctx context.Context, args struct {
+	Status *string
+	First  int32
+}) ([]*roomResolver, error) {
+	rooms, err := q.deps.RoomService.ListHostRooms(ctx, middleware.GetHostID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(args.First)
+	if limit <= 0 || limit > maxRooms {
+		limit = maxRooms
+	}
+	picked := make([]*model.Room, 0, limit)
+	for _, room := range rooms {
+		if len(picked) == limit {
+			break
+		}
+		if args.Status == nil || string(room.Status) == *args.Status {
+			picked = append(picked, room)
+		}
+	}
+
+	l := newLoaders(q.deps, picked)
+	out := make([]*roomResolver, len(picked))
+	for i, room := range picked {
+		out[i] = &roomResolver{room: room, loaders: l}
+	}
+	return out, nil
+}
+
+type roomResolver struct {
+	room    *model.Room
+	loaders *loaders
+}
+
+func (r *roomResolver) Code() string       { return r.room.Code }
+func (r *roomResolver) Status() string     { return string(r.room.Status) }
+func (r *roomResolver) SurveyID() string   { return r.room.SurveyID }
+func (r *roomResolver) HostNotes() *string { return optString(r.room.HostNotes) }
+func (r *roomResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.room.CreatedAt}
+}
+func (r *roomResolver) StartedAt() *graphql.Time { return optTime(r.room.StartedAt) }
+func (r *roomResolver) EndedAt() *graphql.Time   { return optTime(r.room.EndedAt) }
+
+func (r *roomResolver) ended() bool {
+	return r.room.Status == model.RoomStatusEnded
+}
+
+func (r *roomResolver) Survey(ctx context.Context) (*surveyResolver, error) {
+	survey, err := r.loaders.survey(ctx, r.room.SurveyID)
+	if err != nil || survey == nil {
+		return nil, err
+	}
+	return &surveyResolver{survey: survey}, nil
+}
+
+func (r *roomResolver) Players(ctx context.Context) ([]*playerResolver, error) {
+	roster, err := r.loaders.deps.PlayerService.GetRoster(ctx, r.room.Code, r.room.HostID)
+	if err != nil || roster == nil {
+		return []*playerResolver{}, err
+	}
+	out := make([]*playerResolver, len(roster.Players))
+	for i := range roster.Players {
+		out[i] = &playerResolver{player: roster.Players[i], room: r}
+	}
+	return out, nil
+}
+
+func (r *roomResolver) Leaderboard(ctx context.Context, args struct{ Top int32 }) ([]*leaderboardResolver, error) {
+	top := int(args.Top)
+	if top <= 0 {
+		top = 20
+	}
+
+	if r.ended() {
+		snapshot, err := r.loaders.snapshot(ctx, r.room.Code)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot != nil {
+			return leaderboard(snapshot.Leaderboard, top), nil
+		}
+	}
+
+	live, err := r.loaders.deps.PlayerService.GetLeaderboard(ctx, r.room.Code, top)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]model.LeaderboardEntry, len(live))
+	for i, e := range live {
+		entries[i] = model.LeaderboardEntry{
+			PlayerID:      e.PlayerID,
+			Nickname:      e.Nickname,
+			Score:         e.Score,
+			Rank:          e.Rank,
+			Tied:          e.Tied,
+			CompletionSec: e.CompletionSec,
+		}
+	}
+	return leaderboard(entries, top), nil
+}
+
+func (r *roomResolver) Answers(ctx context.Context, args struct {
+	QuestionKey *string
+	PlayerID    *string
+}) ([]*answerResolver, error) {
+	answers, err := r.loaders.roomAnswers(ctx, r.room.Code)
+	if err != nil {
+		return nil, err
+	}
+	out := []*answerResolver{}
+	for _, a := range answers {
+		if args.QuestionKey != nil && a.QuestionKey != *args.QuestionKey {
+			continue
+		}
+		if args.PlayerID != nil && a.PlayerID != *args.PlayerID {
+			continue
+		}
+		out = append(out, &answerResolver{answer: a})
+	}
+	return out, nil
+}
+
+func (r *roomResolver) QuestionProfiles(ctx context.Context) ([]*questionProfileResolver, error) {
+	var profiles []*model.QuestionProfile
+	if r.ended() {
+		snapshot, err := r.loaders.snapshot(ctx, r.room.Code)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot != nil {
+			for i := range snapshot.QuestionProfiles {
+				profiles = append(profiles, &snapshot.QuestionProfiles[i])
+			}
+		}
+	}
+	if profiles == nil && r.loaders.deps.AnalyticsService != nil {
+		live, err := r.loaders.deps.AnalyticsService.ListQuestionProfiles(ctx, r.room.Code)
+		if err != nil {
+			return nil, err
+		}
+		profiles = live
+	}
+
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].QuestionKey < profiles[j].QuestionKey
+	})
+	out := make([]*questionProfileResolver, len(profiles))
+	for i, p := range profiles {
+		out[i] = &questionProfileResolver{profile: p}
+	}
+	return out, nil
+}
+
+func (r *roomResolver) Snapshot(ctx context.Context) (*snapshotResolver, error) {
+	snapshot, err := r.loaders.snapshot(ctx, r.room.Code)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	return &snapshotResolver{snapshot: snapshot}, nil
+}
+
+func (r *roomResolver) AIReport(ctx context.Context) (*aiReportResolver, error) {
+	report, err := r.loaders.aiReport(ctx, r.room.Code)
+	if err != nil || report == nil {
+		return nil, err
+	}
+	return &aiReportResolver{report: report}, nil
+}
+
+func leaderboard(entries []model.LeaderboardEntry, top int) []*leaderboardResolver {
+	if len(entries) > top {
+		entries = entries[:top]
+	}
+	out := make([]*leaderboardResolver, len(entries))
+	for i := range entries {
+		out[i] = &leaderboardResolver{entry: entries[i]}
+	}
+	return out
+}
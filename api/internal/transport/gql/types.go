@@ -0,0 +1,258 @@
+package gql
+
+import (
+	"2026champs/internal/model"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+type surveyResolver struct {
+	survey *model.Survey
+}
+
+func (r *surveyResolver) ID() string     { return r.survey.ID }
+func (r *surveyResolver) Title() string  { return r.survey.Title }
+func (r *surveyResolver) Intent() string { return r.survey.Intent }
+func (r *surveyResolver) Questions() []*questionResolver {
+	out := make([]*questionResolver, len(r.survey.Questions))
+	for i := range r.survey.Questions {
+		out[i] = &questionResolver{question: &r.survey.Questions[i]}
+	}
+	return out
+}
+
+type questionResolver struct {
+	question *model.BaseQuestion
+}
+
+func (r *questionResolver) Key() string      { return r.question.Key }
+func (r *questionResolver) Type() string     { return string(r.question.Type) }
+func (r *questionResolver) Prompt() string   { return r.question.Prompt }
+func (r *questionResolver) PointsMax() int32 { return int32(r.question.PointsMax) }
+
+type playerResolver struct {
+	player model.LobbyPlayer
+	room   *roomResolver
+}
+
+func (r *playerResolver) ID() string          { return r.player.PlayerID }
+func (r *playerResolver) Nickname() string    { return r.player.Nickname }
+func (r *playerResolver) Score() int32        { return int32(r.player.Score) }
+func (r *playerResolver) CurrentKey() *string { return optString(r.player.CurrentKey) }
+func (r *playerResolver) Remaining() int32    { return int32(r.player.Remaining) }
+func (r *playerResolver) Done() bool          { return r.player.Done }
+func (r *playerResolver) Connection() string  { return r.player.Connection }
+func (r *playerResolver) Left() bool          { return r.player.Left }
+func (r *playerResolver) JoinedAt() graphql.Time {
+	return graphql.Time{Time: r.player.JoinedAt}
+}
+func (r *playerResolver) LastActiveAt() graphql.Time {
+	return graphql.Time{Time: r.player.LastActiveAt}
+}
+
+// Answers reads the room's batch, so players cost no query of their own
+func (r *playerResolver) Answers(ctx context.Context) ([]*answerResolver, error) {
+	id := r.player.PlayerID
+	return r.room.Answers(ctx, struct {
+		QuestionKey *string
+		PlayerID    *string
+	}{PlayerID: &id})
+}
+
+type leaderboardResolver struct {
+	entry model.LeaderboardEntry
+}
+
+func (r *leaderboardResolver) PlayerID() string { return r.entry.PlayerID }
+func (r *leaderboardResolver) Nickname() string { return r.entry.Nickname }
+func (r *leaderboardResolver) Score() int32     { return int32(r.entry.Score) }
+func (r *leaderboardResolver) Rank() int32      { return int32(r.entry.Rank) }
+func (r *leaderboardResolver) Tied() bool       { return r.entry.Tied }
+
+type answerResolver struct {
+	answer *model.Answer
+}
+
+func (r *answerResolver) ID() string          { return r.answer.ID }
+func (r *answerResolver) PlayerID() string    { return r.answer.PlayerID }
+func (r *answerResolver) QuestionKey() string { return r.answer.QuestionKey }
+func (r *answerResolver) TextAnswer() *string { return optString(r.answer.TextAnswer) }
+func (r *answerResolver) DegreeValue() *int32 { return optInt(r.answer.DegreeValue) }
+func (r *answerResolver) OptionIndex() *int32 {
+	if r.answer.OptionIndex == nil {
+		return nil
+	}
+	idx := int32(*r.answer.OptionIndex)
+	return &idx
+}
+func (r *answerResolver) MatrixValues() []int32 { return int32s(r.answer.MatrixValues) }
+func (r *answerResolver) Ranking() []int32      { return int32s(r.answer.Ranking) }
+func (r *answerResolver) Status() string        { return string(r.answer.Status) }
+func (r *answerResolver) Resolution() *string   { return optString(string(r.answer.Resolution)) }
+func (r *answerResolver) Tries() int32          { return int32(r.answer.Tries) }
+func (r *answerResolver) ResponseMs() *int32    { return optInt(int(r.answer.ResponseMs)) }
+func (r *answerResolver) PointsEarned() int32   { return int32(r.answer.PointsEarned) }
+func (r *answerResolver) QualityScore() *float64 {
+	if r.answer.QualityScore == 0 {
+		return nil
+	}
+	return &r.answer.QualityScore
+}
+func (r *answerResolver) EvalSummary() *string { return optString(r.answer.EvalSummary) }
+func (r *answerResolver) Overridden() bool     { return r.answer.Override != nil }
+func (r *answerResolver) Starred() bool        { return r.answer.Star != nil }
+func (r *answerResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.answer.CreatedAt}
+}
+
+type questionProfileResolver struct {
+	profile *model.QuestionProfile
+}
+
+func (r *questionProfileResolver) QuestionKey() string { return r.profile.QuestionKey }
+func (r *questionProfileResolver) SatCount() int32     { return int32(r.profile.SatCount) }
+func (r *questionProfileResolver) UnsatCount() int32   { return int32(r.profile.UnsatCount) }
+func (r *questionProfileResolver) SkipCount() int32    { return int32(r.profile.SkipCount) }
+func (r *questionProfileResolver) FollowUpTriggered() int32 {
+	return int32(r.profile.FollowUpTriggered)
+}
+func (r *questionProfileResolver) FollowUpHelped() int32     { return int32(r.profile.FollowUpHelped) }
+func (r *questionProfileResolver) Themes() []*countResolver  { return counts(r.profile.ThemeCounts) }
+func (r *questionProfileResolver) Missing() []*countResolver { return counts(r.profile.MissingCounts) }
+func (r *questionProfileResolver) Misunderstandings() []string {
+	return nonNil(r.profile.Misunderstandings)
+}
+func (r *questionProfileResolver) BestProbes() []string { return nonNil(r.profile.BestProbes) }
+func (r *questionProfileResolver) RatingAverage() *float64 {
+	if r.profile.RatingCount == 0 {
+		return nil
+	}
+	avg := float64(r.profile.RatingSum) / float64(r.profile.RatingCount)
+	return &avg
+}
+func (r *questionProfileResolver) OptionCounts() []*optionCountResolver {
+	out := make([]*optionCountResolver, 0, len(r.profile.OptionHist))
+	for option, n := range r.profile.OptionHist {
+		out = append(out, &optionCountResolver{option: int32(option), count: int32(n)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].option < out[j].option })
+	return out
+}
+
+type countResolver struct {
+	name  string
+	count int32
+}
+
+func (r *countResolver) Name() string { return r.name }
+func (r *countResolver) Count() int32 { return r.count }
+
+type optionCountResolver struct {
+	option int32
+	count  int32
+}
+
+func (r *optionCountResolver) Option() int32 { return r.option }
+func (r *optionCountResolver) Count() int32  { return r.count }
+
+type snapshotResolver struct {
+	snapshot *model.RoomSnapshot
+}
+
+func (r *snapshotResolver) EndedAt() graphql.Time {
+	return graphql.Time{Time: r.snapshot.EndedAt}
+}
+func (r *snapshotResolver) Practice() bool           { return r.snapshot.Practice }
+func (r *snapshotResolver) TotalPlayers() int32      { return int32(r.snapshot.TotalPlayers) }
+func (r *snapshotResolver) CompletionRate() float64  { return r.snapshot.CompletionRate }
+func (r *snapshotResolver) OverallSkipRate() float64 { return r.snapshot.OverallSkipRate }
+func (r *snapshotResolver) Leaderboard() []*leaderboardResolver {
+	return leaderboard(r.snapshot.Leaderboard, len(r.snapshot.Leaderboard))
+}
+
+type aiReportResolver struct {
+	report *model.AIReport
+}
+
+func (r *aiReportResolver) Status() string             { return r.report.Status }
+func (r *aiReportResolver) ExecutiveSummary() []string { return nonNil(r.report.ExecutiveSummary) }
+func (r *aiReportResolver) KeyThemes() []*themeResolver {
+	out := make([]*themeResolver, len(r.report.KeyThemes))
+	for i := range r.report.KeyThemes {
+		out[i] = &themeResolver{theme: &r.report.KeyThemes[i]}
+	}
+	return out
+}
+func (r *aiReportResolver) RecommendedQuestions() []string {
+	return nonNil(r.report.RecommendedQuestions)
+}
+func (r *aiReportResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.report.CreatedAt}
+}
+func (r *aiReportResolver) ReadyAt() *graphql.Time { return optTime(r.report.ReadyAt) }
+
+type themeResolver struct {
+	theme *model.ThemeInsight
+}
+
+func (r *themeResolver) Name() string               { return r.theme.Name }
+func (r *themeResolver) Meaning() string            { return r.theme.Meaning }
+func (r *themeResolver) Percentage() float64        { return r.theme.Percentage }
+func (r *themeResolver) EvidenceSnippets() []string { return nonNil(r.theme.EvidenceSnippets) }
+
+// counts lists a histogram most frequent first, ties by name
+func counts(hist map[string]int) []*countResolver {
+	out := make([]*countResolver, 0, len(hist))
+	for name, n := range hist {
+		out = append(out, &countResolver{name: name, count: int32(n)})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].count != out[j].count {
+			return out[i].count > out[j].count
+		}
+		return out[i].name < out[j].name
+	})
+	return out
+}
+
+// optString leaves empty strings null, as the REST API omits them
+func optString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func optInt(n int) *int32 {
+	if n == 0 {
+		return nil
+	}
+	v := int32(n)
+	return &v
+}
+
+func optTime(t *time.Time) *graphql.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
+
+func int32s(values []int) []int32 {
+	out := make([]int32, len(values))
+	for i, v := range values {
+		out[i] = int32(v)
+	}
+	return out
+}
+
+// nonNil turns nil into an empty list for non-null list fields
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"2026champs/internal/service"
+	"net/http"
+)
+
+// AISchemaHandler exposes how often Gemini responses fail their schema
+type AISchemaHandler struct {
+	evaluator *service.EvaluatorService
+}
+
+// NewAISchemaHandler creates a new AI schema handler
+func NewAISchemaHandler(evaluator *service.EvaluatorService) *AISchemaHandler {
+	return &AISchemaHandler{evaluator: evaluator}
+}
+
+// Metrics handles GET /v1/admin/ai-schema - schema failures per prompt template on this instance
+func (h *AISchemaHandler) Metrics(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.evaluator.SchemaMetrics())
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// APIKeyHandler handles host API key management
+type APIKeyHandler struct {
+	apiKeySvc *service.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(apiKeySvc *service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeySvc: apiKeySvc}
+}
+
+// Create handles POST /v1/api-keys
+func (h *APIKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req model.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	resp, err := h.apiKeySvc.Create(r.Context(), hostID, &req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// List handles GET /v1/api-keys
+func (h *APIKeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	keys, err := h.apiKeySvc.List(r.Context(), hostID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, keys)
+}
+
+// Revoke handles DELETE /v1/api-keys/{keyId}
+func (h *APIKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	keyID := mux.Vars(r)["keyId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	revoked, err := h.apiKeySvc.Revoke(r.Context(), hostID, keyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if !revoked {
+		writeError(w, http.StatusNotFound, "API key not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "revoked"})
+}
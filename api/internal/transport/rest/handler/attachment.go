@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// AttachmentHandler handles answer attachment endpoints
+type AttachmentHandler struct {
+	attachmentSvc *service.AttachmentService
+}
+
+// NewAttachmentHandler creates a new attachment handler
+func NewAttachmentHandler(attachmentSvc *service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{attachmentSvc: attachmentSvc}
+}
+
+// Upload handles POST /v1/rooms/{code}/questions/{questionKey}/attachments (multipart field "file")
+func (h *AttachmentHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	roomCode := middleware.GetRoomCode(r.Context())
+	playerID := middleware.GetPlayerID(r.Context())
+	questionKey := mux.Vars(r)["questionKey"]
+
+	// Leave room for the multipart framing around the file
+	r.Body = http.MaxBytesReader(w, r.Body, model.MaxAttachmentBytes+64<<10)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, service.ErrAttachmentTooLarge.Error())
+			return
+		}
+		writeError(w, http.StatusBadRequest, "expected a multipart upload with a \"file\" field")
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.attachmentSvc.Upload(r.Context(), roomCode, playerID, questionKey, header.Filename, file)
+	if errors.Is(err, service.ErrAttachmentTooLarge) {
+		writeError(w, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrInvalidAttachment) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, attachment)
+}
+
+// List handles GET /v1/rooms/{code}/attachments
+func (h *AttachmentHandler) List(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	attachments, err := h.attachmentSvc.ListByRoom(r.Context(), code, hostID)
+	if !h.checkError(w, err) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, attachments)
+}
+
+// Download handles GET /v1/rooms/{code}/attachments/{attachmentId}
+func (h *AttachmentHandler) Download(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	attachment, body, err := h.attachmentSvc.Open(r.Context(), vars["code"], hostID, vars["attachmentId"])
+	if !h.checkError(w, err) {
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, body)
+}
+
+// checkError writes the response for err and reports whether the caller may continue
+func (h *AttachmentHandler) checkError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, service.ErrNotRoomHost):
+		writeError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrAttachmentNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// AuditHandler serves room audit trails
+type AuditHandler struct {
+	auditSvc *service.AuditService
+}
+
+// NewAuditHandler creates a new audit handler
+func NewAuditHandler(auditSvc *service.AuditService) *AuditHandler {
+	return &AuditHandler{auditSvc: auditSvc}
+}
+
+// List handles GET /v1/rooms/{code}/audit
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	entries, err := h.auditSvc.List(r.Context(), code, hostID)
+	if errors.Is(err, service.ErrNotRoomHost) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if entries == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"roomCode": code, "entries": entries})
+}
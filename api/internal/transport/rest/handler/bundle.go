@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// BundleHandler handles full-room export bundles
+type BundleHandler struct {
+	bundleSvc *service.BundleService
+}
+
+// NewBundleHandler creates a new bundle handler
+func NewBundleHandler(bundleSvc *service.BundleService) *BundleHandler {
+	return &BundleHandler{bundleSvc: bundleSvc}
+}
+
+// Export handles GET /v1/admin/rooms/{code}/bundle - the room as one JSON download
+func (h *BundleHandler) Export(w http.ResponseWriter, r *http.Request) {
+	roomCode := mux.Vars(r)["code"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	bundle, err := h.bundleSvc.Export(r.Context(), hostID, roomCode)
+	if errors.Is(err, service.ErrNotRoomHost) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if bundle == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	w.Header().Set("Content-Disposition", "attachment; filename=\"room-"+roomCode+"-bundle.json\"")
+	writeJSON(w, http.StatusOK, bundle)
+}
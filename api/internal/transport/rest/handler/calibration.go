@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// CalibrationHandler handles evaluation calibration endpoints
+type CalibrationHandler struct {
+	calibrationSvc *service.CalibrationService
+}
+
+// NewCalibrationHandler creates a new calibration handler
+func NewCalibrationHandler(calibrationSvc *service.CalibrationService) *CalibrationHandler {
+	return &CalibrationHandler{calibrationSvc: calibrationSvc}
+}
+
+// GetCalibration handles GET /v1/admin/eval-calibration
+func (h *CalibrationHandler) GetCalibration(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	report, err := h.calibrationSvc.GetCalibration(r.Context(), hostID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+// AuditAnswer handles POST /v1/admin/answers/{answerId}/audit
+func (h *CalibrationHandler) AuditAnswer(w http.ResponseWriter, r *http.Request) {
+	answerID := mux.Vars(r)["answerId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req model.AuditRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	answer, err := h.calibrationSvc.AuditAnswer(r.Context(), hostID, answerID, &req)
+	if errors.Is(err, service.ErrNotRoomHost) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if answer == nil {
+		writeError(w, http.StatusNotFound, "answer not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, answer)
+}
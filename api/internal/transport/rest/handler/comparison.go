@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// ComparisonHandler handles room comparison report endpoints
+type ComparisonHandler struct {
+	compSvc *service.ComparisonService
+}
+
+// NewComparisonHandler creates a new comparison handler
+func NewComparisonHandler(compSvc *service.ComparisonService) *ComparisonHandler {
+	return &ComparisonHandler{compSvc: compSvc}
+}
+
+// Compare handles POST /v1/reports/compare
+func (h *ComparisonHandler) Compare(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req model.ComparisonRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	report, err := h.compSvc.Compare(r.Context(), hostID, &req)
+	if errors.Is(err, service.ErrInvalidComparison) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, report)
+}
+
+// List handles GET /v1/reports/compare
+func (h *ComparisonHandler) List(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	reports, err := h.compSvc.List(r.Context(), hostID, r.URL.Query().Get("surveyId"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, reports)
+}
+
+// Get handles GET /v1/reports/compare/{comparisonId}
+func (h *ComparisonHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["comparisonId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	report, err := h.compSvc.Get(r.Context(), hostID, id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if report == nil {
+		writeError(w, http.StatusNotFound, "comparison not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
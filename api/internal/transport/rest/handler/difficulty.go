@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// DifficultyHandler handles question difficulty calibration endpoints
+type DifficultyHandler struct {
+	difficultySvc *service.DifficultyService
+}
+
+// NewDifficultyHandler creates a new difficulty handler
+func NewDifficultyHandler(difficultySvc *service.DifficultyService) *DifficultyHandler {
+	return &DifficultyHandler{difficultySvc: difficultySvc}
+}
+
+// Get handles GET /v1/surveys/{surveyId}/difficulty
+func (h *DifficultyHandler) Get(w http.ResponseWriter, r *http.Request) {
+	surveyID := mux.Vars(r)["surveyId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	report, err := h.difficultySvc.Get(r.Context(), hostID, surveyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if report == nil {
+		writeError(w, http.StatusNotFound, "survey not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+// Apply handles POST /v1/surveys/{surveyId}/difficulty/apply
+func (h *DifficultyHandler) Apply(w http.ResponseWriter, r *http.Request) {
+	surveyID := mux.Vars(r)["surveyId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	report, err := h.difficultySvc.Apply(r.Context(), hostID, surveyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if report == nil {
+		writeError(w, http.StatusNotFound, "survey not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
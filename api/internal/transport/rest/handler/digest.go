@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// DigestHandler handles weekly digest endpoints
+type DigestHandler struct {
+	digestSvc *service.DigestService
+}
+
+// NewDigestHandler creates a new digest handler
+func NewDigestHandler(digestSvc *service.DigestService) *DigestHandler {
+	return &DigestHandler{digestSvc: digestSvc}
+}
+
+// GetSettings handles GET /v1/digest/settings
+func (h *DigestHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	settings, err := h.digestSvc.GetSettings(r.Context(), hostID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, settings)
+}
+
+// UpdateSettings handles PUT /v1/digest/settings
+func (h *DigestHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req model.DigestSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	settings, err := h.digestSvc.UpdateSettings(r.Context(), hostID, &req)
+	if errors.Is(err, service.ErrInvalidRecipients) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, settings)
+}
+
+// Preview handles GET /v1/digest/preview
+func (h *DigestHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	digest, err := h.digestSvc.Preview(r.Context(), hostID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, digest)
+}
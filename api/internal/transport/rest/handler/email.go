@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// EmailHandler handles emailing AI reports to stakeholders
+type EmailHandler struct {
+	emailSvc *service.EmailService
+}
+
+// NewEmailHandler creates a new email handler
+func NewEmailHandler(emailSvc *service.EmailService) *EmailHandler {
+	return &EmailHandler{emailSvc: emailSvc}
+}
+
+// SendReport handles POST /v1/reports/{roomCode}/email
+func (h *EmailHandler) SendReport(w http.ResponseWriter, r *http.Request) {
+	roomCode := mux.Vars(r)["roomCode"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req model.EmailReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	delivery, err := h.emailSvc.SendReport(r.Context(), roomCode, hostID, &req)
+	switch {
+	case errors.Is(err, service.ErrInvalidRecipients):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, service.ErrNotRoomHost):
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	case errors.Is(err, service.ErrReportNotReady):
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if delivery == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, delivery)
+}
+
+// ListDeliveries handles GET /v1/reports/{roomCode}/email
+func (h *EmailHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	roomCode := mux.Vars(r)["roomCode"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	deliveries, err := h.emailSvc.ListDeliveries(r.Context(), roomCode, hostID)
+	if errors.Is(err, service.ErrNotRoomHost) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if deliveries == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, deliveries)
+}
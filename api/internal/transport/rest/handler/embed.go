@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// embedStreamInterval is how often the SSE variant pushes fresh results
+const embedStreamInterval = 2 * time.Second
+
+// EmbedHandler handles the embeddable live results widget
+type EmbedHandler struct {
+	embedSvc *service.EmbedService
+}
+
+// NewEmbedHandler creates a new embed handler
+func NewEmbedHandler(embedSvc *service.EmbedService) *EmbedHandler {
+	return &EmbedHandler{embedSvc: embedSvc}
+}
+
+// CreateToken handles POST /v1/rooms/{code}/embed-token
+func (h *EmbedHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// The body is optional; an empty one uses the default expiry
+	var req model.EmbedTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	token, err := h.embedSvc.CreateToken(r.Context(), code, hostID, req)
+	if errors.Is(err, service.ErrNotRoomHost) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if token == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, token)
+}
+
+// GetData handles GET /v1/rooms/{code}/embed?token=
+func (h *EmbedHandler) GetData(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+	if err := h.embedSvc.Authorize(code, r.URL.Query().Get("token")); err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	data, err := h.embedSvc.GetData(r.Context(), code)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if data == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, data)
+}
+
+// Stream handles GET /v1/rooms/{code}/embed/stream?token=
+// It sends an embed_update event every few seconds until the room ends or the token expires.
+func (h *EmbedHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+	token := r.URL.Query().Get("token")
+	if err := h.embedSvc.Authorize(code, token); err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable proxy buffering
+	w.WriteHeader(http.StatusOK)
+
+	ticker := time.NewTicker(embedStreamInterval)
+	defer ticker.Stop()
+	for {
+		data, err := h.embedSvc.GetData(r.Context(), code)
+		if err != nil || data == nil {
+			writeSSE(w, "error", map[string]string{"error": "room unavailable"})
+			flusher.Flush()
+			return
+		}
+		writeSSE(w, "embed_update", data)
+		flusher.Flush()
+		if data.Status == model.RoomStatusEnded {
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+		if err := h.embedSvc.Authorize(code, token); err != nil {
+			writeSSE(w, "error", map[string]string{"error": err.Error()})
+			flusher.Flush()
+			return
+		}
+	}
+}
+
+// writeSSE writes one Server-Sent Event with a JSON payload
+func writeSSE(w io.Writer, event string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+}
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// EventGroupHandler handles multi-room event endpoints
+type EventGroupHandler struct {
+	groupSvc *service.EventGroupService
+}
+
+// NewEventGroupHandler creates a new event handler
+func NewEventGroupHandler(groupSvc *service.EventGroupService) *EventGroupHandler {
+	return &EventGroupHandler{groupSvc: groupSvc}
+}
+
+// Create handles POST /v1/events
+func (h *EventGroupHandler) Create(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req model.EventGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	group, err := h.groupSvc.Create(r.Context(), hostID, &req)
+	if errors.Is(err, service.ErrInvalidEventGroup) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, group)
+}
+
+// List handles GET /v1/events
+func (h *EventGroupHandler) List(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	groups, err := h.groupSvc.List(r.Context(), hostID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, groups)
+}
+
+// Get handles GET /v1/events/{eventId}
+func (h *EventGroupHandler) Get(w http.ResponseWriter, r *http.Request) {
+	groupID := mux.Vars(r)["eventId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	group, err := h.groupSvc.Get(r.Context(), hostID, groupID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if group == nil {
+		writeError(w, http.StatusNotFound, "event not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, group)
+}
+
+// Delete handles DELETE /v1/events/{eventId}
+func (h *EventGroupHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	groupID := mux.Vars(r)["eventId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	deleted, err := h.groupSvc.Delete(r.Context(), hostID, groupID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if !deleted {
+		writeError(w, http.StatusNotFound, "event not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// AddRoomRequest is the body of POST /v1/events/{eventId}/rooms
+type AddRoomRequest struct {
+	RoomCode string `json:"roomCode"`
+}
+
+// AddRoom handles POST /v1/events/{eventId}/rooms
+func (h *EventGroupHandler) AddRoom(w http.ResponseWriter, r *http.Request) {
+	groupID := mux.Vars(r)["eventId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req AddRoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RoomCode == "" {
+		writeError(w, http.StatusBadRequest, "roomCode is required")
+		return
+	}
+
+	group, err := h.groupSvc.AddRoom(r.Context(), hostID, groupID, req.RoomCode)
+	h.writeGroup(w, group, err)
+}
+
+// RemoveRoom handles DELETE /v1/events/{eventId}/rooms/{code}
+func (h *EventGroupHandler) RemoveRoom(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	group, err := h.groupSvc.RemoveRoom(r.Context(), hostID, vars["eventId"], vars["code"])
+	h.writeGroup(w, group, err)
+}
+
+func (h *EventGroupHandler) writeGroup(w http.ResponseWriter, group *model.EventGroup, err error) {
+	if errors.Is(err, service.ErrInvalidEventGroup) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if group == nil {
+		writeError(w, http.StatusNotFound, "event not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, group)
+}
+
+// Leaderboard handles GET /v1/events/{eventId}/leaderboard?limit=
+func (h *EventGroupHandler) Leaderboard(w http.ResponseWriter, r *http.Request) {
+	groupID := mux.Vars(r)["eventId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = n
+	}
+
+	board, err := h.groupSvc.Leaderboard(r.Context(), hostID, groupID, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if board == nil {
+		writeError(w, http.StatusNotFound, "event not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, board)
+}
+
+// Analytics handles GET /v1/events/{eventId}/analytics
+func (h *EventGroupHandler) Analytics(w http.ResponseWriter, r *http.Request) {
+	groupID := mux.Vars(r)["eventId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	analytics, err := h.groupSvc.Analytics(r.Context(), hostID, groupID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if analytics == nil {
+		writeError(w, http.StatusNotFound, "event not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, analytics)
+}
+
+// GenerateReport handles POST /v1/events/{eventId}/report
+func (h *EventGroupHandler) GenerateReport(w http.ResponseWriter, r *http.Request) {
+	groupID := mux.Vars(r)["eventId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	report, err := h.groupSvc.TriggerReport(r.Context(), hostID, groupID)
+	if errors.Is(err, service.ErrInvalidEventGroup) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if report == nil {
+		writeError(w, http.StatusNotFound, "event not found")
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, map[string]string{"status": report.Status})
+}
+
+// GetReport handles GET /v1/events/{eventId}/report
+func (h *EventGroupHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	groupID := mux.Vars(r)["eventId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	group, err := h.groupSvc.Get(r.Context(), hostID, groupID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if group == nil || group.Report == nil {
+		writeError(w, http.StatusNotFound, "report not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, group.Report)
+}
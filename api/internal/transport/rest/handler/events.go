@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"2026champs/internal/events"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// EventHandler exposes the room event stream
+type EventHandler struct {
+	metrics  *events.Metrics
+	eventLog *service.EventLogService
+}
+
+// NewEventHandler creates a new event handler
+func NewEventHandler(metrics *events.Metrics, eventLog *service.EventLogService) *EventHandler {
+	return &EventHandler{metrics: metrics, eventLog: eventLog}
+}
+
+// Metrics handles GET /v1/admin/events - events published by this instance, by type and audience
+func (h *EventHandler) Metrics(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.metrics.Snapshot())
+}
+
+// List handles GET /v1/rooms/{code}/events?afterSeq=&limit=
+func (h *EventHandler) List(w http.ResponseWriter, r *http.Request) {
+	roomCode := mux.Vars(r)["code"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var afterSeq int64
+	if v := r.URL.Query().Get("afterSeq"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "afterSeq must be a non-negative integer")
+			return
+		}
+		afterSeq = n
+	}
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = n
+	}
+
+	page, err := h.eventLog.List(r.Context(), roomCode, hostID, afterSeq, limit)
+	if errors.Is(err, service.ErrNotRoomHost) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if page == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// ExperimentHandler handles A/B experiment endpoints
+type ExperimentHandler struct {
+	experimentSvc *service.ExperimentService
+}
+
+// NewExperimentHandler creates a new experiment handler
+func NewExperimentHandler(experimentSvc *service.ExperimentService) *ExperimentHandler {
+	return &ExperimentHandler{experimentSvc: experimentSvc}
+}
+
+// Create handles POST /v1/experiments
+func (h *ExperimentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req model.CreateExperimentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	experiment, err := h.experimentSvc.Create(r.Context(), hostID, &req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, experiment)
+}
+
+// List handles GET /v1/experiments
+func (h *ExperimentHandler) List(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	experiments, err := h.experimentSvc.List(r.Context(), hostID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, experiments)
+}
+
+// Get handles GET /v1/experiments/{experimentId}
+func (h *ExperimentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	experimentID := mux.Vars(r)["experimentId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	experiment, err := h.experimentSvc.Get(r.Context(), hostID, experimentID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if experiment == nil {
+		writeError(w, http.StatusNotFound, "experiment not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, experiment)
+}
+
+// Stop handles POST /v1/experiments/{experimentId}/stop
+func (h *ExperimentHandler) Stop(w http.ResponseWriter, r *http.Request) {
+	experimentID := mux.Vars(r)["experimentId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	stopped, err := h.experimentSvc.Stop(r.Context(), hostID, experimentID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if !stopped {
+		writeError(w, http.StatusNotFound, "active experiment not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": string(model.ExperimentStatusStopped)})
+}
+
+// Compare handles GET /v1/experiments/{experimentId}/comparison
+func (h *ExperimentHandler) Compare(w http.ResponseWriter, r *http.Request) {
+	experimentID := mux.Vars(r)["experimentId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	comparison, err := h.experimentSvc.Compare(r.Context(), hostID, experimentID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if comparison == nil {
+		writeError(w, http.StatusNotFound, "experiment not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, comparison)
+}
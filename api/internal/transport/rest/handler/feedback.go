@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"net/http"
+)
+
+// FeedbackHandler serves players their end-of-room summary
+type FeedbackHandler struct {
+	feedbackSvc *service.FeedbackService
+}
+
+// NewFeedbackHandler creates a new feedback handler
+func NewFeedbackHandler(feedbackSvc *service.FeedbackService) *FeedbackHandler {
+	return &FeedbackHandler{feedbackSvc: feedbackSvc}
+}
+
+// Get handles GET /v1/rooms/{code}/feedback
+func (h *FeedbackHandler) Get(w http.ResponseWriter, r *http.Request) {
+	roomCode := middleware.GetRoomCode(r.Context())
+	playerID := middleware.GetPlayerID(r.Context())
+
+	feedback, err := h.feedbackSvc.Get(r.Context(), roomCode, playerID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if feedback == nil {
+		writeError(w, http.StatusNotFound, "feedback not available yet")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, feedback)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// IntegrationHandler handles Slack and Teams webhook management
+type IntegrationHandler struct {
+	integrationSvc *service.IntegrationService
+}
+
+// NewIntegrationHandler creates a new integration handler
+func NewIntegrationHandler(integrationSvc *service.IntegrationService) *IntegrationHandler {
+	return &IntegrationHandler{integrationSvc: integrationSvc}
+}
+
+// Create handles POST /v1/integrations
+func (h *IntegrationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req model.CreateIntegrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	integration, err := h.integrationSvc.Create(r.Context(), hostID, &req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, integration)
+}
+
+// List handles GET /v1/integrations
+func (h *IntegrationHandler) List(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	integrations, err := h.integrationSvc.List(r.Context(), hostID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, integrations)
+}
+
+// Delete handles DELETE /v1/integrations/{integrationId}
+func (h *IntegrationHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	integrationID := mux.Vars(r)["integrationId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	deleted, err := h.integrationSvc.Delete(r.Context(), hostID, integrationID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if !deleted {
+		writeError(w, http.StatusNotFound, "integration not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// MetaSurveyHandler handles meta-survey endpoints
+type MetaSurveyHandler struct {
+	metaSvc *service.MetaSurveyService
+}
+
+// NewMetaSurveyHandler creates a new meta-survey handler
+func NewMetaSurveyHandler(metaSvc *service.MetaSurveyService) *MetaSurveyHandler {
+	return &MetaSurveyHandler{metaSvc: metaSvc}
+}
+
+// Submit handles POST /v1/rooms/{code}/meta-feedback
+func (h *MetaSurveyHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	roomCode := middleware.GetRoomCode(r.Context())
+	playerID := middleware.GetPlayerID(r.Context())
+
+	var req model.MetaFeedbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	err := h.metaSvc.Submit(r.Context(), roomCode, playerID, &req)
+	switch {
+	case errors.Is(err, service.ErrPlayerLeft):
+		writeError(w, http.StatusGone, err.Error())
+		return
+	case errors.Is(err, service.ErrInvalidMetaFeedback):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, service.ErrMetaSurveyOff):
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, service.ErrMetaSurveyClosed), errors.Is(err, service.ErrNotFinished), errors.Is(err, service.ErrMetaAnswered):
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "recorded"})
+}
+
+// ForSurvey handles GET /v1/surveys/{surveyId}/meta-feedback
+func (h *MetaSurveyHandler) ForSurvey(w http.ResponseWriter, r *http.Request) {
+	surveyID := mux.Vars(r)["surveyId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	feedback, err := h.metaSvc.ForSurvey(r.Context(), surveyID, hostID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if feedback == nil {
+		writeError(w, http.StatusNotFound, "survey not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, feedback)
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"net/http"
+)
+
+// PlanHandler handles plan usage endpoints
+type PlanHandler struct {
+	planSvc *service.PlanService
+}
+
+// NewPlanHandler creates a new plan handler
+func NewPlanHandler(planSvc *service.PlanService) *PlanHandler {
+	return &PlanHandler{planSvc: planSvc}
+}
+
+// Usage handles GET /v1/usage
+func (h *PlanHandler) Usage(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	usage, err := h.planSvc.Usage(r.Context(), hostID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, usage)
+}
@@ -5,7 +5,10 @@ import (
 	"2026champs/internal/service"
 	"2026champs/internal/transport/rest/middleware"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 )
@@ -29,11 +32,12 @@ func (h *PlayerHandler) GetCurrentQuestion(w http.ResponseWriter, r *http.Reques
 	roomCode := middleware.GetRoomCode(r.Context())
 	playerID := middleware.GetPlayerID(r.Context())
 
-	question, player, err := h.playerSvc.GetCurrentQuestion(r.Context(), roomCode, playerID)
+	state, err := h.playerSvc.GetCurrentState(r.Context(), roomCode, playerID)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	question, player := state.Question, state.Player
 
 	response := map[string]interface{}{
 		"done":     false,
@@ -49,6 +53,17 @@ func (h *PlayerHandler) GetCurrentQuestion(w http.ResponseWriter, r *http.Reques
 
 	if question == nil {
 		response["done"] = true
+		if len(state.MetaQuestions) > 0 {
+			response["metaQuestions"] = state.MetaQuestions
+		}
+	} else {
+		// The attempt state came with the pipelined read; no extra round trip for the draft
+		response["draft"] = model.DraftFromAttempt(question.Key, state.Attempt)
+	}
+
+	// Best effort: the question is still served when the estimate fails
+	if progress, err := h.playerSvc.GetProgress(r.Context(), roomCode, playerID); err == nil && progress != nil {
+		response["progress"] = progress
 	}
 
 	writeJSON(w, http.StatusOK, response)
@@ -56,7 +71,24 @@ func (h *PlayerHandler) GetCurrentQuestion(w http.ResponseWriter, r *http.Reques
 
 // DraftRequest is the request body for saving a draft
 type DraftRequest struct {
-	Draft string `json:"draft"`
+	Draft       string `json:"draft"`
+	BaseVersion *int   `json:"baseVersion,omitempty"` // Optional; If-Match header is also accepted
+}
+
+// GetDraft handles GET /v1/rooms/{code}/questions/{questionKey}/draft
+func (h *PlayerHandler) GetDraft(w http.ResponseWriter, r *http.Request) {
+	roomCode := middleware.GetRoomCode(r.Context())
+	playerID := middleware.GetPlayerID(r.Context())
+	questionKey := mux.Vars(r)["questionKey"]
+
+	draft, err := h.answerSvc.GetDraft(r.Context(), roomCode, playerID, questionKey)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	setDraftHeaders(w, draft)
+	writeJSON(w, http.StatusOK, draft)
 }
 
 // SaveDraft handles PUT /v1/rooms/{code}/questions/{questionKey}/draft
@@ -66,17 +98,72 @@ func (h *PlayerHandler) SaveDraft(w http.ResponseWriter, r *http.Request) {
 	questionKey := mux.Vars(r)["questionKey"]
 
 	var req DraftRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAnswerBody(w, r, &req) {
 		return
 	}
 
-	if err := h.answerSvc.SaveDraft(r.Context(), roomCode, playerID, questionKey, req.Draft); err != nil {
+	baseVersion := req.BaseVersion
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && baseVersion == nil {
+		v, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid If-Match header")
+			return
+		}
+		baseVersion = &v
+	}
+
+	draft, err := h.answerSvc.SaveDraft(r.Context(), roomCode, playerID, questionKey, req.Draft, baseVersion)
+	if errors.Is(err, service.ErrPlayerLeft) {
+		writeError(w, http.StatusGone, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrInvalidAnswer) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrDraftConflict) {
+		setDraftHeaders(w, draft)
+		writeJSON(w, http.StatusConflict, map[string]interface{}{
+			"error":   err.Error(),
+			"current": draft,
+		})
+		return
+	}
+	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]string{"status": "saved"})
+	setDraftHeaders(w, draft)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":       "saved",
+		"version":      draft.Version,
+		"lastModified": draft.LastModified,
+	})
+}
+
+func setDraftHeaders(w http.ResponseWriter, draft *model.DraftState) {
+	w.Header().Set("ETag", `"`+strconv.Itoa(draft.Version)+`"`)
+	if draft.LastModified != nil {
+		w.Header().Set("Last-Modified", draft.LastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// decodeAnswerBody decodes an answer or draft body no larger than model.MaxAnswerBodyBytes,
+// writing 413 or 400 and returning false when it cannot
+func decodeAnswerBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, model.MaxAnswerBodyBytes)
+	err := json.NewDecoder(r.Body).Decode(v)
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		writeError(w, http.StatusRequestEntityTooLarge, "request body too large")
+		return false
+	case err != nil:
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return false
+	}
+	return true
 }
 
 // SubmitAnswer handles POST /v1/rooms/{code}/answers
@@ -85,12 +172,19 @@ func (h *PlayerHandler) SubmitAnswer(w http.ResponseWriter, r *http.Request) {
 	playerID := middleware.GetPlayerID(r.Context())
 
 	var req model.SubmitAnswerRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAnswerBody(w, r, &req) {
 		return
 	}
 
 	resp, err := h.answerSvc.SubmitAnswer(r.Context(), roomCode, playerID, &req)
+	if errors.Is(err, service.ErrPlayerLeft) {
+		writeError(w, http.StatusGone, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrInvalidAnswer) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -106,6 +200,21 @@ func (h *PlayerHandler) Skip(w http.ResponseWriter, r *http.Request) {
 	questionKey := mux.Vars(r)["questionKey"]
 
 	nextQuestion, err := h.answerSvc.Skip(r.Context(), roomCode, playerID, questionKey)
+	if errors.Is(err, service.ErrPlayerLeft) {
+		writeError(w, http.StatusGone, err.Error())
+		return
+	}
+	var locked *service.SkipLockedError
+	if errors.As(err, &locked) {
+		writeJSON(w, http.StatusConflict, map[string]interface{}{
+			"error":            locked.Error(),
+			"code":             "skip_locked",
+			"questionKey":      locked.QuestionKey,
+			"triesRemaining":   locked.TriesRemaining,
+			"secondsRemaining": locked.SecondsRemaining,
+		})
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -118,3 +227,75 @@ func (h *PlayerHandler) Skip(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, map[string]interface{}{"done": false, "nextQuestion": nextQuestion})
 }
+
+// RateFollowUpRequest is the body of a follow-up relevance rating
+type RateFollowUpRequest struct {
+	Relevant *bool `json:"relevant"`
+}
+
+// RateFollowUp handles POST /v1/rooms/{code}/questions/{questionKey}/rating
+func (h *PlayerHandler) RateFollowUp(w http.ResponseWriter, r *http.Request) {
+	roomCode := middleware.GetRoomCode(r.Context())
+	playerID := middleware.GetPlayerID(r.Context())
+	questionKey := mux.Vars(r)["questionKey"]
+
+	var req RateFollowUpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Relevant == nil {
+		writeError(w, http.StatusBadRequest, "relevant (true or false) is required")
+		return
+	}
+
+	err := h.answerSvc.RateFollowUp(r.Context(), roomCode, playerID, questionKey, *req.Relevant)
+	switch {
+	case errors.Is(err, service.ErrPlayerLeft):
+		writeError(w, http.StatusGone, err.Error())
+		return
+	case errors.Is(err, service.ErrNotFollowUp):
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, service.ErrAlreadyRated):
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "rated"})
+}
+
+// Leave handles POST /v1/rooms/{code}/leave
+func (h *PlayerHandler) Leave(w http.ResponseWriter, r *http.Request) {
+	roomCode := middleware.GetRoomCode(r.Context())
+	playerID := middleware.GetPlayerID(r.Context())
+
+	result, err := h.answerSvc.LeaveRoom(r.Context(), roomCode, playerID, model.LeaveReasonLeft)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if result == nil {
+		writeError(w, http.StatusNotFound, "player not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// Standing handles GET /v1/rooms/{code}/leaderboard/me
+func (h *PlayerHandler) Standing(w http.ResponseWriter, r *http.Request) {
+	roomCode := middleware.GetRoomCode(r.Context())
+	playerID := middleware.GetPlayerID(r.Context())
+
+	standing, err := h.playerSvc.GetStanding(r.Context(), roomCode, playerID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if standing == nil {
+		writeError(w, http.StatusNotFound, "player is not on the leaderboard")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, standing)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// PollHandler handles live poll endpoints
+type PollHandler struct {
+	pollSvc *service.PollService
+}
+
+// NewPollHandler creates a new poll handler
+func NewPollHandler(pollSvc *service.PollService) *PollHandler {
+	return &PollHandler{pollSvc: pollSvc}
+}
+
+// Launch handles POST /v1/rooms/{code}/polls
+func (h *PollHandler) Launch(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req model.LaunchPollRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	poll, err := h.pollSvc.Launch(r.Context(), code, hostID, &req)
+	switch {
+	case errors.Is(err, service.ErrNotRoomHost):
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	case errors.Is(err, service.ErrInvalidPoll):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, service.ErrPollOpen):
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	case poll == nil:
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, poll)
+}
+
+// List handles GET /v1/rooms/{code}/polls
+func (h *PollHandler) List(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	polls, err := h.pollSvc.List(r.Context(), code, hostID)
+	if errors.Is(err, service.ErrNotRoomHost) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"polls": polls})
+}
+
+// Vote handles POST /v1/rooms/{code}/polls/{pollId}/vote
+func (h *PollHandler) Vote(w http.ResponseWriter, r *http.Request) {
+	roomCode := middleware.GetRoomCode(r.Context())
+	playerID := middleware.GetPlayerID(r.Context())
+	pollID := mux.Vars(r)["pollId"]
+
+	var req model.PollVoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	err := h.pollSvc.Vote(r.Context(), roomCode, playerID, pollID, &req)
+	switch {
+	case errors.Is(err, service.ErrPlayerLeft):
+		writeError(w, http.StatusGone, err.Error())
+		return
+	case errors.Is(err, service.ErrInvalidPoll):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, service.ErrPollClosed), errors.Is(err, service.ErrAlreadyVoted):
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "voted"})
+}
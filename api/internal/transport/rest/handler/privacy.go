@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// PrivacyHandler handles player data access and deletion requests
+type PrivacyHandler struct {
+	privacySvc *service.PrivacyService
+}
+
+// NewPrivacyHandler creates a new privacy handler
+func NewPrivacyHandler(privacySvc *service.PrivacyService) *PrivacyHandler {
+	return &PrivacyHandler{privacySvc: privacySvc}
+}
+
+// ExportPlayerData handles GET /v1/rooms/{code}/players/{playerId}/data
+func (h *PrivacyHandler) ExportPlayerData(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	export, err := h.privacySvc.ExportPlayerData(r.Context(), hostID, vars["code"], vars["playerId"])
+	if errors.Is(err, service.ErrNotRoomHost) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if export == nil {
+		writeError(w, http.StatusNotFound, "no data found for player")
+		return
+	}
+
+	w.Header().Set("Content-Disposition", "attachment; filename=\"player-"+vars["playerId"]+".json\"")
+	writeJSON(w, http.StatusOK, export)
+}
+
+// DeletePlayerData handles DELETE /v1/rooms/{code}/players/{playerId}/data
+func (h *PrivacyHandler) DeletePlayerData(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	result, err := h.privacySvc.DeletePlayerData(r.Context(), hostID, vars["code"], vars["playerId"])
+	if errors.Is(err, service.ErrNotRoomHost) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if result == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
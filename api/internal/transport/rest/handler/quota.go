@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// QuotaHandler handles screening quota endpoints
+type QuotaHandler struct {
+	quotaSvc *service.QuotaService
+}
+
+// NewQuotaHandler creates a new quota handler
+func NewQuotaHandler(quotaSvc *service.QuotaService) *QuotaHandler {
+	return &QuotaHandler{quotaSvc: quotaSvc}
+}
+
+// GetStatus handles GET /v1/rooms/{code}/quotas
+func (h *QuotaHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	status, err := h.quotaSvc.GetStatus(r.Context(), code, hostID)
+	if errors.Is(err, service.ErrNotRoomHost) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if status == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, status)
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// ReplayHandler handles what-if replays of finished rooms
+type ReplayHandler struct {
+	replaySvc *service.ReplayService
+}
+
+// NewReplayHandler creates a new replay handler
+func NewReplayHandler(replaySvc *service.ReplayService) *ReplayHandler {
+	return &ReplayHandler{replaySvc: replaySvc}
+}
+
+// Replay handles POST /v1/reports/{roomCode}/replay
+func (h *ReplayHandler) Replay(w http.ResponseWriter, r *http.Request) {
+	roomCode := mux.Vars(r)["roomCode"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req model.ReplayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	report, err := h.replaySvc.Replay(r.Context(), roomCode, hostID, &req)
+	switch {
+	case errors.Is(err, service.ErrNotRoomHost):
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	case errors.Is(err, service.ErrInvalidReplay):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if report == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
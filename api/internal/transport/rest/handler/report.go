@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"2026champs/internal/model"
 	"2026champs/internal/service"
 	"2026champs/internal/transport/rest/middleware"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -40,6 +44,72 @@ func (h *ReportHandler) GetSnapshot(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, snapshot)
 }
 
+// GetFunnel handles GET /v1/reports/{roomCode}/funnel
+func (h *ReportHandler) GetFunnel(w http.ResponseWriter, r *http.Request) {
+	roomCode := mux.Vars(r)["roomCode"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	funnel, err := h.reportSvc.GetFunnel(r.Context(), roomCode)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if funnel == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, funnel)
+}
+
+// GetEvalQuality handles GET /v1/reports/{roomCode}/eval-quality
+func (h *ReportHandler) GetEvalQuality(w http.ResponseWriter, r *http.Request) {
+	roomCode := mux.Vars(r)["roomCode"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	report, err := h.reportSvc.GetEvalQuality(r.Context(), roomCode)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if report == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+// GetUsage handles GET /v1/reports/{roomCode}/usage
+func (h *ReportHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	roomCode := mux.Vars(r)["roomCode"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	usage, err := h.reportSvc.GetUsage(r.Context(), roomCode)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if usage == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, usage)
+}
+
 // GetAIReport handles GET /v1/reports/{roomCode}/ai
 func (h *ReportHandler) GetAIReport(w http.ResponseWriter, r *http.Request) {
 	roomCode := mux.Vars(r)["roomCode"]
@@ -78,3 +148,56 @@ func (h *ReportHandler) GenerateAIReport(w http.ResponseWriter, r *http.Request)
 
 	writeJSON(w, http.StatusAccepted, map[string]string{"status": "generating"})
 }
+
+// CreateShareLink handles POST /v1/reports/{roomCode}/share
+func (h *ReportHandler) CreateShareLink(w http.ResponseWriter, r *http.Request) {
+	roomCode := mux.Vars(r)["roomCode"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// The body is optional; an empty one uses the default expiry
+	var req model.ShareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	link, err := h.reportSvc.CreateShareLink(r.Context(), roomCode, hostID, req)
+	switch {
+	case errors.Is(err, service.ErrNotRoomHost):
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	case errors.Is(err, service.ErrShareNotReady):
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if link == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, link)
+}
+
+// GetSharedReport handles GET /v1/shared/{token}
+func (h *ReportHandler) GetSharedReport(w http.ResponseWriter, r *http.Request) {
+	token := mux.Vars(r)["token"]
+
+	report, err := h.reportSvc.GetSharedReport(r.Context(), token)
+	if errors.Is(err, service.ErrInvalidShareLink) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
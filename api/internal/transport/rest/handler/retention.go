@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"2026champs/internal/service"
+	"net/http"
+)
+
+// RetentionHandler exposes the data retention policy
+type RetentionHandler struct {
+	retentionSvc *service.RetentionService
+}
+
+// NewRetentionHandler creates a new retention handler
+func NewRetentionHandler(retentionSvc *service.RetentionService) *RetentionHandler {
+	return &RetentionHandler{retentionSvc: retentionSvc}
+}
+
+// Preview handles GET /v1/admin/retention - a dry run of the purge job
+func (h *RetentionHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.retentionSvc.Purge(r.Context(), true))
+}
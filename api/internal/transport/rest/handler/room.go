@@ -6,6 +6,8 @@ import (
 	"2026champs/internal/service"
 	"2026champs/internal/transport/rest/middleware"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -16,14 +18,16 @@ import (
 type RoomHandler struct {
 	roomSvc     *service.RoomService
 	playerSvc   *service.PlayerService
+	answerSvc   *service.AnswerService
 	leaderboard cache.LeaderboardCache
 }
 
 // NewRoomHandler creates a new room handler
-func NewRoomHandler(roomSvc *service.RoomService, playerSvc *service.PlayerService, leaderboard cache.LeaderboardCache) *RoomHandler {
+func NewRoomHandler(roomSvc *service.RoomService, playerSvc *service.PlayerService, answerSvc *service.AnswerService, leaderboard cache.LeaderboardCache) *RoomHandler {
 	return &RoomHandler{
 		roomSvc:     roomSvc,
 		playerSvc:   playerSvc,
+		answerSvc:   answerSvc,
 		leaderboard: leaderboard,
 	}
 }
@@ -32,6 +36,9 @@ func NewRoomHandler(roomSvc *service.RoomService, playerSvc *service.PlayerServi
 type CreateRoomRequest struct {
 	SurveyID         string              `json:"surveyId"`
 	SettingsOverride *model.RoomSettings `json:"settingsOverride,omitempty"`
+	HostNotes        string              `json:"hostNotes,omitempty"`
+	ExperimentID     string              `json:"experimentId,omitempty"` // Run the room as part of an A/B experiment
+	Branding         *model.Branding     `json:"branding,omitempty"`
 }
 
 // Create handles POST /v1/rooms
@@ -53,7 +60,11 @@ func (h *RoomHandler) Create(w http.ResponseWriter, r *http.Request) {
 		settings = req.SettingsOverride
 	}
 
-	room, err := h.roomSvc.CreateRoom(r.Context(), req.SurveyID, hostID, settings)
+	room, err := h.roomSvc.CreateRoom(r.Context(), req.SurveyID, hostID, settings, req.HostNotes, req.ExperimentID, req.Branding)
+	if errors.Is(err, service.ErrInvalidExperiment) || errors.Is(err, service.ErrInvalidRoomSettings) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -82,6 +93,23 @@ func (h *RoomHandler) Get(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, room)
 }
 
+// Branding handles GET /v1/rooms/{code}/branding (public, so the player UI can skin itself before joining)
+func (h *RoomHandler) Branding(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+
+	branding, err := h.roomSvc.GetBranding(r.Context(), code)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if branding == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, branding)
+}
+
 // Start handles POST /v1/rooms/{code}/start
 func (h *RoomHandler) Start(w http.ResponseWriter, r *http.Request) {
 	code := mux.Vars(r)["code"]
@@ -129,6 +157,11 @@ func (h *RoomHandler) Join(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp, err := h.playerSvc.JoinRoom(r.Context(), code, req.Nickname)
+	if errors.Is(err, service.ErrDraining) {
+		w.Header().Set("Retry-After", "5")
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
@@ -157,3 +190,146 @@ func (h *RoomHandler) Leaderboard(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, map[string]interface{}{"leaderboard": entries})
 }
+
+// Players handles GET /v1/rooms/{code}/players
+func (h *RoomHandler) Players(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+	hostID := middleware.GetHostID(r.Context())
+
+	roster, err := h.playerSvc.GetRoster(r.Context(), code, hostID)
+	if errors.Is(err, service.ErrNotRoomHost) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if roster == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, roster)
+}
+
+// JoinInfo handles GET /v1/rooms/{code}/join-info?format=json|png|svg&locale=&scale=
+func (h *RoomHandler) JoinInfo(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+	hostID := middleware.GetHostID(r.Context())
+	query := r.URL.Query()
+
+	info, qr, err := h.roomSvc.GetJoinInfo(r.Context(), code, hostID, query.Get("locale"))
+	if errors.Is(err, service.ErrNotRoomHost) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if info == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	switch query.Get("format") {
+	case "png":
+		scale := 10
+		if n, err := strconv.Atoi(query.Get("scale")); err == nil && n > 0 && n <= 40 {
+			scale = n
+		}
+		png, err := qr.PNG(scale)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(png)
+	case "svg":
+		w.Header().Set("Content-Type", "image/svg+xml")
+		w.Write([]byte(info.QRSVG))
+	default:
+		writeJSON(w, http.StatusOK, info)
+	}
+}
+
+// OverrideAnswer handles POST /v1/rooms/{code}/answers/{answerId}/override
+func (h *RoomHandler) OverrideAnswer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req model.OverrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	resp, err := h.answerSvc.OverrideEvaluation(r.Context(), vars["code"], hostID, vars["answerId"], &req)
+	if errors.Is(err, service.ErrNotRoomHost) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if resp == nil {
+		writeError(w, http.StatusNotFound, "answer not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// StarAnswer handles POST /v1/rooms/{code}/answers/{answerId}/star; the body with a note is optional
+func (h *RoomHandler) StarAnswer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req model.StarRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	answer, err := h.answerSvc.StarAnswer(r.Context(), vars["code"], hostID, vars["answerId"], &req)
+	h.writeStarResult(w, answer, err)
+}
+
+// UnstarAnswer handles DELETE /v1/rooms/{code}/answers/{answerId}/star
+func (h *RoomHandler) UnstarAnswer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	answer, err := h.answerSvc.UnstarAnswer(r.Context(), vars["code"], hostID, vars["answerId"])
+	h.writeStarResult(w, answer, err)
+}
+
+func (h *RoomHandler) writeStarResult(w http.ResponseWriter, answer *model.Answer, err error) {
+	if errors.Is(err, service.ErrNotRoomHost) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if answer == nil {
+		writeError(w, http.StatusNotFound, "answer not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, answer)
+}
@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"2026champs/internal/model"
 	"2026champs/internal/service"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 )
@@ -59,6 +62,68 @@ func (h *SMHandler) CreateCollector(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// ListCollectors handles GET /v1/sm/surveys/{surveyId}/collectors
+func (h *SMHandler) ListCollectors(w http.ResponseWriter, r *http.Request) {
+	surveyID := mux.Vars(r)["surveyId"]
+	if surveyID == "" {
+		writeError(w, http.StatusBadRequest, "surveyId is required")
+		return
+	}
+
+	collectors, err := h.syncSvc.ListCollectors(r.Context(), surveyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"surveyId":   surveyID,
+		"collectors": collectors,
+	})
+}
+
+// CloseCollector handles POST /v1/sm/collectors/{collectorId}/close
+func (h *SMHandler) CloseCollector(w http.ResponseWriter, r *http.Request) {
+	h.setCollectorStatus(w, r, model.SMCollectorClosed)
+}
+
+// ReopenCollector handles POST /v1/sm/collectors/{collectorId}/reopen
+func (h *SMHandler) ReopenCollector(w http.ResponseWriter, r *http.Request) {
+	h.setCollectorStatus(w, r, model.SMCollectorOpen)
+}
+
+func (h *SMHandler) setCollectorStatus(w http.ResponseWriter, r *http.Request, status string) {
+	collectorID := mux.Vars(r)["collectorId"]
+	if collectorID == "" {
+		writeError(w, http.StatusBadRequest, "collectorId is required")
+		return
+	}
+
+	collector, err := h.syncSvc.SetCollectorStatus(r.Context(), collectorID, status)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, collector)
+}
+
+// DeleteCollector handles DELETE /v1/sm/collectors/{collectorId}
+func (h *SMHandler) DeleteCollector(w http.ResponseWriter, r *http.Request) {
+	collectorID := mux.Vars(r)["collectorId"]
+	if collectorID == "" {
+		writeError(w, http.StatusBadRequest, "collectorId is required")
+		return
+	}
+
+	if err := h.syncSvc.DeleteCollector(r.Context(), collectorID); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
 // CreateSurveyFromInternalRequest is the request body for creating SM survey from internal survey
 type CreateSurveyFromInternalRequest struct {
 	SurveyID                 string   `json:"surveyId"`
@@ -136,7 +201,7 @@ func (h *SMHandler) CreateSurveyFromInternal(w http.ResponseWriter, r *http.Requ
 	})
 }
 
-// Sync handles POST /v1/sm/surveys/{surveyId}/sync
+// Sync handles POST /v1/sm/surveys/{surveyId}/sync?dryRun=true
 func (h *SMHandler) Sync(w http.ResponseWriter, r *http.Request) {
 	surveyID := mux.Vars(r)["surveyId"]
 	if surveyID == "" {
@@ -144,7 +209,21 @@ func (h *SMHandler) Sync(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := h.syncSvc.Sync(r.Context(), surveyID)
+	if dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun")); dryRun {
+		report, err := h.syncSvc.DryRunSync(r.Context(), surveyID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, report)
+		return
+	}
+
+	result, err := h.syncSvc.SyncTracked(r.Context(), surveyID)
+	if errors.Is(err, service.ErrSMSyncInProgress) {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -153,6 +232,52 @@ func (h *SMHandler) Sync(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, result)
 }
 
+// SetSchedule handles PUT /v1/sm/surveys/{surveyId}/schedule
+func (h *SMHandler) SetSchedule(w http.ResponseWriter, r *http.Request) {
+	surveyID := mux.Vars(r)["surveyId"]
+	if surveyID == "" {
+		writeError(w, http.StatusBadRequest, "surveyId is required")
+		return
+	}
+
+	var req model.SMScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	schedule, err := h.syncSvc.SetSchedule(r.Context(), surveyID, &req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, schedule)
+}
+
+// DeleteSchedule handles DELETE /v1/sm/surveys/{surveyId}/schedule
+func (h *SMHandler) DeleteSchedule(w http.ResponseWriter, r *http.Request) {
+	surveyID := mux.Vars(r)["surveyId"]
+	if err := h.syncSvc.DeleteSchedule(r.Context(), surveyID); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// SyncStatus handles GET /v1/sm/surveys/{surveyId}/sync/status
+func (h *SMHandler) SyncStatus(w http.ResponseWriter, r *http.Request) {
+	surveyID := mux.Vars(r)["surveyId"]
+	status, err := h.syncSvc.GetSyncStatus(r.Context(), surveyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, status)
+}
+
 // Summary handles GET /v1/sm/surveys/{surveyId}/summary
 func (h *SMHandler) Summary(w http.ResponseWriter, r *http.Request) {
 	surveyID := mux.Vars(r)["surveyId"]
@@ -5,6 +5,7 @@ import (
 	"2026champs/internal/service"
 	"2026champs/internal/transport/rest/middleware"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -30,11 +31,13 @@ type CreateSurveyRequest struct {
 	Intent    string               `json:"intent"`
 	Settings  model.SurveySettings `json:"settings"`
 	Questions []model.BaseQuestion `json:"questions"`
+	Branching []model.BranchRule   `json:"branching,omitempty"`
 }
 
 // GenerateInsightsRequest is the request body for generating questions
 type GenerateInsightsRequest struct {
-	Intent string `json:"intent"`
+	Intent   string `json:"intent"`
+	SurveyID string `json:"surveyId,omitempty"` // Only mine rooms run from this survey
 }
 
 // GenerateFromInsights handles POST /v1/surveys/generate-from-insights
@@ -51,13 +54,17 @@ func (h *SurveyHandler) GenerateFromInsights(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	questions, err := h.insightSvc.GenerateQuestionsFromInsights(r.Context(), hostID, req.Intent)
+	result, err := h.insightSvc.GenerateQuestionsFromInsights(r.Context(), hostID, req.Intent, req.SurveyID)
+	if errors.Is(err, service.ErrInvalidInsightRequest) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]interface{}{"questions": questions})
+	writeJSON(w, http.StatusOK, result)
 }
 
 // Create handles POST /v1/surveys
@@ -87,9 +94,14 @@ func (h *SurveyHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Intent:    req.Intent,
 		Settings:  req.Settings,
 		Questions: req.Questions,
+		Branching: req.Branching,
 	}
 
 	id, err := h.surveySvc.Create(r.Context(), survey)
+	if errors.Is(err, service.ErrInvalidSurvey) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -127,9 +139,15 @@ func (h *SurveyHandler) Update(w http.ResponseWriter, r *http.Request) {
 		Intent:    req.Intent,
 		Settings:  req.Settings,
 		Questions: req.Questions,
+		Branching: req.Branching,
 	}
 
-	if err := h.surveySvc.Update(r.Context(), survey); err != nil {
+	err := h.surveySvc.Update(r.Context(), survey)
+	if errors.Is(err, service.ErrInvalidSurvey) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -170,3 +188,132 @@ func (h *SurveyHandler) List(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, map[string]interface{}{"surveys": surveys})
 }
+
+// Delete handles DELETE /v1/surveys/{surveyId}
+func (h *SurveyHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	surveyID := mux.Vars(r)["surveyId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	survey, err := h.surveySvc.Delete(r.Context(), surveyID, hostID)
+	if errors.Is(err, service.ErrSurveyInUse) {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if survey == nil {
+		writeError(w, http.StatusNotFound, "survey not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, survey)
+}
+
+// Restore handles POST /v1/surveys/{surveyId}/restore
+func (h *SurveyHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	surveyID := mux.Vars(r)["surveyId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	survey, err := h.surveySvc.Restore(r.Context(), surveyID, hostID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if survey == nil {
+		writeError(w, http.StatusNotFound, "survey not in trash")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, survey)
+}
+
+// Trash handles GET /v1/surveys/trash
+func (h *SurveyHandler) Trash(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	surveys, err := h.surveySvc.ListTrash(r.Context(), hostID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"surveys": surveys})
+}
+
+// SetThemeTaxonomy handles PUT /v1/surveys/{surveyId}/theme-taxonomy
+func (h *SurveyHandler) SetThemeTaxonomy(w http.ResponseWriter, r *http.Request) {
+	surveyID := mux.Vars(r)["surveyId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var taxonomy model.ThemeTaxonomy
+	if err := json.NewDecoder(r.Body).Decode(&taxonomy); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	survey, err := h.surveySvc.SetThemeTaxonomy(r.Context(), surveyID, hostID, &taxonomy)
+	if errors.Is(err, service.ErrInvalidSurvey) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if survey == nil {
+		writeError(w, http.StatusNotFound, "survey not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, survey)
+}
+
+// TestEval handles POST /v1/surveys/{surveyId}/questions/{questionKey}/test-eval
+func (h *SurveyHandler) TestEval(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req model.TestEvalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	resp, err := h.surveySvc.TestEvaluate(r.Context(), vars["surveyId"], hostID, vars["questionKey"], &req)
+	if errors.Is(err, service.ErrInvalidSurvey) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if resp == nil {
+		writeError(w, http.StatusNotFound, "question not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// TemplateHandler handles room template endpoints
+type TemplateHandler struct {
+	templateSvc *service.TemplateService
+}
+
+// NewTemplateHandler creates a new template handler
+func NewTemplateHandler(templateSvc *service.TemplateService) *TemplateHandler {
+	return &TemplateHandler{templateSvc: templateSvc}
+}
+
+// Create handles POST /v1/templates
+func (h *TemplateHandler) Create(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req model.RoomTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	template, err := h.templateSvc.Create(r.Context(), hostID, &req)
+	if errors.Is(err, service.ErrInvalidTemplate) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, template)
+}
+
+// List handles GET /v1/templates
+func (h *TemplateHandler) List(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	templates, err := h.templateSvc.List(r.Context(), hostID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, templates)
+}
+
+// Get handles GET /v1/templates/{templateId}
+func (h *TemplateHandler) Get(w http.ResponseWriter, r *http.Request) {
+	templateID := mux.Vars(r)["templateId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	template, err := h.templateSvc.Get(r.Context(), hostID, templateID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if template == nil {
+		writeError(w, http.StatusNotFound, "template not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, template)
+}
+
+// Replace handles PUT /v1/templates/{templateId}
+func (h *TemplateHandler) Replace(w http.ResponseWriter, r *http.Request) {
+	templateID := mux.Vars(r)["templateId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req model.RoomTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	template, err := h.templateSvc.Replace(r.Context(), hostID, templateID, &req)
+	if errors.Is(err, service.ErrInvalidTemplate) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if template == nil {
+		writeError(w, http.StatusNotFound, "template not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, template)
+}
+
+// Delete handles DELETE /v1/templates/{templateId}
+func (h *TemplateHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	templateID := mux.Vars(r)["templateId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	deleted, err := h.templateSvc.Delete(r.Context(), hostID, templateID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if !deleted {
+		writeError(w, http.StatusNotFound, "template not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// CreateRoom handles POST /v1/rooms/from-template/{templateId}
+func (h *TemplateHandler) CreateRoom(w http.ResponseWriter, r *http.Request) {
+	templateID := mux.Vars(r)["templateId"]
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	room, err := h.templateSvc.CreateRoom(r.Context(), hostID, templateID)
+	if errors.Is(err, service.ErrInvalidRoomSettings) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if room == nil {
+		writeError(w, http.StatusNotFound, "template not found")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]string{
+		"roomCode":   room.Code,
+		"roomId":     room.Code,
+		"templateId": templateID,
+	})
+}
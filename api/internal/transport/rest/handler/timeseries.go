@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// TimeseriesHandler serves a room's per-minute participation curve
+type TimeseriesHandler struct {
+	timeseriesSvc *service.TimeseriesService
+}
+
+// NewTimeseriesHandler creates a new timeseries handler
+func NewTimeseriesHandler(timeseriesSvc *service.TimeseriesService) *TimeseriesHandler {
+	return &TimeseriesHandler{timeseriesSvc: timeseriesSvc}
+}
+
+// Get handles GET /v1/rooms/{code}/metrics/timeseries
+func (h *TimeseriesHandler) Get(w http.ResponseWriter, r *http.Request) {
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	series, err := h.timeseriesSvc.Get(r.Context(), mux.Vars(r)["code"], hostID)
+	if errors.Is(err, service.ErrNotRoomHost) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if series == nil {
+		writeError(w, http.StatusNotFound, "room not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, series)
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rest/middleware"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// VoiceHandler handles recorded answer endpoints
+type VoiceHandler struct {
+	voiceSvc *service.VoiceService
+}
+
+// NewVoiceHandler creates a new voice handler
+func NewVoiceHandler(voiceSvc *service.VoiceService) *VoiceHandler {
+	return &VoiceHandler{voiceSvc: voiceSvc}
+}
+
+// Upload handles POST /v1/rooms/{code}/questions/{questionKey}/voice (multipart field "audio")
+func (h *VoiceHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	roomCode := middleware.GetRoomCode(r.Context())
+	playerID := middleware.GetPlayerID(r.Context())
+	questionKey := mux.Vars(r)["questionKey"]
+
+	r.Body = http.MaxBytesReader(w, r.Body, model.MaxVoiceBytes+64<<10)
+	file, _, err := r.FormFile("audio")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, service.ErrVoiceTooLarge.Error())
+			return
+		}
+		writeError(w, http.StatusBadRequest, "expected a multipart upload with an \"audio\" field")
+		return
+	}
+	defer file.Close()
+
+	clip, err := h.voiceSvc.Upload(r.Context(), roomCode, playerID, questionKey, file)
+	switch {
+	case errors.Is(err, service.ErrVoiceTooLarge):
+		writeError(w, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	case errors.Is(err, service.ErrInvalidVoice):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, service.ErrTranscriptionFailed):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, clip)
+}
+
+// Download handles GET /v1/rooms/{code}/voice/{clipId}
+func (h *VoiceHandler) Download(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	hostID := middleware.GetHostID(r.Context())
+	if hostID == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	clip, body, err := h.voiceSvc.Open(r.Context(), vars["code"], hostID, vars["clipId"])
+	switch {
+	case errors.Is(err, service.ErrNotRoomHost):
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	case errors.Is(err, service.ErrAttachmentNotFound):
+		writeError(w, http.StatusNotFound, "voice clip not found")
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", clip.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(clip.Size, 10))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, body)
+}
@@ -1,10 +1,13 @@
 package middleware
 
 import (
+	"2026champs/internal/model"
 	"2026champs/internal/service"
 	"context"
 	"net/http"
 	"strings"
+
+	"github.com/gorilla/mux"
 )
 
 type contextKey string
@@ -13,11 +16,16 @@ const (
 	HostIDKey   contextKey = "hostId"
 	PlayerIDKey contextKey = "playerId"
 	RoomCodeKey contextKey = "roomCode"
+	APIKeyIDKey contextKey = "apiKeyId"
 )
 
+// APIKeyHeader carries a host API key as an alternative to a bearer JWT
+const APIKeyHeader = "X-API-Key"
+
 // AuthMiddleware provides JWT authentication middleware
 type AuthMiddleware struct {
-	authSvc *service.AuthService
+	authSvc   *service.AuthService
+	apiKeySvc *service.APIKeyService // optional
 }
 
 // NewAuthMiddleware creates a new auth middleware
@@ -25,10 +33,19 @@ func NewAuthMiddleware(authSvc *service.AuthService) *AuthMiddleware {
 	return &AuthMiddleware{authSvc: authSvc}
 }
 
-// RequireHost validates host JWT from Authorization header
+// SetAPIKeyService enables X-API-Key authentication on host routes
+func (m *AuthMiddleware) SetAPIKeyService(apiKeySvc *service.APIKeyService) {
+	m.apiKeySvc = apiKeySvc
+}
+
+// RequireHost validates host JWT from Authorization header, or an API key from X-API-Key
 func (m *AuthMiddleware) RequireHost(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		token := extractBearerToken(r)
+		if token == "" && m.apiKeySvc != nil && r.Header.Get(APIKeyHeader) != "" {
+			m.serveWithAPIKey(w, r, next)
+			return
+		}
 		if token == "" {
 			http.Error(w, `{"error":"missing authorization header"}`, http.StatusUnauthorized)
 			return
@@ -45,6 +62,62 @@ func (m *AuthMiddleware) RequireHost(next http.Handler) http.Handler {
 	})
 }
 
+// serveWithAPIKey authenticates an API key and checks it grants the route's scope
+func (m *AuthMiddleware) serveWithAPIKey(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	key, err := m.apiKeySvc.Validate(r.Context(), r.Header.Get(APIKeyHeader))
+	if err != nil {
+		http.Error(w, `{"error":"invalid or revoked API key"}`, http.StatusUnauthorized)
+		return
+	}
+
+	scope := requiredScope(r)
+	if scope == "" {
+		http.Error(w, `{"error":"endpoint not available to API keys"}`, http.StatusForbidden)
+		return
+	}
+	if !key.HasScope(scope) {
+		http.Error(w, `{"error":"API key missing scope `+scope+`"}`, http.StatusForbidden)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), HostIDKey, key.HostID)
+	ctx = context.WithValue(ctx, APIKeyIDKey, key.ID)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// requiredScope maps a matched route to the API key scope it needs.
+// Routes that return "" (key management, admin, player data) require a JWT.
+func requiredScope(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return ""
+	}
+	tmpl, err := route.GetPathTemplate()
+	if err != nil {
+		return ""
+	}
+
+	read := r.Method == http.MethodGet || r.Method == http.MethodOptions
+	pick := func(readScope, writeScope string) string {
+		if read {
+			return readScope
+		}
+		return writeScope
+	}
+
+	switch {
+	case strings.HasSuffix(tmpl, "/players/{playerId}/data"):
+		return ""
+	case strings.HasPrefix(tmpl, "/v1/rooms"):
+		return pick(model.ScopeRoomsRead, model.ScopeRoomsWrite)
+	case strings.HasPrefix(tmpl, "/v1/reports"):
+		return pick(model.ScopeReportsRead, model.ScopeReportsWrite)
+	case strings.HasPrefix(tmpl, "/v1/surveys"), strings.HasPrefix(tmpl, "/v1/sm/"):
+		return pick(model.ScopeSurveysRead, model.ScopeSurveysWrite)
+	}
+	return ""
+}
+
 // RequirePlayer validates player JWT from Authorization header or query param
 func (m *AuthMiddleware) RequirePlayer(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -87,6 +160,14 @@ func GetPlayerID(ctx context.Context) string {
 	return ""
 }
 
+// GetAPIKeyID returns the API key used for the request, or "" for JWT requests
+func GetAPIKeyID(ctx context.Context) string {
+	if v := ctx.Value(APIKeyIDKey); v != nil {
+		return v.(string)
+	}
+	return ""
+}
+
 // GetRoomCode extracts room code from context
 func GetRoomCode(ctx context.Context) string {
 	if v := ctx.Value(RoomCodeKey); v != nil {
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"2026champs/internal/service"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// PlanMiddleware rejects requests that would take a host past their plan's limits
+type PlanMiddleware struct {
+	planSvc *service.PlanService
+}
+
+// NewPlanMiddleware creates a new plan middleware
+func NewPlanMiddleware(planSvc *service.PlanService) *PlanMiddleware {
+	return &PlanMiddleware{planSvc: planSvc}
+}
+
+// LimitSurveys guards host routes that add a survey
+func (m *PlanMiddleware) LimitSurveys(next http.Handler) http.Handler {
+	return m.guard(next, func(r *http.Request) error {
+		return m.planSvc.CheckSurveys(r.Context(), GetHostID(r.Context()))
+	})
+}
+
+// LimitRooms guards host routes that open a room
+func (m *PlanMiddleware) LimitRooms(next http.Handler) http.Handler {
+	return m.guard(next, func(r *http.Request) error {
+		return m.planSvc.CheckRooms(r.Context(), GetHostID(r.Context()))
+	})
+}
+
+// LimitPlayers guards the player join route of the room in {code}
+func (m *PlanMiddleware) LimitPlayers(next http.Handler) http.Handler {
+	return m.guard(next, func(r *http.Request) error {
+		return m.planSvc.CheckPlayers(r.Context(), mux.Vars(r)["code"])
+	})
+}
+
+// guard answers 403 with the limit that was hit. Other errors let the request
+// through: an outage of the usage counters must not take room creation down.
+func (m *PlanMiddleware) guard(next http.Handler, check func(r *http.Request) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := check(r)
+		var limitErr *service.PlanLimitError
+		if errors.As(err, &limitErr) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error":    limitErr.Error(),
+				"code":     "plan_limit_exceeded",
+				"plan":     limitErr.Plan,
+				"resource": limitErr.Resource,
+				"limit":    limitErr.Limit,
+				"used":     limitErr.Used,
+			})
+			return
+		}
+		if err != nil {
+			log.Printf("Plan check failed for %s: %v", r.URL.Path, err)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"2026champs/internal/model"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// errorCodes names the error code of a status when the handler gave none
+var errorCodes = map[int]string{
+	http.StatusBadRequest:            "invalid_request",
+	http.StatusUnauthorized:          "unauthorized",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not_found",
+	http.StatusMethodNotAllowed:      "method_not_allowed",
+	http.StatusConflict:              "conflict",
+	http.StatusGone:                  "gone",
+	http.StatusPreconditionFailed:    "precondition_failed",
+	http.StatusRequestEntityTooLarge: "too_large",
+	http.StatusUnprocessableEntity:   "unprocessable",
+	http.StatusTooManyRequests:       "rate_limited",
+	http.StatusServiceUnavailable:    "unavailable",
+}
+
+// ErrorCode returns the typed error code for an HTTP status
+func ErrorCode(status int) string {
+	if code, ok := errorCodes[status]; ok {
+		return code
+	}
+	if status >= 500 {
+		return "internal"
+	}
+	return "error"
+}
+
+// TypedErrors rewrites the {error, code?, ...} bodies of v1 handlers, and the
+// plain-text errors of the router, into the v2 model.TypedError shape;
+// successful responses pass through untouched
+func TypedErrors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tw := &typedErrorWriter{ResponseWriter: w}
+		next.ServeHTTP(tw, r)
+		tw.finish()
+	})
+}
+
+// typedErrorWriter buffers error responses so finish can rewrite them
+type typedErrorWriter struct {
+	http.ResponseWriter
+	status    int
+	buffering bool
+	body      bytes.Buffer
+}
+
+func (w *typedErrorWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = status
+	if status >= 400 {
+		w.buffering = true
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *typedErrorWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.buffering {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush keeps SSE streams working through the shim
+func (w *typedErrorWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok && !w.buffering {
+		f.Flush()
+	}
+}
+
+// Hijack keeps WebSocket upgrades working through the shim
+func (w *typedErrorWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	return h.Hijack()
+}
+
+func (w *typedErrorWriter) finish() {
+	if !w.buffering {
+		return
+	}
+
+	detail := model.TypedErrorDetail{Code: ErrorCode(w.status), Status: w.status}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(w.body.Bytes(), &fields); err != nil {
+		detail.Message = strings.TrimSpace(w.body.String())
+	} else {
+		if msg, ok := fields["error"].(string); ok {
+			detail.Message = msg
+		}
+		if code, ok := fields["code"].(string); ok && code != "" {
+			detail.Code = code
+		}
+		delete(fields, "error")
+		delete(fields, "code")
+		if len(fields) > 0 {
+			detail.Details = fields
+		}
+	}
+	if detail.Message == "" {
+		detail.Message = http.StatusText(w.status)
+	}
+
+	body, _ := json.Marshal(model.TypedError{Error: detail})
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)+1))
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.Write(append(body, '\n'))
+}
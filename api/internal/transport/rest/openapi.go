@@ -0,0 +1,169 @@
+package rest
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/transport/gql"
+	"2026champs/internal/transport/rest/handler"
+	"2026champs/internal/transport/rest/openapi"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+)
+
+// apiInfo describes the API in the generated OpenAPI documents
+var apiInfo = openapi.Info{
+	Title:       "2026 Champs Survey API",
+	Description: "Kahoot-like async survey room system. WebSocket and SSE endpoints are described in rules/api_contracts.md.",
+}
+
+// securitySchemes are the ways host and player routes authenticate
+var securitySchemes = map[string]openapi.SecurityScheme{
+	"hostToken":   {Type: "http", Scheme: "bearer", BearerFormat: "JWT", Description: "Host JWT from POST /auth/login"},
+	"apiKey":      {Type: "apiKey", In: "header", Name: "X-API-Key", Description: "Host API key"},
+	"playerToken": {Type: "http", Scheme: "bearer", BearerFormat: "JWT", Description: "Room-scoped player token from POST /rooms/{code}/join"},
+}
+
+var (
+	hostSecurity   = []map[string][]string{{"hostToken": {}}, {"apiKey": {}}}
+	playerSecurity = []map[string][]string{{"playerToken": {}}}
+)
+
+// routeDocs gives the summary and body types of routes, keyed "METHOD /path"
+// relative to the version prefix. Routes missing here are still published.
+var routeDocs = map[string]openapi.RouteDoc{
+	"POST /auth/login":        {Summary: "Log in as a host", Request: model.LoginRequest{}, Response: model.LoginResponse{}},
+	"POST /rooms/{code}/join": {OperationID: "roomJoin", Summary: "Join a room as a player", Request: handler.JoinRequest{}, Response: model.PlayerJoinResponse{}},
+
+	"POST /surveys":                                              {OperationID: "surveyCreate", Summary: "Create a survey", Request: handler.CreateSurveyRequest{}, Status: http.StatusCreated},
+	"GET /surveys/{surveyId}":                                    {Summary: "Get a survey", Response: model.Survey{}},
+	"PUT /surveys/{surveyId}":                                    {Summary: "Replace a survey", Request: handler.CreateSurveyRequest{}, Response: model.Survey{}},
+	"POST /surveys/{surveyId}/restore":                           {OperationID: "surveyRestore", Summary: "Restore a survey from the trash", Response: model.Survey{}},
+	"POST /surveys/generate-from-insights":                       {Summary: "Draft questions from past room insights", Request: handler.GenerateInsightsRequest{}},
+	"POST /surveys/{surveyId}/questions/{questionKey}/test-eval": {Summary: "Evaluate a sample answer", Request: model.TestEvalRequest{}, Response: model.TestEvalResponse{}},
+	"POST /rooms/{code}/polls":                                   {Summary: "Launch a live poll", Request: model.LaunchPollRequest{}, Response: model.Poll{}, Status: http.StatusCreated},
+	"POST /rooms/{code}/polls/{pollId}/vote":                     {Summary: "Vote in the open live poll", Request: model.PollVoteRequest{}},
+	"PUT /surveys/{surveyId}/theme-taxonomy":                     {Summary: "Replace a survey's theme taxonomy", Request: model.ThemeTaxonomy{}, Response: model.Survey{}},
+	"GET /surveys/{surveyId}/meta-feedback":                      {Summary: "Get a survey's meta-feedback across rooms", Response: model.SurveyMetaFeedback{}},
+	"POST /rooms/{code}/meta-feedback":                           {Summary: "Answer the meta-survey", Request: model.MetaFeedbackRequest{}},
+
+	"POST /rooms":       {OperationID: "roomCreate", Summary: "Create a room", Request: handler.CreateRoomRequest{}, Status: http.StatusCreated},
+	"GET /rooms/{code}": {Summary: "Get a room", Response: model.Room{}},
+	"POST /rooms/{code}/answers/{answerId}/override": {Summary: "Override an answer's evaluation", Request: model.OverrideRequest{}, Response: model.OverrideResponse{}},
+	"POST /rooms/{code}/answers/{answerId}/star":     {Summary: "Star an answer", Request: model.StarRequest{}},
+	"POST /rooms/{code}/embed-token":                 {Summary: "Create an embed token", Request: model.EmbedTokenRequest{}},
+	"POST /reports/{roomCode}/share":                 {Summary: "Create a share link", Request: model.ShareRequest{}},
+	"POST /reports/{roomCode}/replay":                {Summary: "Replay a room under other settings", Request: model.ReplayRequest{}},
+	"POST /reports/{roomCode}/email":                 {Summary: "Email the AI report", Request: model.EmailReportRequest{}},
+	"POST /reports/compare":                          {Summary: "Compare two rooms of a survey", Request: model.ComparisonRequest{}, Response: model.ComparisonReport{}, Status: http.StatusAccepted},
+	"GET /reports/compare/{comparisonId}":            {Summary: "Get a room comparison", Response: model.ComparisonReport{}},
+	"POST /admin/answers/{answerId}/audit":           {Summary: "Audit an answer's evaluation", Request: model.AuditRequest{}},
+	"GET /admin/ai-schema":                           {Summary: "Get AI response schema failures per prompt template", Response: model.AISchemaReport{}},
+	"POST /experiments":                              {Summary: "Create an A/B experiment", Request: model.CreateExperimentRequest{}, Response: model.Experiment{}, Status: http.StatusCreated},
+	"GET /experiments/{experimentId}":                {Summary: "Get an experiment", Response: model.Experiment{}},
+	"POST /api-keys":                                 {Summary: "Create an API key", Request: model.CreateAPIKeyRequest{}, Response: model.CreateAPIKeyResponse{}, Status: http.StatusCreated},
+	"POST /templates":                                {Summary: "Create a room template", Request: model.RoomTemplateRequest{}, Response: model.RoomTemplate{}, Status: http.StatusCreated},
+	"GET /templates/{templateId}":                    {Summary: "Get a room template", Response: model.RoomTemplate{}},
+	"PUT /templates/{templateId}":                    {Summary: "Replace a room template", Request: model.RoomTemplateRequest{}, Response: model.RoomTemplate{}},
+	"POST /rooms/from-template/{templateId}":         {OperationID: "templateCreateRoom", Summary: "Create a room from a template", Status: http.StatusCreated},
+	"POST /events":                                   {Summary: "Create a multi-room event", Request: model.EventGroupRequest{}, Response: model.EventGroup{}, Status: http.StatusCreated},
+	"GET /events/{eventId}":                          {Summary: "Get an event", Response: model.EventGroup{}},
+	"POST /events/{eventId}/rooms":                   {Summary: "Add a room to an event", Request: handler.AddRoomRequest{}, Response: model.EventGroup{}},
+	"POST /integrations":                             {Summary: "Connect Slack or Teams", Request: model.CreateIntegrationRequest{}, Response: model.Integration{}, Status: http.StatusCreated},
+	"GET /digest/settings":                           {Summary: "Get the weekly digest settings", Response: model.DigestSettings{}},
+	"PUT /digest/settings":                           {Summary: "Opt in or out of the weekly digest", Request: model.DigestSettingsRequest{}, Response: model.DigestSettings{}},
+	"GET /digest/preview":                            {Summary: "Preview last week's digest", Response: model.Digest{}},
+	"GET /usage":                                     {Summary: "Get the host's plan and usage", Response: model.HostUsage{}},
+	"POST /graphql":                                  {OperationID: "graphqlQuery", Summary: "Run a dashboard GraphQL query", Request: gql.Request{}},
+	"POST /sm/surveys/from-internal":                 {Summary: "Create a SurveyMonkey survey from a survey", Request: handler.CreateSurveyFromInternalRequest{}},
+	"POST /sm/surveys/{surveyId}/collectors/weblink": {Summary: "Create a SurveyMonkey web link collector", Request: handler.CreateCollectorRequest{}},
+	"PUT /sm/surveys/{surveyId}/schedule":            {Summary: "Schedule SurveyMonkey syncs", Request: model.SMScheduleRequest{}},
+
+	"POST /rooms/{code}/answers":                        {Summary: "Submit an answer", Request: model.SubmitAnswerRequest{}, Response: model.SubmitAnswerResponse{}},
+	"PUT /rooms/{code}/questions/{questionKey}/draft":   {Summary: "Save a draft answer", Request: handler.DraftRequest{}},
+	"POST /rooms/{code}/questions/{questionKey}/rating": {Summary: "Rate a follow-up question", Request: handler.RateFollowUpRequest{}},
+}
+
+// apiSpecs builds the v1 document and the v2 one, which lists v1 routes under
+// /v2 with typed errors plus the routes registered on v2 itself
+func apiSpecs(hostRoutes, playerRoutes *mux.Router) (v1, v2 *openapi.Spec) {
+	security := map[*mux.Router][]map[string][]string{
+		hostRoutes:   hostSecurity,
+		playerRoutes: playerSecurity,
+	}
+	skip := []string{"/ws/", "/sse/"}
+
+	v1Info, v2Info := apiInfo, apiInfo
+	v1Info.Version, v2Info.Version = "1", "2"
+	v1 = &openapi.Spec{
+		Info:        v1Info,
+		Prefixes:    []string{"/v1"},
+		Security:    security,
+		Schemes:     securitySchemes,
+		Docs:        routeDocs,
+		ErrorSchema: model.APIError{},
+		Skip:        skip,
+	}
+	v2 = &openapi.Spec{
+		Info:        v2Info,
+		Prefixes:    []string{"/v1", "/v2"},
+		Server:      "/v2",
+		Security:    security,
+		Schemes:     securitySchemes,
+		Docs:        routeDocs,
+		ErrorSchema: model.TypedError{},
+		Skip:        skip,
+	}
+	return v1, v2
+}
+
+// serveSpec serves the document of spec, built from the router on first request
+// once every route is registered
+func serveSpec(r *mux.Router, spec *openapi.Spec) http.HandlerFunc {
+	var (
+		once sync.Once
+		body []byte
+		err  error
+	)
+	return func(w http.ResponseWriter, req *http.Request) {
+		once.Do(func() {
+			var doc *openapi.Document
+			if doc, err = spec.Build(r); err == nil {
+				body, err = json.Marshal(doc)
+			}
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}
+}
+
+// v1Compat serves a /v2 request with the v1 route of the same path, so v2 only
+// needs its own routes where the contract changes
+func v1Compat(r *mux.Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		v1Req := req.Clone(req.Context())
+		v1Req.URL.Path = "/v1" + strings.TrimPrefix(req.URL.Path, "/v2")
+		if req.URL.RawPath != "" {
+			v1Req.URL.RawPath = "/v1" + strings.TrimPrefix(req.URL.RawPath, "/v2")
+		}
+
+		var match mux.RouteMatch
+		if !r.Match(v1Req, &match) || match.MatchErr != nil {
+			status := http.StatusNotFound
+			if match.MatchErr == mux.ErrMethodMismatch {
+				status = http.StatusMethodNotAllowed
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(model.APIError{Error: http.StatusText(status)})
+			return
+		}
+		r.ServeHTTP(w, v1Req)
+	})
+}
@@ -0,0 +1,288 @@
+// Package openapi builds an OpenAPI 3 document from the routes registered on a
+// mux router, so the published spec always lists exactly the routes served.
+// Request and response bodies come from the Go types named in RouteDoc entries;
+// routes without one are still listed with their path parameters and security.
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gorilla/mux"
+)
+
+// Version is the OpenAPI version of the generated documents
+const Version = "3.0.3"
+
+// Document is an OpenAPI 3 document, limited to the parts the generator fills in
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Servers    []Server                        `json:"servers,omitempty"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Server is a base URL the paths are relative to
+type Server struct {
+	URL string `json:"url"`
+}
+
+// Operation is one method on one path
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter is a path or query parameter
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody is a JSON request body
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response is one response of an operation
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a body
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the named schemas and security schemes
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme is a way of authenticating requests
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// RouteDoc describes one route beyond what the router knows. Request and
+// Response are zero values of the body types, e.g. handler.JoinRequest{}.
+type RouteDoc struct {
+	OperationID string // Needed when the handler is wrapped in middleware
+	Summary     string
+	Request     interface{}
+	Response    interface{}
+	Status      int // Success status; 200 when zero
+}
+
+// Spec configures how a router is turned into a document
+type Spec struct {
+	Info Info
+
+	// Prefixes are the path prefixes whose routes are listed, relative to the
+	// prefix; a later prefix replaces the same operation under an earlier one
+	Prefixes []string
+	Server   string // Base URL of the listed paths; the last prefix when empty
+
+	// Security maps the subrouters that authenticate their routes to the
+	// security requirements they accept; routes elsewhere are public
+	Security map[*mux.Router][]map[string][]string
+	Schemes  map[string]SecurityScheme
+
+	// Docs maps "METHOD /path", with the path relative to its prefix, to its RouteDoc
+	Docs map[string]RouteDoc
+
+	// ErrorSchema is the body of every error response
+	ErrorSchema interface{}
+
+	// Skip lists paths, relative to their prefix, that are not plain HTTP (WebSockets, SSE)
+	Skip []string
+}
+
+var pathParam = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// Build walks the router and returns the document for the routes under Prefixes
+func (s *Spec) Build(r *mux.Router) (*Document, error) {
+	server := s.Server
+	if server == "" && len(s.Prefixes) > 0 {
+		server = s.Prefixes[len(s.Prefixes)-1]
+	}
+	schemas := newSchemaSet()
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    s.Info,
+		Servers: []Server{{URL: server}},
+		Paths:   make(map[string]map[string]Operation),
+		Components: Components{
+			Schemas:         schemas.components,
+			SecuritySchemes: s.Schemes,
+		},
+	}
+	var errorSchema *Schema
+	if s.ErrorSchema != nil {
+		errorSchema = schemas.of(reflect.TypeOf(s.ErrorSchema))
+	}
+
+	ids := make(map[string]bool)
+	for _, prefix := range s.Prefixes {
+		err := r.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+			tpl, err := route.GetPathTemplate()
+			if err != nil || !strings.HasPrefix(tpl, prefix+"/") {
+				return nil
+			}
+			methods, err := route.GetMethods()
+			if err != nil {
+				return nil // Prefix-only routes (subrouters, catch-alls)
+			}
+			rel := strings.TrimPrefix(tpl, prefix)
+			if s.skipped(rel) {
+				return nil
+			}
+			path := pathParam.ReplaceAllString(rel, "{$1}")
+
+			for _, method := range methods {
+				if method == http.MethodOptions {
+					continue
+				}
+				op := s.operation(method, rel, path, route.GetHandler(), schemas, errorSchema)
+				if ids[op.OperationID] {
+					op.OperationID = pathOperationID(method, path)
+				}
+				ids[op.OperationID] = true
+				op.Security = s.Security[router]
+				if doc.Paths[path] == nil {
+					doc.Paths[path] = make(map[string]Operation)
+				}
+				doc.Paths[path][strings.ToLower(method)] = op
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+func (s *Spec) skipped(rel string) bool {
+	for _, p := range s.Skip {
+		if strings.HasPrefix(rel, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Spec) operation(method, rel, path string, h http.Handler, schemas *schemaSet, errorSchema *Schema) Operation {
+	doc := s.Docs[method+" "+rel]
+	op := Operation{
+		OperationID: doc.OperationID,
+		Summary:     doc.Summary,
+		Responses:   make(map[string]Response),
+	}
+	if op.OperationID == "" {
+		op.OperationID = operationID(method, path, h)
+	}
+	if tag := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0]; tag != "" {
+		op.Tags = []string{tag}
+	}
+	for _, m := range pathParam.FindAllStringSubmatch(rel, -1) {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:     m[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+
+	if doc.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  jsonContent(schemas.of(reflect.TypeOf(doc.Request))),
+		}
+	}
+	status := doc.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	ok := Response{Description: http.StatusText(status)}
+	if doc.Response != nil {
+		ok.Content = jsonContent(schemas.of(reflect.TypeOf(doc.Response)))
+	}
+	op.Responses[strconv.Itoa(status)] = ok
+	if errorSchema != nil {
+		op.Responses["default"] = Response{Description: "Error", Content: jsonContent(errorSchema)}
+	}
+	return op
+}
+
+// operationID names the operation after its handler method ("RoomHandler.Join"
+// becomes "roomJoin"); wrapped handlers and closures fall back to method and path
+func operationID(method, path string, h http.Handler) string {
+	if hf, ok := h.(http.HandlerFunc); ok {
+		name := runtime.FuncForPC(reflect.ValueOf(hf).Pointer()).Name()
+		name = strings.TrimSuffix(name[strings.LastIndex(name, "/")+1:], "-fm")
+		if i := strings.Index(name, ".(*"); i >= 0 && !strings.Contains(name, ".func") {
+			recv, fn, _ := strings.Cut(name[i+3:], ").")
+			return lowerInitials(strings.TrimSuffix(recv, "Handler")) + fn
+		}
+	}
+	return pathOperationID(method, path)
+}
+
+// pathOperationID names an operation after its method and path: "GET
+// /rooms/{code}/players" becomes "getRoomsCodePlayers"
+func pathOperationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, part := range strings.FieldsFunc(path, func(r rune) bool {
+		return r == '/' || r == '{' || r == '}' || r == '-' || r == '.'
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
+
+// lowerInitials lowercases the leading capitals of a name, keeping the one that
+// starts the next word: "Room" -> "room", "SM" -> "sm", "APIKey" -> "apiKey"
+func lowerInitials(s string) string {
+	r := []rune(s)
+	for i := range r {
+		if !unicode.IsUpper(r[i]) || (i > 0 && i+1 < len(r) && unicode.IsLower(r[i+1])) {
+			break
+		}
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
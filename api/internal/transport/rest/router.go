@@ -2,7 +2,9 @@ package rest
 
 import (
 	"2026champs/internal/cache"
+	"2026champs/internal/events"
 	"2026champs/internal/service"
+	"2026champs/internal/transport/gql"
 	"2026champs/internal/transport/rest/handler"
 	"2026champs/internal/transport/rest/middleware"
 	"2026champs/internal/transport/ws"
@@ -24,6 +26,37 @@ type Container struct {
 	WSHub          *ws.Hub
 	SMSyncService  *service.SMSyncService
 	InsightService *service.InsightService
+
+	CalibrationService *service.CalibrationService
+	PrivacyService     *service.PrivacyService
+	RetentionService   *service.RetentionService
+	APIKeyService      *service.APIKeyService
+	IntegrationService *service.IntegrationService
+	EmailService       *service.EmailService
+	EmbedService       *service.EmbedService
+	QuotaService       *service.QuotaService
+	PollService        *service.PollService
+	ReactionService    *service.ReactionService
+	MetaSurveyService  *service.MetaSurveyService
+	AttachmentService  *service.AttachmentService
+	VoiceService       *service.VoiceService
+	ExperimentService  *service.ExperimentService
+	ReplayService      *service.ReplayService
+	AuditService       *service.AuditService
+	EventMetrics       *events.Metrics
+	EvaluatorService   *service.EvaluatorService
+	EventLogService    *service.EventLogService
+	FeedbackService    *service.FeedbackService
+	TimeseriesService  *service.TimeseriesService
+	TemplateService    *service.TemplateService
+	DifficultyService  *service.DifficultyService
+	BundleService      *service.BundleService
+	EventGroupService  *service.EventGroupService
+	ComparisonService  *service.ComparisonService
+	DigestService      *service.DigestService
+	CopilotService     *service.CopilotService
+	PlanService        *service.PlanService
+	AnalyticsService   *service.AnalyticsService
 }
 
 // NewRouter creates the API router with all endpoints
@@ -33,13 +66,29 @@ func NewRouter(c *Container) http.Handler {
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(c.AuthService)
 	surveyHandler := handler.NewSurveyHandler(c.SurveyService, c.InsightService)
-	roomHandler := handler.NewRoomHandler(c.RoomService, c.PlayerService, c.Leaderboard)
+	roomHandler := handler.NewRoomHandler(c.RoomService, c.PlayerService, c.AnswerService, c.Leaderboard)
 	playerHandler := handler.NewPlayerHandler(c.PlayerService, c.AnswerService)
 	reportHandler := handler.NewReportHandler(c.ReportService)
 	wsHandler := ws.NewHandler(c.WSHub, c.AuthService, c.PlayerService)
+	if c.CopilotService != nil {
+		wsHandler.SetCopilotService(c.CopilotService)
+	}
+	if c.ReactionService != nil {
+		wsHandler.SetReactionService(c.ReactionService)
+	}
 
 	// Initialize middleware
 	authMW := middleware.NewAuthMiddleware(c.AuthService)
+	if c.APIKeyService != nil {
+		authMW.SetAPIKeyService(c.APIKeyService)
+	}
+
+	// Plan limits wrap the routes that add surveys, rooms and players
+	limitSurveys, limitRooms, limitPlayers := noLimit, noLimit, noLimit
+	if c.PlanService != nil {
+		planMW := middleware.NewPlanMiddleware(c.PlanService)
+		limitSurveys, limitRooms, limitPlayers = planMW.LimitSurveys, planMW.LimitRooms, planMW.LimitPlayers
+	}
 
 	// CORS middleware (apply first)
 	r.Use(corsMiddleware)
@@ -49,15 +98,35 @@ func NewRouter(c *Container) http.Handler {
 
 	// Public routes
 	v1.HandleFunc("/auth/login", authHandler.Login).Methods("POST", "OPTIONS")
-	v1.HandleFunc("/rooms/{code}/join", roomHandler.Join).Methods("POST", "OPTIONS")
+	v1.Handle("/rooms/{code}/join", limitPlayers(http.HandlerFunc(roomHandler.Join))).Methods("POST", "OPTIONS")
+	v1.HandleFunc("/rooms/{code}/branding", roomHandler.Branding).Methods("GET", "OPTIONS")
+	v1.HandleFunc("/shared/{token}", reportHandler.GetSharedReport).Methods("GET", "OPTIONS")
+
+	// Embeddable live results (public with embed token in query param)
+	var embedHandler *handler.EmbedHandler
+	if c.EmbedService != nil {
+		embedHandler = handler.NewEmbedHandler(c.EmbedService)
+		v1.HandleFunc("/rooms/{code}/embed", embedHandler.GetData).Methods("GET", "OPTIONS")
+		v1.HandleFunc("/rooms/{code}/embed/stream", embedHandler.Stream).Methods("GET")
+	}
 
 	// WebSocket routes (public with token in query param)
 	v1.HandleFunc("/ws/rooms/{code}/host", wsHandler.HostWS).Methods("GET")
 	v1.HandleFunc("/ws/rooms/{code}/player", wsHandler.PlayerWS).Methods("GET")
 
+	// Server-Sent Events fallback for clients behind proxies that block WebSockets
+	v1.HandleFunc("/sse/rooms/{code}/host", wsHandler.HostSSE).Methods("GET")
+	v1.HandleFunc("/sse/rooms/{code}/player", wsHandler.PlayerSSE).Methods("GET")
+
 	// Health check
 	r.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
+		// Fail health checks while draining so the load balancer stops routing here
+		if c.PlayerService != nil && c.PlayerService.IsDraining() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"status":"draining"}`))
+			return
+		}
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{"status":"ok"}`))
 	}).Methods("GET")
@@ -67,27 +136,230 @@ func NewRouter(c *Container) http.Handler {
 	hostRoutes.Use(authMW.RequireHost)
 
 	hostRoutes.HandleFunc("/surveys/generate-from-insights", surveyHandler.GenerateFromInsights).Methods("POST", "OPTIONS")
-	hostRoutes.HandleFunc("/surveys", surveyHandler.Create).Methods("POST", "OPTIONS")
+	hostRoutes.Handle("/surveys", limitSurveys(http.HandlerFunc(surveyHandler.Create))).Methods("POST", "OPTIONS")
 	hostRoutes.HandleFunc("/surveys", surveyHandler.List).Methods("GET", "OPTIONS")
+	hostRoutes.HandleFunc("/surveys/trash", surveyHandler.Trash).Methods("GET", "OPTIONS")
 	hostRoutes.HandleFunc("/surveys/{surveyId}", surveyHandler.Get).Methods("GET", "OPTIONS")
 	hostRoutes.HandleFunc("/surveys/{surveyId}", surveyHandler.Update).Methods("PUT", "OPTIONS")
-	hostRoutes.HandleFunc("/rooms", roomHandler.Create).Methods("POST", "OPTIONS")
+	hostRoutes.HandleFunc("/surveys/{surveyId}", surveyHandler.Delete).Methods("DELETE", "OPTIONS")
+	hostRoutes.Handle("/surveys/{surveyId}/restore", limitSurveys(http.HandlerFunc(surveyHandler.Restore))).Methods("POST", "OPTIONS")
+	hostRoutes.HandleFunc("/surveys/{surveyId}/questions/{questionKey}/test-eval", surveyHandler.TestEval).Methods("POST", "OPTIONS")
+	hostRoutes.HandleFunc("/surveys/{surveyId}/theme-taxonomy", surveyHandler.SetThemeTaxonomy).Methods("PUT", "OPTIONS")
+	hostRoutes.Handle("/rooms", limitRooms(http.HandlerFunc(roomHandler.Create))).Methods("POST", "OPTIONS")
 	hostRoutes.HandleFunc("/rooms/{code}", roomHandler.Get).Methods("GET", "OPTIONS")
 	hostRoutes.HandleFunc("/rooms/{code}/start", roomHandler.Start).Methods("POST", "OPTIONS")
 	hostRoutes.HandleFunc("/rooms/{code}/end", roomHandler.End).Methods("POST", "OPTIONS")
 	hostRoutes.HandleFunc("/rooms/{code}/leaderboard", roomHandler.Leaderboard).Methods("GET", "OPTIONS")
+	hostRoutes.HandleFunc("/rooms/{code}/players", roomHandler.Players).Methods("GET", "OPTIONS")
+	hostRoutes.HandleFunc("/rooms/{code}/join-info", roomHandler.JoinInfo).Methods("GET", "OPTIONS")
+	if embedHandler != nil {
+		hostRoutes.HandleFunc("/rooms/{code}/embed-token", embedHandler.CreateToken).Methods("POST", "OPTIONS")
+	}
+	if c.QuotaService != nil {
+		quotaHandler := handler.NewQuotaHandler(c.QuotaService)
+		hostRoutes.HandleFunc("/rooms/{code}/quotas", quotaHandler.GetStatus).Methods("GET", "OPTIONS")
+	}
+	var pollHandler *handler.PollHandler
+	if c.PollService != nil {
+		pollHandler = handler.NewPollHandler(c.PollService)
+		hostRoutes.HandleFunc("/rooms/{code}/polls", pollHandler.Launch).Methods("POST", "OPTIONS")
+		hostRoutes.HandleFunc("/rooms/{code}/polls", pollHandler.List).Methods("GET", "OPTIONS")
+	}
+	var attachmentHandler *handler.AttachmentHandler
+	if c.AttachmentService != nil {
+		attachmentHandler = handler.NewAttachmentHandler(c.AttachmentService)
+		hostRoutes.HandleFunc("/rooms/{code}/attachments", attachmentHandler.List).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/rooms/{code}/attachments/{attachmentId}", attachmentHandler.Download).Methods("GET", "OPTIONS")
+	}
+	var voiceHandler *handler.VoiceHandler
+	if c.VoiceService != nil {
+		voiceHandler = handler.NewVoiceHandler(c.VoiceService)
+		hostRoutes.HandleFunc("/rooms/{code}/voice/{clipId}", voiceHandler.Download).Methods("GET", "OPTIONS")
+	}
+	hostRoutes.HandleFunc("/rooms/{code}/answers/{answerId}/override", roomHandler.OverrideAnswer).Methods("POST", "OPTIONS")
+	hostRoutes.HandleFunc("/rooms/{code}/answers/{answerId}/star", roomHandler.StarAnswer).Methods("POST", "OPTIONS")
+	hostRoutes.HandleFunc("/rooms/{code}/answers/{answerId}/star", roomHandler.UnstarAnswer).Methods("DELETE", "OPTIONS")
+	if c.AuditService != nil {
+		auditHandler := handler.NewAuditHandler(c.AuditService)
+		hostRoutes.HandleFunc("/rooms/{code}/audit", auditHandler.List).Methods("GET", "OPTIONS")
+	}
+	if c.TimeseriesService != nil {
+		timeseriesHandler := handler.NewTimeseriesHandler(c.TimeseriesService)
+		hostRoutes.HandleFunc("/rooms/{code}/metrics/timeseries", timeseriesHandler.Get).Methods("GET", "OPTIONS")
+	}
 
 	// Report routes (host only)
+	if c.ComparisonService != nil {
+		comparisonHandler := handler.NewComparisonHandler(c.ComparisonService)
+		hostRoutes.HandleFunc("/reports/compare", comparisonHandler.Compare).Methods("POST", "OPTIONS")
+		hostRoutes.HandleFunc("/reports/compare", comparisonHandler.List).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/reports/compare/{comparisonId}", comparisonHandler.Get).Methods("GET", "OPTIONS")
+	}
 	hostRoutes.HandleFunc("/reports/{roomCode}/snapshot", reportHandler.GetSnapshot).Methods("GET", "OPTIONS")
+	hostRoutes.HandleFunc("/reports/{roomCode}/funnel", reportHandler.GetFunnel).Methods("GET", "OPTIONS")
+	hostRoutes.HandleFunc("/reports/{roomCode}/eval-quality", reportHandler.GetEvalQuality).Methods("GET", "OPTIONS")
+	hostRoutes.HandleFunc("/reports/{roomCode}/usage", reportHandler.GetUsage).Methods("GET", "OPTIONS")
 	hostRoutes.HandleFunc("/reports/{roomCode}/ai", reportHandler.GetAIReport).Methods("GET", "OPTIONS")
 	hostRoutes.HandleFunc("/reports/{roomCode}/ai", reportHandler.GenerateAIReport).Methods("POST", "OPTIONS")
+	hostRoutes.HandleFunc("/reports/{roomCode}/share", reportHandler.CreateShareLink).Methods("POST", "OPTIONS")
+	if c.ReplayService != nil {
+		replayHandler := handler.NewReplayHandler(c.ReplayService)
+		hostRoutes.HandleFunc("/reports/{roomCode}/replay", replayHandler.Replay).Methods("POST", "OPTIONS")
+	}
+
+	// Emailing AI reports to stakeholders (host only)
+	if c.EmailService != nil {
+		emailHandler := handler.NewEmailHandler(c.EmailService)
+		hostRoutes.HandleFunc("/reports/{roomCode}/email", emailHandler.SendReport).Methods("POST", "OPTIONS")
+		hostRoutes.HandleFunc("/reports/{roomCode}/email", emailHandler.ListDeliveries).Methods("GET", "OPTIONS")
+	}
+
+	// Evaluation calibration (host only)
+	if c.CalibrationService != nil {
+		calibrationHandler := handler.NewCalibrationHandler(c.CalibrationService)
+		hostRoutes.HandleFunc("/admin/eval-calibration", calibrationHandler.GetCalibration).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/admin/answers/{answerId}/audit", calibrationHandler.AuditAnswer).Methods("POST", "OPTIONS")
+	}
+
+	// A/B experiments on prompts and follow-up strategies (host only)
+	if c.ExperimentService != nil {
+		experimentHandler := handler.NewExperimentHandler(c.ExperimentService)
+		hostRoutes.HandleFunc("/experiments", experimentHandler.Create).Methods("POST", "OPTIONS")
+		hostRoutes.HandleFunc("/experiments", experimentHandler.List).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/experiments/{experimentId}", experimentHandler.Get).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/experiments/{experimentId}/stop", experimentHandler.Stop).Methods("POST", "OPTIONS")
+		hostRoutes.HandleFunc("/experiments/{experimentId}/comparison", experimentHandler.Compare).Methods("GET", "OPTIONS")
+	}
+
+	// Player data access and deletion requests (host only)
+	if c.PrivacyService != nil {
+		privacyHandler := handler.NewPrivacyHandler(c.PrivacyService)
+		hostRoutes.HandleFunc("/rooms/{code}/players/{playerId}/data", privacyHandler.ExportPlayerData).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/rooms/{code}/players/{playerId}/data", privacyHandler.DeletePlayerData).Methods("DELETE", "OPTIONS")
+	}
+
+	// Data retention dry run (host only; purges themselves only run from the scheduled job)
+	if c.RetentionService != nil {
+		retentionHandler := handler.NewRetentionHandler(c.RetentionService)
+		hostRoutes.HandleFunc("/admin/retention", retentionHandler.Preview).Methods("GET", "OPTIONS")
+	}
+
+	// Published room event counters and the persisted event stream (host only)
+	eventHandler := handler.NewEventHandler(c.EventMetrics, c.EventLogService)
+	if c.EventMetrics != nil {
+		hostRoutes.HandleFunc("/admin/events", eventHandler.Metrics).Methods("GET", "OPTIONS")
+	}
+	if c.EventLogService != nil {
+		hostRoutes.HandleFunc("/rooms/{code}/events", eventHandler.List).Methods("GET", "OPTIONS")
+	}
+
+	// Gemini response schema failures per prompt template (host only)
+	if c.EvaluatorService != nil {
+		aiSchemaHandler := handler.NewAISchemaHandler(c.EvaluatorService)
+		hostRoutes.HandleFunc("/admin/ai-schema", aiSchemaHandler.Metrics).Methods("GET", "OPTIONS")
+	}
+
+	// API keys for server-to-server integrations (host JWT only)
+	if c.APIKeyService != nil {
+		apiKeyHandler := handler.NewAPIKeyHandler(c.APIKeyService)
+		hostRoutes.HandleFunc("/api-keys", apiKeyHandler.Create).Methods("POST", "OPTIONS")
+		hostRoutes.HandleFunc("/api-keys", apiKeyHandler.List).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/api-keys/{keyId}", apiKeyHandler.Revoke).Methods("DELETE", "OPTIONS")
+	}
+
+	// Room templates and one-call room setup (host only)
+	if c.TemplateService != nil {
+		templateHandler := handler.NewTemplateHandler(c.TemplateService)
+		hostRoutes.HandleFunc("/templates", templateHandler.Create).Methods("POST", "OPTIONS")
+		hostRoutes.HandleFunc("/templates", templateHandler.List).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/templates/{templateId}", templateHandler.Get).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/templates/{templateId}", templateHandler.Replace).Methods("PUT", "OPTIONS")
+		hostRoutes.HandleFunc("/templates/{templateId}", templateHandler.Delete).Methods("DELETE", "OPTIONS")
+		hostRoutes.Handle("/rooms/from-template/{templateId}", limitRooms(http.HandlerFunc(templateHandler.CreateRoom))).Methods("POST", "OPTIONS")
+	}
+
+	// Question difficulty calibration (host only)
+	if c.DifficultyService != nil {
+		difficultyHandler := handler.NewDifficultyHandler(c.DifficultyService)
+		hostRoutes.HandleFunc("/surveys/{surveyId}/difficulty", difficultyHandler.Get).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/surveys/{surveyId}/difficulty/apply", difficultyHandler.Apply).Methods("POST", "OPTIONS")
+	}
+
+	// Meta-survey: players rate the survey's clarity and length after their last question
+	var metaSurveyHandler *handler.MetaSurveyHandler
+	if c.MetaSurveyService != nil {
+		metaSurveyHandler = handler.NewMetaSurveyHandler(c.MetaSurveyService)
+		hostRoutes.HandleFunc("/surveys/{surveyId}/meta-feedback", metaSurveyHandler.ForSurvey).Methods("GET", "OPTIONS")
+	}
+
+	// Multi-room events with a combined leaderboard, analytics and AI report (host only)
+	if c.EventGroupService != nil {
+		eventGroupHandler := handler.NewEventGroupHandler(c.EventGroupService)
+		hostRoutes.HandleFunc("/events", eventGroupHandler.Create).Methods("POST", "OPTIONS")
+		hostRoutes.HandleFunc("/events", eventGroupHandler.List).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/events/{eventId}", eventGroupHandler.Get).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/events/{eventId}", eventGroupHandler.Delete).Methods("DELETE", "OPTIONS")
+		hostRoutes.HandleFunc("/events/{eventId}/rooms", eventGroupHandler.AddRoom).Methods("POST", "OPTIONS")
+		hostRoutes.HandleFunc("/events/{eventId}/rooms/{code}", eventGroupHandler.RemoveRoom).Methods("DELETE", "OPTIONS")
+		hostRoutes.HandleFunc("/events/{eventId}/leaderboard", eventGroupHandler.Leaderboard).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/events/{eventId}/analytics", eventGroupHandler.Analytics).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/events/{eventId}/report", eventGroupHandler.GenerateReport).Methods("POST", "OPTIONS")
+		hostRoutes.HandleFunc("/events/{eventId}/report", eventGroupHandler.GetReport).Methods("GET", "OPTIONS")
+	}
+
+	// Full-room export bundles for support and archives (host only)
+	if c.BundleService != nil {
+		bundleHandler := handler.NewBundleHandler(c.BundleService)
+		hostRoutes.HandleFunc("/admin/rooms/{code}/bundle", bundleHandler.Export).Methods("GET", "OPTIONS")
+	}
+
+	// Plan limits and how much of them the host uses (host only)
+	if c.PlanService != nil {
+		planHandler := handler.NewPlanHandler(c.PlanService)
+		hostRoutes.HandleFunc("/usage", planHandler.Usage).Methods("GET", "OPTIONS")
+	}
+
+	// GraphQL for dashboards that read rooms, players, answers and reports in one request (host JWT only)
+	if c.AnalyticsService != nil {
+		hostRoutes.Handle("/graphql", gql.NewHandler(&gql.Deps{
+			SurveyService:    c.SurveyService,
+			RoomService:      c.RoomService,
+			PlayerService:    c.PlayerService,
+			AnswerService:    c.AnswerService,
+			ReportService:    c.ReportService,
+			AnalyticsService: c.AnalyticsService,
+		})).Methods("POST", "OPTIONS")
+	}
+
+	// Slack and Teams report delivery (host only)
+	if c.IntegrationService != nil {
+		integrationHandler := handler.NewIntegrationHandler(c.IntegrationService)
+		hostRoutes.HandleFunc("/integrations", integrationHandler.Create).Methods("POST", "OPTIONS")
+		hostRoutes.HandleFunc("/integrations", integrationHandler.List).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/integrations/{integrationId}", integrationHandler.Delete).Methods("DELETE", "OPTIONS")
+	}
+
+	// Weekly digest of the host's rooms (host only)
+	if c.DigestService != nil {
+		digestHandler := handler.NewDigestHandler(c.DigestService)
+		hostRoutes.HandleFunc("/digest/settings", digestHandler.GetSettings).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/digest/settings", digestHandler.UpdateSettings).Methods("PUT", "OPTIONS")
+		hostRoutes.HandleFunc("/digest/preview", digestHandler.Preview).Methods("GET", "OPTIONS")
+	}
 
 	// SurveyMonkey routes (host only)
 	if c.SMSyncService != nil {
 		smHandler := handler.NewSMHandler(c.SMSyncService, c.SurveyService)
 		hostRoutes.HandleFunc("/sm/surveys/from-internal", smHandler.CreateSurveyFromInternal).Methods("POST", "OPTIONS")
 		hostRoutes.HandleFunc("/sm/surveys/{surveyId}/collectors/weblink", smHandler.CreateCollector).Methods("POST", "OPTIONS")
+		hostRoutes.HandleFunc("/sm/surveys/{surveyId}/collectors", smHandler.ListCollectors).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/sm/collectors/{collectorId}/close", smHandler.CloseCollector).Methods("POST", "OPTIONS")
+		hostRoutes.HandleFunc("/sm/collectors/{collectorId}/reopen", smHandler.ReopenCollector).Methods("POST", "OPTIONS")
+		hostRoutes.HandleFunc("/sm/collectors/{collectorId}", smHandler.DeleteCollector).Methods("DELETE", "OPTIONS")
 		hostRoutes.HandleFunc("/sm/surveys/{surveyId}/sync", smHandler.Sync).Methods("POST", "OPTIONS")
+		hostRoutes.HandleFunc("/sm/surveys/{surveyId}/sync/status", smHandler.SyncStatus).Methods("GET", "OPTIONS")
+		hostRoutes.HandleFunc("/sm/surveys/{surveyId}/schedule", smHandler.SetSchedule).Methods("PUT", "OPTIONS")
+		hostRoutes.HandleFunc("/sm/surveys/{surveyId}/schedule", smHandler.DeleteSchedule).Methods("DELETE", "OPTIONS")
 		hostRoutes.HandleFunc("/sm/surveys/{surveyId}/summary", smHandler.Summary).Methods("GET", "OPTIONS")
 		hostRoutes.HandleFunc("/sm/surveys/{surveyId}/distribution/{metric}", smHandler.Distribution).Methods("GET", "OPTIONS")
 	}
@@ -97,13 +369,50 @@ func NewRouter(c *Container) http.Handler {
 	playerRoutes.Use(authMW.RequirePlayer)
 
 	playerRoutes.HandleFunc("/rooms/{code}/question/current", playerHandler.GetCurrentQuestion).Methods("GET", "OPTIONS")
+	playerRoutes.HandleFunc("/rooms/{code}/questions/{questionKey}/draft", playerHandler.GetDraft).Methods("GET", "OPTIONS")
 	playerRoutes.HandleFunc("/rooms/{code}/questions/{questionKey}/draft", playerHandler.SaveDraft).Methods("PUT", "OPTIONS")
 	playerRoutes.HandleFunc("/rooms/{code}/answers", playerHandler.SubmitAnswer).Methods("POST", "OPTIONS")
 	playerRoutes.HandleFunc("/rooms/{code}/questions/{questionKey}/skip", playerHandler.Skip).Methods("POST", "OPTIONS")
+	playerRoutes.HandleFunc("/rooms/{code}/questions/{questionKey}/rating", playerHandler.RateFollowUp).Methods("POST", "OPTIONS")
+	playerRoutes.HandleFunc("/rooms/{code}/leave", playerHandler.Leave).Methods("POST", "OPTIONS")
+	playerRoutes.HandleFunc("/rooms/{code}/leaderboard/me", playerHandler.Standing).Methods("GET", "OPTIONS")
+
+	// End-of-room summary for players who missed the player_feedback event
+	if c.FeedbackService != nil {
+		feedbackHandler := handler.NewFeedbackHandler(c.FeedbackService)
+		playerRoutes.HandleFunc("/rooms/{code}/feedback", feedbackHandler.Get).Methods("GET", "OPTIONS")
+	}
+	if pollHandler != nil {
+		playerRoutes.HandleFunc("/rooms/{code}/polls/{pollId}/vote", pollHandler.Vote).Methods("POST", "OPTIONS")
+	}
+	if metaSurveyHandler != nil {
+		playerRoutes.HandleFunc("/rooms/{code}/meta-feedback", metaSurveyHandler.Submit).Methods("POST", "OPTIONS")
+	}
+	if attachmentHandler != nil {
+		playerRoutes.HandleFunc("/rooms/{code}/questions/{questionKey}/attachments", attachmentHandler.Upload).Methods("POST", "OPTIONS")
+	}
+	if voiceHandler != nil {
+		playerRoutes.HandleFunc("/rooms/{code}/questions/{questionKey}/voice", voiceHandler.Upload).Methods("POST", "OPTIONS")
+	}
+
+	// OpenAPI documents, generated from the registered routes
+	v1Spec, v2Spec := apiSpecs(hostRoutes, playerRoutes)
+	v1.HandleFunc("/openapi.json", serveSpec(r, v1Spec)).Methods("GET", "OPTIONS")
+
+	// API v2: routes registered on v2 take precedence; every other /v2 path is
+	// served by its v1 route through the compatibility shim, with typed errors
+	v2 := r.PathPrefix("/v2").Subrouter()
+	v2.HandleFunc("/openapi.json", serveSpec(r, v2Spec)).Methods("GET", "OPTIONS")
+	v2.PathPrefix("/").Handler(middleware.TypedErrors(v1Compat(r)))
 
 	return r
 }
 
+// noLimit leaves a route unguarded when plan limits are not wired
+func noLimit(next http.Handler) http.Handler {
+	return next
+}
+
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		allowedOrigins := os.Getenv("CORS_ALLOWED_ORIGINS")
@@ -118,12 +427,13 @@ func corsMiddleware(next http.Handler) http.Handler {
 
 		allowedHeaders := os.Getenv("CORS_ALLOWED_HEADERS")
 		if allowedHeaders == "" {
-			allowedHeaders = "Content-Type, Authorization"
+			allowedHeaders = "Content-Type, Authorization, If-Match, X-API-Key"
 		}
 
 		w.Header().Set("Access-Control-Allow-Origin", allowedOrigins)
 		w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
 		w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+		w.Header().Set("Access-Control-Expose-Headers", "ETag, Last-Modified")
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -0,0 +1,33 @@
+package rpc
+
+import (
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rpc/champspb"
+
+	"google.golang.org/grpc"
+)
+
+type answerServer struct {
+	champspb.UnimplementedAnswerServiceServer
+	roomSvc   *service.RoomService
+	answerSvc *service.AnswerService
+}
+
+func (s *answerServer) ListAnswers(req *champspb.ListAnswersRequest, stream grpc.ServerStreamingServer[champspb.Answer]) error {
+	ctx := stream.Context()
+	room, err := hostRoom(ctx, s.roomSvc, req.GetRoomCode())
+	if err != nil {
+		return err
+	}
+
+	answers, err := s.answerSvc.ListRoomAnswers(ctx, room.Code, req.GetQuestionKey())
+	if err != nil {
+		return toStatus(err)
+	}
+	for _, a := range answers {
+		if err := stream.Send(answerToPB(a)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package rpc
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rpc/champspb"
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// apiKeyMetadata carries a host API key as an alternative to a bearer JWT
+const apiKeyMetadata = "x-api-key"
+
+type hostIDKey struct{}
+
+// hostID returns the host the call was authenticated as
+func hostID(ctx context.Context) string {
+	id, _ := ctx.Value(hostIDKey{}).(string)
+	return id
+}
+
+// methodScopes maps every RPC to the API key scope it needs, as on the REST routes
+var methodScopes = map[string]string{
+	champspb.SurveyService_CreateSurvey_FullMethodName:     model.ScopeSurveysWrite,
+	champspb.SurveyService_GetSurvey_FullMethodName:        model.ScopeSurveysRead,
+	champspb.SurveyService_ListSurveys_FullMethodName:      model.ScopeSurveysRead,
+	champspb.RoomService_CreateRoom_FullMethodName:         model.ScopeRoomsWrite,
+	champspb.RoomService_GetRoom_FullMethodName:            model.ScopeRoomsRead,
+	champspb.RoomService_StartRoom_FullMethodName:          model.ScopeRoomsWrite,
+	champspb.RoomService_EndRoom_FullMethodName:            model.ScopeRoomsWrite,
+	champspb.AnswerService_ListAnswers_FullMethodName:      model.ScopeRoomsRead,
+	champspb.ReportService_GetSnapshot_FullMethodName:      model.ScopeReportsRead,
+	champspb.ReportService_GetAIReport_FullMethodName:      model.ScopeReportsRead,
+	champspb.ReportService_GenerateAIReport_FullMethodName: model.ScopeReportsWrite,
+}
+
+// authenticator checks the host JWT ("authorization: Bearer ...") or API key
+// ("x-api-key") of every call; the reflection service needs neither
+type authenticator struct {
+	authSvc   *service.AuthService
+	apiKeySvc *service.APIKeyService
+}
+
+func (a *authenticator) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if strings.HasPrefix(info.FullMethod, "/grpc.reflection.") {
+		return handler(srv, ss)
+	}
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authedStream{ServerStream: ss, ctx: ctx})
+}
+
+func (a *authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if token := bearerToken(md); token != "" {
+		claims, err := a.authSvc.ValidateHostToken(token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+		}
+		return context.WithValue(ctx, hostIDKey{}, claims.HostID), nil
+	}
+
+	keys := md.Get(apiKeyMetadata)
+	if len(keys) == 0 || a.apiKeySvc == nil {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization")
+	}
+	key, err := a.apiKeySvc.Validate(ctx, keys[0])
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or revoked API key")
+	}
+	scope := methodScopes[method]
+	if scope == "" {
+		return nil, status.Error(codes.PermissionDenied, "method not available to API keys")
+	}
+	if !key.HasScope(scope) {
+		return nil, status.Errorf(codes.PermissionDenied, "API key missing scope %s", scope)
+	}
+	return context.WithValue(ctx, hostIDKey{}, key.HostID), nil
+}
+
+func bearerToken(md metadata.MD) string {
+	for _, v := range md.Get("authorization") {
+		scheme, token, ok := strings.Cut(v, " ")
+		if ok && strings.EqualFold(scheme, "bearer") {
+			return token
+		}
+	}
+	return ""
+}
+
+// authedStream carries the authenticated context into streaming handlers
+type authedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authedStream) Context() context.Context {
+	return s.ctx
+}
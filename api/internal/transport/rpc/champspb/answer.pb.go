@@ -0,0 +1,337 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: champs/v1/answer.proto
+
+package champspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListAnswersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomCode      string                 `protobuf:"bytes,1,opt,name=room_code,json=roomCode,proto3" json:"room_code,omitempty"`
+	QuestionKey   string                 `protobuf:"bytes,2,opt,name=question_key,json=questionKey,proto3" json:"question_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAnswersRequest) Reset() {
+	*x = ListAnswersRequest{}
+	mi := &file_champs_v1_answer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAnswersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAnswersRequest) ProtoMessage() {}
+
+func (x *ListAnswersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_answer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAnswersRequest.ProtoReflect.Descriptor instead.
+func (*ListAnswersRequest) Descriptor() ([]byte, []int) {
+	return file_champs_v1_answer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListAnswersRequest) GetRoomCode() string {
+	if x != nil {
+		return x.RoomCode
+	}
+	return ""
+}
+
+func (x *ListAnswersRequest) GetQuestionKey() string {
+	if x != nil {
+		return x.QuestionKey
+	}
+	return ""
+}
+
+type Answer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RoomCode      string                 `protobuf:"bytes,2,opt,name=room_code,json=roomCode,proto3" json:"room_code,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,3,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	QuestionKey   string                 `protobuf:"bytes,4,opt,name=question_key,json=questionKey,proto3" json:"question_key,omitempty"`
+	TextAnswer    string                 `protobuf:"bytes,5,opt,name=text_answer,json=textAnswer,proto3" json:"text_answer,omitempty"`
+	DegreeValue   int32                  `protobuf:"varint,6,opt,name=degree_value,json=degreeValue,proto3" json:"degree_value,omitempty"`
+	OptionIndex   *int32                 `protobuf:"varint,7,opt,name=option_index,json=optionIndex,proto3,oneof" json:"option_index,omitempty"`
+	MatrixValues  []int32                `protobuf:"varint,8,rep,packed,name=matrix_values,json=matrixValues,proto3" json:"matrix_values,omitempty"`
+	Ranking       []int32                `protobuf:"varint,9,rep,packed,name=ranking,proto3" json:"ranking,omitempty"`
+	Status        string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	Resolution    string                 `protobuf:"bytes,11,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	Tries         int32                  `protobuf:"varint,12,opt,name=tries,proto3" json:"tries,omitempty"`
+	ResponseMs    int64                  `protobuf:"varint,13,opt,name=response_ms,json=responseMs,proto3" json:"response_ms,omitempty"`
+	PointsEarned  int32                  `protobuf:"varint,14,opt,name=points_earned,json=pointsEarned,proto3" json:"points_earned,omitempty"`
+	QualityScore  float64                `protobuf:"fixed64,15,opt,name=quality_score,json=qualityScore,proto3" json:"quality_score,omitempty"`
+	EvalSummary   string                 `protobuf:"bytes,16,opt,name=eval_summary,json=evalSummary,proto3" json:"eval_summary,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Answer) Reset() {
+	*x = Answer{}
+	mi := &file_champs_v1_answer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Answer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Answer) ProtoMessage() {}
+
+func (x *Answer) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_answer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Answer.ProtoReflect.Descriptor instead.
+func (*Answer) Descriptor() ([]byte, []int) {
+	return file_champs_v1_answer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Answer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Answer) GetRoomCode() string {
+	if x != nil {
+		return x.RoomCode
+	}
+	return ""
+}
+
+func (x *Answer) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *Answer) GetQuestionKey() string {
+	if x != nil {
+		return x.QuestionKey
+	}
+	return ""
+}
+
+func (x *Answer) GetTextAnswer() string {
+	if x != nil {
+		return x.TextAnswer
+	}
+	return ""
+}
+
+func (x *Answer) GetDegreeValue() int32 {
+	if x != nil {
+		return x.DegreeValue
+	}
+	return 0
+}
+
+func (x *Answer) GetOptionIndex() int32 {
+	if x != nil && x.OptionIndex != nil {
+		return *x.OptionIndex
+	}
+	return 0
+}
+
+func (x *Answer) GetMatrixValues() []int32 {
+	if x != nil {
+		return x.MatrixValues
+	}
+	return nil
+}
+
+func (x *Answer) GetRanking() []int32 {
+	if x != nil {
+		return x.Ranking
+	}
+	return nil
+}
+
+func (x *Answer) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Answer) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+func (x *Answer) GetTries() int32 {
+	if x != nil {
+		return x.Tries
+	}
+	return 0
+}
+
+func (x *Answer) GetResponseMs() int64 {
+	if x != nil {
+		return x.ResponseMs
+	}
+	return 0
+}
+
+func (x *Answer) GetPointsEarned() int32 {
+	if x != nil {
+		return x.PointsEarned
+	}
+	return 0
+}
+
+func (x *Answer) GetQualityScore() float64 {
+	if x != nil {
+		return x.QualityScore
+	}
+	return 0
+}
+
+func (x *Answer) GetEvalSummary() string {
+	if x != nil {
+		return x.EvalSummary
+	}
+	return ""
+}
+
+func (x *Answer) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_champs_v1_answer_proto protoreflect.FileDescriptor
+
+const file_champs_v1_answer_proto_rawDesc = "" +
+	"\n" +
+	"\x16champs/v1/answer.proto\x12\tchamps.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"T\n" +
+	"\x12ListAnswersRequest\x12\x1b\n" +
+	"\troom_code\x18\x01 \x01(\tR\broomCode\x12!\n" +
+	"\fquestion_key\x18\x02 \x01(\tR\vquestionKey\"\xc8\x04\n" +
+	"\x06Answer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\troom_code\x18\x02 \x01(\tR\broomCode\x12\x1b\n" +
+	"\tplayer_id\x18\x03 \x01(\tR\bplayerId\x12!\n" +
+	"\fquestion_key\x18\x04 \x01(\tR\vquestionKey\x12\x1f\n" +
+	"\vtext_answer\x18\x05 \x01(\tR\n" +
+	"textAnswer\x12!\n" +
+	"\fdegree_value\x18\x06 \x01(\x05R\vdegreeValue\x12&\n" +
+	"\foption_index\x18\a \x01(\x05H\x00R\voptionIndex\x88\x01\x01\x12#\n" +
+	"\rmatrix_values\x18\b \x03(\x05R\fmatrixValues\x12\x18\n" +
+	"\aranking\x18\t \x03(\x05R\aranking\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\v \x01(\tR\n" +
+	"resolution\x12\x14\n" +
+	"\x05tries\x18\f \x01(\x05R\x05tries\x12\x1f\n" +
+	"\vresponse_ms\x18\r \x01(\x03R\n" +
+	"responseMs\x12#\n" +
+	"\rpoints_earned\x18\x0e \x01(\x05R\fpointsEarned\x12#\n" +
+	"\rquality_score\x18\x0f \x01(\x01R\fqualityScore\x12!\n" +
+	"\feval_summary\x18\x10 \x01(\tR\vevalSummary\x129\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\x0f\n" +
+	"\r_option_index2R\n" +
+	"\rAnswerService\x12A\n" +
+	"\vListAnswers\x12\x1d.champs.v1.ListAnswersRequest\x1a\x11.champs.v1.Answer0\x01B5Z32026champs/internal/transport/rpc/champspb;champspbb\x06proto3"
+
+var (
+	file_champs_v1_answer_proto_rawDescOnce sync.Once
+	file_champs_v1_answer_proto_rawDescData []byte
+)
+
+func file_champs_v1_answer_proto_rawDescGZIP() []byte {
+	file_champs_v1_answer_proto_rawDescOnce.Do(func() {
+		file_champs_v1_answer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_champs_v1_answer_proto_rawDesc), len(file_champs_v1_answer_proto_rawDesc)))
+	})
+	return file_champs_v1_answer_proto_rawDescData
+}
+
+var file_champs_v1_answer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_champs_v1_answer_proto_goTypes = []any{
+	(*ListAnswersRequest)(nil),    // 0: champs.v1.ListAnswersRequest
+	(*Answer)(nil),                // 1: champs.v1.Answer
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_champs_v1_answer_proto_depIdxs = []int32{
+	2, // 0: champs.v1.Answer.created_at:type_name -> google.protobuf.Timestamp
+	0, // 1: champs.v1.AnswerService.ListAnswers:input_type -> champs.v1.ListAnswersRequest
+	1, // 2: champs.v1.AnswerService.ListAnswers:output_type -> champs.v1.Answer
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_champs_v1_answer_proto_init() }
+func file_champs_v1_answer_proto_init() {
+	if File_champs_v1_answer_proto != nil {
+		return
+	}
+	file_champs_v1_answer_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_champs_v1_answer_proto_rawDesc), len(file_champs_v1_answer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_champs_v1_answer_proto_goTypes,
+		DependencyIndexes: file_champs_v1_answer_proto_depIdxs,
+		MessageInfos:      file_champs_v1_answer_proto_msgTypes,
+	}.Build()
+	File_champs_v1_answer_proto = out.File
+	file_champs_v1_answer_proto_goTypes = nil
+	file_champs_v1_answer_proto_depIdxs = nil
+}
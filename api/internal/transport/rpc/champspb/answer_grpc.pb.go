@@ -0,0 +1,124 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: champs/v1/answer.proto
+
+package champspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AnswerService_ListAnswers_FullMethodName = "/champs.v1.AnswerService/ListAnswers"
+)
+
+// AnswerServiceClient is the client API for AnswerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AnswerServiceClient interface {
+	ListAnswers(ctx context.Context, in *ListAnswersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Answer], error)
+}
+
+type answerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnswerServiceClient(cc grpc.ClientConnInterface) AnswerServiceClient {
+	return &answerServiceClient{cc}
+}
+
+func (c *answerServiceClient) ListAnswers(ctx context.Context, in *ListAnswersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Answer], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AnswerService_ServiceDesc.Streams[0], AnswerService_ListAnswers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListAnswersRequest, Answer]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AnswerService_ListAnswersClient = grpc.ServerStreamingClient[Answer]
+
+// AnswerServiceServer is the server API for AnswerService service.
+// All implementations must embed UnimplementedAnswerServiceServer
+// for forward compatibility.
+type AnswerServiceServer interface {
+	ListAnswers(*ListAnswersRequest, grpc.ServerStreamingServer[Answer]) error
+	mustEmbedUnimplementedAnswerServiceServer()
+}
+
+// UnimplementedAnswerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAnswerServiceServer struct{}
+
+func (UnimplementedAnswerServiceServer) ListAnswers(*ListAnswersRequest, grpc.ServerStreamingServer[Answer]) error {
+	return status.Errorf(codes.Unimplemented, "method ListAnswers not implemented")
+}
+func (UnimplementedAnswerServiceServer) mustEmbedUnimplementedAnswerServiceServer() {}
+func (UnimplementedAnswerServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeAnswerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnswerServiceServer will
+// result in compilation errors.
+type UnsafeAnswerServiceServer interface {
+	mustEmbedUnimplementedAnswerServiceServer()
+}
+
+func RegisterAnswerServiceServer(s grpc.ServiceRegistrar, srv AnswerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAnswerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AnswerService_ServiceDesc, srv)
+}
+
+func _AnswerService_ListAnswers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListAnswersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AnswerServiceServer).ListAnswers(m, &grpc.GenericServerStream[ListAnswersRequest, Answer]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AnswerService_ListAnswersServer = grpc.ServerStreamingServer[Answer]
+
+// AnswerService_ServiceDesc is the grpc.ServiceDesc for AnswerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnswerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "champs.v1.AnswerService",
+	HandlerType: (*AnswerServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListAnswers",
+			Handler:       _AnswerService_ListAnswers_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "champs/v1/answer.proto",
+}
@@ -0,0 +1,660 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: champs/v1/report.proto
+
+package champspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LeaderboardEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Nickname      string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Score         int32                  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	Rank          int32                  `protobuf:"varint,4,opt,name=rank,proto3" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	mi := &file_champs_v1_report_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_report_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_champs_v1_report_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LeaderboardEntry) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+type QuestionSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionKey   string                 `protobuf:"bytes,1,opt,name=question_key,json=questionKey,proto3" json:"question_key,omitempty"`
+	SatCount      int32                  `protobuf:"varint,2,opt,name=sat_count,json=satCount,proto3" json:"sat_count,omitempty"`
+	UnsatCount    int32                  `protobuf:"varint,3,opt,name=unsat_count,json=unsatCount,proto3" json:"unsat_count,omitempty"`
+	SkipCount     int32                  `protobuf:"varint,4,opt,name=skip_count,json=skipCount,proto3" json:"skip_count,omitempty"`
+	ThemeCounts   map[string]int32       `protobuf:"bytes,5,rep,name=theme_counts,json=themeCounts,proto3" json:"theme_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuestionSummary) Reset() {
+	*x = QuestionSummary{}
+	mi := &file_champs_v1_report_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuestionSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuestionSummary) ProtoMessage() {}
+
+func (x *QuestionSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_report_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuestionSummary.ProtoReflect.Descriptor instead.
+func (*QuestionSummary) Descriptor() ([]byte, []int) {
+	return file_champs_v1_report_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *QuestionSummary) GetQuestionKey() string {
+	if x != nil {
+		return x.QuestionKey
+	}
+	return ""
+}
+
+func (x *QuestionSummary) GetSatCount() int32 {
+	if x != nil {
+		return x.SatCount
+	}
+	return 0
+}
+
+func (x *QuestionSummary) GetUnsatCount() int32 {
+	if x != nil {
+		return x.UnsatCount
+	}
+	return 0
+}
+
+func (x *QuestionSummary) GetSkipCount() int32 {
+	if x != nil {
+		return x.SkipCount
+	}
+	return 0
+}
+
+func (x *QuestionSummary) GetThemeCounts() map[string]int32 {
+	if x != nil {
+		return x.ThemeCounts
+	}
+	return nil
+}
+
+type Snapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomCode      string                 `protobuf:"bytes,1,opt,name=room_code,json=roomCode,proto3" json:"room_code,omitempty"`
+	SurveyId      string                 `protobuf:"bytes,2,opt,name=survey_id,json=surveyId,proto3" json:"survey_id,omitempty"`
+	EndedAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	Leaderboard   []*LeaderboardEntry    `protobuf:"bytes,4,rep,name=leaderboard,proto3" json:"leaderboard,omitempty"`
+	Questions     []*QuestionSummary     `protobuf:"bytes,5,rep,name=questions,proto3" json:"questions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	mi := &file_champs_v1_report_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_report_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_champs_v1_report_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Snapshot) GetRoomCode() string {
+	if x != nil {
+		return x.RoomCode
+	}
+	return ""
+}
+
+func (x *Snapshot) GetSurveyId() string {
+	if x != nil {
+		return x.SurveyId
+	}
+	return ""
+}
+
+func (x *Snapshot) GetEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedAt
+	}
+	return nil
+}
+
+func (x *Snapshot) GetLeaderboard() []*LeaderboardEntry {
+	if x != nil {
+		return x.Leaderboard
+	}
+	return nil
+}
+
+func (x *Snapshot) GetQuestions() []*QuestionSummary {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
+type Theme struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Meaning          string                 `protobuf:"bytes,2,opt,name=meaning,proto3" json:"meaning,omitempty"`
+	Percentage       float64                `protobuf:"fixed64,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	EvidenceSnippets []string               `protobuf:"bytes,4,rep,name=evidence_snippets,json=evidenceSnippets,proto3" json:"evidence_snippets,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Theme) Reset() {
+	*x = Theme{}
+	mi := &file_champs_v1_report_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Theme) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Theme) ProtoMessage() {}
+
+func (x *Theme) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_report_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Theme.ProtoReflect.Descriptor instead.
+func (*Theme) Descriptor() ([]byte, []int) {
+	return file_champs_v1_report_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Theme) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Theme) GetMeaning() string {
+	if x != nil {
+		return x.Meaning
+	}
+	return ""
+}
+
+func (x *Theme) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *Theme) GetEvidenceSnippets() []string {
+	if x != nil {
+		return x.EvidenceSnippets
+	}
+	return nil
+}
+
+type AIReport struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	RoomCode             string                 `protobuf:"bytes,1,opt,name=room_code,json=roomCode,proto3" json:"room_code,omitempty"`
+	Status               string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	ExecutiveSummary     []string               `protobuf:"bytes,3,rep,name=executive_summary,json=executiveSummary,proto3" json:"executive_summary,omitempty"`
+	KeyThemes            []*Theme               `protobuf:"bytes,4,rep,name=key_themes,json=keyThemes,proto3" json:"key_themes,omitempty"`
+	RecommendedQuestions []string               `protobuf:"bytes,5,rep,name=recommended_questions,json=recommendedQuestions,proto3" json:"recommended_questions,omitempty"`
+	CreatedAt            *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReadyAt              *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=ready_at,json=readyAt,proto3" json:"ready_at,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *AIReport) Reset() {
+	*x = AIReport{}
+	mi := &file_champs_v1_report_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AIReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AIReport) ProtoMessage() {}
+
+func (x *AIReport) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_report_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AIReport.ProtoReflect.Descriptor instead.
+func (*AIReport) Descriptor() ([]byte, []int) {
+	return file_champs_v1_report_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AIReport) GetRoomCode() string {
+	if x != nil {
+		return x.RoomCode
+	}
+	return ""
+}
+
+func (x *AIReport) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AIReport) GetExecutiveSummary() []string {
+	if x != nil {
+		return x.ExecutiveSummary
+	}
+	return nil
+}
+
+func (x *AIReport) GetKeyThemes() []*Theme {
+	if x != nil {
+		return x.KeyThemes
+	}
+	return nil
+}
+
+func (x *AIReport) GetRecommendedQuestions() []string {
+	if x != nil {
+		return x.RecommendedQuestions
+	}
+	return nil
+}
+
+func (x *AIReport) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AIReport) GetReadyAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReadyAt
+	}
+	return nil
+}
+
+type GetSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomCode      string                 `protobuf:"bytes,1,opt,name=room_code,json=roomCode,proto3" json:"room_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSnapshotRequest) Reset() {
+	*x = GetSnapshotRequest{}
+	mi := &file_champs_v1_report_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSnapshotRequest) ProtoMessage() {}
+
+func (x *GetSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_report_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*GetSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_champs_v1_report_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetSnapshotRequest) GetRoomCode() string {
+	if x != nil {
+		return x.RoomCode
+	}
+	return ""
+}
+
+type GetAIReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomCode      string                 `protobuf:"bytes,1,opt,name=room_code,json=roomCode,proto3" json:"room_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAIReportRequest) Reset() {
+	*x = GetAIReportRequest{}
+	mi := &file_champs_v1_report_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAIReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAIReportRequest) ProtoMessage() {}
+
+func (x *GetAIReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_report_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAIReportRequest.ProtoReflect.Descriptor instead.
+func (*GetAIReportRequest) Descriptor() ([]byte, []int) {
+	return file_champs_v1_report_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetAIReportRequest) GetRoomCode() string {
+	if x != nil {
+		return x.RoomCode
+	}
+	return ""
+}
+
+type GenerateAIReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomCode      string                 `protobuf:"bytes,1,opt,name=room_code,json=roomCode,proto3" json:"room_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateAIReportRequest) Reset() {
+	*x = GenerateAIReportRequest{}
+	mi := &file_champs_v1_report_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateAIReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateAIReportRequest) ProtoMessage() {}
+
+func (x *GenerateAIReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_report_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateAIReportRequest.ProtoReflect.Descriptor instead.
+func (*GenerateAIReportRequest) Descriptor() ([]byte, []int) {
+	return file_champs_v1_report_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GenerateAIReportRequest) GetRoomCode() string {
+	if x != nil {
+		return x.RoomCode
+	}
+	return ""
+}
+
+var File_champs_v1_report_proto protoreflect.FileDescriptor
+
+const file_champs_v1_report_proto_rawDesc = "" +
+	"\n" +
+	"\x16champs/v1/report.proto\x12\tchamps.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"u\n" +
+	"\x10LeaderboardEntry\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1a\n" +
+	"\bnickname\x18\x02 \x01(\tR\bnickname\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x05R\x05score\x12\x12\n" +
+	"\x04rank\x18\x04 \x01(\x05R\x04rank\"\xa1\x02\n" +
+	"\x0fQuestionSummary\x12!\n" +
+	"\fquestion_key\x18\x01 \x01(\tR\vquestionKey\x12\x1b\n" +
+	"\tsat_count\x18\x02 \x01(\x05R\bsatCount\x12\x1f\n" +
+	"\vunsat_count\x18\x03 \x01(\x05R\n" +
+	"unsatCount\x12\x1d\n" +
+	"\n" +
+	"skip_count\x18\x04 \x01(\x05R\tskipCount\x12N\n" +
+	"\ftheme_counts\x18\x05 \x03(\v2+.champs.v1.QuestionSummary.ThemeCountsEntryR\vthemeCounts\x1a>\n" +
+	"\x10ThemeCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xf4\x01\n" +
+	"\bSnapshot\x12\x1b\n" +
+	"\troom_code\x18\x01 \x01(\tR\broomCode\x12\x1b\n" +
+	"\tsurvey_id\x18\x02 \x01(\tR\bsurveyId\x125\n" +
+	"\bended_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendedAt\x12=\n" +
+	"\vleaderboard\x18\x04 \x03(\v2\x1b.champs.v1.LeaderboardEntryR\vleaderboard\x128\n" +
+	"\tquestions\x18\x05 \x03(\v2\x1a.champs.v1.QuestionSummaryR\tquestions\"\x82\x01\n" +
+	"\x05Theme\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\ameaning\x18\x02 \x01(\tR\ameaning\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x03 \x01(\x01R\n" +
+	"percentage\x12+\n" +
+	"\x11evidence_snippets\x18\x04 \x03(\tR\x10evidenceSnippets\"\xc4\x02\n" +
+	"\bAIReport\x12\x1b\n" +
+	"\troom_code\x18\x01 \x01(\tR\broomCode\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12+\n" +
+	"\x11executive_summary\x18\x03 \x03(\tR\x10executiveSummary\x12/\n" +
+	"\n" +
+	"key_themes\x18\x04 \x03(\v2\x10.champs.v1.ThemeR\tkeyThemes\x123\n" +
+	"\x15recommended_questions\x18\x05 \x03(\tR\x14recommendedQuestions\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x125\n" +
+	"\bready_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\areadyAt\"1\n" +
+	"\x12GetSnapshotRequest\x12\x1b\n" +
+	"\troom_code\x18\x01 \x01(\tR\broomCode\"1\n" +
+	"\x12GetAIReportRequest\x12\x1b\n" +
+	"\troom_code\x18\x01 \x01(\tR\broomCode\"6\n" +
+	"\x17GenerateAIReportRequest\x12\x1b\n" +
+	"\troom_code\x18\x01 \x01(\tR\broomCode2\xe2\x01\n" +
+	"\rReportService\x12A\n" +
+	"\vGetSnapshot\x12\x1d.champs.v1.GetSnapshotRequest\x1a\x13.champs.v1.Snapshot\x12A\n" +
+	"\vGetAIReport\x12\x1d.champs.v1.GetAIReportRequest\x1a\x13.champs.v1.AIReport\x12K\n" +
+	"\x10GenerateAIReport\x12\".champs.v1.GenerateAIReportRequest\x1a\x13.champs.v1.AIReportB5Z32026champs/internal/transport/rpc/champspb;champspbb\x06proto3"
+
+var (
+	file_champs_v1_report_proto_rawDescOnce sync.Once
+	file_champs_v1_report_proto_rawDescData []byte
+)
+
+func file_champs_v1_report_proto_rawDescGZIP() []byte {
+	file_champs_v1_report_proto_rawDescOnce.Do(func() {
+		file_champs_v1_report_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_champs_v1_report_proto_rawDesc), len(file_champs_v1_report_proto_rawDesc)))
+	})
+	return file_champs_v1_report_proto_rawDescData
+}
+
+var file_champs_v1_report_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_champs_v1_report_proto_goTypes = []any{
+	(*LeaderboardEntry)(nil),        // 0: champs.v1.LeaderboardEntry
+	(*QuestionSummary)(nil),         // 1: champs.v1.QuestionSummary
+	(*Snapshot)(nil),                // 2: champs.v1.Snapshot
+	(*Theme)(nil),                   // 3: champs.v1.Theme
+	(*AIReport)(nil),                // 4: champs.v1.AIReport
+	(*GetSnapshotRequest)(nil),      // 5: champs.v1.GetSnapshotRequest
+	(*GetAIReportRequest)(nil),      // 6: champs.v1.GetAIReportRequest
+	(*GenerateAIReportRequest)(nil), // 7: champs.v1.GenerateAIReportRequest
+	nil,                             // 8: champs.v1.QuestionSummary.ThemeCountsEntry
+	(*timestamppb.Timestamp)(nil),   // 9: google.protobuf.Timestamp
+}
+var file_champs_v1_report_proto_depIdxs = []int32{
+	8,  // 0: champs.v1.QuestionSummary.theme_counts:type_name -> champs.v1.QuestionSummary.ThemeCountsEntry
+	9,  // 1: champs.v1.Snapshot.ended_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: champs.v1.Snapshot.leaderboard:type_name -> champs.v1.LeaderboardEntry
+	1,  // 3: champs.v1.Snapshot.questions:type_name -> champs.v1.QuestionSummary
+	3,  // 4: champs.v1.AIReport.key_themes:type_name -> champs.v1.Theme
+	9,  // 5: champs.v1.AIReport.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 6: champs.v1.AIReport.ready_at:type_name -> google.protobuf.Timestamp
+	5,  // 7: champs.v1.ReportService.GetSnapshot:input_type -> champs.v1.GetSnapshotRequest
+	6,  // 8: champs.v1.ReportService.GetAIReport:input_type -> champs.v1.GetAIReportRequest
+	7,  // 9: champs.v1.ReportService.GenerateAIReport:input_type -> champs.v1.GenerateAIReportRequest
+	2,  // 10: champs.v1.ReportService.GetSnapshot:output_type -> champs.v1.Snapshot
+	4,  // 11: champs.v1.ReportService.GetAIReport:output_type -> champs.v1.AIReport
+	4,  // 12: champs.v1.ReportService.GenerateAIReport:output_type -> champs.v1.AIReport
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_champs_v1_report_proto_init() }
+func file_champs_v1_report_proto_init() {
+	if File_champs_v1_report_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_champs_v1_report_proto_rawDesc), len(file_champs_v1_report_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_champs_v1_report_proto_goTypes,
+		DependencyIndexes: file_champs_v1_report_proto_depIdxs,
+		MessageInfos:      file_champs_v1_report_proto_msgTypes,
+	}.Build()
+	File_champs_v1_report_proto = out.File
+	file_champs_v1_report_proto_goTypes = nil
+	file_champs_v1_report_proto_depIdxs = nil
+}
@@ -0,0 +1,423 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: champs/v1/room.proto
+
+package champspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Room struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	SurveyId      string                 `protobuf:"bytes,2,opt,name=survey_id,json=surveyId,proto3" json:"survey_id,omitempty"`
+	HostId        string                 `protobuf:"bytes,3,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	HostNotes     string                 `protobuf:"bytes,5,opt,name=host_notes,json=hostNotes,proto3" json:"host_notes,omitempty"`
+	ScopeSummary  string                 `protobuf:"bytes,6,opt,name=scope_summary,json=scopeSummary,proto3" json:"scope_summary,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	EndedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Room) Reset() {
+	*x = Room{}
+	mi := &file_champs_v1_room_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Room) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Room) ProtoMessage() {}
+
+func (x *Room) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_room_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Room.ProtoReflect.Descriptor instead.
+func (*Room) Descriptor() ([]byte, []int) {
+	return file_champs_v1_room_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Room) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Room) GetSurveyId() string {
+	if x != nil {
+		return x.SurveyId
+	}
+	return ""
+}
+
+func (x *Room) GetHostId() string {
+	if x != nil {
+		return x.HostId
+	}
+	return ""
+}
+
+func (x *Room) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Room) GetHostNotes() string {
+	if x != nil {
+		return x.HostNotes
+	}
+	return ""
+}
+
+func (x *Room) GetScopeSummary() string {
+	if x != nil {
+		return x.ScopeSummary
+	}
+	return ""
+}
+
+func (x *Room) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Room) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Room) GetEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedAt
+	}
+	return nil
+}
+
+type CreateRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SurveyId      string                 `protobuf:"bytes,1,opt,name=survey_id,json=surveyId,proto3" json:"survey_id,omitempty"`
+	HostNotes     string                 `protobuf:"bytes,2,opt,name=host_notes,json=hostNotes,proto3" json:"host_notes,omitempty"`
+	ExperimentId  string                 `protobuf:"bytes,3,opt,name=experiment_id,json=experimentId,proto3" json:"experiment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoomRequest) Reset() {
+	*x = CreateRoomRequest{}
+	mi := &file_champs_v1_room_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoomRequest) ProtoMessage() {}
+
+func (x *CreateRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_room_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoomRequest.ProtoReflect.Descriptor instead.
+func (*CreateRoomRequest) Descriptor() ([]byte, []int) {
+	return file_champs_v1_room_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateRoomRequest) GetSurveyId() string {
+	if x != nil {
+		return x.SurveyId
+	}
+	return ""
+}
+
+func (x *CreateRoomRequest) GetHostNotes() string {
+	if x != nil {
+		return x.HostNotes
+	}
+	return ""
+}
+
+func (x *CreateRoomRequest) GetExperimentId() string {
+	if x != nil {
+		return x.ExperimentId
+	}
+	return ""
+}
+
+type GetRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoomRequest) Reset() {
+	*x = GetRoomRequest{}
+	mi := &file_champs_v1_room_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoomRequest) ProtoMessage() {}
+
+func (x *GetRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_room_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoomRequest.ProtoReflect.Descriptor instead.
+func (*GetRoomRequest) Descriptor() ([]byte, []int) {
+	return file_champs_v1_room_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetRoomRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type StartRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartRoomRequest) Reset() {
+	*x = StartRoomRequest{}
+	mi := &file_champs_v1_room_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartRoomRequest) ProtoMessage() {}
+
+func (x *StartRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_room_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartRoomRequest.ProtoReflect.Descriptor instead.
+func (*StartRoomRequest) Descriptor() ([]byte, []int) {
+	return file_champs_v1_room_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StartRoomRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type EndRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EndRoomRequest) Reset() {
+	*x = EndRoomRequest{}
+	mi := &file_champs_v1_room_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EndRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndRoomRequest) ProtoMessage() {}
+
+func (x *EndRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_room_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndRoomRequest.ProtoReflect.Descriptor instead.
+func (*EndRoomRequest) Descriptor() ([]byte, []int) {
+	return file_champs_v1_room_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EndRoomRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+var File_champs_v1_room_proto protoreflect.FileDescriptor
+
+const file_champs_v1_room_proto_rawDesc = "" +
+	"\n" +
+	"\x14champs/v1/room.proto\x12\tchamps.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd9\x02\n" +
+	"\x04Room\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1b\n" +
+	"\tsurvey_id\x18\x02 \x01(\tR\bsurveyId\x12\x17\n" +
+	"\ahost_id\x18\x03 \x01(\tR\x06hostId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"host_notes\x18\x05 \x01(\tR\thostNotes\x12#\n" +
+	"\rscope_summary\x18\x06 \x01(\tR\fscopeSummary\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"started_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x125\n" +
+	"\bended_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\aendedAt\"t\n" +
+	"\x11CreateRoomRequest\x12\x1b\n" +
+	"\tsurvey_id\x18\x01 \x01(\tR\bsurveyId\x12\x1d\n" +
+	"\n" +
+	"host_notes\x18\x02 \x01(\tR\thostNotes\x12#\n" +
+	"\rexperiment_id\x18\x03 \x01(\tR\fexperimentId\"$\n" +
+	"\x0eGetRoomRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"&\n" +
+	"\x10StartRoomRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"$\n" +
+	"\x0eEndRoomRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code2\xf3\x01\n" +
+	"\vRoomService\x12;\n" +
+	"\n" +
+	"CreateRoom\x12\x1c.champs.v1.CreateRoomRequest\x1a\x0f.champs.v1.Room\x125\n" +
+	"\aGetRoom\x12\x19.champs.v1.GetRoomRequest\x1a\x0f.champs.v1.Room\x129\n" +
+	"\tStartRoom\x12\x1b.champs.v1.StartRoomRequest\x1a\x0f.champs.v1.Room\x125\n" +
+	"\aEndRoom\x12\x19.champs.v1.EndRoomRequest\x1a\x0f.champs.v1.RoomB5Z32026champs/internal/transport/rpc/champspb;champspbb\x06proto3"
+
+var (
+	file_champs_v1_room_proto_rawDescOnce sync.Once
+	file_champs_v1_room_proto_rawDescData []byte
+)
+
+func file_champs_v1_room_proto_rawDescGZIP() []byte {
+	file_champs_v1_room_proto_rawDescOnce.Do(func() {
+		file_champs_v1_room_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_champs_v1_room_proto_rawDesc), len(file_champs_v1_room_proto_rawDesc)))
+	})
+	return file_champs_v1_room_proto_rawDescData
+}
+
+var file_champs_v1_room_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_champs_v1_room_proto_goTypes = []any{
+	(*Room)(nil),                  // 0: champs.v1.Room
+	(*CreateRoomRequest)(nil),     // 1: champs.v1.CreateRoomRequest
+	(*GetRoomRequest)(nil),        // 2: champs.v1.GetRoomRequest
+	(*StartRoomRequest)(nil),      // 3: champs.v1.StartRoomRequest
+	(*EndRoomRequest)(nil),        // 4: champs.v1.EndRoomRequest
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+}
+var file_champs_v1_room_proto_depIdxs = []int32{
+	5, // 0: champs.v1.Room.created_at:type_name -> google.protobuf.Timestamp
+	5, // 1: champs.v1.Room.started_at:type_name -> google.protobuf.Timestamp
+	5, // 2: champs.v1.Room.ended_at:type_name -> google.protobuf.Timestamp
+	1, // 3: champs.v1.RoomService.CreateRoom:input_type -> champs.v1.CreateRoomRequest
+	2, // 4: champs.v1.RoomService.GetRoom:input_type -> champs.v1.GetRoomRequest
+	3, // 5: champs.v1.RoomService.StartRoom:input_type -> champs.v1.StartRoomRequest
+	4, // 6: champs.v1.RoomService.EndRoom:input_type -> champs.v1.EndRoomRequest
+	0, // 7: champs.v1.RoomService.CreateRoom:output_type -> champs.v1.Room
+	0, // 8: champs.v1.RoomService.GetRoom:output_type -> champs.v1.Room
+	0, // 9: champs.v1.RoomService.StartRoom:output_type -> champs.v1.Room
+	0, // 10: champs.v1.RoomService.EndRoom:output_type -> champs.v1.Room
+	7, // [7:11] is the sub-list for method output_type
+	3, // [3:7] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_champs_v1_room_proto_init() }
+func file_champs_v1_room_proto_init() {
+	if File_champs_v1_room_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_champs_v1_room_proto_rawDesc), len(file_champs_v1_room_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_champs_v1_room_proto_goTypes,
+		DependencyIndexes: file_champs_v1_room_proto_depIdxs,
+		MessageInfos:      file_champs_v1_room_proto_msgTypes,
+	}.Build()
+	File_champs_v1_room_proto = out.File
+	file_champs_v1_room_proto_goTypes = nil
+	file_champs_v1_room_proto_depIdxs = nil
+}
@@ -0,0 +1,654 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: champs/v1/survey.proto
+
+package champspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SurveySettings struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	SatisfactoryThreshold float64                `protobuf:"fixed64,1,opt,name=satisfactory_threshold,json=satisfactoryThreshold,proto3" json:"satisfactory_threshold,omitempty"`
+	MaxFollowUps          int32                  `protobuf:"varint,2,opt,name=max_follow_ups,json=maxFollowUps,proto3" json:"max_follow_ups,omitempty"`
+	DefaultPointsMax      int32                  `protobuf:"varint,3,opt,name=default_points_max,json=defaultPointsMax,proto3" json:"default_points_max,omitempty"`
+	AllowSkipAfter        int32                  `protobuf:"varint,4,opt,name=allow_skip_after,json=allowSkipAfter,proto3" json:"allow_skip_after,omitempty"`
+	AutoCalibrate         bool                   `protobuf:"varint,5,opt,name=auto_calibrate,json=autoCalibrate,proto3" json:"auto_calibrate,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *SurveySettings) Reset() {
+	*x = SurveySettings{}
+	mi := &file_champs_v1_survey_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SurveySettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurveySettings) ProtoMessage() {}
+
+func (x *SurveySettings) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_survey_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurveySettings.ProtoReflect.Descriptor instead.
+func (*SurveySettings) Descriptor() ([]byte, []int) {
+	return file_champs_v1_survey_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SurveySettings) GetSatisfactoryThreshold() float64 {
+	if x != nil {
+		return x.SatisfactoryThreshold
+	}
+	return 0
+}
+
+func (x *SurveySettings) GetMaxFollowUps() int32 {
+	if x != nil {
+		return x.MaxFollowUps
+	}
+	return 0
+}
+
+func (x *SurveySettings) GetDefaultPointsMax() int32 {
+	if x != nil {
+		return x.DefaultPointsMax
+	}
+	return 0
+}
+
+func (x *SurveySettings) GetAllowSkipAfter() int32 {
+	if x != nil {
+		return x.AllowSkipAfter
+	}
+	return 0
+}
+
+func (x *SurveySettings) GetAutoCalibrate() bool {
+	if x != nil {
+		return x.AutoCalibrate
+	}
+	return false
+}
+
+type Question struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Prompt        string                 `protobuf:"bytes,3,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	Rubric        string                 `protobuf:"bytes,4,opt,name=rubric,proto3" json:"rubric,omitempty"`
+	PointsMax     int32                  `protobuf:"varint,5,opt,name=points_max,json=pointsMax,proto3" json:"points_max,omitempty"`
+	Threshold     float64                `protobuf:"fixed64,6,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	ScaleMin      int32                  `protobuf:"varint,7,opt,name=scale_min,json=scaleMin,proto3" json:"scale_min,omitempty"`
+	ScaleMax      int32                  `protobuf:"varint,8,opt,name=scale_max,json=scaleMax,proto3" json:"scale_max,omitempty"`
+	Options       []string               `protobuf:"bytes,9,rep,name=options,proto3" json:"options,omitempty"`
+	Rows          []string               `protobuf:"bytes,10,rep,name=rows,proto3" json:"rows,omitempty"`
+	Columns       []string               `protobuf:"bytes,11,rep,name=columns,proto3" json:"columns,omitempty"`
+	MinLength     int32                  `protobuf:"varint,12,opt,name=min_length,json=minLength,proto3" json:"min_length,omitempty"`
+	MaxLength     int32                  `protobuf:"varint,13,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Question) Reset() {
+	*x = Question{}
+	mi := &file_champs_v1_survey_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Question) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Question) ProtoMessage() {}
+
+func (x *Question) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_survey_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Question.ProtoReflect.Descriptor instead.
+func (*Question) Descriptor() ([]byte, []int) {
+	return file_champs_v1_survey_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Question) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Question) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Question) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *Question) GetRubric() string {
+	if x != nil {
+		return x.Rubric
+	}
+	return ""
+}
+
+func (x *Question) GetPointsMax() int32 {
+	if x != nil {
+		return x.PointsMax
+	}
+	return 0
+}
+
+func (x *Question) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *Question) GetScaleMin() int32 {
+	if x != nil {
+		return x.ScaleMin
+	}
+	return 0
+}
+
+func (x *Question) GetScaleMax() int32 {
+	if x != nil {
+		return x.ScaleMax
+	}
+	return 0
+}
+
+func (x *Question) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Question) GetRows() []string {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *Question) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *Question) GetMinLength() int32 {
+	if x != nil {
+		return x.MinLength
+	}
+	return 0
+}
+
+func (x *Question) GetMaxLength() int32 {
+	if x != nil {
+		return x.MaxLength
+	}
+	return 0
+}
+
+type Survey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	HostId        string                 `protobuf:"bytes,2,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Intent        string                 `protobuf:"bytes,4,opt,name=intent,proto3" json:"intent,omitempty"`
+	Settings      *SurveySettings        `protobuf:"bytes,5,opt,name=settings,proto3" json:"settings,omitempty"`
+	Questions     []*Question            `protobuf:"bytes,6,rep,name=questions,proto3" json:"questions,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Survey) Reset() {
+	*x = Survey{}
+	mi := &file_champs_v1_survey_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Survey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Survey) ProtoMessage() {}
+
+func (x *Survey) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_survey_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Survey.ProtoReflect.Descriptor instead.
+func (*Survey) Descriptor() ([]byte, []int) {
+	return file_champs_v1_survey_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Survey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Survey) GetHostId() string {
+	if x != nil {
+		return x.HostId
+	}
+	return ""
+}
+
+func (x *Survey) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Survey) GetIntent() string {
+	if x != nil {
+		return x.Intent
+	}
+	return ""
+}
+
+func (x *Survey) GetSettings() *SurveySettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *Survey) GetQuestions() []*Question {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
+func (x *Survey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Survey) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateSurveyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Intent        string                 `protobuf:"bytes,2,opt,name=intent,proto3" json:"intent,omitempty"`
+	Settings      *SurveySettings        `protobuf:"bytes,3,opt,name=settings,proto3" json:"settings,omitempty"`
+	Questions     []*Question            `protobuf:"bytes,4,rep,name=questions,proto3" json:"questions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSurveyRequest) Reset() {
+	*x = CreateSurveyRequest{}
+	mi := &file_champs_v1_survey_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSurveyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSurveyRequest) ProtoMessage() {}
+
+func (x *CreateSurveyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_survey_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSurveyRequest.ProtoReflect.Descriptor instead.
+func (*CreateSurveyRequest) Descriptor() ([]byte, []int) {
+	return file_champs_v1_survey_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateSurveyRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateSurveyRequest) GetIntent() string {
+	if x != nil {
+		return x.Intent
+	}
+	return ""
+}
+
+func (x *CreateSurveyRequest) GetSettings() *SurveySettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *CreateSurveyRequest) GetQuestions() []*Question {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
+type GetSurveyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSurveyRequest) Reset() {
+	*x = GetSurveyRequest{}
+	mi := &file_champs_v1_survey_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSurveyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSurveyRequest) ProtoMessage() {}
+
+func (x *GetSurveyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_survey_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSurveyRequest.ProtoReflect.Descriptor instead.
+func (*GetSurveyRequest) Descriptor() ([]byte, []int) {
+	return file_champs_v1_survey_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetSurveyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListSurveysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSurveysRequest) Reset() {
+	*x = ListSurveysRequest{}
+	mi := &file_champs_v1_survey_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSurveysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSurveysRequest) ProtoMessage() {}
+
+func (x *ListSurveysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_survey_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSurveysRequest.ProtoReflect.Descriptor instead.
+func (*ListSurveysRequest) Descriptor() ([]byte, []int) {
+	return file_champs_v1_survey_proto_rawDescGZIP(), []int{5}
+}
+
+type ListSurveysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Surveys       []*Survey              `protobuf:"bytes,1,rep,name=surveys,proto3" json:"surveys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSurveysResponse) Reset() {
+	*x = ListSurveysResponse{}
+	mi := &file_champs_v1_survey_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSurveysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSurveysResponse) ProtoMessage() {}
+
+func (x *ListSurveysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_champs_v1_survey_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSurveysResponse.ProtoReflect.Descriptor instead.
+func (*ListSurveysResponse) Descriptor() ([]byte, []int) {
+	return file_champs_v1_survey_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListSurveysResponse) GetSurveys() []*Survey {
+	if x != nil {
+		return x.Surveys
+	}
+	return nil
+}
+
+var File_champs_v1_survey_proto protoreflect.FileDescriptor
+
+const file_champs_v1_survey_proto_rawDesc = "" +
+	"\n" +
+	"\x16champs/v1/survey.proto\x12\tchamps.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xec\x01\n" +
+	"\x0eSurveySettings\x125\n" +
+	"\x16satisfactory_threshold\x18\x01 \x01(\x01R\x15satisfactoryThreshold\x12$\n" +
+	"\x0emax_follow_ups\x18\x02 \x01(\x05R\fmaxFollowUps\x12,\n" +
+	"\x12default_points_max\x18\x03 \x01(\x05R\x10defaultPointsMax\x12(\n" +
+	"\x10allow_skip_after\x18\x04 \x01(\x05R\x0eallowSkipAfter\x12%\n" +
+	"\x0eauto_calibrate\x18\x05 \x01(\bR\rautoCalibrate\"\xdd\x02\n" +
+	"\bQuestion\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06prompt\x18\x03 \x01(\tR\x06prompt\x12\x16\n" +
+	"\x06rubric\x18\x04 \x01(\tR\x06rubric\x12\x1d\n" +
+	"\n" +
+	"points_max\x18\x05 \x01(\x05R\tpointsMax\x12\x1c\n" +
+	"\tthreshold\x18\x06 \x01(\x01R\tthreshold\x12\x1b\n" +
+	"\tscale_min\x18\a \x01(\x05R\bscaleMin\x12\x1b\n" +
+	"\tscale_max\x18\b \x01(\x05R\bscaleMax\x12\x18\n" +
+	"\aoptions\x18\t \x03(\tR\aoptions\x12\x12\n" +
+	"\x04rows\x18\n" +
+	" \x03(\tR\x04rows\x12\x18\n" +
+	"\acolumns\x18\v \x03(\tR\acolumns\x12\x1d\n" +
+	"\n" +
+	"min_length\x18\f \x01(\x05R\tminLength\x12\x1d\n" +
+	"\n" +
+	"max_length\x18\r \x01(\x05R\tmaxLength\"\xbf\x02\n" +
+	"\x06Survey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\ahost_id\x18\x02 \x01(\tR\x06hostId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x16\n" +
+	"\x06intent\x18\x04 \x01(\tR\x06intent\x125\n" +
+	"\bsettings\x18\x05 \x01(\v2\x19.champs.v1.SurveySettingsR\bsettings\x121\n" +
+	"\tquestions\x18\x06 \x03(\v2\x13.champs.v1.QuestionR\tquestions\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xad\x01\n" +
+	"\x13CreateSurveyRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x16\n" +
+	"\x06intent\x18\x02 \x01(\tR\x06intent\x125\n" +
+	"\bsettings\x18\x03 \x01(\v2\x19.champs.v1.SurveySettingsR\bsettings\x121\n" +
+	"\tquestions\x18\x04 \x03(\v2\x13.champs.v1.QuestionR\tquestions\"\"\n" +
+	"\x10GetSurveyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x14\n" +
+	"\x12ListSurveysRequest\"B\n" +
+	"\x13ListSurveysResponse\x12+\n" +
+	"\asurveys\x18\x01 \x03(\v2\x11.champs.v1.SurveyR\asurveys2\xdd\x01\n" +
+	"\rSurveyService\x12A\n" +
+	"\fCreateSurvey\x12\x1e.champs.v1.CreateSurveyRequest\x1a\x11.champs.v1.Survey\x12;\n" +
+	"\tGetSurvey\x12\x1b.champs.v1.GetSurveyRequest\x1a\x11.champs.v1.Survey\x12L\n" +
+	"\vListSurveys\x12\x1d.champs.v1.ListSurveysRequest\x1a\x1e.champs.v1.ListSurveysResponseB5Z32026champs/internal/transport/rpc/champspb;champspbb\x06proto3"
+
+var (
+	file_champs_v1_survey_proto_rawDescOnce sync.Once
+	file_champs_v1_survey_proto_rawDescData []byte
+)
+
+func file_champs_v1_survey_proto_rawDescGZIP() []byte {
+	file_champs_v1_survey_proto_rawDescOnce.Do(func() {
+		file_champs_v1_survey_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_champs_v1_survey_proto_rawDesc), len(file_champs_v1_survey_proto_rawDesc)))
+	})
+	return file_champs_v1_survey_proto_rawDescData
+}
+
+var file_champs_v1_survey_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_champs_v1_survey_proto_goTypes = []any{
+	(*SurveySettings)(nil),        // 0: champs.v1.SurveySettings
+	(*Question)(nil),              // 1: champs.v1.Question
+	(*Survey)(nil),                // 2: champs.v1.Survey
+	(*CreateSurveyRequest)(nil),   // 3: champs.v1.CreateSurveyRequest
+	(*GetSurveyRequest)(nil),      // 4: champs.v1.GetSurveyRequest
+	(*ListSurveysRequest)(nil),    // 5: champs.v1.ListSurveysRequest
+	(*ListSurveysResponse)(nil),   // 6: champs.v1.ListSurveysResponse
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+}
+var file_champs_v1_survey_proto_depIdxs = []int32{
+	0,  // 0: champs.v1.Survey.settings:type_name -> champs.v1.SurveySettings
+	1,  // 1: champs.v1.Survey.questions:type_name -> champs.v1.Question
+	7,  // 2: champs.v1.Survey.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 3: champs.v1.Survey.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: champs.v1.CreateSurveyRequest.settings:type_name -> champs.v1.SurveySettings
+	1,  // 5: champs.v1.CreateSurveyRequest.questions:type_name -> champs.v1.Question
+	2,  // 6: champs.v1.ListSurveysResponse.surveys:type_name -> champs.v1.Survey
+	3,  // 7: champs.v1.SurveyService.CreateSurvey:input_type -> champs.v1.CreateSurveyRequest
+	4,  // 8: champs.v1.SurveyService.GetSurvey:input_type -> champs.v1.GetSurveyRequest
+	5,  // 9: champs.v1.SurveyService.ListSurveys:input_type -> champs.v1.ListSurveysRequest
+	2,  // 10: champs.v1.SurveyService.CreateSurvey:output_type -> champs.v1.Survey
+	2,  // 11: champs.v1.SurveyService.GetSurvey:output_type -> champs.v1.Survey
+	6,  // 12: champs.v1.SurveyService.ListSurveys:output_type -> champs.v1.ListSurveysResponse
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_champs_v1_survey_proto_init() }
+func file_champs_v1_survey_proto_init() {
+	if File_champs_v1_survey_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_champs_v1_survey_proto_rawDesc), len(file_champs_v1_survey_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_champs_v1_survey_proto_goTypes,
+		DependencyIndexes: file_champs_v1_survey_proto_depIdxs,
+		MessageInfos:      file_champs_v1_survey_proto_msgTypes,
+	}.Build()
+	File_champs_v1_survey_proto = out.File
+	file_champs_v1_survey_proto_goTypes = nil
+	file_champs_v1_survey_proto_depIdxs = nil
+}
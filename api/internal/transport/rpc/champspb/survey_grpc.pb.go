@@ -0,0 +1,197 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: champs/v1/survey.proto
+
+package champspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SurveyService_CreateSurvey_FullMethodName = "/champs.v1.SurveyService/CreateSurvey"
+	SurveyService_GetSurvey_FullMethodName    = "/champs.v1.SurveyService/GetSurvey"
+	SurveyService_ListSurveys_FullMethodName  = "/champs.v1.SurveyService/ListSurveys"
+)
+
+// SurveyServiceClient is the client API for SurveyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SurveyServiceClient interface {
+	CreateSurvey(ctx context.Context, in *CreateSurveyRequest, opts ...grpc.CallOption) (*Survey, error)
+	GetSurvey(ctx context.Context, in *GetSurveyRequest, opts ...grpc.CallOption) (*Survey, error)
+	ListSurveys(ctx context.Context, in *ListSurveysRequest, opts ...grpc.CallOption) (*ListSurveysResponse, error)
+}
+
+type surveyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSurveyServiceClient(cc grpc.ClientConnInterface) SurveyServiceClient {
+	return &surveyServiceClient{cc}
+}
+
+func (c *surveyServiceClient) CreateSurvey(ctx context.Context, in *CreateSurveyRequest, opts ...grpc.CallOption) (*Survey, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Survey)
+	err := c.cc.Invoke(ctx, SurveyService_CreateSurvey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *surveyServiceClient) GetSurvey(ctx context.Context, in *GetSurveyRequest, opts ...grpc.CallOption) (*Survey, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Survey)
+	err := c.cc.Invoke(ctx, SurveyService_GetSurvey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *surveyServiceClient) ListSurveys(ctx context.Context, in *ListSurveysRequest, opts ...grpc.CallOption) (*ListSurveysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSurveysResponse)
+	err := c.cc.Invoke(ctx, SurveyService_ListSurveys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SurveyServiceServer is the server API for SurveyService service.
+// All implementations must embed UnimplementedSurveyServiceServer
+// for forward compatibility.
+type SurveyServiceServer interface {
+	CreateSurvey(context.Context, *CreateSurveyRequest) (*Survey, error)
+	GetSurvey(context.Context, *GetSurveyRequest) (*Survey, error)
+	ListSurveys(context.Context, *ListSurveysRequest) (*ListSurveysResponse, error)
+	mustEmbedUnimplementedSurveyServiceServer()
+}
+
+// UnimplementedSurveyServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSurveyServiceServer struct{}
+
+func (UnimplementedSurveyServiceServer) CreateSurvey(context.Context, *CreateSurveyRequest) (*Survey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSurvey not implemented")
+}
+func (UnimplementedSurveyServiceServer) GetSurvey(context.Context, *GetSurveyRequest) (*Survey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSurvey not implemented")
+}
+func (UnimplementedSurveyServiceServer) ListSurveys(context.Context, *ListSurveysRequest) (*ListSurveysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSurveys not implemented")
+}
+func (UnimplementedSurveyServiceServer) mustEmbedUnimplementedSurveyServiceServer() {}
+func (UnimplementedSurveyServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeSurveyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SurveyServiceServer will
+// result in compilation errors.
+type UnsafeSurveyServiceServer interface {
+	mustEmbedUnimplementedSurveyServiceServer()
+}
+
+func RegisterSurveyServiceServer(s grpc.ServiceRegistrar, srv SurveyServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSurveyServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SurveyService_ServiceDesc, srv)
+}
+
+func _SurveyService_CreateSurvey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSurveyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SurveyServiceServer).CreateSurvey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SurveyService_CreateSurvey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SurveyServiceServer).CreateSurvey(ctx, req.(*CreateSurveyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SurveyService_GetSurvey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSurveyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SurveyServiceServer).GetSurvey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SurveyService_GetSurvey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SurveyServiceServer).GetSurvey(ctx, req.(*GetSurveyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SurveyService_ListSurveys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSurveysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SurveyServiceServer).ListSurveys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SurveyService_ListSurveys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SurveyServiceServer).ListSurveys(ctx, req.(*ListSurveysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SurveyService_ServiceDesc is the grpc.ServiceDesc for SurveyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SurveyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "champs.v1.SurveyService",
+	HandlerType: (*SurveyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSurvey",
+			Handler:    _SurveyService_CreateSurvey_Handler,
+		},
+		{
+			MethodName: "GetSurvey",
+			Handler:    _SurveyService_GetSurvey_Handler,
+		},
+		{
+			MethodName: "ListSurveys",
+			Handler:    _SurveyService_ListSurveys_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "champs/v1/survey.proto",
+}
@@ -0,0 +1,187 @@
+package rpc
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/transport/rpc/champspb"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func surveyToPB(s *model.Survey) *champspb.Survey {
+	questions := make([]*champspb.Question, len(s.Questions))
+	for i, q := range s.Questions {
+		questions[i] = questionToPB(q)
+	}
+	return &champspb.Survey{
+		Id:     s.ID,
+		HostId: s.HostID,
+		Title:  s.Title,
+		Intent: s.Intent,
+		Settings: &champspb.SurveySettings{
+			SatisfactoryThreshold: s.Settings.SatisfactoryThreshold,
+			MaxFollowUps:          int32(s.Settings.MaxFollowUps),
+			DefaultPointsMax:      int32(s.Settings.DefaultPointsMax),
+			AllowSkipAfter:        int32(s.Settings.AllowSkipAfter),
+			AutoCalibrate:         s.Settings.AutoCalibrate,
+		},
+		Questions: questions,
+		CreatedAt: timestamp(&s.CreatedAt),
+		UpdatedAt: timestamp(&s.UpdatedAt),
+	}
+}
+
+func questionToPB(q model.BaseQuestion) *champspb.Question {
+	return &champspb.Question{
+		Key:       q.Key,
+		Type:      string(q.Type),
+		Prompt:    q.Prompt,
+		Rubric:    q.Rubric,
+		PointsMax: int32(q.PointsMax),
+		Threshold: q.Threshold,
+		ScaleMin:  int32(q.ScaleMin),
+		ScaleMax:  int32(q.ScaleMax),
+		Options:   q.Options,
+		Rows:      q.Rows,
+		Columns:   q.Columns,
+		MinLength: int32(q.MinLength),
+		MaxLength: int32(q.MaxLength),
+	}
+}
+
+func questionFromPB(q *champspb.Question) model.BaseQuestion {
+	return model.BaseQuestion{
+		Key:       q.GetKey(),
+		Type:      model.QuestionType(q.GetType()),
+		Prompt:    q.GetPrompt(),
+		Rubric:    q.GetRubric(),
+		PointsMax: int(q.GetPointsMax()),
+		Threshold: q.GetThreshold(),
+		ScaleMin:  int(q.GetScaleMin()),
+		ScaleMax:  int(q.GetScaleMax()),
+		Options:   q.GetOptions(),
+		Rows:      q.GetRows(),
+		Columns:   q.GetColumns(),
+		MinLength: int(q.GetMinLength()),
+		MaxLength: int(q.GetMaxLength()),
+	}
+}
+
+func settingsFromPB(s *champspb.SurveySettings) model.SurveySettings {
+	return model.SurveySettings{
+		SatisfactoryThreshold: s.GetSatisfactoryThreshold(),
+		MaxFollowUps:          int(s.GetMaxFollowUps()),
+		DefaultPointsMax:      int(s.GetDefaultPointsMax()),
+		AllowSkipAfter:        int(s.GetAllowSkipAfter()),
+		AutoCalibrate:         s.GetAutoCalibrate(),
+	}
+}
+
+func roomToPB(r *model.Room) *champspb.Room {
+	return &champspb.Room{
+		Code:         r.Code,
+		SurveyId:     r.SurveyID,
+		HostId:       r.HostID,
+		Status:       string(r.Status),
+		HostNotes:    r.HostNotes,
+		ScopeSummary: r.ScopeSummary,
+		CreatedAt:    timestamp(&r.CreatedAt),
+		StartedAt:    timestamp(r.StartedAt),
+		EndedAt:      timestamp(r.EndedAt),
+	}
+}
+
+func answerToPB(a *model.Answer) *champspb.Answer {
+	out := &champspb.Answer{
+		Id:           a.ID,
+		RoomCode:     a.RoomCode,
+		PlayerId:     a.PlayerID,
+		QuestionKey:  a.QuestionKey,
+		TextAnswer:   a.TextAnswer,
+		DegreeValue:  int32(a.DegreeValue),
+		MatrixValues: int32s(a.MatrixValues),
+		Ranking:      int32s(a.Ranking),
+		Status:       string(a.Status),
+		Resolution:   string(a.Resolution),
+		Tries:        int32(a.Tries),
+		ResponseMs:   a.ResponseMs,
+		PointsEarned: int32(a.PointsEarned),
+		QualityScore: a.QualityScore,
+		EvalSummary:  a.EvalSummary,
+		CreatedAt:    timestamp(&a.CreatedAt),
+	}
+	if a.OptionIndex != nil {
+		idx := int32(*a.OptionIndex)
+		out.OptionIndex = &idx
+	}
+	return out
+}
+
+func snapshotToPB(s *model.RoomSnapshot) *champspb.Snapshot {
+	out := &champspb.Snapshot{
+		RoomCode: s.RoomCode,
+		SurveyId: s.SurveyID,
+		EndedAt:  timestamp(&s.EndedAt),
+	}
+	for _, e := range s.Leaderboard {
+		out.Leaderboard = append(out.Leaderboard, &champspb.LeaderboardEntry{
+			PlayerId: e.PlayerID,
+			Nickname: e.Nickname,
+			Score:    int32(e.Score),
+			Rank:     int32(e.Rank),
+		})
+	}
+	for _, p := range s.QuestionProfiles {
+		themes := make(map[string]int32, len(p.ThemeCounts))
+		for theme, n := range p.ThemeCounts {
+			themes[theme] = int32(n)
+		}
+		out.Questions = append(out.Questions, &champspb.QuestionSummary{
+			QuestionKey: p.QuestionKey,
+			SatCount:    int32(p.SatCount),
+			UnsatCount:  int32(p.UnsatCount),
+			SkipCount:   int32(p.SkipCount),
+			ThemeCounts: themes,
+		})
+	}
+	return out
+}
+
+func aiReportToPB(r *model.AIReport) *champspb.AIReport {
+	out := &champspb.AIReport{
+		RoomCode:             r.RoomCode,
+		Status:               r.Status,
+		ExecutiveSummary:     r.ExecutiveSummary,
+		RecommendedQuestions: r.RecommendedQuestions,
+		CreatedAt:            timestamp(&r.CreatedAt),
+		ReadyAt:              timestamp(r.ReadyAt),
+	}
+	for _, t := range r.KeyThemes {
+		out.KeyThemes = append(out.KeyThemes, &champspb.Theme{
+			Name:             t.Name,
+			Meaning:          t.Meaning,
+			Percentage:       t.Percentage,
+			EvidenceSnippets: t.EvidenceSnippets,
+		})
+	}
+	return out
+}
+
+// timestamp converts t, leaving nil and zero times unset
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func int32s(values []int) []int32 {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]int32, len(values))
+	for i, v := range values {
+		out[i] = int32(v)
+	}
+	return out
+}
@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rpc/champspb"
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type reportServer struct {
+	champspb.UnimplementedReportServiceServer
+	roomSvc   *service.RoomService
+	reportSvc *service.ReportService
+}
+
+func (s *reportServer) GetSnapshot(ctx context.Context, req *champspb.GetSnapshotRequest) (*champspb.Snapshot, error) {
+	if _, err := hostRoom(ctx, s.roomSvc, req.GetRoomCode()); err != nil {
+		return nil, err
+	}
+	snapshot, err := s.reportSvc.GetSnapshot(ctx, req.GetRoomCode())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if snapshot == nil {
+		return nil, status.Error(codes.NotFound, "snapshot not found; the room has not ended")
+	}
+	return snapshotToPB(snapshot), nil
+}
+
+func (s *reportServer) GetAIReport(ctx context.Context, req *champspb.GetAIReportRequest) (*champspb.AIReport, error) {
+	if _, err := hostRoom(ctx, s.roomSvc, req.GetRoomCode()); err != nil {
+		return nil, err
+	}
+	report, err := s.reportSvc.GetAIReport(ctx, req.GetRoomCode())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if report == nil {
+		return nil, status.Error(codes.NotFound, "AI report not generated yet")
+	}
+	return aiReportToPB(report), nil
+}
+
+func (s *reportServer) GenerateAIReport(ctx context.Context, req *champspb.GenerateAIReportRequest) (*champspb.AIReport, error) {
+	code := req.GetRoomCode()
+	if _, err := hostRoom(ctx, s.roomSvc, code); err != nil {
+		return nil, err
+	}
+	snapshot, err := s.reportSvc.GetSnapshot(ctx, code)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if snapshot == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the room has not ended")
+	}
+
+	if err := s.reportSvc.TriggerAIReport(ctx, code); err != nil {
+		return nil, toStatus(err)
+	}
+	// Generation outlives the call
+	go func() {
+		if _, err := s.reportSvc.GenerateAIReport(context.Background(), code); err != nil {
+			fmt.Printf("[gRPC] AI report for room %s failed: %v\n", code, err)
+		}
+	}()
+	return s.GetAIReport(ctx, &champspb.GetAIReportRequest{RoomCode: code})
+}
@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rpc/champspb"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type roomServer struct {
+	champspb.UnimplementedRoomServiceServer
+	roomSvc *service.RoomService
+	planSvc *service.PlanService
+}
+
+func (s *roomServer) CreateRoom(ctx context.Context, req *champspb.CreateRoomRequest) (*champspb.Room, error) {
+	if err := requireField("survey_id", req.GetSurveyId()); err != nil {
+		return nil, err
+	}
+	host := hostID(ctx)
+	if err := checkPlan(s.planSvc != nil, func() error { return s.planSvc.CheckRooms(ctx, host) }); err != nil {
+		return nil, err
+	}
+
+	room, err := s.roomSvc.CreateRoom(ctx, req.GetSurveyId(), host, &model.RoomSettings{}, req.GetHostNotes(), req.GetExperimentId(), nil)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return roomToPB(room), nil
+}
+
+func (s *roomServer) GetRoom(ctx context.Context, req *champspb.GetRoomRequest) (*champspb.Room, error) {
+	room, err := hostRoom(ctx, s.roomSvc, req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+	return roomToPB(room), nil
+}
+
+func (s *roomServer) StartRoom(ctx context.Context, req *champspb.StartRoomRequest) (*champspb.Room, error) {
+	room, err := hostRoom(ctx, s.roomSvc, req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+	if room.Status != model.RoomStatusLobby {
+		return nil, status.Errorf(codes.FailedPrecondition, "room is %s, not LOBBY", room.Status)
+	}
+	if err := s.roomSvc.StartRoom(ctx, room.Code, room.HostID); err != nil {
+		return nil, toStatus(err)
+	}
+	return s.GetRoom(ctx, &champspb.GetRoomRequest{Code: room.Code})
+}
+
+func (s *roomServer) EndRoom(ctx context.Context, req *champspb.EndRoomRequest) (*champspb.Room, error) {
+	room, err := hostRoom(ctx, s.roomSvc, req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+	if room.Status == model.RoomStatusEnded {
+		return nil, status.Error(codes.FailedPrecondition, "room already ended")
+	}
+	if err := s.roomSvc.EndRoom(ctx, room.Code, room.HostID); err != nil {
+		return nil, toStatus(err)
+	}
+	return s.GetRoom(ctx, &champspb.GetRoomRequest{Code: room.Code})
+}
@@ -0,0 +1,98 @@
+// Package rpc serves the gRPC API for service-to-service integrations. It
+// shares the service layer with the REST API; the protobuf definitions live in
+// api/proto/champs/v1 and the generated code in champspb.
+package rpc
+
+//go:generate protoc -I ../../../proto --go_out=../../.. --go_opt=module=2026champs --go-grpc_out=../../.. --go-grpc_opt=module=2026champs champs/v1/survey.proto champs/v1/room.proto champs/v1/answer.proto champs/v1/report.proto
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rpc/champspb"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// Deps holds the services behind the gRPC API
+type Deps struct {
+	AuthService   *service.AuthService
+	APIKeyService *service.APIKeyService // optional, enables x-api-key
+	SurveyService *service.SurveyService
+	RoomService   *service.RoomService
+	AnswerService *service.AnswerService
+	ReportService *service.ReportService
+	PlanService   *service.PlanService // optional, enforces plan limits
+}
+
+// NewServer creates the gRPC server with the Survey, Room, Answer and Report
+// services registered, every call authenticated as a host
+func NewServer(d *Deps) *grpc.Server {
+	auth := &authenticator{authSvc: d.AuthService, apiKeySvc: d.APIKeyService}
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auth.unary),
+		grpc.ChainStreamInterceptor(auth.stream),
+	)
+
+	champspb.RegisterSurveyServiceServer(srv, &surveyServer{surveySvc: d.SurveyService, planSvc: d.PlanService})
+	champspb.RegisterRoomServiceServer(srv, &roomServer{roomSvc: d.RoomService, planSvc: d.PlanService})
+	champspb.RegisterAnswerServiceServer(srv, &answerServer{roomSvc: d.RoomService, answerSvc: d.AnswerService})
+	champspb.RegisterReportServiceServer(srv, &reportServer{roomSvc: d.RoomService, reportSvc: d.ReportService})
+
+	// Lets tools like grpcurl list the services without the .proto files
+	reflection.Register(srv)
+	return srv
+}
+
+// hostRoom loads a room of the calling host; NotFound for other hosts' rooms,
+// so room codes cannot be probed
+func hostRoom(ctx context.Context, roomSvc *service.RoomService, code string) (*model.Room, error) {
+	if err := requireField("room code", code); err != nil {
+		return nil, err
+	}
+	room, err := roomSvc.GetRoom(ctx, code)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if room == nil || room.HostID != hostID(ctx) {
+		return nil, status.Errorf(codes.NotFound, "room %s not found", code)
+	}
+	return room, nil
+}
+
+// toStatus maps service errors to gRPC status codes
+func toStatus(err error) error {
+	var limitErr *service.PlanLimitError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &limitErr):
+		return status.Error(codes.ResourceExhausted, limitErr.Error())
+	case errors.Is(err, service.ErrNotRoomHost):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, primitive.ErrInvalidHex):
+		return status.Error(codes.InvalidArgument, "invalid id")
+	case errors.Is(err, service.ErrInvalidSurvey),
+		errors.Is(err, service.ErrInvalidExperiment),
+		errors.Is(err, service.ErrInvalidRoomSettings):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func requireField(name, value string) error {
+	if value == "" {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("%s is required", name))
+	}
+	return nil
+}
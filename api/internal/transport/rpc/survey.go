@@ -0,0 +1,91 @@
+package rpc
+
+import (
+	"2026champs/internal/model"
+	"2026champs/internal/service"
+	"2026champs/internal/transport/rpc/champspb"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type surveyServer struct {
+	champspb.UnimplementedSurveyServiceServer
+	surveySvc *service.SurveyService
+	planSvc   *service.PlanService
+}
+
+func (s *surveyServer) CreateSurvey(ctx context.Context, req *champspb.CreateSurveyRequest) (*champspb.Survey, error) {
+	host := hostID(ctx)
+	if err := checkPlan(s.planSvc != nil, func() error { return s.planSvc.CheckSurveys(ctx, host) }); err != nil {
+		return nil, err
+	}
+
+	questions := make([]model.BaseQuestion, len(req.GetQuestions()))
+	for i, q := range req.GetQuestions() {
+		questions[i] = questionFromPB(q)
+		if questions[i].Key == "" {
+			questions[i].Key = fmt.Sprintf("Q%d", i+1)
+		}
+	}
+	survey := &model.Survey{
+		HostID:    host,
+		Title:     req.GetTitle(),
+		Intent:    req.GetIntent(),
+		Settings:  settingsFromPB(req.GetSettings()),
+		Questions: questions,
+	}
+	id, err := s.surveySvc.Create(ctx, survey)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	survey.ID = id
+	return surveyToPB(survey), nil
+}
+
+func (s *surveyServer) GetSurvey(ctx context.Context, req *champspb.GetSurveyRequest) (*champspb.Survey, error) {
+	if err := requireField("id", req.GetId()); err != nil {
+		return nil, err
+	}
+	survey, err := s.surveySvc.GetByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if survey == nil || survey.HostID != hostID(ctx) {
+		return nil, status.Errorf(codes.NotFound, "survey %s not found", req.GetId())
+	}
+	return surveyToPB(survey), nil
+}
+
+func (s *surveyServer) ListSurveys(ctx context.Context, _ *champspb.ListSurveysRequest) (*champspb.ListSurveysResponse, error) {
+	surveys, err := s.surveySvc.GetByHostID(ctx, hostID(ctx))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &champspb.ListSurveysResponse{Surveys: make([]*champspb.Survey, len(surveys))}
+	for i, survey := range surveys {
+		resp.Surveys[i] = surveyToPB(survey)
+	}
+	return resp, nil
+}
+
+// checkPlan runs a plan limit check when plans are wired. As on REST, only a
+// hit limit fails the call; an outage of the usage counters lets it through.
+func checkPlan(enabled bool, check func() error) error {
+	if !enabled {
+		return nil
+	}
+	err := check()
+	var limitErr *service.PlanLimitError
+	if errors.As(err, &limitErr) {
+		return toStatus(err)
+	}
+	if err != nil {
+		log.Printf("Plan check failed: %v", err)
+	}
+	return nil
+}
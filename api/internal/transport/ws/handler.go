@@ -1,9 +1,15 @@
 package ws
 
 import (
+	"2026champs/internal/events"
 	"2026champs/internal/service"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -30,6 +36,9 @@ type Handler struct {
 	hub       *Hub
 	authSvc   *service.AuthService
 	playerSvc *service.PlayerService
+
+	copilotSvc  *service.CopilotService
+	reactionSvc *service.ReactionService
 }
 
 // NewHandler creates a new WebSocket handler
@@ -41,6 +50,16 @@ func NewHandler(hub *Hub, authSvc *service.AuthService, playerSvc *service.Playe
 	}
 }
 
+// SetCopilotService enables copilot_request messages from hosts
+func (h *Handler) SetCopilotService(svc *service.CopilotService) {
+	h.copilotSvc = svc
+}
+
+// SetReactionService enables reaction messages from players
+func (h *Handler) SetReactionService(svc *service.ReactionService) {
+	h.reactionSvc = svc
+}
+
 // HostWS handles GET /v1/ws/rooms/{code}/host
 func (h *Handler) HostWS(w http.ResponseWriter, r *http.Request) {
 	code := mux.Vars(r)["code"]
@@ -57,6 +76,11 @@ func (h *Handler) HostWS(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	version, ok := negotiateVersion(w, r)
+	if !ok {
+		return
+	}
+
 	wsConn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Printf("WebSocket upgrade error: %v", err)
@@ -64,12 +88,17 @@ func (h *Handler) HostWS(w http.ResponseWriter, r *http.Request) {
 	}
 
 	conn := &Connection{
-		RoomCode: code,
-		IsHost:   true,
-		Send:     make(chan []byte, 256),
-		Hub:      h.hub,
+		RoomCode:  code,
+		HostID:    claims.HostID,
+		IsHost:    true,
+		Version:   version,
+		Transport: TransportWebSocket,
+		Send:      make(chan []byte, 256),
+		Hub:       h.hub,
 	}
 
+	sendWelcome(conn)
+	h.hub.Resume(r.Context(), conn, r.URL.Query().Get("lastEventId"))
 	h.hub.Register(conn)
 
 	log.Printf("Host %s connected to room %s via WebSocket", claims.HostID, code)
@@ -99,9 +128,8 @@ func (h *Handler) PlayerWS(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	wsConn, err := upgrader.Upgrade(w, r, nil)
-	if err != nil {
-		log.Printf("WebSocket upgrade error: %v", err)
+	version, ok := negotiateVersion(w, r)
+	if !ok {
 		return
 	}
 
@@ -109,19 +137,34 @@ func (h *Handler) PlayerWS(w http.ResponseWriter, r *http.Request) {
 	player, err := h.playerSvc.GetPlayer(r.Context(), code, claims.PlayerID)
 	nickname := ""
 	if err == nil && player != nil {
+		if player.HasLeft() {
+			http.Error(w, "player has left the room", http.StatusGone)
+			return
+		}
 		nickname = player.Nickname
 	}
 
+	wsConn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("WebSocket upgrade error: %v", err)
+		return
+	}
+
 	conn := &Connection{
-		RoomCode: code,
-		PlayerID: claims.PlayerID,
-		Nickname: nickname,
-		IsHost:   false,
-		Send:     make(chan []byte, 256),
-		Hub:      h.hub,
+		RoomCode:  code,
+		PlayerID:  claims.PlayerID,
+		Nickname:  nickname,
+		IsHost:    false,
+		Version:   version,
+		Transport: TransportWebSocket,
+		Send:      make(chan []byte, 256),
+		Hub:       h.hub,
 	}
 
+	sendWelcome(conn)
+	h.hub.Resume(r.Context(), conn, r.URL.Query().Get("lastEventId"))
 	h.hub.Register(conn)
+	h.playerSvc.TrackPresence(code, claims.PlayerID)
 
 	log.Printf("Player %s connected to room %s via WebSocket", claims.PlayerID, code)
 
@@ -129,9 +172,47 @@ func (h *Handler) PlayerWS(w http.ResponseWriter, r *http.Request) {
 	go h.readPump(wsConn, conn)
 }
 
+// negotiateVersion reads the client's ?v= protocol version and rejects
+// unsupported ones with 426 before upgrading. Clients that don't announce
+// a version are treated as the oldest supported protocol.
+func negotiateVersion(w http.ResponseWriter, r *http.Request) (int, bool) {
+	raw := r.URL.Query().Get("v")
+	if raw == "" {
+		return MinProtocolVersion, true
+	}
+
+	version, err := strconv.Atoi(raw)
+	if err == nil && version >= MinProtocolVersion && version <= ProtocolVersion {
+		return version, true
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUpgradeRequired)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error":              fmt.Sprintf("unsupported protocol version %q", raw),
+		"protocolVersion":    ProtocolVersion,
+		"minProtocolVersion": MinProtocolVersion,
+	})
+	return 0, false
+}
+
+// sendWelcome queues the protocol handshake before any broadcast reaches the connection
+func sendWelcome(conn *Connection) {
+	data, _ := json.Marshal(newMessage(events.Welcome, events.WelcomePayload{
+		ProtocolVersion:    ProtocolVersion,
+		MinProtocolVersion: MinProtocolVersion,
+		ClientVersion:      conn.Version,
+		UpgradeRecommended: conn.Version < ProtocolVersion,
+	}))
+	conn.Send <- data
+}
+
 func (h *Handler) readPump(wsConn *websocket.Conn, conn *Connection) {
 	defer func() {
 		h.hub.Unregister(conn)
+		if !conn.IsHost {
+			h.playerSvc.ForgetPresence(conn.RoomCode, conn.PlayerID)
+		}
 		wsConn.Close()
 	}()
 
@@ -143,15 +224,63 @@ func (h *Handler) readPump(wsConn *websocket.Conn, conn *Connection) {
 	})
 
 	for {
-		_, _, err := wsConn.ReadMessage()
+		_, data, err := wsConn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("WebSocket error: %v", err)
 			}
 			break
 		}
-		// We don't process incoming messages for now
-		// Future: handle client-side events like heartbeats
+		h.handleClientMessage(conn, data)
+	}
+}
+
+// handleClientMessage processes messages sent by clients; unknown types are ignored
+func (h *Handler) handleClientMessage(conn *Connection, data []byte) {
+	var msg Message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return
+	}
+
+	switch msg.Type {
+	case events.Activity:
+		if conn.IsHost {
+			return
+		}
+		if err := h.playerSvc.TouchActivity(context.Background(), conn.RoomCode, conn.PlayerID); err != nil {
+			log.Printf("Failed to record activity for player %s: %v", conn.PlayerID, err)
+		}
+	case events.Ack:
+		var ack events.AckPayload
+		if err := json.Unmarshal(msg.Payload, &ack); err != nil {
+			return
+		}
+		if err := h.hub.Ack(context.Background(), conn, ack.ID); err != nil {
+			log.Printf("Failed to record ack for room %s: %v", conn.RoomCode, err)
+		}
+	case events.CopilotRequest:
+		if !conn.IsHost || h.copilotSvc == nil {
+			return
+		}
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			if err := h.copilotSvc.Request(ctx, conn.HostID, conn.RoomCode); err != nil {
+				log.Printf("Co-pilot hints for room %s: %v", conn.RoomCode, err)
+			}
+		}()
+	case events.Reaction:
+		if conn.IsHost || h.reactionSvc == nil {
+			return
+		}
+		var reaction events.ReactionPayload
+		if err := json.Unmarshal(msg.Payload, &reaction); err != nil {
+			return
+		}
+		err := h.reactionSvc.React(context.Background(), conn.RoomCode, conn.PlayerID, reaction.Emoji)
+		if err != nil && !errors.Is(err, service.ErrInvalidReaction) && !errors.Is(err, service.ErrPlayerLeft) {
+			log.Printf("Failed to record reaction for player %s: %v", conn.PlayerID, err)
+		}
 	}
 }
 
@@ -1,61 +1,99 @@
 package ws
 
 import (
+	"2026champs/internal/cache"
+	"2026champs/internal/events"
 	"encoding/json"
 	"log"
 	"sync"
 )
 
-// MessageType defines the type of WebSocket message
-type MessageType string
-
-// Host message types
-const (
-	MsgRoomStarted          MessageType = "room_started"
-	MsgRoomEnded            MessageType = "room_ended"
-	MsgPlayerJoined         MessageType = "player_joined"
-	MsgPlayerLeft           MessageType = "player_left"
-	MsgLeaderboardUpdate    MessageType = "leaderboard_update"
-	MsgPlayerProgressUpdate MessageType = "player_progress_update"
-	MsgAnalyticsUpdate      MessageType = "analytics_update"
-)
-
-// Player message types
-const (
-	MsgNextQuestion     MessageType = "next_question"
-	MsgAIThinking       MessageType = "ai_thinking"
-	MsgEvaluationResult MessageType = "evaluation_result"
-	MsgError            MessageType = "error"
-)
+// MessageType is the envelope's type; the catalog lives in the events package
+type MessageType = events.Type
 
 // Message is the WebSocket envelope format
 type Message struct {
+	V       int             `json:"v"` // Protocol version of the payload schema
 	Type    MessageType     `json:"type"`
 	Payload json.RawMessage `json:"payload"`
+
+	Seq int64  `json:"seq,omitempty"` // Room event log sequence, when the event was persisted
+	ID  string `json:"id,omitempty"`  // Outbox ID to resume after, when the outbox is enabled
 }
 
-// Hub manages WebSocket connections for rooms
+// Transports a Connection can be served over
+const (
+	TransportWebSocket = "websocket"
+	TransportSSE       = "sse"
+)
+
+// Subscriber receives the hub's routed messages, whatever transport carries them
+type Subscriber interface {
+	Room() string
+	Player() string // Empty for host subscribers
+	Name() string   // Player nickname
+	Host() bool
+	Deliver(data []byte) bool // Queues data without blocking; false if it was dropped
+	Close()                   // Ends the subscription; called once by the hub
+}
+
+// Hub routes room messages to host and player subscribers
 type Hub struct {
-	// Room -> connections
-	hostConns   map[string]*Connection
-	playerConns map[string]map[string]*Connection // roomCode -> playerID -> conn
+	// Room -> subscribers
+	hostConns   map[string]Subscriber
+	playerConns map[string]map[string]Subscriber // roomCode -> playerID -> subscriber
 
 	mu sync.RWMutex
 
 	// Channels for coordination
-	register   chan *Connection
-	unregister chan *Connection
+	register   chan Subscriber
+	unregister chan Subscriber
 	broadcast  chan *BroadcastMessage
+
+	// Optional Redis streams outbox; see SetOutbox
+	outbox   cache.OutboxCache
+	cursorMu sync.Mutex
+	cursors  map[Subscriber]*outboxCursor
 }
 
-// Connection represents a WebSocket connection
+// Connection is a channel-backed subscriber; a pump drains Send to the client
 type Connection struct {
-	RoomCode string
-	PlayerID string // Empty for host connections
-	Nickname string
-	IsHost   bool
-	Send     chan []byte
-	Hub      *Hub
+	RoomCode  string
+	PlayerID  string // Empty for host connections
+	HostID    string // Empty for player connections
+	Nickname  string
+	IsHost    bool
+	Version   int    // Protocol version announced by the client
+	Transport string // websocket, sse
+	Send      chan []byte
+	Hub       *Hub
+}
+
+// Room returns the connection's room code
+func (c *Connection) Room() string { return c.RoomCode }
+
+// Player returns the connected player's ID, empty for hosts
+func (c *Connection) Player() string { return c.PlayerID }
+
+// Name returns the connected player's nickname
+func (c *Connection) Name() string { return c.Nickname }
+
+// Host reports whether this is the room host's connection
+func (c *Connection) Host() bool { return c.IsHost }
+
+// Deliver queues data, dropping it if the buffer is full
+func (c *Connection) Deliver(data []byte) bool {
+	select {
+	case c.Send <- data:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close closes the send channel, which makes the pump disconnect the client
+func (c *Connection) Close() {
+	close(c.Send)
 }
 
 // BroadcastMessage is a message to broadcast
@@ -66,13 +104,13 @@ type BroadcastMessage struct {
 	Message  *Message
 }
 
-// NewHub creates a new WebSocket hub
+// NewHub creates a new hub
 func NewHub() *Hub {
 	h := &Hub{
-		hostConns:   make(map[string]*Connection),
-		playerConns: make(map[string]map[string]*Connection),
-		register:    make(chan *Connection),
-		unregister:  make(chan *Connection),
+		hostConns:   make(map[string]Subscriber),
+		playerConns: make(map[string]map[string]Subscriber),
+		register:    make(chan Subscriber),
+		unregister:  make(chan Subscriber),
 		broadcast:   make(chan *BroadcastMessage, 256),
 	}
 	go h.run()
@@ -84,38 +122,39 @@ func (h *Hub) run() {
 		select {
 		case conn := <-h.register:
 			h.mu.Lock()
-			if conn.IsHost {
-				h.hostConns[conn.RoomCode] = conn
-				log.Printf("Host connected to room %s", conn.RoomCode)
+			if conn.Host() {
+				h.hostConns[conn.Room()] = conn
+				log.Printf("Host connected to room %s", conn.Room())
 			} else {
-				if h.playerConns[conn.RoomCode] == nil {
-					h.playerConns[conn.RoomCode] = make(map[string]*Connection)
+				if h.playerConns[conn.Room()] == nil {
+					h.playerConns[conn.Room()] = make(map[string]Subscriber)
 				}
-				h.playerConns[conn.RoomCode][conn.PlayerID] = conn
-				log.Printf("Player %s connected to room %s", conn.PlayerID, conn.RoomCode)
+				h.playerConns[conn.Room()][conn.Player()] = conn
+				log.Printf("Player %s connected to room %s", conn.Player(), conn.Room())
 
 				// Notify host
-				h.notifyHostPlayerJoined(conn.RoomCode, conn.PlayerID, conn.Nickname)
+				h.notifyHostPlayerJoined(conn.Room(), conn.Player(), conn.Name())
 			}
 			h.mu.Unlock()
 
 		case conn := <-h.unregister:
+			h.forgetCursor(conn)
 			h.mu.Lock()
-			if conn.IsHost {
-				if existing, ok := h.hostConns[conn.RoomCode]; ok && existing == conn {
-					delete(h.hostConns, conn.RoomCode)
-					close(conn.Send)
-					log.Printf("Host disconnected from room %s", conn.RoomCode)
+			if conn.Host() {
+				if existing, ok := h.hostConns[conn.Room()]; ok && existing == conn {
+					delete(h.hostConns, conn.Room())
+					conn.Close()
+					log.Printf("Host disconnected from room %s", conn.Room())
 				}
 			} else {
-				if players, ok := h.playerConns[conn.RoomCode]; ok {
-					if existing, ok := players[conn.PlayerID]; ok && existing == conn {
-						delete(players, conn.PlayerID)
-						close(conn.Send)
-						log.Printf("Player %s disconnected from room %s", conn.PlayerID, conn.RoomCode)
+				if players, ok := h.playerConns[conn.Room()]; ok {
+					if existing, ok := players[conn.Player()]; ok && existing == conn {
+						delete(players, conn.Player())
+						conn.Close()
+						log.Printf("Player %s disconnected from room %s", conn.Player(), conn.Room())
 
 						// Notify host
-						h.notifyHostPlayerLeft(conn.RoomCode, conn.PlayerID)
+						h.notifyHostPlayerLeft(conn.Room(), conn.Player())
 					}
 				}
 			}
@@ -127,30 +166,20 @@ func (h *Hub) run() {
 
 			if msg.ToHost {
 				if conn, ok := h.hostConns[msg.RoomCode]; ok {
-					select {
-					case conn.Send <- data:
-					default:
-						// Drop message if buffer full
-					}
+					conn.Deliver(data) // Dropped if buffer full
 				}
 			} else if msg.ToPlayer != "" {
 				// Send to specific player
 				if players, ok := h.playerConns[msg.RoomCode]; ok {
 					if conn, ok := players[msg.ToPlayer]; ok {
-						select {
-						case conn.Send <- data:
-						default:
-						}
+						conn.Deliver(data)
 					}
 				}
 			} else {
 				// Broadcast to all players
 				if players, ok := h.playerConns[msg.RoomCode]; ok {
 					for _, conn := range players {
-						select {
-						case conn.Send <- data:
-						default:
-						}
+						conn.Deliver(data)
 					}
 				}
 			}
@@ -159,105 +188,151 @@ func (h *Hub) run() {
 	}
 }
 
-// Register adds a connection
-func (h *Hub) Register(conn *Connection) {
+// Register adds a subscriber
+func (h *Hub) Register(conn Subscriber) {
 	h.register <- conn
 }
 
-// Unregister removes a connection
-func (h *Hub) Unregister(conn *Connection) {
+// Unregister removes a subscriber
+func (h *Hub) Unregister(conn Subscriber) {
 	h.unregister <- conn
 }
 
-// BroadcastToHost sends a message to the room host (implements service.Broadcaster)
-func (h *Hub) BroadcastToHost(roomCode string, msgType string, payload interface{}) {
-	data, _ := json.Marshal(payload)
-	h.broadcast <- &BroadcastMessage{
-		RoomCode: roomCode,
-		ToHost:   true,
-		Message: &Message{
-			Type:    MessageType(msgType),
-			Payload: data,
-		},
+// Dispatch routes a published event to its audience (implements events.Sink).
+// With an outbox, the event goes through the room's stream instead.
+func (h *Hub) Dispatch(e *events.Event) {
+	if h.outbox != nil && h.appendOutbox(e) {
+		return
 	}
-}
 
-// BroadcastToPlayer sends a message to a specific player (implements service.Broadcaster)
-func (h *Hub) BroadcastToPlayer(roomCode, playerID string, msgType string, payload interface{}) {
-	data, _ := json.Marshal(payload)
-	h.broadcast <- &BroadcastMessage{
-		RoomCode: roomCode,
-		ToPlayer: playerID,
-		Message: &Message{
-			Type:    MessageType(msgType),
-			Payload: data,
-		},
+	msg := newMessage(e.Type, e.Payload)
+	msg.Seq = e.Seq
+	switch e.Audience {
+	case events.AudienceHost:
+		h.broadcast <- &BroadcastMessage{RoomCode: e.RoomCode, ToHost: true, Message: msg}
+	case events.AudiencePlayer:
+		h.broadcast <- &BroadcastMessage{RoomCode: e.RoomCode, ToPlayer: e.PlayerID, Message: msg}
+	case events.AudiencePlayers:
+		h.broadcast <- &BroadcastMessage{RoomCode: e.RoomCode, Message: msg}
+	case events.AudienceRoom:
+		h.broadcast <- &BroadcastMessage{RoomCode: e.RoomCode, ToHost: true, Message: msg}
+		h.broadcast <- &BroadcastMessage{RoomCode: e.RoomCode, Message: msg}
+	default:
+		log.Printf("[WS] Dropping %q event with unknown audience %q", e.Type, e.Audience)
 	}
 }
 
-// BroadcastToAllPlayers sends a message to all players in a room (implements service.Broadcaster)
-func (h *Hub) BroadcastToAllPlayers(roomCode string, msgType string, payload interface{}) {
-	data, _ := json.Marshal(payload)
-	h.broadcast <- &BroadcastMessage{
-		RoomCode: roomCode,
-		ToPlayer: "", // Empty means all
-		Message: &Message{
-			Type:    MessageType(msgType),
-			Payload: data,
-		},
+// BroadcastToEveryone sends a message to every host and player subscriber on this instance
+func (h *Hub) BroadcastToEveryone(msgType MessageType, payload interface{}) int {
+	msg, _ := json.Marshal(newMessage(msgType, payload))
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	sent := 0
+	for _, conn := range h.hostConns {
+		if conn.Deliver(msg) {
+			sent++
+		}
+	}
+	for _, players := range h.playerConns {
+		for _, conn := range players {
+			if conn.Deliver(msg) {
+				sent++
+			}
+		}
 	}
+	return sent
 }
 
-// DisconnectRoom closes all connections for a room (implements service.Broadcaster)
+// DisconnectRoom closes all subscribers of a room (implements events.Sink).
+// With an outbox, they are closed once the room's pending events are delivered.
 func (h *Hub) DisconnectRoom(roomCode string) {
+	if h.outbox != nil && h.appendClose(roomCode, events.AudienceRoom, "") {
+		return
+	}
+
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
 	// Disconnect host
 	if conn, ok := h.hostConns[roomCode]; ok {
 		delete(h.hostConns, roomCode)
-		close(conn.Send)
+		h.forgetCursor(conn)
+		conn.Close()
 		log.Printf("Host forced disconnect from room %s", roomCode)
 	}
 
 	// Disconnect players
 	if players, ok := h.playerConns[roomCode]; ok {
 		for playerID, conn := range players {
-			close(conn.Send)
+			h.forgetCursor(conn)
+			conn.Close()
 			log.Printf("Player %s forced disconnect from room %s", playerID, roomCode)
 		}
 		delete(h.playerConns, roomCode)
 	}
 }
 
-func (h *Hub) notifyHostPlayerJoined(roomCode, playerID, nickname string) {
-	if conn, ok := h.hostConns[roomCode]; ok {
-		payload := map[string]string{
-			"playerId": playerID,
-			"nickname": nickname,
-		}
-		dataMsg, _ := json.Marshal(payload)
+// DisconnectPlayer closes one player's subscriber, e.g. after they left the room (implements events.Sink)
+func (h *Hub) DisconnectPlayer(roomCode, playerID string) {
+	if h.outbox != nil && h.appendClose(roomCode, events.AudiencePlayer, playerID) {
+		return
+	}
 
-		data, _ := json.Marshal(&Message{
-			Type:    MsgPlayerJoined,
-			Payload: json.RawMessage(dataMsg),
-		})
-		select {
-		case conn.Send <- data:
-		default:
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if players, ok := h.playerConns[roomCode]; ok {
+		if conn, ok := players[playerID]; ok {
+			delete(players, playerID)
+			h.forgetCursor(conn)
+			conn.Close()
+			log.Printf("Player %s forced disconnect from room %s", playerID, roomCode)
 		}
 	}
 }
 
+// registered reports whether sub is still subscribed; the caller holds h.mu
+func (h *Hub) registered(sub Subscriber) bool {
+	if sub.Host() {
+		return h.hostConns[sub.Room()] == sub
+	}
+	return h.playerConns[sub.Room()][sub.Player()] == sub
+}
+
+// closeSubscriber closes one subscriber if it is still registered
+func (h *Hub) closeSubscriber(sub Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.registered(sub) {
+		return
+	}
+	if sub.Host() {
+		delete(h.hostConns, sub.Room())
+		log.Printf("Host forced disconnect from room %s", sub.Room())
+	} else {
+		delete(h.playerConns[sub.Room()], sub.Player())
+		log.Printf("Player %s forced disconnect from room %s", sub.Player(), sub.Room())
+	}
+	h.forgetCursor(sub)
+	sub.Close()
+}
+
+func (h *Hub) notifyHostPlayerJoined(roomCode, playerID, nickname string) {
+	if conn, ok := h.hostConns[roomCode]; ok {
+		data, _ := json.Marshal(newMessage(events.PlayerJoined, events.PlayerJoinedPayload{
+			PlayerID: playerID,
+			Nickname: nickname,
+		}))
+		conn.Deliver(data)
+	}
+}
+
 func (h *Hub) notifyHostPlayerLeft(roomCode, playerID string) {
 	if conn, ok := h.hostConns[roomCode]; ok {
-		data, _ := json.Marshal(&Message{
-			Type:    MsgPlayerLeft,
-			Payload: json.RawMessage(`{"playerId":"` + playerID + `"}`),
-		})
-		select {
-		case conn.Send <- data:
-		default:
-		}
+		data, _ := json.Marshal(newMessage(events.PlayerLeft, events.PlayerLeftPayload{PlayerID: playerID}))
+		conn.Deliver(data)
 	}
 }
@@ -0,0 +1,56 @@
+package ws
+
+import (
+	"2026champs/internal/events"
+	"bytes"
+	"encoding/json"
+	"log"
+	"reflect"
+)
+
+// Protocol versions. Clients announce theirs with ?v= when connecting;
+// clients that don't are treated as MinProtocolVersion.
+//
+//	1: untyped envelope {type, payload}
+//	2: envelope carries "v", payloads follow the events catalog, welcome on connect
+const (
+	ProtocolVersion    = 2
+	MinProtocolVersion = 1
+)
+
+// encodePayload serializes payload through the typed struct for msgType.
+// Untyped maps are checked against the schema and any field it doesn't
+// know about is logged and dropped.
+func encodePayload(msgType MessageType, payload interface{}) json.RawMessage {
+	data, _ := json.Marshal(payload)
+
+	typ, ok := events.PayloadType(msgType)
+	if !ok {
+		log.Printf("[WS] No payload schema for message type %q", msgType)
+		return data
+	}
+	if t := reflect.TypeOf(payload); t == typ || (t != nil && t.Kind() == reflect.Ptr && t.Elem() == typ) {
+		return data
+	}
+
+	typed := reflect.New(typ).Interface()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(typed); err != nil {
+		log.Printf("[WS] Payload for %q does not match schema: %v", msgType, err)
+		if err := json.Unmarshal(data, typed); err != nil {
+			return data
+		}
+	}
+	out, _ := json.Marshal(typed)
+	return out
+}
+
+// newMessage builds a versioned envelope
+func newMessage(msgType MessageType, payload interface{}) *Message {
+	return &Message{
+		V:       ProtocolVersion,
+		Type:    msgType,
+		Payload: encodePayload(msgType, payload),
+	}
+}
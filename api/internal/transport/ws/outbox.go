@@ -0,0 +1,368 @@
+package ws
+
+import (
+	"2026champs/internal/cache"
+	"2026champs/internal/events"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outbox delivery tuning
+const (
+	outboxReadBlock = time.Second // Longest wait for new entries; new rooms are picked up after it
+	outboxReadCount = 200         // Entries read per room per round
+	outboxIdle      = 200 * time.Millisecond
+	outboxGiveUp    = 30 * time.Second // A subscriber that accepts nothing for this long is disconnected
+)
+
+// lastEventId values with a special meaning
+const (
+	outboxLatest = "$"   // New events only
+	outboxOldest = "0-0" // Every retained event
+)
+
+// outboxCursor is a subscriber's position in its room's outbox
+type outboxCursor struct {
+	after        string    // Last entry delivered or skipped
+	failingSince time.Time // When the subscriber's buffer filled up; zero while it keeps up
+}
+
+// SetOutbox makes the hub deliver room events through Redis streams: Dispatch
+// appends them and StartOutbox pushes them to subscribers, retrying the ones
+// whose buffers are full instead of dropping their messages
+func (h *Hub) SetOutbox(outbox cache.OutboxCache) {
+	h.outbox = outbox
+	h.cursors = make(map[Subscriber]*outboxCursor)
+}
+
+// OutboxEnabled reports whether messages carry outbox IDs clients can resume from
+func (h *Hub) OutboxEnabled() bool {
+	return h.outbox != nil
+}
+
+// StartOutbox delivers outbox entries to this instance's subscribers until ctx is done
+func (h *Hub) StartOutbox(ctx context.Context) {
+	if h.outbox == nil {
+		return
+	}
+	go func() {
+		heads := make(map[string]string) // Room -> last ID read
+		for ctx.Err() == nil {
+			rooms := h.outboxRooms()
+			if len(rooms) == 0 {
+				clear(heads)
+				select {
+				case <-ctx.Done():
+				case <-time.After(outboxIdle):
+				}
+				continue
+			}
+
+			for room := range heads {
+				if _, ok := rooms[room]; !ok {
+					delete(heads, room)
+				}
+			}
+			for room, subs := range rooms {
+				head, ok := heads[room]
+				if !ok {
+					// Start at the subscriber furthest behind; the read catches everyone up
+					head = subs[0].cursor.after
+					for _, sub := range subs[1:] {
+						if streamIDBefore(sub.cursor.after, head) {
+							head = sub.cursor.after
+						}
+					}
+					heads[room] = head
+					continue
+				}
+				for _, sub := range subs {
+					if streamIDBefore(sub.cursor.after, head) {
+						h.catchUp(ctx, room, sub, head)
+					}
+				}
+			}
+
+			entries, err := h.outbox.Read(ctx, heads, outboxReadCount, outboxReadBlock)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("[Outbox] Read failed: %v", err)
+					time.Sleep(outboxReadBlock)
+				}
+				continue
+			}
+			for room, list := range entries {
+				if len(list) == 0 {
+					continue
+				}
+				for _, sub := range rooms[room] {
+					// Subscribers still behind catch up in order next round
+					if !streamIDBefore(sub.cursor.after, heads[room]) {
+						h.deliverEntries(sub, list)
+					}
+				}
+				heads[room] = list[len(list)-1].ID
+			}
+		}
+	}()
+}
+
+// outboxSubscriber pairs a registered subscriber with its cursor
+type outboxSubscriber struct {
+	Subscriber
+	cursor *outboxCursor
+}
+
+// outboxRooms lists the registered subscribers of each room that have a cursor
+func (h *Hub) outboxRooms() map[string][]outboxSubscriber {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	h.cursorMu.Lock()
+	defer h.cursorMu.Unlock()
+
+	rooms := make(map[string][]outboxSubscriber)
+	add := func(sub Subscriber) {
+		if cursor, ok := h.cursors[sub]; ok {
+			rooms[sub.Room()] = append(rooms[sub.Room()], outboxSubscriber{Subscriber: sub, cursor: cursor})
+		}
+	}
+	for _, conn := range h.hostConns {
+		add(conn)
+	}
+	for _, players := range h.playerConns {
+		for _, conn := range players {
+			add(conn)
+		}
+	}
+	return rooms
+}
+
+// catchUp delivers the entries up to head that a subscriber missed, e.g. because
+// it resumed from an older ID or its buffer was full
+func (h *Hub) catchUp(ctx context.Context, room string, sub outboxSubscriber, head string) {
+	for streamIDBefore(sub.cursor.after, head) {
+		list, err := h.outbox.Range(ctx, room, sub.cursor.after, head, outboxReadCount)
+		if err != nil {
+			log.Printf("[Outbox] Catch-up for room %s failed: %v", room, err)
+			return
+		}
+		if len(list) == 0 {
+			sub.cursor.after = head
+			return
+		}
+		if !h.deliverEntries(sub, list) {
+			return
+		}
+	}
+}
+
+// deliverEntries pushes the entries after the subscriber's cursor that are meant
+// for it, in order. It stops at the first one its buffer refuses, to be retried
+// next round, and reports whether everything went out.
+func (h *Hub) deliverEntries(sub outboxSubscriber, list []cache.OutboxEntry) bool {
+	h.mu.RLock()
+	if !h.registered(sub.Subscriber) {
+		h.mu.RUnlock()
+		return false
+	}
+
+	delivered, closing := true, false
+	for i := range list {
+		entry := &list[i]
+		if !streamIDBefore(sub.cursor.after, entry.ID) {
+			continue
+		}
+		if !addressedTo(sub.Subscriber, events.Audience(entry.Audience), entry.PlayerID) {
+			sub.cursor.after = entry.ID
+			continue
+		}
+		if entry.Close {
+			sub.cursor.after = entry.ID
+			closing = true
+			break
+		}
+
+		data, _ := json.Marshal(&Message{
+			V:       ProtocolVersion,
+			Type:    MessageType(entry.Type),
+			Payload: json.RawMessage(entry.Payload),
+			Seq:     entry.Seq,
+			ID:      entry.ID,
+		})
+		if !sub.Deliver(data) {
+			if sub.cursor.failingSince.IsZero() {
+				sub.cursor.failingSince = time.Now()
+			}
+			closing = time.Since(sub.cursor.failingSince) > outboxGiveUp
+			delivered = false
+			break
+		}
+		sub.cursor.after = entry.ID
+		sub.cursor.failingSince = time.Time{}
+	}
+	h.mu.RUnlock()
+
+	if closing {
+		h.closeSubscriber(sub.Subscriber)
+		return false
+	}
+	return delivered
+}
+
+// appendOutbox queues a published event in its room's outbox; false if Redis failed
+func (h *Hub) appendOutbox(e *events.Event) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := h.outbox.Append(ctx, e.RoomCode, &cache.OutboxEntry{
+		Type:     string(e.Type),
+		Audience: string(e.Audience),
+		PlayerID: e.PlayerID,
+		Seq:      e.Seq,
+		Payload:  encodePayload(e.Type, e.Payload),
+	})
+	if err != nil {
+		log.Printf("[Outbox] Append %s %s failed, sending directly: %v", e.RoomCode, e.Type, err)
+		return false
+	}
+	return true
+}
+
+// appendClose queues the disconnection of an audience behind the room's pending events
+func (h *Hub) appendClose(roomCode string, audience events.Audience, playerID string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := h.outbox.Append(ctx, roomCode, &cache.OutboxEntry{
+		Audience: string(audience),
+		PlayerID: playerID,
+		Close:    true,
+	})
+	if err != nil {
+		log.Printf("[Outbox] Append disconnect for %s failed, disconnecting now: %v", roomCode, err)
+		return false
+	}
+	return true
+}
+
+// Resume positions a subscriber in its room's outbox; call it before Register.
+// It receives the events after lastEventID, after the last one it acknowledged
+// when lastEventID is empty, only new events for "$" and all retained ones
+// for "0-0". Clients whose ID was trimmed away get resync_required and the
+// events that are left.
+func (h *Hub) Resume(ctx context.Context, sub Subscriber, lastEventID string) {
+	if h.outbox == nil {
+		return
+	}
+	if lastEventID == "" {
+		acked, err := h.outbox.Acked(ctx, sub.Room(), outboxConsumer(sub))
+		if err != nil {
+			log.Printf("[Outbox] Acknowledged ID for room %s: %v", sub.Room(), err)
+		}
+		lastEventID = acked
+	}
+
+	cursor := &outboxCursor{}
+	first, last, err := h.outbox.Bounds(ctx, sub.Room())
+	switch {
+	case err != nil:
+		// Stream IDs start with the time in ms, so this skips what came before now
+		log.Printf("[Outbox] Bounds for room %s: %v", sub.Room(), err)
+		cursor.after = fmt.Sprintf("%d-0", time.Now().UnixMilli())
+	case lastEventID == "" || lastEventID == outboxLatest || !validStreamID(lastEventID):
+		cursor.after = orZero(last)
+	case lastEventID == outboxOldest:
+		cursor.after = outboxOldest
+	case streamIDBefore(lastEventID, first) || streamIDBefore(orZero(last), lastEventID):
+		// Trimmed, expired, or from a stream that no longer exists
+		cursor.after = outboxOldest
+		data, _ := json.Marshal(newMessage(events.ResyncRequired, events.ResyncRequiredPayload{
+			LastEventID:   lastEventID,
+			OldestEventID: first,
+		}))
+		sub.Deliver(data)
+	default:
+		cursor.after = lastEventID
+	}
+
+	h.cursorMu.Lock()
+	h.cursors[sub] = cursor
+	h.cursorMu.Unlock()
+}
+
+// Ack records the last outbox ID a subscriber processed, so it can resume from there
+func (h *Hub) Ack(ctx context.Context, sub Subscriber, id string) error {
+	if h.outbox == nil || !validStreamID(id) {
+		return nil
+	}
+	return h.outbox.Ack(ctx, sub.Room(), outboxConsumer(sub), id)
+}
+
+// forgetCursor drops a subscriber's outbox position once it is gone
+func (h *Hub) forgetCursor(sub Subscriber) {
+	if h.outbox == nil {
+		return
+	}
+	h.cursorMu.Lock()
+	delete(h.cursors, sub)
+	h.cursorMu.Unlock()
+}
+
+// outboxConsumer names a subscriber for acknowledgments; reconnects share the name
+func outboxConsumer(sub Subscriber) string {
+	if sub.Host() {
+		return "host"
+	}
+	return "player:" + sub.Player()
+}
+
+// addressedTo reports whether an event for audience reaches sub
+func addressedTo(sub Subscriber, audience events.Audience, playerID string) bool {
+	switch audience {
+	case events.AudienceHost:
+		return sub.Host()
+	case events.AudiencePlayer:
+		return !sub.Host() && sub.Player() == playerID
+	case events.AudiencePlayers:
+		return !sub.Host()
+	case events.AudienceRoom:
+		return true
+	}
+	return false
+}
+
+// parseStreamID splits a Redis stream ID ("<ms>-<seq>")
+func parseStreamID(id string) (ms, seq uint64, ok bool) {
+	msPart, seqPart, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+	ms, err1 := strconv.ParseUint(msPart, 10, 64)
+	seq, err2 := strconv.ParseUint(seqPart, 10, 64)
+	return ms, seq, err1 == nil && err2 == nil
+}
+
+func validStreamID(id string) bool {
+	_, _, ok := parseStreamID(id)
+	return ok
+}
+
+// streamIDBefore reports whether stream ID a comes before b; empty IDs come first
+func streamIDBefore(a, b string) bool {
+	aMs, aSeq, _ := parseStreamID(a)
+	bMs, bSeq, _ := parseStreamID(b)
+	return aMs < bMs || (aMs == bMs && aSeq < bSeq)
+}
+
+// orZero turns the empty stream's missing ID into the one before every entry
+func orZero(id string) string {
+	if id == "" {
+		return outboxOldest
+	}
+	return id
+}
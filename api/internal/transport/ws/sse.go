@@ -0,0 +1,166 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// sseKeepAlive is how often an idle stream gets a comment line, so proxies keep it open
+const sseKeepAlive = 25 * time.Second
+
+// HostSSE handles GET /v1/sse/rooms/{code}/host
+func (h *Handler) HostSSE(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+	token := r.URL.Query().Get("token")
+
+	if token == "" {
+		http.Error(w, "missing token", http.StatusUnauthorized)
+		return
+	}
+
+	claims, err := h.authSvc.ValidateHostToken(token)
+	if err != nil {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	version, ok := negotiateVersion(w, r)
+	if !ok {
+		return
+	}
+
+	conn := &Connection{
+		RoomCode:  code,
+		IsHost:    true,
+		Version:   version,
+		Transport: TransportSSE,
+		Send:      make(chan []byte, 256),
+		Hub:       h.hub,
+	}
+
+	log.Printf("Host %s connected to room %s via SSE", claims.HostID, code)
+	h.serveSSE(w, r, conn)
+}
+
+// PlayerSSE handles GET /v1/sse/rooms/{code}/player
+func (h *Handler) PlayerSSE(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+	token := r.URL.Query().Get("token")
+
+	if token == "" {
+		http.Error(w, "missing token", http.StatusUnauthorized)
+		return
+	}
+
+	claims, err := h.authSvc.ValidatePlayerToken(token)
+	if err != nil {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	if claims.RoomCode != code {
+		http.Error(w, "token not valid for this room", http.StatusForbidden)
+		return
+	}
+
+	version, ok := negotiateVersion(w, r)
+	if !ok {
+		return
+	}
+
+	// Fetch player to get nickname
+	player, err := h.playerSvc.GetPlayer(r.Context(), code, claims.PlayerID)
+	nickname := ""
+	if err == nil && player != nil {
+		if player.HasLeft() {
+			http.Error(w, "player has left the room", http.StatusGone)
+			return
+		}
+		nickname = player.Nickname
+	}
+
+	conn := &Connection{
+		RoomCode:  code,
+		PlayerID:  claims.PlayerID,
+		Nickname:  nickname,
+		IsHost:    false,
+		Version:   version,
+		Transport: TransportSSE,
+		Send:      make(chan []byte, 256),
+		Hub:       h.hub,
+	}
+
+	h.playerSvc.TrackPresence(code, claims.PlayerID)
+	defer h.playerSvc.ForgetPresence(code, claims.PlayerID)
+
+	log.Printf("Player %s connected to room %s via SSE", claims.PlayerID, code)
+	h.serveSSE(w, r, conn)
+}
+
+// serveSSE registers conn with the hub and streams its messages until the
+// client goes away or the hub closes the subscription. Each event's data is
+// the same JSON envelope WebSocket clients receive; with the outbox, its id
+// line carries the envelope's id so EventSource resumes after it.
+func (h *Handler) serveSSE(w http.ResponseWriter, r *http.Request, conn *Connection) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable proxy buffering
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// EventSource sends the last id it saw when it reconnects
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("lastEventId")
+	}
+
+	sendWelcome(conn)
+	h.hub.Resume(r.Context(), conn, lastEventID)
+	h.hub.Register(conn)
+	defer h.hub.Unregister(conn)
+
+	ticker := time.NewTicker(sseKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case message, ok := <-conn.Send:
+			if !ok {
+				return
+			}
+			if h.hub.OutboxEnabled() {
+				var envelope struct {
+					ID string `json:"id"`
+				}
+				if json.Unmarshal(message, &envelope) == nil && envelope.ID != "" {
+					fmt.Fprintf(w, "id: %s\n", envelope.ID)
+				}
+			}
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", message); err != nil {
+				return
+			}
+			flusher.Flush()
+
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
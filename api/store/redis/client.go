@@ -1,11 +0,0 @@
-package redis
-
-import (
-	"github.com/redis/go-redis/v9"
-)
-
-func NewRedisClient(addr string) *redis.Client {
-	return redis.NewClient(&redis.Options{
-		Addr: addr,
-	})
-}
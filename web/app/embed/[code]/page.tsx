@@ -0,0 +1,98 @@
+'use client';
+
+import { useEffect, useState } from 'react';
+import { useParams } from 'next/navigation';
+import { rooms, type EmbedData } from '@/lib/api';
+import WordCloud from '@/components/WordCloud';
+
+// Iframe widget with a room's live results: /embed/CODE?token=...
+// Uses the SSE stream, and falls back to polling where EventSource is unavailable.
+export default function EmbedWidget() {
+    const params = useParams();
+    const code = params.code as string;
+
+    const [data, setData] = useState<EmbedData | null>(null);
+    const [error, setError] = useState('');
+
+    useEffect(() => {
+        const token = new URLSearchParams(window.location.search).get('token') || '';
+
+        if (typeof EventSource === 'undefined') {
+            const load = () => rooms.embedData(code, token).then(setData).catch(() => setError('Results unavailable'));
+            load();
+            const interval = setInterval(load, 5000);
+            return () => clearInterval(interval);
+        }
+
+        const source = new EventSource(rooms.embedStreamUrl(code, token));
+        source.addEventListener('embed_update', (e) => {
+            const update = JSON.parse((e as MessageEvent).data) as EmbedData;
+            setData(update);
+            if (update.status === 'ENDED') source.close();
+        });
+        source.addEventListener('error', () => {
+            source.close();
+            setError((prev) => prev || 'Live updates stopped');
+        });
+        return () => source.close();
+    }, [code]);
+
+    if (!data) {
+        return <div className="p-4 text-sm text-[var(--text-muted)]">{error || 'Loading results...'}</div>;
+    }
+
+    return (
+        <div className="p-4 space-y-4 text-sm">
+            <div className="flex gap-4 font-bold">
+                <span>{data.participation.players} players</span>
+                <span>{data.participation.answers} answers</span>
+                <span>{Math.round(data.participation.completionRate * 100)}% finished</span>
+            </div>
+
+            {data.themes.length > 0 && (
+                <div className="flex flex-wrap gap-2">
+                    {data.themes.map((t) => (
+                        <span key={t.theme} className="px-2 py-1 rounded-full bg-[var(--color-purple)] text-white">
+                            {t.theme} · {t.count}
+                        </span>
+                    ))}
+                </div>
+            )}
+
+            {data.questions.map((q) => {
+                const hist = q.type === 'MCQ' ? q.optionHist : q.type === 'DEGREE' ? q.ratingHist : undefined;
+                const max = Math.max(1, ...Object.values(hist || {}));
+                return (
+                    <div key={q.key}>
+                        <p className="font-bold">{q.prompt}</p>
+                        {hist &&
+                            Object.entries(hist).map(([k, count]) => (
+                                <div key={k} className="flex items-center gap-2">
+                                    <span className="w-24 truncate">{q.type === 'MCQ' ? q.options?.[Number(k)] ?? k : k}</span>
+                                    <div className="h-3 bg-[var(--color-blue)] rounded" style={{ width: `${(count / max) * 60}%` }} />
+                                    <span>{count}</span>
+                                </div>
+                            ))}
+                        {q.type === 'MATRIX' &&
+                            (q.rows || []).map((row, r) => {
+                                const counts = Object.entries(q.matrixHist?.[r] || {});
+                                const top = counts.sort((a, b) => b[1] - a[1])[0];
+                                return (
+                                    <div key={r} className="flex gap-2">
+                                        <span className="w-24 truncate">{row}</span>
+                                        <span className="text-[var(--text-muted)]">
+                                            {top ? `${q.columns?.[Number(top[0])] ?? top[0]} (${top[1]})` : '—'}
+                                        </span>
+                                    </div>
+                                );
+                            })}
+                        {q.type === 'WORDS' && q.wordCloud && <WordCloud words={q.wordCloud} max={20} />}
+                        {q.themes && q.themes.length > 0 && (
+                            <p className="text-[var(--text-muted)]">{q.themes.map((t) => t.theme).join(', ')}</p>
+                        )}
+                    </div>
+                );
+            })}
+        </div>
+    );
+}
@@ -2,8 +2,9 @@
 
 import { useEffect, useState } from 'react';
 import { useParams } from 'next/navigation';
-import { reports, surveymonkey, type RoomSnapshot, type AIReport, type SMSurveyResponse, type SMSummary } from '@/lib/api';
+import { reports, surveymonkey, surveys, type Question, type RoomSnapshot, type AIReport, type SMSurveyResponse, type SMSummary } from '@/lib/api';
 import GameBackground from '@/components/GameBackground';
+import WordCloud from '@/components/WordCloud';
 
 type Tab = 'snapshot' | 'ai' | 'surveymonkey';
 
@@ -26,6 +27,8 @@ export default function ReportsPage() {
     const [syncing, setSyncing] = useState(false);
     const [summary, setSummary] = useState<SMSummary | null>(null);
     const [loadingSummary, setLoadingSummary] = useState(false);
+    const [sharing, setSharing] = useState(false);
+    const [questions, setQuestions] = useState<Record<string, Question>>({});
 
     useEffect(() => {
         loadSnapshot();
@@ -51,6 +54,10 @@ export default function ReportsPage() {
         try {
             const data = await reports.getSnapshot(code);
             setSnapshot(data);
+            // Option labels for ranking results
+            surveys.get(data.surveyId)
+                .then(s => setQuestions(Object.fromEntries(s.questions.map(q => [q.key, q]))))
+                .catch(() => {});
         } catch (err) {
             console.error('Failed to load snapshot:', err);
         } finally {
@@ -150,6 +157,20 @@ export default function ReportsPage() {
         }
     };
 
+    const copyShareLink = async () => {
+        setSharing(true);
+        try {
+            const link = await reports.createShareLink(code);
+            navigator.clipboard.writeText(link.url);
+            alert(`Read-only link copied! Expires ${new Date(link.expiresAt).toLocaleDateString()}`);
+        } catch (err) {
+            console.error('Failed to create share link:', err);
+            alert('Failed to create share link');
+        } finally {
+            setSharing(false);
+        }
+    };
+
     const copySMLink = () => {
         if (smSurvey?.weblinkUrl) {
             navigator.clipboard.writeText(smSurvey.weblinkUrl);
@@ -177,6 +198,11 @@ export default function ReportsPage() {
                             </p>
                         </div>
                     </div>
+                    {snapshot && (
+                        <button onClick={copyShareLink} disabled={sharing} className="btn btn-secondary">
+                            {sharing ? 'Creating link...' : '🔗 Share Results'}
+                        </button>
+                    )}
                 </header>
 
                 {/* Tabs */}
@@ -316,6 +342,31 @@ export default function ReportsPage() {
                                                     </div>
                                                 )}
 
+                                                {/* Ranking: options by mean position, best first */}
+                                                {q.avgPosition && Object.keys(q.avgPosition).length > 0 && (
+                                                    <div className="mb-4 p-4 bg-white rounded-lg border-2 border-[var(--border-color)]">
+                                                        <div className="text-sm font-bold text-[var(--text-muted)] mb-3">Average Position ({q.rankCount} rankings)</div>
+                                                        <ol className="space-y-1">
+                                                            {Object.entries(q.avgPosition)
+                                                                .sort((a, b) => a[1] - b[1])
+                                                                .map(([opt, avg]) => (
+                                                                    <li key={opt} className="flex justify-between font-bold">
+                                                                        <span>{questions[q.questionKey ?? q.key]?.options?.[Number(opt)] ?? `Option ${Number(opt) + 1}`}</span>
+                                                                        <span className="text-[var(--color-purple)]">{avg.toFixed(2)} · {q.bordaScores?.[Number(opt)] ?? 0} pts</span>
+                                                                    </li>
+                                                                ))}
+                                                        </ol>
+                                                    </div>
+                                                )}
+
+                                                {/* Word cloud (WORDS questions) */}
+                                                {q.wordCloud && q.wordCloud.length > 0 && (
+                                                    <div className="mb-4 p-4 bg-white rounded-lg border-2 border-[var(--border-color)]">
+                                                        <div className="text-sm font-bold text-[var(--text-muted)] mb-3">Word Cloud</div>
+                                                        <WordCloud words={q.wordCloud} />
+                                                    </div>
+                                                )}
+
                                                 {/* Themes */}
                                                 {q.topThemes?.length > 0 && (
                                                     <div className="mb-3">
@@ -2,16 +2,19 @@
 
 import { useEffect, useState, useCallback } from 'react';
 import { useParams, useRouter } from 'next/navigation';
-import { rooms, getHostWebSocketUrl, type LeaderboardEntry } from '@/lib/api';
-import { useHostWebSocket, type PlayerJoinedEvent, type PlayerLeftEvent, type LeaderboardUpdateEvent, type PlayerProgressEvent, type RoomEndedEvent } from '@/hooks/useWebSocket';
+import { rooms, getHostWebSocketUrl, type LeaderboardEntry, type LobbyRoster, type JoinInfo, type RoomQuotas, type WordCount } from '@/lib/api';
+import { useHostWebSocket, type PlayerJoinedEvent, type PlayerLeftEvent, type LeaderboardUpdateEvent, type PlayerProgressEvent, type PlayerIdleEvent, type PlayerActiveEvent, type LobbyUpdateEvent, type PlayerScreenedOutEvent, type AnalyticsUpdateEvent, type RoomEndedEvent } from '@/hooks/useWebSocket';
 import LobbyBackground from '@/components/LobbyBackground';
 import GameBackground from '@/components/GameBackground';
+import WordCloud from '@/components/WordCloud';
 
 interface Player {
     id: string;
     nickname: string;
     status: 'joined' | 'answering' | 'done';
     currentQuestion?: string;
+    idle?: boolean;
+    attachments?: Record<string, number>; // Images uploaded per question
 }
 
 export default function RoomDashboard() {
@@ -24,6 +27,15 @@ export default function RoomDashboard() {
     const [leaderboard, setLeaderboard] = useState<LeaderboardEntry[]>([]);
     const [wsUrl, setWsUrl] = useState<string | null>(null);
     const [loading, setLoading] = useState(false);
+    const [joinInfo, setJoinInfo] = useState<JoinInfo | null>(null);
+    const [quotas, setQuotas] = useState<RoomQuotas | null>(null);
+    const [wordClouds, setWordClouds] = useState<Record<string, WordCount[]>>({});
+
+    const loadQuotas = useCallback(() => {
+        rooms.quotas(code)
+            .then(setQuotas)
+            .catch(err => console.error('Failed to load quotas:', err));
+    }, [code]);
 
     // WebSocket handlers
     const handlePlayerJoined = useCallback((event: PlayerJoinedEvent) => {
@@ -59,17 +71,67 @@ export default function RoomDashboard() {
                     ...player,
                     status: 'answering',
                     currentQuestion: event.questionKey,
+                    attachments: event.attachments
+                        ? { ...player.attachments, [event.questionKey]: event.attachments }
+                        : player.attachments,
                 });
             }
             return updated;
         });
     }, []);
 
+    const setPlayerIdle = useCallback((playerId: string, idle: boolean) => {
+        setPlayers(prev => {
+            const player = prev.get(playerId);
+            if (!player || player.idle === idle) return prev;
+            const updated = new Map(prev);
+            updated.set(playerId, { ...player, idle });
+            return updated;
+        });
+    }, []);
+
+    // Replace the player list with the server roster (REST on load, lobby_update while waiting)
+    const applyRoster = useCallback((roster: LobbyRoster) => {
+        setPlayers(prev => {
+            const updated = new Map<string, Player>();
+            roster.players.forEach(p => {
+                const existing = prev.get(p.playerId);
+                updated.set(p.playerId, {
+                    id: p.playerId,
+                    nickname: p.nickname,
+                    status: p.done ? 'done' : (existing?.status ?? (p.currentKey && roster.status !== 'LOBBY' ? 'answering' : 'joined')),
+                    currentQuestion: existing?.currentQuestion ?? (roster.status !== 'LOBBY' ? p.currentKey : undefined),
+                    idle: p.connection !== 'connected',
+                    attachments: existing?.attachments,
+                });
+            });
+            return updated;
+        });
+    }, []);
+
     const { status: wsStatus } = useHostWebSocket(wsUrl, {
         onPlayerJoined: handlePlayerJoined,
         onPlayerLeft: handlePlayerLeft,
         onLeaderboardUpdate: handleLeaderboardUpdate,
         onPlayerProgress: handlePlayerProgress,
+        onPlayerIdle: (event: PlayerIdleEvent) => setPlayerIdle(event.playerId, true),
+        onPlayerActive: (event: PlayerActiveEvent) => setPlayerIdle(event.playerId, false),
+        onLobbyUpdate: (event: LobbyUpdateEvent) => applyRoster(event),
+        onPlayerScreenedOut: (event: PlayerScreenedOutEvent) => {
+            setPlayers(prev => {
+                const player = prev.get(event.playerId);
+                if (!player) return prev;
+                const updated = new Map(prev);
+                updated.set(event.playerId, { ...player, status: 'done', currentQuestion: undefined });
+                return updated;
+            });
+            loadQuotas();
+        },
+        onAnalyticsUpdate: (event: AnalyticsUpdateEvent) => {
+            if (event.wordCloud) {
+                setWordClouds(prev => ({ ...prev, [event.questionKey]: event.wordCloud! }));
+            }
+        },
         onRoomEnded: (event: RoomEndedEvent) => {
             console.log("Room ended via WebSocket:", event);
             setRoomStatus('ended');
@@ -80,7 +142,28 @@ export default function RoomDashboard() {
         // Set up WebSocket connection
         const url = getHostWebSocketUrl(code);
         setWsUrl(url);
-    }, [code]);
+
+        rooms.players(code)
+            .then(roster => {
+                applyRoster(roster);
+                if (roster.status === 'ACTIVE') setRoomStatus('active');
+            })
+            .catch(err => console.error('Failed to load roster:', err));
+
+        rooms.joinInfo(code)
+            .then(setJoinInfo)
+            .catch(err => console.error('Failed to load join info:', err));
+
+        loadQuotas();
+    }, [code, applyRoster, loadQuotas]);
+
+    // Quota fill levels change with every MCQ answer, so refresh them while live
+    const hasQuotas = (quotas?.quotas.length ?? 0) > 0;
+    useEffect(() => {
+        if (roomStatus !== 'active' || !hasQuotas) return;
+        const interval = setInterval(loadQuotas, 5000);
+        return () => clearInterval(interval);
+    }, [roomStatus, hasQuotas, loadQuotas]);
 
     const handleStartRoom = async () => {
         setLoading(true);
@@ -112,7 +195,7 @@ export default function RoomDashboard() {
     };
 
     const copyJoinLink = () => {
-        const link = `${window.location.origin}/?code=${code}`;
+        const link = joinInfo?.shortUrl ?? `${window.location.origin}/?code=${code}`;
         navigator.clipboard.writeText(link);
     };
 
@@ -172,6 +255,14 @@ export default function RoomDashboard() {
                             <p className="text-sm font-bold text-[var(--text-muted)] uppercase tracking-widest mb-2">Room Code</p>
                             <div className="text-6xl font-black text-party-gradient tracking-widest mb-6 drop-shadow-sm">{code}</div>
 
+                            {joinInfo && roomStatus === 'waiting' && (
+                                <div className="flex flex-col items-center mb-6">
+                                    {/* eslint-disable-next-line @next/next/no-img-element */}
+                                    <img src={joinInfo.qrPng} alt={`QR code to join room ${code}`} className="w-48 h-48 rounded-xl border-2 border-[var(--border-color)] bg-white" />
+                                    <div className="text-sm font-bold text-[var(--text-muted)] mt-2">{joinInfo.shortUrl}</div>
+                                </div>
+                            )}
+
                             <div className="flex flex-col sm:flex-row items-center justify-center gap-4">
                                 <button onClick={copyJoinLink} className="btn btn-secondary">
                                     📋 Copy Link
@@ -214,6 +305,39 @@ export default function RoomDashboard() {
                                 </div>
                             )}
                         </div>
+
+                        {/* Screening quotas */}
+                        {quotas && hasQuotas && (
+                            <div className="card-party">
+                                <h2 className="text-2xl font-black mb-6">🎯 Quotas</h2>
+                                <div className="space-y-3">
+                                    {quotas.quotas.map((q) => (
+                                        <div key={`${q.questionKey}-${q.option}`}>
+                                            <div className="flex justify-between font-bold">
+                                                <span>{q.questionKey}: {q.label}</span>
+                                                <span className={q.full ? 'text-[var(--color-green)]' : ''}>
+                                                    {q.count} / {q.limit}{q.full ? ' ✓' : ''}
+                                                </span>
+                                            </div>
+                                            <div className="h-3 bg-[var(--bg-cream)] border-2 border-[var(--border-color)] rounded-full overflow-hidden">
+                                                <div className="h-full bg-[var(--color-purple)]" style={{ width: `${Math.min(100, (q.count / q.limit) * 100)}%` }} />
+                                            </div>
+                                        </div>
+                                    ))}
+                                </div>
+                                <p className="mt-4 text-sm font-bold text-[var(--text-muted)]">
+                                    {quotas.screenedOut} player{quotas.screenedOut !== 1 ? 's' : ''} screened out
+                                </p>
+                            </div>
+                        )}
+
+                        {/* Live word clouds for WORDS questions */}
+                        {Object.entries(wordClouds).map(([key, words]) => (
+                            <div key={key} className="card-party">
+                                <h2 className="text-2xl font-black mb-6">☁️ {key}</h2>
+                                <WordCloud words={words} />
+                            </div>
+                        ))}
                     </div>
 
                     {/* Players Sidebar */}
@@ -229,7 +353,8 @@ export default function RoomDashboard() {
                                 {playerList.map((player) => (
                                     <div
                                         key={player.id}
-                                        className="flex items-center gap-3 p-3 rounded-xl bg-[var(--bg-cream)] border-2 border-[var(--border-color)]"
+                                        className={`flex items-center gap-3 p-3 rounded-xl bg-[var(--bg-cream)] border-2 border-[var(--border-color)] transition-opacity ${player.idle ? 'opacity-40' : ''}`}
+                                        title={player.idle ? 'Idle' : undefined}
                                     >
                                         <div className="w-10 h-10 rounded-full border-2 border-[var(--color-blue)] flex items-center justify-center font-black text-[var(--color-blue)] bg-white">
                                             {(player.nickname || '?').charAt(0).toUpperCase()}
@@ -241,6 +366,11 @@ export default function RoomDashboard() {
                                                     On {player.currentQuestion}
                                                 </div>
                                             )}
+                                            {player.attachments && Object.keys(player.attachments).length > 0 && (
+                                                <div className="text-xs font-bold text-[var(--text-muted)]">
+                                                    📎 {Object.values(player.attachments).reduce((a, b) => a + b, 0)} image(s)
+                                                </div>
+                                            )}
                                         </div>
                                         <span className={`badge-party ${player.status === 'answering' ? 'animate-pulse' : ''}`}
                                             style={{
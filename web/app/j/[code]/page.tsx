@@ -0,0 +1,22 @@
+'use client';
+
+import { useEffect } from 'react';
+import { useParams, useRouter } from 'next/navigation';
+
+// Short join link (/j/CODE?l=fr), encoded in the room QR code
+export default function ShortJoinLink() {
+    const params = useParams();
+    const router = useRouter();
+    const code = params.code as string;
+
+    useEffect(() => {
+        const query = new URLSearchParams({ code: code.toUpperCase() });
+        const locale = new URLSearchParams(window.location.search).get('l');
+        if (locale) {
+            query.set('locale', locale);
+        }
+        router.replace(`/?${query.toString()}`);
+    }, [code, router]);
+
+    return null;
+}
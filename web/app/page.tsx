@@ -12,6 +12,19 @@ export default function Home() {
   const [error, setError] = useState('');
   const [loading, setLoading] = useState(false);
 
+  useEffect(() => {
+    // Join links carry the room code and an optional preferred locale
+    const params = new URLSearchParams(window.location.search);
+    const linkCode = params.get('code');
+    if (linkCode) {
+      setRoomCode(linkCode.toUpperCase());
+    }
+    const locale = params.get('locale');
+    if (locale) {
+      localStorage.setItem('preferred_locale', locale);
+    }
+  }, []);
+
   useEffect(() => {
     // Auto-rejoin check
     const storedToken = localStorage.getItem('player_token');
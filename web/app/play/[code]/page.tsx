@@ -1,13 +1,13 @@
 'use client';
 
-import { useEffect, useState, useCallback } from 'react';
+import { useEffect, useState, useCallback, useRef } from 'react';
 import { useParams, useRouter } from 'next/navigation';
-import { auth, player, getPlayerWebSocketUrl, type Question, type SubmitAnswerResponse } from '@/lib/api';
-import { usePlayerWebSocket, type NextQuestionEvent, type EvaluationResultEvent, type RoomEndedEvent } from '@/hooks/useWebSocket';
+import { auth, player, getPlayerWebSocketUrl, type Attachment, type Question, type SubmitAnswerResponse, type VoiceClip } from '@/lib/api';
+import { usePlayerWebSocket, useActivityPings, type NextQuestionEvent, type EvaluationResultEvent, type RoomEndedEvent } from '@/hooks/useWebSocket';
 import LobbyBackground from '@/components/LobbyBackground';
 import GameBackground from '@/components/GameBackground';
 
-type GameState = 'loading' | 'answering' | 'evaluated' | 'done' | 'waiting_for_ai' | 'waiting_for_start';
+type GameState = 'loading' | 'answering' | 'evaluated' | 'done' | 'waiting_for_ai' | 'waiting_for_start' | 'screened_out';
 
 export default function PlayerGame() {
     const params = useParams();
@@ -18,9 +18,22 @@ export default function PlayerGame() {
     const [currentQuestion, setCurrentQuestion] = useState<Question | null>(null);
     const [answer, setAnswer] = useState('');
     const [degreeValue, setDegreeValue] = useState(3);
+    const [matrixValues, setMatrixValues] = useState<number[]>([]);
+    const [ranking, setRanking] = useState<number[]>([]);
+    const [dragIndex, setDragIndex] = useState<number | null>(null);
+    const [attachments, setAttachments] = useState<Attachment[]>([]);
+    const [uploading, setUploading] = useState(false);
+    const [uploadError, setUploadError] = useState('');
+    const [voiceClip, setVoiceClip] = useState<VoiceClip | null>(null);
+    const [recording, setRecording] = useState(false);
+    const [transcribing, setTranscribing] = useState(false);
+    const [voiceError, setVoiceError] = useState('');
+    const recorderRef = useRef<MediaRecorder | null>(null);
     const [submitting, setSubmitting] = useState(false);
     const [lastAttemptId, setLastAttemptId] = useState<string | null>(null);
     const [result, setResult] = useState<SubmitAnswerResponse | null>(null);
+    const [screenedMessage, setScreenedMessage] = useState('');
+    const [followUpDraft, setFollowUpDraft] = useState(''); // Streamed follow-up prompt, until the result arrives
 
     const PartyThemes = [
         { bg: 'bg-[#e21b3c]', hover: 'hover:bg-[#f32d4e]', icon: '🔺' },
@@ -76,9 +89,11 @@ export default function PlayerGame() {
 
     // WebSocket handlers
     const handleNextQuestion = useCallback((event: NextQuestionEvent) => {
+        setFollowUpDraft('');
         setCurrentQuestion(event.question);
         setAnswer('');
         setDegreeValue(3);
+        setMatrixValues([]);
         setResult(null);
         setAttemptCount(0);
         setGameState('answering');
@@ -86,6 +101,7 @@ export default function PlayerGame() {
 
     const handleEvaluationResult = useCallback((event: EvaluationResultEvent) => {
         console.log("Received evaluation result:", event);
+        setFollowUpDraft('');
         setTotalPoints(prev => prev + (event.pointsEarned || 0));
 
         // Map the event fields to a result object for consistency
@@ -109,6 +125,7 @@ export default function PlayerGame() {
                     setCurrentQuestion(fullResult.nextQuestion);
                     setAnswer('');
                     setDegreeValue(3);
+                    setMatrixValues([]);
                     setResult(null);
                     setAttemptCount(0);
                     setGameState('answering');
@@ -135,13 +152,21 @@ export default function PlayerGame() {
         }
     }, []);
 
-    const { disconnect } = usePlayerWebSocket(wsUrl, {
+    const handleScreenedOut = useCallback((message?: string) => {
+        setScreenedMessage(message || 'Thanks for your interest! Your survey ends here.');
+        setSubmitting(false);
+        setGameState('screened_out');
+    }, []);
+
+    const { disconnect, send, status: wsStatus } = usePlayerWebSocket(wsUrl, {
         onNextQuestion: handleNextQuestion,
         onEvaluationResult: handleEvaluationResult,
         onAIThinking: () => {
             console.log("AI is thinking...");
             setGameState('waiting_for_ai');
         },
+        onFollowUpPartial: (event) => setFollowUpDraft(event.prompt),
+        onScreenedOut: (event) => handleScreenedOut(event.message),
         onRoomStarted: () => {
             console.log("Room started! Loading question...");
             loadCurrentQuestion();
@@ -159,6 +184,8 @@ export default function PlayerGame() {
         },
     });
 
+    useActivityPings(send, wsStatus === 'connected');
+
     useEffect(() => {
         const url = getPlayerWebSocketUrl(code);
         setWsUrl(url);
@@ -201,10 +228,93 @@ export default function PlayerGame() {
         );
     };
 
+    // Rankings start in the authored order
+    useEffect(() => {
+        if (currentQuestion?.type === 'RANKING') {
+            setRanking((currentQuestion.options || []).map((_, i) => i));
+        }
+    }, [currentQuestion]);
+
+    useEffect(() => {
+        setAttachments([]);
+        setUploadError('');
+        setVoiceClip(null);
+        setVoiceError('');
+    }, [currentQuestion?.key]);
+
+    // Record up to two minutes, then upload for transcription
+    const startRecording = async () => {
+        if (!currentQuestion || recording) return;
+        setVoiceError('');
+        try {
+            const stream = await navigator.mediaDevices.getUserMedia({ audio: true });
+            const recorder = new MediaRecorder(stream);
+            const chunks: Blob[] = [];
+            const questionKey = currentQuestion.key;
+            recorder.ondataavailable = (e) => chunks.push(e.data);
+            recorder.onstop = async () => {
+                stream.getTracks().forEach(t => t.stop());
+                setRecording(false);
+                setTranscribing(true);
+                try {
+                    const clip = await player.uploadVoice(code, questionKey, new Blob(chunks, { type: recorder.mimeType }));
+                    setVoiceClip(clip);
+                    setAnswer(clip.transcript);
+                } catch (err) {
+                    setVoiceError(err instanceof Error ? err.message : 'Transcription failed');
+                } finally {
+                    setTranscribing(false);
+                }
+            };
+            recorderRef.current = recorder;
+            recorder.start();
+            setRecording(true);
+            setTimeout(() => {
+                if (recorder.state === 'recording') recorder.stop();
+            }, 120000);
+        } catch {
+            setVoiceError('Microphone is not available');
+        }
+    };
+
+    const stopRecording = () => {
+        if (recorderRef.current?.state === 'recording') recorderRef.current.stop();
+    };
+
+    const handleAttach = async (file: File | undefined) => {
+        if (!file || !currentQuestion) return;
+        setUploading(true);
+        setUploadError('');
+        try {
+            const uploaded = await player.uploadAttachment(code, currentQuestion.key, file);
+            setAttachments(prev => [...prev, uploaded]);
+        } catch (err) {
+            setUploadError(err instanceof Error ? err.message : 'Upload failed');
+        } finally {
+            setUploading(false);
+        }
+    };
+
+    const attachmentIds = () => (attachments.length > 0 ? attachments.map(a => a.id) : undefined);
+
+    const moveRank = (from: number, to: number) => {
+        if (to < 0 || to >= ranking.length || from === to) return;
+        setRanking(prev => {
+            const next = [...prev];
+            const [item] = next.splice(from, 1);
+            next.splice(to, 0, item);
+            return next;
+        });
+    };
+
+    const matrixComplete = currentQuestion?.type === 'MATRIX' &&
+        (currentQuestion.rows || []).every((_, row) => matrixValues[row] !== undefined);
+
     const handleSubmit = async () => {
         if (!currentQuestion || submitting || gameState !== 'answering') return;
 
-        const hasAnswer = currentQuestion.type === 'DEGREE' || answer.trim().length > 0;
+        const hasAnswer = currentQuestion.type === 'DEGREE' || currentQuestion.type === 'RANKING' ||
+            (currentQuestion.type === 'MATRIX' ? matrixComplete : answer.trim().length > 0);
         if (!hasAnswer) return;
 
         setSubmitting(true);
@@ -213,8 +323,12 @@ export default function PlayerGame() {
             // This now returns immediately with "Submitted" status
             await player.submitAnswer(code, {
                 questionKey: currentQuestion.key,
-                textAnswer: currentQuestion.type === 'ESSAY' ? answer : undefined,
+                textAnswer: currentQuestion.type === 'ESSAY' || currentQuestion.type === 'WORDS' ? answer : undefined,
                 degreeValue: currentQuestion.type === 'DEGREE' ? degreeValue : undefined,
+                matrixValues: currentQuestion.type === 'MATRIX' ? matrixValues : undefined,
+                ranking: currentQuestion.type === 'RANKING' ? ranking : undefined,
+                attachmentIds: attachmentIds(),
+                voiceClipId: currentQuestion.type === 'ESSAY' ? voiceClip?.id : undefined,
                 clientAttemptId: generateClientAttemptId(),
             });
 
@@ -243,6 +357,7 @@ export default function PlayerGame() {
                 setCurrentQuestion(response.nextQuestion);
                 setAnswer('');
                 setDegreeValue(3);
+                setMatrixValues([]);
                 setResult(null);
                 setAttemptCount(0);
                 setGameState('answering');
@@ -300,6 +415,29 @@ export default function PlayerGame() {
         );
     }
 
+    // Screened out by a full quota
+    if (gameState === 'screened_out') {
+        return (
+            <div className="min-h-screen flex items-center justify-center p-6 relative">
+                <LobbyBackground />
+                <div className="card-party text-center max-w-md animate-slide-up">
+                    <div className="text-6xl mb-6">🙏</div>
+                    <h1 className="text-4xl font-black mb-4">Thank You!</h1>
+                    <p className="text-xl font-bold text-[var(--text-muted)] mb-8">{screenedMessage}</p>
+                    <button
+                        onClick={() => {
+                            auth.logout();
+                            router.push('/');
+                        }}
+                        className="btn btn-secondary w-full"
+                    >
+                        Back to Home
+                    </button>
+                </div>
+            </div>
+        );
+    }
+
     // Done state
     if (gameState === 'done') {
         return (
@@ -396,6 +534,7 @@ export default function PlayerGame() {
                                     <span className="badge-party" style={{ borderColor: 'var(--border-color)' }}>#{currentQuestion.key}</span>
                                     <span className="badge-party" style={{ borderColor: 'var(--color-blue)' }}>
                                         {currentQuestion.type === 'ESSAY' ? '📝 Essay' :
+                                            currentQuestion.type === 'WORDS' ? '☁️ Words' :
                                             currentQuestion.type === 'MCQ' ? '🔘 Choice' : '📊 Rating'}
                                     </span>
                                     {currentQuestion.pointsMax && (
@@ -417,11 +556,57 @@ export default function PlayerGame() {
                                         value={answer}
                                         onChange={(e) => setAnswer(e.target.value)}
                                         rows={6}
+                                        readOnly={voiceClip !== null}
                                         disabled={submitting || gameState === 'waiting_for_ai'}
                                         autoFocus
                                     />
                                 )}
 
+                                {/* Words: a few words for the word cloud */}
+                                {currentQuestion.type === 'WORDS' && gameState === 'answering' && (
+                                    <input
+                                        className="input-party mb-6 text-lg"
+                                        placeholder="A few words..."
+                                        value={answer}
+                                        onChange={(e) => setAnswer(e.target.value)}
+                                        onKeyDown={(e) => {
+                                            if (e.key === 'Enter') handleSubmit();
+                                        }}
+                                        disabled={submitting}
+                                        autoFocus
+                                    />
+                                )}
+
+                                {/* Voice answer: the transcript is what gets evaluated */}
+                                {currentQuestion.type === 'ESSAY' && currentQuestion.allowVoice && gameState === 'answering' && (
+                                    <div className="mb-6 flex flex-wrap items-center gap-3">
+                                        {voiceClip ? (
+                                            <button
+                                                type="button"
+                                                className="btn btn-secondary text-sm"
+                                                onClick={() => {
+                                                    setVoiceClip(null);
+                                                    setAnswer('');
+                                                }}
+                                                disabled={submitting}
+                                            >
+                                                🗑️ Discard recording
+                                            </button>
+                                        ) : (
+                                            <button
+                                                type="button"
+                                                className={`btn text-sm ${recording ? 'btn-primary animate-pulse' : 'btn-secondary'}`}
+                                                onClick={recording ? stopRecording : startRecording}
+                                                disabled={submitting || transcribing}
+                                            >
+                                                {transcribing ? 'Transcribing...' : recording ? '⏹️ Stop recording' : '🎙️ Record answer'}
+                                            </button>
+                                        )}
+                                        {voiceClip && <span className="text-xs font-bold text-[var(--text-muted)]">Transcribed from your recording</span>}
+                                        {voiceError && <span className="text-sm font-bold text-[var(--color-pink)]">{voiceError}</span>}
+                                    </div>
+                                )}
+
                                 {/* Waiting UI */}
                                 {gameState === 'waiting_for_ai' && (
                                     <div className="flex flex-col items-center justify-center py-12 text-[var(--color-purple)]">
@@ -434,6 +619,11 @@ export default function PlayerGame() {
                                             <span className="font-black text-2xl tracking-tighter italic">AI IS COOKING...</span>
                                         </div>
                                         <p className="text-sm font-bold mt-4 text-[var(--text-muted)] uppercase tracking-widest">Analyzing your brilliance</p>
+                                        {followUpDraft && (
+                                            <p className="text-lg font-bold mt-6 text-[var(--text-dark)] text-center animate-pulse">
+                                                {followUpDraft.replace(/\*/g, '')}
+                                            </p>
+                                        )}
                                     </div>
                                 )}
 
@@ -460,6 +650,106 @@ export default function PlayerGame() {
                                     </div>
                                 )}
 
+                                {/* Matrix Grid: one column per row */}
+                                {currentQuestion.type === 'MATRIX' && gameState === 'answering' && (
+                                    <div className="mb-8 overflow-x-auto">
+                                        <table className="w-full text-left border-separate border-spacing-y-2">
+                                            <thead>
+                                                <tr>
+                                                    <th />
+                                                    {(currentQuestion.columns || []).map((col, c) => (
+                                                        <th key={c} className="px-2 text-center text-sm font-bold text-[var(--text-muted)]">{col}</th>
+                                                    ))}
+                                                </tr>
+                                            </thead>
+                                            <tbody>
+                                                {(currentQuestion.rows || []).map((row, r) => (
+                                                    <tr key={r} className="bg-[var(--bg-cream)]">
+                                                        <td className="p-3 font-bold rounded-l-xl">{row}</td>
+                                                        {(currentQuestion.columns || []).map((_, c) => (
+                                                            <td key={c} className="p-3 text-center last:rounded-r-xl">
+                                                                <input
+                                                                    type="radio"
+                                                                    name={`row-${r}`}
+                                                                    className="w-5 h-5 accent-[var(--color-purple)] cursor-pointer"
+                                                                    checked={matrixValues[r] === c}
+                                                                    onChange={() => setMatrixValues(prev => {
+                                                                        const next = [...prev];
+                                                                        next[r] = c;
+                                                                        return next;
+                                                                    })}
+                                                                    disabled={submitting}
+                                                                />
+                                                            </td>
+                                                        ))}
+                                                    </tr>
+                                                ))}
+                                            </tbody>
+                                        </table>
+                                    </div>
+                                )}
+
+                                {/* Ranking: drag (or use the arrows) to order best-first */}
+                                {currentQuestion.type === 'RANKING' && gameState === 'answering' && (
+                                    <ol className="mb-8 space-y-2">
+                                        {ranking.map((opt, pos) => (
+                                            <li
+                                                key={opt}
+                                                draggable={!submitting}
+                                                onDragStart={() => setDragIndex(pos)}
+                                                onDragOver={(e) => e.preventDefault()}
+                                                onDrop={() => {
+                                                    if (dragIndex !== null) moveRank(dragIndex, pos);
+                                                    setDragIndex(null);
+                                                }}
+                                                className={`flex items-center gap-3 p-4 rounded-xl bg-[var(--bg-cream)] border-2 border-[var(--border-color)] font-bold cursor-grab ${dragIndex === pos ? 'opacity-50' : ''}`}
+                                            >
+                                                <span className="w-8 h-8 flex items-center justify-center rounded-full bg-[var(--color-purple)] text-white">{pos + 1}</span>
+                                                <span className="flex-1">{currentQuestion.options?.[opt]}</span>
+                                                <button type="button" onClick={() => moveRank(pos, pos - 1)} disabled={pos === 0 || submitting} className="px-2 disabled:opacity-30" aria-label="Move up">▲</button>
+                                                <button type="button" onClick={() => moveRank(pos, pos + 1)} disabled={pos === ranking.length - 1 || submitting} className="px-2 disabled:opacity-30" aria-label="Move down">▼</button>
+                                            </li>
+                                        ))}
+                                    </ol>
+                                )}
+
+                                {/* Optional image attachments, e.g. a screenshot of a bug */}
+                                {currentQuestion.allowAttachments && gameState === 'answering' && (
+                                    <div className="mb-6 p-4 bg-[var(--bg-cream)] rounded-xl border-2 border-dashed border-[var(--border-color)]">
+                                        <div className="flex flex-wrap items-center gap-2">
+                                            {attachments.map((a) => (
+                                                <span key={a.id} className="flex items-center gap-1 px-3 py-1 rounded-full bg-white border-2 border-[var(--border-color)] text-sm font-bold">
+                                                    📎 {a.fileName}
+                                                    <button
+                                                        type="button"
+                                                        onClick={() => setAttachments(prev => prev.filter(x => x.id !== a.id))}
+                                                        className="ml-1 text-[var(--text-muted)]"
+                                                        aria-label={`Remove ${a.fileName}`}
+                                                    >
+                                                        ✕
+                                                    </button>
+                                                </span>
+                                            ))}
+                                            {attachments.length < 3 && (
+                                                <label className={`btn btn-secondary text-sm ${uploading || submitting ? 'opacity-50 pointer-events-none' : 'cursor-pointer'}`}>
+                                                    {uploading ? 'Uploading...' : '📷 Add image'}
+                                                    <input
+                                                        type="file"
+                                                        accept="image/png,image/jpeg,image/gif,image/webp"
+                                                        className="hidden"
+                                                        onChange={(e) => {
+                                                            handleAttach(e.target.files?.[0]);
+                                                            e.target.value = '';
+                                                        }}
+                                                    />
+                                                </label>
+                                            )}
+                                        </div>
+                                        <p className="text-xs text-[var(--text-muted)] mt-2">PNG, JPEG, GIF or WebP up to 5 MB, max 3 images.</p>
+                                        {uploadError && <p className="text-sm text-[var(--color-pink)] font-bold mt-1">{uploadError}</p>}
+                                    </div>
+                                )}
+
                                 {currentQuestion.type === 'MCQ' && currentQuestion.options && (
                                     <MCQControl
                                         options={currentQuestion.options}
@@ -472,7 +762,10 @@ export default function PlayerGame() {
                                             player.submitAnswer(code, {
                                                 questionKey: currentQuestion.key,
                                                 optionIndex: index,
+                                                attachmentIds: attachmentIds(),
                                                 clientAttemptId: attemptId,
+                                            }).then(res => {
+                                                if (res.status === 'SCREENED_OUT') handleScreenedOut(res.message);
                                             }).catch(err => {
                                                 console.error('Submit MCQ failed:', err);
                                                 setSubmitting(false);
@@ -488,7 +781,7 @@ export default function PlayerGame() {
                                     <div className="flex items-center gap-3">
                                         <button
                                             onClick={handleSubmit}
-                                            disabled={submitting || (currentQuestion.type === 'ESSAY' && !answer.trim())}
+                                            disabled={submitting || uploading || recording || transcribing || ((currentQuestion.type === 'ESSAY' || currentQuestion.type === 'WORDS') && !answer.trim()) || (currentQuestion.type === 'MATRIX' && !matrixComplete)}
                                             className="btn btn-primary flex-1 py-4 text-xl hover:scale-105"
                                         >
                                             {submitting ? (
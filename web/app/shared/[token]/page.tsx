@@ -0,0 +1,77 @@
+'use client';
+
+import { useEffect, useState } from 'react';
+import { useParams } from 'next/navigation';
+import { reports, type SharedReport } from '@/lib/api';
+import GameBackground from '@/components/GameBackground';
+
+// Public, read-only results opened from a host's share link
+export default function SharedReportPage() {
+    const params = useParams();
+    const token = params.token as string;
+
+    const [report, setReport] = useState<SharedReport | null>(null);
+    const [error, setError] = useState('');
+
+    useEffect(() => {
+        reports.getShared(token)
+            .then(setReport)
+            .catch(() => setError('This link is invalid or has expired.'));
+    }, [token]);
+
+    return (
+        <div className="min-h-screen p-6 relative">
+            <GameBackground />
+
+            <div className="relative z-10 max-w-4xl mx-auto space-y-6">
+                {error ? (
+                    <div className="card-party text-center py-16 font-bold text-[var(--text-muted)]">{error}</div>
+                ) : !report ? (
+                    <div className="card-party flex items-center justify-center py-16">
+                        <div className="spinner" style={{ width: 40, height: 40 }} />
+                    </div>
+                ) : (
+                    <>
+                        <header>
+                            <h1 className="text-3xl font-black">
+                                <span className="text-party-gradient">📊 {report.surveyTitle || 'Room Results'}</span>
+                            </h1>
+                            <p className="text-[var(--text-muted)] font-bold">
+                                {report.totalPlayers} players · {Math.round((report.completionRate || 0) * 100)}% completion
+                                · ended {new Date(report.endedAt).toLocaleDateString()}
+                            </p>
+                        </header>
+
+                        {report.aiReport?.executiveSummary && (
+                            <div className="card-party">
+                                <h2 className="text-xl font-black mb-3">Summary</h2>
+                                <ul className="list-disc pl-6 space-y-1">
+                                    {report.aiReport.executiveSummary.map((line, i) => (
+                                        <li key={i}>{line}</li>
+                                    ))}
+                                </ul>
+                            </div>
+                        )}
+
+                        {report.questionProfiles?.map((q) => (
+                            <div key={q.key} className="card-party">
+                                <h3 className="font-black mb-2">{q.prompt}</h3>
+                                <p className="text-sm text-[var(--text-muted)] font-bold">
+                                    {q.answerCount} answers
+                                    {q.mean !== undefined && ` · mean ${q.mean.toFixed(1)}`}
+                                </p>
+                                {q.topThemes?.length > 0 && (
+                                    <p className="mt-2">Themes: {q.topThemes.join(', ')}</p>
+                                )}
+                            </div>
+                        ))}
+
+                        <p className="text-center text-sm text-[var(--text-muted)]">
+                            Read-only link · expires {new Date(report.expiresAt).toLocaleDateString()}
+                        </p>
+                    </>
+                )}
+            </div>
+        </div>
+    );
+}
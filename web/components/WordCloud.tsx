@@ -0,0 +1,27 @@
+'use client';
+
+import type { WordCount } from '@/lib/api';
+
+const COLORS = ['var(--color-purple)', 'var(--color-blue)', 'var(--color-pink)', 'var(--color-green)'];
+
+// Word cloud for WORDS questions: font size scales with each word's share of the top count
+export default function WordCloud({ words, max = 30 }: { words: WordCount[]; max?: number }) {
+    const shown = words.slice(0, max);
+    if (shown.length === 0) return null;
+    const top = Math.max(1, ...shown.map((w) => w.count));
+
+    return (
+        <div className="flex flex-wrap items-baseline justify-center gap-x-3 gap-y-1">
+            {shown.map((w, i) => (
+                <span
+                    key={w.word}
+                    title={`${w.count}`}
+                    className="font-black leading-tight"
+                    style={{ fontSize: `${0.8 + (w.count / top) * 1.6}rem`, color: COLORS[i % COLORS.length] }}
+                >
+                    {w.word}
+                </span>
+            ))}
+        </div>
+    );
+}
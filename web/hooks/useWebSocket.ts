@@ -18,6 +18,11 @@ interface UseWebSocketOptions {
     reconnectInterval?: number;
 }
 
+// Maps a WebSocket URL to its SSE fallback (/v1/ws/... -> /v1/sse/...)
+function toSSEUrl(url: string): string {
+    return url.replace(/^ws(s?):\/\//, 'http$1://').replace('/ws/rooms/', '/sse/rooms/');
+}
+
 export function useWebSocket(url: string | null, options: UseWebSocketOptions = {}) {
     const {
         onMessage,
@@ -32,6 +37,8 @@ export function useWebSocket(url: string | null, options: UseWebSocketOptions =
     const [lastMessage, setLastMessage] = useState<WebSocketMessage | null>(null);
 
     const wsRef = useRef<WebSocket | null>(null);
+    const sseRef = useRef<EventSource | null>(null);
+    const useSSERef = useRef(false); // Set once a WebSocket never manages to open
     const reconnectCountRef = useRef(0);
     const reconnectTimeoutRef = useRef<NodeJS.Timeout | null>(null);
 
@@ -46,6 +53,16 @@ export function useWebSocket(url: string | null, options: UseWebSocketOptions =
     useEffect(() => { onDisconnectRef.current = onDisconnect; }, [onDisconnect]);
     useEffect(() => { onErrorRef.current = onError; }, [onError]);
 
+    const handleData = (data: string) => {
+        try {
+            const message = JSON.parse(data) as WebSocketMessage;
+            setLastMessage(message);
+            onMessageRef.current?.(message);
+        } catch (e) {
+            console.error('Failed to parse WebSocket message:', e);
+        }
+    };
+
     const connect = useCallback(() => {
         if (!url) return;
 
@@ -53,12 +70,48 @@ export function useWebSocket(url: string | null, options: UseWebSocketOptions =
         if (wsRef.current) {
             wsRef.current.close();
         }
+        if (sseRef.current) {
+            sseRef.current.close();
+        }
+
+        const scheduleReconnect = () => {
+            if (reconnectCountRef.current < reconnectAttempts) {
+                reconnectCountRef.current += 1;
+                reconnectTimeoutRef.current = setTimeout(() => {
+                    connect();
+                }, reconnectInterval);
+            }
+        };
 
         setStatus('connecting');
+
+        // Server-Sent Events fallback for proxies that block WebSockets (receive only)
+        if (useSSERef.current && typeof EventSource !== 'undefined') {
+            const es = new EventSource(toSSEUrl(url));
+            sseRef.current = es;
+
+            es.onopen = () => {
+                setStatus('connected');
+                reconnectCountRef.current = 0;
+                onConnectRef.current?.();
+            };
+            es.onerror = (event) => {
+                es.close();
+                setStatus('disconnected');
+                onErrorRef.current?.(event);
+                onDisconnectRef.current?.();
+                scheduleReconnect();
+            };
+            es.onmessage = (event) => handleData(event.data);
+            return;
+        }
+
         const ws = new WebSocket(url);
         wsRef.current = ws;
+        let opened = false;
 
         ws.onopen = () => {
+            opened = true;
             setStatus('connected');
             reconnectCountRef.current = 0;
             onConnectRef.current?.();
@@ -68,13 +121,15 @@ export function useWebSocket(url: string | null, options: UseWebSocketOptions =
             setStatus('disconnected');
             onDisconnectRef.current?.();
 
-            // Attempt reconnection
-            if (reconnectCountRef.current < reconnectAttempts) {
-                reconnectCountRef.current += 1;
-                reconnectTimeoutRef.current = setTimeout(() => {
-                    connect();
-                }, reconnectInterval);
+            // Never opened: likely blocked by a proxy, so retry over SSE right away
+            if (!opened && !useSSERef.current && reconnectCountRef.current < reconnectAttempts) {
+                useSSERef.current = true;
+                connect();
+                return;
             }
+
+            // Attempt reconnection
+            scheduleReconnect();
         };
 
         ws.onerror = (event) => {
@@ -82,15 +137,7 @@ export function useWebSocket(url: string | null, options: UseWebSocketOptions =
             onErrorRef.current?.(event);
         };
 
-        ws.onmessage = (event) => {
-            try {
-                const message = JSON.parse(event.data) as WebSocketMessage;
-                setLastMessage(message);
-                onMessageRef.current?.(message);
-            } catch (e) {
-                console.error('Failed to parse WebSocket message:', e);
-            }
-        };
+        ws.onmessage = (event) => handleData(event.data);
     }, [url, reconnectAttempts, reconnectInterval]);
 
     const disconnect = useCallback(() => {
@@ -102,6 +149,10 @@ export function useWebSocket(url: string | null, options: UseWebSocketOptions =
             wsRef.current.close();
             wsRef.current = null;
         }
+        if (sseRef.current) {
+            sseRef.current.close();
+            sseRef.current = null;
+        }
         setStatus('disconnected');
     }, [reconnectAttempts]);
 
@@ -164,6 +215,36 @@ export interface PlayerProgressEvent {
     questionKey: string;
     status: string;
     resolution: 'SAT' | 'UNSAT' | null;
+    attachments?: number; // Images uploaded with the answer
+}
+
+export interface PlayerIdleEvent {
+    type: 'player_idle';
+    playerId: string;
+    lastActiveAt: string;
+    idleSeconds: number;
+}
+
+export interface PlayerActiveEvent {
+    type: 'player_active';
+    playerId: string;
+}
+
+export interface LobbyUpdateEvent extends LobbyRoster {
+    type: 'lobby_update';
+}
+
+export interface PlayerScreenedOutEvent {
+    type: 'player_screened_out';
+    playerId: string;
+    questionKey: string;
+    option: number;
+}
+
+export interface AnalyticsUpdateEvent {
+    type: 'analytics_update';
+    questionKey: string;
+    wordCloud?: { word: string; count: number }[]; // WORDS questions, most frequent first
 }
 
 export type HostEvent =
@@ -171,6 +252,11 @@ export type HostEvent =
     | PlayerLeftEvent
     | LeaderboardUpdateEvent
     | PlayerProgressEvent
+    | PlayerIdleEvent
+    | PlayerActiveEvent
+    | LobbyUpdateEvent
+    | PlayerScreenedOutEvent
+    | AnalyticsUpdateEvent
     | RoomEndedEvent;
 
 export function useHostWebSocket(
@@ -180,6 +266,11 @@ export function useHostWebSocket(
         onPlayerLeft?: (event: PlayerLeftEvent) => void;
         onLeaderboardUpdate?: (event: LeaderboardUpdateEvent) => void;
         onPlayerProgress?: (event: PlayerProgressEvent) => void;
+        onPlayerIdle?: (event: PlayerIdleEvent) => void;
+        onPlayerActive?: (event: PlayerActiveEvent) => void;
+        onLobbyUpdate?: (event: LobbyUpdateEvent) => void;
+        onPlayerScreenedOut?: (event: PlayerScreenedOutEvent) => void;
+        onAnalyticsUpdate?: (event: AnalyticsUpdateEvent) => void;
         onRoomEnded?: (event: RoomEndedEvent) => void;
     } = {}
 ) {
@@ -200,6 +291,21 @@ export function useHostWebSocket(
             case 'player_progress_update':
                 handlers.onPlayerProgress?.(event as unknown as PlayerProgressEvent);
                 break;
+            case 'player_idle':
+                handlers.onPlayerIdle?.(event as unknown as PlayerIdleEvent);
+                break;
+            case 'player_active':
+                handlers.onPlayerActive?.(event as unknown as PlayerActiveEvent);
+                break;
+            case 'lobby_update':
+                handlers.onLobbyUpdate?.(event as unknown as LobbyUpdateEvent);
+                break;
+            case 'player_screened_out':
+                handlers.onPlayerScreenedOut?.(event as unknown as PlayerScreenedOutEvent);
+                break;
+            case 'analytics_update':
+                handlers.onAnalyticsUpdate?.(event as unknown as AnalyticsUpdateEvent);
+                break;
             case 'room_ended':
                 handlers.onRoomEnded?.(event as unknown as RoomEndedEvent);
                 break;
@@ -213,7 +319,7 @@ export function useHostWebSocket(
 // Player WebSocket Hook
 // ============================================
 
-import { type Question } from '@/lib/api';
+import { type Question, type LobbyRoster } from '@/lib/api';
 
 export interface NextQuestionEvent {
     type: 'next_question';
@@ -229,6 +335,14 @@ export interface EvaluationResultEvent {
     followUp?: Question | null;
 }
 
+// The AI follow-up prompt so far, each event carrying the whole prompt; display only
+export interface FollowUpPartialEvent {
+    type: 'followup_partial';
+    questionKey: string;
+    parentKey: string;
+    prompt: string;
+}
+
 export interface ErrorEvent {
     type: 'error';
     message: string;
@@ -249,7 +363,12 @@ export interface RoomEndedEvent {
     status: 'ENDED';
 }
 
-export type PlayerEvent = NextQuestionEvent | EvaluationResultEvent | AIThinkingEvent | ErrorEvent | RoomStartedEvent | RoomEndedEvent;
+export interface ScreenedOutEvent {
+    type: 'screened_out';
+    message: string;
+}
+
+export type PlayerEvent = NextQuestionEvent | EvaluationResultEvent | AIThinkingEvent | FollowUpPartialEvent | ErrorEvent | RoomStartedEvent | RoomEndedEvent | ScreenedOutEvent;
 
 export function usePlayerWebSocket(
     url: string | null,
@@ -257,9 +376,11 @@ export function usePlayerWebSocket(
         onNextQuestion?: (event: NextQuestionEvent) => void;
         onEvaluationResult?: (event: EvaluationResultEvent) => void;
         onAIThinking?: (event: AIThinkingEvent) => void;
+        onFollowUpPartial?: (event: FollowUpPartialEvent) => void;
         onError?: (event: ErrorEvent) => void;
         onRoomStarted?: (event: RoomStartedEvent) => void;
         onRoomEnded?: (event: RoomEndedEvent) => void;
+        onScreenedOut?: (event: ScreenedOutEvent) => void;
     } = {}
 ) {
     const handleMessage = useCallback((message: WebSocketMessage) => {
@@ -276,6 +397,9 @@ export function usePlayerWebSocket(
             case 'evaluation_result':
                 handlers.onEvaluationResult?.(event as unknown as EvaluationResultEvent);
                 break;
+            case 'followup_partial':
+                handlers.onFollowUpPartial?.(event as unknown as FollowUpPartialEvent);
+                break;
             case 'error':
                 handlers.onError?.(event as unknown as ErrorEvent);
                 break;
@@ -285,8 +409,43 @@ export function usePlayerWebSocket(
             case 'room_ended':
                 handlers.onRoomEnded?.(event as unknown as RoomEndedEvent);
                 break;
+            case 'screened_out':
+                handlers.onScreenedOut?.(event as unknown as ScreenedOutEvent);
+                break;
         }
     }, [handlers]);
 
     return useWebSocket(url, { onMessage: handleMessage });
 }
+
+// ============================================
+// Activity pings (presence)
+// ============================================
+
+const ACTIVITY_PING_INTERVAL = 10000;
+
+// Sends throttled activity pings while the player interacts with the page,
+// so the host can tell idle players apart from busy ones
+export function useActivityPings(send: (data: unknown) => void, enabled: boolean) {
+    const lastPingRef = useRef(0);
+
+    useEffect(() => {
+        if (!enabled) return;
+
+        const ping = () => {
+            if (document.visibilityState !== 'visible') return;
+            const now = Date.now();
+            if (now - lastPingRef.current < ACTIVITY_PING_INTERVAL) return;
+            lastPingRef.current = now;
+            send({ type: 'activity' });
+        };
+
+        const events = ['keydown', 'pointerdown', 'touchstart', 'visibilitychange'];
+        events.forEach(e => document.addEventListener(e, ping, { passive: true }));
+        ping();
+
+        return () => {
+            events.forEach(e => document.removeEventListener(e, ping));
+        };
+    }, [send, enabled]);
+}
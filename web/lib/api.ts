@@ -19,9 +19,19 @@ export interface Survey {
     intent: string;
     settings: SurveySettings;
     questions: Question[];
+    branching?: BranchRule[];
     createdAt: string;
 }
 
+// Host-defined branching: answering `when` with a matching option/range queues `ask`
+export interface BranchRule {
+    when: string;
+    options?: number[]; // MCQ option indexes
+    min?: number; // DEGREE, inclusive
+    max?: number;
+    ask: string[];
+}
+
 export interface SurveySettings {
     maxFollowUps: number;
     allowSkipAfter: number;
@@ -29,14 +39,24 @@ export interface SurveySettings {
 
 export interface Question {
     key: string;
-    type: 'ESSAY' | 'DEGREE' | 'MCQ';
-    prompt: string;
+    type: 'ESSAY' | 'DEGREE' | 'MCQ' | 'MATRIX' | 'RANKING' | 'WORDS';
+    prompt: string; // May pipe earlier answers: {{Q2.answer}} or {{Q2.answer|fallback}}
     rubric?: string;
     pointsMax: number;
     threshold?: number;
     scaleMin?: number;
     scaleMax?: number;
-    options?: string[]; // MCQ only
+    options?: string[]; // MCQ and RANKING
+    quotas?: OptionQuota[]; // MCQ only: screening caps per option
+    rows?: string[]; // MATRIX only
+    columns?: string[]; // MATRIX only
+    allowAttachments?: boolean; // Players may upload images with their answer
+    allowVoice?: boolean; // ESSAY: players may record the answer instead
+}
+
+export interface OptionQuota {
+    option: number;
+    limit: number;
 }
 
 export interface CreateSurveyRequest {
@@ -44,6 +64,7 @@ export interface CreateSurveyRequest {
     intent: string;
     settings: SurveySettings;
     questions: Omit<Question, 'key'>[];
+    branching?: BranchRule[];
 }
 
 export interface Room {
@@ -77,16 +98,41 @@ export interface SubmitAnswerRequest {
     textAnswer?: string;
     degreeValue?: number;
     optionIndex?: number;
+    matrixValues?: number[]; // MATRIX: column index per row
+    ranking?: number[]; // RANKING: every option index, best first
+    attachmentIds?: string[]; // From player.uploadAttachment, max 3
+    voiceClipId?: string; // From player.uploadVoice; the transcript is evaluated as the text answer
     clientAttemptId: string;
 }
 
+export interface VoiceClip {
+    id: string;
+    questionKey: string;
+    transcript: string;
+    provider: string;
+    contentType: string;
+    size: number;
+    createdAt: string;
+}
+
+export interface Attachment {
+    id: string;
+    playerId: string;
+    questionKey: string;
+    fileName: string;
+    contentType: string;
+    size: number;
+    createdAt: string;
+}
+
 export interface SubmitAnswerResponse {
-    status: 'EVALUATED';
+    status: 'EVALUATED' | 'SCREENED_OUT';
     resolution: 'SAT' | 'UNSAT';
     pointsEarned: number;
     evalSummary: string;
     followUp: Question | null;
     nextQuestion: Question | null;
+    message?: string; // SCREENED_OUT only
 }
 
 export interface RoomSnapshot {
@@ -109,13 +155,120 @@ export interface LeaderboardEntry {
     rank: number;
 }
 
+export interface LobbyPlayer {
+    playerId: string;
+    nickname: string;
+    joinedAt: string;
+    lastActiveAt: string;
+    score: number;
+    currentKey?: string;
+    remaining: number;
+    done: boolean;
+    connection: 'connected' | 'idle' | 'disconnected';
+}
+
+export interface LobbyRoster {
+    roomCode: string;
+    status: 'LOBBY' | 'ACTIVE' | 'ENDED';
+    players: LobbyPlayer[];
+    connected: number;
+}
+
+export interface JoinInfo {
+    roomCode: string;
+    joinUrl: string;
+    shortUrl: string;
+    qrSvg: string;
+    qrPng: string;
+}
+
+export interface ShareLink {
+    token: string;
+    url: string;
+    expiresAt: string;
+}
+
+export interface SharedReport {
+    roomCode: string;
+    surveyTitle?: string;
+    endedAt: string;
+    totalPlayers: number;
+    completionRate: number;
+    overallSkipRate: number;
+    topScores: number[];
+    questionProfiles: QuestionProfile[];
+    aiReport?: AIReport;
+    expiresAt: string;
+}
+
+export interface RoomQuotas {
+    roomCode: string;
+    quotas: {
+        questionKey: string;
+        option: number;
+        label: string;
+        limit: number;
+        count: number;
+        full: boolean;
+    }[];
+    screenedOut: number;
+}
+
+export interface EmbedToken {
+    token: string;
+    dataUrl: string;
+    streamUrl: string;
+    iframeUrl: string;
+    expiresAt: string;
+}
+
+export interface EmbedQuestion {
+    key: string;
+    prompt: string;
+    type: 'ESSAY' | 'DEGREE' | 'MCQ' | 'MATRIX' | 'RANKING' | 'WORDS';
+    answerCount: number;
+    skipCount: number;
+    scaleMin?: number;
+    scaleMax?: number;
+    ratingHist?: { [value: number]: number };
+    ratingMean?: number;
+    options?: string[];
+    optionHist?: { [index: number]: number };
+    rows?: string[];
+    columns?: string[];
+    matrixHist?: { [row: number]: { [column: number]: number } };
+    avgPosition?: { [option: number]: number };
+    wordCloud?: WordCount[];
+    themes?: { theme: string; count: number }[];
+}
+
+export interface WordCount {
+    word: string;
+    count: number;
+}
+
+export interface EmbedData {
+    roomCode: string;
+    status: 'LOBBY' | 'ACTIVE' | 'ENDED';
+    participation: { players: number; finished: number; answers: number; completionRate: number };
+    themes: { theme: string; count: number }[];
+    questions: EmbedQuestion[];
+    updatedAt: string;
+}
+
 export interface QuestionProfile {
     key: string;
+    questionKey?: string;
     prompt: string;
     type: string;
     ratingSum: number;
     ratingCount: number;
     optionHist: { [key: number]: number };
+    matrixHist?: { [row: number]: { [column: number]: number } };
+    rankCount?: number;
+    bordaScores?: { [option: number]: number };
+    avgPosition?: { [option: number]: number }; // RANKING: 1 = best
+    wordCloud?: WordCount[]; // WORDS: most frequent first
     answerCount: number;
     topThemes: string[];
     topMissing: string[];
@@ -336,6 +489,46 @@ export const rooms = {
         });
     },
 
+    joinInfo: async (code: string, locale?: string): Promise<JoinInfo> => {
+        const query = locale ? `?locale=${encodeURIComponent(locale)}` : '';
+        return request<JoinInfo>(`/rooms/${code}/join-info${query}`, {
+            headers: authHeaders('host'),
+        });
+    },
+
+    attachments: async (code: string): Promise<Attachment[]> => {
+        return request<Attachment[]>(`/rooms/${code}/attachments`, {
+            headers: authHeaders('host'),
+        });
+    },
+
+    quotas: async (code: string): Promise<RoomQuotas> => {
+        return request<RoomQuotas>(`/rooms/${code}/quotas`, {
+            headers: authHeaders('host'),
+        });
+    },
+
+    embedToken: async (code: string): Promise<EmbedToken> => {
+        return request<EmbedToken>(`/rooms/${code}/embed-token`, {
+            method: 'POST',
+            headers: authHeaders('host'),
+        });
+    },
+
+    embedData: async (code: string, token: string): Promise<EmbedData> => {
+        return request<EmbedData>(`/rooms/${code}/embed?token=${encodeURIComponent(token)}`);
+    },
+
+    embedStreamUrl: (code: string, token: string): string => {
+        return `${getApiBase()}/rooms/${code}/embed/stream?token=${encodeURIComponent(token)}`;
+    },
+
+    players: async (code: string): Promise<LobbyRoster> => {
+        return request<LobbyRoster>(`/rooms/${code}/players`, {
+            headers: authHeaders('host'),
+        });
+    },
+
     join: async (code: string, nickname: string): Promise<JoinRoomResponse> => {
         const response = await request<JoinRoomResponse>(`/rooms/${code}/join`, {
             method: 'POST',
@@ -396,6 +589,37 @@ export const player = {
         });
     },
 
+    // Multipart upload, so it bypasses request() and its JSON Content-Type
+    uploadAttachment: async (code: string, questionKey: string, file: File): Promise<Attachment> => {
+        const body = new FormData();
+        body.append('file', file);
+        const response = await fetch(`${getApiBase()}/rooms/${code}/questions/${questionKey}/attachments`, {
+            method: 'POST',
+            headers: authHeaders('player'),
+            body,
+        });
+        const data = await response.json();
+        if (!response.ok) {
+            throw new ApiError(response.status, data.error || 'Upload failed');
+        }
+        return data as Attachment;
+    },
+
+    uploadVoice: async (code: string, questionKey: string, audio: Blob): Promise<VoiceClip> => {
+        const body = new FormData();
+        body.append('audio', audio, 'answer.webm');
+        const response = await fetch(`${getApiBase()}/rooms/${code}/questions/${questionKey}/voice`, {
+            method: 'POST',
+            headers: authHeaders('player'),
+            body,
+        });
+        const data = await response.json();
+        if (!response.ok) {
+            throw new ApiError(response.status, data.error || 'Transcription failed');
+        }
+        return data as VoiceClip;
+    },
+
     skipQuestion: async (code: string, questionKey: string): Promise<SkipQuestionResponse> => {
         return request<SkipQuestionResponse>(`/rooms/${code}/questions/${questionKey}/skip`, {
             method: 'POST',
@@ -427,6 +651,18 @@ export const reports = {
             headers: authHeaders('host'),
         });
     },
+
+    createShareLink: async (code: string, expiresInHours?: number): Promise<ShareLink> => {
+        return request<ShareLink>(`/reports/${code}/share`, {
+            method: 'POST',
+            headers: authHeaders('host'),
+            body: JSON.stringify({ expiresInHours }),
+        });
+    },
+
+    getShared: async (token: string): Promise<SharedReport> => {
+        return request<SharedReport>(`/shared/${encodeURIComponent(token)}`);
+    },
 };
 
 // ============================================